@@ -26,6 +26,7 @@ import (
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	pluginDiscovery "github.com/opentofu/opentofu/internal/plugin/discovery"
+	"github.com/opentofu/opentofu/internal/providerdaemon"
 )
 
 // runningInAutomationEnvName gives the name of an environment variable that
@@ -84,6 +85,11 @@ func makeMeta(
 		configDir = "" // No config dir available (e.g. looking up a home directory failed)
 	}
 
+	var providerDaemon *providerdaemon.Client
+	if socketPath := os.Getenv(providerdaemon.SocketPathEnvVar); socketPath != "" {
+		providerDaemon = providerdaemon.NewClient(socketPath)
+	}
+
 	return command.Meta{
 		WorkingDir: wd,
 		View:       view.SetRunningInAutomation(inAutomation),
@@ -114,10 +120,17 @@ func makeMeta(
 		ProviderDevOverrides: providerDevOverrides,
 		UnmanagedProviders:   unmanagedProviders,
 
+		ProviderInstallPolicy: providerInstallPolicy(config.ProviderInstallation),
+		ProviderDaemon:        providerDaemon,
+
 		// OCICredentialsPolicyBuilder is passed here for some commands (e.g. providers lock) that cannot
 		// use ProvidersSource but still might need OCICredentials provided by the config
 		OCICredentialsPolicyBuilder: config.OCICredentialsPolicy,
 
+		// OCISignaturePoliciesBuilder is passed for the same reason, so that
+		// those commands can also enforce the cosign signature trust policies.
+		OCISignaturePoliciesBuilder: config.OCISignatureTrustPolicies,
+
 		// ProviderSourceLocationConfig is used for some commands that do not make
 		// use of the OpenTofu configuration files. Therefore, there is no way to configure
 		// the retries from other places than env vars.
@@ -241,6 +254,30 @@ func initCommands(meta command.Meta) {
 			}, nil
 		},
 
+		"modules": func() (cli.Command, error) {
+			return &command.ModulesCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"modules outdated": func() (cli.Command, error) {
+			return &command.ModulesOutdatedCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"modules push": func() (cli.Command, error) {
+			return &command.ModulesPushCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"modules tree": func() (cli.Command, error) {
+			return &command.ModulesTreeCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"output": func() (cli.Command, error) {
 			return &command.OutputCommand{
 				Meta: meta,
@@ -259,6 +296,12 @@ func initCommands(meta command.Meta) {
 			}, nil
 		},
 
+		"providers conformance": func() (cli.Command, error) {
+			return &command.ProvidersConformanceCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"providers lock": func() (cli.Command, error) {
 			return &command.ProvidersLockCommand{
 				Meta: meta,
@@ -271,12 +314,36 @@ func initCommands(meta command.Meta) {
 			}, nil
 		},
 
+		"providers outdated": func() (cli.Command, error) {
+			return &command.ProvidersOutdatedCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"providers push": func() (cli.Command, error) {
+			return &command.ProvidersPushCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"providers serve": func() (cli.Command, error) {
+			return &command.ProvidersServeCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"providers schema": func() (cli.Command, error) {
 			return &command.ProvidersSchemaCommand{
 				Meta: meta,
 			}, nil
 		},
 
+		"providers why": func() (cli.Command, error) {
+			return &command.ProvidersWhyCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"push": func() (cli.Command, error) {
 			return &command.PushCommand{
 				Meta: meta,
@@ -289,6 +356,24 @@ func initCommands(meta command.Meta) {
 			}, nil
 		},
 
+		"registry": func() (cli.Command, error) {
+			return &command.RegistryCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"registry build": func() (cli.Command, error) {
+			return &command.RegistryBuildCommand{
+				Meta: meta,
+			}, nil
+		},
+
+		"registry serve": func() (cli.Command, error) {
+			return &command.RegistryServeCommand{
+				Meta: meta,
+			}, nil
+		},
+
 		"show": func() (cli.Command, error) {
 			return &command.ShowCommand{
 				Meta: meta,
@@ -57,6 +57,7 @@ func legacyCommandMain(
 		config.RegistryProtocols,
 		services,
 		config.OCICredentialsPolicy,
+		config.OCISignatureTrustPolicies,
 		wd.RootModuleDir(), // this has to be the directory that tofu has been executed from, not the one after -chdir
 	)
 	if len(diags) > 0 {
@@ -182,7 +182,7 @@ func realMain() int {
 	}
 	services := newServiceDiscovery(ctx, config.RegistryProtocols, credsSrc)
 
-	modulePkgFetcher := remoteModulePackageFetcher(ctx, config.OCICredentialsPolicy)
+	modulePkgFetcher := remoteModulePackageFetcher(ctx, config.OCICredentialsPolicy, config.OCISignatureTrustPolicies)
 
 	providerDevOverrides := providerDevOverrides(config.ProviderInstallation)
 
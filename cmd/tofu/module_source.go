@@ -11,18 +11,21 @@ import (
 
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 )
 
-func remoteModulePackageFetcher(ctx context.Context, getOCICredsPolicy oci.OCICredsPolicyBuilder) *getmodules.PackageFetcher {
+func remoteModulePackageFetcher(ctx context.Context, getOCICredsPolicy oci.OCICredsPolicyBuilder, getOCISignaturePolicies oci.OCISignaturePoliciesBuilder) *getmodules.PackageFetcher {
 	// TODO: Pass in a real getmodules.PackageFetcherEnvironment here,
 	// which knows how to make use of the OCI authentication policy.
 	return getmodules.NewPackageFetcher(ctx, &modulePackageFetcherEnvironment{
-		getOCICredsPolicy: getOCICredsPolicy,
+		getOCICredsPolicy:       getOCICredsPolicy,
+		getOCISignaturePolicies: getOCISignaturePolicies,
 	})
 }
 
 type modulePackageFetcherEnvironment struct {
-	getOCICredsPolicy oci.OCICredsPolicyBuilder
+	getOCICredsPolicy       oci.OCICredsPolicyBuilder
+	getOCISignaturePolicies oci.OCISignaturePoliciesBuilder
 }
 
 // OCIRepositoryStore implements getmodules.PackageFetcherEnvironment.
@@ -39,3 +42,8 @@ func (m *modulePackageFetcherEnvironment) OCIRepositoryStore(ctx context.Context
 	}
 	return oci.GetOCIRepositoryStore(ctx, registryDomainName, repositoryPath, credsPolicy)
 }
+
+// OCISignaturePolicy implements getmodules.PackageFetcherEnvironment.
+func (m *modulePackageFetcherEnvironment) OCISignaturePolicy(_ context.Context, registryDomainName string, repositoryPath string) (*cosign.Policy, error) {
+	return m.getOCISignaturePolicies.PolicyFor(registryDomainName, repositoryPath)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/opentofu/opentofu/internal/command/cliconfig"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/providercache"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -33,6 +34,7 @@ func providerSource(
 	registryClientConfig *cliconfig.RegistryProtocolsConfig,
 	services *disco.Disco,
 	getOCICredsPolicy oci.OCICredsPolicyBuilder,
+	getOCISignaturePolicies oci.OCISignaturePoliciesBuilder,
 	originalWorkingDir string,
 ) (getproviders.Source, tfdiags.Diagnostics) {
 	if len(configs) == 0 {
@@ -46,7 +48,7 @@ func providerSource(
 	// the validation logic in the cliconfig package. Therefore we'll just
 	// ignore any additional configurations in here.
 	config := configs[0]
-	return explicitProviderSource(ctx, config, registryClientConfig, services, getOCICredsPolicy)
+	return explicitProviderSource(ctx, config, registryClientConfig, services, getOCICredsPolicy, getOCISignaturePolicies)
 }
 
 func explicitProviderSource(
@@ -55,13 +57,14 @@ func explicitProviderSource(
 	registryClientConfig *cliconfig.RegistryProtocolsConfig,
 	services *disco.Disco,
 	getOCICredsPolicy oci.OCICredsPolicyBuilder,
+	getOCISignaturePolicies oci.OCISignaturePoliciesBuilder,
 ) (getproviders.Source, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 	var searchRules []getproviders.MultiSourceSelector
 
 	log.Printf("[DEBUG] Explicit provider installation configuration is set")
 	for _, methodConfig := range config.Methods {
-		source, moreDiags := providerSourceForCLIConfigLocation(ctx, methodConfig.Location, methodConfig.Retries, methodConfig.Trusted, registryClientConfig, services, getOCICredsPolicy)
+		source, moreDiags := providerSourceForCLIConfigLocation(ctx, methodConfig.Location, methodConfig.Retries, methodConfig.Trusted, config.Download, registryClientConfig, services, getOCICredsPolicy, getOCISignaturePolicies)
 		diags = diags.Append(moreDiags)
 		if moreDiags.HasErrors() {
 			continue
@@ -221,13 +224,15 @@ func providerSourceForCLIConfigLocation(
 	loc cliconfig.ProviderInstallationLocation,
 	locationRetries cliconfig.ProviderInstallationMethodRetries,
 	trustedSource cliconfig.ProviderInstallationMethodTrusted,
+	download *cliconfig.ProviderInstallationDownload,
 	registryClientConfig *cliconfig.RegistryProtocolsConfig,
 	services *disco.Disco,
 	makeOCICredsPolicy oci.OCICredsPolicyBuilder,
+	getOCISignaturePolicies oci.OCISignaturePoliciesBuilder,
 ) (getproviders.Source, tfdiags.Diagnostics) {
 	if loc == cliconfig.ProviderInstallationDirect {
 		return getproviders.NewMemoizeSource(
-			getproviders.NewRegistrySource(ctx, services, newRegistryHTTPClient(ctx, registryClientConfig), providerSourceLocationConfig(locationRetries, trustedSource)),
+			getproviders.NewRegistrySource(ctx, services, newRegistryHTTPClient(ctx, registryClientConfig), providerSourceLocationConfig(locationRetries, trustedSource, download)),
 		), nil
 	}
 
@@ -261,7 +266,7 @@ func providerSourceForCLIConfigLocation(
 		// this client is not suitable for the HTTP mirror source, so we
 		// don't use this client directly.
 		httpTimeout := newRegistryHTTPClient(ctx, registryClientConfig).HTTPClient.Timeout
-		return getproviders.NewHTTPMirrorSource(ctx, url, services.CredentialsSource(), httpTimeout, providerSourceLocationConfig(locationRetries, trustedSource)), nil
+		return getproviders.NewHTTPMirrorSource(ctx, url, services.CredentialsSource(), httpTimeout, providerSourceLocationConfig(locationRetries, trustedSource, download)), nil
 
 	case cliconfig.ProviderInstallationOCIMirror:
 		mappingFunc := loc.RepositoryMapping
@@ -281,6 +286,7 @@ func providerSourceForCLIConfigLocation(
 				}
 				return oci.GetOCIRepositoryStore(ctx, registryDomain, repositoryName, credsPolicy)
 			},
+			getOCISignaturePolicies.PolicyFor,
 		), nil
 
 	default:
@@ -302,21 +308,53 @@ func providerDevOverrides(configs []*cliconfig.ProviderInstallation) map[addrs.P
 	return configs[0].DevOverrides
 }
 
+// providerInstallPolicy converts the policy block from the CLI configuration,
+// if any, into the form expected by the provider installer.
+func providerInstallPolicy(configs []*cliconfig.ProviderInstallation) *providercache.InstallPolicy {
+	if len(configs) == 0 || configs[0].Policy == nil {
+		return nil
+	}
+
+	// As with providerDevOverrides, we only consider the first configuration
+	// because the cliconfig package rejects any others.
+	config := configs[0].Policy
+	ret := &providercache.InstallPolicy{
+		Allow:               config.Allow,
+		Deny:                config.Deny,
+		MinimumReleaseAge:   config.MinimumReleaseAge,
+		DeniedVersionsFiles: config.DeniedVersionsFiles,
+	}
+	for _, rule := range config.VersionRules {
+		ret.VersionRules = append(ret.VersionRules, providercache.InstallPolicyVersionRule{
+			Providers:   rule.Providers,
+			Constraints: rule.Constraints,
+		})
+	}
+	return ret
+}
+
 // providerSourceLocationConfig is meant to build a global configuration for the
 // remote locations to download a provider from. This is built out of the
-// TF_PROVIDER_DOWNLOAD_RETRY env variable and is meant to be passed through
+// TF_PROVIDER_DOWNLOAD_RETRY env variable and the optional download block of
+// the provider_installation CLI configuration, and is meant to be passed through
 // [getproviders.Source] all the way down to the [getproviders.PackageLocation]
 // to be able to tweak the configurations of the http clients used there.
-func providerSourceLocationConfig(locationRetries cliconfig.ProviderInstallationMethodRetries, trustAllHashes cliconfig.ProviderInstallationMethodTrusted) getproviders.LocationConfig {
+func providerSourceLocationConfig(locationRetries cliconfig.ProviderInstallationMethodRetries, trustAllHashes cliconfig.ProviderInstallationMethodTrusted, download *cliconfig.ProviderInstallationDownload) getproviders.LocationConfig {
 	// If there is no configuration for the retries in .tofurc, get the one from env variable
 	retries, configured := locationRetries()
 	if !configured {
 		retries = cliconfig.ProviderDownloadRetries()
 	}
-	return getproviders.LocationConfig{
+	ret := getproviders.LocationConfig{
 		ProviderDownloadRetries: retries,
 		TrustAllHashes:          trustAllHashes != nil && trustAllHashes(),
 	}
+	if download != nil {
+		ret.ProviderDownloadRetryWaitMin = download.RetryWaitMin
+		ret.ProviderDownloadRetryWaitMax = download.RetryWaitMax
+		ret.ProviderDownloadBandwidthLimit = download.BandwidthLimit
+	}
+	return ret
 }
 
 // providerSourceLocationConfigFromEnv is similar to providerSourceLocationConfig but does not
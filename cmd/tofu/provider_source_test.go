@@ -132,6 +132,7 @@ func TestProviderSource(t *testing.T) {
 				},
 				services,
 				ociCredsPolicy,
+				nil,
 				originalWorkingDir,
 			)
 
@@ -260,7 +261,7 @@ func TestInitProviderSourceForCLIConfigLocationWithRetries(t *testing.T) {
 				"providers.v1": server.URL + "/providers/v1/",
 			})
 
-			providerSrc, diags := providerSourceForCLIConfigLocation(t.Context(), methodType, retries, trusted, &cliconfig.RegistryProtocolsConfig{}, disco, nil)
+			providerSrc, diags := providerSourceForCLIConfigLocation(t.Context(), methodType, retries, trusted, nil, &cliconfig.RegistryProtocolsConfig{}, disco, nil, nil)
 			if diags.HasErrors() {
 				t.Fatalf("unexpected error creating the provider source: %s", diags)
 			}
@@ -320,7 +321,7 @@ func TestConfigureProviderDownloadRetry(t *testing.T) {
 				return 0, false
 			}, func() bool {
 				return false
-			})
+			}, nil)
 
 			if diff := cmp.Diff(tt.expectedConfig, got); diff != "" {
 				t.Fatalf("expected no diff. got:\n%s", diff)
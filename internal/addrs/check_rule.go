@@ -52,6 +52,8 @@ func (c CheckRule) String() string {
 		return fmt.Sprintf("%s.assert[%d]", container, c.Index)
 	case InputValidation:
 		return fmt.Sprintf("%s.validation[%d]", container, c.Index)
+	case OutputValidation:
+		return fmt.Sprintf("%s.validation[%d]", container, c.Index)
 	default:
 		// This should not happen
 		return fmt.Sprintf("%s.condition[%d]", container, c.Index)
@@ -94,6 +96,7 @@ const (
 	CheckDataResource     CheckRuleType = 4
 	CheckAssertion        CheckRuleType = 5
 	InputValidation       CheckRuleType = 6
+	OutputValidation      CheckRuleType = 7
 )
 
 // Description returns a human-readable description of the check type. This is
@@ -112,6 +115,8 @@ func (c CheckRuleType) Description() string {
 		return "Check block assertion"
 	case InputValidation:
 		return "Input variable validation"
+	case OutputValidation:
+		return "Module output value validation"
 	default:
 		// This should not happen
 		return "Condition"
@@ -15,11 +15,12 @@ func _() {
 	_ = x[CheckDataResource-4]
 	_ = x[CheckAssertion-5]
 	_ = x[InputValidation-6]
+	_ = x[OutputValidation-7]
 }
 
-const _CheckRuleType_name = "InvalidConditionResourcePreconditionResourcePostconditionOutputPreconditionCheckDataResourceCheckAssertionInputValidation"
+const _CheckRuleType_name = "InvalidConditionResourcePreconditionResourcePostconditionOutputPreconditionCheckDataResourceCheckAssertionInputValidationOutputValidation"
 
-var _CheckRuleType_index = [...]uint8{0, 16, 36, 57, 75, 92, 106, 121}
+var _CheckRuleType_index = [...]uint8{0, 16, 36, 57, 75, 92, 106, 121, 137}
 
 func (i CheckRuleType) String() string {
 	idx := int(i) - 0
@@ -39,6 +39,8 @@ type MoveEndpoint struct {
 	// this is always either a ModuleInstance or an AbsResourceInstance,
 	// and we only consider the possibility of interpreting it as
 	// a AbsModuleCall or an AbsResource in UnifyMoveEndpoints.
+	// Any of its instance keys might be a WildcardKey, written as [*]
+	// in the configuration.
 	// This is intentionally unexported to encapsulate this unusual
 	// meaning of AbsMoveable.
 	relSubject AbsMoveable
@@ -86,6 +88,27 @@ func (e *MoveEndpoint) MightUnifyWith(other *MoveEndpoint) bool {
 	return from != nil && to != nil
 }
 
+// ModuleWildcardCount returns the number of module steps in the receiver
+// that use the wildcard instance key [*], not counting a wildcard on the
+// final step of a module address.
+//
+// The "from" and "to" endpoints of a move statement must have the same
+// number of module wildcards, because the instance keys matched by the
+// wildcards in "from" are substituted positionally into the wildcards of
+// "to".
+func (e *MoveEndpoint) ModuleWildcardCount() int {
+	var steps ModuleInstance
+	switch addr := e.relSubject.(type) {
+	case ModuleInstance:
+		if len(addr) > 0 {
+			steps = addr[:len(addr)-1]
+		}
+	case AbsResourceInstance:
+		steps = addr.Module
+	}
+	return countWildcardSteps(steps)
+}
+
 // ConfigMoveable transforms the receiver into a ConfigMovable by resolving it
 // relative to the given base module, which should be the module where
 // the MoveEndpoint expression was found.
@@ -127,8 +150,14 @@ func (e *MoveEndpoint) ConfigMoveable(baseModule Module) ConfigMoveable {
 // in configuration. Before the result will be useful you'll need to combine
 // it with the address of the module where it was declared in order to get
 // an absolute address relative to the root module.
+//
+// Any module or resource instance key may be given as the wildcard [*], which
+// selects all instances at that step. A wildcard on the final step is
+// equivalent to giving no key at all, selecting the whole resource or module
+// call, while wildcards on earlier module steps are matched against the
+// instance keys of the "to" endpoint in the same position.
 func ParseMoveEndpoint(traversal hcl.Traversal) (*MoveEndpoint, tfdiags.Diagnostics) {
-	path, remain, diags := parseModuleInstancePrefix(traversal)
+	path, remain, diags := parseModuleInstancePrefixWithOptionalRanges(traversal, true)
 	if diags.HasErrors() {
 		return nil, diags
 	}
@@ -142,7 +171,7 @@ func ParseMoveEndpoint(traversal hcl.Traversal) (*MoveEndpoint, tfdiags.Diagnost
 		}, diags
 	}
 
-	riAddr, moreDiags := parseResourceInstanceUnderModule(path, remain, false)
+	riAddr, moreDiags := parseResourceInstanceUnderModule(path, remain, true)
 	diags = diags.Append(moreDiags)
 	if diags.HasErrors() {
 		return nil, diags
@@ -167,7 +196,8 @@ func ParseMoveEndpoint(traversal hcl.Traversal) (*MoveEndpoint, tfdiags.Diagnost
 // address type possible.
 //
 // Not all combinations of addresses are unifyable: the two addresses must
-// either both include resources or both just be modules. If the two
+// either both include resources or both just be modules, and they must
+// have the same number of module wildcards. If the two
 // given addresses are incompatible then UnifyMoveEndpoints returns (nil, nil),
 // in which case the caller should typically report an error to the user
 // stating the unification constraints.
@@ -205,6 +235,10 @@ func UnifyMoveEndpoints(moduleAddr Module, relFrom, relTo *MoveEndpoint) (modFro
 		panic("unhandled move address types")
 	}
 
+	if relFrom.ModuleWildcardCount() != relTo.ModuleWildcardCount() {
+		return nil, nil
+	}
+
 	modFrom = relFrom.prepareMoveEndpointInModule(moduleAddr, wantType)
 	modTo = relTo.prepareMoveEndpointInModule(moduleAddr, wantType)
 	if modFrom == nil || modTo == nil {
@@ -224,6 +258,11 @@ func (e *MoveEndpoint) prepareMoveEndpointInModule(moduleAddr Module, wantType T
 	case ModuleInstance:
 		switch wantType {
 		case ModuleInstanceAddrType:
+			if relAddr[len(relAddr)-1].IsPlaceholder() {
+				// A wildcard selects the whole module call, so it can't
+				// be paired with a specific module instance.
+				return nil
+			}
 			// Since our internal representation is already a module instance,
 			// we can just rewrap this one.
 			return &MoveEndpointInModule{
@@ -251,6 +290,11 @@ func (e *MoveEndpoint) prepareMoveEndpointInModule(moduleAddr Module, wantType T
 	case AbsResourceInstance:
 		switch wantType {
 		case AbsResourceInstanceAddrType:
+			if relAddr.Resource.IsPlaceholder() {
+				// A wildcard selects the whole resource, so it can't
+				// be paired with a specific resource instance.
+				return nil
+			}
 			return &MoveEndpointInModule{
 				SourceRange: e.SourceRange,
 				module:      moduleAddr,
@@ -281,7 +325,7 @@ func (e *MoveEndpoint) prepareMoveEndpointInModule(moduleAddr Module, wantType T
 func (e *MoveEndpoint) internalAddrType() TargetableAddrType {
 	switch addr := e.relSubject.(type) {
 	case ModuleInstance:
-		if !addr.IsRoot() && addr[len(addr)-1].InstanceKey == NoKey {
+		if !addr.IsRoot() && (addr[len(addr)-1].InstanceKey == NoKey || addr[len(addr)-1].IsPlaceholder()) {
 			// NOTE: We're fudging a little here and using
 			// ModuleAddrType to represent AbsModuleCall rather
 			// than Module.
@@ -289,7 +333,7 @@ func (e *MoveEndpoint) internalAddrType() TargetableAddrType {
 		}
 		return ModuleInstanceAddrType
 	case AbsResourceInstance:
-		if addr.Resource.Key == NoKey {
+		if addr.Resource.Key == NoKey || addr.Resource.IsPlaceholder() {
 			return AbsResourceAddrType
 		}
 		return AbsResourceInstanceAddrType
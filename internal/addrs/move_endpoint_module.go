@@ -230,14 +230,14 @@ func (e *MoveEndpointInModule) synthModuleInstance() ModuleInstance {
 
 	switch sub := e.relSubject.(type) {
 	case ModuleInstance:
-		inst = append(inst, sub...)
+		inst = appendAnyKeySteps(inst, sub)
 	case AbsModuleCall:
-		inst = append(inst, sub.Module...)
+		inst = appendAnyKeySteps(inst, sub.Module)
 		inst = append(inst, ModuleInstanceStep{Name: sub.Call.Name, InstanceKey: anyKey})
 	case AbsResource:
-		inst = append(inst, sub.Module...)
+		inst = appendAnyKeySteps(inst, sub.Module)
 	case AbsResourceInstance:
-		inst = append(inst, sub.Module...)
+		inst = appendAnyKeySteps(inst, sub.Module)
 	default:
 		panic(fmt.Sprintf("unhandled relative address type %T", sub))
 	}
@@ -245,6 +245,129 @@ func (e *MoveEndpointInModule) synthModuleInstance() ModuleInstance {
 	return inst
 }
 
+// appendAnyKeySteps appends the given steps to inst, replacing any wildcard
+// instance keys written in the configuration with anyKey.
+func appendAnyKeySteps(inst, steps ModuleInstance) ModuleInstance {
+	for _, step := range steps {
+		if step.IsPlaceholder() {
+			step.InstanceKey = anyKey
+		}
+		inst = append(inst, step)
+	}
+	return inst
+}
+
+// relModuleSteps returns the steps of the receiver's relative address that
+// lead to the module containing the selected object, excluding the final
+// module call step of a module address.
+func (e *MoveEndpointInModule) relModuleSteps() ModuleInstance {
+	switch sub := e.relSubject.(type) {
+	case ModuleInstance:
+		return sub[:len(sub)-1]
+	case AbsModuleCall:
+		return sub.Module
+	case AbsResource:
+		return sub.Module
+	case AbsResourceInstance:
+		return sub.Module
+	default:
+		panic(fmt.Sprintf("unhandled relative address type %T", sub))
+	}
+}
+
+// HasWildcards returns true if any of the module steps in the receiver's
+// relative address use the wildcard instance key [*].
+func (e *MoveEndpointInModule) HasWildcards() bool {
+	return countWildcardSteps(e.relModuleSteps()) > 0
+}
+
+// MatchWildcards decides whether the given module instance address is one
+// that the module steps of the receiver could select, where modInst is the
+// absolute address of the module instance that directly contains the
+// selected object. If so, it returns the instance keys that the receiver's
+// wildcards matched, in order.
+func (e *MoveEndpointInModule) MatchWildcards(modInst ModuleInstance) ([]InstanceKey, bool) {
+	_, mRel, match := e.matchModuleInstancePrefix(modInst)
+	if !match {
+		return nil, false
+	}
+	return matchWildcardSteps(e.relModuleSteps(), mRel)
+}
+
+// SubstituteWildcards returns a copy of the receiver where each wildcard
+// instance key in its module steps is replaced by the next of the given keys,
+// as returned by MatchWildcards.
+func (e *MoveEndpointInModule) SubstituteWildcards(keys []InstanceKey) *MoveEndpointInModule {
+	ret := *e
+	switch sub := e.relSubject.(type) {
+	case ModuleInstance:
+		ret.relSubject = substituteWildcardSteps(sub, keys)
+	case AbsModuleCall:
+		ret.relSubject = AbsModuleCall{Module: substituteWildcardSteps(sub.Module, keys), Call: sub.Call}
+	case AbsResource:
+		ret.relSubject = sub.Resource.Absolute(substituteWildcardSteps(sub.Module, keys))
+	case AbsResourceInstance:
+		ret.relSubject = sub.Resource.Absolute(substituteWildcardSteps(sub.Module, keys))
+	default:
+		panic(fmt.Sprintf("unhandled relative address type %T", sub))
+	}
+	return &ret
+}
+
+// countWildcardSteps returns the number of steps in the given address that
+// use a wildcard instance key.
+func countWildcardSteps(steps ModuleInstance) int {
+	count := 0
+	for _, step := range steps {
+		if step.IsPlaceholder() {
+			count++
+		}
+	}
+	return count
+}
+
+// matchWildcardSteps compares relative module instance steps from a move
+// endpoint with the given steps of an actual module instance, treating a
+// wildcard instance key in the pattern as matching any key. If all of the
+// steps match it returns the keys that the wildcards matched, in order.
+func matchWildcardSteps(pattern, steps ModuleInstance) ([]InstanceKey, bool) {
+	if len(pattern) != len(steps) {
+		return nil, false
+	}
+	var keys []InstanceKey
+	for i, step := range pattern {
+		if step.IsPlaceholder() {
+			if step.Name != steps[i].Name {
+				return nil, false
+			}
+			keys = append(keys, steps[i].InstanceKey)
+			continue
+		}
+		if step != steps[i] {
+			return nil, false
+		}
+	}
+	return keys, true
+}
+
+// substituteWildcardSteps returns a copy of the given relative module
+// instance steps where each wildcard instance key is replaced by the next
+// of the given keys.
+func substituteWildcardSteps(pattern ModuleInstance, keys []InstanceKey) ModuleInstance {
+	if countWildcardSteps(pattern) == 0 {
+		return pattern
+	}
+	ret := make(ModuleInstance, len(pattern))
+	for i, step := range pattern {
+		if step.IsPlaceholder() && len(keys) > 0 {
+			step.InstanceKey = keys[0]
+			keys = keys[1:]
+		}
+		ret[i] = step
+	}
+	return ret
+}
+
 // SelectsModule returns true if the receiver directly selects either
 // the given module or a resource nested directly inside that module.
 //
@@ -509,15 +632,15 @@ func (m ModuleInstance) MoveDestination(fromMatch, toMatch *MoveEndpointInModule
 		if len(relSubject) > len(mRel) {
 			return nil, false // too short to possibly match
 		}
-		for i := range relSubject {
-			if relSubject[i] != mRel[i] {
-				return nil, false // this step doesn't match
-			}
+		keys, match := matchWildcardSteps(relSubject, mRel[:len(relSubject)])
+		if !match {
+			return nil, false // at least one step doesn't match
 		}
 		// If we get to here then we've found a match. Since the statement
 		// addresses are already themselves ModuleInstance fragments we can
-		// just slice out the relevant parts.
-		mNewMatch = toMatch.relSubject.(ModuleInstance)
+		// just slice out the relevant parts, substituting any keys matched
+		// by wildcards.
+		mNewMatch = substituteWildcardSteps(toMatch.relSubject.(ModuleInstance), keys)
 		mSuffix = mRel[len(relSubject):]
 	case AbsModuleCall:
 		// The module instance part of relSubject must be a prefix of
@@ -526,10 +649,9 @@ func (m ModuleInstance) MoveDestination(fromMatch, toMatch *MoveEndpointInModule
 		if len(relSubject.Module) > len(mRel)-1 {
 			return nil, false
 		}
-		for i := range relSubject.Module {
-			if relSubject.Module[i] != mRel[i] {
-				return nil, false // this step doesn't match
-			}
+		keys, match := matchWildcardSteps(relSubject.Module, mRel[:len(relSubject.Module)])
+		if !match {
+			return nil, false // at least one step doesn't match
 		}
 		// The call name must also match the next step of mRel, after
 		// the relSubject.Module prefix.
@@ -540,7 +662,9 @@ func (m ModuleInstance) MoveDestination(fromMatch, toMatch *MoveEndpointInModule
 		// If we get to here then we've found a match. We need to construct
 		// a new mNewMatch that's an instance of the "new" relSubject with
 		// the same key as our call.
-		mNewMatch = toMatch.relSubject.(AbsModuleCall).Instance(callStep.InstanceKey)
+		toRelSubject := toMatch.relSubject.(AbsModuleCall)
+		toRelSubject.Module = substituteWildcardSteps(toRelSubject.Module, keys)
+		mNewMatch = toRelSubject.Instance(callStep.InstanceKey)
 		mSuffix = mRel[len(relSubject.Module)+1:]
 	default:
 		panic("invalid address type for module-kind move endpoint")
@@ -598,25 +722,23 @@ func (r AbsResource) MoveDestination(fromMatch, toMatch *MoveEndpointInModule) (
 		}
 
 		// The remaining steps of the module path must _exactly_ match
-		// the relative module path in the "fromMatch" address.
-		if len(mRel) != len(fromRelSubject.Module) {
-			return AbsResource{}, false // can't match if lengths are different
-		}
-		for i := range mRel {
-			if mRel[i] != fromRelSubject.Module[i] {
-				return AbsResource{}, false // all of the steps must match
-			}
+		// the relative module path in the "fromMatch" address, aside from
+		// any wildcard instance keys.
+		keys, match := matchWildcardSteps(fromRelSubject.Module, mRel)
+		if !match {
+			return AbsResource{}, false // all of the steps must match
 		}
 
 		// If we got here then we have a match, and so our result is the
 		// module instance where the statement was declared (mPrefix) followed
 		// by the "to" relative address in toMatch.
 		toRelSubject := toMatch.relSubject.(AbsResource)
+		toModule := substituteWildcardSteps(toRelSubject.Module, keys)
 		var mNew ModuleInstance
-		if len(mPrefix) > 0 || len(toRelSubject.Module) > 0 {
-			mNew = make(ModuleInstance, 0, len(mPrefix)+len(toRelSubject.Module))
+		if len(mPrefix) > 0 || len(toModule) > 0 {
+			mNew = make(ModuleInstance, 0, len(mPrefix)+len(toModule))
 			mNew = append(mNew, mPrefix...)
-			mNew = append(mNew, toRelSubject.Module...)
+			mNew = append(mNew, toModule...)
 		}
 		ret := toRelSubject.Resource.Absolute(mNew)
 		return ret, true
@@ -680,25 +802,23 @@ func (r AbsResourceInstance) MoveDestination(fromMatch, toMatch *MoveEndpointInM
 			}
 
 			// The remaining steps of the module path must _exactly_ match
-			// the relative module path in the "fromMatch" address.
-			if len(mRel) != len(fromRelSubject.Module) {
-				return AbsResourceInstance{}, false // can't match if lengths are different
-			}
-			for i := range mRel {
-				if mRel[i] != fromRelSubject.Module[i] {
-					return AbsResourceInstance{}, false // all of the steps must match
-				}
+			// the relative module path in the "fromMatch" address, aside from
+			// any wildcard instance keys.
+			keys, match := matchWildcardSteps(fromRelSubject.Module, mRel)
+			if !match {
+				return AbsResourceInstance{}, false // all of the steps must match
 			}
 
 			// If we got here then we have a match, and so our result is the
 			// module instance where the statement was declared (mPrefix) followed
 			// by the "to" relative address in toMatch.
 			toRelSubject := toMatch.relSubject.(AbsResourceInstance)
+			toModule := substituteWildcardSteps(toRelSubject.Module, keys)
 			var mNew ModuleInstance
-			if len(mPrefix) > 0 || len(toRelSubject.Module) > 0 {
-				mNew = make(ModuleInstance, 0, len(mPrefix)+len(toRelSubject.Module))
+			if len(mPrefix) > 0 || len(toModule) > 0 {
+				mNew = make(ModuleInstance, 0, len(mPrefix)+len(toModule))
 				mNew = append(mNew, mPrefix...)
-				mNew = append(mNew, toRelSubject.Module...)
+				mNew = append(mNew, toModule...)
 			}
 			ret := toRelSubject.Resource.Absolute(mNew)
 			return ret, true
@@ -1748,3 +1748,177 @@ func mustParseAbsResourceInstanceStr(s string) AbsResourceInstance {
 	}
 	return r
 }
+
+func TestMoveDestinationWildcards(t *testing.T) {
+	tests := []struct {
+		DeclModule       string
+		StmtFrom, StmtTo string
+		Receiver         string
+		WantMatch        bool
+		WantResult       string
+	}{
+		{
+			``,
+			`test_object.a[*]`,
+			`module.x.test_object.a[*]`,
+			`test_object.a["k"]`,
+			true,
+			`module.x.test_object.a["k"]`,
+		},
+		{
+			``,
+			`module.a[*].test_object.x`,
+			`module.b[*].test_object.y`,
+			`module.a[1].test_object.x[0]`,
+			true,
+			`module.b[1].test_object.y[0]`,
+		},
+		{
+			``,
+			`module.a[*].test_object.x`,
+			`module.b[*].test_object.y`,
+			`module.z[1].test_object.x[0]`,
+			false,
+			``,
+		},
+		{
+			``,
+			`module.a[*].module.b[*].test_object.x`,
+			`module.c[*].module.d[*].test_object.x`,
+			`module.a[1].module.b["q"].test_object.x`,
+			true,
+			`module.c[1].module.d["q"].test_object.x`,
+		},
+		{
+			`foo`,
+			`module.a[*].test_object.x`,
+			`module.b[*].test_object.x`,
+			`module.foo[2].module.a["p"].test_object.x`,
+			true,
+			`module.foo[2].module.b["p"].test_object.x`,
+		},
+		{
+			``,
+			`module.a[*].module.c`,
+			`module.b[*].module.c`,
+			`module.a["p"].module.c[2]`,
+			true,
+			`module.b["p"].module.c[2]`,
+		},
+		{
+			``,
+			`module.a[*]`,
+			`module.b[*]`,
+			`module.a["p"].module.c`,
+			true,
+			`module.b["p"].module.c`,
+		},
+		{
+			``,
+			`module.a[*].module.c`,
+			`module.b[*].module.c`,
+			`module.b["p"].module.c[2]`,
+			false,
+			``,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(
+			fmt.Sprintf(
+				"%s: %s to %s with %s",
+				test.DeclModule,
+				test.StmtFrom, test.StmtTo,
+				test.Receiver,
+			),
+			func(t *testing.T) {
+				parseStmtEP := func(t *testing.T, input string) *MoveEndpoint {
+					t.Helper()
+
+					moveEp, diags := ParseMoveEndpoint(parseWildcardTraversal(t, input))
+					if diags.HasErrors() {
+						t.Fatalf("unexpected error: %s", diags.Err().Error())
+					}
+					return moveEp
+				}
+
+				fromEPLocal := parseStmtEP(t, test.StmtFrom)
+				toEPLocal := parseStmtEP(t, test.StmtTo)
+
+				declModule := RootModule
+				if test.DeclModule != "" {
+					declModule = strings.Split(test.DeclModule, ".")
+				}
+				fromEP, toEP := UnifyMoveEndpoints(declModule, fromEPLocal, toEPLocal)
+				if fromEP == nil || toEP == nil {
+					t.Fatalf("invalid test case: non-unifyable endpoints\nfrom: %s\nto:   %s", fromEPLocal, toEPLocal)
+				}
+
+				var gotAddr fmt.Stringer
+				var gotMatch bool
+				if strings.Contains(test.Receiver, "test_object") {
+					receiverAddr, diags := ParseAbsResourceInstanceStr(test.Receiver)
+					if diags.HasErrors() {
+						t.Fatalf("invalid receiver address: %s", diags.Err().Error())
+					}
+					gotAddr, gotMatch = receiverAddr.MoveDestination(fromEP, toEP)
+				} else {
+					receiverAddr, diags := ParseModuleInstanceStr(test.Receiver)
+					if diags.HasErrors() {
+						t.Fatalf("invalid receiver address: %s", diags.Err().Error())
+					}
+					gotAddr, gotMatch = receiverAddr.MoveDestination(fromEP, toEP)
+				}
+				if !test.WantMatch {
+					if gotMatch {
+						t.Errorf("unexpected match\nreceiver: %s\nfrom:     %s\nto:       %s\nresult:   %s", test.Receiver, fromEP, toEP, gotAddr)
+					}
+					return
+				}
+
+				if !gotMatch {
+					t.Fatalf("unexpected non-match\nreceiver: %s\nfrom:     %s\nto:       %s\ngot:      no match\nwant:     %s", test.Receiver, fromEP, toEP, test.WantResult)
+				}
+
+				if gotStr, wantStr := gotAddr.String(), test.WantResult; gotStr != wantStr {
+					t.Errorf("wrong result\ngot:  %s\nwant: %s", gotStr, wantStr)
+				}
+			},
+		)
+	}
+}
+
+func TestMoveEndpointMatchWildcards(t *testing.T) {
+	fromEPLocal, diags := ParseMoveEndpoint(parseWildcardTraversal(t, `module.a[*].module.b[*].test_object.x`))
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Err().Error())
+	}
+	toEPLocal, diags := ParseMoveEndpoint(parseWildcardTraversal(t, `module.c[*].module.d[*].test_object.x`))
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Err().Error())
+	}
+	fromEP, toEP := UnifyMoveEndpoints(Module{"foo"}, fromEPLocal, toEPLocal)
+	if fromEP == nil || toEP == nil {
+		t.Fatalf("non-unifyable endpoints")
+	}
+	if !toEP.HasWildcards() {
+		t.Fatalf("endpoint %s should have wildcards", toEP)
+	}
+
+	modInst, diags := ParseModuleInstanceStr(`module.foo[0].module.c["x"].module.d[3]`)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Err().Error())
+	}
+	keys, ok := toEP.MatchWildcards(modInst)
+	if !ok {
+		t.Fatalf("%s should match %s", toEP, modInst)
+	}
+	if _, ok := toEP.MatchWildcards(modInst[:2]); ok {
+		t.Fatalf("%s should not match %s", toEP, modInst[:2])
+	}
+
+	got := fromEP.SubstituteWildcards(keys).InModuleInstance(modInst[:1])
+	if got, want := got.String(), `module.foo[0].module.a["x"].module.b[3].test_object.x`; got != want {
+		t.Errorf("wrong result\ngot:  %s\nwant: %s", got, want)
+	}
+}
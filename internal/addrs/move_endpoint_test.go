@@ -7,11 +7,13 @@ package addrs
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestParseMoveEndpoint(t *testing.T) {
@@ -305,17 +307,51 @@ func TestParseMoveEndpoint(t *testing.T) {
 			nil,
 			`Invalid address: A resource name is required.`,
 		},
+		{
+			`foo.bar[*]`,
+			AbsResourceInstance{
+				Module: RootModuleInstance,
+				Resource: ResourceInstance{
+					Resource: Resource{
+						Mode: ManagedResourceMode,
+						Type: "foo",
+						Name: "bar",
+					},
+					Key: WildcardKey{UnknownKeyType},
+				},
+			},
+			``,
+		},
+		{
+			`module.boop[*].foo.bar`,
+			AbsResourceInstance{
+				Module: ModuleInstance{
+					ModuleInstanceStep{Name: "boop", InstanceKey: WildcardKey{UnknownKeyType}},
+				},
+				Resource: ResourceInstance{
+					Resource: Resource{
+						Mode: ManagedResourceMode,
+						Type: "foo",
+						Name: "bar",
+					},
+					Key: NoKey,
+				},
+			},
+			``,
+		},
+		{
+			`module.boop[*].module.bap[*]`,
+			ModuleInstance{
+				ModuleInstanceStep{Name: "boop", InstanceKey: WildcardKey{UnknownKeyType}},
+				ModuleInstanceStep{Name: "bap", InstanceKey: WildcardKey{UnknownKeyType}},
+			},
+			``,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.Input, func(t *testing.T) {
-			traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(test.Input), "", hcl.InitialPos)
-			if hclDiags.HasErrors() {
-				// We're not trying to test the HCL parser here, so any
-				// failures at this point are likely to be bugs in the
-				// test case itself.
-				t.Fatalf("syntax error: %s", hclDiags.Error())
-			}
+			traversal := parseWildcardTraversal(t, test.Input)
 
 			moveEp, diags := ParseMoveEndpoint(traversal)
 
@@ -465,6 +501,55 @@ func TestUnifyMoveEndpoints(t *testing.T) {
 			WantFrom:  ``, // Can't unify module instance with resource instance
 			WantTo:    ``,
 		},
+		{
+			InputFrom: `foo.bar[*]`,
+			InputTo:   `module.a.foo.bar[*]`,
+			Module:    RootModule,
+			WantFrom:  `foo.bar[*]`,
+			WantTo:    `module.a.foo.bar[*]`,
+		},
+		{
+			InputFrom: `foo.bar[*]`,
+			InputTo:   `foo.baz`,
+			Module:    RootModule,
+			WantFrom:  `foo.bar[*]`,
+			WantTo:    `foo.baz[*]`,
+		},
+		{
+			InputFrom: `module.a[*].foo.bar`,
+			InputTo:   `module.b[*].foo.bar`,
+			Module:    RootModule,
+			WantFrom:  `module.a[*].foo.bar[*]`,
+			WantTo:    `module.b[*].foo.bar[*]`,
+		},
+		{
+			InputFrom: `module.a[*].module.c[*]`,
+			InputTo:   `module.b[*].module.c`,
+			Module:    RootModule,
+			WantFrom:  `module.a[*].module.c[*]`,
+			WantTo:    `module.b[*].module.c[*]`,
+		},
+		{
+			InputFrom: `foo.bar[*]`,
+			InputTo:   `foo.bar[0]`,
+			Module:    RootModule,
+			WantFrom:  ``, // Can't unify a whole resource wildcard with one instance
+			WantTo:    ``,
+		},
+		{
+			InputFrom: `module.a[*]`,
+			InputTo:   `module.b["x"]`,
+			Module:    RootModule,
+			WantFrom:  ``, // Can't unify a whole module call wildcard with one instance
+			WantTo:    ``,
+		},
+		{
+			InputFrom: `module.a[*].foo.bar`,
+			InputTo:   `foo.bar`,
+			Module:    RootModule,
+			WantFrom:  ``, // The module wildcards must correspond
+			WantTo:    ``,
+		},
 	}
 
 	for _, test := range tests {
@@ -472,13 +557,7 @@ func TestUnifyMoveEndpoints(t *testing.T) {
 			parseInput := func(input string) *MoveEndpoint {
 				t.Helper()
 
-				traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(input), "", hcl.InitialPos)
-				if hclDiags.HasErrors() {
-					// We're not trying to test the HCL parser here, so any
-					// failures at this point are likely to be bugs in the
-					// test case itself.
-					t.Fatalf("syntax error: %s", hclDiags.Error())
-				}
+				traversal := parseWildcardTraversal(t, input)
 
 				moveEp, diags := ParseMoveEndpoint(traversal)
 				if diags.HasErrors() {
@@ -687,3 +766,25 @@ func TestSubjectAllowed(t *testing.T) {
 		})
 	}
 }
+
+// parseWildcardTraversal parses the given absolute traversal, additionally
+// accepting the wildcard instance key [*] in any index position.
+func parseWildcardTraversal(t *testing.T, input string) hcl.Traversal {
+	t.Helper()
+
+	const placeholder = "__wildcard__"
+	src := strings.ReplaceAll(input, "[*]", `["`+placeholder+`"]`)
+	traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(src), "", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		// We're not trying to test the HCL parser here, so any
+		// failures at this point are likely to be bugs in the
+		// test case itself.
+		t.Fatalf("syntax error: %s", hclDiags.Error())
+	}
+	for i, step := range traversal {
+		if idx, ok := step.(hcl.TraverseIndex); ok && idx.Key.Type() == cty.String && idx.Key.AsString() == placeholder {
+			traversal[i] = hcl.TraverseSplat{SrcRange: idx.SrcRange}
+		}
+	}
+	return traversal
+}
@@ -0,0 +1,141 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// targetPatternWildcard is the instance key syntax that selects all of the
+// instances of a module call or resource in a target pattern.
+const targetPatternWildcard = "[*]"
+
+// ParseTargetPatternStr is like ParseTargetStr but additionally accepts the
+// wildcard instance key [*] in place of the instance key of any module call
+// or resource in the address, selecting all of its instances.
+//
+// A pattern that uses wildcards produces one of the configuration-level
+// targetable addresses, Module or ConfigResource, and so cannot also use
+// specific instance keys elsewhere in the same address. A pattern without any
+// wildcards produces the same result as ParseTargetStr.
+func ParseTargetPatternStr(str string) (*Target, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	stripped, wildcard := stripTargetPatternWildcards(str)
+	target, targetDiags := ParseTargetStr(stripped)
+	diags = diags.Append(targetDiags)
+	if targetDiags.HasErrors() || !wildcard {
+		return target, diags
+	}
+
+	var module ModuleInstance
+	switch subject := target.Subject.(type) {
+	case ModuleInstance:
+		module = subject
+	case AbsResource:
+		module = subject.Module
+	default:
+		// The only other possibility is AbsResourceInstance, which means
+		// that the resource has a specific instance key.
+		diags = diags.Append(invalidTargetPatternDiag(str))
+		return nil, diags
+	}
+	for _, step := range module {
+		if step.InstanceKey != NoKey {
+			diags = diags.Append(invalidTargetPatternDiag(str))
+			return nil, diags
+		}
+	}
+
+	switch subject := target.Subject.(type) {
+	case ModuleInstance:
+		target.Subject = subject.Module()
+	case AbsResource:
+		target.Subject = subject.Config()
+	}
+	return target, diags
+}
+
+// TargetPatternString returns a string representation of the given targetable
+// address that ParseTargetPatternStr will parse back into an identical
+// result.
+//
+// This differs from the address's String method only for the
+// configuration-level addresses Module and ConfigResource, whose module
+// calls are written with the wildcard instance key.
+func TargetPatternString(target Targetable) string {
+	var module Module
+	var resource *Resource
+	switch target := target.(type) {
+	case Module:
+		module = target
+	case ConfigResource:
+		module = target.Module
+		resource = &target.Resource
+	default:
+		return target.String()
+	}
+
+	var buf strings.Builder
+	for i, name := range module {
+		if i > 0 {
+			buf.WriteByte('.')
+		}
+		buf.WriteString("module.")
+		buf.WriteString(name)
+		buf.WriteString(targetPatternWildcard)
+	}
+	if resource != nil {
+		if len(module) > 0 {
+			buf.WriteByte('.')
+		}
+		buf.WriteString(resource.String())
+		if len(module) == 0 {
+			// A wildcard on the resource itself keeps the result
+			// configuration-level when there are no module calls.
+			buf.WriteString(targetPatternWildcard)
+		}
+	}
+	return buf.String()
+}
+
+// stripTargetPatternWildcards removes all of the wildcard instance keys from
+// the given target pattern, ignoring any that appear inside quoted strings,
+// and reports whether there were any.
+func stripTargetPatternWildcards(str string) (string, bool) {
+	var buf strings.Builder
+	wildcard := false
+	inQuotes := false
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case inQuotes && c == '\\' && i+1 < len(str):
+			buf.WriteByte(c)
+			i++
+			buf.WriteByte(str[i])
+			continue
+		case c == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && strings.HasPrefix(str[i:], targetPatternWildcard):
+			wildcard = true
+			i += len(targetPatternWildcard) - 1
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	return buf.String(), wildcard
+}
+
+func invalidTargetPatternDiag(str string) tfdiags.Diagnostic {
+	return tfdiags.Sourceless(
+		tfdiags.Error,
+		"Invalid target pattern",
+		fmt.Sprintf("The address %q uses the wildcard instance key [*] together with a specific instance key. An address that uses wildcards must use them, or no instance key at all, for each module call and resource.", str),
+	)
+}
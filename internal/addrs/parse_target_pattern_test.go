@@ -0,0 +1,119 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package addrs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTargetPatternStr(t *testing.T) {
+	tests := []struct {
+		Input   string
+		Want    Targetable
+		WantErr string
+	}{
+		{
+			`module.foo["a"].aws_instance.bar`,
+			AbsResource{
+				Module: RootModuleInstance.Child("foo", StringKey("a")),
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "aws_instance",
+					Name: "bar",
+				},
+			},
+			``,
+		},
+		{
+			`module.foo[*]`,
+			Module{"foo"},
+			``,
+		},
+		{
+			`module.foo[*].module.bar`,
+			Module{"foo", "bar"},
+			``,
+		},
+		{
+			`module.foo[*].data.aws_ami.bar[*]`,
+			ConfigResource{
+				Module: Module{"foo"},
+				Resource: Resource{
+					Mode: DataResourceMode,
+					Type: "aws_ami",
+					Name: "bar",
+				},
+			},
+			``,
+		},
+		{
+			`aws_instance.bar[*]`,
+			ConfigResource{
+				Module: RootModule,
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "aws_instance",
+					Name: "bar",
+				},
+			},
+			``,
+		},
+		{
+			`module.foo["[*]"]`,
+			RootModuleInstance.Child("foo", StringKey("[*]")),
+			``,
+		},
+		{
+			`module.foo[*].aws_instance.bar[0]`,
+			nil,
+			`uses the wildcard instance key [*] together with a specific instance key`,
+		},
+		{
+			`module.foo["a"].module.bar[*]`,
+			nil,
+			`uses the wildcard instance key [*] together with a specific instance key`,
+		},
+		{
+			`module.foo[*].`,
+			nil,
+			`Attribute name required`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			got, diags := ParseTargetPatternStr(test.Input)
+			if test.WantErr != "" {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success; want error containing %q", test.WantErr)
+				}
+				if gotErr := diags.Err().Error(); !strings.Contains(gotErr, test.WantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: containing %s", gotErr, test.WantErr)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected error: %s", diags.Err())
+			}
+			if diff := cmp.Diff(test.Want, got.Subject); diff != "" {
+				t.Fatalf("wrong result\n%s", diff)
+			}
+
+			// The pattern string of the result must parse back into the
+			// same address, so that patterns can be saved in plan files.
+			again, diags := ParseTargetPatternStr(TargetPatternString(got.Subject))
+			if diags.HasErrors() {
+				t.Fatalf("unexpected error reparsing %q: %s", TargetPatternString(got.Subject), diags.Err())
+			}
+			if diff := cmp.Diff(got.Subject, again.Subject); diff != "" {
+				t.Fatalf("wrong result after round-trip\n%s", diff)
+			}
+		})
+	}
+}
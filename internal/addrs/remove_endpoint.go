@@ -28,6 +28,13 @@ type RemoveEndpoint struct {
 
 	// the representation of our relative address as a ConfigRemovable
 	RelSubject ConfigRemovable
+
+	// RelInstance is the relative address of the particular module instance,
+	// resource in a module instance, or resource instance that the endpoint
+	// selects, or nil if it selects all instances of RelSubject. It can only
+	// be set by ParseRemoveInstanceEndpoint, and is always either a
+	// ModuleInstance, an AbsResource or an AbsResourceInstance.
+	RelInstance Targetable
 }
 
 // ParseRemoveEndpoint attempts to interpret the given traversal as a
@@ -60,29 +67,94 @@ func ParseRemoveEndpoint(traversal hcl.Traversal) (*RemoveEndpoint, tfdiags.Diag
 		return nil, diags
 	}
 
-	if riAddr.Resource.Mode == DataResourceMode {
+	diags = diags.Append(checkRemovableResourceMode(riAddr.Resource.Mode, traversal))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return &RemoveEndpoint{
+		RelSubject:  riAddr,
+		SourceRange: rng,
+	}, diags
+}
+
+// ParseRemoveInstanceEndpoint is like ParseRemoveEndpoint but also accepts
+// module and resource instance keys, such as in module.a["x"] or
+// aws_instance.b[0], in which case RelInstance of the result is set to the
+// relative address of the selected instance.
+//
+// This is used for "removed" blocks that use for_each, where each.key is
+// typically used to select the instances to remove.
+func ParseRemoveInstanceEndpoint(traversal hcl.Traversal) (*RemoveEndpoint, tfdiags.Diagnostics) {
+	path, remain, diags := parseModuleInstancePrefix(traversal)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	rng := tfdiags.SourceRangeFromHCL(traversal.SourceRange())
+	hasModuleKeys := false
+	for _, step := range path {
+		if step.InstanceKey != NoKey {
+			hasModuleKeys = true
+		}
+	}
+
+	if len(remain) == 0 {
+		ret := &RemoveEndpoint{
+			RelSubject:  path.Module(),
+			SourceRange: rng,
+		}
+		if hasModuleKeys {
+			ret.RelInstance = path
+		}
+		return ret, diags
+	}
+
+	riAddr, moreDiags := parseResourceInstanceUnderModule(path, remain, false)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	diags = diags.Append(checkRemovableResourceMode(riAddr.Resource.Resource.Mode, traversal))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ret := &RemoveEndpoint{
+		RelSubject:  riAddr.ConfigResource(),
+		SourceRange: rng,
+	}
+	switch {
+	case riAddr.Resource.Key != NoKey:
+		ret.RelInstance = riAddr
+	case hasModuleKeys:
+		ret.RelInstance = riAddr.ContainingResource()
+	}
+	return ret, diags
+}
+
+// checkRemovableResourceMode returns an error if resources of the given mode
+// can't be the target of a "removed" block.
+func checkRemovableResourceMode(mode ResourceMode, traversal hcl.Traversal) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if mode == DataResourceMode {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Data source address is not allowed",
 			Detail:   "Data sources cannot be destroyed, and therefore, 'removed' blocks are not allowed to target them. To remove data sources from the state, you should remove the data source block from the configuration.",
 			Subject:  traversal.SourceRange().Ptr(),
 		})
-
-		return nil, diags
 	}
-	if riAddr.Resource.Mode == EphemeralResourceMode {
+	if mode == EphemeralResourceMode {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Ephemeral resource address is not allowed",
 			Detail:   "Ephemeral resources cannot be destroyed, and therefore, 'removed' blocks are not allowed to target them. To remove ephemeral resources from the state, you should remove the ephemeral resource block from the configuration.",
 			Subject:  traversal.SourceRange().Ptr(),
 		})
-
-		return nil, diags
 	}
 
-	return &RemoveEndpoint{
-		RelSubject:  riAddr,
-		SourceRange: rng,
-	}, diags
+	return diags
 }
@@ -257,3 +257,126 @@ func TestParseRemoveEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRemoveInstanceEndpoint(t *testing.T) {
+	tests := []struct {
+		Input        string
+		WantRel      ConfigRemovable
+		WantInstance Targetable
+		WantErr      string
+	}{
+		{
+			`foo.bar`,
+			ConfigResource{
+				Module: RootModule,
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "foo",
+					Name: "bar",
+				},
+			},
+			nil,
+			``,
+		},
+		{
+			`foo.bar["a"]`,
+			ConfigResource{
+				Module: RootModule,
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "foo",
+					Name: "bar",
+				},
+			},
+			AbsResourceInstance{
+				Module: RootModuleInstance,
+				Resource: ResourceInstance{
+					Resource: Resource{
+						Mode: ManagedResourceMode,
+						Type: "foo",
+						Name: "bar",
+					},
+					Key: StringKey("a"),
+				},
+			},
+			``,
+		},
+		{
+			`module.boop[0].foo.bar`,
+			ConfigResource{
+				Module: Module{"boop"},
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "foo",
+					Name: "bar",
+				},
+			},
+			AbsResource{
+				Module: ModuleInstance{
+					ModuleInstanceStep{Name: "boop", InstanceKey: IntKey(0)},
+				},
+				Resource: Resource{
+					Mode: ManagedResourceMode,
+					Type: "foo",
+					Name: "bar",
+				},
+			},
+			``,
+		},
+		{
+			`module.boop["x"]`,
+			Module{"boop"},
+			ModuleInstance{
+				ModuleInstanceStep{Name: "boop", InstanceKey: StringKey("x")},
+			},
+			``,
+		},
+		{
+			`module.boop`,
+			Module{"boop"},
+			nil,
+			``,
+		},
+		{
+			`data.foo.bar["a"]`,
+			nil,
+			nil,
+			`Data source address is not allowed: Data sources cannot be destroyed, and therefore, 'removed' blocks are not allowed to target them. To remove data sources from the state, you should remove the data source block from the configuration.`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(test.Input), "", hcl.InitialPos)
+			if hclDiags.HasErrors() {
+				// We're not trying to test the HCL parser here, so any
+				// failures at this point are likely to be bugs in the
+				// test case itself.
+				t.Fatalf("syntax error: %s", hclDiags.Error())
+			}
+
+			removeEp, diags := ParseRemoveInstanceEndpoint(traversal)
+
+			switch {
+			case test.WantErr != "":
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success\nwant error: %s", test.WantErr)
+				}
+				gotErr := diags.Err().Error()
+				if gotErr != test.WantErr {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", gotErr, test.WantErr)
+				}
+			default:
+				if diags.HasErrors() {
+					t.Fatalf("unexpected error: %s", diags.Err().Error())
+				}
+				if diff := cmp.Diff(test.WantRel, removeEp.RelSubject); diff != "" {
+					t.Errorf("wrong subject\n%s", diff)
+				}
+				if diff := cmp.Diff(test.WantInstance, removeEp.RelInstance); diff != "" {
+					t.Errorf("wrong instance\n%s", diff)
+				}
+			}
+		})
+	}
+}
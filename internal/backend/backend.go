@@ -300,6 +300,12 @@ type Operation struct {
 	Targets      []addrs.Targetable
 	Excludes     []addrs.Targetable
 	ForceReplace []addrs.AbsResourceInstance
+	// Profile selects a profile from the root module, whose targets and
+	// excludes are added to Targets and Excludes. See configs.Profile.
+	Profile string
+	// AllowDeferral allows deferring resources whose instances can't be
+	// determined during planning. See tofu.PlanOpts.AllowDeferral.
+	AllowDeferral bool
 	// Injected by the command creating the operation (plan/apply/refresh/etc...)
 	Variables map[string]UnparsedVariableValue
 	RootCall  configs.StaticModuleCall
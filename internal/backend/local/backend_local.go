@@ -10,11 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/zclconf/go-cty/cty"
 
+	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/backend"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configload"
@@ -203,14 +205,22 @@ func (b *Local) localRunDirect(ctx context.Context, stopCtx context.Context, op
 		return nil, nil, diags
 	}
 
+	targets, excludes, profileDiags := profileTargetsAndExcludes(op, config)
+	diags = diags.Append(profileDiags)
+	if profileDiags.HasErrors() {
+		return nil, nil, diags
+	}
+
 	planOpts := &tofu.PlanOpts{
 		Mode:               op.PlanMode,
-		Targets:            op.Targets,
-		Excludes:           op.Excludes,
+		Targets:            targets,
+		Excludes:           excludes,
+		Profile:            op.Profile,
 		ForceReplace:       op.ForceReplace,
 		SetVariables:       variables,
 		SkipRefresh:        op.Type != backend.OperationTypeRefresh && !op.PlanRefresh,
 		GenerateConfigPath: op.GenerateConfigOut,
+		AllowDeferral:      op.AllowDeferral,
 	}
 	run.PlanOpts = planOpts
 
@@ -282,6 +292,21 @@ func (b *Local) localRunForPlanFile(ctx context.Context, op *backend.Operation,
 	// we need to apply the plan.
 	run.Plan = plan
 
+	if op.Profile != "" && op.Profile != plan.Profile {
+		var detail string
+		if plan.Profile == "" {
+			detail = fmt.Sprintf("The given plan file was created without a profile, but this operation selects profile %q.", op.Profile)
+		} else {
+			detail = fmt.Sprintf("The given plan file was created using profile %q, but this operation selects profile %q.", plan.Profile, op.Profile)
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Saved plan profile mismatch",
+			detail+" Create a new plan using the intended profile.",
+		))
+		return nil, snap, diags
+	}
+
 	subCall := op.RootCall.WithVariables(plan.VariableMapper())
 
 	loader := configload.NewLoaderFromSnapshot(snap)
@@ -409,6 +434,57 @@ func (b *Local) localRunForPlanFile(ctx context.Context, op *backend.Operation,
 	return run, snap, diags
 }
 
+// profileTargetsAndExcludes returns the targets and excludes for the given
+// operation, adding those of the profile it selects from the root module of
+// the given configuration, if any.
+func profileTargetsAndExcludes(op *backend.Operation, config *configs.Config) ([]addrs.Targetable, []addrs.Targetable, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if op.Profile == "" {
+		return op.Targets, op.Excludes, diags
+	}
+
+	profile, exists := config.Module.Profiles[op.Profile]
+	if !exists {
+		var suggestion string
+		if len(config.Module.Profiles) == 0 {
+			suggestion = "The root module does not declare any profiles."
+		} else {
+			names := make([]string, 0, len(config.Module.Profiles))
+			for name := range config.Module.Profiles {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			suggestion = fmt.Sprintf("The root module declares the following profiles: %s.", strings.Join(names, ", "))
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unknown profile",
+			fmt.Sprintf("There is no profile named %q in the root module's \"terraform\" block. %s", op.Profile, suggestion),
+		))
+		return nil, nil, diags
+	}
+
+	targets := append(slices.Clone(op.Targets), profile.Targets...)
+	excludes := append(slices.Clone(op.Excludes), profile.Excludes...)
+	if len(targets) != 0 && len(excludes) != 0 {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid combination of arguments",
+			fmt.Sprintf("The target and exclude planning options are mutually-exclusive, and profile %q uses %s. Each plan must use either only the target options or only the exclude options.", profile.Name, profileKind(profile)),
+		))
+		return nil, nil, diags
+	}
+	return targets, excludes, diags
+}
+
+func profileKind(profile *configs.Profile) string {
+	if len(profile.Targets) != 0 {
+		return "targets"
+	}
+	return "excludes"
+}
+
 // interactiveCollectVariables attempts to complete the given existing
 // map of variables by interactively prompting for any variables that are
 // declared as required but not yet present.
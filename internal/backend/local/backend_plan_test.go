@@ -9,9 +9,11 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
@@ -705,6 +707,80 @@ func TestLocal_planOutPathNoChange(t *testing.T) {
 	}
 }
 
+func TestLocal_planProfile(t *testing.T) {
+	b := TestLocal(t)
+	TestLocalProvider(t, b, "test", planFixtureSchema())
+
+	outDir := t.TempDir()
+	planPath := filepath.Join(outDir, "plan.tfplan")
+
+	op, done := testOperationPlan(t, "./testdata/plan-profile")
+	op.PlanRefresh = true
+	op.PlanOutPath = planPath
+	op.Profile = "only_bar"
+	cfg := cty.ObjectVal(map[string]cty.Value{
+		"path": cty.StringVal(b.StatePath),
+	})
+	cfgRaw, err := plans.NewDynamicValue(cfg, cfg.Type())
+	if err != nil {
+		t.Fatal(err)
+	}
+	op.PlanOutBackend = &plans.Backend{
+		// Just a placeholder so that we can generate a valid plan file.
+		Type:   "local",
+		Config: cfgRaw,
+	}
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result != backend.OperationSuccess {
+		t.Fatalf("plan operation failed:\n%s", done(t).Stderr())
+	}
+
+	plan := testReadPlan(t, planPath)
+	if got, want := plan.Profile, "only_bar"; got != want {
+		t.Errorf("wrong profile in plan %q; want %q", got, want)
+	}
+	var got []string
+	for _, r := range plan.Changes.Resources {
+		got = append(got, r.Addr.String())
+	}
+	sort.Strings(got)
+	want := []string{"test_instance.bar[0]", "test_instance.bar[1]"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong planned resources\n%s", diff)
+	}
+
+	done(t)
+}
+
+func TestLocal_planUnknownProfile(t *testing.T) {
+	b := TestLocal(t)
+	TestLocalProvider(t, b, "test", planFixtureSchema())
+
+	op, done := testOperationPlan(t, "./testdata/plan-profile")
+	op.Profile = "nonexistent"
+
+	run, err := b.Operation(context.Background(), op)
+	if err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	<-run.Done()
+	if run.Result != backend.OperationFailure {
+		t.Fatalf("plan operation succeeded")
+	}
+
+	// the backend should be unlocked after a run
+	assertBackendStateUnlocked(t, b)
+
+	if got, want := done(t).Stderr(), "The root module declares the following profiles: only_bar."; !strings.Contains(got, want) {
+		t.Fatalf("unexpected error output:\n%s\nwant: %s", got, want)
+	}
+}
+
 func testOperationPlan(t *testing.T, configDir string) (*backend.Operation, func(*testing.T) *terminal.TestOutput) {
 	t.Helper()
 
@@ -0,0 +1,15 @@
+terraform {
+  profile "only_bar" {
+    targets = ["test_instance.bar[*]"]
+  }
+}
+
+resource "test_instance" "foo" {
+  ami = "foo"
+}
+
+resource "test_instance" "bar" {
+  count = 2
+
+  ami = "bar"
+}
@@ -66,6 +66,24 @@ func (b *Remote) opApply(ctx context.Context, stopCtx, cancelCtx context.Context
 		))
 	}
 
+	if op.AllowDeferral {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Deferring resources is not currently supported",
+			`The "remote" backend does not currently support deferring resources `+
+				`with unknown instances as part of a plan.`,
+		))
+	}
+
+	if op.Profile != "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Profiles are not currently supported",
+			`The "remote" backend does not currently support selecting a profile `+
+				`as part of a plan.`,
+		))
+	}
+
 	if b.hasExplicitVariableValues(ctx, op) {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Error,
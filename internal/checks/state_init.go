@@ -43,16 +43,20 @@ func collectInitialStatuses(into addrs.Map[addrs.ConfigCheckable, *configCheckab
 	for _, oc := range cfg.Module.Outputs {
 		addr := oc.Addr().InModule(moduleAddr)
 
-		ct := len(oc.Preconditions)
-		if ct == 0 {
+		if (len(oc.Preconditions) + len(oc.Validations)) == 0 {
 			// We just ignore output values that don't declare any checks.
 			continue
 		}
 
-		st := &configCheckableState{}
+		st := &configCheckableState{
+			checkTypes: make(map[addrs.CheckRuleType]int),
+		}
 
-		st.checkTypes = map[addrs.CheckRuleType]int{
-			addrs.OutputPrecondition: ct,
+		if ct := len(oc.Preconditions); ct > 0 {
+			st.checkTypes[addrs.OutputPrecondition] = ct
+		}
+		if ct := len(oc.Validations); ct > 0 {
+			st.checkTypes[addrs.OutputValidation] = ct
 		}
 
 		into.Put(addr, st)
@@ -274,6 +274,8 @@ func (c *ApplyCommand) OperationRequest(
 	opReq.Targets = applyArgs.Operation.Targets
 	opReq.Excludes = applyArgs.Operation.Excludes
 	opReq.ForceReplace = applyArgs.Operation.ForceReplace
+	opReq.AllowDeferral = applyArgs.Operation.AllowDeferral
+	opReq.Profile = applyArgs.Operation.Profile
 	opReq.Type = backend.OperationTypeApply
 	opReq.View = view.Operation()
 
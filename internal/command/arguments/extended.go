@@ -129,6 +129,17 @@ type Operation struct {
 	// a module all at once. We could potentially loosen this later if we
 	// learn a use-case for broader matching.
 	ForceReplace []addrs.AbsResourceInstance
+
+	// Profile is the name of a profile declared in the root module's
+	// "terraform" block, whose targets and excludes are added to Targets
+	// and Excludes once the configuration is loaded.
+	Profile string
+
+	// AllowDeferral allows OpenTofu to defer planning any resource or module
+	// call whose count or for_each is not known yet, along with everything
+	// that depends on it, instead of failing the plan. The deferred resources
+	// are then planned in a subsequent round once their expansion is known.
+	AllowDeferral bool
 }
 
 // parseDirectTargetables gets a list of strings passed from directly from the CLI
@@ -284,6 +295,11 @@ func BindOperation(cli *CommandLine) *Operation {
 	cli.StringArrayVar(&forceReplaceRaw, "replace", nil,
 		`Force replacement of a particular resource instance using its resource address. If the plan would've otherwise produced an update or no-op action for this instance, OpenTofu will plan to replace it instead. You can use this option multiple times to replace more than one object.`,
 	).SetDisplay("=resource")
+	cli.StringVar(&o.Profile, "profile", "",
+		`Limit the planning operation using the targets or excludes of the given profile, declared in a "profile" block in the root module's "terraform" block. When applying a saved plan, verify that the plan was created using the given profile.`,
+	).SetDisplay("=name")
+	cli.BoolVar(&o.AllowDeferral, "allow-deferral", false,
+		`Defer planning of resources and module calls whose count or for_each depends on values that will not be known until apply, and of everything that depends on them, instead of returning an error. Run another plan and apply after this one to converge the deferred resources.`)
 
 	// This processes the raw target flags into addrs.Targetable values, returning diagnostics if invalid.
 	cli.PreHook(func() tfdiags.Diagnostics {
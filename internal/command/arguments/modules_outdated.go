@@ -0,0 +1,38 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ModulesOutdated represents the command-line arguments for the 'modules outdated' command.
+type ModulesOutdated struct {
+	// View represents the global view options
+	View *View
+	// Vars holds and provides information for the flags related to variables that a user can give into the process
+	Vars *Vars
+}
+
+// BindModulesOutdated registers CLI arguments, returning a ModulesOutdated value and it's corresponding hooks.
+func BindModulesOutdated(cli *CommandLine) *ModulesOutdated {
+	arguments := ModulesOutdated{
+		View: BindView(cli, viewFlagJson),
+		Vars: BindVars(cli),
+	}
+
+	return &arguments
+}
+
+// ParseModulesOutdated processes CLI arguments, returning a ModulesOutdated value, a closer function, and errors.
+// If errors are encountered, a ModulesOutdated value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseModulesOutdated(args []string) (*ModulesOutdated, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	arguments := BindModulesOutdated(cli)
+	closer, diags := cli.parseWithHooks("modules outdated", args)
+	return arguments, closer, diags
+}
@@ -0,0 +1,73 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseModulesOutdated_basicValidation(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *ModulesOutdated
+		wantErrText string
+	}{
+		"defaults": {
+			args: nil,
+			want: modulesOutdatedArgsWithDefaults(nil),
+		},
+		"json": {
+			args: []string{"-json"},
+			want: modulesOutdatedArgsWithDefaults(func(v *ModulesOutdated) {
+				v.View.ViewType = ViewJSON
+			}),
+		},
+		"unexpected argument": {
+			args:        []string{"network"},
+			want:        modulesOutdatedArgsWithDefaults(nil),
+			wantErrText: "Too many command line arguments",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseModulesOutdated(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func modulesOutdatedArgsWithDefaults(mutate func(v *ModulesOutdated)) *ModulesOutdated {
+	ret := &ModulesOutdated{
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+		Vars: &Vars{},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ModulesPush represents the command-line arguments for the 'modules push' command.
+type ModulesPush struct {
+	// Directory is the directory containing the module package to publish.
+	Directory string
+
+	// Address is the oci:// URL of the repository to publish to, optionally
+	// including the tag to publish as.
+	Address string
+
+	// OCIPush holds the options shared with the other commands that publish
+	// to OCI registries.
+	OCIPush *OCIPush
+
+	// View represents the global view options
+	View *View
+}
+
+// BindModulesPush registers CLI arguments, returning a ModulesPush value and it's corresponding hooks.
+func BindModulesPush(cli *CommandLine) *ModulesPush {
+	push := ModulesPush{
+		View:    BindView(cli, viewFlagNone),
+		OCIPush: BindOCIPush(cli),
+	}
+
+	cli.ArgHelp = "The modules push command requires a module directory and an oci:// repository address as command-line arguments."
+	cli.PositionalArg(&push.Directory, "module-dir", false)
+	cli.PositionalArg(&push.Address, "address", false)
+
+	return &push
+}
+
+// ParseModulesPush processes CLI arguments, returning a ModulesPush value, a closer function, and errors.
+// If errors are encountered, a ModulesPush value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseModulesPush(args []string) (*ModulesPush, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	push := BindModulesPush(cli)
+	closer, diags := cli.parseWithHooks("modules push", args)
+	return push, closer, diags
+}
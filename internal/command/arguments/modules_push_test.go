@@ -0,0 +1,91 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseModulesPush(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *ModulesPush
+		wantErrText string
+	}{
+		"defaults": {
+			args: []string{"network", "oci://example.com/network?tag=v1.0.0"},
+			want: modulesPushArgsWithDefaults(nil),
+		},
+		"signed with annotations": {
+			args: []string{
+				"-signing-key=cosign.key",
+				"-annotation=org.opencontainers.image.source=https://example.com/network",
+				"-annotation=org.opencontainers.image.description=Networking a=b",
+				"network", "oci://example.com/network?tag=v1.0.0",
+			},
+			want: modulesPushArgsWithDefaults(func(v *ModulesPush) {
+				v.OCIPush.SigningKeyFile = "cosign.key"
+				v.OCIPush.Annotations = map[string]string{
+					"org.opencontainers.image.source":      "https://example.com/network",
+					"org.opencontainers.image.description": "Networking a=b",
+				}
+			}),
+		},
+		"invalid annotation": {
+			args:        []string{"-annotation=nope", "network", "oci://example.com/network?tag=v1.0.0"},
+			want:        modulesPushArgsWithDefaults(nil),
+			wantErrText: `The -annotation option value "nope" must be a key and a value separated by an equals sign`,
+		},
+		"no address": {
+			args: []string{"network"},
+			want: modulesPushArgsWithDefaults(func(v *ModulesPush) {
+				v.Address = ""
+			}),
+			wantErrText: "The modules push command requires a module directory and an oci:// repository address as command-line arguments.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseModulesPush(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func modulesPushArgsWithDefaults(mutate func(v *ModulesPush)) *ModulesPush {
+	ret := &ModulesPush{
+		Directory: "network",
+		Address:   "oci://example.com/network?tag=v1.0.0",
+		OCIPush:   &OCIPush{},
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
@@ -0,0 +1,38 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ModulesTree represents the command-line arguments for the 'modules tree' command.
+type ModulesTree struct {
+	// View represents the global view options
+	View *View
+	// Vars holds and provides information for the flags related to variables that a user can give into the process
+	Vars *Vars
+}
+
+// BindModulesTree registers CLI arguments, returning a ModulesTree value and it's corresponding hooks.
+func BindModulesTree(cli *CommandLine) *ModulesTree {
+	arguments := ModulesTree{
+		View: BindView(cli, viewFlagJson),
+		Vars: BindVars(cli),
+	}
+
+	return &arguments
+}
+
+// ParseModulesTree processes CLI arguments, returning a ModulesTree value, a closer function, and errors.
+// If errors are encountered, a ModulesTree value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseModulesTree(args []string) (*ModulesTree, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	arguments := BindModulesTree(cli)
+	closer, diags := cli.parseWithHooks("modules tree", args)
+	return arguments, closer, diags
+}
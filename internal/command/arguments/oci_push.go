@@ -0,0 +1,57 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// OCIPush represents the command-line options shared by the commands that
+// publish packages to OCI registries.
+type OCIPush struct {
+	// SigningKeyFile is the path of the PEM-encoded private key used to
+	// sign the published manifest, if any.
+	SigningKeyFile string
+
+	// Annotations are the additional annotations for the published manifest.
+	Annotations map[string]string
+}
+
+// BindOCIPush registers the CLI options shared by the commands that publish
+// packages to OCI registries, returning an OCIPush value that is populated
+// once the command line has been parsed.
+func BindOCIPush(cli *CommandLine) *OCIPush {
+	push := OCIPush{}
+
+	var rawAnnotations []string
+	cli.StringVar(&push.SigningKeyFile, "signing-key", "", "Path of a PEM-encoded private key to sign the published manifest with, such as one created by \"cosign generate-key-pair\". If the key is encrypted, set its password in the TF_OCI_SIGNING_KEY_PASSPHRASE environment variable.").SetDisplay("=path")
+	cli.StringArrayVar(&rawAnnotations, "annotation", nil, "Add an annotation to the published manifest. Use this option multiple times to add more than one annotation.").SetDisplay("=key=value")
+
+	cli.PreHook(func() tfdiags.Diagnostics {
+		var diags tfdiags.Diagnostics
+		for _, raw := range rawAnnotations {
+			key, value, ok := strings.Cut(raw, "=")
+			if !ok || key == "" {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Invalid annotation",
+					fmt.Sprintf("The -annotation option value %q must be a key and a value separated by an equals sign, like -annotation=org.opencontainers.image.source=https://example.com/.", raw),
+				))
+				continue
+			}
+			if push.Annotations == nil {
+				push.Annotations = make(map[string]string)
+			}
+			push.Annotations[key] = value
+		}
+		return diags
+	})
+
+	return &push
+}
@@ -60,6 +60,46 @@ func TestParsePlan_basicValid(t *testing.T) {
 				},
 			},
 		},
+		"allow deferral": {
+			[]string{"-allow-deferral"},
+			&Plan{
+				DetailedExitCode: false,
+				View: &View{
+					ConsolidateWarnings: true,
+					InputEnabled:        true,
+					ViewType:            ViewHuman,
+				},
+				OutPath: "",
+				State:   &State{Lock: true},
+				Vars:    &Vars{},
+				Operation: &Operation{
+					PlanMode:      plans.NormalMode,
+					Parallelism:   10,
+					Refresh:       true,
+					AllowDeferral: true,
+				},
+			},
+		},
+		"profile": {
+			[]string{"-profile=networking"},
+			&Plan{
+				DetailedExitCode: false,
+				View: &View{
+					ConsolidateWarnings: true,
+					InputEnabled:        true,
+					ViewType:            ViewHuman,
+				},
+				OutPath: "",
+				State:   &State{Lock: true},
+				Vars:    &Vars{},
+				Operation: &Operation{
+					PlanMode:    plans.NormalMode,
+					Parallelism: 10,
+					Refresh:     true,
+					Profile:     "networking",
+				},
+			},
+		},
 		"JSON view disables input": {
 			[]string{"-json"},
 			&Plan{
@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersConformance represents the command-line arguments for the 'providers conformance' command.
+type ProvidersConformance struct {
+	// Executable is the path of the provider plugin executable to check.
+	Executable string
+
+	// ResourceTypes limits the checks to the given managed resource types,
+	// or all of them if empty.
+	ResourceTypes []string
+
+	// Apply enables the checks that create and destroy real objects.
+	Apply bool
+
+	// View represents the global view options
+	View *View
+}
+
+// BindProvidersConformance registers CLI arguments, returning a ProvidersConformance value and it's corresponding hooks.
+func BindProvidersConformance(cli *CommandLine) *ProvidersConformance {
+	arguments := ProvidersConformance{
+		View: BindView(cli, viewFlagJson),
+	}
+
+	cli.StringArrayVar(&arguments.ResourceTypes, "resource", nil, "Limit the checks to the given managed resource type. Use this option more than once to check more than one resource type.")
+	cli.BoolVar(&arguments.Apply, "apply", false, "Also create, read, import and destroy a real object of each resource type.")
+
+	cli.ArgHelp = "The providers conformance command requires the path of a provider plugin executable as its only argument."
+	cli.PositionalArg(&arguments.Executable, "executable", false)
+
+	return &arguments
+}
+
+// ParseProvidersConformance processes CLI arguments, returning a ProvidersConformance value, a closer function, and errors.
+// If errors are encountered, a ProvidersConformance value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseProvidersConformance(args []string) (*ProvidersConformance, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	arguments := BindProvidersConformance(cli)
+	closer, diags := cli.parseWithHooks("providers conformance", args)
+	return arguments, closer, diags
+}
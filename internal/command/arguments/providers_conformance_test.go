@@ -0,0 +1,88 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProvidersConformance(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *ProvidersConformance
+		wantErrText string
+	}{
+		"defaults": {
+			args: []string{"./terraform-provider-example"},
+			want: providersConformanceArgsWithDefaults(nil),
+		},
+		"all options": {
+			args: []string{
+				"-json",
+				"-apply",
+				"-resource=example_thing",
+				"-resource=example_other",
+				"./terraform-provider-example",
+			},
+			want: providersConformanceArgsWithDefaults(func(v *ProvidersConformance) {
+				v.View.ViewType = ViewJSON
+				v.Apply = true
+				v.ResourceTypes = []string{"example_thing", "example_other"}
+			}),
+		},
+		"no arguments": {
+			args: []string{},
+			want: providersConformanceArgsWithDefaults(func(v *ProvidersConformance) {
+				v.Executable = ""
+			}),
+			wantErrText: "The providers conformance command requires the path of a provider plugin executable as its only argument.",
+		},
+		"too many arguments": {
+			args:        []string{"./terraform-provider-example", "./terraform-provider-other"},
+			want:        providersConformanceArgsWithDefaults(nil),
+			wantErrText: "The providers conformance command requires the path of a provider plugin executable as its only argument.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseProvidersConformance(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func providersConformanceArgsWithDefaults(mutate func(v *ProvidersConformance)) *ProvidersConformance {
+	ret := &ProvidersConformance{
+		Executable: "./terraform-provider-example",
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
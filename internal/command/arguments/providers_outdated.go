@@ -0,0 +1,38 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersOutdated represents the command-line arguments for the 'providers outdated' command.
+type ProvidersOutdated struct {
+	// View represents the global view options
+	View *View
+	// Vars holds and provides information for the flags related to variables that a user can give into the process
+	Vars *Vars
+}
+
+// BindProvidersOutdated registers CLI arguments, returning a ProvidersOutdated value and it's corresponding hooks.
+func BindProvidersOutdated(cli *CommandLine) *ProvidersOutdated {
+	arguments := ProvidersOutdated{
+		View: BindView(cli, viewFlagJson),
+		Vars: BindVars(cli),
+	}
+
+	return &arguments
+}
+
+// ParseProvidersOutdated processes CLI arguments, returning a ProvidersOutdated value, a closer function, and errors.
+// If errors are encountered, a ProvidersOutdated value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseProvidersOutdated(args []string) (*ProvidersOutdated, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	arguments := BindProvidersOutdated(cli)
+	closer, diags := cli.parseWithHooks("providers outdated", args)
+	return arguments, closer, diags
+}
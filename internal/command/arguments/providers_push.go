@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersPush represents the command-line arguments for the 'providers push' command.
+type ProvidersPush struct {
+	// Directory is the directory containing the provider packages to publish.
+	Directory string
+
+	// Address is the oci:// URL of the repository to publish to. The tag is
+	// always the version number of the provider, so it cannot be included.
+	Address string
+
+	// OCIPush holds the options shared with the other commands that publish
+	// to OCI registries.
+	OCIPush *OCIPush
+
+	// View represents the global view options
+	View *View
+}
+
+// BindProvidersPush registers CLI arguments, returning a ProvidersPush value and it's corresponding hooks.
+func BindProvidersPush(cli *CommandLine) *ProvidersPush {
+	push := ProvidersPush{
+		View:    BindView(cli, viewFlagNone),
+		OCIPush: BindOCIPush(cli),
+	}
+
+	cli.ArgHelp = "The providers push command requires a directory of provider packages and an oci:// repository address as command-line arguments."
+	cli.PositionalArg(&push.Directory, "packages-dir", false)
+	cli.PositionalArg(&push.Address, "address", false)
+
+	return &push
+}
+
+// ParseProvidersPush processes CLI arguments, returning a ProvidersPush value, a closer function, and errors.
+// If errors are encountered, a ProvidersPush value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseProvidersPush(args []string) (*ProvidersPush, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	push := BindProvidersPush(cli)
+	closer, diags := cli.parseWithHooks("providers push", args)
+	return push, closer, diags
+}
@@ -0,0 +1,90 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProvidersPush(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *ProvidersPush
+		wantErrText string
+	}{
+		"defaults": {
+			args: []string{"dist", "oci://example.com/providers/random"},
+			want: providersPushArgsWithDefaults(nil),
+		},
+		"signed with annotations": {
+			args: []string{
+				"-signing-key=cosign.key",
+				"-annotation=org.opencontainers.image.source=https://example.com/random",
+				"dist", "oci://example.com/providers/random",
+			},
+			want: providersPushArgsWithDefaults(func(v *ProvidersPush) {
+				v.OCIPush.SigningKeyFile = "cosign.key"
+				v.OCIPush.Annotations = map[string]string{
+					"org.opencontainers.image.source": "https://example.com/random",
+				}
+			}),
+		},
+		"empty annotation key": {
+			args:        []string{"-annotation==value", "dist", "oci://example.com/providers/random"},
+			want:        providersPushArgsWithDefaults(nil),
+			wantErrText: `The -annotation option value "=value" must be a key and a value separated by an equals sign`,
+		},
+		"no arguments": {
+			args: []string{},
+			want: providersPushArgsWithDefaults(func(v *ProvidersPush) {
+				v.Directory = ""
+				v.Address = ""
+			}),
+			wantErrText: "The providers push command requires a directory of provider packages and an oci:// repository address as command-line arguments.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseProvidersPush(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func providersPushArgsWithDefaults(mutate func(v *ProvidersPush)) *ProvidersPush {
+	ret := &ProvidersPush{
+		Directory: "dist",
+		Address:   "oci://example.com/providers/random",
+		OCIPush:   &OCIPush{},
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
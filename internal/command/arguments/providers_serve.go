@@ -0,0 +1,72 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"time"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersServe represents the command-line arguments for the 'providers serve' command.
+type ProvidersServe struct {
+	// SocketPath is the path of the unix socket where the daemon listens
+	// for requests. If empty, the command chooses a path itself.
+	SocketPath string
+
+	// IdleTimeout is how long the daemon waits without being used before it
+	// shuts down.
+	IdleTimeout time.Duration
+
+	// Warm is the number of spare processes to keep running for each
+	// provider the daemon has been asked for.
+	Warm int
+
+	// View represents the global view options
+	View *View
+}
+
+// BindProvidersServe registers CLI arguments, returning a ProvidersServe value and it's corresponding hooks.
+func BindProvidersServe(cli *CommandLine) *ProvidersServe {
+	serve := ProvidersServe{
+		View: BindView(cli, viewFlagNone),
+	}
+
+	cli.StringVar(&serve.SocketPath, "socket", "", "Path of the unix socket to listen on. By default OpenTofu creates a socket in the system's temporary directory.").SetDisplay("=path")
+	cli.DurationVar(&serve.IdleTimeout, "idle-timeout", 15*time.Minute, "Shut down after no command has used the daemon for this long. Defaults to 15m.").SetDisplay("=15m")
+	cli.IntVar(&serve.Warm, "warm", 1, "Number of spare processes to keep running for each provider, so that they are ready for the next command. Defaults to 1.").SetDisplay("=n")
+
+	cli.PreHook(func() tfdiags.Diagnostics {
+		var diags tfdiags.Diagnostics
+		if serve.IdleTimeout <= 0 {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid idle timeout",
+				"The -idle-timeout option must be a positive duration, such as \"15m\".",
+			))
+		}
+		if serve.Warm < 0 {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid number of warm processes",
+				"The -warm option must not be negative.",
+			))
+		}
+		return diags
+	})
+
+	return &serve
+}
+
+// ParseProvidersServe processes CLI arguments, returning a ProvidersServe value, a closer function, and errors.
+// If errors are encountered, a ProvidersServe value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseProvidersServe(args []string) (*ProvidersServe, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	serve := BindProvidersServe(cli)
+	closer, diags := cli.parseWithHooks("providers serve", args)
+	return serve, closer, diags
+}
@@ -0,0 +1,98 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProvidersServe(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *ProvidersServe
+		wantErrText string
+	}{
+		"defaults": {
+			args: nil,
+			want: providersServeArgsWithDefaults(nil),
+		},
+		"all options": {
+			args: []string{"-socket=/tmp/tofu.sock", "-idle-timeout=1h", "-warm=3"},
+			want: providersServeArgsWithDefaults(func(v *ProvidersServe) {
+				v.SocketPath = "/tmp/tofu.sock"
+				v.IdleTimeout = time.Hour
+				v.Warm = 3
+			}),
+		},
+		"no warm processes": {
+			args: []string{"-warm=0"},
+			want: providersServeArgsWithDefaults(func(v *ProvidersServe) {
+				v.Warm = 0
+			}),
+		},
+		"zero idle timeout": {
+			args: []string{"-idle-timeout=0s"},
+			want: providersServeArgsWithDefaults(func(v *ProvidersServe) {
+				v.IdleTimeout = 0
+			}),
+			wantErrText: "The -idle-timeout option must be a positive duration",
+		},
+		"negative warm": {
+			args: []string{"-warm=-1"},
+			want: providersServeArgsWithDefaults(func(v *ProvidersServe) {
+				v.Warm = -1
+			}),
+			wantErrText: "The -warm option must not be negative.",
+		},
+		"unexpected argument": {
+			args:        []string{"foo"},
+			want:        providersServeArgsWithDefaults(nil),
+			wantErrText: "Too many command line arguments",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseProvidersServe(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func providersServeArgsWithDefaults(mutate func(v *ProvidersServe)) *ProvidersServe {
+	ret := &ProvidersServe{
+		SocketPath:  "",
+		IdleTimeout: 15 * time.Minute,
+		Warm:        1,
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
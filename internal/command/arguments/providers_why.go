@@ -0,0 +1,45 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ProvidersWhy represents the command-line arguments for the 'providers why' command.
+type ProvidersWhy struct {
+	// Provider is the source address of the provider to explain the version
+	// selection for, or empty to explain it for all providers.
+	Provider string
+
+	// View represents the global view options
+	View *View
+	// Vars holds and provides information for the flags related to variables that a user can give into the process
+	Vars *Vars
+}
+
+// BindProvidersWhy registers CLI arguments, returning a ProvidersWhy value and it's corresponding hooks.
+func BindProvidersWhy(cli *CommandLine) *ProvidersWhy {
+	arguments := ProvidersWhy{
+		View: BindView(cli, viewFlagNone),
+		Vars: BindVars(cli),
+	}
+
+	cli.ArgHelp = "The providers why command expects at most one argument with the source address of a provider."
+	cli.PositionalArg(&arguments.Provider, "provider", true)
+
+	return &arguments
+}
+
+// ParseProvidersWhy processes CLI arguments, returning a ProvidersWhy value, a closer function, and errors.
+// If errors are encountered, a ProvidersWhy value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseProvidersWhy(args []string) (*ProvidersWhy, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	arguments := BindProvidersWhy(cli)
+	closer, diags := cli.parseWithHooks("providers why", args)
+	return arguments, closer, diags
+}
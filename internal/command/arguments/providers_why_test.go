@@ -0,0 +1,80 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProvidersWhy_basicValidation(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *ProvidersWhy
+		wantErrText string
+	}{
+		"all providers": {
+			args: nil,
+			want: providersWhyArgsWithDefaults(nil),
+		},
+		"single provider": {
+			args: []string{"hashicorp/aws"},
+			want: providersWhyArgsWithDefaults(func(v *ProvidersWhy) {
+				v.Provider = "hashicorp/aws"
+			}),
+		},
+		"too many arguments": {
+			args: []string{"hashicorp/aws", "hashicorp/google"},
+			want: providersWhyArgsWithDefaults(func(v *ProvidersWhy) {
+				v.Provider = "hashicorp/aws"
+			}),
+			wantErrText: "The providers why command expects at most one argument with the source address of a provider.",
+		},
+		"json not supported": {
+			args:        []string{"-json"},
+			want:        providersWhyArgsWithDefaults(nil),
+			wantErrText: "flag provided but not defined: -json",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseProvidersWhy(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func providersWhyArgsWithDefaults(mutate func(v *ProvidersWhy)) *ProvidersWhy {
+	ret := &ProvidersWhy{
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+		Vars: &Vars{},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
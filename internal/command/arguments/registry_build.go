@@ -0,0 +1,52 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RegistryBuild represents the command-line arguments for the 'registry build' command.
+type RegistryBuild struct {
+	// SourceDir is the directory containing the provider packages and
+	// module archives to publish.
+	SourceDir string
+
+	// OutputDir is the directory where the static registry is written.
+	OutputDir string
+
+	// SigningKeyFile is the path of the ASCII-armored GPG private key used
+	// to sign the provider checksums.
+	SigningKeyFile string
+
+	// View represents the global view options
+	View *View
+}
+
+// BindRegistryBuild registers CLI arguments, returning a RegistryBuild value and it's corresponding hooks.
+func BindRegistryBuild(cli *CommandLine) *RegistryBuild {
+	build := RegistryBuild{
+		View: BindView(cli, viewFlagNone),
+	}
+
+	cli.StringVar(&build.SigningKeyFile, "signing-key", "", "Path of an ASCII-armored GPG private key to sign the checksums of the provider packages with. Required if the source directory contains any providers. If the key is protected by a passphrase, set it in the TF_REGISTRY_SIGNING_KEY_PASSPHRASE environment variable.").SetDisplay("=path")
+
+	cli.ArgHelp = "The registry build command requires a source directory and an output directory as command-line arguments."
+	cli.PositionalArg(&build.SourceDir, "source-dir", false)
+	cli.PositionalArg(&build.OutputDir, "output-dir", false)
+
+	return &build
+}
+
+// ParseRegistryBuild processes CLI arguments, returning a RegistryBuild value, a closer function, and errors.
+// If errors are encountered, a RegistryBuild value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseRegistryBuild(args []string) (*RegistryBuild, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	build := BindRegistryBuild(cli)
+	closer, diags := cli.parseWithHooks("registry build", args)
+	return build, closer, diags
+}
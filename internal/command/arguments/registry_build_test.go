@@ -0,0 +1,92 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRegistryBuild(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *RegistryBuild
+		wantErrText string
+	}{
+		"no directories": {
+			args:        nil,
+			want:        registryBuildArgsWithDefaults(nil),
+			wantErrText: "The registry build command requires a source directory and an output directory as command-line arguments.",
+		},
+		"only source directory": {
+			args: []string{"packages"},
+			want: registryBuildArgsWithDefaults(func(v *RegistryBuild) {
+				v.SourceDir = "packages"
+			}),
+			wantErrText: "The registry build command requires a source directory and an output directory as command-line arguments.",
+		},
+		"both directories": {
+			args: []string{"packages", "public"},
+			want: registryBuildArgsWithDefaults(func(v *RegistryBuild) {
+				v.SourceDir = "packages"
+				v.OutputDir = "public"
+			}),
+		},
+		"signing key": {
+			args: []string{"-signing-key=key.asc", "packages", "public"},
+			want: registryBuildArgsWithDefaults(func(v *RegistryBuild) {
+				v.SourceDir = "packages"
+				v.OutputDir = "public"
+				v.SigningKeyFile = "key.asc"
+			}),
+		},
+		"too many arguments": {
+			args: []string{"packages", "public", "extra"},
+			want: registryBuildArgsWithDefaults(func(v *RegistryBuild) {
+				v.SourceDir = "packages"
+				v.OutputDir = "public"
+			}),
+			wantErrText: "The registry build command requires a source directory and an output directory as command-line arguments.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseRegistryBuild(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func registryBuildArgsWithDefaults(mutate func(v *RegistryBuild)) *RegistryBuild {
+	ret := &RegistryBuild{
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
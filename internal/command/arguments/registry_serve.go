@@ -0,0 +1,78 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// RegistryServe represents the command-line arguments for the 'registry serve' command.
+type RegistryServe struct {
+	// Directory is the directory containing a registry generated by the
+	// 'registry build' command.
+	Directory string
+
+	// Listen is the TCP address to listen on.
+	Listen string
+
+	// TLSCertFile and TLSKeyFile are the paths of the PEM-encoded certificate
+	// and private key to serve HTTPS with.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// PlainHTTP serves unencrypted HTTP instead of HTTPS, for use behind a
+	// reverse proxy that terminates TLS.
+	PlainHTTP bool
+
+	// View represents the global view options
+	View *View
+}
+
+// BindRegistryServe registers CLI arguments, returning a RegistryServe value and it's corresponding hooks.
+func BindRegistryServe(cli *CommandLine) *RegistryServe {
+	serve := RegistryServe{
+		View: BindView(cli, viewFlagNone),
+	}
+
+	cli.StringVar(&serve.Listen, "listen", ":8443", "TCP address to listen on. Defaults to \":8443\".").SetDisplay("=addr")
+	cli.StringVar(&serve.TLSCertFile, "tls-cert", "", "Path of a PEM-encoded TLS certificate for the registry hostname.").SetDisplay("=path")
+	cli.StringVar(&serve.TLSKeyFile, "tls-key", "", "Path of the PEM-encoded private key for the certificate given in -tls-cert.").SetDisplay("=path")
+	cli.BoolVar(&serve.PlainHTTP, "http", false, "Serve unencrypted HTTP instead of HTTPS. OpenTofu only uses registries over HTTPS, so this is only useful behind a reverse proxy that terminates TLS.")
+
+	cli.ArgHelp = "The registry serve command requires the directory created by the registry build command as a command-line argument."
+	cli.PositionalArg(&serve.Directory, "registry-dir", false)
+
+	cli.PreHook(func() tfdiags.Diagnostics {
+		var diags tfdiags.Diagnostics
+		switch {
+		case serve.PlainHTTP && (serve.TLSCertFile != "" || serve.TLSKeyFile != ""):
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Conflicting TLS options",
+				"The -http option cannot be used together with -tls-cert or -tls-key.",
+			))
+		case !serve.PlainHTTP && (serve.TLSCertFile == "" || serve.TLSKeyFile == ""):
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"TLS certificate required",
+				"OpenTofu only uses registries over HTTPS, so both -tls-cert and -tls-key are required. To serve plain HTTP behind a reverse proxy that terminates TLS, use -http instead.",
+			))
+		}
+		return diags
+	})
+
+	return &serve
+}
+
+// ParseRegistryServe processes CLI arguments, returning a RegistryServe value, a closer function, and errors.
+// If errors are encountered, a RegistryServe value is still returned representing
+// the best effort interpretation of the arguments.
+func ParseRegistryServe(args []string) (*RegistryServe, func(), tfdiags.Diagnostics) {
+	cli := new(CommandLine)
+	serve := BindRegistryServe(cli)
+	closer, diags := cli.parseWithHooks("registry serve", args)
+	return serve, closer, diags
+}
@@ -0,0 +1,102 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package arguments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRegistryServe(t *testing.T) {
+	testCases := map[string]struct {
+		args        []string
+		want        *RegistryServe
+		wantErrText string
+	}{
+		"https": {
+			args: []string{"-tls-cert=cert.pem", "-tls-key=key.pem", "public"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.TLSCertFile = "cert.pem"
+				v.TLSKeyFile = "key.pem"
+			}),
+		},
+		"plain http": {
+			args: []string{"-http", "-listen=127.0.0.1:8080", "public"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.PlainHTTP = true
+				v.Listen = "127.0.0.1:8080"
+			}),
+		},
+		"no directory": {
+			args: []string{"-http"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.Directory = ""
+				v.PlainHTTP = true
+			}),
+			wantErrText: "The registry serve command requires the directory created by the registry build command as a command-line argument.",
+		},
+		"no certificate": {
+			args:        []string{"public"},
+			want:        registryServeArgsWithDefaults(nil),
+			wantErrText: "both -tls-cert and -tls-key are required",
+		},
+		"certificate without key": {
+			args: []string{"-tls-cert=cert.pem", "public"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.TLSCertFile = "cert.pem"
+			}),
+			wantErrText: "both -tls-cert and -tls-key are required",
+		},
+		"plain http with certificate": {
+			args: []string{"-http", "-tls-cert=cert.pem", "-tls-key=key.pem", "public"},
+			want: registryServeArgsWithDefaults(func(v *RegistryServe) {
+				v.PlainHTTP = true
+				v.TLSCertFile = "cert.pem"
+				v.TLSKeyFile = "key.pem"
+			}),
+			wantErrText: "The -http option cannot be used together with -tls-cert or -tls-key.",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, closer, diags := ParseRegistryServe(tc.args)
+			defer closer()
+
+			if tc.wantErrText != "" && len(diags) == 0 {
+				t.Errorf("test wanted error but got nothing")
+			} else if tc.wantErrText == "" && len(diags) > 0 {
+				t.Errorf("test didn't expect errors but got some: %s", diags.ErrWithWarnings())
+			} else if tc.wantErrText != "" && len(diags) > 0 {
+				errStr := diags.ErrWithWarnings().Error()
+				if !strings.Contains(errStr, tc.wantErrText) {
+					t.Errorf("the returned diagnostics does not contain the expected error message.\ndiags:\n\t%s\nwanted:\n\t%s\n", errStr, tc.wantErrText)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected result\n%s", diff)
+			}
+		})
+	}
+}
+
+func registryServeArgsWithDefaults(mutate func(v *RegistryServe)) *RegistryServe {
+	ret := &RegistryServe{
+		Directory: "public",
+		Listen:    ":8443",
+		View: &View{
+			ConsolidateWarnings: true,
+			ViewType:            ViewHuman,
+			InputEnabled:        false,
+		},
+	}
+	if mutate != nil {
+		mutate(ret)
+	}
+	return ret
+}
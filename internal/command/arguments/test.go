@@ -31,6 +31,19 @@ type Test struct {
 	// human-readable format or JSON for each run step depending on the
 	// ViewType.
 	Verbose bool
+
+	// MockSeed is mixed into the seed used to generate values for mocked
+	// and overridden resources. Changing it produces a different set of
+	// values, and reusing it reproduces the same ones.
+	MockSeed int
+
+	// Tags contains a list of run block tags. If not empty, only the run
+	// blocks labelled with at least one of them will be executed.
+	Tags []string
+
+	// SkipTags contains a list of run block tags. Run blocks labelled with
+	// any of them will be skipped, even if they also match Tags.
+	SkipTags []string
 }
 
 // BindTest registers CLI arguments, returning a Test value and it's corresponding hooks.
@@ -43,6 +56,9 @@ func BindTest(cli *CommandLine) *Test {
 	cli.StringArrayVar(&test.Filter, "filter", nil, "If specified, OpenTofu will only execute the test files specified by this flag. You can use this option multiple times to execute more than one test file. The path should be relative to the current working directory, even if -test-directory is set.").SetDisplay("=testfile")
 	cli.StringVar(&test.TestDirectory, "test-directory", "tests", `Set the OpenTofu test directory, defaults to "tests". When set, the test command will search for test files in the current directory and in the one specified by the flag.`).SetDisplay("=path")
 	cli.BoolVar(&test.Verbose, "verbose", false, "Print the plan or state for each test run block as it executes.")
+	cli.IntVar(&test.MockSeed, "mock-seed", 0, "Seed for the values generated for mocked and overridden resources. Use the same seed to reproduce a test failure that depends on the generated values.").SetDisplay("=n")
+	cli.StringArrayVar(&test.Tags, "tags", nil, "If specified, OpenTofu will only execute the run blocks labelled with one of the given tags. You can use this option multiple times to select more than one tag. Run blocks that are not selected are reported as skipped.").SetDisplay("=tag")
+	cli.StringArrayVar(&test.SkipTags, "skip-tags", nil, "If specified, OpenTofu will skip the run blocks labelled with one of the given tags, even if they were selected by -tags. You can use this option multiple times.").SetDisplay("=tag")
 
 	return &test
 }
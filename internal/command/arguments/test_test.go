@@ -79,6 +79,8 @@ func TestParseTest(t *testing.T) {
 				TestDirectory: "tests",
 				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
 				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
 			},
 			wantDiags: nil,
 		},
@@ -89,6 +91,8 @@ func TestParseTest(t *testing.T) {
 				TestDirectory: "tests",
 				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
 				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
 			},
 			wantDiags: nil,
 		},
@@ -99,6 +103,8 @@ func TestParseTest(t *testing.T) {
 				TestDirectory: "tests",
 				View:          &View{ConsolidateWarnings: true, ViewType: ViewJSON},
 				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
 			},
 			wantDiags: nil,
 		},
@@ -109,6 +115,8 @@ func TestParseTest(t *testing.T) {
 				TestDirectory: "other",
 				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
 				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
 			},
 			wantDiags: nil,
 		},
@@ -120,6 +128,31 @@ func TestParseTest(t *testing.T) {
 				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
 				Verbose:       true,
 				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
+			},
+		},
+		"mock-seed": {
+			args: []string{"-mock-seed=1234"},
+			want: &Test{
+				Filter:        []string{},
+				TestDirectory: "tests",
+				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
+				MockSeed:      1234,
+				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
+			},
+		},
+		"tags": {
+			args: []string{"-tags=slow", "-tags=network", "-skip-tags=flaky"},
+			want: &Test{
+				Filter:        []string{},
+				TestDirectory: "tests",
+				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
+				Vars:          &Vars{},
+				Tags:          []string{"slow", "network"},
+				SkipTags:      []string{"flaky"},
 			},
 		},
 		"unknown flag": {
@@ -129,6 +162,8 @@ func TestParseTest(t *testing.T) {
 				TestDirectory: "tests",
 				View:          &View{ConsolidateWarnings: true, ViewType: ViewHuman},
 				Vars:          &Vars{},
+				Tags:          []string{},
+				SkipTags:      []string{},
 			},
 			wantDiags: tfdiags.Diagnostics{
 				tfdiags.Sourceless(
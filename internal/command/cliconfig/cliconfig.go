@@ -83,6 +83,11 @@ type Config struct {
 	// prefix.
 	OCIDefaultCredentials    []*OCIDefaultCredentials
 	OCIRepositoryCredentials []*OCIRepositoryCredentials
+
+	// OCISignaturePolicies represents any oci_signature_policy blocks in
+	// the configuration. Each must have a unique repository prefix, which
+	// we validate after loading the configuration.
+	OCISignaturePolicies []*OCISignaturePolicy
 }
 
 // ConfigHost is the structure of the "host" nested block within the CLI
@@ -229,7 +234,7 @@ func (cl *ConfigLoader) loadConfigFile(path string) (*Config, tfdiags.Diagnostic
 	registryProtocolsConfig, registryProtocolsDiags := decodeRegistryProtocolsConfigFromConfig(obj)
 	diags = diags.Append(registryProtocolsDiags)
 	result.RegistryProtocols = registryProtocolsConfig
-	providerInstBlocks, providerInstDiags := decodeProviderInstallationFromConfig(obj)
+	providerInstBlocks, providerInstDiags := decodeProviderInstallationFromConfig(obj, path)
 	diags = diags.Append(providerInstDiags)
 	result.ProviderInstallation = providerInstBlocks
 	ociDefaultCredsBlocks, ociDefaultCredsDiags := decodeOCIDefaultCredentialsFromConfig(obj, path)
@@ -238,6 +243,9 @@ func (cl *ConfigLoader) loadConfigFile(path string) (*Config, tfdiags.Diagnostic
 	ociCredsBlocks, ociCredsDiags := decodeOCIRepositoryCredentialsFromConfig(obj)
 	diags = diags.Append(ociCredsDiags)
 	result.OCIRepositoryCredentials = ociCredsBlocks
+	ociSigPolicyBlocks, ociSigPolicyDiags := decodeOCISignaturePoliciesFromConfig(obj, path)
+	diags = diags.Append(ociSigPolicyDiags)
+	result.OCISignaturePolicies = ociSigPolicyBlocks
 
 	if result.PluginCacheDir != "" {
 		result.PluginCacheDir = os.ExpandEnv(result.PluginCacheDir)
@@ -399,6 +407,19 @@ func (c *Config) Validate() tfdiags.Diagnostics {
 			seenOCICredentialsAddrs[creds.RepositoryPrefix] = struct{}{}
 		}
 	}
+	if len(c.OCISignaturePolicies) != 0 {
+		seenOCISignaturePolicyAddrs := make(map[string]struct{})
+		for _, policy := range c.OCISignaturePolicies {
+			if _, ok := seenOCISignaturePolicyAddrs[policy.RepositoryPrefix]; ok {
+				diags = diags.Append(
+					//nolint:stylecheck // Despite typical Go idiom, our existing precedent here is to return full sentences suitable for inclusion in diagnostics.
+					fmt.Errorf("Duplicate oci_signature_policy block for %q", policy.RepositoryPrefix),
+				)
+				continue
+			}
+			seenOCISignaturePolicyAddrs[policy.RepositoryPrefix] = struct{}{}
+		}
+	}
 
 	if c.PluginCacheDir != "" {
 		_, err := os.Stat(c.PluginCacheDir)
@@ -464,6 +485,10 @@ func (c *Config) Merge(c2 *Config) *Config {
 		result.OCIRepositoryCredentials = append(result.OCIRepositoryCredentials, c.OCIRepositoryCredentials...)
 		result.OCIRepositoryCredentials = append(result.OCIRepositoryCredentials, c2.OCIRepositoryCredentials...)
 	}
+	if (len(c.OCISignaturePolicies) + len(c2.OCISignaturePolicies)) > 0 {
+		result.OCISignaturePolicies = append(result.OCISignaturePolicies, c.OCISignaturePolicies...)
+		result.OCISignaturePolicies = append(result.OCISignaturePolicies, c2.OCISignaturePolicies...)
+	}
 
 	return &result
 }
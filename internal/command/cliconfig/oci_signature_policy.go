@@ -0,0 +1,286 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl"
+	hclast "github.com/hashicorp/hcl/hcl/ast"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig/ociauthconfig"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// OCISignatureTrustPolicies returns an object that encapsulates the operator's
+// configured trust policies for cosign signatures of providers and modules
+// installed from OCI registries.
+//
+// This reads the public key and certificate files named in the configuration,
+// and so can fail if any of those files are missing or invalid.
+//
+// This should be called only on a [Config] where [Config.Validate] was already called
+// and returned no error diagnostics. Calling this on an unvalidated or invalid
+// configuration produces unspecified results, possibly including panics.
+func (c *Config) OCISignatureTrustPolicies() (cosign.Policies, error) {
+	var ret cosign.Policies
+	for _, block := range c.OCISignaturePolicies {
+		policy, err := block.policy()
+		if err != nil {
+			return cosign.Policies{}, fmt.Errorf("oci_signature_policy %q: %w", block.RepositoryPrefix, err)
+		}
+		// Validation already checked that the prefix is valid.
+		registryDomain, repositoryPrefix, _ := ociauthconfig.ParseRepositoryAddressPrefix(block.RepositoryPrefix)
+		ret.Add(registryDomain, repositoryPrefix, policy)
+	}
+	return ret, nil
+}
+
+// OCISignaturePolicy corresponds directly to a single oci_signature_policy
+// block in the CLI configuration, which requires that all providers and
+// modules installed from a set of OCI repositories have a cosign signature
+// from one of the configured signers.
+type OCISignaturePolicy struct {
+	// A repository address prefix, in the form "domain/path", that describes
+	// which repositories this policy applies to. If more than one policy
+	// matches a repository then the one with the longest prefix applies.
+	RepositoryPrefix string
+
+	// PublicKeyFiles are paths to PEM-encoded public key files, such as
+	// those written by "cosign generate-key-pair", whose signatures are
+	// trusted.
+	PublicKeyFiles []string
+
+	// CertificateRootsFile is the path to a file of PEM-encoded certificates
+	// of the authorities trusted to issue signing certificates, and
+	// CertificateIdentities and CertificateOIDCIssuer constrain which of
+	// those signing certificates are trusted.
+	CertificateRootsFile  string
+	CertificateIdentities []string
+	CertificateOIDCIssuer string
+
+	// TransparencyLogPublicKeyFiles are paths to PEM-encoded public key
+	// files of the Rekor transparency logs trusted to record when
+	// certificate-based signatures were made.
+	TransparencyLogPublicKeyFiles []string
+}
+
+// policy reads the files named in the receiver and returns the
+// corresponding [cosign.Policy].
+func (p *OCISignaturePolicy) policy() (*cosign.Policy, error) {
+	ret := &cosign.Policy{
+		Identities: p.CertificateIdentities,
+		OIDCIssuer: p.CertificateOIDCIssuer,
+	}
+	for _, filename := range p.PublicKeyFiles {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("reading public key file: %w", err)
+		}
+		keys, err := cosign.ParsePublicKeysPEM(src)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key file %s: %w", filename, err)
+		}
+		ret.PublicKeys = append(ret.PublicKeys, keys...)
+	}
+	if p.CertificateRootsFile != "" {
+		src, err := os.ReadFile(p.CertificateRootsFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading certificate roots file: %w", err)
+		}
+		ret.Roots, err = cosign.ParseCertificatesPEM(src)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate roots file %s: %w", p.CertificateRootsFile, err)
+		}
+	}
+	for _, filename := range p.TransparencyLogPublicKeyFiles {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("reading transparency log public key file: %w", err)
+		}
+		keys, err := cosign.ParsePublicKeysPEM(src)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transparency log public key file %s: %w", filename, err)
+		}
+		ret.TransparencyLogKeys = append(ret.TransparencyLogKeys, keys...)
+	}
+	return ret, nil
+}
+
+// decodeOCISignaturePoliciesFromConfig uses the HCL AST API directly
+// to decode "oci_signature_policy" blocks from the given file.
+//
+// The overall CLI configuration can contain zero or more blocks of this
+// type. We require that each one describes a distinct OCI repository
+// address prefix, but that constraint must be enforced by the caller of
+// this function because it must be checked across all of the CLI
+// configuration files together, rather than just one file at a time.
+//
+// This follows the same conventions as decodeOCIRepositoryCredentialsFromConfig,
+// and so see that function for more information.
+func decodeOCISignaturePoliciesFromConfig(hclFile *hclast.File, filename string) ([]*OCISignaturePolicy, tfdiags.Diagnostics) {
+	var ret []*OCISignaturePolicy
+	var diags tfdiags.Diagnostics
+
+	root, ok := hclFile.Node.(*hclast.ObjectList)
+	if !ok {
+		return ret, diags
+	}
+	for _, block := range root.Items {
+		const errInvalidSummary = "Invalid oci_signature_policy block"
+		if block.Keys[0].Token.Value() != "oci_signature_policy" {
+			continue
+		}
+
+		const TWO = 2 // To quiet the "mnd" linter
+		unwrapHCLObjectKeysFromJSON(block, TWO)
+		if len(block.Keys) != TWO {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("The oci_signature_policy block at %s must have one label, giving an OCI repository address prefix.", block.Pos()),
+			))
+			continue
+		}
+
+		isJSON := block.Keys[0].Token.JSON
+		if block.Assign.Line != 0 && !isJSON {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("The oci_signature_policy block at %s must not be introduced with an equals sign.", block.Pos()),
+			))
+			continue
+		}
+		body, ok := block.Val.(*hclast.ObjectType)
+		if !ok {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("The oci_signature_policy block at %s must be represented by a JSON object.", block.Pos()),
+			))
+			continue
+		}
+		label, ok := block.Keys[1].Token.Value().(string)
+		if !ok {
+			// HCL grammar doesn't allow anything other than string in the key position,
+			// so we should not get here.
+			panic(fmt.Sprintf("HCL returned non-string label %#v for oci_signature_policy block", block.Keys[1].Token))
+		}
+
+		result, blockDiags := decodeOCISignaturePolicyBlockBody(label, body, filename)
+		diags = diags.Append(blockDiags)
+		if result != nil {
+			ret = append(ret, result)
+		}
+	}
+
+	return ret, diags
+}
+
+func decodeOCISignaturePolicyBlockBody(label string, body *hclast.ObjectType, filename string) (*OCISignaturePolicy, tfdiags.Diagnostics) {
+	const errInvalidSummary = "Invalid oci_signature_policy block"
+	var diags tfdiags.Diagnostics
+
+	if _, _, err := ociauthconfig.ParseRepositoryAddressPrefix(label); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("The oci_signature_policy block at %s has an invalid block label: %s.", body.Pos(), err),
+		))
+		return nil, diags
+	}
+
+	// Any relative file paths in this block are resolved relative to the directory
+	// containing the file where this block came from.
+	baseDir := filepath.Dir(filename)
+
+	type BodyContent struct {
+		PublicKeyFiles        []string `hcl:"public_key_files"`
+		CertificateRootsFile  string   `hcl:"certificate_roots_file"`
+		CertificateIdentities []string `hcl:"certificate_identities"`
+		CertificateOIDCIssuer string   `hcl:"certificate_oidc_issuer"`
+
+		TransparencyLogPublicKeyFiles []string `hcl:"transparency_log_public_key_files"`
+	}
+	var bodyContent BodyContent
+	err := hcl.DecodeObject(&bodyContent, body)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("Invalid oci_signature_policy block at %s: %s.", body.Pos(), err),
+		))
+		return nil, diags
+	}
+
+	if len(bodyContent.PublicKeyFiles) == 0 && bodyContent.CertificateRootsFile == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("The oci_signature_policy block at %s must set at least one of public_key_files or certificate_roots_file.", body.Pos()),
+		))
+		return nil, diags
+	}
+	if (bodyContent.CertificateRootsFile != "") != (len(bodyContent.CertificateIdentities) != 0) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("The oci_signature_policy block at %s must set both certificate_roots_file and certificate_identities together when trusting signing certificates.", body.Pos()),
+		))
+		return nil, diags
+	}
+	if (bodyContent.CertificateRootsFile != "") != (len(bodyContent.TransparencyLogPublicKeyFiles) != 0) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("The oci_signature_policy block at %s must set both certificate_roots_file and transparency_log_public_key_files together when trusting signing certificates, because signing certificates can be checked only at the time a transparency log recorded the signature.", body.Pos()),
+		))
+		return nil, diags
+	}
+	if bodyContent.CertificateOIDCIssuer != "" && bodyContent.CertificateRootsFile == "" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("The oci_signature_policy block at %s sets certificate_oidc_issuer, which is relevant only when certificate_roots_file is also set.", body.Pos()),
+		))
+		return nil, diags
+	}
+
+	ret := &OCISignaturePolicy{
+		RepositoryPrefix:      label,
+		CertificateIdentities: bodyContent.CertificateIdentities,
+		CertificateOIDCIssuer: bodyContent.CertificateOIDCIssuer,
+	}
+	for _, keyFile := range bodyContent.PublicKeyFiles {
+		ret.PublicKeyFiles = append(ret.PublicKeyFiles, resolveCLIConfigFilePath(baseDir, keyFile))
+	}
+	if bodyContent.CertificateRootsFile != "" {
+		ret.CertificateRootsFile = resolveCLIConfigFilePath(baseDir, bodyContent.CertificateRootsFile)
+	}
+	for _, keyFile := range bodyContent.TransparencyLogPublicKeyFiles {
+		ret.TransparencyLogPublicKeyFiles = append(ret.TransparencyLogPublicKeyFiles, resolveCLIConfigFilePath(baseDir, keyFile))
+	}
+	return ret, diags
+}
+
+// resolveCLIConfigFilePath resolves the given path relative to baseDir if it
+// isn't already absolute.
+func resolveCLIConfigFilePath(baseDir, path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	// We'll also make a best effort to "absolute-ize" the path so that it
+	// won't get reinterpreted differently if the process switches to a
+	// different working directory after loading the CLI config.
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	return path
+}
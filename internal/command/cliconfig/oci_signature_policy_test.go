@@ -0,0 +1,182 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cliconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/oci/cosign"
+)
+
+func TestLoadConfig_ociSignaturePolicies(t *testing.T) {
+	// Relative paths are resolved relative to the directory containing
+	// the configuration file. As in TestLoadConfig_ociDefaultCredentials,
+	// "/etc/opentofu/other.pub" is not an absolute path on Windows.
+	abs := func(path string) string {
+		ret, err := filepath.Abs(filepath.FromSlash(path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+	otherKeyPath := "/etc/opentofu/other.pub"
+	if runtime.GOOS == "windows" {
+		otherKeyPath = abs("testdata/etc/opentofu/other.pub")
+	}
+	wantPolicies := []*OCISignaturePolicy{
+		{
+			RepositoryPrefix: "example.com",
+			PublicKeyFiles: []string{
+				abs("testdata/cosign.pub"),
+				otherKeyPath,
+			},
+		},
+		{
+			RepositoryPrefix:      "example.net/opentofu",
+			CertificateRootsFile:  abs("testdata/fulcio.pem"),
+			CertificateIdentities: []string{"https://github.com/example/providers/.github/workflows/release.yml@refs/heads/main"},
+			CertificateOIDCIssuer: "https://token.actions.githubusercontent.com",
+			TransparencyLogPublicKeyFiles: []string{
+				abs("testdata/rekor.pub"),
+			},
+		},
+	}
+
+	// The keys in this map correspond to fixture names under
+	// the "testdata" directory.
+	tests := map[string]struct {
+		want    []*OCISignaturePolicy
+		wantErr string
+	}{
+		"oci-signature-policy": {
+			wantPolicies,
+			``,
+		},
+		"oci-signature-policy.json": {
+			wantPolicies,
+			``,
+		},
+		"oci-signature-policy-empty": {
+			nil,
+			`must set at least one of public_key_files or certificate_roots_file`,
+		},
+		"oci-signature-policy-noidentities": {
+			nil,
+			`must set both certificate_roots_file and certificate_identities together`,
+		},
+		"oci-signature-policy-notlog": {
+			nil,
+			`must set both certificate_roots_file and transparency_log_public_key_files together`,
+		},
+		"oci-signature-policy-issueronly": {
+			nil,
+			`sets certificate_oidc_issuer, which is relevant only when certificate_roots_file is also set`,
+		},
+		"oci-signature-policy-badlabel": {
+			nil,
+			`has an invalid block label`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixtureFile := filepath.Join("testdata", name)
+			gotConfig, diags := loadConfigFile(fixtureFile)
+			if diags.HasErrors() {
+				errStr := diags.Err().Error()
+				if test.wantErr == "" {
+					t.Errorf("unexpected errors: %s", errStr)
+				}
+				if !strings.Contains(errStr, test.wantErr) {
+					t.Errorf("missing expected error\nwant substring: %s\ngot: %s", test.wantErr, errStr)
+				}
+			} else if test.wantErr != "" {
+				t.Errorf("unexpected success\nwant error with substring: %s", test.wantErr)
+			}
+
+			got := gotConfig.OCISignaturePolicies
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error("unexpected result\n" + diff)
+			}
+		})
+	}
+
+	t.Run("oci-signature-policy-duplicate", func(t *testing.T) {
+		// As with oci_credentials blocks, duplicates are detected only
+		// during the validation step.
+		fixtureFile := filepath.Join("testdata", "oci-signature-policy-duplicate")
+		gotConfig, loadDiags := loadConfigFile(fixtureFile)
+		if loadDiags.HasErrors() {
+			t.Errorf("unexpected errors from loadConfigFile: %s", loadDiags.Err().Error())
+		}
+
+		validateDiags := gotConfig.Validate()
+		wantErr := `Duplicate oci_signature_policy block for "example.com"`
+		if !validateDiags.HasErrors() {
+			t.Fatalf("unexpected success\nwant error with substring: %s", wantErr)
+		}
+		if errStr := validateDiags.Err().Error(); !strings.Contains(errStr, wantErr) {
+			t.Errorf("missing expected error\nwant substring: %s\ngot: %s", wantErr, errStr)
+		}
+	})
+}
+
+func TestConfigOCISignatureTrustPolicies(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &Config{
+		OCISignaturePolicies: []*OCISignaturePolicy{
+			{
+				RepositoryPrefix: "example.com/foo",
+				PublicKeyFiles:   []string{keyFile},
+			},
+		},
+	}
+	policies, err := config.OCISignatureTrustPolicies()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	policy := policies.PolicyFor("example.com", "foo/bar")
+	if policy == nil {
+		t.Fatal("no policy for example.com/foo/bar")
+	}
+	if len(policy.PublicKeys) != 1 || cosign.KeyID(policy.PublicKeys[0]) != cosign.KeyID(key.Public()) {
+		t.Errorf("wrong public keys in policy")
+	}
+	if policy := policies.PolicyFor("example.com", "bar"); policy != nil {
+		t.Errorf("unexpected policy for example.com/bar")
+	}
+
+	config.OCISignaturePolicies[0].PublicKeyFiles = []string{filepath.Join(t.TempDir(), "missing.pub")}
+	_, err = config.OCISignatureTrustPolicies()
+	if err == nil || !strings.Contains(err.Error(), "reading public key file") {
+		t.Errorf("wrong error for missing key file: %v", err)
+	}
+}
@@ -7,7 +7,12 @@ package cliconfig
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl"
 	hclast "github.com/hashicorp/hcl/hcl/ast"
@@ -45,6 +50,45 @@ type ProviderInstallation struct {
 	// providers, because they are still subject to version constraints and
 	// checksum verification.
 	DevOverrides map[addrs.Provider]getproviders.PackageLocalDir
+
+	// Policy, if set, restricts which providers and provider versions
+	// OpenTofu may select for installation, regardless of which installation
+	// method would be used to install them.
+	Policy *ProviderInstallationPolicy
+
+	// Download, if set, customizes how OpenTofu downloads provider packages
+	// from network-based installation methods.
+	Download *ProviderInstallationDownload
+}
+
+// ProviderInstallationDownload is the structure of the "download" block
+// nested within a "provider_installation" block.
+type ProviderInstallationDownload struct {
+	// BandwidthLimit is the maximum number of bytes per second to transfer
+	// while downloading each provider package, or zero for no limit.
+	BandwidthLimit int64
+
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between
+	// retries of a provider package download. Zero means to use the default.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+}
+
+// ProviderInstallationPolicy is the structure of the "policy" block nested
+// within a "provider_installation" block.
+type ProviderInstallationPolicy struct {
+	Allow               getproviders.MultiSourceMatchingPatterns
+	Deny                getproviders.MultiSourceMatchingPatterns
+	MinimumReleaseAge   time.Duration
+	VersionRules        []ProviderInstallationPolicyVersionRule
+	DeniedVersionsFiles []string
+}
+
+// ProviderInstallationPolicyVersionRule is a single entry from the
+// "version_constraints" argument of a provider installation policy.
+type ProviderInstallationPolicyVersionRule struct {
+	Providers   getproviders.MultiSourceMatchingPatterns
+	Constraints getproviders.VersionConstraints
 }
 
 // decodeProviderInstallationFromConfig uses the HCL AST API directly to
@@ -61,7 +105,9 @@ type ProviderInstallation struct {
 // Note that this function wants the top-level file object which might or
 // might not contain provider_installation blocks, not a provider_installation
 // block directly itself.
-func decodeProviderInstallationFromConfig(hclFile *hclast.File) ([]*ProviderInstallation, tfdiags.Diagnostics) {
+//
+// filename is used to resolve relative paths in the configuration.
+func decodeProviderInstallationFromConfig(hclFile *hclast.File, filename string) ([]*ProviderInstallation, tfdiags.Diagnostics) {
 	var ret []*ProviderInstallation
 	var diags tfdiags.Diagnostics
 
@@ -99,6 +145,8 @@ func decodeProviderInstallationFromConfig(hclFile *hclast.File) ([]*ProviderInst
 
 		pi := &ProviderInstallation{}
 		devOverrides := make(map[addrs.Provider]getproviders.PackageLocalDir)
+		seenPolicy := false
+		seenDownload := false
 
 		body, ok := block.Val.(*hclast.ObjectType)
 		if !ok {
@@ -299,6 +347,44 @@ func decodeProviderInstallationFromConfig(hclFile *hclast.File) ([]*ProviderInst
 
 				continue // We won't add anything to pi.MethodConfigs for this one
 
+			case "policy":
+				if seenPolicy {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation policy block",
+						fmt.Sprintf("Duplicate policy block at %s. Only one policy block is allowed in each provider_installation block.", methodBlock.Pos()),
+					))
+					continue
+				}
+				seenPolicy = true
+				policy, moreDiags := decodeProviderInstallationPolicyBlock(methodBody, filename)
+				diags = diags.Append(moreDiags)
+				if moreDiags.HasErrors() {
+					continue
+				}
+				pi.Policy = policy
+
+				continue // A policy is not an installation method
+
+			case "download":
+				if seenDownload {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation download block",
+						fmt.Sprintf("Duplicate download block at %s. Only one download block is allowed in each provider_installation block.", methodBlock.Pos()),
+					))
+					continue
+				}
+				seenDownload = true
+				download, moreDiags := decodeProviderInstallationDownloadBlock(methodBody)
+				diags = diags.Append(moreDiags)
+				if moreDiags.HasErrors() {
+					continue
+				}
+				pi.Download = download
+
+				continue // Download settings are not an installation method
+
 			default:
 				diags = diags.Append(tfdiags.Sourceless(
 					tfdiags.Error,
@@ -321,12 +407,281 @@ func decodeProviderInstallationFromConfig(hclFile *hclast.File) ([]*ProviderInst
 			pi.DevOverrides = devOverrides
 		}
 
+		if pi.Policy != nil && pi.Policy.MinimumReleaseAge > 0 {
+			for _, method := range pi.Methods {
+				if reason := methodLacksReleaseTimes(method); reason != "" {
+					diags = diags.Append(tfdiags.Sourceless(
+						tfdiags.Error,
+						"Invalid provider_installation policy block",
+						fmt.Sprintf("The provider_installation block at %s sets minimum_release_age, but %s, so OpenTofu would refuse to install any provider from it. Remove minimum_release_age or this installation method.", block.Pos(), reason),
+					))
+				}
+			}
+		}
+
 		ret = append(ret, pi)
 	}
 
 	return ret, diags
 }
 
+// decodeProviderInstallationPolicyBlock decodes the content of a policy block
+// from inside a provider_installation block.
+func decodeProviderInstallationPolicyBlock(body *hclast.ObjectType, filename string) (*ProviderInstallationPolicy, tfdiags.Diagnostics) {
+	const errInvalidSummary = "Invalid provider_installation policy block"
+	var diags tfdiags.Diagnostics
+
+	type BodyContent struct {
+		Allow               []string          `hcl:"allow"`
+		Deny                []string          `hcl:"deny"`
+		MinimumReleaseAge   string            `hcl:"minimum_release_age"`
+		VersionConstraints  map[string]string `hcl:"version_constraints"`
+		DeniedVersionsFiles []string          `hcl:"denied_versions_files"`
+	}
+	var bodyContent BodyContent
+	err := hcl.DecodeObject(&bodyContent, body)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("Invalid policy block at %s: %s.", body.Pos(), err),
+		))
+		return nil, diags
+	}
+
+	ret := &ProviderInstallationPolicy{}
+	ret.Allow, err = getproviders.ParseMultiSourceMatchingPatterns(bodyContent.Allow)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("Invalid \"allow\" argument in the policy block at %s: %s.", body.Pos(), err),
+		))
+	}
+	ret.Deny, err = getproviders.ParseMultiSourceMatchingPatterns(bodyContent.Deny)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("Invalid \"deny\" argument in the policy block at %s: %s.", body.Pos(), err),
+		))
+	}
+	if bodyContent.MinimumReleaseAge != "" {
+		ret.MinimumReleaseAge, err = parseMinimumReleaseAge(bodyContent.MinimumReleaseAge)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("Invalid \"minimum_release_age\" argument in the policy block at %s: %s.", body.Pos(), err),
+			))
+		}
+	}
+
+	// We sort the patterns so that the rules are always checked in a
+	// consistent order, since the HCL decoder produces a map.
+	for _, pattern := range slices.Sorted(maps.Keys(bodyContent.VersionConstraints)) {
+		providers, err := getproviders.ParseMultiSourceMatchingPatterns([]string{pattern})
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("Invalid provider pattern %q in \"version_constraints\" in the policy block at %s: %s.", pattern, body.Pos(), err),
+			))
+			continue
+		}
+		constraints, err := getproviders.ParseVersionConstraints(bodyContent.VersionConstraints[pattern])
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("Invalid version constraint for %q in \"version_constraints\" in the policy block at %s: %s.", pattern, body.Pos(), err),
+			))
+			continue
+		}
+		ret.VersionRules = append(ret.VersionRules, ProviderInstallationPolicyVersionRule{
+			Providers:   providers,
+			Constraints: constraints,
+		})
+	}
+
+	// Relative paths are resolved relative to the directory containing the
+	// file where this block came from.
+	baseDir := filepath.Dir(filename)
+	for _, deniedFile := range bodyContent.DeniedVersionsFiles {
+		ret.DeniedVersionsFiles = append(ret.DeniedVersionsFiles, resolveCLIConfigFilePath(baseDir, deniedFile))
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return ret, diags
+}
+
+// decodeProviderInstallationDownloadBlock decodes the content of a download
+// block from inside a provider_installation block.
+func decodeProviderInstallationDownloadBlock(body *hclast.ObjectType) (*ProviderInstallationDownload, tfdiags.Diagnostics) {
+	const errInvalidSummary = "Invalid provider_installation download block"
+	var diags tfdiags.Diagnostics
+
+	type BodyContent struct {
+		BandwidthLimit string `hcl:"bandwidth_limit"`
+		RetryWaitMin   string `hcl:"retry_wait_min"`
+		RetryWaitMax   string `hcl:"retry_wait_max"`
+	}
+	var bodyContent BodyContent
+	err := hcl.DecodeObject(&bodyContent, body)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("Invalid download block at %s: %s.", body.Pos(), err),
+		))
+		return nil, diags
+	}
+
+	ret := &ProviderInstallationDownload{}
+	if bodyContent.BandwidthLimit != "" {
+		ret.BandwidthLimit, err = parseBandwidthLimit(bodyContent.BandwidthLimit)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("Invalid \"bandwidth_limit\" argument in the download block at %s: %s.", body.Pos(), err),
+			))
+		}
+	}
+	for _, arg := range []struct {
+		name string
+		raw  string
+		into *time.Duration
+	}{
+		{"retry_wait_min", bodyContent.RetryWaitMin, &ret.RetryWaitMin},
+		{"retry_wait_max", bodyContent.RetryWaitMax, &ret.RetryWaitMax},
+	} {
+		if arg.raw == "" {
+			continue
+		}
+		d, err := time.ParseDuration(arg.raw)
+		if err != nil || d <= 0 {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				errInvalidSummary,
+				fmt.Sprintf("Invalid %q argument in the download block at %s: must be a positive duration like \"1s\" or \"2m\".", arg.name, body.Pos()),
+			))
+			continue
+		}
+		*arg.into = d
+	}
+	if ret.RetryWaitMin > 0 && ret.RetryWaitMax > 0 && ret.RetryWaitMin > ret.RetryWaitMax {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			errInvalidSummary,
+			fmt.Sprintf("Invalid download block at %s: \"retry_wait_min\" must not be greater than \"retry_wait_max\".", body.Pos()),
+		))
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return ret, diags
+}
+
+// bandwidthLimitUnits are the suffixes accepted by parseBandwidthLimit, in
+// the order they must be tested so that no suffix is shadowed by a shorter
+// one that it ends with.
+var bandwidthLimitUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"KB", 1000},
+	{"MB", 1000 * 1000},
+	{"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// parseBandwidthLimit parses a number of bytes per second, written either as
+// a whole number of bytes or with one of the suffixes "B", "KB", "MB", "GB",
+// "KiB", "MiB" or "GiB".
+func parseBandwidthLimit(s string) (int64, error) {
+	numStr, multiplier := s, int64(1)
+	for _, unit := range bandwidthLimitUnits {
+		if rest, ok := strings.CutSuffix(s, unit.suffix); ok {
+			numStr, multiplier = strings.TrimSpace(rest), unit.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseInt(numStr, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("must be a positive number of bytes per second, like \"512KiB\" or \"2MB\"")
+	}
+	return n * multiplier, nil
+}
+
+// parseMinimumReleaseAge parses a duration in the syntax accepted by
+// [time.ParseDuration], or a whole number of days with the suffix "d".
+func parseMinimumReleaseAge(s string) (time.Duration, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid number of days", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("must be a duration like \"7d\" or \"36h\"")
+		}
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
+// methodLacksReleaseTimes returns a description of why the given installation
+// method cannot report when each provider version was released, or an empty
+// string if it can.
+//
+// Only provider registries and OCI mirrors report release times. The
+// default public registry doesn't, so a direct method must be limited to
+// other registries using its include and exclude patterns.
+func methodLacksReleaseTimes(method *ProviderInstallationMethod) string {
+	switch location := method.Location.(type) {
+	case ProviderInstallationFilesystemMirror:
+		return fmt.Sprintf("the filesystem mirror at %s cannot report when each provider version was released", string(location))
+	case ProviderInstallationNetworkMirror:
+		return fmt.Sprintf("the network mirror at %s cannot report when each provider version was released", string(location))
+	case providerInstallationDirect:
+		// Invalid patterns are reported when the installation method is
+		// used, so we just ignore them here.
+		include, _ := getproviders.ParseMultiSourceMatchingPatterns(method.Include)
+		exclude, _ := getproviders.ParseMultiSourceMatchingPatterns(method.Exclude)
+		matchesDefaultHost := func(pattern addrs.Provider) bool {
+			return pattern.Hostname == addrs.DefaultProviderRegistryHost || pattern.Hostname == svchost.Hostname(getproviders.Wildcard)
+		}
+		for _, pattern := range exclude {
+			if matchesDefaultHost(pattern) && pattern.Namespace == getproviders.Wildcard && pattern.Type == getproviders.Wildcard {
+				return ""
+			}
+		}
+		reachesDefault := len(include) == 0
+		for _, pattern := range include {
+			if matchesDefaultHost(pattern) {
+				reachesDefault = true
+			}
+		}
+		if reachesDefault {
+			return fmt.Sprintf("the direct installation method may install providers from %s, which doesn't report when each provider version was released", addrs.DefaultProviderRegistryHost)
+		}
+	}
+	return ""
+}
+
 // decodeOCIMirrorInstallationMethodBlock decodes the content of an oci_mirror block
 // from inside a provider_installation block.
 func decodeOCIMirrorInstallationMethodBlock(methodBody *hclast.ObjectType) (location ProviderInstallationLocation, include, exclude []string, diags tfdiags.Diagnostics) {
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/opentofu/svchost"
@@ -305,3 +306,193 @@ func TestLoadConfig_providerInstallationOCIMirrorErrors(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadConfig_providerInstallationPolicy(t *testing.T) {
+	gotConfig, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-installation-policy"))
+	if diags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diags.Err().Error())
+	}
+	if got, want := len(gotConfig.ProviderInstallation), 1; got != want {
+		t.Fatalf("wrong number of provider_installation blocks %d; want %d", got, want)
+	}
+
+	deniedFile, err := filepath.Abs(filepath.Join(fixtureDir, "denied-provider-versions"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustPatterns := func(patterns ...string) getproviders.MultiSourceMatchingPatterns {
+		t.Helper()
+		ret, err := getproviders.ParseMultiSourceMatchingPatterns(patterns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ret
+	}
+	want := &ProviderInstallationPolicy{
+		Allow:             mustPatterns("hashicorp/*", "tf.example.com/internal/*"),
+		Deny:              mustPatterns("hashicorp/null"),
+		MinimumReleaseAge: 7 * 24 * time.Hour,
+		VersionRules: []ProviderInstallationPolicyVersionRule{
+			{
+				Providers:   mustPatterns("hashicorp/aws"),
+				Constraints: getproviders.MustParseVersionConstraints(">= 5.0.0"),
+			},
+		},
+		DeniedVersionsFiles: []string{deniedFile},
+	}
+	got := gotConfig.ProviderInstallation[0].Policy
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if got, want := len(gotConfig.ProviderInstallation[0].Methods), 1; got != want {
+		t.Errorf("wrong number of provider installation methods %d; want %d", got, want)
+	}
+}
+
+func TestLoadConfig_providerInstallationPolicyErrors(t *testing.T) {
+	_, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-installation-policy-errors"))
+	if !diags.HasErrors() {
+		t.Fatalf("unexpected success; want errors")
+	}
+	got := diags.Err().Error()
+	for _, want := range []string{
+		`Invalid "allow" argument in the policy block`,
+		`Invalid "minimum_release_age" argument in the policy block`,
+		`Invalid version constraint for "hashicorp/aws"`,
+		`Duplicate policy block at 9:3`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing expected error\ngot: %s\nwant substring: %s", got, want)
+		}
+	}
+}
+
+func TestLoadConfig_providerInstallationPolicyReleaseAge(t *testing.T) {
+	_, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-installation-policy-release-age"))
+	if !diags.HasErrors() {
+		t.Fatalf("unexpected success; want errors")
+	}
+	// Only the filesystem mirror, the network mirror and the direct method
+	// that can reach the default registry can't report release times.
+	if got, want := len(diags), 3; got != want {
+		t.Errorf("wrong number of diagnostics %d; want %d\n%s", got, want, diags.Err().Error())
+	}
+	got := diags.Err().Error()
+	for _, want := range []string{
+		`the filesystem mirror at /tmp/example1 cannot report`,
+		`the network mirror at https://tf-Mirror.example.com/ cannot report`,
+		`the direct installation method may install providers from registry.opentofu.org`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing expected error\ngot: %s\nwant substring: %s", got, want)
+		}
+	}
+}
+
+func TestParseMinimumReleaseAge(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		"days":     {input: "7d", want: 7 * 24 * time.Hour},
+		"hours":    {input: "36h", want: 36 * time.Hour},
+		"zero":     {input: "0d", want: 0},
+		"bad days": {input: "sevend", wantErr: true},
+		"negative": {input: "-1h", wantErr: true},
+		"garbage":  {input: "a week", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseMinimumReleaseAge(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("unexpected success; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("wrong result %s; want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_providerInstallationDownload(t *testing.T) {
+	gotConfig, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-installation-download"))
+	if diags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diags.Err().Error())
+	}
+	if got, want := len(gotConfig.ProviderInstallation), 1; got != want {
+		t.Fatalf("wrong number of provider_installation blocks %d; want %d", got, want)
+	}
+
+	want := &ProviderInstallationDownload{
+		BandwidthLimit: 512 * 1024,
+		RetryWaitMin:   2 * time.Second,
+		RetryWaitMax:   time.Minute,
+	}
+	got := gotConfig.ProviderInstallation[0].Download
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+	if got, want := len(gotConfig.ProviderInstallation[0].Methods), 1; got != want {
+		t.Errorf("wrong number of provider installation methods %d; want %d", got, want)
+	}
+}
+
+func TestLoadConfig_providerInstallationDownloadErrors(t *testing.T) {
+	_, diags := loadConfigFile(filepath.Join(fixtureDir, "provider-installation-download-errors"))
+	if !diags.HasErrors() {
+		t.Fatalf("unexpected success; want errors")
+	}
+	got := diags.Err().Error()
+	for _, want := range []string{
+		`Invalid "bandwidth_limit" argument in the download block`,
+		`"retry_wait_min" must not be greater than "retry_wait_max"`,
+		`Duplicate download block at 7:3`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing expected error\ngot: %s\nwant substring: %s", got, want)
+		}
+	}
+}
+
+func TestParseBandwidthLimit(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		"bytes":          {input: "1500", want: 1500},
+		"bytes suffix":   {input: "1500B", want: 1500},
+		"kilobytes":      {input: "64KB", want: 64000},
+		"kibibytes":      {input: "64KiB", want: 64 * 1024},
+		"mebibytes":      {input: "2 MiB", want: 2 * 1024 * 1024},
+		"gigabytes":      {input: "1GB", want: 1000 * 1000 * 1000},
+		"zero":           {input: "0", wantErr: true},
+		"negative":       {input: "-5MB", wantErr: true},
+		"fractional":     {input: "1.5MB", wantErr: true},
+		"unknown suffix": {input: "5TB", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseBandwidthLimit(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("unexpected success; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("wrong result %d; want %d", got, test.want)
+			}
+		})
+	}
+}
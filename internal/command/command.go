@@ -395,9 +395,11 @@ func RootCommander(help *bool, ver *bool, chdir *string) Command {
 			LoginCommander(),
 			LogoutCommander(),
 			MetadataCommander(),
+			ModulesCommander(),
 			OutputCommander(),
 			ProvidersCommander(),
 			RefreshCommander(),
+			RegistryCommander(),
 			ShowCommander(),
 			TaintCommander(),
 			TestCommander(),
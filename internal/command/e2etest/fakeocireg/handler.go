@@ -90,6 +90,8 @@ func (h *repositoryHandler) serve(ctx context.Context, noun, arg string) {
 			return
 		}
 		h.serveTagList(ctx)
+	case "referrers":
+		h.serveReferrers(ctx, arg)
 	default:
 		h.resp.WriteHeader(http.StatusNotFound)
 	}
@@ -195,6 +197,75 @@ func (h *repositoryHandler) serveTagList(ctx context.Context) {
 	_, _ = h.resp.Write(respBodyRaw)
 }
 
+func (h *repositoryHandler) serveReferrers(ctx context.Context, reference string) {
+	digest, err := ociDigest.Parse(reference)
+	if err != nil {
+		h.resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	artifactType := h.req.URL.Query().Get("artifactType")
+
+	// The store can tell us which manifests refer to the given digest in
+	// any way, so we need to filter those to include only the ones that
+	// refer to it as their subject.
+	preds, err := h.store.Predecessors(ctx, ociv1.Descriptor{Digest: digest})
+	if err != nil {
+		h.handleRequestError(ctx, err)
+		return
+	}
+	respBody := ociv1.Index{
+		MediaType: ociv1.MediaTypeImageIndex,
+		Manifests: []ociv1.Descriptor{},
+	}
+	respBody.SchemaVersion = 2
+	for _, pred := range preds {
+		if pred.MediaType != ociv1.MediaTypeImageManifest {
+			continue
+		}
+		reader, err := h.store.Fetch(ctx, pred)
+		if err != nil {
+			h.handleRequestError(ctx, err)
+			return
+		}
+		var manifest ociv1.Manifest
+		err = json.NewDecoder(reader).Decode(&manifest)
+		reader.Close()
+		if err != nil {
+			log.Printf("[ERROR] fakeocireg: %s", err)
+			h.resp.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if manifest.Subject == nil || manifest.Subject.Digest != digest {
+			continue
+		}
+		if artifactType != "" && manifest.ArtifactType != artifactType {
+			continue
+		}
+		respBody.Manifests = append(respBody.Manifests, ociv1.Descriptor{
+			MediaType:    pred.MediaType,
+			ArtifactType: manifest.ArtifactType,
+			Digest:       pred.Digest,
+			Size:         pred.Size,
+			Annotations:  manifest.Annotations,
+		})
+	}
+	respBodyRaw, err := json.Marshal(&respBody)
+	if err != nil {
+		log.Printf("[ERROR] fakeocireg: %s", err)
+		h.resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	h.resp.Header().Set("Content-Type", ociv1.MediaTypeImageIndex)
+	h.resp.Header().Set("Content-Length", strconv.Itoa(len(respBodyRaw)))
+	if artifactType != "" {
+		h.resp.Header().Set("OCI-Filters-Applied", "artifactType")
+	}
+	h.resp.WriteHeader(http.StatusOK)
+	// Intentionally ignoring error because it should only happen if
+	// the client disconnects before reading everything, or similar.
+	_, _ = h.resp.Write(respBodyRaw)
+}
+
 func (h *repositoryHandler) handleRequestError(_ context.Context, err error) {
 	if err == nil {
 		panic("call handleError only with a non-nil error")
@@ -202,6 +273,7 @@ func (h *repositoryHandler) handleRequestError(_ context.Context, err error) {
 	log.Printf("[ERROR] fakeocireg: %s", err)
 	if errors.Is(err, orasErrs.ErrNotFound) {
 		h.resp.WriteHeader(http.StatusNotFound)
+		return
 	}
 	h.resp.WriteHeader(http.StatusBadRequest)
 }
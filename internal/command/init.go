@@ -13,6 +13,7 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/svchost"
@@ -637,6 +638,11 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 	// and incomplete providers are stored here for later analysis.
 	var incompleteProviders []string
 
+	// Downloads that take a while report their progress periodically, so that
+	// it's clear OpenTofu hasn't stalled, but we track when we last did so
+	// for each provider so that quick downloads produce no extra output.
+	downloadProgressReported := make(map[addrs.Provider]time.Time)
+
 	// Because we're currently just streaming a series of events sequentially
 	// into the terminal, we're showing only a subset of the events to keep
 	// things relatively concise. Later it'd be nice to have a progress UI
@@ -676,6 +682,22 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 		FetchPackageBegin: func(provider addrs.Provider, version getproviders.Version, location getproviders.PackageLocation, inProviderCache bool) {
 			view.InstallingProvider(provider.ForDisplay(), version.String(), inProviderCache)
 		},
+		FetchPackageProgress: func(provider addrs.Provider, version getproviders.Version, progress getproviders.PackageDownloadProgress) {
+			now := time.Now()
+			last, seen := downloadProgressReported[provider]
+			if !seen {
+				// The first report only starts the clock, unless the download
+				// is resuming, which is worth mentioning right away.
+				downloadProgressReported[provider] = now
+				if progress.Resumed == 0 {
+					return
+				}
+			} else if now.Sub(last) < initDownloadProgressInterval {
+				return
+			}
+			downloadProgressReported[provider] = now
+			view.DownloadingProvider(provider.ForDisplay(), version.String(), progress)
+		},
 		QueryPackagesFailure: func(provider addrs.Provider, err error) {
 			switch errorTy := err.(type) {
 			case getproviders.ErrProviderNotFound:
@@ -786,6 +808,22 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 				),
 			))
 		},
+		QueryPackagesPolicyFallback: func(provider addrs.Provider, selectedVersion getproviders.Version, rejected []error) {
+			displayRejected := make([]string, len(rejected))
+			for i, err := range rejected {
+				displayRejected[i] = fmt.Sprintf("- %s", err)
+			}
+
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Warning,
+				"Provider versions rejected by installation policy",
+				fmt.Sprintf("The provider installation policy in the CLI configuration rejected newer versions of %s, so OpenTofu selected v%s instead:\n%s",
+					provider.ForDisplay(),
+					selectedVersion,
+					strings.Join(displayRejected, "\n"),
+				),
+			))
+		},
 		LinkFromCacheFailure: func(provider addrs.Provider, version getproviders.Version, err error) {
 			diags = diags.Append(tfdiags.Sourceless(
 				tfdiags.Error,
@@ -880,6 +918,8 @@ func (c *InitCommand) getProviders(ctx context.Context, config *configs.Config,
 
 			if authResult != nil && authResult.SigningSkipped() {
 				view.ProviderInstalledSkippedSignature(provider.ForDisplay(), version.String())
+			} else if signers := authResult.OCISignersString(); signers != "" && keyID == "" {
+				view.ProviderInstalledOCISigned(provider.ForDisplay(), version.String(), signers)
 			} else {
 				view.ProviderInstalled(provider.ForDisplay(), version.String(), authResult.String(), keyID)
 			}
@@ -1337,6 +1377,10 @@ func (c *InitCommand) Synopsis() string {
 	return "Prepare your working directory for other commands"
 }
 
+// initDownloadProgressInterval is the minimum time between two reports of
+// the progress of the same provider package download.
+const initDownloadProgressInterval = 10 * time.Second
+
 // providerProtocolTooOld is a message sent to the CLI UI if the provider's
 // supported protocol versions are too old for the user's version of tofu,
 // but a newer version of the provider is compatible.
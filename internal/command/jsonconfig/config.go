@@ -114,12 +114,13 @@ type resource struct {
 }
 
 type output struct {
-	Sensitive   bool        `json:"sensitive,omitempty"`
-	Ephemeral   bool        `json:"ephemeral,omitempty"`
-	Deprecated  string      `json:"deprecated,omitempty"`
-	Expression  *expression `json:"expression,omitempty"`
-	DependsOn   []string    `json:"depends_on,omitempty"`
-	Description string      `json:"description,omitempty"`
+	Type        json.RawMessage `json:"type,omitempty"`
+	Sensitive   bool            `json:"sensitive,omitempty"`
+	Ephemeral   bool            `json:"ephemeral,omitempty"`
+	Deprecated  string          `json:"deprecated,omitempty"`
+	Expression  *expression     `json:"expression,omitempty"`
+	DependsOn   []string        `json:"depends_on,omitempty"`
+	Description string          `json:"description,omitempty"`
 }
 
 type provisioner struct {
@@ -372,6 +373,15 @@ func marshalModule(c *configs.Config, schemas *tofu.Schemas, addr string) (modul
 		if v.Description != "" {
 			o.Description = v.Description
 		}
+		// As for variables below, we leave the type unset when the output
+		// value has no type constraint.
+		if v.ConstraintType != cty.NilType && !v.ConstraintType.Equals(cty.DynamicPseudoType) {
+			typeJSON, err := v.ConstraintType.MarshalJSON()
+			if err != nil {
+				return module, fmt.Errorf("failed to marshal %#v as JSON: %w", v.ConstraintType, err)
+			}
+			o.Type = typeJSON
+		}
 		if len(v.DependsOn) > 0 {
 			dependencies := make([]string, len(v.DependsOn))
 			for i, d := range v.DependsOn {
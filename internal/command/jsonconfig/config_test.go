@@ -268,6 +268,29 @@ func TestMarshalModule(t *testing.T) {
 				},
 			},
 		},
+		"output, collection type": {
+			Input: &configs.Config{
+				Module: &configs.Module{
+					Outputs: map[string]*configs.Output{
+						"example": {
+							Name:           "example",
+							ConstraintType: cty.List(cty.String),
+							Type:           cty.List(cty.String),
+						},
+					},
+				},
+			},
+			Schemas: emptySchemas,
+			Want: module{
+				Outputs: map[string]output{
+					"example": {
+						Type:       json.RawMessage(`["list","string"]`),
+						Expression: &expression{},
+					},
+				},
+				ModuleCalls: map[string]moduleCall{},
+			},
+		},
 		"resources": {
 			Input: &configs.Config{
 				Module: &configs.Module{
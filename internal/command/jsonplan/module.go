@@ -19,3 +19,23 @@ type Module struct {
 	// recursively describing the full module tree.
 	ChildModules []Module `json:"child_modules,omitempty"`
 }
+
+// DeferredModuleCall describes a module call whose planning OpenTofu deferred
+// to a later plan/apply round, because its instances could not be determined
+// yet.
+type DeferredModuleCall struct {
+	// Address is the absolute module call address, without an instance key
+	// on its last step.
+	Address string `json:"address"`
+
+	// ModuleAddress is the address of the module instance containing the
+	// call. Omitted if the call is in the root module.
+	ModuleAddress string `json:"module_address,omitempty"`
+
+	Name string `json:"name"`
+
+	// Reason is a keyword describing why the module call was deferred. The
+	// only possible value is "instance_count_unknown": the module call's
+	// count or for_each value is not known yet.
+	Reason string `json:"reason"`
+}
@@ -68,8 +68,14 @@ type Plan struct {
 	Config             json.RawMessage   `json:"configuration,omitempty"`
 	RelevantAttributes []ResourceAttr    `json:"relevant_attributes,omitempty"`
 	Checks             json.RawMessage   `json:"checks,omitempty"`
-	Timestamp          string            `json:"timestamp,omitempty"`
-	Errored            bool              `json:"errored"`
+	// DeferredResources lists the resources whose planning was deferred to
+	// a later round, sorted by address.
+	DeferredResources []DeferredResource `json:"deferred_resources,omitempty"`
+	// DeferredModuleCalls lists the module calls whose planning was deferred
+	// to a later round, sorted by address.
+	DeferredModuleCalls []DeferredModuleCall `json:"deferred_module_calls,omitempty"`
+	Timestamp           string               `json:"timestamp,omitempty"`
+	Errored             bool                 `json:"errored"`
 }
 
 func newPlan() *Plan {
@@ -289,6 +295,18 @@ func MarshalForLog(
 		return nil, fmt.Errorf("error in marshaling output changes: %w", err)
 	}
 
+	// output.DeferredResources
+	if p.Changes != nil {
+		output.DeferredResources, err = MarshalDeferredResources(p.Changes.Deferred)
+		if err != nil {
+			return nil, fmt.Errorf("error in marshaling deferred resources: %w", err)
+		}
+		output.DeferredModuleCalls, err = MarshalDeferredModuleCalls(p.Changes.DeferredModuleCalls)
+		if err != nil {
+			return nil, fmt.Errorf("error in marshaling deferred module calls: %w", err)
+		}
+	}
+
 	// output.Checks
 	if p.Checks != nil && p.Checks.ConfigResults.Len() > 0 {
 		output.Checks = jsonchecks.MarshalCheckStates(p.Checks)
@@ -701,6 +719,76 @@ func GenerateChange(beforeVal, afterVal cty.Value) (*Change, error) {
 	}, nil
 }
 
+// MarshalDeferredResources converts the given deferred resources into their
+// JSON representation, sorted by address.
+func MarshalDeferredResources(deferred []*plans.DeferredResource) ([]DeferredResource, error) {
+	var ret []DeferredResource
+	for _, d := range deferred {
+		r := DeferredResource{
+			Address:       d.Addr.String(),
+			ModuleAddress: d.Addr.Module.String(),
+			Type:          d.Addr.Resource.Type,
+			Name:          d.Addr.Resource.Name,
+		}
+
+		switch d.Addr.Resource.Mode {
+		case addrs.ManagedResourceMode:
+			r.Mode = jsonstate.ManagedResourceMode
+		case addrs.DataResourceMode:
+			r.Mode = jsonstate.DataResourceMode
+		case addrs.EphemeralResourceMode:
+			r.Mode = jsonstate.EphemeralResourceMode
+		default:
+			return nil, fmt.Errorf("resource %s has an unsupported mode %s", r.Address, d.Addr.Resource.Mode.String())
+		}
+
+		switch d.Reason {
+		case plans.DeferredReasonInstanceCountUnknown:
+			r.Reason = "instance_count_unknown"
+		case plans.DeferredReasonDeferredPrereq:
+			r.Reason = "deferred_prereq"
+		default:
+			return nil, fmt.Errorf("resource %s has an unsupported deferral reason %s", r.Address, d.Reason)
+		}
+
+		ret = append(ret, r)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Address < ret[j].Address
+	})
+
+	return ret, nil
+}
+
+// MarshalDeferredModuleCalls converts the given deferred module calls into
+// their JSON representation, sorted by address.
+func MarshalDeferredModuleCalls(deferred []*plans.DeferredModuleCall) ([]DeferredModuleCall, error) {
+	var ret []DeferredModuleCall
+	for _, d := range deferred {
+		c := DeferredModuleCall{
+			Address:       d.Addr.String(),
+			ModuleAddress: d.Addr.Module.String(),
+			Name:          d.Addr.Call.Name,
+		}
+
+		switch d.Reason {
+		case plans.DeferredReasonInstanceCountUnknown:
+			c.Reason = "instance_count_unknown"
+		default:
+			return nil, fmt.Errorf("module call %s has an unsupported deferral reason %s", c.Address, d.Reason)
+		}
+
+		ret = append(ret, c)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Address < ret[j].Address
+	})
+
+	return ret, nil
+}
+
 // MarshalOutputChanges converts the provided internal representation of
 // Changes objects into the structured JSON representation.
 //
@@ -562,3 +562,108 @@ func TestGenerateChange(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalDeferredResources(t *testing.T) {
+	deferred := []*plans.DeferredResource{
+		{
+			Addr: addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_thing",
+				Name: "downstream",
+			}.Absolute(addrs.RootModuleInstance.Child("child", addrs.IntKey(0))),
+			Reason: plans.DeferredReasonDeferredPrereq,
+		},
+		{
+			Addr: addrs.Resource{
+				Mode: addrs.DataResourceMode,
+				Type: "test_source",
+				Name: "counted",
+			}.Absolute(addrs.RootModuleInstance),
+			Reason: plans.DeferredReasonInstanceCountUnknown,
+		},
+	}
+
+	got, err := MarshalDeferredResources(deferred)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []DeferredResource{
+		{
+			Address: "data.test_source.counted",
+			Mode:    "data",
+			Type:    "test_source",
+			Name:    "counted",
+			Reason:  "instance_count_unknown",
+		},
+		{
+			Address:       "module.child[0].test_thing.downstream",
+			ModuleAddress: "module.child[0]",
+			Mode:          "managed",
+			Type:          "test_thing",
+			Name:          "downstream",
+			Reason:        "deferred_prereq",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	_, err = MarshalDeferredResources([]*plans.DeferredResource{
+		{
+			Addr: addrs.Resource{
+				Mode: addrs.ManagedResourceMode,
+				Type: "test_thing",
+				Name: "invalid",
+			}.Absolute(addrs.RootModuleInstance),
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an invalid deferral reason")
+	}
+}
+
+func TestMarshalDeferredModuleCalls(t *testing.T) {
+	deferred := []*plans.DeferredModuleCall{
+		{
+			Addr:   addrs.RootModuleInstance.Child("parent", addrs.StringKey("a")).ChildCall("child"),
+			Reason: plans.DeferredReasonInstanceCountUnknown,
+		},
+		{
+			Addr:   addrs.RootModuleInstance.ChildCall("counted"),
+			Reason: plans.DeferredReasonInstanceCountUnknown,
+		},
+	}
+
+	got, err := MarshalDeferredModuleCalls(deferred)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []DeferredModuleCall{
+		{
+			Address: "module.counted",
+			Name:    "counted",
+			Reason:  "instance_count_unknown",
+		},
+		{
+			Address:       `module.parent["a"].module.child`,
+			ModuleAddress: `module.parent["a"]`,
+			Name:          "child",
+			Reason:        "instance_count_unknown",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	_, err = MarshalDeferredModuleCalls([]*plans.DeferredModuleCall{
+		{
+			Addr:   addrs.RootModuleInstance.ChildCall("prereq"),
+			Reason: plans.DeferredReasonDeferredPrereq,
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unsupported deferral reason")
+	}
+}
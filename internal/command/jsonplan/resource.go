@@ -103,3 +103,29 @@ type ResourceChange struct {
 	// and treat them as an unspecified reason.
 	ActionReason string `json:"action_reason,omitempty"`
 }
+
+// DeferredResource describes a resource whose planning OpenTofu deferred to a
+// later plan/apply round, because its instances could not be determined yet.
+type DeferredResource struct {
+	// Address is the absolute resource address
+	Address string `json:"address"`
+
+	// ModuleAddress is the module portion of the above address. Omitted if the
+	// resource is in the root module.
+	ModuleAddress string `json:"module_address,omitempty"`
+
+	// "managed", "data" or "ephemeral"
+	Mode string `json:"mode"`
+
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Reason is a keyword describing why the resource was deferred. The
+	// possible values are:
+	//
+	//   - "instance_count_unknown": the resource's count or for_each value
+	//     is not known yet.
+	//   - "deferred_prereq": the resource depends on another deferred
+	//     resource.
+	Reason string `json:"reason"`
+}
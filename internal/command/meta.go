@@ -37,6 +37,8 @@ import (
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/plugins"
+	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/providerdaemon"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/provisioners"
 	"github.com/opentofu/opentofu/internal/states"
@@ -147,6 +149,15 @@ type Meta struct {
 	// just trusting that someone else did it before running OpenTofu.
 	UnmanagedProviders map[addrs.Provider]*plugin.ReattachConfig
 
+	// ProviderInstallPolicy, if set, restricts which providers and provider
+	// versions the provider installer may select.
+	ProviderInstallPolicy *providercache.InstallPolicy
+
+	// ProviderDaemon, if set, is a client for a "tofu providers serve"
+	// process that commands should ask for already-running provider
+	// processes instead of starting their own.
+	ProviderDaemon *providerdaemon.Client
+
 	// ----------------------------------------------------------
 	// Protected: commands can set these
 	// ----------------------------------------------------------
@@ -204,6 +215,7 @@ type Meta struct {
 	// when the providers sources are built.
 	ProviderSourceLocationConfig getproviders.LocationConfig
 	OCICredentialsPolicyBuilder  oci.OCICredsPolicyBuilder
+	OCISignaturePoliciesBuilder  oci.OCISignaturePoliciesBuilder
 }
 
 type testingOverrides struct {
@@ -400,10 +412,12 @@ func (m *Meta) contextOpts(ctx context.Context) (*tofu.ContextOpts, error) {
 		)
 	} else {
 		var providerFactories map[addrs.Provider]providers.Factory
-		providerFactories, err = m.providerFactories()
-		opts.Plugins = plugins.NewLibrary(
+		var schemaStore plugins.ProviderSchemaStore
+		providerFactories, schemaStore, err = m.providerFactories(ctx)
+		opts.Plugins = plugins.NewLibraryWithSchemaStore(
 			providerFactories,
 			m.provisionerFactories(),
+			schemaStore,
 		)
 	}
 
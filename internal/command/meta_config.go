@@ -86,7 +86,7 @@ func (m *Meta) loadSingleModule(ctx context.Context, dir string, load configs.Se
 
 	module, hclDiags := m.configLoader().LoadConfigDirSelective(dir, load)
 	diags = diags.Append(hclDiags)
-	diags = diags.Append(module.WithStaticCall(call))
+	diags = diags.Append(module.WithStaticCall(ctx, call))
 	return module, diags
 }
 
@@ -170,7 +170,7 @@ func (m *Meta) loadSingleModuleWithTests(ctx context.Context, dir string, testDi
 
 	module, hclDiags := m.configLoader().LoadConfigDirWithTests(dir, testDir)
 	diags = diags.Append(hclDiags)
-	diags = diags.Append(module.WithStaticCall(call))
+	diags = diags.Append(module.WithStaticCall(ctx, call))
 	return module, diags
 }
 
@@ -0,0 +1,88 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/cliconfig/ociauthconfig"
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ociSigningKeyPassphraseEnvVar is the environment variable that holds the
+// password of an encrypted key given to the "-signing-key" option of the
+// commands that publish packages to OCI registries. We don't accept the
+// password as a command line option because it would then be visible to
+// other users of the system.
+const ociSigningKeyPassphraseEnvVar = "TF_OCI_SIGNING_KEY_PASSPHRASE"
+
+// ociPushStore returns a store for publishing packages to the given OCI
+// repository, using the same OCI credentials from the CLI configuration that
+// the installers use.
+func (m *Meta) ociPushStore(ctx context.Context, addr oci.OCIRepositoryAddress) (oci.OCIRepositoryPushStore, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	var credsPolicy ociauthconfig.CredentialsConfigs
+	if m.OCICredentialsPolicyBuilder != nil {
+		var err error
+		credsPolicy, err = m.OCICredentialsPolicyBuilder(ctx)
+		if err != nil {
+			// This deals with only a small number of errors that we can't catch during CLI config validation
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid credentials configuration for OCI registries",
+				fmt.Sprintf("Cannot determine the credentials to use for %s: %s.", addr.RegistryDomain, err),
+			))
+			return nil, diags
+		}
+	}
+
+	store, err := oci.GetOCIRepositoryPushStore(ctx, addr.RegistryDomain, addr.RepositoryName, credsPolicy)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to configure OCI registry client",
+			fmt.Sprintf("Cannot configure a client for oci://%s: %s.", addr, err),
+		))
+		return nil, diags
+	}
+	return store, diags
+}
+
+// ociPushOptions returns the options for publishing packages to an OCI
+// registry based on the given command line options, loading the signing key
+// if there is one.
+//
+// The second result describes the signing key for display in the UI, or is
+// empty if the published manifest will not be signed.
+func (m *Meta) ociPushOptions(args *arguments.OCIPush) (oci.PushOptions, string, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	opts := oci.PushOptions{
+		Annotations: args.Annotations,
+	}
+	if args.SigningKeyFile == "" {
+		return opts, "", diags
+	}
+
+	src, err := os.ReadFile(args.SigningKeyFile)
+	if err == nil {
+		opts.SigningKey, err = cosign.ParsePrivateKeyPEM(src, []byte(os.Getenv(ociSigningKeyPassphraseEnvVar)))
+	}
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid signing key",
+			fmt.Sprintf("Cannot use the signing key in %s: %s.", args.SigningKeyFile, err),
+		))
+		return opts, "", diags
+	}
+	return opts, "key " + cosign.KeyID(opts.SigningKey.Public()), diags
+}
@@ -13,6 +13,7 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 
@@ -24,7 +25,9 @@ import (
 	"github.com/opentofu/opentofu/internal/logging"
 	tfplugin "github.com/opentofu/opentofu/internal/plugin"
 	tfplugin6 "github.com/opentofu/opentofu/internal/plugin6"
+	"github.com/opentofu/opentofu/internal/plugins"
 	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/providerdaemon"
 	"github.com/opentofu/opentofu/internal/providers"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -83,6 +86,7 @@ func (m *Meta) providerInstallerCustomSource(source getproviders.Source) *provid
 		unmanagedProviderTypes[ty] = struct{}{}
 	}
 	inst.SetUnmanagedProviderTypes(unmanagedProviderTypes)
+	inst.SetInstallPolicy(m.ProviderInstallPolicy)
 	return inst
 }
 
@@ -217,10 +221,15 @@ func (m *Meta) providerDevOverrideRuntimeWarnings() tfdiags.Diagnostics {
 // package have been modified outside of the installer. If it returns an error,
 // the returned map may be incomplete or invalid, but will be as complete
 // as possible given the cause of the error.
-func (m *Meta) providerFactories() (map[addrs.Provider]providers.Factory, error) {
+//
+// If the global plugin cache directory is enabled then providerFactories also
+// returns a schema store that persists provider schemas in that directory,
+// keyed by the checksums recorded in the dependency lock file. Otherwise, the
+// returned store is nil.
+func (m *Meta) providerFactories(ctx context.Context) (map[addrs.Provider]providers.Factory, plugins.ProviderSchemaStore, error) {
 	locks, diags := m.lockedDependencies()
 	if diags.HasErrors() {
-		return nil, fmt.Errorf("failed to read dependency lock file: %w", diags.Err())
+		return nil, nil, fmt.Errorf("failed to read dependency lock file: %w", diags.Err())
 	}
 
 	// We'll always run through all of our providers, even if one of them
@@ -236,6 +245,11 @@ func (m *Meta) providerFactories() (map[addrs.Provider]providers.Factory, error)
 	providerLocks := locks.AllProviders()
 	cacheDir := providercache.NewDir(m.WorkingDir.ProviderLocalCacheDir())
 
+	var schemaStore *providerSchemaStore
+	if globalCacheDir := m.providerGlobalCacheDir(); globalCacheDir != nil {
+		schemaStore = newProviderSchemaStore(providercache.NewSchemaCacheForDir(globalCacheDir))
+	}
+
 	// The internal providers are _always_ available, even if the configuration
 	// doesn't request them, because they don't need any special installation
 	// and they'll just be ignored if not used.
@@ -310,7 +324,17 @@ func (m *Meta) providerFactories() (map[addrs.Provider]providers.Factory, error)
 		// This should only ever be called once per provider type.
 		// It creates the schema cache to be re-used in all of the subsequent
 		// provider instances.
-		factory := providerFactory(cached)
+		var factory providers.Factory
+		var store plugins.ProviderSchemaStore
+		if schemaStore != nil {
+			schemaStore.keys[provider] = providercache.SchemaCacheKey(provider, version, lock.AllHashes())
+			store = schemaStore
+		}
+		if m.ProviderDaemon != nil && len(lock.AllHashes()) != 0 {
+			factory = daemonProviderFactory(ctx, m.ProviderDaemon, cached, lock.AllHashes(), store)
+		} else {
+			factory = providerFactory(cached, store)
+		}
 
 		factories[provider] = func() (providers.Interface, error) {
 			checkLock.Lock()
@@ -338,7 +362,12 @@ func (m *Meta) providerFactories() (map[addrs.Provider]providers.Factory, error)
 	if len(errs) > 0 {
 		err = providerPluginErrors(errs)
 	}
-	return factories, err
+	if schemaStore == nil {
+		// We must return an untyped nil here, so that callers can compare
+		// the result with nil.
+		return factories, nil, err
+	}
+	return factories, schemaStore, err
 }
 
 func (m *Meta) internalProviders() map[string]providers.Factory {
@@ -352,8 +381,15 @@ func (m *Meta) internalProviders() map[string]providers.Factory {
 // providerFactory produces a provider factory that runs up the executable
 // file in the given cache package and uses go-plugin to implement
 // providers.Interface against it.
-func providerFactory(meta *providercache.CachedProvider) providers.Factory {
+//
+// If schemaStore is not nil then the provider instances will prefer to use
+// a schema from that store, if available, and will save the schema they
+// read from the provider into it otherwise.
+func providerFactory(meta *providercache.CachedProvider, schemaStore plugins.ProviderSchemaStore) providers.Factory {
 	schemaCache := providers.NewSchemaCache()
+	if schemaStore != nil {
+		schemaCache = storedSchemaCache(schemaCache, meta.Provider, schemaStore)
+	}
 
 	return func() (providers.Interface, error) {
 		execFile, err := meta.ExecutableFile()
@@ -424,7 +460,7 @@ func devOverrideProviderFactory(provider addrs.Provider, localDir getproviders.P
 		Provider:   provider,
 		Version:    getproviders.UnspecifiedVersion,
 		PackageDir: string(localDir),
-	})
+	}, nil)
 }
 
 // unmanagedProviderFactory produces a provider factory that uses the passed
@@ -434,56 +470,150 @@ func unmanagedProviderFactory(provider addrs.Provider, reattach *plugin.Reattach
 	schemaCache := providers.NewSchemaCache()
 
 	return func() (providers.Interface, error) {
-		config := &plugin.ClientConfig{
-			HandshakeConfig:  tfplugin.Handshake,
-			Logger:           logging.NewProviderLogger("unmanaged."),
-			AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
-			Managed:          false,
-			Reattach:         reattach,
-			SyncStdout:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stdout", provider)),
-			SyncStderr:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stderr", provider)),
-		}
+		p, _, err := reattachProvider(provider, reattach, "unmanaged.", schemaCache)
+		return p, err
+	}
+}
 
-		if reattach.ProtocolVersion == 0 {
-			// As of the 0.15 release, sdk.v2 doesn't include the protocol
-			// version in the ReattachConfig (only recently added to
-			// go-plugin), so client.NegotiatedVersion() always returns 0. We
-			// assume that an unmanaged provider reporting protocol version 0 is
-			// actually using proto v5 for backwards compatibility.
-			if defaultPlugins, ok := tfplugin.VersionedPlugins[5]; ok {
-				config.Plugins = defaultPlugins
-			} else {
-				return nil, errors.New("no supported plugins for protocol 0")
-			}
-		} else if plugins, ok := tfplugin.VersionedPlugins[reattach.ProtocolVersion]; !ok {
-			return nil, fmt.Errorf("no supported plugins for protocol %d", reattach.ProtocolVersion)
-		} else {
-			config.Plugins = plugins
-		}
+// daemonProviderFactory produces a provider factory that asks the given
+// provider daemon for an already-running process of the given cached
+// provider package, and implements providers.Interface against it.
+//
+// If the daemon cannot provide a process then the factory starts the
+// provider itself, in the same way as [providerFactory].
+func daemonProviderFactory(ctx context.Context, daemon *providerdaemon.Client, meta *providercache.CachedProvider, hashes []getproviders.Hash, schemaStore plugins.ProviderSchemaStore) providers.Factory {
+	schemaCache := providers.NewSchemaCache()
+	if schemaStore != nil {
+		schemaCache = storedSchemaCache(schemaCache, meta.Provider, schemaStore)
+	}
+	fallback := providerFactory(meta, schemaStore)
 
-		client := plugin.NewClient(config)
-		rpcClient, err := client.Client()
+	return func() (providers.Interface, error) {
+		reattach, err := checkoutDaemonProvider(ctx, daemon, meta, hashes)
 		if err != nil {
-			return nil, err
+			log.Printf("[WARN] Starting %s v%s directly because the provider daemon cannot provide it: %s", meta.Provider, meta.Version, err)
+			return fallback()
 		}
-
-		raw, err := rpcClient.Dispense(tfplugin.ProviderPluginName)
+		log.Printf("[DEBUG] Using %s v%s process %d from the provider daemon", meta.Provider, meta.Version, reattach.Pid)
+
+		// go-plugin can only notice that a reattached process has exited by
+		// polling for it once per second, so we mark the process as a test
+		// process to stop go-plugin waiting for it when the provider is
+		// closed, and instead shut it down ourselves in daemonProvider.Close.
+		reattach.Test = true
+		p, client, err := reattachProvider(meta.Provider, reattach, "daemon.", schemaCache)
 		if err != nil {
 			return nil, err
 		}
+		return daemonProvider{Interface: p, client: client}, nil
+	}
+}
 
-		protoVer := client.NegotiatedVersion()
-		if protoVer == 0 {
-			// As of the 0.15 release, sdk.v2 doesn't include the protocol
-			// version in the ReattachConfig (only recently added to
-			// go-plugin), so client.NegotiatedVersion() always returns 0. We
-			// assume that an unmanaged provider reporting protocol version 0 is
-			// actually using proto v5 for backwards compatibility.
-			protoVer = 5
+// daemonProvider is a provider whose process was started by a provider
+// daemon.
+type daemonProvider struct {
+	providers.Interface
+	client *plugin.Client
+}
+
+// Close asks the provider process to exit, without waiting for it to do so.
+// The provider daemon is responsible for cleaning up after the process.
+func (p daemonProvider) Close(ctx context.Context) error {
+	if err := p.Interface.Close(ctx); err != nil {
+		return err
+	}
+	rpcClient, err := p.client.Client()
+	if err != nil {
+		return err
+	}
+	return rpcClient.Close()
+}
+
+func checkoutDaemonProvider(ctx context.Context, daemon *providerdaemon.Client, meta *providercache.CachedProvider, hashes []getproviders.Hash) (*plugin.ReattachConfig, error) {
+	packageDir, err := filepath.Abs(meta.PackageDir)
+	if err != nil {
+		return nil, err
+	}
+	// The daemon identifies a package by its directory, so we resolve the
+	// link that "tofu init" creates to a package in the global plugin cache
+	// directory so that the package has the same identity however it was
+	// installed into this working directory.
+	packageDir, err = filepath.EvalSymlinks(packageDir)
+	if err != nil {
+		return nil, err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	hashStrs := make([]string, len(hashes))
+	for i, hash := range hashes {
+		hashStrs[i] = hash.String()
+	}
+	return daemon.Checkout(ctx, providerdaemon.CheckoutRequest{
+		Provider:   meta.Provider.String(),
+		Version:    meta.Version.String(),
+		PackageDir: packageDir,
+		Hashes:     hashStrs,
+		Dir:        wd,
+		Env:        os.Environ(),
+		ClientPID:  os.Getpid(),
+	})
+}
+
+// reattachProvider uses go-plugin to connect to the already-running provider
+// process described by the given reattach information.
+func reattachProvider(provider addrs.Provider, reattach *plugin.ReattachConfig, loggerPrefix string, schemaCache providers.SchemaCache) (providers.Interface, *plugin.Client, error) {
+	config := &plugin.ClientConfig{
+		HandshakeConfig:  tfplugin.Handshake,
+		Logger:           logging.NewProviderLogger(loggerPrefix),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Managed:          false,
+		Reattach:         reattach,
+		SyncStdout:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stdout", provider)),
+		SyncStderr:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stderr", provider)),
+	}
+
+	if reattach.ProtocolVersion == 0 {
+		// As of the 0.15 release, sdk.v2 doesn't include the protocol
+		// version in the ReattachConfig (only recently added to
+		// go-plugin), so client.NegotiatedVersion() always returns 0. We
+		// assume that an unmanaged provider reporting protocol version 0 is
+		// actually using proto v5 for backwards compatibility.
+		if defaultPlugins, ok := tfplugin.VersionedPlugins[5]; ok {
+			config.Plugins = defaultPlugins
+		} else {
+			return nil, nil, errors.New("no supported plugins for protocol 0")
 		}
+	} else if plugins, ok := tfplugin.VersionedPlugins[reattach.ProtocolVersion]; !ok {
+		return nil, nil, fmt.Errorf("no supported plugins for protocol %d", reattach.ProtocolVersion)
+	} else {
+		config.Plugins = plugins
+	}
+
+	client := plugin.NewClient(config)
+	rpcClient, err := client.Client()
+	if err != nil {
+		return nil, nil, err
+	}
 
-		return initializeProviderInstance(raw, protoVer, client, schemaCache)
+	raw, err := rpcClient.Dispense(tfplugin.ProviderPluginName)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	protoVer := client.NegotiatedVersion()
+	if protoVer == 0 {
+		// As of the 0.15 release, sdk.v2 doesn't include the protocol
+		// version in the ReattachConfig (only recently added to
+		// go-plugin), so client.NegotiatedVersion() always returns 0. We
+		// assume that an unmanaged provider reporting protocol version 0 is
+		// actually using proto v5 for backwards compatibility.
+		protoVer = 5
+	}
+
+	p, err := initializeProviderInstance(raw, protoVer, client, schemaCache)
+	return p, client, err
 }
 
 // providerPluginErrors is an error implementation we can return from
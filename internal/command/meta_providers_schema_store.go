@@ -0,0 +1,90 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"log"
+	"sync"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/plugins"
+	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/providers"
+)
+
+// providerSchemaStore is the implementation of [plugins.ProviderSchemaStore]
+// used by [Meta.providerFactories], which persists schemas in a
+// [providercache.SchemaCache] using keys derived from the dependency lock
+// file.
+//
+// Providers without a key, such as development overrides, are never stored.
+type providerSchemaStore struct {
+	cache *providercache.SchemaCache
+	keys  map[addrs.Provider]string
+
+	// loaded memoizes the schemas loaded from the cache, because both the
+	// plugin library and each provider's own schema cache will ask for the
+	// same schema and some providers have very large schemas.
+	mu     sync.Mutex
+	loaded map[addrs.Provider]providers.ProviderSchema
+}
+
+var _ plugins.ProviderSchemaStore = (*providerSchemaStore)(nil)
+
+func newProviderSchemaStore(cache *providercache.SchemaCache) *providerSchemaStore {
+	return &providerSchemaStore{
+		cache:  cache,
+		keys:   make(map[addrs.Provider]string),
+		loaded: make(map[addrs.Provider]providers.ProviderSchema),
+	}
+}
+
+func (s *providerSchemaStore) LoadProviderSchema(addr addrs.Provider) (providers.ProviderSchema, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if schema, ok := s.loaded[addr]; ok {
+		return schema, true
+	}
+	schema, ok := s.cache.Load(s.keys[addr])
+	if ok {
+		s.loaded[addr] = schema
+	}
+	return schema, ok
+}
+
+func (s *providerSchemaStore) StoreProviderSchema(addr addrs.Provider, schema providers.ProviderSchema) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.loaded[addr]; ok {
+		return // already stored
+	}
+	if err := s.cache.Store(s.keys[addr], schema); err != nil {
+		// The cache is just an optimization, so we'll continue without it.
+		log.Printf("[WARN] Failed to save the schema for %s in the schema cache: %s", addr, err)
+		return
+	}
+	s.loaded[addr] = schema
+}
+
+// storedSchemaCache wraps the given schema cache so that the first request
+// for a schema will try the given store before asking the provider, and will
+// save the provider's response into the store otherwise.
+func storedSchemaCache(schemaCache providers.SchemaCache, addr addrs.Provider, store plugins.ProviderSchemaStore) providers.SchemaCache {
+	return func(getSchema func() providers.ProviderSchema) providers.ProviderSchema {
+		return schemaCache(func() providers.ProviderSchema {
+			if schema, ok := store.LoadProviderSchema(addr); ok {
+				return schema
+			}
+			schema := getSchema()
+			if !schema.Diagnostics.HasErrors() {
+				store.StoreProviderSchema(addr, schema)
+			}
+			return schema
+		})
+	}
+}
@@ -6,8 +6,12 @@
 package command
 
 import (
+	"log"
+
 	"github.com/opentofu/opentofu/internal/command/arguments"
 	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/lang"
 )
 
 func MetadataFunctionsCommander() Command {
@@ -20,7 +24,7 @@ func MetadataFunctionsCommander() Command {
 	arguments.BindMetadataFunctions(&cmd.CommandLine)
 	cmd.Run = func(meta Meta) int {
 		view := views.NewMetadataFunctions(meta.View)
-		if !view.PrintFunctions() {
+		if !view.PrintFunctions(metadataModuleFunctions(&meta)) {
 			return 1
 		}
 		return 0
@@ -29,6 +33,29 @@ func MetadataFunctionsCommander() Command {
 	return cmd
 }
 
+// metadataModuleFunctions returns the functions declared in "function" blocks
+// of the root module in the current working directory, if there is one.
+//
+// The built-in functions are still useful to list when the configuration is
+// invalid, and the output must be valid JSON, so any problems loading the
+// configuration are only logged.
+func metadataModuleFunctions(meta *Meta) map[string]*lang.UserFunction {
+	if meta.WorkingDir == nil {
+		return nil
+	}
+	dir := meta.WorkingDir.NormalizePath(meta.WorkingDir.RootModuleDir())
+	if empty, err := configs.IsEmptyDir(dir); err != nil || empty {
+		return nil
+	}
+
+	mod, diags := meta.configLoader().LoadConfigDirSelective(dir, configs.SelectiveLoadAll)
+	if diags.HasErrors() || mod == nil {
+		log.Printf("[WARN] metadata functions: not including module functions from invalid configuration in %s: %s", dir, diags.Error())
+		return nil
+	}
+	return mod.UserFunctions()
+}
+
 // MetadataFunctionsCommand is a Command implementation that prints out information
 // about the available functions in OpenTofu.
 type MetadataFunctionsCommand struct {
@@ -50,5 +77,7 @@ func (c *MetadataFunctionsCommand) Run(rawArgs []string) int {
 const metadataFunctionsCommandHelp = `
 Usage: tofu [global options] metadata functions -json
 
-  Prints out a json representation of the available function signatures.
+  Prints out a json representation of the available function signatures,
+  including any functions declared in "function" blocks of the configuration
+  in the current working directory.
 `
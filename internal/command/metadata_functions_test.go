@@ -7,6 +7,8 @@ package command
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/opentofu/opentofu/internal/command/workdir"
@@ -67,6 +69,52 @@ func TestMetadataFunctions_output(t *testing.T) {
 	}
 }
 
+func TestMetadataFunctions_moduleFunctions(t *testing.T) {
+	td := t.TempDir()
+	err := os.WriteFile(filepath.Join(td, "main.tf"), []byte(`
+function "greet" {
+  description = "Greets someone."
+  parameter "name" {
+    type = string
+  }
+  result = "Hello, ${name}!"
+}
+`), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(td)
+
+	view, done := testView(t)
+	meta := Meta{
+		WorkingDir: workdir.NewDir("."),
+		View:       view,
+	}
+
+	code := RunCommander(t, MetadataFunctionsCommander(), meta, []string{"-json"})
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("wrong exit status %d; want 0\nstderr: %s", code, output.Stderr())
+	}
+
+	var got functions
+	if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	gotGreet, ok := got.Signatures["module::greet"]
+	if !ok {
+		t.Fatal(`missing function signature for "module::greet"`)
+	}
+	wantGreet := `{"description":"Greets someone.","return_type":"dynamic","parameters":[{"name":"name","is_nullable":true,"type":"string"}]}`
+	if string(gotGreet) != wantGreet {
+		t.Fatalf("wrong function signature for \"module::greet\":\ngot: %q\nwant: %q", gotGreet, wantGreet)
+	}
+	if _, ok := got.Signatures["max"]; !ok {
+		t.Fatal(`missing function signature for "max"`)
+	}
+}
+
 type functions struct {
 	FormatVersion string                     `json:"format_version"`
 	Signatures    map[string]json.RawMessage `json:"function_signatures,omitempty"`
@@ -0,0 +1,52 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+func ModulesCommander() Command {
+	cmd := Command{
+		Name:  "modules",
+		Short: "Work with module packages",
+		Long:  "This command has subcommands for working with module packages.",
+
+		Commands: []Command{
+			ModulesOutdatedCommander(),
+			ModulesPushCommander(),
+			ModulesTreeCommander(),
+		},
+	}
+
+	return cmd
+}
+
+// ModulesCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type ModulesCommand struct {
+	Meta
+}
+
+func (c *ModulesCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *ModulesCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] modules <subcommand> [options] [args]
+
+  This command has subcommands for working with module packages.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *ModulesCommand) Synopsis() string {
+	return "Work with module packages"
+}
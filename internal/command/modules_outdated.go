@@ -0,0 +1,130 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/cliconfig/ociauthconfig"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ModulesOutdatedCommander() Command {
+	cmd := Command{
+		Name:  "outdated",
+		Short: "List newer versions of the installed modules",
+		Long:  `Lists the versions of each module package installed for the current configuration that are newer than the installed version, separated into those that the module calls' version constraints allow and those that they do not.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindModulesOutdated(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ModulesOutdatedCommand{meta}.Execute(args, views.NewModulesOutdated(args.View, meta.View))
+	}
+
+	return cmd
+}
+
+// ModulesOutdatedCommand is a Command implementation that implements the
+// "tofu modules outdated" command, which lists the newer versions of the
+// modules installed for the current configuration.
+type ModulesOutdatedCommand struct {
+	Meta
+}
+
+func (c *ModulesOutdatedCommand) Help() string {
+	return `
+Usage: tofu [global options] modules outdated [options]
+
+  Lists the versions of each module package installed for the current
+  configuration that are newer than the installed version, separated into
+  those that the module calls' version constraints allow and those that
+  they do not.
+
+  Only modules from a module registry or an OCI repository have versions.
+  For OCI sources, the versions are the tags of the repository that are
+  version numbers, and the installed version is the one selected by the
+  "tag" argument of the source address.
+
+  Run "tofu init" before this command to install the modules.
+
+Options:
+
+  -json              Produce output in a machine-readable JSON format.
+
+  -var 'foo=bar'     Set a value for one of the input variables in the root
+                     module of the configuration. Use this option more than
+                     once to set more than one variable.
+
+  -var-file=filename Load variable values from the given file, in addition
+                     to the default files terraform.tfvars and *.auto.tfvars.
+                     Use this option more than once to include more than one
+                     variables file.
+`
+}
+
+func (c *ModulesOutdatedCommand) Synopsis() string {
+	return "List newer versions of the installed modules"
+}
+
+func (c *ModulesOutdatedCommand) Run(rawArgs []string) int {
+	return RunCommand(ModulesOutdatedCommander(), c.Meta, rawArgs)
+}
+
+func (c ModulesOutdatedCommand) Execute(args *arguments.ModulesOutdated, view views.ModulesOutdated) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	config, confDiags := c.loadConfig(ctx, ".")
+	diags = diags.Append(confDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	modules, moreDiags := initwd.CheckModuleVersions(ctx, config, c.modulePackageVersionSource(ctx))
+	diags = diags.Append(moreDiags)
+
+	// Any errors here are about retrieving the versions of individual
+	// module calls, which the report marks as unchecked, so we still
+	// print the report for all of the others.
+	ok := view.Outdated(modules)
+	view.Diagnostics(diags)
+	if !ok || diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+// modulePackageVersionSource returns the source used to retrieve the
+// available versions of module packages, which uses the same module registry
+// client and OCI credentials from the CLI configuration as the module
+// installer.
+func (m *Meta) modulePackageVersionSource(ctx context.Context) initwd.ModulePackageVersionSource {
+	return initwd.NewModulePackageVersionSource(
+		m.registryClient(ctx),
+		func(ctx context.Context, registryDomain, repositoryName string) (initwd.OCIRepositoryTagLister, error) {
+			var credsPolicy ociauthconfig.CredentialsConfigs
+			if m.OCICredentialsPolicyBuilder != nil {
+				var err error
+				credsPolicy, err = m.OCICredentialsPolicyBuilder(ctx)
+				if err != nil {
+					// This deals with only a small number of errors that we can't catch during CLI config validation
+					return nil, fmt.Errorf("invalid credentials configuration for OCI registries: %w", err)
+				}
+			}
+			return oci.GetOCIRepositoryStore(ctx, registryDomain, repositoryName, credsPolicy)
+		},
+	)
+}
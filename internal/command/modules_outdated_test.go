@@ -0,0 +1,37 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/command/workdir"
+)
+
+func TestModulesOutdated_localModules(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("modules"), td)
+	t.Chdir(td)
+
+	view, done := testView(t)
+	meta := Meta{
+		WorkingDir: workdir.NewDir("."),
+		View:       view,
+	}
+	code := RunCommander(t, ModulesOutdatedCommander(), meta, nil)
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+	}
+
+	// Local modules don't have versions, so there's nothing to report.
+	want := "The configuration does not call any modules from a module registry or OCI repository.\n"
+	if diff := cmp.Diff(want, output.Stdout()); diff != "" {
+		t.Errorf("wrong output\n%s", diff)
+	}
+}
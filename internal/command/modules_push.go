@@ -0,0 +1,140 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ModulesPushCommander() Command {
+	cmd := Command{
+		Name:  "push",
+		Short: "Publish a module package to an OCI registry",
+		Long: `Publishes the module package in the given directory to a repository in an OCI registry, in the form that "tofu init" installs from module source addresses like "oci://example.com/network?tag=v1.0.0".
+
+Credentials for the registry are taken from the same CLI configuration and Docker-style configuration files that "tofu init" uses.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindModulesPush(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ModulesPushCommand{meta}.Execute(args, views.NewModulesPush(meta.View))
+	}
+
+	return cmd
+}
+
+// ModulesPushCommand is a Command implementation that implements the
+// "tofu modules push" command, which publishes a module package to an
+// OCI registry.
+type ModulesPushCommand struct {
+	Meta
+}
+
+func (c *ModulesPushCommand) Help() string {
+	return `
+Usage: tofu [global options] modules push [options] MODULE_DIR oci://REGISTRY/REPOSITORY?tag=TAG
+
+  Publishes the module package in the given directory to a repository in
+  an OCI registry, in the form that "tofu init" installs from module
+  source addresses like "oci://example.com/network?tag=v1.0.0".
+
+  The ".git" and ".terraform" subdirectories of the module directory are
+  not included in the package. If the address does not include a tag then
+  the package is published as "latest".
+
+  Credentials for the registry are taken from the same CLI configuration
+  and Docker-style configuration files that "tofu init" uses.
+
+Options:
+
+  -annotation=key=value  Add an annotation to the published manifest. Use
+                         this option multiple times to add more than one
+                         annotation.
+
+  -signing-key=path      Path of a PEM-encoded private key to sign the
+                         published manifest with, such as one created by
+                         "cosign generate-key-pair". If the key is
+                         encrypted, set its password in the
+                         TF_OCI_SIGNING_KEY_PASSPHRASE environment
+                         variable.
+`
+}
+
+func (c *ModulesPushCommand) Synopsis() string {
+	return "Publish a module package to an OCI registry"
+}
+
+func (c *ModulesPushCommand) Run(rawArgs []string) int {
+	return RunCommand(ModulesPushCommander(), c.Meta, rawArgs)
+}
+
+func (c ModulesPushCommand) Execute(args *arguments.ModulesPush, view views.ModulesPush) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	addr, tag, err := oci.ParseOCIRepositoryURL(args.Address)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid repository address",
+			fmt.Sprintf("Cannot publish to %q: %s.", args.Address, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+	if tag == "" {
+		// This is the same default that the module installer uses when
+		// a source address doesn't include a tag.
+		tag = "latest"
+	}
+	if info, err := os.Stat(args.Directory); err != nil || !info.IsDir() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid module directory",
+			fmt.Sprintf("The module package to publish must be a directory, but %s is not.", args.Directory),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	opts, signer, moreDiags := c.ociPushOptions(args.OCIPush)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+	store, moreDiags := c.ociPushStore(ctx, addr)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	desc, err := oci.PushModulePackage(ctx, store, addr, tag, args.Directory, opts)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to publish module package",
+			fmt.Sprintf("Cannot publish %s to oci://%s: %s.", args.Directory, addr, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	view.Diagnostics(diags)
+	view.Pushed(addr.String(), tag, desc.Digest.String(), signer)
+	return 0
+}
@@ -0,0 +1,98 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ModulesTreeCommander() Command {
+	cmd := Command{
+		Name:  "tree",
+		Short: "Show the installed modules and their latest versions",
+		Long:  `Prints the tree of module calls in the current configuration, including the calls in nested modules, with the installed version and the latest available version of each module package from a module registry or an OCI repository.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindModulesTree(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ModulesTreeCommand{meta}.Execute(args, views.NewModulesTree(args.View, meta.View))
+	}
+
+	return cmd
+}
+
+// ModulesTreeCommand is a Command implementation that implements the
+// "tofu modules tree" command, which prints the tree of module calls in the
+// current configuration along with their versions.
+type ModulesTreeCommand struct {
+	Meta
+}
+
+func (c *ModulesTreeCommand) Help() string {
+	return `
+Usage: tofu [global options] modules tree [options]
+
+  Prints the tree of module calls in the current configuration, including
+  the calls in nested modules, with the installed version and the latest
+  available version of each module package from a module registry or an
+  OCI repository.
+
+  Run "tofu init" before this command to install the modules.
+
+Options:
+
+  -json              Produce output in a machine-readable JSON format.
+
+  -var 'foo=bar'     Set a value for one of the input variables in the root
+                     module of the configuration. Use this option more than
+                     once to set more than one variable.
+
+  -var-file=filename Load variable values from the given file, in addition
+                     to the default files terraform.tfvars and *.auto.tfvars.
+                     Use this option more than once to include more than one
+                     variables file.
+`
+}
+
+func (c *ModulesTreeCommand) Synopsis() string {
+	return "Show the installed modules and their latest versions"
+}
+
+func (c *ModulesTreeCommand) Run(rawArgs []string) int {
+	return RunCommand(ModulesTreeCommander(), c.Meta, rawArgs)
+}
+
+func (c ModulesTreeCommand) Execute(args *arguments.ModulesTree, view views.ModulesTree) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	config, confDiags := c.loadConfig(ctx, ".")
+	diags = diags.Append(confDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	modules, moreDiags := initwd.CheckModuleVersions(ctx, config, c.modulePackageVersionSource(ctx))
+	diags = diags.Append(moreDiags)
+
+	// Any errors here are about retrieving the versions of individual
+	// module calls, which the report marks as unchecked, so we still
+	// print the report for all of the others.
+	ok := view.Tree(modules)
+	view.Diagnostics(diags)
+	if !ok || diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
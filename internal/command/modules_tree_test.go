@@ -0,0 +1,81 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/command/workdir"
+)
+
+func TestModulesTree(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("modules"), td)
+	t.Chdir(td)
+
+	t.Run("human", func(t *testing.T) {
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		}
+		code := RunCommander(t, ModulesTreeCommander(), meta, nil)
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+		}
+
+		want := `.
+├── module.child: ./child
+└── module.count_child: ./child
+
+`
+		if diff := cmp.Diff(want, output.Stdout()); diff != "" {
+			t.Errorf("wrong output\n%s", diff)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir: workdir.NewDir("."),
+			View:       view,
+		}
+		code := RunCommander(t, ModulesTreeCommander(), meta, []string{"-json"})
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+		}
+
+		var got map[string]any
+		if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+			t.Fatalf("invalid JSON output: %s\n%s", err, output.Stdout())
+		}
+		want := map[string]any{
+			"format_version": "1.0",
+			"modules": []any{
+				map[string]any{
+					"address": "module.child",
+					"name":    "child",
+					"source":  "./child",
+					"modules": []any{},
+				},
+				map[string]any{
+					"address": "module.count_child",
+					"name":    "count_child",
+					"source":  "./child",
+					"modules": []any{},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong output\n%s", diff)
+		}
+	})
+}
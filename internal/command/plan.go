@@ -149,6 +149,8 @@ func (c *PlanCommand) OperationRequest(
 	opReq.Targets = args.Targets
 	opReq.Excludes = args.Excludes
 	opReq.ForceReplace = args.ForceReplace
+	opReq.AllowDeferral = args.AllowDeferral
+	opReq.Profile = args.Profile
 	opReq.Type = backend.OperationTypePlan
 	opReq.View = view.Operation()
 
@@ -221,6 +223,18 @@ Plan Customization Options:
   -exclude-file=filename  Similar to -exclude, but specifies zero or more
                           resource addresses from a file.
 
+  -profile=name           Limit the planning operation using the targets or
+                          excludes of the given profile, declared in a
+                          "profile" block in the root module's "terraform"
+                          block.
+
+  -allow-deferral         Defer planning of resources and module calls whose
+                          count or for_each depends on values that will not
+                          be known until apply, and of everything that
+                          depends on them, instead of returning an error. Run
+                          another plan and apply afterwards to converge the
+                          deferred resources.
+
   -var 'foo=bar'          Set a value for one of the input variables in the
                           root module of the configuration. Use this option
                           more than once to set more than one variable.
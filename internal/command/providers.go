@@ -26,9 +26,14 @@ func ProvidersCommander() Command {
 This provides an overview of all of the provider requirements across all referenced modules, as an aid to understanding why particular provider plugins are needed and why particular versions are selected.`,
 
 		Commands: []Command{
+			ProvidersConformanceCommander(),
 			ProvidersLockCommander(),
 			ProvidersMirrorCommander(),
+			ProvidersOutdatedCommander(),
+			ProvidersPushCommander(),
 			ProvidersSchemaCommander(),
+			ProvidersServeCommander(),
+			ProvidersWhyCommander(),
 		},
 
 		DiagsWithNewline: true,
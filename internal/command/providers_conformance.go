@@ -0,0 +1,167 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	plugin "github.com/hashicorp/go-plugin"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/logging"
+	tfplugin "github.com/opentofu/opentofu/internal/plugin"
+	"github.com/opentofu/opentofu/internal/providerconformance"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ProvidersConformanceCommander() Command {
+	cmd := Command{
+		Name:  "conformance",
+		Short: "Check that a provider plugin follows the provider protocol",
+		Long:  `Starts the given provider plugin executable and drives it through the same sequence of calls that OpenTofu makes to validate, plan and apply a configuration, using a configuration generated from the schema of each managed resource type, and reports each response that breaks the rules OpenTofu enforces on providers.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindProvidersConformance(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ProvidersConformanceCommand{meta}.Execute(args, views.NewProvidersConformance(args.View, meta.View))
+	}
+
+	return cmd
+}
+
+// ProvidersConformanceCommand is a Command implementation that implements
+// the "tofu providers conformance" command, which checks that a provider
+// plugin follows the rules of the provider protocol.
+type ProvidersConformanceCommand struct {
+	Meta
+}
+
+func (c *ProvidersConformanceCommand) Help() string {
+	return `
+Usage: tofu [global options] providers conformance [options] EXECUTABLE
+
+  Starts the given provider plugin executable and drives it through the same
+  sequence of calls that OpenTofu makes to validate, plan and apply a
+  configuration, and reports each response that breaks the rules OpenTofu
+  enforces on providers, such as a plan that doesn't match the configuration
+  or an applied object that doesn't match the plan.
+
+  The checks use a configuration generated from the schema of each managed
+  resource type, which sets each required argument to a placeholder value
+  and leaves the others unset. The provider itself is configured the same
+  way, so use environment variables to set anything else it needs, such as
+  credentials.
+
+  By default, only the checks without side effects run. Use the -apply
+  option to also create a real object of each resource type, then read,
+  plan, import and destroy it.
+
+  Exits with a non-zero status if the provider breaks any rule that
+  OpenTofu enforces. Responses that OpenTofu tolerates, such as those from
+  providers using the legacy SDK type system, are reported as warnings.
+
+Options:
+
+  -apply             Also create, read, import and destroy a real object of
+                     each resource type, using the provider's credentials.
+
+  -json              Produce output in a machine-readable JSON format.
+
+  -resource=TYPE     Limit the checks to the given managed resource type.
+                     Use this option more than once to check more than one
+                     resource type.
+`
+}
+
+func (c *ProvidersConformanceCommand) Synopsis() string {
+	return "Check that a provider plugin follows the provider protocol"
+}
+
+func (c *ProvidersConformanceCommand) Run(rawArgs []string) int {
+	return RunCommand(ProvidersConformanceCommander(), c.Meta, rawArgs)
+}
+
+func (c ProvidersConformanceCommand) Execute(args *arguments.ProvidersConformance, view views.ProvidersConformance) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	provider, err := startProviderExecutable(args.Executable)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to start provider",
+			fmt.Sprintf("Could not start the provider plugin %s: %s.", args.Executable, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+	defer func() {
+		// The context might already be cancelled, but the plugin process
+		// must still be stopped.
+		_ = provider.Close(context.WithoutCancel(ctx))
+	}()
+
+	report, moreDiags := providerconformance.Check(ctx, provider, providerconformance.Options{
+		ResourceTypes: args.ResourceTypes,
+		Apply:         args.Apply,
+	})
+	diags = diags.Append(moreDiags)
+
+	view.Diagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	if !view.Report(report) {
+		return 1
+	}
+	if report.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+// startProviderExecutable starts the given provider plugin executable in
+// the same way as providerFactory starts the executable of an installed
+// provider package, supporting both protocol versions 5 and 6.
+func startProviderExecutable(execFile string) (providers.Interface, error) {
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  tfplugin.Handshake,
+		Logger:           logging.NewProviderLogger(""),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Managed:          true,
+		Cmd:              exec.Command(execFile),
+		AutoMTLS:         enableProviderAutoMTLS,
+		VersionedPlugins: tfplugin.VersionedPlugins,
+		SyncStdout:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stdout", execFile)),
+		SyncStderr:       logging.PluginOutputMonitor(fmt.Sprintf("%s:stderr", execFile)),
+	})
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+	raw, err := rpcClient.Dispense(tfplugin.ProviderPluginName)
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+
+	p, err := initializeProviderInstance(raw, client.NegotiatedVersion(), client, providers.NewSchemaCache())
+	if err != nil {
+		client.Kill()
+		return nil, err
+	}
+	return p, nil
+}
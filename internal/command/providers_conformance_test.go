@@ -0,0 +1,46 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/cli"
+)
+
+func TestProvidersConformance_missingExecutable(t *testing.T) {
+	view, done := testView(t)
+	meta := Meta{View: view}
+
+	execFile := filepath.Join(t.TempDir(), "terraform-provider-missing")
+	code := RunCommander(t, ProvidersConformanceCommander(), meta, []string{execFile})
+	output := done(t)
+	if code != 1 {
+		t.Fatalf("wrong exit code %d; want 1\n%s", code, output.Stdout())
+	}
+	if got, want := output.Stderr(), "Failed to start provider"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+	}
+	if got := output.Stdout(); got != "" {
+		t.Errorf("unexpected output\n%s", got)
+	}
+}
+
+func TestProvidersConformance_noArguments(t *testing.T) {
+	view, done := testView(t)
+	meta := Meta{View: view}
+
+	code := RunCommander(t, ProvidersConformanceCommander(), meta, nil)
+	output := done(t)
+	if code != cli.RunResultHelp {
+		t.Fatalf("wrong exit code %d; want %d\n%s", code, cli.RunResultHelp, output.Stdout())
+	}
+	if got, want := output.Stderr(), "requires the path of a provider plugin executable"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+	}
+}
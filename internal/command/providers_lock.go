@@ -171,6 +171,7 @@ func (c ProvidersLockCommand) Execute(args *arguments.ProvidersLock, view views.
 				}
 				return oci.GetOCIRepositoryStore(ctx, registryDomain, repositoryName, credsPolicy)
 			},
+			c.OCISignaturePoliciesBuilder.PolicyFor,
 		)
 	default:
 		// With no special options we consult upstream registries directly,
@@ -304,6 +305,7 @@ func (c ProvidersLockCommand) Execute(args *arguments.ProvidersLock, view views.
 
 		dir := providercache.NewDirWithPlatform(tempDir, platform)
 		installer := providercache.NewInstaller(dir, source)
+		installer.SetInstallPolicy(c.ProviderInstallPolicy)
 
 		newLocks, err := installer.EnsureProviderVersions(ctx, oldLocks, reqs, providercache.InstallNewProvidersForce)
 		if err != nil {
@@ -0,0 +1,127 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ProvidersOutdatedCommander() Command {
+	cmd := Command{
+		Name:  "outdated",
+		Short: "List newer versions of the required providers",
+		Long:  `Lists the versions of each provider required by the current configuration that are newer than the version selected in the dependency lock file, separated into those that the configuration's version constraints allow and those that they do not.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindProvidersOutdated(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ProvidersOutdatedCommand{meta}.Execute(args, views.NewProvidersOutdated(args.View, meta.View))
+	}
+
+	return cmd
+}
+
+// ProvidersOutdatedCommand is a Command implementation that implements the
+// "tofu providers outdated" command, which lists the newer versions of the
+// providers required by the current configuration.
+type ProvidersOutdatedCommand struct {
+	Meta
+}
+
+func (c *ProvidersOutdatedCommand) Help() string {
+	return `
+Usage: tofu [global options] providers outdated [options]
+
+  Lists the versions of each provider required by the current configuration
+  that are newer than the version selected in the dependency lock file,
+  separated into those that the configuration's version constraints allow
+  and those that they do not.
+
+  "tofu init -upgrade" selects the newest of the allowed versions. Use
+  "tofu providers why" to see which constraints exclude the others.
+
+  The available versions are retrieved using the provider installation
+  methods from the CLI configuration, in the same way as "tofu init".
+  Versions that the provider installation policy rejects are listed
+  separately.
+
+Options:
+
+  -json              Produce output in a machine-readable JSON format.
+
+  -var 'foo=bar'     Set a value for one of the input variables in the root
+                     module of the configuration. Use this option more than
+                     once to set more than one variable.
+
+  -var-file=filename Load variable values from the given file, in addition
+                     to the default files terraform.tfvars and *.auto.tfvars.
+                     Use this option more than once to include more than one
+                     variables file.
+`
+}
+
+func (c *ProvidersOutdatedCommand) Synopsis() string {
+	return "List newer versions of the required providers"
+}
+
+func (c *ProvidersOutdatedCommand) Run(rawArgs []string) int {
+	return RunCommand(ProvidersOutdatedCommander(), c.Meta, rawArgs)
+}
+
+func (c ProvidersOutdatedCommand) Execute(args *arguments.ProvidersOutdated, view views.ProvidersOutdated) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	config, confDiags := c.loadConfig(ctx, ".")
+	diags = diags.Append(confDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+	reqs, _, moreDiags := config.ProviderRequirements()
+	diags = diags.Append(moreDiags)
+	reqsByModule, moreDiags := config.ProviderRequirementsByModule()
+	diags = diags.Append(moreDiags)
+	locks, lockDiags := c.lockedDependencies()
+	diags = diags.Append(lockDiags)
+	policy, policyDiags := c.providerInstallPolicyChecker()
+	diags = diags.Append(policyDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	selections := make(map[addrs.Provider]getproviders.VersionSelection)
+	locked := make(map[addrs.Provider]getproviders.Version)
+	for _, provider := range c.providersForVersionSelection(reqs) {
+		selection, moreDiags := c.providerVersionSelection(ctx, provider, reqsByModule, policy)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			continue
+		}
+		selections[provider] = selection
+		if lock := locks.Provider(provider); lock != nil {
+			locked[provider] = lock.Version()
+		}
+	}
+
+	view.Diagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	if !view.Outdated(reqs, selections, locked) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,91 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/command/workdir"
+)
+
+func TestProvidersOutdated(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("providers-why"), td)
+	t.Chdir(td)
+	initProvidersWhyFixture(t)
+
+	providerSource, closer := newMockProviderSource(t, map[string][]string{
+		"test": {"1.0.0", "1.1.0", "1.2.0", "2.0.0"},
+	})
+	defer closer()
+
+	t.Run("human", func(t *testing.T) {
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir:     workdir.NewDir("."),
+			View:           view,
+			ProviderSource: providerSource,
+		}
+		code := RunCommander(t, ProvidersOutdatedCommander(), meta, nil)
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+		}
+
+		wantOutput := []string{
+			"- hashicorp/test: locked v1.1.0, version constraints ~> 1.0, >= 1.1.0",
+			"  - 1 newer version allowed by the version constraints, up to v1.2.0",
+			"  - 1 newer version not allowed by the version constraints, up to v2.0.0",
+		}
+		stdout := output.Stdout()
+		for _, want := range wantOutput {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("output missing %q:\n%s", want, stdout)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir:     workdir.NewDir("."),
+			View:           view,
+			ProviderSource: providerSource,
+		}
+		code := RunCommander(t, ProvidersOutdatedCommander(), meta, []string{"-json"})
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+		}
+
+		var got map[string]any
+		if err := json.Unmarshal([]byte(output.Stdout()), &got); err != nil {
+			t.Fatalf("invalid JSON output: %s\n%s", err, output.Stdout())
+		}
+		want := map[string]any{
+			"format_version": "1.0",
+			"providers": []any{
+				map[string]any{
+					"address":             "registry.opentofu.org/hashicorp/test",
+					"version_constraints": "~> 1.0, >= 1.1.0",
+					"locked":              "1.1.0",
+					"selected":            "1.2.0",
+					"latest":              "2.0.0",
+					"newer_allowed":       []any{"1.2.0"},
+					"newer_disallowed":    []any{"2.0.0"},
+					"newer_rejected":      []any{},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong output\n%s", diff)
+		}
+	})
+}
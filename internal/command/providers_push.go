@@ -0,0 +1,149 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/oci"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ProvidersPushCommander() Command {
+	cmd := Command{
+		Name:  "push",
+		Short: "Publish a provider release to an OCI registry",
+		Long: `Publishes the packages of a provider release to a repository in an OCI registry, as a single multi-platform artifact tagged with the version number, in the form that "tofu init" installs from when the repository is configured as an oci_mirror provider installation method.
+
+Credentials for the registry are taken from the same CLI configuration and Docker-style configuration files that "tofu init" uses.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindProvidersPush(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ProvidersPushCommand{meta}.Execute(args, views.NewProvidersPush(meta.View))
+	}
+
+	return cmd
+}
+
+// ProvidersPushCommand is a Command implementation that implements the
+// "tofu providers push" command, which publishes the packages of a
+// provider release to an OCI registry.
+type ProvidersPushCommand struct {
+	Meta
+}
+
+func (c *ProvidersPushCommand) Help() string {
+	return `
+Usage: tofu [global options] providers push [options] PACKAGES_DIR oci://REGISTRY/REPOSITORY
+
+  Publishes the packages of a provider release to a repository in an OCI
+  registry, as a single multi-platform artifact tagged with the version
+  number, in the form that "tofu init" installs from when the repository
+  is configured as an oci_mirror provider installation method.
+
+  The packages directory must contain the zip archives of a single version
+  of the provider, named as terraform-provider-TYPE_VERSION_OS_ARCH.zip.
+  Other files in the directory, such as checksums and signatures, are
+  ignored, so this can be the output directory of typical provider release
+  tooling.
+
+  Credentials for the registry are taken from the same CLI configuration
+  and Docker-style configuration files that "tofu init" uses.
+
+Options:
+
+  -annotation=key=value  Add an annotation to the published manifest. Use
+                         this option multiple times to add more than one
+                         annotation.
+
+  -signing-key=path      Path of a PEM-encoded private key to sign the
+                         published manifest with, such as one created by
+                         "cosign generate-key-pair". If the key is
+                         encrypted, set its password in the
+                         TF_OCI_SIGNING_KEY_PASSPHRASE environment
+                         variable.
+`
+}
+
+func (c *ProvidersPushCommand) Synopsis() string {
+	return "Publish a provider release to an OCI registry"
+}
+
+func (c *ProvidersPushCommand) Run(rawArgs []string) int {
+	return RunCommand(ProvidersPushCommander(), c.Meta, rawArgs)
+}
+
+func (c ProvidersPushCommand) Execute(args *arguments.ProvidersPush, view views.ProvidersPush) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	addr, tag, err := oci.ParseOCIRepositoryURL(args.Address)
+	if err == nil && tag != "" {
+		err = fmt.Errorf("must not include a tag, because provider releases are always tagged with their version number")
+	}
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid repository address",
+			fmt.Sprintf("Cannot publish to %q: %s.", args.Address, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	version, packages, err := oci.FindProviderPackages(args.Directory)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid provider packages",
+			fmt.Sprintf("Cannot find the provider packages to publish: %s.", err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	opts, signer, moreDiags := c.ociPushOptions(args.OCIPush)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+	store, moreDiags := c.ociPushStore(ctx, addr)
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	desc, err := oci.PushProviderPackages(ctx, store, addr, version, packages, opts)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to publish provider packages",
+			fmt.Sprintf("Cannot publish v%s to oci://%s: %s.", version, addr, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	platforms := slices.SortedFunc(maps.Keys(packages), func(a, b getproviders.Platform) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	view.Diagnostics(diags)
+	view.Pushed(addr.String(), version, platforms, desc.Digest.String(), signer)
+	return 0
+}
@@ -0,0 +1,187 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/providerdaemon"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ProvidersServeCommander() Command {
+	cmd := Command{
+		Name:  "serve",
+		Short: "Keep provider plugin processes running for other commands",
+		Long: `Runs a local daemon that starts provider plugins ahead of time, so that other OpenTofu commands can use an already-running provider process instead of waiting for a new one to start.
+
+To use the daemon, set the TF_PROVIDER_DAEMON environment variable to the socket path that this command prints when it starts. Each command still gets its own provider processes, which are never shared between commands that run in a different working directory or with different environment variables.
+
+The daemon stops when interrupted, or after no command has used it for the duration given by -idle-timeout.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindProvidersServe(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ProvidersServeCommand{meta}.Execute(args, views.NewProvidersServe(meta.View))
+	}
+
+	return cmd
+}
+
+// ProvidersServeCommand is a Command implementation that implements the
+// "tofu providers serve" command, which runs a daemon that keeps provider
+// plugin processes ready for other OpenTofu commands to use.
+type ProvidersServeCommand struct {
+	Meta
+}
+
+func (c *ProvidersServeCommand) Help() string {
+	return `
+Usage: tofu [global options] providers serve [options]
+
+  Runs a local daemon that starts provider plugins ahead of time, so that
+  other OpenTofu commands can use an already-running provider process
+  instead of waiting for a new one to start.
+
+  To use the daemon, set the TF_PROVIDER_DAEMON environment variable to the
+  socket path that this command prints when it starts. Each command still
+  gets its own provider processes, which are never shared between commands
+  that run in a different working directory or with different environment
+  variables.
+
+  The daemon stops when interrupted, or after no command has used it for
+  the duration given by -idle-timeout.
+
+Options:
+
+  -socket=path       Path of the unix socket to listen on. By default
+                     OpenTofu creates a socket in the system's temporary
+                     directory.
+
+  -idle-timeout=15m  Shut down after no command has used the daemon for
+                     this long. Defaults to 15m.
+
+  -warm=n            Number of spare processes to keep running for each
+                     provider, so that they are ready for the next command.
+                     Defaults to 1.
+`
+}
+
+func (c *ProvidersServeCommand) Synopsis() string {
+	return "Keep provider plugin processes running for other commands"
+}
+
+func (c *ProvidersServeCommand) Run(rawArgs []string) int {
+	return RunCommand(ProvidersServeCommander(), c.Meta, rawArgs)
+}
+
+func (c ProvidersServeCommand) Execute(args *arguments.ProvidersServe, view views.ProvidersServe) int {
+	var diags tfdiags.Diagnostics
+
+	if runtime.GOOS == "windows" {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Provider daemon not supported",
+			"The tofu providers serve command is not supported on Windows.",
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	socketPath := args.SocketPath
+	if socketPath == "" {
+		socketPath = providerdaemon.DefaultSocketPath()
+	}
+	socketPath, err := filepath.Abs(socketPath)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid socket path",
+			fmt.Sprintf("Cannot use %q as the socket path: %s.", args.SocketPath, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	if info, err := os.Lstat(socketPath); err == nil {
+		// A socket left behind by a daemon that didn't exit cleanly can be
+		// replaced, but we must not take over from one that's still running,
+		// and we must never remove anything else that's at the path.
+		if info.Mode().Type() != os.ModeSocket {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid socket path",
+				fmt.Sprintf("Cannot use %s as the socket path, because a file that is not a socket already exists there.", socketPath),
+			))
+			view.Diagnostics(diags)
+			return 1
+		}
+		if conn, err := net.Dial("unix", socketPath); err == nil {
+			conn.Close()
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Provider daemon already running",
+				fmt.Sprintf("Another process is already listening on %s.", socketPath),
+			))
+			view.Diagnostics(diags)
+			return 1
+		}
+		_ = os.Remove(socketPath)
+	}
+
+	// The provider processes create their own sockets in a directory that
+	// only the current user can access.
+	pluginSocketDir, err := os.MkdirTemp("", "tofu-providers-")
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to create socket directory",
+			fmt.Sprintf("Cannot create a directory for provider plugin sockets: %s.", err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+	defer os.RemoveAll(pluginSocketDir)
+
+	ln, err := providerdaemon.Listen(socketPath)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to listen on socket",
+			fmt.Sprintf("Cannot listen on %s: %s.", socketPath, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	server := providerdaemon.NewServer(pluginSocketDir)
+	server.IdleTimeout = args.IdleTimeout
+	server.Warm = args.Warm
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	view.Listening(socketPath, providerdaemon.SocketPathEnvVar)
+	if err := server.Serve(ctx, ln); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Provider daemon failed",
+			fmt.Sprintf("The provider daemon stopped unexpectedly: %s.", err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+	view.Stopped()
+	return 0
+}
@@ -0,0 +1,80 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestProvidersServe_existingPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the provider daemon is not supported on Windows")
+	}
+
+	// Unix socket paths have a short length limit, so we can't use
+	// t.TempDir here.
+	socketDir, err := os.MkdirTemp("", "tofu-pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(socketDir) })
+
+	t.Run("not a socket", func(t *testing.T) {
+		path := filepath.Join(socketDir, "notes.txt")
+		if err := os.WriteFile(path, []byte("important"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		view, done := testView(t)
+		code := RunCommander(t, ProvidersServeCommander(), Meta{View: view}, []string{"-socket=" + path})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("wrong exit code %d; want 1\n%s", code, output.All())
+		}
+		if got, want := output.Stderr(), "a file that is not a socket already exists there"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+		}
+		if content, err := os.ReadFile(path); err != nil || string(content) != "important" {
+			t.Errorf("the existing file was modified: %q, %v", content, err)
+		}
+	})
+
+	t.Run("already running", func(t *testing.T) {
+		path := filepath.Join(socketDir, "control.sock")
+		ln, err := net.Listen("unix", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ln.Close()
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				conn.Close()
+			}
+		}()
+
+		view, done := testView(t)
+		code := RunCommander(t, ProvidersServeCommander(), Meta{View: view}, []string{"-socket=" + path})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("wrong exit code %d; want 1\n%s", code, output.All())
+		}
+		if got, want := output.Stderr(), "Another process is already listening"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+		}
+		if _, err := os.Lstat(path); err != nil {
+			t.Errorf("the running daemon's socket was removed: %s", err)
+		}
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providercache"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func ProvidersWhyCommander() Command {
+	cmd := Command{
+		Name:  "why",
+		Short: "Explain how provider versions are selected",
+		Long: `Explains how "tofu init" selects a version of each provider required by the current configuration, by showing the version constraints that each module declares for it, which of those constraints exclude newer versions, and what "tofu init -upgrade" would change in the dependency lock file.
+
+If a provider source address is given, only that provider is explained.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindProvidersWhy(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return ProvidersWhyCommand{meta}.Execute(args, views.NewProvidersWhy(meta.View))
+	}
+
+	return cmd
+}
+
+// ProvidersWhyCommand is a Command implementation that implements the
+// "tofu providers why" command, which explains how the version of each
+// provider required by the current configuration is selected.
+type ProvidersWhyCommand struct {
+	Meta
+}
+
+func (c *ProvidersWhyCommand) Help() string {
+	return `
+Usage: tofu [global options] providers why [options] [PROVIDER]
+
+  Explains how "tofu init" selects a version of each provider required by
+  the current configuration, by showing the version constraints that each
+  module declares for it, which of those constraints exclude newer
+  versions, and what "tofu init -upgrade" would change in the dependency
+  lock file.
+
+  If a provider source address is given, such as "hashicorp/aws", only that
+  provider is explained.
+
+  The available versions are retrieved using the provider installation
+  methods from the CLI configuration, in the same way as "tofu init", and
+  any versions that the provider installation policy rejects are listed
+  along with the reason.
+
+Options:
+
+  -var 'foo=bar'     Set a value for one of the input variables in the root
+                     module of the configuration. Use this option more than
+                     once to set more than one variable.
+
+  -var-file=filename Load variable values from the given file, in addition
+                     to the default files terraform.tfvars and *.auto.tfvars.
+                     Use this option more than once to include more than one
+                     variables file.
+`
+}
+
+func (c *ProvidersWhyCommand) Synopsis() string {
+	return "Explain how provider versions are selected"
+}
+
+func (c *ProvidersWhyCommand) Run(rawArgs []string) int {
+	return RunCommand(ProvidersWhyCommander(), c.Meta, rawArgs)
+}
+
+func (c ProvidersWhyCommand) Execute(args *arguments.ProvidersWhy, view views.ProvidersWhy) int {
+	var diags tfdiags.Diagnostics
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+
+	config, confDiags := c.loadConfig(ctx, ".")
+	diags = diags.Append(confDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+	reqs, _, moreDiags := config.ProviderRequirements()
+	diags = diags.Append(moreDiags)
+	reqsByModule, moreDiags := config.ProviderRequirementsByModule()
+	diags = diags.Append(moreDiags)
+	locks, lockDiags := c.lockedDependencies()
+	diags = diags.Append(lockDiags)
+	policy, policyDiags := c.providerInstallPolicyChecker()
+	diags = diags.Append(policyDiags)
+	if diags.HasErrors() {
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	providers := c.providersForVersionSelection(reqs)
+	if args.Provider != "" {
+		provider, moreDiags := addrs.ParseProviderSourceString(args.Provider)
+		diags = diags.Append(moreDiags)
+		if diags.HasErrors() {
+			view.Diagnostics(diags)
+			return 1
+		}
+		if !slices.Contains(providers, provider) {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Provider not required",
+				fmt.Sprintf("The current configuration does not require %s, or its version is not selected by \"tofu init\". Run \"tofu providers\" to see the providers that the configuration requires.", provider.ForDisplay()),
+			))
+			view.Diagnostics(diags)
+			return 1
+		}
+		providers = []addrs.Provider{provider}
+	}
+
+	for _, provider := range providers {
+		selection, moreDiags := c.providerVersionSelection(ctx, provider, reqsByModule, policy)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			continue
+		}
+		locked := getproviders.UnspecifiedVersion
+		if lock := locks.Provider(provider); lock != nil {
+			locked = lock.Version()
+		}
+		view.Explain(provider, reqsByModule, selection, locked)
+	}
+
+	view.Diagnostics(diags)
+	if diags.HasErrors() {
+		return 1
+	}
+	return 0
+}
+
+// providersForVersionSelection returns the providers from the given
+// requirements that "tofu init" selects a version of, in a stable order.
+//
+// Built-in providers and providers with development overrides or unmanaged
+// processes are excluded, because those are never installed.
+func (m *Meta) providersForVersionSelection(reqs getproviders.Requirements) []addrs.Provider {
+	var ret []addrs.Provider
+	for provider := range reqs {
+		if provider.IsBuiltIn() {
+			continue
+		}
+		if _, ok := m.ProviderDevOverrides[provider]; ok {
+			continue
+		}
+		if _, ok := m.UnmanagedProviders[provider]; ok {
+			continue
+		}
+		ret = append(ret, provider)
+	}
+	slices.SortFunc(ret, func(a, b addrs.Provider) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return ret
+}
+
+// providerInstallPolicyChecker returns a checker for the provider
+// installation policy from the CLI configuration, which allows everything
+// if there is no policy.
+func (m *Meta) providerInstallPolicyChecker() (*providercache.InstallPolicyChecker, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	checker, err := providercache.NewInstallPolicyChecker(m.ProviderInstallPolicy, m.providerInstallSource(), getproviders.CurrentPlatform)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Invalid provider installation policy",
+			fmt.Sprintf("Failed to load the provider installation policy: %s.", err),
+		))
+	}
+	return checker, diags
+}
+
+// providerVersionSelection retrieves the available versions of the given
+// provider from the provider installation source, and then describes which
+// of them "tofu init -upgrade" would select given the version constraints
+// that each of the modules in the given tree declare for it and the given
+// provider installation policy.
+func (m *Meta) providerVersionSelection(ctx context.Context, provider addrs.Provider, reqs *configs.ModuleRequirements, policy *providercache.InstallPolicyChecker) (getproviders.VersionSelection, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	available, warnings, err := m.providerInstallSource().AvailableVersions(ctx, provider)
+	if len(warnings) != 0 {
+		displayWarnings := make([]string, len(warnings))
+		for i, warning := range warnings {
+			displayWarnings[i] = fmt.Sprintf("- %s", warning)
+		}
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Warning,
+			"Additional provider information from registry",
+			fmt.Sprintf("The remote registry returned warnings for %s:\n%s",
+				provider.String(),
+				strings.Join(displayWarnings, "\n"),
+			),
+		))
+	}
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to query available provider packages",
+			fmt.Sprintf("Could not retrieve the list of available versions for provider %s: %s.", provider.ForDisplay(), err),
+		))
+		return getproviders.VersionSelection{}, diags
+	}
+
+	selection := getproviders.NewVersionSelection(available, moduleProviderConstraints(reqs, provider))
+	selection.ApplyPolicy(func(version getproviders.Version) error {
+		return policy.CheckVersion(ctx, provider, version)
+	})
+	return selection, diags
+}
+
+// moduleProviderConstraints returns the version constraints that the given
+// module and each of its descendants declare for the given provider, with
+// one element for each module that requires the provider.
+func moduleProviderConstraints(reqs *configs.ModuleRequirements, provider addrs.Provider) []getproviders.VersionConstraints {
+	var ret []getproviders.VersionConstraints
+	if vc, ok := reqs.Requirements[provider]; ok {
+		ret = append(ret, vc)
+	}
+	for _, name := range slices.Sorted(maps.Keys(reqs.Children)) {
+		ret = append(ret, moduleProviderConstraints(reqs.Children[name], provider)...)
+	}
+	return ret
+}
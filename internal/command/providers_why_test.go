@@ -0,0 +1,135 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/opentofu/opentofu/internal/command/workdir"
+	"github.com/opentofu/opentofu/internal/providercache"
+)
+
+func TestProvidersWhy(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath("providers-why"), td)
+	t.Chdir(td)
+	initProvidersWhyFixture(t)
+
+	// Newer versions were released after the dependency lock file was
+	// last updated.
+	providerSource, closer := newMockProviderSource(t, map[string][]string{
+		"test": {"1.0.0", "1.1.0", "1.2.0", "2.0.0"},
+	})
+	defer closer()
+
+	t.Run("all providers", func(t *testing.T) {
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir:     workdir.NewDir("."),
+			View:           view,
+			ProviderSource: providerSource,
+		}
+		code := RunCommander(t, ProvidersWhyCommander(), meta, nil)
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+		}
+
+		wantOutput := []string{
+			"Provider hashicorp/test",
+			"├── ~> 1.0 (allows up to v1.2.0) [limiting]",
+			"└── module.child",
+			"    └── >= 1.1.0 (allows up to v2.0.0)",
+			"Available versions: 4, from v1.0.0 to v2.0.0",
+			"Selected version: v1.2.0, because the constraints marked [limiting] exclude newer versions such as v2.0.0",
+			`The dependency lock file selects v1.1.0. Running "tofu init -upgrade" would upgrade it to v1.2.0.`,
+		}
+		stdout := output.Stdout()
+		for _, want := range wantOutput {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("output missing %q:\n%s", want, stdout)
+			}
+		}
+	})
+
+	t.Run("installation policy", func(t *testing.T) {
+		deniedFile := filepath.Join(t.TempDir(), "denied")
+		if err := os.WriteFile(deniedFile, []byte("registry.opentofu.org/hashicorp/test 1.2.0 # broken\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir:     workdir.NewDir("."),
+			View:           view,
+			ProviderSource: providerSource,
+			ProviderInstallPolicy: &providercache.InstallPolicy{
+				DeniedVersionsFiles: []string{deniedFile},
+			},
+		}
+		code := RunCommander(t, ProvidersWhyCommander(), meta, nil)
+		output := done(t)
+		if code != 0 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.Stderr())
+		}
+
+		wantOutput := []string{
+			"Selected version: v1.1.0, because the provider installation policy rejects newer versions:",
+			"  - v1.2.0 is denied by " + deniedFile + ":1: broken",
+			`The dependency lock file selects v1.1.0. Running "tofu init -upgrade" would not change it.`,
+		}
+		stdout := output.Stdout()
+		for _, want := range wantOutput {
+			if !strings.Contains(stdout, want) {
+				t.Errorf("output missing %q:\n%s", want, stdout)
+			}
+		}
+	})
+
+	t.Run("provider not required", func(t *testing.T) {
+		view, done := testView(t)
+		meta := Meta{
+			WorkingDir:     workdir.NewDir("."),
+			View:           view,
+			ProviderSource: providerSource,
+		}
+		code := RunCommander(t, ProvidersWhyCommander(), meta, []string{"hashicorp/aws"})
+		output := done(t)
+		if code != 1 {
+			t.Fatalf("wrong exit code %d\n%s", code, output.All())
+		}
+		if got, want := output.Stderr(), "The current configuration does not require hashicorp/aws"; !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	})
+}
+
+// initProvidersWhyFixture runs "tofu init" in the current working directory
+// at a time when only some versions of the test provider were available, so
+// that the dependency lock file selects an older version.
+func initProvidersWhyFixture(t *testing.T) {
+	t.Helper()
+
+	providerSource, closer := newMockProviderSource(t, map[string][]string{
+		"test": {"1.0.0", "1.1.0"},
+	})
+	defer closer()
+	view, done := testView(t)
+	meta := Meta{
+		WorkingDir:       workdir.NewDir("."),
+		testingOverrides: metaOverridesForProvider(testProvider()),
+		View:             view,
+		ProviderSource:   providerSource,
+	}
+	code := RunCommander(t, InitCommander(), meta, nil)
+	output := done(t)
+	if code != 0 {
+		t.Fatalf("init failed\n%s", output.Stderr())
+	}
+}
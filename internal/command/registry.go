@@ -0,0 +1,52 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+func RegistryCommander() Command {
+	cmd := Command{
+		Name:  "registry",
+		Short: "Build and serve a private provider and module registry",
+		Long:  "This command has subcommands for building and serving a private registry of providers and modules.",
+
+		Commands: []Command{
+			RegistryBuildCommander(),
+			RegistryServeCommander(),
+		},
+	}
+
+	return cmd
+}
+
+// RegistryCommand is a Command implementation that just shows help for
+// the subcommands nested below it.
+type RegistryCommand struct {
+	Meta
+}
+
+func (c *RegistryCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *RegistryCommand) Help() string {
+	helpText := `
+Usage: tofu [global options] registry <subcommand> [options] [args]
+
+  This command has subcommands for building and serving a private registry
+  of providers and modules.
+
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *RegistryCommand) Synopsis() string {
+	return "Build and serve a private provider and module registry"
+}
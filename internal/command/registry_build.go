@@ -0,0 +1,122 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/registryserver"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// registrySigningKeyPassphraseEnvVar is the environment variable that holds
+// the passphrase of the key given to "tofu registry build -signing-key".
+// We don't accept the passphrase as a command line option because it would
+// then be visible to other users of the system.
+const registrySigningKeyPassphraseEnvVar = "TF_REGISTRY_SIGNING_KEY_PASSPHRASE"
+
+func RegistryBuildCommander() Command {
+	cmd := Command{
+		Name:  "build",
+		Short: "Generate a static registry from provider and module packages",
+		Long: `Generates the documents of the provider and module registry protocols, along with signed checksums for each provider release, from a directory of provider packages and module archives.
+
+The result can be published on any static file server that serves HTTPS at the root of the registry hostname, or served directly with "tofu registry serve".`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindRegistryBuild(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return RegistryBuildCommand{meta}.Execute(args, views.NewRegistryBuild(meta.View))
+	}
+
+	return cmd
+}
+
+// RegistryBuildCommand is a Command implementation that implements the
+// "tofu registry build" command, which generates a static provider and
+// module registry from a directory of packages.
+type RegistryBuildCommand struct {
+	Meta
+}
+
+func (c *RegistryBuildCommand) Help() string {
+	return `
+Usage: tofu [global options] registry build [options] SOURCE_DIR OUTPUT_DIR
+
+  Generates the documents of the provider and module registry protocols,
+  along with signed checksums for each provider release, from a directory
+  of provider packages and module archives.
+
+  The source directory uses the following layout:
+
+    providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_OS_ARCH.zip
+    providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_manifest.json
+    modules/NAMESPACE/NAME/SYSTEM/VERSION.tar.gz
+
+  The manifest file is optional and declares the plugin protocol versions
+  of a provider release, which otherwise default to 5.0. Module archives
+  may also be .tgz, .tar.xz, .tar.bz2 or .zip files.
+
+  The output directory must not exist yet or be empty. The result can be
+  published on any static file server that serves HTTPS at the root of the
+  registry hostname, or served directly with "tofu registry serve".
+
+Options:
+
+  -signing-key=path  Path of an ASCII-armored GPG private key to sign the
+                     checksums of the provider packages with. Required if
+                     the source directory contains any providers. If the
+                     key is protected by a passphrase, set it in the
+                     TF_REGISTRY_SIGNING_KEY_PASSPHRASE environment
+                     variable.
+`
+}
+
+func (c *RegistryBuildCommand) Synopsis() string {
+	return "Generate a static registry from provider and module packages"
+}
+
+func (c *RegistryBuildCommand) Run(rawArgs []string) int {
+	return RunCommand(RegistryBuildCommander(), c.Meta, rawArgs)
+}
+
+func (c RegistryBuildCommand) Execute(args *arguments.RegistryBuild, view views.RegistryBuild) int {
+	var diags tfdiags.Diagnostics
+
+	var opts registryserver.BuildOptions
+	if args.SigningKeyFile != "" {
+		key, err := registryserver.LoadSigningKey(args.SigningKeyFile, []byte(os.Getenv(registrySigningKeyPassphraseEnvVar)))
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid signing key",
+				fmt.Sprintf("Cannot use the given signing key: %s.", err),
+			))
+			view.Diagnostics(diags)
+			return 1
+		}
+		opts.SigningKey = key
+	}
+
+	result, err := registryserver.Build(args.SourceDir, args.OutputDir, opts)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to build registry",
+			fmt.Sprintf("Cannot build a registry from %s: %s.", args.SourceDir, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	view.Built(result, args.OutputDir)
+	return 0
+}
@@ -0,0 +1,169 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package command
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/command/views"
+	"github.com/opentofu/opentofu/internal/registryserver"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// registryServeShutdownTimeout is how long "tofu registry serve" waits for
+// requests in progress to complete after it is interrupted.
+const registryServeShutdownTimeout = 10 * time.Second
+
+func RegistryServeCommander() Command {
+	cmd := Command{
+		Name:  "serve",
+		Short: "Serve a registry generated by tofu registry build",
+		Long: `Serves a registry generated by "tofu registry build" over HTTPS, including the service discovery document that OpenTofu uses to find the provider and module registry services of a host.
+
+The registry must be served at the root of the hostname used in provider and module source addresses, so the certificate given in -tls-cert must be valid for that hostname.`,
+
+		DiagsWithNewline: true,
+	}
+
+	args := arguments.BindRegistryServe(&cmd.CommandLine)
+	cmd.Run = func(meta Meta) int {
+		return RegistryServeCommand{meta}.Execute(args, views.NewRegistryServe(meta.View))
+	}
+
+	return cmd
+}
+
+// RegistryServeCommand is a Command implementation that implements the
+// "tofu registry serve" command, which serves a registry generated by
+// "tofu registry build".
+type RegistryServeCommand struct {
+	Meta
+}
+
+func (c *RegistryServeCommand) Help() string {
+	return `
+Usage: tofu [global options] registry serve [options] REGISTRY_DIR
+
+  Serves a registry generated by "tofu registry build" over HTTPS, including
+  the service discovery document that OpenTofu uses to find the provider
+  and module registry services of a host.
+
+  The registry must be served at the root of the hostname used in provider
+  and module source addresses, so the certificate given in -tls-cert must
+  be valid for that hostname.
+
+Options:
+
+  -listen=addr     TCP address to listen on. Defaults to ":8443".
+
+  -tls-cert=path   Path of a PEM-encoded TLS certificate for the registry
+                   hostname.
+
+  -tls-key=path    Path of the PEM-encoded private key for the certificate
+                   given in -tls-cert.
+
+  -http            Serve unencrypted HTTP instead of HTTPS. OpenTofu only
+                   uses registries over HTTPS, so this is only useful
+                   behind a reverse proxy that terminates TLS.
+`
+}
+
+func (c *RegistryServeCommand) Synopsis() string {
+	return "Serve a registry generated by tofu registry build"
+}
+
+func (c *RegistryServeCommand) Run(rawArgs []string) int {
+	return RunCommand(RegistryServeCommander(), c.Meta, rawArgs)
+}
+
+func (c RegistryServeCommand) Execute(args *arguments.RegistryServe, view views.RegistryServe) int {
+	var diags tfdiags.Diagnostics
+
+	if _, err := os.Stat(filepath.Join(args.Directory, ".well-known", "terraform.json")); err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Not a registry directory",
+			fmt.Sprintf("The directory %s does not contain a registry generated by \"tofu registry build\".", args.Directory),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+
+	var tlsConfig *tls.Config
+	if !args.PlainHTTP {
+		cert, err := tls.LoadX509KeyPair(args.TLSCertFile, args.TLSKeyFile)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid TLS certificate",
+				fmt.Sprintf("Cannot load the TLS certificate and key: %s.", err),
+			))
+			view.Diagnostics(diags)
+			return 1
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	ln, err := net.Listen("tcp", args.Listen)
+	if err != nil {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to listen",
+			fmt.Sprintf("Cannot listen on %s: %s.", args.Listen, err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+		scheme = "https"
+	}
+
+	server := &http.Server{
+		Handler:           registryserver.NewHandler(args.Directory),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, done := c.InterruptibleContext(c.CommandContext())
+	defer done()
+	shutdown := make(chan struct{})
+	go func() {
+		defer close(shutdown)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), registryServeShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	view.Listening(fmt.Sprintf("%s://%s/", scheme, ln.Addr()))
+	if err := server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Registry server failed",
+			fmt.Sprintf("The registry server stopped unexpectedly: %s.", err),
+		))
+		view.Diagnostics(diags)
+		return 1
+	}
+	// Serve returns as soon as the shutdown begins, but we want to let the
+	// requests in progress complete before we exit.
+	<-shutdown
+	view.Stopped()
+	return 0
+}
@@ -104,6 +104,10 @@ Options:
   -json                 If specified, machine readable output will be printed in
                         JSON format
 
+  -mock-seed=n          Seed for the values generated for mocked and overridden
+                        resources. Use the same seed to reproduce a test failure
+                        that depends on the generated values.
+
   -json-into=out.json   Produce the same output as -json, but sent directly
                         to the given file. This allows automation to preserve
                         the original human-readable output streams, while
@@ -111,6 +115,15 @@ Options:
 
   -no-color             If specified, output won't contain any color.
 
+  -skip-tags=tag        If specified, OpenTofu will skip the run blocks labelled
+                        with one of the given tags, even if they were selected
+                        by -tags. You can use this option multiple times.
+
+  -tags=tag             If specified, OpenTofu will only execute the run blocks
+                        labelled with one of the given tags. You can use this
+                        option multiple times to select more than one tag. Run
+                        blocks that are not selected are reported as skipped.
+
   -test-directory=path  Set the OpenTofu test directory, defaults to "tests". When set, the
                         test command will search for test files in the current directory and
                         in the one specified by the flag.
@@ -164,6 +177,10 @@ func (c TestCommand) Execute(args *arguments.Test, view views.Test) int {
 		return 1
 	}
 
+	for _, file := range config.Module.Tests {
+		file.MockSeed = int64(args.MockSeed)
+	}
+
 	runCount := 0
 	fileCount := 0
 
@@ -202,6 +219,7 @@ func (c TestCommand) Execute(args *arguments.Test, view views.Test) int {
 						Config: file,
 						Name:   name,
 						Runs:   runs,
+						Fuzzes: testFuzzes(file),
 					}
 				}
 
@@ -226,6 +244,7 @@ func (c TestCommand) Execute(args *arguments.Test, view views.Test) int {
 					Config: file,
 					Name:   name,
 					Runs:   runs,
+					Fuzzes: testFuzzes(file),
 				}
 			}
 			return files
@@ -294,6 +313,9 @@ func (c TestCommand) Execute(args *arguments.Test, view views.Test) int {
 		Stopped:   false,
 
 		Verbose: args.Verbose,
+
+		Tags:     args.Tags,
+		SkipTags: args.SkipTags,
 	}
 
 	view.Abstract(&suite)
@@ -353,6 +375,18 @@ func (c TestCommand) Execute(args *arguments.Test, view views.Test) int {
 	return 0
 }
 
+// testFuzzes creates the fuzz blocks to execute for the given test file.
+func testFuzzes(file *configs.TestFile) []*moduletest.Fuzz {
+	var fuzzes []*moduletest.Fuzz
+	for _, fuzz := range file.Fuzzes {
+		fuzzes = append(fuzzes, &moduletest.Fuzz{
+			Config: fuzz,
+			Name:   fuzz.Name,
+		})
+	}
+	return fuzzes
+}
+
 // test runner
 
 type TestSuiteRunner struct {
@@ -384,6 +418,31 @@ type TestSuiteRunner struct {
 
 	// Verbose tells the runner to print out plan files during each test run.
 	Verbose bool
+
+	// Tags and SkipTags select the run blocks to execute based on their tags.
+	// Run blocks that are not selected are marked as skipped.
+	Tags     []string
+	SkipTags []string
+}
+
+// Selected returns true if the given run block should be executed according
+// to the tags requested by the user.
+func (runner *TestSuiteRunner) Selected(run *moduletest.Run) bool {
+	for _, tag := range runner.SkipTags {
+		if run.Config.HasTag(tag) {
+			return false
+		}
+	}
+
+	if len(runner.Tags) == 0 {
+		return true
+	}
+	for _, tag := range runner.Tags {
+		if run.Config.HasTag(tag) {
+			return true
+		}
+	}
+	return false
 }
 
 func (runner *TestSuiteRunner) Start(ctx context.Context) {
@@ -421,6 +480,12 @@ type TestFileRunner struct {
 	Suite *TestSuiteRunner
 
 	States map[string]*TestFileState
+
+	// deadline is closed once the run block currently executing has exceeded
+	// its timeout, and is nil if that run block has no timeout. timedOut
+	// records whether an operation was stopped because of the deadline.
+	deadline <-chan struct{}
+	timedOut bool
 }
 
 type TestFileState struct {
@@ -455,6 +520,13 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 			continue
 		}
 
+		if !runner.Suite.Selected(run) {
+			// The user has filtered this run block out by its tags.
+			log.Printf("[DEBUG] TestFileRunner: skipping run block %s/%s as it was not selected by its tags", file.Name, run.Name)
+			run.Status = moduletest.Skip
+			continue
+		}
+
 		key := MainStateIdentifier
 		config := runner.Suite.Config
 		if run.Config.ConfigUnderTest != nil {
@@ -519,10 +591,28 @@ func (runner *TestFileRunner) ExecuteTestFile(ctx context.Context, file *modulet
 		file.Status = file.Status.Merge(run.Status)
 	}
 
+	for _, fuzz := range file.Fuzzes {
+		if runner.Suite.Cancelled {
+			return
+		}
+
+		if runner.Suite.Stopped || file.Status == moduletest.Error {
+			fuzz.Status = moduletest.Skip
+			continue
+		}
+
+		log.Printf("[TRACE] TestFileRunner: executing fuzz block %s/%s", file.Name, fuzz.Name)
+		fuzz.Execute(ctx, runner.Suite.Config.Module)
+		file.Status = file.Status.Merge(fuzz.Status)
+	}
+
 	runner.Suite.View.File(file)
 	for _, run := range file.Runs {
 		runner.Suite.View.Run(run, file)
 	}
+	for _, fuzz := range file.Fuzzes {
+		runner.Suite.View.Fuzz(fuzz, file)
+	}
 }
 
 func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletest.Run, file *moduletest.File, state *states.State, config *configs.Config) (*states.State, bool) {
@@ -541,6 +631,19 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 		return state, false
 	}
 
+	if run.Config.Timeout > 0 {
+		deadlineCtx, cancelDeadline := context.WithTimeout(context.WithoutCancel(ctx), run.Config.Timeout)
+		runner.deadline = deadlineCtx.Done()
+		defer func() {
+			cancelDeadline()
+			if runner.timedOut {
+				run.Status = moduletest.Timeout
+			}
+			runner.deadline = nil
+			runner.timedOut = false
+		}()
+	}
+
 	run.Diagnostics = run.Diagnostics.Append(file.Config.Validate())
 	if run.Diagnostics.HasErrors() {
 		run.Status = moduletest.Error
@@ -576,11 +679,51 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 		return state, false
 	}
 
-	planCtx, plan, planDiags := runner.plan(ctx, config, state, run, file)
-	if run.Config.Command == configs.PlanTestCommand {
+	var planCtx, applyCtx *tofu.Context
+	var plan *plans.Plan
+	var updated *states.State
+	var planDiags, applyDiags tfdiags.Diagnostics
+
+	// applied records whether any apply operation has been attempted, and
+	// therefore whether the state may have been updated by this run block.
+	applied := false
+
+	for attempt := 0; ; attempt++ {
 		expectedFailures, sourceRanges := run.BuildExpectedFailuresAndSourceMaps()
-		// Then we want to assess our conditions and diagnostics differently.
-		planDiags = run.ValidateExpectedFailures(expectedFailures, sourceRanges, planDiags)
+
+		planCtx, plan, planDiags = runner.plan(ctx, config, state, run, file)
+		if run.Config.Command == configs.PlanTestCommand {
+			// Then we want to assess our conditions and diagnostics differently.
+			planDiags = run.ValidateExpectedFailures(expectedFailures, sourceRanges, planDiags)
+			if runner.retry(run, file, attempt, planDiags) {
+				continue
+			}
+			break
+		}
+
+		planDiags = checkProblematicPlanErrors(expectedFailures, planDiags)
+		if planDiags.HasErrors() {
+			if runner.retry(run, file, attempt, planDiags) {
+				continue
+			}
+			break
+		}
+
+		applyCtx, updated, applyDiags = runner.apply(ctx, plan, state, config, run, file)
+		applied = true
+
+		// Remove expected diagnostics, and add diagnostics in case anything that should have failed didn't.
+		applyDiags = run.ValidateExpectedFailures(expectedFailures, sourceRanges, applyDiags)
+		if runner.retry(run, file, attempt, applyDiags) {
+			// The failed apply operation may still have made partial
+			// changes, so the next attempt plans against the updated state.
+			state = updated
+			continue
+		}
+		break
+	}
+
+	if run.Config.Command == configs.PlanTestCommand {
 		run.Diagnostics = run.Diagnostics.Append(planDiags)
 		if planDiags.HasErrors() {
 			run.Status = moduletest.Error
@@ -625,16 +768,12 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 		return state, false
 	}
 
-	expectedFailures, sourceRanges := run.BuildExpectedFailuresAndSourceMaps()
-
-	planDiags = checkProblematicPlanErrors(expectedFailures, planDiags)
-
 	// Otherwise any error during the planning prevents our apply from
 	// continuing which is an error.
 	run.Diagnostics = run.Diagnostics.Append(planDiags)
 	if planDiags.HasErrors() {
 		run.Status = moduletest.Error
-		return state, false
+		return state, applied
 	}
 
 	// Since we're carrying on an executing the apply operation as well, we're
@@ -651,11 +790,6 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 	}
 	run.Diagnostics = filteredDiags
 
-	applyCtx, updated, applyDiags := runner.apply(ctx, plan, state, config, run, file)
-
-	// Remove expected diagnostics, and add diagnostics in case anything that should have failed didn't.
-	applyDiags = run.ValidateExpectedFailures(expectedFailures, sourceRanges, applyDiags)
-
 	run.Diagnostics = run.Diagnostics.Append(applyDiags)
 	if applyDiags.HasErrors() {
 		run.Status = moduletest.Error
@@ -702,6 +836,22 @@ func (runner *TestFileRunner) ExecuteTestRun(ctx context.Context, run *moduletes
 	return updated, true
 }
 
+// retry returns true if the operation for the given run block that produced
+// diags failed and should be attempted again.
+func (runner *TestFileRunner) retry(run *moduletest.Run, file *moduletest.File, attempt int, diags tfdiags.Diagnostics) bool {
+	if !diags.HasErrors() || attempt >= run.Config.Retries {
+		return false
+	}
+
+	if runner.Suite.Stopped || runner.Suite.Cancelled || runner.timedOut {
+		// Don't retry anything the user or the timeout has interrupted.
+		return false
+	}
+
+	log.Printf("[WARN] TestFileRunner: attempt %d of %d failed for %s/%s, retrying: %s", attempt+1, run.Config.Retries+1, file.Name, run.Name, diags.Err())
+	return true
+}
+
 func (runner *TestFileRunner) validate(ctx context.Context, config *configs.Config, run *moduletest.Run, file *moduletest.File) tfdiags.Diagnostics {
 	log.Printf("[TRACE] TestFileRunner: called validate for %s/%s", file.Name, run.Name)
 
@@ -984,11 +1134,36 @@ func (runner *TestFileRunner) wait(ctx *tofu.Context, runningCtx context.Context
 
 	}
 
+	// This function handles what happens when the run block exceeds its
+	// timeout. We stop the current operation, the same as for a hard cancel,
+	// but the remaining run blocks and the cleanup still execute normally.
+	handleTimeout := func() {
+		if runningCtx.Err() != nil {
+			// The operation finished at the same time as the deadline, so
+			// there is nothing to stop.
+			return
+		}
+
+		log.Printf("[DEBUG] TestFileRunner: test execution timed out during %s", identifier)
+
+		runner.timedOut = true
+		diags = diags.Append(tfdiags.Sourceless(tfdiags.Error, "Test run timed out", fmt.Sprintf("The run block exceeded its timeout of %s, so the current operation was stopped.", run.Config.Timeout)))
+		go ctx.Stop()
+
+		select {
+		case <-runner.Suite.CancelledCtx.Done():
+			handleCancelled()
+		case <-runningCtx.Done():
+		}
+	}
+
 	select {
 	case <-runner.Suite.StoppedCtx.Done():
 		handleStopped()
 	case <-runner.Suite.CancelledCtx.Done():
 		handleCancelled()
+	case <-runner.deadline:
+		handleTimeout()
 	case <-runningCtx.Done():
 		// The operation exited normally.
 	}
@@ -80,6 +80,10 @@ func TestTest(t *testing.T) {
 			expected: "1 passed, 0 failed.",
 			code:     0,
 		},
+		"check_assertions": {
+			expected: "2 passed, 0 failed.",
+			code:     0,
+		},
 		"expect_failures_inputs": {
 			expected: "1 passed, 0 failed.",
 			code:     0,
@@ -263,6 +267,17 @@ func TestTest_Full_Output(t *testing.T) {
 			expected: "Incompatible plan options",
 			code:     1,
 		},
+		"run_tags": {
+			override: "run_tags",
+			args:     []string{"-tags=fast", "-skip-tags=flaky"},
+			expected: "1 passed, 0 failed, 2 skipped.",
+			code:     0,
+		},
+		"run_tags_all": {
+			override: "run_tags",
+			expected: "3 passed, 0 failed.",
+			code:     0,
+		},
 		"is_sorted": {
 			expected: "1.tftest.hcl... pass\n  run \"a\"... pass\n2.tftest.hcl... pass\n  run \"b\"... pass\n3.tftest.hcl... pass\n  run \"c\"... pass",
 			code:     0,
@@ -342,6 +357,85 @@ func TestTest_Interrupt(t *testing.T) {
 	}
 }
 
+func TestTest_Timeout(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "run_timeout")), td)
+	t.Chdir(td)
+
+	provider := testing_command.NewProvider(nil)
+	view, done := testView(t)
+
+	// The provider waits for a second after sending on this channel, which
+	// gives the run block time to exceed its timeout.
+	interrupt := make(chan struct{}, 1)
+	provider.Interrupt = interrupt
+
+	c := &TestCommand{
+		WorkingDir:       workdir.NewDir("."),
+		testingOverrides: metaOverridesForProvider(provider.Provider),
+		View:             view,
+	}
+
+	code := c.Run([]string{"-no-color"})
+	output := done(t).All()
+
+	if code != 1 {
+		t.Errorf("expected status code 1 but got %d", code)
+	}
+
+	for _, expected := range []string{
+		"run \"slow\"... timeout",
+		"run \"after\"... pass",
+		"Test run timed out",
+		"1 passed, 0 failed, 1 timed out.",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("output didn't contain expected string %q:\n\n%s", expected, output)
+		}
+	}
+
+	if provider.ResourceCount() > 0 {
+		t.Errorf("should have deleted all resources on completion but left %v", provider.ResourceString())
+	}
+}
+
+func TestTest_Fuzz(t *testing.T) {
+	td := t.TempDir()
+	testCopyDir(t, testFixturePath(path.Join("test", "fuzz_validation")), td)
+	t.Chdir(td)
+
+	provider := testing_command.NewProvider(nil)
+	view, done := testView(t)
+
+	c := &TestCommand{
+		WorkingDir:       workdir.NewDir("."),
+		testingOverrides: metaOverridesForProvider(provider.Provider),
+		View:             view,
+	}
+
+	code := c.Run([]string{"-no-color"})
+	output := done(t).All()
+
+	if code != 1 {
+		t.Errorf("expected status code 1 but got %d", code)
+	}
+
+	for _, expected := range []string{
+		"fuzz \"port\"... pass (100 cases)",
+		"fuzz \"name\"... fail",
+		"name = null",
+		"1 passed, 1 failed.",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("output didn't contain expected string %q:\n\n%s", expected, output)
+		}
+	}
+
+	if provider.ResourceCount() > 0 {
+		t.Errorf("fuzz blocks should not create resources but left %v", provider.ResourceString())
+	}
+}
+
 func TestTest_DoubleInterrupt(t *testing.T) {
 	td := t.TempDir()
 	testCopyDir(t, testFixturePath(path.Join("test", "with_double_interrupt")), td)
@@ -0,0 +1,32 @@
+variable "input" {
+  type = string
+}
+
+resource "test_resource" "resource" {
+  value = var.input
+}
+
+ephemeral "test_ephemeral_resource" "resource" {
+  id = var.input
+}
+
+check "value_is_set" {
+  assert {
+    condition     = test_resource.resource.value != ""
+    error_message = "value must not be empty"
+  }
+}
+
+check "value_is_long" {
+  assert {
+    condition     = length(test_resource.resource.value) > 20
+    error_message = "value is too short"
+  }
+}
+
+check "secret_is_set" {
+  assert {
+    condition     = ephemeral.test_ephemeral_resource.resource.value != ""
+    error_message = "secret must not be empty"
+  }
+}
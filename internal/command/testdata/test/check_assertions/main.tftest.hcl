@@ -0,0 +1,42 @@
+variables {
+  input = "short"
+}
+
+run "plan" {
+  command = plan
+
+  expect_check_failures = [
+    check.value_is_long
+  ]
+
+  assert {
+    condition     = check.value_is_set.status == "pass"
+    error_message = "check.value_is_set should pass"
+  }
+
+  assert {
+    condition     = check.value_is_long.status == "fail"
+    error_message = "check.value_is_long should fail"
+  }
+
+  assert {
+    condition     = ephemeral.test_ephemeral_resource.resource.value == "ephemeral-short"
+    error_message = "wrong ephemeral value"
+  }
+}
+
+run "apply" {
+  expect_check_failures = [
+    check.value_is_long
+  ]
+
+  assert {
+    condition     = contains(check.value_is_long.messages, "value is too short")
+    error_message = "wrong check messages"
+  }
+
+  assert {
+    condition     = ephemeral.test_ephemeral_resource.resource.value == "ephemeral-short"
+    error_message = "wrong ephemeral value"
+  }
+}
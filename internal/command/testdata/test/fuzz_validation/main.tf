@@ -0,0 +1,22 @@
+variable "port" {
+  type     = number
+  nullable = false
+
+  validation {
+    condition     = var.port > 0 && var.port < 65536
+    error_message = "The port must be between 1 and 65535."
+  }
+}
+
+variable "name" {
+  type = string
+
+  validation {
+    condition     = length(var.name) > 0
+    error_message = "The name must not be empty."
+  }
+}
+
+resource "test_resource" "foo" {
+  value = "${var.name}:${var.port}"
+}
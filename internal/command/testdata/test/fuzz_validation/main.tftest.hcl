@@ -0,0 +1,18 @@
+fuzz "port" {
+  generator "port" {
+    min = -100
+    max = 70000
+  }
+
+  generator "name" {
+    values = ["web"]
+  }
+
+  expect_valid = var.port >= 1 && var.port <= 65535
+}
+
+fuzz "name" {
+  generator "port" {
+    values = [80]
+  }
+}
@@ -0,0 +1,26 @@
+run "fast" {
+  tags = ["fast"]
+
+  assert {
+    condition = test_resource.foo.value == "bar"
+    error_message = "invalid value"
+  }
+}
+
+run "slow" {
+  tags = ["slow"]
+
+  assert {
+    condition = test_resource.foo.value == "bar"
+    error_message = "invalid value"
+  }
+}
+
+run "flaky" {
+  tags = ["fast", "flaky"]
+
+  assert {
+    condition = test_resource.foo.value == "bar"
+    error_message = "invalid value"
+  }
+}
@@ -0,0 +1,4 @@
+resource "test_resource" "foo" {
+  value           = "bar"
+  interrupt_count = 1
+}
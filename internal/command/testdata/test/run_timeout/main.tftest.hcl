@@ -0,0 +1,12 @@
+run "slow" {
+  timeout = "100ms"
+}
+
+run "after" {
+  command = plan
+
+  assert {
+    condition = test_resource.foo.value == "bar"
+    error_message = "invalid value"
+  }
+}
@@ -65,7 +65,16 @@ var (
 				},
 			},
 		},
-		// TODO ephemeral testing support - when implementing testing support for ephemeral resources, consider configuring ephemeral schema here
+		EphemeralResources: map[string]providers.Schema{
+			"test_ephemeral_resource": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":    {Type: cty.String, Required: true},
+						"value": {Type: cty.String, Computed: true},
+					},
+				},
+			},
+		},
 	}
 )
 
@@ -99,6 +108,7 @@ func NewProvider(store *ResourceStore) *TestProvider {
 	provider.Provider.ApplyResourceChangeFn = provider.ApplyResourceChange
 	provider.Provider.ReadResourceFn = provider.ReadResource
 	provider.Provider.ReadDataSourceFn = provider.ReadDataSource
+	provider.Provider.OpenEphemeralResourceFn = provider.OpenEphemeralResource
 
 	return provider
 }
@@ -286,6 +296,23 @@ func (provider *TestProvider) ReadDataSource(request providers.ReadDataSourceReq
 	}
 }
 
+func (provider *TestProvider) OpenEphemeralResource(request providers.OpenEphemeralResourceRequest) providers.OpenEphemeralResourceResponse {
+	// Ephemeral resources are not kept in the store, their value is derived
+	// from the configuration so tests can predict it.
+	id := request.Config.GetAttr("id")
+	value := cty.UnknownVal(cty.String)
+	if id.IsKnown() && !id.IsNull() {
+		value = cty.StringVal("ephemeral-" + id.AsString())
+	}
+
+	return providers.OpenEphemeralResourceResponse{
+		Result: cty.ObjectVal(map[string]cty.Value{
+			"id":    id,
+			"value": value,
+		}),
+	}
+}
+
 // ResourceStore manages a set of cty.Value resources that can be shared between
 // TestProvider providers.
 type ResourceStore struct {
@@ -37,6 +37,10 @@ func (h moduleInstallationHookHuman) Install(modulePath string, v *version.Versi
 	}
 }
 
+func (h moduleInstallationHookHuman) PackageSignatureVerified(modulePath, packageAddr, signer string) {
+	_, _ = h.v.streams.Println(fmt.Sprintf("- %s: verified cosign signature from %s", modulePath, signer))
+}
+
 // moduleInstallationHookJSON is the implementation of [initwd.ModuleInstallHooks] that prints the modules
 // installation progress information in JSON format.
 type moduleInstallationHookJSON struct {
@@ -62,6 +66,10 @@ func (h moduleInstallationHookJSON) Install(modulePath string, _ *version.Versio
 	}
 }
 
+func (h moduleInstallationHookJSON) PackageSignatureVerified(modulePath, packageAddr, signer string) {
+	h.v.Info(fmt.Sprintf("%s: verified cosign signature from %s", modulePath, signer))
+}
+
 // moduleInstallationHookMulti is the implementation of [initwd.ModuleInstallHooks] that wraps multiple
 // implementation of [initwd.ModuleInstallHooks] and acts as a proxy for all of those.
 // This is used for the `-json-into` flag.
@@ -80,3 +88,9 @@ func (m moduleInstallationHookMulti) Install(modulePath string, v *version.Versi
 		h.Install(modulePath, v, localDir)
 	}
 }
+
+func (m moduleInstallationHookMulti) PackageSignatureVerified(modulePath, packageAddr, signer string) {
+	for _, h := range m {
+		h.PackageSignatureVerified(modulePath, packageAddr, signer)
+	}
+}
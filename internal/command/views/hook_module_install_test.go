@@ -75,6 +75,19 @@ func TestModuleInstallationHooks(t *testing.T) {
 			},
 			wantStdout: withNewline("- root.networking in /path/to/.terraform/modules/networking"),
 		},
+		"package_signature_verified": {
+			viewCall: func(hook initwd.ModuleInstallHooks) {
+				hook.PackageSignatureVerified("root.networking", "oci://example.com/modules/networking", "key 0123456789ABCDEF")
+			},
+			wantJson: []map[string]any{
+				{
+					"@level":   "info",
+					"@message": "root.networking: verified cosign signature from key 0123456789ABCDEF",
+					"@module":  "tofu.ui",
+				},
+			},
+			wantStdout: withNewline("- root.networking: verified cosign signature from key 0123456789ABCDEF"),
+		},
 	}
 
 	for name, tc := range tests {
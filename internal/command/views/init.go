@@ -8,8 +8,10 @@ package views
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/initwd"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -35,8 +37,10 @@ type Init interface {
 	FindingLatestProviderVersion(provider string)
 	UsingProviderFromCache(provider string, version string)
 	InstallingProvider(provider string, version string, toCache bool)
+	DownloadingProvider(provider string, version string, progress getproviders.PackageDownloadProgress)
 	ProviderInstalled(provider string, version string, authResult string, keyID string)
 	ProviderInstalledSkippedSignature(provider string, version string)
+	ProviderInstalledOCISigned(provider string, version string, signers string)
 	WaitingForCacheLock(cacheDir string)
 	ProvidersSignedInfo()
 	ProviderUpgradeLockfileConflict()
@@ -168,6 +172,12 @@ func (m InitMulti) InstallingProvider(provider string, version string, toCache b
 	}
 }
 
+func (m InitMulti) DownloadingProvider(provider string, version string, progress getproviders.PackageDownloadProgress) {
+	for _, o := range m {
+		o.DownloadingProvider(provider, version, progress)
+	}
+}
+
 func (m InitMulti) ProviderInstalled(provider string, version string, authResult string, keyID string) {
 	for _, o := range m {
 		o.ProviderInstalled(provider, version, authResult, keyID)
@@ -180,6 +190,12 @@ func (m InitMulti) ProviderInstalledSkippedSignature(provider string, version st
 	}
 }
 
+func (m InitMulti) ProviderInstalledOCISigned(provider string, version string, signers string) {
+	for _, o := range m {
+		o.ProviderInstalledOCISigned(provider, version, signers)
+	}
+}
+
 func (m InitMulti) WaitingForCacheLock(cacheDir string) {
 	for _, o := range m {
 		o.WaitingForCacheLock(cacheDir)
@@ -351,6 +367,10 @@ func (v *InitHuman) InstallingProvider(provider string, version string, toCache
 	}
 }
 
+func (v *InitHuman) DownloadingProvider(provider string, version string, progress getproviders.PackageDownloadProgress) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("- Downloading %s v%s: %s", provider, version, formatDownloadProgress(progress)))
+}
+
 func (v *InitHuman) ProviderInstalled(provider string, version string, authResult string, keyID string) {
 	if keyID != "" {
 		keyID = v.view.colorize.Color(fmt.Sprintf(", key ID [reset][bold]%s[reset]", keyID))
@@ -362,6 +382,11 @@ func (v *InitHuman) ProviderInstalledSkippedSignature(provider string, version s
 	_, _ = v.view.streams.Println(fmt.Sprintf("- Installed %s v%s. Signature validation was skipped due to the registry not containing GPG keys for this provider", provider, version))
 }
 
+func (v *InitHuman) ProviderInstalledOCISigned(provider string, version string, signers string) {
+	signers = v.view.colorize.Color(fmt.Sprintf("[reset][bold]%s[reset]", signers))
+	_, _ = v.view.streams.Println(fmt.Sprintf("- Installed %s v%s (signed, cosign signer %s)", provider, version, signers))
+}
+
 func (v *InitHuman) WaitingForCacheLock(cacheDir string) {
 	_, _ = v.view.streams.Println(fmt.Sprintf("- Waiting for lock on cache directory %s", cacheDir))
 }
@@ -501,6 +526,10 @@ func (v *InitJSON) InstallingProvider(provider string, version string, toCache b
 	}
 }
 
+func (v *InitJSON) DownloadingProvider(provider string, version string, progress getproviders.PackageDownloadProgress) {
+	v.view.Info(fmt.Sprintf("Downloading %s v%s: %s", provider, version, formatDownloadProgress(progress)))
+}
+
 func (v *InitJSON) ProviderInstalled(provider string, version string, authResult string, keyID string) {
 	if keyID != "" {
 		keyID = fmt.Sprintf(", key ID %s", keyID)
@@ -512,6 +541,10 @@ func (v *InitJSON) ProviderInstalledSkippedSignature(provider string, version st
 	v.view.Warn(fmt.Sprintf("Installed %s v%s. Signature validation was skipped due to the registry not containing GPG keys for this provider", provider, version))
 }
 
+func (v *InitJSON) ProviderInstalledOCISigned(provider string, version string, signers string) {
+	v.view.Info(fmt.Sprintf("Installed %s v%s (signed, cosign signer %s)", provider, version, signers))
+}
+
 func (v *InitJSON) WaitingForCacheLock(cacheDir string) {
 	v.view.Info(fmt.Sprintf("Waiting for lock on cache directory %s", cacheDir))
 }
@@ -555,3 +588,39 @@ func (v *InitJSON) Backend() Backend {
 		view: v.view,
 	}
 }
+
+// formatDownloadProgress describes the progress of a provider package
+// download in a form suitable for a single line of UI output.
+func formatDownloadProgress(progress getproviders.PackageDownloadProgress) string {
+	var buf strings.Builder
+	if progress.Total >= 0 {
+		percent := int64(100)
+		if progress.Total > 0 {
+			percent = progress.Downloaded * 100 / progress.Total
+		}
+		fmt.Fprintf(&buf, "%s of %s (%d%%)", formatByteSize(progress.Downloaded), formatByteSize(progress.Total), percent)
+		if eta, ok := progress.ETA(); ok && progress.Downloaded < progress.Total {
+			fmt.Fprintf(&buf, ", about %s remaining", eta.Round(time.Second))
+		}
+	} else {
+		fmt.Fprintf(&buf, "%s so far", formatByteSize(progress.Downloaded))
+	}
+	if progress.Resumed > 0 {
+		fmt.Fprintf(&buf, ", resumed after %s from an earlier attempt", formatByteSize(progress.Resumed))
+	}
+	return buf.String()
+}
+
+// formatByteSize formats a number of bytes using binary unit prefixes.
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/terminal"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -265,6 +267,41 @@ func TestInitViews(t *testing.T) {
 			},
 			wantStdout: withNewline("- Installing hashicorp/aws v5.0.0 to the shared cache directory..."),
 		},
+		"downloadingProvider": {
+			viewCall: func(init Init) {
+				init.DownloadingProvider("hashicorp/aws", "5.0.0", getproviders.PackageDownloadProgress{
+					Downloaded: 100 << 20,
+					Total:      400 << 20,
+					Elapsed:    10 * time.Second,
+				})
+			},
+			wantJson: []map[string]any{
+				{
+					"@level":   "info",
+					"@message": "Downloading hashicorp/aws v5.0.0: 100.0 MiB of 400.0 MiB (25%), about 30s remaining",
+					"@module":  "tofu.ui",
+				},
+			},
+			wantStdout: withNewline("- Downloading hashicorp/aws v5.0.0: 100.0 MiB of 400.0 MiB (25%), about 30s remaining"),
+		},
+		"downloadingProvider_resumedUnknownSize": {
+			viewCall: func(init Init) {
+				init.DownloadingProvider("hashicorp/aws", "5.0.0", getproviders.PackageDownloadProgress{
+					Downloaded: 3 << 10,
+					Total:      -1,
+					Resumed:    1000,
+					Elapsed:    time.Second,
+				})
+			},
+			wantJson: []map[string]any{
+				{
+					"@level":   "info",
+					"@message": "Downloading hashicorp/aws v5.0.0: 3.0 KiB so far, resumed after 1000 B from an earlier attempt",
+					"@module":  "tofu.ui",
+				},
+			},
+			wantStdout: withNewline("- Downloading hashicorp/aws v5.0.0: 3.0 KiB so far, resumed after 1000 B from an earlier attempt"),
+		},
 		"providerInstalled_noKeyID": {
 			viewCall: func(init Init) {
 				init.ProviderInstalled("hashicorp/aws", "5.0.0", "signed by HashiCorp", "")
@@ -372,6 +409,20 @@ func TestInitViews(t *testing.T) {
 			wantStdout: withNewline("- Installed hashicorp/random v3.0.0. Signature validation was skipped due to the registry not containing GPG keys for this provider"),
 			wantStderr: "",
 		},
+		"providerInstalledOCISigned": {
+			viewCall: func(init Init) {
+				init.ProviderInstalledOCISigned("example.com/foo/bar", "1.0.0", "key 0123456789ABCDEF")
+			},
+			wantJson: []map[string]any{
+				{
+					"@level":   "info",
+					"@message": "Installed example.com/foo/bar v1.0.0 (signed, cosign signer key 0123456789ABCDEF)",
+					"@module":  "tofu.ui",
+				},
+			},
+			wantStdout: withNewline("- Installed example.com/foo/bar v1.0.0 (signed, cosign signer key 0123456789ABCDEF)"),
+			wantStderr: "",
+		},
 		"providerUpgradeLockfileConflict": {
 			viewCall: func(init Init) {
 				init.ProviderUpgradeLockfileConflict()
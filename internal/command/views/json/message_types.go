@@ -37,6 +37,7 @@ const (
 	MessageTestAbstract  MessageType = "test_abstract"
 	MessageTestFile      MessageType = "test_file"
 	MessageTestRun       MessageType = "test_run"
+	MessageTestFuzz      MessageType = "test_fuzz"
 	MessageTestPlan      MessageType = "test_plan"
 	MessageTestState     MessageType = "test_state"
 	MessageTestSummary   MessageType = "test_summary"
@@ -26,12 +26,20 @@ type TestRunStatus struct {
 	Status TestStatus `json:"status"`
 }
 
+type TestFuzzStatus struct {
+	Path   string     `json:"path"`
+	Fuzz   string     `json:"fuzz"`
+	Status TestStatus `json:"status"`
+	Cases  int        `json:"cases"`
+}
+
 type TestSuiteSummary struct {
-	Status  TestStatus `json:"status"`
-	Passed  int        `json:"passed"`
-	Failed  int        `json:"failed"`
-	Errored int        `json:"errored"`
-	Skipped int        `json:"skipped"`
+	Status   TestStatus `json:"status"`
+	Passed   int        `json:"passed"`
+	Failed   int        `json:"failed"`
+	Errored  int        `json:"errored"`
+	TimedOut int        `json:"timed_out,omitempty"`
+	Skipped  int        `json:"skipped"`
 }
 
 type TestFileCleanup struct {
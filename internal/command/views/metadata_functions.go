@@ -23,7 +23,8 @@ var (
 type MetadataFunctions interface {
 	Diagnostics(diags tfdiags.Diagnostics)
 	// PrintFunctions returns true if it managed to print the functions and false otherwise.
-	PrintFunctions() bool
+	// The given module functions, if any, are printed along with the built-in functions.
+	PrintFunctions(moduleFunctions map[string]*lang.UserFunction) bool
 }
 
 // NewMetadataFunctions returns an initialized MetadataFunctions implementation for the given ViewType.
@@ -43,8 +44,10 @@ func (v *MetadataFunctionsMixed) Diagnostics(diags tfdiags.Diagnostics) {
 	v.view.Diagnostics(diags)
 }
 
-func (v *MetadataFunctionsMixed) PrintFunctions() bool {
-	scope := &lang.Scope{}
+func (v *MetadataFunctionsMixed) PrintFunctions(moduleFunctions map[string]*lang.UserFunction) bool {
+	scope := &lang.Scope{
+		ModuleFunctions: moduleFunctions,
+	}
 	funcs := scope.Functions()
 	filteredFuncs := make(map[string]function.Function)
 	for k, f := range funcs {
@@ -61,7 +61,7 @@ func TestMetadataFunctions_Diagnostics(t *testing.T) {
 func TestMetadataFunctions_printFunctions(t *testing.T) {
 	view, done := testView(t)
 	v := NewMetadataFunctions(view)
-	status := v.PrintFunctions()
+	status := v.PrintFunctions(nil)
 	output := done(t)
 	if !status {
 		t.Fatalf("failed to generate the functions output: %s", output.All())
@@ -0,0 +1,189 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+
+	version "github.com/hashicorp/go-version"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ModulesOutdated interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	// Outdated reports the newer versions of each of the given module calls,
+	// returning true if the printing has been done successfully and false
+	// otherwise.
+	Outdated(modules []initwd.ModuleVersions) bool
+}
+
+// NewModulesOutdated returns an initialized ModulesOutdated implementation for the given ViewType.
+// As with [NewProvidersOutdated], the returned view always prints diagnostics in human format, while
+// [ModulesOutdated.Outdated] prints a single JSON document when the -json option is set.
+func NewModulesOutdated(args *arguments.View, view *View) ModulesOutdated {
+	return &ModulesOutdatedMixed{view: view, json: args.ViewType == arguments.ViewJSON}
+}
+
+type ModulesOutdatedMixed struct {
+	view *View
+	json bool
+}
+
+var _ ModulesOutdated = (*ModulesOutdatedMixed)(nil)
+
+func (v *ModulesOutdatedMixed) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ModulesOutdatedMixed) Outdated(modules []initwd.ModuleVersions) bool {
+	var checked []initwd.ModuleVersions
+	for _, mv := range modules {
+		if mv.Checked || mv.Failed {
+			checked = append(checked, mv)
+		}
+	}
+	if v.json {
+		return v.printJSON(checked)
+	}
+	v.printHuman(checked)
+	return true
+}
+
+func (v *ModulesOutdatedMixed) printHuman(modules []initwd.ModuleVersions) {
+	if len(modules) == 0 {
+		_, _ = v.view.streams.Println("The configuration does not call any modules from a module registry or OCI repository.")
+		return
+	}
+
+	upgradable := false
+	restricted := false
+	for _, mv := range modules {
+		_, _ = v.view.streams.Println(fmt.Sprintf("- %s: %s", mv.Path, describeModuleVersion(mv)))
+
+		if mv.Failed {
+			_, _ = v.view.streams.Println("  - Not checked, because the available versions could not be retrieved")
+			continue
+		}
+		allowed, disallowed := mv.Newer()
+		if len(allowed) == 0 && len(disallowed) == 0 {
+			_, _ = v.view.streams.Println("  - Up to date")
+			continue
+		}
+		if len(allowed) != 0 {
+			upgradable = true
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - %s allowed by the version constraints, up to v%s", countModuleVersions(allowed, mv.Installed), allowed[len(allowed)-1]))
+		}
+		if len(disallowed) != 0 {
+			restricted = true
+			reason := "not allowed by the version constraints"
+			if _, ok := mv.SourceAddr.(addrs.ModuleSourceRegistry); !ok {
+				reason = "not selected by the source address"
+			}
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - %s %s, up to v%s", countModuleVersions(disallowed, mv.Installed), reason, disallowed[len(disallowed)-1]))
+		}
+	}
+
+	if upgradable || restricted {
+		_, _ = v.view.streams.Println("")
+	}
+	if upgradable {
+		_, _ = v.view.streams.Println("Run \"tofu init -upgrade\" to install the newest versions allowed by the version constraints.")
+	}
+	if restricted {
+		_, _ = v.view.streams.Println("Change the version constraints or the tag in the source address of a module call to use a version that they don't allow.")
+	}
+}
+
+// describeModuleVersion returns the source address of the given module call
+// along with its installed version and version constraints, if any.
+func describeModuleVersion(mv initwd.ModuleVersions) string {
+	ret := mv.SourceAddr.ForDisplay()
+	if mv.Installed != nil {
+		ret += fmt.Sprintf(" v%s", mv.Installed)
+	}
+	if mv.Constraints.HasRequirements() {
+		ret += fmt.Sprintf(", version constraints %s", mv.Constraints.String())
+	}
+	return ret
+}
+
+func countModuleVersions(l version.Collection, installed *version.Version) string {
+	noun := "version"
+	if len(l) != 1 {
+		noun = "versions"
+	}
+	if installed == nil {
+		return fmt.Sprintf("%d %s", len(l), noun)
+	}
+	return fmt.Sprintf("%d newer %s", len(l), noun)
+}
+
+func (v *ModulesOutdatedMixed) printJSON(modules []initwd.ModuleVersions) bool {
+	output := modulesOutdatedOutput{
+		FormatVersion: "1.0",
+		Modules:       make([]moduleOutdatedOutput, 0, len(modules)),
+	}
+	for _, mv := range modules {
+		allowed, disallowed := mv.Newer()
+		output.Modules = append(output.Modules, moduleOutdatedOutput{
+			Address:            mv.Path.String(),
+			Source:             mv.SourceAddr.String(),
+			VersionConstraints: mv.Constraints.String(),
+			Installed:          moduleVersionString(mv.Installed),
+			Latest:             moduleVersionString(mv.Latest()),
+			NewerAllowed:       moduleVersionStrings(allowed),
+			NewerDisallowed:    moduleVersionStrings(disallowed),
+			Unchecked:          mv.Failed,
+		})
+	}
+
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		_, _ = v.view.streams.Eprintln(fmt.Sprintf("\nError marshalling JSON: %s", err))
+		return false
+	}
+	_, _ = v.view.streams.Println(string(jsonOutput))
+	return true
+}
+
+// moduleVersionString returns the given version as it was originally written,
+// so that versions from OCI tags like "v1.0.0" are reported in the same form
+// as the tag.
+func moduleVersionString(v *version.Version) string {
+	if v == nil {
+		return ""
+	}
+	return v.Original()
+}
+
+func moduleVersionStrings(l version.Collection) []string {
+	ret := make([]string, len(l))
+	for i, v := range l {
+		ret[i] = v.Original()
+	}
+	return ret
+}
+
+type modulesOutdatedOutput struct {
+	FormatVersion string                 `json:"format_version"`
+	Modules       []moduleOutdatedOutput `json:"modules"`
+}
+
+type moduleOutdatedOutput struct {
+	Address            string   `json:"address"`
+	Source             string   `json:"source"`
+	VersionConstraints string   `json:"version_constraints,omitempty"`
+	Installed          string   `json:"installed,omitempty"`
+	Latest             string   `json:"latest,omitempty"`
+	NewerAllowed       []string `json:"newer_allowed"`
+	NewerDisallowed    []string `json:"newer_disallowed"`
+	Unchecked          bool     `json:"unchecked,omitempty"`
+}
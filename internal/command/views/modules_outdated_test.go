@@ -0,0 +1,215 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	version "github.com/hashicorp/go-version"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestModulesOutdatedView(t *testing.T) {
+	modules := testModuleVersions(t)
+	// The versions of module.storage.module.backup couldn't be retrieved.
+	unchecked := slices.Clone(modules)
+	unchecked[3] = initwd.ModuleVersions{
+		Path:       unchecked[3].Path,
+		SourceAddr: unchecked[3].SourceAddr,
+		Installed:  unchecked[3].Installed,
+		Failed:     true,
+	}
+
+	tests := map[string]struct {
+		viewType   arguments.ViewType
+		viewCall   func(v ModulesOutdated)
+		wantStdout string
+		wantStderr string
+	}{
+		"human": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ModulesOutdated) {
+				v.Outdated(modules)
+			},
+			wantStdout: `- module.network: hashicorp/consul/aws v1.1.0, version constraints ~> 1.0
+  - 1 newer version allowed by the version constraints, up to v1.2.0
+  - 1 newer version not allowed by the version constraints, up to v2.0.0
+- module.storage: oci://example.com/modules/storage?tag=1.0.0 v1.0.0
+  - 1 newer version not selected by the source address, up to v1.1.0
+- module.storage.module.backup: hashicorp/backup/aws v3.0.0
+  - Up to date
+
+Run "tofu init -upgrade" to install the newest versions allowed by the version constraints.
+Change the version constraints or the tag in the source address of a module call to use a version that they don't allow.
+`,
+		},
+		"human with unchecked modules": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ModulesOutdated) {
+				v.Outdated(unchecked[2:])
+			},
+			wantStdout: `- module.storage: oci://example.com/modules/storage?tag=1.0.0 v1.0.0
+  - 1 newer version not selected by the source address, up to v1.1.0
+- module.storage.module.backup: hashicorp/backup/aws v3.0.0
+  - Not checked, because the available versions could not be retrieved
+
+Change the version constraints or the tag in the source address of a module call to use a version that they don't allow.
+`,
+		},
+		"human without versioned modules": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ModulesOutdated) {
+				v.Outdated(modules[1:2])
+			},
+			wantStdout: "The configuration does not call any modules from a module registry or OCI repository.\n",
+		},
+		"json": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ModulesOutdated) {
+				v.Outdated(modules)
+			},
+			wantStdout: `{
+  "format_version": "1.0",
+  "modules": [
+    {
+      "address": "module.network",
+      "source": "registry.opentofu.org/hashicorp/consul/aws",
+      "version_constraints": "~> 1.0",
+      "installed": "1.1.0",
+      "latest": "2.0.0",
+      "newer_allowed": [
+        "1.2.0"
+      ],
+      "newer_disallowed": [
+        "2.0.0"
+      ]
+    },
+    {
+      "address": "module.storage",
+      "source": "oci://example.com/modules/storage?tag=1.0.0",
+      "installed": "1.0.0",
+      "latest": "1.1.0",
+      "newer_allowed": [],
+      "newer_disallowed": [
+        "1.1.0"
+      ]
+    },
+    {
+      "address": "module.storage.module.backup",
+      "source": "registry.opentofu.org/hashicorp/backup/aws",
+      "installed": "3.0.0",
+      "latest": "3.0.0",
+      "newer_allowed": [],
+      "newer_disallowed": []
+    }
+  ]
+}
+`,
+		},
+		"json with unchecked modules": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ModulesOutdated) {
+				v.Outdated(unchecked[3:])
+			},
+			wantStdout: `{
+  "format_version": "1.0",
+  "modules": [
+    {
+      "address": "module.storage.module.backup",
+      "source": "registry.opentofu.org/hashicorp/backup/aws",
+      "installed": "3.0.0",
+      "newer_allowed": [],
+      "newer_disallowed": [],
+      "unchecked": true
+    }
+  ]
+}
+`,
+		},
+		"json diagnostics are human": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ModulesOutdated) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewModulesOutdated(&arguments.View{ViewType: tc.viewType}, view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// testModuleVersions returns the versions of a module tree with a registry
+// module that has a local child module and an OCI module that has a
+// registry child module, in the order returned by
+// [initwd.CheckModuleVersions].
+func testModuleVersions(t *testing.T) []initwd.ModuleVersions {
+	t.Helper()
+
+	registrySource := func(raw string) addrs.ModuleSource {
+		addr, err := addrs.ParseModuleSourceRegistry(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return addr
+	}
+	versions := func(raw ...string) version.Collection {
+		ret := make(version.Collection, len(raw))
+		for i, s := range raw {
+			ret[i] = version.Must(version.NewVersion(s))
+		}
+		return ret
+	}
+
+	return []initwd.ModuleVersions{
+		{
+			Path:        addrs.Module{"network"},
+			SourceAddr:  registrySource("hashicorp/consul/aws"),
+			Constraints: configs.VersionConstraint{Required: version.MustConstraints(version.NewConstraint("~> 1.0"))},
+			Installed:   version.Must(version.NewVersion("1.1.0")),
+			Available:   versions("1.0.0", "1.1.0", "1.2.0", "2.0.0", "2.1.0-beta1"),
+			Checked:     true,
+		},
+		{
+			Path:       addrs.Module{"network", "subnets"},
+			SourceAddr: addrs.ModuleSourceLocal("./subnets"),
+		},
+		{
+			Path:       addrs.Module{"storage"},
+			SourceAddr: addrs.ModuleSourceRemote{Package: addrs.ModulePackage("oci://example.com/modules/storage?tag=1.0.0")},
+			Installed:  version.Must(version.NewVersion("1.0.0")),
+			Available:  versions("1.0.0", "1.1.0"),
+			Checked:    true,
+		},
+		{
+			Path:       addrs.Module{"storage", "backup"},
+			SourceAddr: registrySource("hashicorp/backup/aws"),
+			Installed:  version.Must(version.NewVersion("3.0.0")),
+			Available:  versions("2.0.0", "3.0.0"),
+			Checked:    true,
+		},
+	}
+}
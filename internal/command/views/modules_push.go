@@ -0,0 +1,45 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ModulesPush interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+
+	// Pushed reports that the module package was published to the given
+	// repository under the given tag, with a manifest of the given digest.
+	// signer describes the key that signed the manifest, if any.
+	Pushed(repository, tag, digest, signer string)
+}
+
+// NewModulesPush returns an initialized ModulesPush implementation.
+func NewModulesPush(view *View) ModulesPush {
+	return &ModulesPushHuman{view: view}
+}
+
+type ModulesPushHuman struct {
+	view *View
+}
+
+var _ ModulesPush = (*ModulesPushHuman)(nil)
+
+func (v *ModulesPushHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ModulesPushHuman) Pushed(repository, tag, digest, signer string) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("Pushed module package to oci://%s?tag=%s", repository, tag))
+	_, _ = v.view.streams.Println(fmt.Sprintf("Digest: %s", digest))
+	if signer != "" {
+		_, _ = v.view.streams.Println(fmt.Sprintf("Signed by %s", signer))
+	}
+	_, _ = v.view.streams.Println(fmt.Sprintf("\nTo always install exactly this package, use the following module source address:\n  oci://%s?digest=%s", repository, digest))
+}
@@ -0,0 +1,68 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestModulesPushView(t *testing.T) {
+	tests := map[string]struct {
+		viewCall   func(v ModulesPush)
+		wantStdout string
+		wantStderr string
+	}{
+		"pushed": {
+			viewCall: func(v ModulesPush) {
+				v.Pushed("example.com/network", "v1.0.0", "sha256:abc", "")
+			},
+			wantStdout: `Pushed module package to oci://example.com/network?tag=v1.0.0
+Digest: sha256:abc
+
+To always install exactly this package, use the following module source address:
+  oci://example.com/network?digest=sha256:abc
+`,
+		},
+		"pushed and signed": {
+			viewCall: func(v ModulesPush) {
+				v.Pushed("example.com/network", "v1.0.0", "sha256:abc", "key 0123456789ABCDEF")
+			},
+			wantStdout: `Pushed module package to oci://example.com/network?tag=v1.0.0
+Digest: sha256:abc
+Signed by key 0123456789ABCDEF
+
+To always install exactly this package, use the following module source address:
+  oci://example.com/network?digest=sha256:abc
+`,
+		},
+		"diagnostics error": {
+			viewCall: func(v ModulesPush) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewModulesPush(view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/xlab/treeprint"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/initwd"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ModulesTree interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	// Tree prints the given module calls as a tree, returning true if the
+	// printing has been done successfully and false otherwise.
+	//
+	// The module calls must be in the order returned by
+	// [initwd.CheckModuleVersions], where each call appears after the call
+	// of its parent module.
+	Tree(modules []initwd.ModuleVersions) bool
+}
+
+// NewModulesTree returns an initialized ModulesTree implementation for the given ViewType.
+// As with [NewProvidersOutdated], the returned view always prints diagnostics in human format, while
+// [ModulesTree.Tree] prints a single JSON document when the -json option is set.
+func NewModulesTree(args *arguments.View, view *View) ModulesTree {
+	return &ModulesTreeMixed{view: view, json: args.ViewType == arguments.ViewJSON}
+}
+
+type ModulesTreeMixed struct {
+	view *View
+	json bool
+}
+
+var _ ModulesTree = (*ModulesTreeMixed)(nil)
+
+func (v *ModulesTreeMixed) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ModulesTreeMixed) Tree(modules []initwd.ModuleVersions) bool {
+	if v.json {
+		return v.printJSON(modules)
+	}
+	v.printHuman(modules)
+	return true
+}
+
+func (v *ModulesTreeMixed) printHuman(modules []initwd.ModuleVersions) {
+	if len(modules) == 0 {
+		_, _ = v.view.streams.Println("The configuration does not call any modules.")
+		return
+	}
+
+	tree := treeprint.New()
+	branches := map[string]treeprint.Tree{"": tree}
+	for _, mv := range modules {
+		parent := branches[mv.Path.Parent().String()]
+		label := fmt.Sprintf("module.%s: %s", mv.Path[len(mv.Path)-1], describeModuleVersion(mv))
+		switch {
+		case mv.Checked:
+			if latest := mv.Latest(); latest != nil {
+				label += fmt.Sprintf(" (latest v%s)", latest)
+			} else {
+				label += " (no versions available)"
+			}
+		case mv.Failed:
+			label += " (versions not checked)"
+		}
+		branches[mv.Path.String()] = parent.AddBranch(label)
+	}
+	_, _ = v.view.streams.Println(tree.String())
+}
+
+func (v *ModulesTreeMixed) printJSON(modules []initwd.ModuleVersions) bool {
+	output := modulesTreeOutput{
+		FormatVersion: "1.0",
+		Modules:       make([]*moduleTreeOutput, 0),
+	}
+	nodes := make(map[string]*moduleTreeOutput)
+	for _, mv := range modules {
+		node := &moduleTreeOutput{
+			Address:            mv.Path.String(),
+			Name:               mv.Path[len(mv.Path)-1],
+			Source:             mv.SourceAddr.String(),
+			VersionConstraints: mv.Constraints.String(),
+			Installed:          moduleVersionString(mv.Installed),
+			Unchecked:          mv.Failed,
+			Modules:            make([]*moduleTreeOutput, 0),
+		}
+		if mv.Checked {
+			node.Latest = moduleVersionString(mv.Latest())
+		}
+		nodes[node.Address] = node
+		if parent, ok := nodes[mv.Path.Parent().String()]; ok {
+			parent.Modules = append(parent.Modules, node)
+		} else {
+			output.Modules = append(output.Modules, node)
+		}
+	}
+
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		_, _ = v.view.streams.Eprintln(fmt.Sprintf("\nError marshalling JSON: %s", err))
+		return false
+	}
+	_, _ = v.view.streams.Println(string(jsonOutput))
+	return true
+}
+
+type modulesTreeOutput struct {
+	FormatVersion string              `json:"format_version"`
+	Modules       []*moduleTreeOutput `json:"modules"`
+}
+
+type moduleTreeOutput struct {
+	Address            string              `json:"address"`
+	Name               string              `json:"name"`
+	Source             string              `json:"source"`
+	VersionConstraints string              `json:"version_constraints,omitempty"`
+	Installed          string              `json:"installed,omitempty"`
+	Latest             string              `json:"latest,omitempty"`
+	Unchecked          bool                `json:"unchecked,omitempty"`
+	Modules            []*moduleTreeOutput `json:"modules"`
+}
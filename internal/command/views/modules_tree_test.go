@@ -0,0 +1,145 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/initwd"
+)
+
+func TestModulesTreeView(t *testing.T) {
+	modules := testModuleVersions(t)
+	// The versions of module.storage.module.backup couldn't be retrieved.
+	unchecked := slices.Clone(modules)
+	unchecked[3] = initwd.ModuleVersions{
+		Path:       unchecked[3].Path,
+		SourceAddr: unchecked[3].SourceAddr,
+		Installed:  unchecked[3].Installed,
+		Failed:     true,
+	}
+
+	tests := map[string]struct {
+		viewType   arguments.ViewType
+		viewCall   func(v ModulesTree)
+		wantStdout string
+	}{
+		"human": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ModulesTree) {
+				v.Tree(modules)
+			},
+			wantStdout: `.
+├── module.network: hashicorp/consul/aws v1.1.0, version constraints ~> 1.0 (latest v2.0.0)
+│   └── module.subnets: ./subnets
+└── module.storage: oci://example.com/modules/storage?tag=1.0.0 v1.0.0 (latest v1.1.0)
+    └── module.backup: hashicorp/backup/aws v3.0.0 (latest v3.0.0)
+
+`,
+		},
+		"human with unchecked modules": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ModulesTree) {
+				v.Tree(unchecked[2:])
+			},
+			wantStdout: `.
+└── module.storage: oci://example.com/modules/storage?tag=1.0.0 v1.0.0 (latest v1.1.0)
+    └── module.backup: hashicorp/backup/aws v3.0.0 (versions not checked)
+
+`,
+		},
+		"json with unchecked modules": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ModulesTree) {
+				v.Tree(unchecked[3:])
+			},
+			wantStdout: `{
+  "format_version": "1.0",
+  "modules": [
+    {
+      "address": "module.storage.module.backup",
+      "name": "backup",
+      "source": "registry.opentofu.org/hashicorp/backup/aws",
+      "installed": "3.0.0",
+      "unchecked": true,
+      "modules": []
+    }
+  ]
+}
+`,
+		},
+		"human without modules": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ModulesTree) {
+				v.Tree(nil)
+			},
+			wantStdout: "The configuration does not call any modules.\n",
+		},
+		"json": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ModulesTree) {
+				v.Tree(modules)
+			},
+			wantStdout: `{
+  "format_version": "1.0",
+  "modules": [
+    {
+      "address": "module.network",
+      "name": "network",
+      "source": "registry.opentofu.org/hashicorp/consul/aws",
+      "version_constraints": "~> 1.0",
+      "installed": "1.1.0",
+      "latest": "2.0.0",
+      "modules": [
+        {
+          "address": "module.network.module.subnets",
+          "name": "subnets",
+          "source": "./subnets",
+          "modules": []
+        }
+      ]
+    },
+    {
+      "address": "module.storage",
+      "name": "storage",
+      "source": "oci://example.com/modules/storage?tag=1.0.0",
+      "installed": "1.0.0",
+      "latest": "1.1.0",
+      "modules": [
+        {
+          "address": "module.storage.module.backup",
+          "name": "backup",
+          "source": "registry.opentofu.org/hashicorp/backup/aws",
+          "installed": "3.0.0",
+          "latest": "3.0.0",
+          "modules": []
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewModulesTree(&arguments.View{ViewType: tc.viewType}, view))
+			output := done(t)
+			if diff := cmp.Diff("", output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
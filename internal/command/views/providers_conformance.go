@@ -0,0 +1,158 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/providerconformance"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ProvidersConformance interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	// Report prints the result of the conformance checks, returning true if
+	// the printing has been done successfully and false otherwise.
+	Report(report *providerconformance.Report) bool
+}
+
+// NewProvidersConformance returns an initialized ProvidersConformance implementation for the given ViewType.
+// As with [NewProvidersOutdated], the returned view always prints diagnostics in human format, while
+// [ProvidersConformance.Report] prints a single JSON document when the -json option is set.
+func NewProvidersConformance(args *arguments.View, view *View) ProvidersConformance {
+	return &ProvidersConformanceMixed{view: view, json: args.ViewType == arguments.ViewJSON}
+}
+
+type ProvidersConformanceMixed struct {
+	view *View
+	json bool
+}
+
+var _ ProvidersConformance = (*ProvidersConformanceMixed)(nil)
+
+func (v *ProvidersConformanceMixed) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ProvidersConformanceMixed) Report(report *providerconformance.Report) bool {
+	if v.json {
+		return v.printJSON(report)
+	}
+	v.printHuman(report)
+	return true
+}
+
+func (v *ProvidersConformanceMixed) printHuman(report *providerconformance.Report) {
+	var violationCount, warningCount, skipped int
+	printViolations := func(violations []providerconformance.Violation) {
+		for _, violation := range violations {
+			kind := "Violation"
+			if violation.Warning {
+				kind = "Warning"
+				warningCount++
+			} else {
+				violationCount++
+			}
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - %s (%s): %s", kind, violation.Check, violation.Message))
+		}
+	}
+
+	if len(report.Violations) != 0 {
+		_, _ = v.view.streams.Println("- Provider schema")
+		printViolations(report.Violations)
+	}
+	for _, rt := range report.ResourceTypes {
+		_, _ = v.view.streams.Println(fmt.Sprintf("- %s", rt.TypeName))
+		if len(rt.Checks) != 0 && len(rt.Violations) == 0 {
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - Passed: %s", strings.Join(rt.Checks, ", ")))
+		}
+		printViolations(rt.Violations)
+		if rt.Skipped != "" {
+			skipped++
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - Skipped: %s", rt.Skipped))
+		}
+	}
+
+	if len(report.ResourceTypes) == 0 {
+		_, _ = v.view.streams.Println("The provider has no managed resource types to check.")
+		return
+	}
+	_, _ = v.view.streams.Println(fmt.Sprintf(
+		"\nChecked %s: %s, %s, %d skipped.",
+		countNoun(len(report.ResourceTypes), "resource type", "resource types"),
+		countNoun(violationCount, "violation", "violations"),
+		countNoun(warningCount, "warning", "warnings"),
+		skipped,
+	))
+}
+
+func countNoun(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+func (v *ProvidersConformanceMixed) printJSON(report *providerconformance.Report) bool {
+	output := providersConformanceOutput{
+		FormatVersion: "1.0",
+		Conforms:      !report.HasErrors(),
+		Violations:    conformanceViolationsOutput(report.Violations),
+		ResourceTypes: make([]conformanceResourceTypeOutput, 0, len(report.ResourceTypes)),
+	}
+	for _, rt := range report.ResourceTypes {
+		checks := append(make([]string, 0, len(rt.Checks)), rt.Checks...)
+		output.ResourceTypes = append(output.ResourceTypes, conformanceResourceTypeOutput{
+			Type:       rt.TypeName,
+			Checks:     checks,
+			Skipped:    rt.Skipped,
+			Violations: conformanceViolationsOutput(rt.Violations),
+		})
+	}
+
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		_, _ = v.view.streams.Eprintln(fmt.Sprintf("\nError marshalling JSON: %s", err))
+		return false
+	}
+	_, _ = v.view.streams.Println(string(jsonOutput))
+	return true
+}
+
+func conformanceViolationsOutput(violations []providerconformance.Violation) []conformanceViolationOutput {
+	ret := make([]conformanceViolationOutput, len(violations))
+	for i, violation := range violations {
+		ret[i] = conformanceViolationOutput{
+			Check:   violation.Check,
+			Message: violation.Message,
+			Warning: violation.Warning,
+		}
+	}
+	return ret
+}
+
+type providersConformanceOutput struct {
+	FormatVersion string                          `json:"format_version"`
+	Conforms      bool                            `json:"conforms"`
+	Violations    []conformanceViolationOutput    `json:"violations"`
+	ResourceTypes []conformanceResourceTypeOutput `json:"resource_types"`
+}
+
+type conformanceResourceTypeOutput struct {
+	Type       string                       `json:"type"`
+	Checks     []string                     `json:"checks"`
+	Skipped    string                       `json:"skipped,omitempty"`
+	Violations []conformanceViolationOutput `json:"violations"`
+}
+
+type conformanceViolationOutput struct {
+	Check   string `json:"check"`
+	Message string `json:"message"`
+	Warning bool   `json:"warning"`
+}
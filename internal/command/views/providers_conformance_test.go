@@ -0,0 +1,144 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/providerconformance"
+)
+
+func TestProvidersConformanceView(t *testing.T) {
+	report := &providerconformance.Report{
+		Violations: []providerconformance.Violation{
+			{
+				Check:   "schema",
+				Message: `GetProviderSchema returned a negative schema version for data resource type "example_lookup".`,
+			},
+		},
+		ResourceTypes: []providerconformance.ResourceTypeReport{
+			{
+				TypeName: "example_bucket",
+				Checks:   []string{"plan create", "upgrade state"},
+			},
+			{
+				TypeName: "example_instance",
+				Checks:   []string{"plan create", "upgrade state"},
+				Violations: []providerconformance.Violation{
+					{
+						Check:   "plan create",
+						Message: `PlanResourceChange planned an invalid value for example_instance.name: planned value cty.StringVal("other") does not match config value cty.StringVal("tofu-conformance-0").`,
+					},
+					{
+						Check:   "upgrade state",
+						Message: `UpgradeResourceState changed a state that was already at the current schema version: example_instance.tags: was null, but now cty.MapValEmpty(cty.String).`,
+						Warning: true,
+					},
+				},
+			},
+			{
+				TypeName: "example_network",
+				Skipped:  "The provider rejected the generated configuration: cidr_block must be a valid CIDR block",
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		viewType   arguments.ViewType
+		report     *providerconformance.Report
+		wantStdout string
+	}{
+		"human": {
+			viewType: arguments.ViewHuman,
+			report:   report,
+			wantStdout: `- Provider schema
+  - Violation (schema): GetProviderSchema returned a negative schema version for data resource type "example_lookup".
+- example_bucket
+  - Passed: plan create, upgrade state
+- example_instance
+  - Violation (plan create): PlanResourceChange planned an invalid value for example_instance.name: planned value cty.StringVal("other") does not match config value cty.StringVal("tofu-conformance-0").
+  - Warning (upgrade state): UpgradeResourceState changed a state that was already at the current schema version: example_instance.tags: was null, but now cty.MapValEmpty(cty.String).
+- example_network
+  - Skipped: The provider rejected the generated configuration: cidr_block must be a valid CIDR block
+
+Checked 3 resource types: 2 violations, 1 warning, 1 skipped.
+`,
+		},
+		"human without resource types": {
+			viewType:   arguments.ViewHuman,
+			report:     &providerconformance.Report{},
+			wantStdout: "The provider has no managed resource types to check.\n",
+		},
+		"json": {
+			viewType: arguments.ViewJSON,
+			report:   report,
+			wantStdout: `{
+  "format_version": "1.0",
+  "conforms": false,
+  "violations": [
+    {
+      "check": "schema",
+      "message": "GetProviderSchema returned a negative schema version for data resource type \"example_lookup\".",
+      "warning": false
+    }
+  ],
+  "resource_types": [
+    {
+      "type": "example_bucket",
+      "checks": [
+        "plan create",
+        "upgrade state"
+      ],
+      "violations": []
+    },
+    {
+      "type": "example_instance",
+      "checks": [
+        "plan create",
+        "upgrade state"
+      ],
+      "violations": [
+        {
+          "check": "plan create",
+          "message": "PlanResourceChange planned an invalid value for example_instance.name: planned value cty.StringVal(\"other\") does not match config value cty.StringVal(\"tofu-conformance-0\").",
+          "warning": false
+        },
+        {
+          "check": "upgrade state",
+          "message": "UpgradeResourceState changed a state that was already at the current schema version: example_instance.tags: was null, but now cty.MapValEmpty(cty.String).",
+          "warning": true
+        }
+      ]
+    },
+    {
+      "type": "example_network",
+      "checks": [],
+      "skipped": "The provider rejected the generated configuration: cidr_block must be a valid CIDR block",
+      "violations": []
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			NewProvidersConformance(&arguments.View{ViewType: tc.viewType}, view).Report(tc.report)
+			output := done(t)
+			if diff := cmp.Diff("", output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ProvidersOutdated interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	// Outdated reports the newer versions of each of the given providers,
+	// returning true if the printing has been done successfully and false
+	// otherwise.
+	Outdated(reqs getproviders.Requirements, selections map[addrs.Provider]getproviders.VersionSelection, locked map[addrs.Provider]getproviders.Version) bool
+}
+
+// NewProvidersOutdated returns an initialized ProvidersOutdated implementation for the given ViewType.
+// As with [NewVersion], the returned view always prints diagnostics in human format, while
+// [ProvidersOutdated.Outdated] prints a single JSON document when the -json option is set.
+func NewProvidersOutdated(args *arguments.View, view *View) ProvidersOutdated {
+	return &ProvidersOutdatedMixed{view: view, json: args.ViewType == arguments.ViewJSON}
+}
+
+type ProvidersOutdatedMixed struct {
+	view *View
+	json bool
+}
+
+var _ ProvidersOutdated = (*ProvidersOutdatedMixed)(nil)
+
+func (v *ProvidersOutdatedMixed) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ProvidersOutdatedMixed) Outdated(reqs getproviders.Requirements, selections map[addrs.Provider]getproviders.VersionSelection, locked map[addrs.Provider]getproviders.Version) bool {
+	providers := slices.SortedFunc(maps.Keys(selections), func(a, b addrs.Provider) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	if v.json {
+		return v.printJSON(providers, reqs, selections, locked)
+	}
+	v.printHuman(providers, reqs, selections, locked)
+	return true
+}
+
+func (v *ProvidersOutdatedMixed) printHuman(providers []addrs.Provider, reqs getproviders.Requirements, selections map[addrs.Provider]getproviders.VersionSelection, locked map[addrs.Provider]getproviders.Version) {
+	if len(providers) == 0 {
+		_, _ = v.view.streams.Println("The configuration does not require any providers that can be upgraded.")
+		return
+	}
+
+	upgradable := false
+	restricted := false
+	rejectedByPolicy := false
+	for _, provider := range providers {
+		selection := selections[provider]
+		current := locked[provider]
+		constraintsStr := getproviders.VersionConstraintsString(reqs[provider])
+		if constraintsStr == "" {
+			constraintsStr = "no version constraints"
+		} else {
+			constraintsStr = "version constraints " + constraintsStr
+		}
+
+		if current == getproviders.UnspecifiedVersion {
+			_, _ = v.view.streams.Println(fmt.Sprintf("- %s: not locked, %s", provider.ForDisplay(), constraintsStr))
+		} else {
+			_, _ = v.view.streams.Println(fmt.Sprintf("- %s: locked v%s, %s", provider.ForDisplay(), current, constraintsStr))
+		}
+
+		allowed, disallowed, rejected := selection.NewerThan(current)
+		if len(allowed) == 0 && len(disallowed) == 0 && len(rejected) == 0 {
+			_, _ = v.view.streams.Println("  - Up to date")
+			continue
+		}
+		if len(allowed) != 0 {
+			upgradable = true
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - %s allowed by the version constraints, up to v%s", countVersions(allowed, current), allowed[len(allowed)-1]))
+		}
+		if len(disallowed) != 0 {
+			restricted = true
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - %s not allowed by the version constraints, up to v%s", countVersions(disallowed, current), disallowed[len(disallowed)-1]))
+		}
+		if len(rejected) != 0 {
+			rejectedByPolicy = true
+			_, _ = v.view.streams.Println(fmt.Sprintf("  - %s rejected by the provider installation policy, up to v%s", countVersions(rejected, current), rejected[len(rejected)-1]))
+		}
+	}
+
+	if upgradable || restricted || rejectedByPolicy {
+		_, _ = v.view.streams.Println("")
+	}
+	if upgradable {
+		_, _ = v.view.streams.Println("Run \"tofu init -upgrade\" to select the newest versions allowed by the version constraints.")
+	}
+	switch {
+	case rejectedByPolicy:
+		_, _ = v.view.streams.Println("Run \"tofu providers why PROVIDER\" to see which version constraints or provider installation policy rules exclude newer versions of a provider.")
+	case restricted:
+		_, _ = v.view.streams.Println("Run \"tofu providers why PROVIDER\" to see which version constraints exclude newer versions of a provider.")
+	}
+}
+
+func countVersions(l getproviders.VersionList, current getproviders.Version) string {
+	noun := "version"
+	if len(l) != 1 {
+		noun = "versions"
+	}
+	if current == getproviders.UnspecifiedVersion {
+		return fmt.Sprintf("%d %s", len(l), noun)
+	}
+	return fmt.Sprintf("%d newer %s", len(l), noun)
+}
+
+func (v *ProvidersOutdatedMixed) printJSON(providers []addrs.Provider, reqs getproviders.Requirements, selections map[addrs.Provider]getproviders.VersionSelection, locked map[addrs.Provider]getproviders.Version) bool {
+	output := providersOutdatedOutput{
+		FormatVersion: "1.0",
+		Providers:     make([]providerOutdatedOutput, 0, len(providers)),
+	}
+	for _, provider := range providers {
+		selection := selections[provider]
+		current := locked[provider]
+		allowed, disallowed, rejected := selection.NewerThan(current)
+		output.Providers = append(output.Providers, providerOutdatedOutput{
+			Address:            provider.String(),
+			VersionConstraints: getproviders.VersionConstraintsString(reqs[provider]),
+			Locked:             versionString(current),
+			Selected:           versionString(selection.Selected),
+			Latest:             versionString(selection.Newest),
+			NewerAllowed:       versionStrings(allowed),
+			NewerDisallowed:    versionStrings(disallowed),
+			NewerRejected:      versionStrings(rejected),
+		})
+	}
+
+	jsonOutput, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		_, _ = v.view.streams.Eprintln(fmt.Sprintf("\nError marshalling JSON: %s", err))
+		return false
+	}
+	_, _ = v.view.streams.Println(string(jsonOutput))
+	return true
+}
+
+func versionString(version getproviders.Version) string {
+	if version == getproviders.UnspecifiedVersion {
+		return ""
+	}
+	return version.String()
+}
+
+func versionStrings(l getproviders.VersionList) []string {
+	ret := make([]string, len(l))
+	for i, version := range l {
+		ret[i] = version.String()
+	}
+	return ret
+}
+
+type providersOutdatedOutput struct {
+	FormatVersion string                   `json:"format_version"`
+	Providers     []providerOutdatedOutput `json:"providers"`
+}
+
+type providerOutdatedOutput struct {
+	Address            string   `json:"address"`
+	VersionConstraints string   `json:"version_constraints,omitempty"`
+	Locked             string   `json:"locked,omitempty"`
+	Selected           string   `json:"selected,omitempty"`
+	Latest             string   `json:"latest,omitempty"`
+	NewerAllowed       []string `json:"newer_allowed"`
+	NewerDisallowed    []string `json:"newer_disallowed"`
+	NewerRejected      []string `json:"newer_rejected"`
+}
@@ -0,0 +1,174 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/command/arguments"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestProvidersOutdatedView(t *testing.T) {
+	aws := addrs.NewDefaultProvider("aws")
+	random := addrs.NewDefaultProvider("random")
+	null := addrs.NewDefaultProvider("null")
+	reqs := getproviders.Requirements{
+		aws:    getproviders.MustParseVersionConstraints("~> 4.0"),
+		random: nil,
+		null:   getproviders.MustParseVersionConstraints(">= 3.0.0"),
+	}
+	selections := map[addrs.Provider]getproviders.VersionSelection{
+		aws: getproviders.NewVersionSelection(getproviders.VersionList{
+			getproviders.MustParseVersion("4.0.0"),
+			getproviders.MustParseVersion("4.1.0"),
+			getproviders.MustParseVersion("4.2.0"),
+			getproviders.MustParseVersion("5.0.0"),
+		}, []getproviders.VersionConstraints{reqs[aws]}),
+		random: getproviders.NewVersionSelection(getproviders.VersionList{
+			getproviders.MustParseVersion("3.6.0"),
+		}, []getproviders.VersionConstraints{reqs[random]}),
+		null: getproviders.NewVersionSelection(getproviders.VersionList{
+			getproviders.MustParseVersion("3.0.0"),
+			getproviders.MustParseVersion("3.1.0"),
+		}, []getproviders.VersionConstraints{reqs[null]}),
+	}
+	locked := map[addrs.Provider]getproviders.Version{
+		aws:    getproviders.MustParseVersion("4.1.0"),
+		random: getproviders.MustParseVersion("3.6.0"),
+	}
+
+	tests := map[string]struct {
+		viewType   arguments.ViewType
+		viewCall   func(v ProvidersOutdated)
+		wantStdout string
+		wantStderr string
+	}{
+		"human": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ProvidersOutdated) {
+				v.Outdated(reqs, selections, locked)
+			},
+			wantStdout: `- hashicorp/aws: locked v4.1.0, version constraints ~> 4.0
+  - 1 newer version allowed by the version constraints, up to v4.2.0
+  - 1 newer version not allowed by the version constraints, up to v5.0.0
+- hashicorp/null: not locked, version constraints >= 3.0.0
+  - 2 versions allowed by the version constraints, up to v3.1.0
+- hashicorp/random: locked v3.6.0, no version constraints
+  - Up to date
+
+Run "tofu init -upgrade" to select the newest versions allowed by the version constraints.
+Run "tofu providers why PROVIDER" to see which version constraints exclude newer versions of a provider.
+`,
+		},
+		"human with policy": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ProvidersOutdated) {
+				selection := getproviders.NewVersionSelection(getproviders.VersionList{
+					getproviders.MustParseVersion("4.0.0"),
+					getproviders.MustParseVersion("4.1.0"),
+					getproviders.MustParseVersion("4.2.0"),
+					getproviders.MustParseVersion("5.0.0"),
+				}, []getproviders.VersionConstraints{reqs[aws]})
+				selection.ApplyPolicy(func(version getproviders.Version) error {
+					if version.Same(getproviders.MustParseVersion("4.2.0")) {
+						return errors.New("denied")
+					}
+					return nil
+				})
+				v.Outdated(reqs, map[addrs.Provider]getproviders.VersionSelection{aws: selection}, locked)
+			},
+			wantStdout: `- hashicorp/aws: locked v4.1.0, version constraints ~> 4.0
+  - 1 newer version not allowed by the version constraints, up to v5.0.0
+  - 1 newer version rejected by the provider installation policy, up to v4.2.0
+
+Run "tofu providers why PROVIDER" to see which version constraints or provider installation policy rules exclude newer versions of a provider.
+`,
+		},
+		"human without providers": {
+			viewType: arguments.ViewHuman,
+			viewCall: func(v ProvidersOutdated) {
+				v.Outdated(nil, nil, nil)
+			},
+			wantStdout: "The configuration does not require any providers that can be upgraded.\n",
+		},
+		"json": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ProvidersOutdated) {
+				v.Outdated(reqs, selections, locked)
+			},
+			wantStdout: `{
+  "format_version": "1.0",
+  "providers": [
+    {
+      "address": "registry.opentofu.org/hashicorp/aws",
+      "version_constraints": "~> 4.0",
+      "locked": "4.1.0",
+      "selected": "4.2.0",
+      "latest": "5.0.0",
+      "newer_allowed": [
+        "4.2.0"
+      ],
+      "newer_disallowed": [
+        "5.0.0"
+      ],
+      "newer_rejected": []
+    },
+    {
+      "address": "registry.opentofu.org/hashicorp/null",
+      "version_constraints": ">= 3.0.0",
+      "selected": "3.1.0",
+      "latest": "3.1.0",
+      "newer_allowed": [
+        "3.0.0",
+        "3.1.0"
+      ],
+      "newer_disallowed": [],
+      "newer_rejected": []
+    },
+    {
+      "address": "registry.opentofu.org/hashicorp/random",
+      "locked": "3.6.0",
+      "selected": "3.6.0",
+      "latest": "3.6.0",
+      "newer_allowed": [],
+      "newer_disallowed": [],
+      "newer_rejected": []
+    }
+  ]
+}
+`,
+		},
+		"json diagnostics are human": {
+			viewType: arguments.ViewJSON,
+			viewCall: func(v ProvidersOutdated) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewProvidersOutdated(&arguments.View{ViewType: tc.viewType}, view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ProvidersPush interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+
+	// Pushed reports that packages for the given platforms were published
+	// to the given repository as the given version, with an index manifest
+	// of the given digest. signer describes the key that signed the
+	// manifest, if any.
+	Pushed(repository string, version getproviders.Version, platforms []getproviders.Platform, digest, signer string)
+}
+
+// NewProvidersPush returns an initialized ProvidersPush implementation.
+func NewProvidersPush(view *View) ProvidersPush {
+	return &ProvidersPushHuman{view: view}
+}
+
+type ProvidersPushHuman struct {
+	view *View
+}
+
+var _ ProvidersPush = (*ProvidersPushHuman)(nil)
+
+func (v *ProvidersPushHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ProvidersPushHuman) Pushed(repository string, version getproviders.Version, platforms []getproviders.Platform, digest, signer string) {
+	names := make([]string, len(platforms))
+	for i, platform := range platforms {
+		names[i] = platform.String()
+	}
+	_, _ = v.view.streams.Println(fmt.Sprintf("Pushed provider v%s (%s) to oci://%s", version, strings.Join(names, ", "), repository))
+	_, _ = v.view.streams.Println(fmt.Sprintf("Digest: %s", digest))
+	if signer != "" {
+		_, _ = v.view.streams.Println(fmt.Sprintf("Signed by %s", signer))
+	}
+}
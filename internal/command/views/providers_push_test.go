@@ -0,0 +1,61 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestProvidersPushView(t *testing.T) {
+	tests := map[string]struct {
+		viewCall   func(v ProvidersPush)
+		wantStdout string
+		wantStderr string
+	}{
+		"pushed": {
+			viewCall: func(v ProvidersPush) {
+				v.Pushed(
+					"example.com/providers/random",
+					getproviders.MustParseVersion("1.2.0"),
+					[]getproviders.Platform{{OS: "darwin", Arch: "arm64"}, {OS: "linux", Arch: "amd64"}},
+					"sha256:abc",
+					"key 0123456789ABCDEF",
+				)
+			},
+			wantStdout: `Pushed provider v1.2.0 (darwin_arm64, linux_amd64) to oci://example.com/providers/random
+Digest: sha256:abc
+Signed by key 0123456789ABCDEF
+`,
+		},
+		"diagnostics error": {
+			viewCall: func(v ProvidersPush) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewProvidersPush(view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
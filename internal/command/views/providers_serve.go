@@ -0,0 +1,43 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type ProvidersServe interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	Listening(socketPath string, envVar string)
+	Stopped()
+}
+
+// NewProvidersServe returns an initialized ProvidersServe implementation.
+func NewProvidersServe(view *View) ProvidersServe {
+	return &ProvidersServeHuman{view: view}
+}
+
+type ProvidersServeHuman struct {
+	view *View
+}
+
+var _ ProvidersServe = (*ProvidersServeHuman)(nil)
+
+func (v *ProvidersServeHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ProvidersServeHuman) Listening(socketPath string, envVar string) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("OpenTofu provider daemon listening on %s", socketPath))
+	_, _ = v.view.streams.Println("To use it, set the following environment variable in the shell where you run OpenTofu:")
+	_, _ = v.view.streams.Println(fmt.Sprintf("\n  export %s=%s\n", envVar, socketPath))
+}
+
+func (v *ProvidersServeHuman) Stopped() {
+	_, _ = v.view.streams.Println("OpenTofu provider daemon stopped")
+}
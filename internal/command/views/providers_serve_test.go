@@ -0,0 +1,61 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestProvidersServeView(t *testing.T) {
+	tests := map[string]struct {
+		viewCall   func(v ProvidersServe)
+		wantStdout string
+		wantStderr string
+	}{
+		"listening": {
+			viewCall: func(v ProvidersServe) {
+				v.Listening("/tmp/tofu-providers-123.sock", "TF_PROVIDER_DAEMON")
+			},
+			wantStdout: `OpenTofu provider daemon listening on /tmp/tofu-providers-123.sock
+To use it, set the following environment variable in the shell where you run OpenTofu:
+
+  export TF_PROVIDER_DAEMON=/tmp/tofu-providers-123.sock
+
+`,
+		},
+		"stopped": {
+			viewCall: func(v ProvidersServe) {
+				v.Stopped()
+			},
+			wantStdout: "OpenTofu provider daemon stopped\n",
+		},
+		"diagnostics error": {
+			viewCall: func(v ProvidersServe) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewProvidersServe(view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
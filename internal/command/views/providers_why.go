@@ -0,0 +1,150 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/xlab/treeprint"
+)
+
+type ProvidersWhy interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	// Explain describes how a version of the given provider is selected,
+	// given the requirements of the configuration, the versions that
+	// are available and the version in the dependency lock file, if any.
+	Explain(provider addrs.Provider, reqs *configs.ModuleRequirements, selection getproviders.VersionSelection, locked getproviders.Version)
+}
+
+// NewProvidersWhy returns an initialized ProvidersWhy implementation.
+func NewProvidersWhy(view *View) ProvidersWhy {
+	return &ProvidersWhyHuman{view: view}
+}
+
+type ProvidersWhyHuman struct {
+	view *View
+}
+
+var _ ProvidersWhy = (*ProvidersWhyHuman)(nil)
+
+func (v *ProvidersWhyHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *ProvidersWhyHuman) Explain(provider addrs.Provider, reqs *configs.ModuleRequirements, selection getproviders.VersionSelection, locked getproviders.Version) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("\nProvider %s", provider.ForDisplay()))
+
+	tree := treeprint.New()
+	populateConstraintsTreeNode(tree, reqs, provider, selection)
+	_, _ = v.view.streams.Println("\nVersion constraints:")
+	_, _ = v.view.streams.Println(tree.String())
+
+	if len(selection.Available) == 0 {
+		_, _ = v.view.streams.Println("No versions of this provider are available.")
+		return
+	}
+	_, _ = v.view.streams.Println(fmt.Sprintf(
+		"Available versions: %d, from v%s to v%s",
+		len(selection.Available), selection.Available[0], selection.Available[len(selection.Available)-1],
+	))
+
+	switch {
+	case selection.Selected == getproviders.UnspecifiedVersion && len(selection.Rejected) != 0:
+		_, _ = v.view.streams.Println("Selected version: none, because the provider installation policy rejects every available version that meets all of the version constraints:")
+		printRejectedVersions(v.view, selection.Rejected)
+	case selection.Selected == getproviders.UnspecifiedVersion:
+		_, _ = v.view.streams.Println("Selected version: none, because no available version meets all of the version constraints")
+	case len(selection.Rejected) != 0:
+		_, _ = v.view.streams.Println(fmt.Sprintf("Selected version: v%s, because the provider installation policy rejects newer versions:", selection.Selected))
+		printRejectedVersions(v.view, selection.Rejected)
+	case selection.Selected.LessThan(selection.Newest):
+		_, _ = v.view.streams.Println(fmt.Sprintf(
+			"Selected version: v%s, because the constraints marked [limiting] exclude newer versions such as v%s",
+			selection.Selected, selection.Newest,
+		))
+	case selection.Selected.Same(selection.Newest):
+		_, _ = v.view.streams.Println(fmt.Sprintf("Selected version: v%s, the newest available version", selection.Selected))
+	default:
+		_, _ = v.view.streams.Println(fmt.Sprintf("Selected version: v%s", selection.Selected))
+	}
+
+	_, _ = v.view.streams.Println("")
+	switch {
+	case selection.Selected == getproviders.UnspecifiedVersion && len(selection.Rejected) != 0:
+		_, _ = v.view.streams.Println(`Running "tofu init -upgrade" would fail. Change the version constraints or the provider installation policy to allow a version that both accept.`)
+	case selection.Selected == getproviders.UnspecifiedVersion:
+		_, _ = v.view.streams.Println(`Running "tofu init -upgrade" would fail. Relax the version constraints marked [limiting] to allow a version that all of the constraints accept.`)
+	case locked == getproviders.UnspecifiedVersion:
+		_, _ = v.view.streams.Println(fmt.Sprintf(`This provider is not in the dependency lock file. Running "tofu init" would select v%s.`, selection.Selected))
+	case locked.Same(selection.Selected):
+		_, _ = v.view.streams.Println(fmt.Sprintf(`The dependency lock file selects v%s. Running "tofu init -upgrade" would not change it.`, locked))
+	case locked.LessThan(selection.Selected):
+		_, _ = v.view.streams.Println(fmt.Sprintf(`The dependency lock file selects v%s. Running "tofu init -upgrade" would upgrade it to v%s.`, locked, selection.Selected))
+	default:
+		_, _ = v.view.streams.Println(fmt.Sprintf(`The dependency lock file selects v%s. Running "tofu init -upgrade" would change it to v%s.`, locked, selection.Selected))
+	}
+}
+
+// printRejectedVersions prints the reason why the provider installation policy
+// rejects each of the given versions, newest first, as the installer
+// considers them.
+func printRejectedVersions(view *View, rejected []getproviders.RejectedVersion) {
+	for i := len(rejected) - 1; i >= 0; i-- {
+		_, _ = view.streams.Println(fmt.Sprintf("  - %s", rejected[i].Reason))
+	}
+}
+
+// populateConstraintsTreeNode adds the version constraints that the given
+// module and its descendants declare for the given provider to the given
+// tree, omitting any modules that don't require the provider at all.
+func populateConstraintsTreeNode(tree treeprint.Tree, node *configs.ModuleRequirements, provider addrs.Provider, selection getproviders.VersionSelection) {
+	if vc, ok := node.Requirements[provider]; ok {
+		tree.AddNode(describeVersionConstraints(vc, selection))
+	}
+	for _, name := range slices.Sorted(maps.Keys(node.Children)) {
+		child := node.Children[name]
+		if !moduleRequiresProvider(child, provider) {
+			continue
+		}
+		branch := tree.AddBranch(fmt.Sprintf("module.%s", name))
+		populateConstraintsTreeNode(branch, child, provider, selection)
+	}
+}
+
+func describeVersionConstraints(vc getproviders.VersionConstraints, selection getproviders.VersionSelection) string {
+	if len(vc) == 0 {
+		return "(no version constraints)"
+	}
+	ret := getproviders.VersionConstraintsString(vc)
+	newest := selection.NewestAllowedBy(vc)
+	if newest == getproviders.UnspecifiedVersion {
+		ret += " (allows no available versions)"
+	} else {
+		ret += fmt.Sprintf(" (allows up to v%s)", newest)
+	}
+	if selection.IsLimitedBy(vc) {
+		ret += " [limiting]"
+	}
+	return ret
+}
+
+func moduleRequiresProvider(node *configs.ModuleRequirements, provider addrs.Provider) bool {
+	if _, ok := node.Requirements[provider]; ok {
+		return true
+	}
+	for _, child := range node.Children {
+		if moduleRequiresProvider(child, provider) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,210 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestProvidersWhyView(t *testing.T) {
+	aws := addrs.NewDefaultProvider("aws")
+	random := addrs.NewDefaultProvider("random")
+	available := getproviders.VersionList{
+		getproviders.MustParseVersion("4.0.0"),
+		getproviders.MustParseVersion("4.1.0"),
+		getproviders.MustParseVersion("5.0.0"),
+	}
+	reqs := func(root, network string) *configs.ModuleRequirements {
+		return &configs.ModuleRequirements{
+			Requirements: getproviders.Requirements{
+				aws: getproviders.MustParseVersionConstraints(root),
+			},
+			Children: map[string]*configs.ModuleRequirements{
+				"network": {
+					Requirements: getproviders.Requirements{
+						aws: getproviders.MustParseVersionConstraints(network),
+					},
+					Children: map[string]*configs.ModuleRequirements{
+						"subnets": {
+							Requirements: getproviders.Requirements{
+								aws: nil,
+							},
+						},
+					},
+				},
+				"unrelated": {
+					Requirements: getproviders.Requirements{
+						random: nil,
+					},
+				},
+			},
+		}
+	}
+	selection := func(constraints ...string) getproviders.VersionSelection {
+		var vcs []getproviders.VersionConstraints
+		for _, s := range constraints {
+			vcs = append(vcs, getproviders.MustParseVersionConstraints(s))
+		}
+		return getproviders.NewVersionSelection(available, append(vcs, nil))
+	}
+
+	tests := map[string]struct {
+		viewCall   func(v ProvidersWhy)
+		wantStdout string
+		wantStderr string
+	}{
+		"limited upgrade": {
+			viewCall: func(v ProvidersWhy) {
+				v.Explain(aws, reqs("~> 4.0", ">= 4.0.0"), selection("~> 4.0", ">= 4.0.0"), getproviders.MustParseVersion("4.0.0"))
+			},
+			wantStdout: `
+Provider hashicorp/aws
+
+Version constraints:
+.
+├── ~> 4.0 (allows up to v4.1.0) [limiting]
+└── module.network
+    ├── >= 4.0.0 (allows up to v5.0.0)
+    └── module.subnets
+        └── (no version constraints)
+
+Available versions: 3, from v4.0.0 to v5.0.0
+Selected version: v4.1.0, because the constraints marked [limiting] exclude newer versions such as v5.0.0
+
+The dependency lock file selects v4.0.0. Running "tofu init -upgrade" would upgrade it to v4.1.0.
+`,
+		},
+		"conflict": {
+			viewCall: func(v ProvidersWhy) {
+				v.Explain(aws, reqs("~> 4.0", ">= 5.0.0"), selection("~> 4.0", ">= 5.0.0"), getproviders.UnspecifiedVersion)
+			},
+			wantStdout: `
+Provider hashicorp/aws
+
+Version constraints:
+.
+├── ~> 4.0 (allows up to v4.1.0) [limiting]
+└── module.network
+    ├── >= 5.0.0 (allows up to v5.0.0)
+    └── module.subnets
+        └── (no version constraints)
+
+Available versions: 3, from v4.0.0 to v5.0.0
+Selected version: none, because no available version meets all of the version constraints
+
+Running "tofu init -upgrade" would fail. Relax the version constraints marked [limiting] to allow a version that all of the constraints accept.
+`,
+		},
+		"not locked": {
+			viewCall: func(v ProvidersWhy) {
+				v.Explain(aws, reqs(">= 4.0.0", ">= 4.1.0"), selection(">= 4.0.0", ">= 4.1.0"), getproviders.UnspecifiedVersion)
+			},
+			wantStdout: `
+Provider hashicorp/aws
+
+Version constraints:
+.
+├── >= 4.0.0 (allows up to v5.0.0)
+└── module.network
+    ├── >= 4.1.0 (allows up to v5.0.0)
+    └── module.subnets
+        └── (no version constraints)
+
+Available versions: 3, from v4.0.0 to v5.0.0
+Selected version: v5.0.0, the newest available version
+
+This provider is not in the dependency lock file. Running "tofu init" would select v5.0.0.
+`,
+		},
+		"rejected by policy": {
+			viewCall: func(v ProvidersWhy) {
+				sel := selection(">= 4.0.0", ">= 4.0.0")
+				sel.ApplyPolicy(func(version getproviders.Version) error {
+					if version.GreaterThan(getproviders.MustParseVersion("4.0.0")) {
+						return fmt.Errorf("v%s is denied by denied:1", version)
+					}
+					return nil
+				})
+				v.Explain(aws, reqs(">= 4.0.0", ">= 4.0.0"), sel, getproviders.MustParseVersion("4.0.0"))
+			},
+			wantStdout: `
+Provider hashicorp/aws
+
+Version constraints:
+.
+├── >= 4.0.0 (allows up to v5.0.0)
+└── module.network
+    ├── >= 4.0.0 (allows up to v5.0.0)
+    └── module.subnets
+        └── (no version constraints)
+
+Available versions: 3, from v4.0.0 to v5.0.0
+Selected version: v4.0.0, because the provider installation policy rejects newer versions:
+  - v5.0.0 is denied by denied:1
+  - v4.1.0 is denied by denied:1
+
+The dependency lock file selects v4.0.0. Running "tofu init -upgrade" would not change it.
+`,
+		},
+		"all rejected by policy": {
+			viewCall: func(v ProvidersWhy) {
+				sel := selection("~> 4.0", ">= 4.0.0")
+				sel.ApplyPolicy(func(version getproviders.Version) error {
+					return fmt.Errorf("v%s is denied by denied:1", version)
+				})
+				v.Explain(aws, reqs("~> 4.0", ">= 4.0.0"), sel, getproviders.UnspecifiedVersion)
+			},
+			wantStdout: `
+Provider hashicorp/aws
+
+Version constraints:
+.
+├── ~> 4.0 (allows up to v4.1.0) [limiting]
+└── module.network
+    ├── >= 4.0.0 (allows up to v5.0.0)
+    └── module.subnets
+        └── (no version constraints)
+
+Available versions: 3, from v4.0.0 to v5.0.0
+Selected version: none, because the provider installation policy rejects every available version that meets all of the version constraints:
+  - v4.1.0 is denied by denied:1
+  - v4.0.0 is denied by denied:1
+
+Running "tofu init -upgrade" would fail. Change the version constraints or the provider installation policy to allow a version that both accept.
+`,
+		},
+		"diagnostics error": {
+			viewCall: func(v ProvidersWhy) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewProvidersWhy(view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
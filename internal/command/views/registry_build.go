@@ -0,0 +1,49 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opentofu/opentofu/internal/registryserver"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type RegistryBuild interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	Built(result *registryserver.BuildResult, outputDir string)
+}
+
+// NewRegistryBuild returns an initialized RegistryBuild implementation.
+func NewRegistryBuild(view *View) RegistryBuild {
+	return &RegistryBuildHuman{view: view}
+}
+
+type RegistryBuildHuman struct {
+	view *View
+}
+
+var _ RegistryBuild = (*RegistryBuildHuman)(nil)
+
+func (v *RegistryBuildHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *RegistryBuildHuman) Built(result *registryserver.BuildResult, outputDir string) {
+	for _, provider := range result.Providers {
+		platforms := make([]string, len(provider.Platforms))
+		for i, platform := range provider.Platforms {
+			platforms[i] = platform.String()
+		}
+		_, _ = v.view.streams.Println(fmt.Sprintf("- Published provider %s/%s v%s (%s)", provider.Namespace, provider.Type, provider.Version, strings.Join(platforms, ", ")))
+	}
+	for _, module := range result.Modules {
+		_, _ = v.view.streams.Println(fmt.Sprintf("- Published module %s/%s/%s v%s", module.Namespace, module.Name, module.System, module.Version))
+	}
+	_, _ = v.view.streams.Println(fmt.Sprintf("\nRegistry written to %s.", outputDir))
+	_, _ = v.view.streams.Println(`Serve this directory at the root of an HTTPS server for the registry hostname, or run "tofu registry serve".`)
+}
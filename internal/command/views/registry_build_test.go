@@ -0,0 +1,76 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"testing"
+
+	"github.com/apparentlymart/go-versions/versions"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/registryserver"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestRegistryBuildView(t *testing.T) {
+	tests := map[string]struct {
+		viewCall   func(v RegistryBuild)
+		wantStdout string
+		wantStderr string
+	}{
+		"built": {
+			viewCall: func(v RegistryBuild) {
+				v.Built(&registryserver.BuildResult{
+					Providers: []registryserver.BuiltProvider{
+						{
+							Namespace: "example",
+							Type:      "random",
+							Version:   getproviders.MustParseVersion("1.0.0"),
+							Platforms: []getproviders.Platform{{OS: "darwin", Arch: "arm64"}, {OS: "linux", Arch: "amd64"}},
+						},
+					},
+					Modules: []registryserver.BuiltModule{
+						{
+							Namespace: "example",
+							Name:      "network",
+							System:    "aws",
+							Version:   versions.MustParseVersion("1.1.0"),
+						},
+					},
+				}, "public")
+			},
+			wantStdout: `- Published provider example/random v1.0.0 (darwin_arm64, linux_amd64)
+- Published module example/network/aws v1.1.0
+
+Registry written to public.
+Serve this directory at the root of an HTTPS server for the registry hostname, or run "tofu registry serve".
+`,
+		},
+		"diagnostics error": {
+			viewCall: func(v RegistryBuild) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewRegistryBuild(view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"fmt"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+type RegistryServe interface {
+	Diagnostics(diags tfdiags.Diagnostics)
+	Listening(url string)
+	Stopped()
+}
+
+// NewRegistryServe returns an initialized RegistryServe implementation.
+func NewRegistryServe(view *View) RegistryServe {
+	return &RegistryServeHuman{view: view}
+}
+
+type RegistryServeHuman struct {
+	view *View
+}
+
+var _ RegistryServe = (*RegistryServeHuman)(nil)
+
+func (v *RegistryServeHuman) Diagnostics(diags tfdiags.Diagnostics) {
+	v.view.Diagnostics(diags)
+}
+
+func (v *RegistryServeHuman) Listening(url string) {
+	_, _ = v.view.streams.Println(fmt.Sprintf("OpenTofu registry listening on %s", url))
+}
+
+func (v *RegistryServeHuman) Stopped() {
+	_, _ = v.view.streams.Println("OpenTofu registry stopped")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package views
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestRegistryServeView(t *testing.T) {
+	tests := map[string]struct {
+		viewCall   func(v RegistryServe)
+		wantStdout string
+		wantStderr string
+	}{
+		"listening": {
+			viewCall: func(v RegistryServe) {
+				v.Listening("https://[::]:8443/")
+			},
+			wantStdout: "OpenTofu registry listening on https://[::]:8443/\n",
+		},
+		"stopped": {
+			viewCall: func(v RegistryServe) {
+				v.Stopped()
+			},
+			wantStdout: "OpenTofu registry stopped\n",
+		},
+		"diagnostics error": {
+			viewCall: func(v RegistryServe) {
+				v.Diagnostics(tfdiags.Diagnostics{
+					tfdiags.Sourceless(tfdiags.Error, "An error occurred", "This is an error message"),
+				})
+			},
+			wantStderr: withNewline("\nError: An error occurred\n\nThis is an error message"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			view, done := testView(t)
+			tc.viewCall(NewRegistryServe(view))
+			output := done(t)
+			if diff := cmp.Diff(tc.wantStderr, output.Stderr()); diff != "" {
+				t.Errorf("invalid stderr (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStdout, output.Stdout()); diff != "" {
+				t.Errorf("invalid stdout (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -48,6 +48,9 @@ type Test interface {
 	// Run prints out the summary for a single test run block.
 	Run(run *moduletest.Run, file *moduletest.File)
 
+	// Fuzz prints out the summary for a single fuzz block.
+	Fuzz(fuzz *moduletest.Fuzz, file *moduletest.File)
+
 	// DestroySummary prints out the summary of the destroy step of each test
 	// file. If everything goes well, this should be empty.
 	DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State)
@@ -124,6 +127,12 @@ func (m TestMulti) Run(run *moduletest.Run, file *moduletest.File) {
 	}
 }
 
+func (m TestMulti) Fuzz(fuzz *moduletest.Fuzz, file *moduletest.File) {
+	for _, t := range m {
+		t.Fuzz(fuzz, file)
+	}
+}
+
 func (m TestMulti) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
 	for _, t := range m {
 		t.DestroySummary(diags, run, file, state)
@@ -173,6 +182,9 @@ func (t *TestHuman) Conclusion(suite *moduletest.Suite) {
 			count := counts[run.Status]
 			counts[run.Status] = count + 1
 		}
+		for _, fuzz := range file.Fuzzes {
+			counts[fuzz.Status]++
+		}
 	}
 
 	if suite.Status <= moduletest.Skip {
@@ -193,6 +205,9 @@ func (t *TestHuman) Conclusion(suite *moduletest.Suite) {
 	}
 
 	t.view.streams.Printf(" %d passed, %d failed", counts[moduletest.Pass], counts[moduletest.Fail]+counts[moduletest.Error])
+	if counts[moduletest.Timeout] > 0 {
+		t.view.streams.Printf(", %d timed out", counts[moduletest.Timeout])
+	}
 	if counts[moduletest.Skip] > 0 {
 		t.view.streams.Printf(", %d skipped.\n", counts[moduletest.Skip])
 	} else {
@@ -278,6 +293,16 @@ func (t *TestHuman) Run(run *moduletest.Run, file *moduletest.File) {
 	t.Diagnostics(run, file, run.Diagnostics)
 }
 
+func (t *TestHuman) Fuzz(fuzz *moduletest.Fuzz, file *moduletest.File) {
+	t.view.streams.Printf("  fuzz %q... %s", fuzz.Name, colorizeTestStatus(fuzz.Status, t.view.colorize))
+	if fuzz.Cases > 0 {
+		t.view.streams.Printf(" (%d cases)", fuzz.Cases)
+	}
+	t.view.streams.Println()
+
+	t.Diagnostics(nil, file, fuzz.Diagnostics)
+}
+
 func (t *TestHuman) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
 	identifier := file.Name
 	if run != nil {
@@ -411,8 +436,16 @@ func (t *TestJSON) Conclusion(suite *moduletest.Suite) {
 		Status: json.ToTestStatus(suite.Status),
 	}
 	for _, file := range suite.Files {
+		statuses := make([]moduletest.Status, 0, len(file.Runs)+len(file.Fuzzes))
 		for _, run := range file.Runs {
-			switch run.Status {
+			statuses = append(statuses, run.Status)
+		}
+		for _, fuzz := range file.Fuzzes {
+			statuses = append(statuses, fuzz.Status)
+		}
+
+		for _, status := range statuses {
+			switch status {
 			case moduletest.Skip:
 				summary.Skipped++
 			case moduletest.Pass:
@@ -421,6 +454,8 @@ func (t *TestJSON) Conclusion(suite *moduletest.Suite) {
 				summary.Errored++
 			case moduletest.Fail:
 				summary.Failed++
+			case moduletest.Timeout:
+				summary.TimedOut++
 			}
 		}
 	}
@@ -442,6 +477,9 @@ func (t *TestJSON) Conclusion(suite *moduletest.Suite) {
 		}
 
 		fmt.Fprintf(&message, " %d passed, %d failed", summary.Passed, summary.Failed+summary.Errored)
+		if summary.TimedOut > 0 {
+			fmt.Fprintf(&message, ", %d timed out", summary.TimedOut)
+		}
 		if summary.Skipped > 0 {
 			fmt.Fprintf(&message, ", %d skipped.", summary.Skipped)
 		} else {
@@ -515,6 +553,16 @@ func (t *TestJSON) Run(run *moduletest.Run, file *moduletest.File) {
 	t.Diagnostics(run, file, run.Diagnostics)
 }
 
+func (t *TestJSON) Fuzz(fuzz *moduletest.Fuzz, file *moduletest.File) {
+	t.view.log.Info(
+		fmt.Sprintf("  fuzz %q... %s", fuzz.Name, testStatus(fuzz.Status)),
+		"type", json.MessageTestFuzz,
+		json.MessageTestFuzz, json.TestFuzzStatus{Path: file.Name, Fuzz: fuzz.Name, Status: json.ToTestStatus(fuzz.Status), Cases: fuzz.Cases},
+		"@testfile", file.Name)
+
+	t.Diagnostics(nil, file, fuzz.Diagnostics)
+}
+
 func (t *TestJSON) DestroySummary(diags tfdiags.Diagnostics, run *moduletest.Run, file *moduletest.File, state *states.State) {
 	if state.HasManagedResourceInstanceObjects() {
 		cleanup := json.TestFileCleanup{}
@@ -610,6 +658,8 @@ func colorizeTestStatus(status moduletest.Status, color *colorstring.Colorize) s
 	switch status {
 	case moduletest.Error, moduletest.Fail:
 		return color.Color("[red]fail[reset]")
+	case moduletest.Timeout:
+		return color.Color("[red]timeout[reset]")
 	case moduletest.Pass:
 		return color.Color("[green]pass[reset]")
 	case moduletest.Skip:
@@ -625,6 +675,8 @@ func testStatus(status moduletest.Status) string {
 	switch status {
 	case moduletest.Error, moduletest.Fail:
 		return "fail"
+	case moduletest.Timeout:
+		return "timeout"
 	case moduletest.Pass:
 		return "pass"
 	case moduletest.Skip:
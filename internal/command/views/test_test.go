@@ -82,6 +82,33 @@ func TestTestHuman_Conclusion(t *testing.T) {
 			Expected: "\nExecuted 0 tests, 6 skipped.\n",
 		},
 
+		"timed out tests": {
+			Suite: &moduletest.Suite{
+				Status: moduletest.Timeout,
+				Files: map[string]*moduletest.File{
+					"descriptive_test_name.tftest.hcl": {
+						Name:   "descriptive_test_name.tftest.hcl",
+						Status: moduletest.Timeout,
+						Runs: []*moduletest.Run{
+							{
+								Name:   "test_one",
+								Status: moduletest.Pass,
+							},
+							{
+								Name:   "test_two",
+								Status: moduletest.Timeout,
+							},
+							{
+								Name:   "test_three",
+								Status: moduletest.Skip,
+							},
+						},
+					},
+				},
+			},
+			Expected: "\nFailure! 1 passed, 0 failed, 1 timed out, 1 skipped.\n",
+		},
+
 		"only passed tests": {
 			Suite: &moduletest.Suite{
 				Status: moduletest.Pass,
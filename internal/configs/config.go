@@ -981,6 +981,9 @@ func (c *Config) getProviderConfigTransformForTest(evalCtx *hcl.EvalContext) tes
 					IsMocked:          testProvider.IsMocked,
 					MockResources:     testProvider.MockResources,
 					OverrideResources: testProvider.OverrideResources,
+					MockGenerators:    testProvider.MockGenerators,
+					MockSchemaHints:   testProvider.MockSchemaHints,
+					MockSeed:          testProvider.MockSeed,
 				}
 
 			}
@@ -1009,6 +1012,9 @@ func (c *Config) getProviderConfigTransformForTest(evalCtx *hcl.EvalContext) tes
 					IsMocked:          true,
 					MockResources:     mp.MockResources,
 					OverrideResources: mp.OverrideResources,
+					MockGenerators:    mp.Generators,
+					MockSchemaHints:   mp.SchemaHints,
+					MockSeed:          file.MockSeed,
 				}
 			}
 		}
@@ -1099,10 +1105,11 @@ func (c *Config) transformOverriddenResourcesForTest(run *TestRun, file *TestFil
 		}
 
 		res.IsOverridden = true
+		res.OverrideSeed = file.MockSeed
 		if res.Overrides == nil {
-			res.Overrides = addrs.NewOverrideTrie[map[string]cty.Value]()
+			res.Overrides = addrs.NewOverrideTrie[*OverrideResource]()
 		}
-		res.Overrides.Set(overrideRes.TargetParsed, overrideRes.Values, overrideRes.Target.SourceRange().Ptr())
+		res.Overrides.Set(overrideRes.TargetParsed, overrideRes, overrideRes.Target.SourceRange().Ptr())
 	}
 
 	return func() {
@@ -1119,7 +1126,8 @@ func (c *Config) transformOverriddenResourcesForTest(run *TestRun, file *TestFil
 			}
 
 			res.IsOverridden = false
-			res.Overrides = addrs.NewOverrideTrie[map[string]cty.Value]()
+			res.OverrideSeed = 0
+			res.Overrides = addrs.NewOverrideTrie[*OverrideResource]()
 		}
 	}, diags
 }
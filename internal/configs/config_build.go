@@ -29,7 +29,7 @@ import (
 func BuildConfig(ctx context.Context, root *Module, call StaticModuleCall, walker ModuleWalker) (*Config, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
-	diags = diags.Extend(root.WithStaticCall(call))
+	diags = diags.Extend(root.WithStaticCall(ctx, call))
 
 	cfg := &Config{
 		Module: root,
@@ -205,7 +205,10 @@ func loadModule(ctx context.Context, root *Config, req *ModuleRequest, call Stat
 		return nil, diags
 	}
 
-	diags = diags.Extend(mod.WithStaticCall(call))
+	if req.Parent != nil {
+		mod.inheritedTypes = req.Parent.Module.exportedTypes()
+	}
+	diags = diags.Extend(mod.WithStaticCall(ctx, call))
 
 	cfg := &Config{
 		Parent:          req.Parent,
@@ -20,6 +20,7 @@ import (
 
 	version "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestBuildConfig(t *testing.T) {
@@ -163,6 +164,48 @@ func TestBuildConfigChildModuleBackend(t *testing.T) {
 	}
 }
 
+func TestBuildConfigExportedTypes(t *testing.T) {
+	parser := NewParser(nil)
+	mod, diags := parser.LoadConfigDir("testdata/config-build-types")
+	assertNoDiagnostics(t, diags)
+
+	cfg, diags := BuildConfig(t.Context(), mod, RootModuleCallForTesting(), ModuleWalkerFunc(
+		func(_ context.Context, req *ModuleRequest) (*Module, *version.Version, hcl.Diagnostics) {
+			sourcePath := filepath.Join(req.Parent.Module.SourceDir, req.SourceAddr.String())
+			mod, modDiags := parser.LoadConfigDir(sourcePath)
+			return mod, nil, modDiags
+		},
+	))
+
+	// The root module's "internal" type isn't exported, so the grandchild
+	// module can't use it.
+	assertExactDiagnostics(t, diags, []string{
+		`testdata/config-build-types/child/grandchild/grandchild.tf:4,1-16: Missing type definition; The type "internal" has no definition, so it must be exported by one of this module's ancestor modules, but none of them export a type with that name.`,
+	})
+
+	serverTy := cty.Object(map[string]cty.Type{
+		"name": cty.String,
+		"port": cty.Number,
+	})
+	child := cfg.Children["child"].Module
+	if got, want := child.Variables["servers"].Type, cty.List(serverTy); !got.Equals(want) {
+		t.Errorf("wrong type for child var.servers\ngot:  %#v\nwant: %#v", got, want)
+	}
+	wantDefault := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("a"),
+			"port": cty.NumberIntVal(80),
+		}),
+	})
+	if got := child.Variables["servers"].Default; !got.RawEquals(wantDefault) {
+		t.Errorf("wrong default for child var.servers\ngot:  %#v\nwant: %#v", got, wantDefault)
+	}
+	grandchild := cfg.Children["child"].Children["grandchild"].Module
+	if got := grandchild.Variables["server"].Type; !got.Equals(serverTy) {
+		t.Errorf("wrong type for grandchild var.server\ngot:  %#v\nwant: %#v", got, serverTy)
+	}
+}
+
 func TestBuildConfigInvalidModules(t *testing.T) {
 	testDir := "testdata/config-diagnostics"
 	dirs, err := os.ReadDir(testDir)
@@ -46,7 +46,7 @@ func (l *loader) loadConfig(ctx context.Context, rootMod *configs.Module, call c
 			Module: rootMod,
 		}
 		if rootMod != nil {
-			diags = diags.Extend(rootMod.WithStaticCall(call))
+			diags = diags.Extend(rootMod.WithStaticCall(ctx, call))
 		}
 
 		return cfg, diags
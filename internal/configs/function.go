@@ -0,0 +1,281 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang"
+)
+
+// Function represents a "function" block in a module or file, which declares
+// a pure function that expressions in the same module can call as
+// module::<name>.
+type Function struct {
+	Name        string
+	Description string
+
+	Params []*FunctionParam
+	// VarParam is the optional variadic parameter, which accepts any number
+	// of additional arguments after those for Params.
+	VarParam *FunctionParam
+
+	// Result is the expression that produces the result of the function. It
+	// can refer only to the function's parameters, by name, and call other
+	// functions.
+	Result hcl.Expression
+
+	DeclRange hcl.Range
+}
+
+// FunctionParam represents a "parameter" or "variadic_parameter" block
+// within a "function" block.
+type FunctionParam struct {
+	Name        string
+	Description string
+
+	Type         cty.Type
+	TypeDefaults *typeexpr.Defaults
+
+	DeclRange hcl.Range
+}
+
+func decodeFunctionBlock(block *hcl.Block) (*Function, hcl.Diagnostics) {
+	fn := &Function{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	content, diags := block.Body.Content(functionBlockSchema)
+
+	if !hclsyntax.ValidIdentifier(fn.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid function name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &fn.Description)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["result"]; exists {
+		fn.Result = attr.Expr
+	}
+
+	declared := make(map[string]*FunctionParam)
+	for _, block := range content.Blocks {
+		param, paramDiags := decodeFunctionParamBlock(block)
+		diags = append(diags, paramDiags...)
+		if param == nil {
+			continue
+		}
+
+		if existing, exists := declared[param.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate function parameter",
+				Detail:   fmt.Sprintf("A parameter named %q was already declared at %s. Parameter names must be unique within a function.", param.Name, existing.DeclRange),
+				Subject:  &param.DeclRange,
+			})
+			continue
+		}
+		declared[param.Name] = param
+
+		switch block.Type {
+		case "parameter":
+			if fn.VarParam != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Parameter after variadic parameter",
+					Detail:   fmt.Sprintf("The variadic parameter %q must be declared after all of the other parameters of the function.", fn.VarParam.Name),
+					Subject:  &param.DeclRange,
+				})
+				continue
+			}
+			fn.Params = append(fn.Params, param)
+		case "variadic_parameter":
+			if fn.VarParam != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate variadic parameter",
+					Detail:   fmt.Sprintf("The variadic parameter for this function was already declared at %s. A function can have only one variadic parameter.", fn.VarParam.DeclRange),
+					Subject:  &param.DeclRange,
+				})
+				continue
+			}
+			fn.VarParam = param
+		}
+	}
+
+	return fn, diags
+}
+
+func decodeFunctionParamBlock(block *hcl.Block) (*FunctionParam, hcl.Diagnostics) {
+	param := &FunctionParam{
+		Name:      block.Labels[0],
+		Type:      cty.DynamicPseudoType,
+		DeclRange: block.DefRange,
+	}
+
+	content, diags := block.Body.Content(functionParamBlockSchema)
+
+	if !hclsyntax.ValidIdentifier(param.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid function parameter name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+		return nil, diags
+	}
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &param.Description)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["type"]; exists {
+		ty, tyDefaults, _, tyDiags := decodeVariableType(attr.Expr)
+		diags = append(diags, tyDiags...)
+		param.Type = ty
+		param.TypeDefaults = tyDefaults
+	}
+
+	return param, diags
+}
+
+// UserFunction returns the definition of the function in the form that the
+// lang package uses to make it available in an evaluation scope.
+func (f *Function) UserFunction() *lang.UserFunction {
+	ret := &lang.UserFunction{
+		Description: f.Description,
+		Params:      make([]lang.UserFunctionParam, len(f.Params)),
+		Result:      f.Result,
+	}
+	for i, p := range f.Params {
+		ret.Params[i] = p.userFunctionParam()
+	}
+	if f.VarParam != nil {
+		p := f.VarParam.userFunctionParam()
+		ret.VarParam = &p
+	}
+	return ret
+}
+
+func (p *FunctionParam) userFunctionParam() lang.UserFunctionParam {
+	return lang.UserFunctionParam{
+		Name:        p.Name,
+		Description: p.Description,
+		Type:        p.Type,
+		Defaults:    p.TypeDefaults,
+	}
+}
+
+// UserFunctions returns the definitions of all of the functions declared in
+// the module, keyed by name, or nil if there are none.
+func (m *Module) UserFunctions() map[string]*lang.UserFunction {
+	if len(m.Functions) == 0 {
+		return nil
+	}
+	ret := make(map[string]*lang.UserFunction, len(m.Functions))
+	for name, fn := range m.Functions {
+		ret[name] = fn.UserFunction()
+	}
+	return ret
+}
+
+// validateFunctions checks that the functions declared in the module only
+// call other functions that are declared in the module, and that none of
+// them call themselves.
+func (m *Module) validateFunctions() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if len(m.Functions) == 0 {
+		return diags
+	}
+
+	names := make([]string, 0, len(m.Functions))
+	for name := range m.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	userFuncs := m.UserFunctions()
+	for _, name := range names {
+		fn := m.Functions[name]
+		for _, called := range lang.UserFunctionCalls(userFuncs[name]) {
+			if _, exists := m.Functions[called]; !exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Call to unknown function",
+					Detail:   fmt.Sprintf("There is no function named %q declared in a \"function\" block of this module.", called),
+					Subject:  fn.Result.Range().Ptr(),
+				})
+			}
+		}
+	}
+
+	if cycle := lang.UserFunctionCycle(userFuncs); cycle != nil {
+		fn := m.Functions[cycle[0]]
+		calls := make([]string, 0, len(cycle)+1)
+		for _, name := range append(cycle, cycle[0]) {
+			calls = append(calls, lang.UserFunctionName(name))
+		}
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Recursive function call",
+			Detail:   fmt.Sprintf("The function %q calls itself through the chain of calls %s. Functions cannot be recursive.", fn.Name, strings.Join(calls, " -> ")),
+			Subject:  &fn.DeclRange,
+		})
+	}
+
+	return diags
+}
+
+var functionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "description",
+		},
+		{
+			Name:     "result",
+			Required: true,
+		},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       "parameter",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type:       "variadic_parameter",
+			LabelNames: []string{"name"},
+		},
+	},
+}
+
+var functionParamBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "description",
+		},
+		{
+			Name: "type",
+		},
+	},
+}
@@ -0,0 +1,71 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestModuleFunctions(t *testing.T) {
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/functions")
+	assertNoDiagnostics(t, diags)
+
+	fn, ok := mod.Functions["resource_name"]
+	if !ok {
+		t.Fatal(`missing function "resource_name"`)
+	}
+	if got, want := fn.Description, "Builds a name for a resource from its parts."; got != want {
+		t.Errorf("wrong description\ngot:  %q\nwant: %q", got, want)
+	}
+	if got, want := len(fn.Params), 1; got != want {
+		t.Fatalf("wrong number of parameters %d; want %d", got, want)
+	}
+	if got, want := fn.Params[0].Name, "prefix"; got != want {
+		t.Errorf("wrong parameter name %q; want %q", got, want)
+	}
+	if fn.VarParam == nil || fn.VarParam.Name != "parts" || !fn.VarParam.Type.Equals(cty.String) {
+		t.Errorf("wrong variadic parameter %#v", fn.VarParam)
+	}
+
+	// The local value calls the functions, which exercises the parameter
+	// type defaults and the call from one function to another.
+	got, valDiags := mod.StaticEvaluator.Evaluate(t.Context(), mod.Locals["tags"].Expr, StaticIdentifier{
+		Module:    mod.StaticEvaluator.call.addr,
+		Subject:   "local.tags",
+		DeclRange: mod.Locals["tags"].DeclRange,
+	})
+	assertNoDiagnostics(t, valDiags)
+	want := cty.ObjectVal(map[string]cty.Value{
+		"env":   cty.StringVal("prod"),
+		"owner": cty.StringVal("platform"),
+		"name":  cty.StringVal("prod-platform"),
+	})
+	if !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestModuleFunctions_invalid(t *testing.T) {
+	tests := map[string]string{
+		"functions-recursive":    `The function "a" calls itself through the chain of calls module::a -> module::b -> module::a.`,
+		"functions-unknown-call": `There is no function named "missing" declared in a "function" block of this module.`,
+	}
+
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/"+name)
+			if !diags.HasErrors() {
+				t.Fatal("unexpected success")
+			}
+			if got := diags.Error(); !strings.Contains(got, want) {
+				t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
@@ -22,6 +22,11 @@ import (
 // config schema, attributes, blocks and cty types in general.
 type MockValueComposer struct {
 	rand *rand.Rand
+
+	// rules and schemaHints configure realistic value generation for
+	// computed string attributes, see WithGenerators.
+	rules       []MockGeneratorRule
+	schemaHints bool
 }
 
 func NewMockValueComposer(seed int64) MockValueComposer {
@@ -182,7 +187,7 @@ func (mvc MockValueComposer) composeMockValueForAttributes(attrs map[string]*con
 			} else if hasOverride {
 				mockAttrs[k] = ovConvert
 			} else if attr.Computed {
-				mockAttrs[k] = mvc.getMockValueByType(attr.ImpliedType())
+				mockAttrs[k] = mvc.getMockValueForAttribute(k, attr.ImpliedType())
 			} else {
 				// Null value
 				// NOTE: this does not handle configschema.NestedGroup correctly, but
@@ -201,7 +206,7 @@ func (mvc MockValueComposer) composeMockValueForAttributes(attrs map[string]*con
 			if hasOverride {
 				mockAttrs[k] = ovConvert
 			} else {
-				mockAttrs[k] = mvc.getMockValueByType(attr.ImpliedType())
+				mockAttrs[k] = mvc.getMockValueForAttribute(k, attr.ImpliedType())
 			}
 		} else {
 			panic("invalid schema: none of configschema.Attribute.Required/Computed/Optional set on " + k)
@@ -0,0 +1,189 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl2shim
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MockGeneratorKind identifies the shape of a value produced by a mock value
+// generator rule.
+type MockGeneratorKind string
+
+const (
+	// MockGenerateARN produces an AWS-style ARN.
+	MockGenerateARN MockGeneratorKind = "arn"
+	// MockGenerateID produces a resource-style identifier.
+	MockGenerateID MockGeneratorKind = "id"
+	// MockGenerateUUID produces a version 4 UUID.
+	MockGenerateUUID MockGeneratorKind = "uuid"
+	// MockGenerateIPv4 produces an address from the 10.0.0.0/8 private range.
+	MockGenerateIPv4 MockGeneratorKind = "ipv4"
+	// MockGenerateIPv6 produces an address from the fd00::/8 unique local range.
+	MockGenerateIPv6 MockGeneratorKind = "ipv6"
+	// MockGenerateCIDR produces an IPv4 /24 prefix from the 10.0.0.0/8 range.
+	MockGenerateCIDR MockGeneratorKind = "cidr"
+	// MockGenerateJSON produces a string containing a JSON object.
+	MockGenerateJSON MockGeneratorKind = "json"
+	// MockGenerateString produces a random alphanumeric string, the same as
+	// the values used when no rule applies.
+	MockGenerateString MockGeneratorKind = "string"
+)
+
+// MockGeneratorKinds lists all the supported generator kinds, in the order
+// they should be presented to users.
+var MockGeneratorKinds = []MockGeneratorKind{
+	MockGenerateARN,
+	MockGenerateID,
+	MockGenerateUUID,
+	MockGenerateIPv4,
+	MockGenerateIPv6,
+	MockGenerateCIDR,
+	MockGenerateJSON,
+	MockGenerateString,
+}
+
+// ParseMockGeneratorKind returns the generator kind with the given name, or
+// false if there is no such kind.
+func ParseMockGeneratorKind(s string) (MockGeneratorKind, bool) {
+	for _, kind := range MockGeneratorKinds {
+		if string(kind) == s {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+// MockGeneratorRule selects a generator kind for the attributes whose name
+// matches Pattern. Pattern uses the syntax of path.Match, so "*_arn" matches
+// any attribute name ending with "_arn".
+type MockGeneratorRule struct {
+	Pattern string
+	Kind    MockGeneratorKind
+}
+
+// Matches returns true if the rule applies to the attribute with the given
+// name.
+func (r MockGeneratorRule) Matches(name string) bool {
+	ok, err := path.Match(r.Pattern, name)
+	return err == nil && ok
+}
+
+// mockSchemaHintRules are the rules used when schema hints are enabled. They
+// are only consulted after any user-provided rules, and only for attributes
+// of string type. More specific patterns must come first since the first
+// matching rule wins.
+var mockSchemaHintRules = []MockGeneratorRule{
+	{Pattern: "arn", Kind: MockGenerateARN},
+	{Pattern: "*_arn", Kind: MockGenerateARN},
+	{Pattern: "*cidr*", Kind: MockGenerateCIDR},
+	{Pattern: "*ipv6*", Kind: MockGenerateIPv6},
+	{Pattern: "ip", Kind: MockGenerateIPv4},
+	{Pattern: "*_ip", Kind: MockGenerateIPv4},
+	{Pattern: "*ip_address*", Kind: MockGenerateIPv4},
+	{Pattern: "*json*", Kind: MockGenerateJSON},
+	{Pattern: "*policy", Kind: MockGenerateJSON},
+	{Pattern: "uuid", Kind: MockGenerateUUID},
+	{Pattern: "*_uuid", Kind: MockGenerateUUID},
+	{Pattern: "id", Kind: MockGenerateID},
+	{Pattern: "*_id", Kind: MockGenerateID},
+}
+
+// WithGenerators returns a copy of the composer that uses the given rules to
+// generate values for computed string attributes instead of random strings.
+// When schemaHints is set, a built-in set of rules keyed by common attribute
+// names (such as "arn", "*_ip" or "*cidr*") is used for attributes that no
+// user-provided rule matches.
+//
+// The returned composer shares its random source with the original one, so
+// the generated values remain deterministic for a given seed.
+func (mvc MockValueComposer) WithGenerators(rules []MockGeneratorRule, schemaHints bool) MockValueComposer {
+	mvc.rules = rules
+	mvc.schemaHints = schemaHints
+	return mvc
+}
+
+// getMockValueForAttribute generates a mock value for the named attribute,
+// using the first generator rule that matches its name. Rules only apply to
+// attributes of string type, anything else falls back to getMockValueByType.
+func (mvc MockValueComposer) getMockValueForAttribute(name string, t cty.Type) cty.Value {
+	if !t.Equals(cty.String) {
+		return mvc.getMockValueByType(t)
+	}
+
+	for _, rule := range mvc.rules {
+		if rule.Matches(name) {
+			return cty.StringVal(mvc.generate(rule.Kind))
+		}
+	}
+
+	if mvc.schemaHints {
+		for _, rule := range mockSchemaHintRules {
+			if rule.Matches(name) {
+				return cty.StringVal(mvc.generate(rule.Kind))
+			}
+		}
+	}
+
+	return mvc.getMockValueByType(t)
+}
+
+// generate produces a string of the given kind using the composer's random
+// source.
+func (mvc MockValueComposer) generate(kind MockGeneratorKind) string {
+	switch kind {
+	case MockGenerateARN:
+		return fmt.Sprintf("arn:aws:mock:us-east-1:%012d:resource/%s", mvc.rand.Int63n(1e12), mvc.getMockHex(17))
+	case MockGenerateID:
+		return "mock-" + mvc.getMockHex(17)
+	case MockGenerateUUID:
+		b := make([]byte, 16)
+		for i := range b {
+			b[i] = byte(mvc.rand.Intn(256))
+		}
+		b[6] = (b[6] & 0x0f) | 0x40 // version 4
+		b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	case MockGenerateIPv4:
+		return fmt.Sprintf("10.%d.%d.%d", mvc.rand.Intn(256), mvc.rand.Intn(256), mvc.rand.Intn(254)+1)
+	case MockGenerateIPv6:
+		groups := make([]string, 7)
+		for i := range groups {
+			groups[i] = fmt.Sprintf("%x", mvc.rand.Intn(0x10000))
+		}
+		return fmt.Sprintf("fd%02x:%s", mvc.rand.Intn(256), strings.Join(groups, ":"))
+	case MockGenerateCIDR:
+		return fmt.Sprintf("10.%d.%d.0/24", mvc.rand.Intn(256), mvc.rand.Intn(256))
+	case MockGenerateJSON:
+		// Marshalling a map sorts the keys, so the result is stable.
+		raw, _ := json.Marshal(map[string]string{
+			"id": "mock-" + mvc.getMockHex(17),
+		})
+		return string(raw)
+	default:
+		return mvc.getMockString()
+	}
+}
+
+// getMockHex returns a random string of lowercase hexadecimal digits with the
+// given length.
+func (mvc MockValueComposer) getMockHex(length int) string {
+	const chars = "0123456789abcdef"
+
+	b := strings.Builder{}
+	b.Grow(length)
+
+	for i := 0; i < length; i++ {
+		b.WriteByte(chars[mvc.rand.Intn(len(chars))])
+	}
+
+	return b.String()
+}
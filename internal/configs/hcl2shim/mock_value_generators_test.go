@@ -0,0 +1,98 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hcl2shim
+
+import (
+	"encoding/json"
+	"net/netip"
+	"regexp"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+func TestComposeMockValueBySchema_generators(t *testing.T) {
+	t.Parallel()
+
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"id":         {Type: cty.String, Computed: true},
+			"arn":        {Type: cty.String, Computed: true},
+			"role_arn":   {Type: cty.String, Computed: true},
+			"cidr_block": {Type: cty.String, Computed: true},
+			"private_ip": {Type: cty.String, Computed: true},
+			"ipv6_addr":  {Type: cty.String, Computed: true},
+			"policy":     {Type: cty.String, Computed: true},
+			"token":      {Type: cty.String, Computed: true},
+			"count_id":   {Type: cty.Number, Computed: true},
+			"name":       {Type: cty.String, Optional: true, Computed: true},
+		},
+	}
+
+	checks := map[string]func(string) bool{
+		"id":         regexp.MustCompile(`^mock-[0-9a-f]{17}$`).MatchString,
+		"arn":        regexp.MustCompile(`^arn:aws:mock:us-east-1:[0-9]{12}:resource/[0-9a-f]{17}$`).MatchString,
+		"role_arn":   regexp.MustCompile(`^arn:aws:`).MatchString,
+		"cidr_block": func(s string) bool { p, err := netip.ParsePrefix(s); return err == nil && p.Addr().Is4() },
+		"private_ip": func(s string) bool { a, err := netip.ParseAddr(s); return err == nil && a.Is4() && a.IsPrivate() },
+		"ipv6_addr":  func(s string) bool { a, err := netip.ParseAddr(s); return err == nil && a.Is6() && a.IsPrivate() },
+		"policy":     func(s string) bool { return json.Valid([]byte(s)) },
+		// The user-provided rule takes precedence over the schema hints.
+		"token": regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString,
+		"name":  regexp.MustCompile(`^[0-9a-f]{8}-`).MatchString,
+	}
+
+	rules := []MockGeneratorRule{
+		{Pattern: "token", Kind: MockGenerateUUID},
+		{Pattern: "na*", Kind: MockGenerateUUID},
+	}
+
+	compose := func() cty.Value {
+		val, diags := NewMockValueComposer(42).WithGenerators(rules, true).ComposeBySchema(schema, cty.NullVal(schema.ImpliedType()), nil)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected diags: %s", diags.ErrWithWarnings())
+		}
+		return val
+	}
+
+	got := compose()
+	for name, check := range checks {
+		v := got.GetAttr(name).AsString()
+		if !check(v) {
+			t.Errorf("unexpected value for %q: %s", name, v)
+		}
+	}
+
+	if !got.GetAttr("count_id").RawEquals(cty.Zero) {
+		t.Errorf("rules must not apply to non-string attributes, got %#v", got.GetAttr("count_id"))
+	}
+
+	if again := compose(); !again.RawEquals(got) {
+		t.Errorf("generated values are not deterministic for the same seed")
+	}
+}
+
+func TestComposeMockValueBySchema_noSchemaHints(t *testing.T) {
+	t.Parallel()
+
+	schema := &configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"arn": {Type: cty.String, Computed: true},
+		},
+	}
+
+	got, diags := NewMockValueComposer(42).WithGenerators(nil, false).ComposeBySchema(schema, cty.NullVal(schema.ImpliedType()), nil)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected diags: %s", diags.ErrWithWarnings())
+	}
+
+	want, _ := NewMockValueComposer(42).ComposeBySchema(schema, cty.NullVal(schema.ImpliedType()), nil)
+	if !got.RawEquals(want) {
+		t.Errorf("expected the default value when no rules are configured\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
@@ -8,12 +8,17 @@ package configs
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/encryption/config"
 	"github.com/opentofu/opentofu/internal/experiments"
+	"github.com/opentofu/opentofu/internal/lang"
 )
 
 // Module is a container for a set of configuration constructs that are
@@ -38,6 +43,7 @@ type Module struct {
 	ProviderLocalNames   map[addrs.Provider]string
 	ProviderMetas        map[addrs.Provider]*ProviderMeta
 	Encryption           *config.EncryptionConfig
+	Profiles             map[string]*Profile
 
 	Variables map[string]*Variable
 	Locals    map[string]*Local
@@ -55,6 +61,15 @@ type Module struct {
 
 	Checks map[string]*Check
 
+	Functions map[string]*Function
+
+	Types map[string]*TypeDefinition
+
+	// inheritedTypes are the named types exported by the module's parent,
+	// which provide the definitions of any of the module's own Types that
+	// are declared without one.
+	inheritedTypes map[string]*TypeDefinition
+
 	Tests map[string]*TestFile
 
 	// IsOverridden indicates if the module is being overridden. It's used in
@@ -95,6 +110,7 @@ type File struct {
 	ProviderMetas     []*ProviderMeta
 	RequiredProviders []*RequiredProviders
 	Encryptions       []*config.EncryptionConfig
+	Profiles          []*Profile
 
 	Variables []*Variable
 	Locals    []*Local
@@ -111,6 +127,10 @@ type File struct {
 	Removed []*Removed
 
 	Checks []*Check
+
+	Functions []*Function
+
+	Types []*TypeDefinition
 }
 
 // SelectiveLoader allows the consumer to only load and validate the portions of files needed for the given operations/contexts
@@ -133,6 +153,8 @@ func (s SelectiveLoader) filter(input []*File) []*File {
 		outFile := &File{
 			Variables: inFile.Variables,
 			Locals:    inFile.Locals,
+			Functions: inFile.Functions,
+			Types:     inFile.Types,
 		}
 
 		switch s {
@@ -178,7 +200,10 @@ func NewModule(primaryFiles, overrideFiles []*File, sourceDir string, load Selec
 		DataResources:      map[string]*Resource{},
 		EphemeralResources: map[string]*Resource{},
 		Checks:             map[string]*Check{},
+		Functions:          map[string]*Function{},
+		Types:              map[string]*TypeDefinition{},
 		ProviderMetas:      map[addrs.Provider]*ProviderMeta{},
+		Profiles:           map[string]*Profile{},
 		Tests:              map[string]*TestFile{},
 		SourceDir:          sourceDir,
 	}
@@ -232,16 +257,316 @@ func NewModule(primaryFiles, overrideFiles []*File, sourceDir string, load Selec
 		diags = append(diags, fileDiags...)
 	}
 
+	diags = append(diags, mod.validateNestedLocals()...)
+	diags = append(diags, mod.validateFunctions()...)
+	diags = append(diags, mod.validateTypeDefinitions()...)
+
+	// Named types exported by ancestor modules aren't known yet, so anything
+	// that depends on them is resolved later in WithStaticCall.
+	diags = append(diags, mod.resolveNamedTypes(false)...)
+
 	return mod, diags
 }
 
-func (m *Module) WithStaticCall(call StaticModuleCall) hcl.Diagnostics {
+// validateNestedLocals checks that the local values declared within resource
+// and module blocks don't have the same names as the local values declared
+// at the module level, which they would otherwise shadow, and that they are
+// not used by the count and for_each arguments that decide how many
+// instances of the block there are.
+func (m *Module) validateNestedLocals() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	check := func(locals map[string]*Local, count, forEach hcl.Expression) {
+		for _, expr := range []hcl.Expression{count, forEach} {
+			if expr == nil {
+				continue
+			}
+			for _, traversal := range expr.Variables() {
+				name, ok := localValueNameInTraversal(traversal)
+				if !ok {
+					continue
+				}
+				if _, exists := locals[name]; exists {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid reference to block local value",
+						Detail:   fmt.Sprintf("The local value %q is declared within this block, so it cannot be used to decide how many instances of the block to create.", name),
+						Subject:  traversal.SourceRange().Ptr(),
+					})
+				}
+			}
+		}
+
+		for _, l := range locals {
+			if existing, exists := m.Locals[l.Name]; exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate local value definition",
+					Detail:   fmt.Sprintf("A local value named %q was already defined at %s. Local values declared within a block must not have the same name as a local value of the module.", existing.Name, existing.DeclRange),
+					Subject:  &l.DeclRange,
+				})
+			}
+		}
+	}
+
+	for _, r := range m.ManagedResources {
+		check(r.Locals, r.Count, r.ForEach)
+	}
+	for _, r := range m.DataResources {
+		check(r.Locals, r.Count, r.ForEach)
+	}
+	for _, r := range m.EphemeralResources {
+		check(r.Locals, r.Count, r.ForEach)
+	}
+	for _, mc := range m.ModuleCalls {
+		check(mc.Locals, mc.Count, mc.ForEach)
+	}
+
+	return diags
+}
+
+// validateTypeDefinitions checks that none of the named types declared in the
+// module are defined in terms of themselves, which would make them infinitely
+// large, and that the module declares all of the named types it refers to.
+// Each type that is part of a cycle is treated as "any" so that it doesn't
+// cause further errors.
+func (m *Module) validateTypeDefinitions() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	names := make([]string, 0, len(m.Types))
+	for name := range m.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(m.Types))
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		td, exists := m.Types[name]
+		if !exists || td.Definition == nil {
+			return
+		}
+		switch state[name] {
+		case visiting:
+			i := slices.Index(stack, name)
+			chain := append(slices.Clone(stack[i:]), name)
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Cyclic type definition",
+				Detail:   fmt.Sprintf("The type %q refers to itself through the chain of types %s. A type cannot contain itself.", name, strings.Join(chain, " -> ")),
+				Subject:  &td.DeclRange,
+			})
+			for _, n := range stack[i:] {
+				m.Types[n].setConstraint(lang.TypeConversionConstraint{}, true)
+			}
+			return
+		case visited:
+			return
+		}
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, ref := range lang.TypeConstraintNames(td.Definition) {
+			visit(ref)
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+	}
+	for _, name := range names {
+		visit(name)
+	}
+
+	// Every named type must be declared in the module, even if its
+	// definition comes from an ancestor module. Any other keyword is most
+	// likely a mistyped built-in type, so in a module that doesn't declare
+	// any types we report it in the same way as the type constraint parser.
+	undeclared := func(expr hcl.Expression) {
+		for _, name := range lang.TypeConstraintNames(expr) {
+			if _, exists := m.Types[name]; exists {
+				continue
+			}
+			detail := fmt.Sprintf("The keyword %q is not a valid type specification.", name)
+			if len(m.Types) != 0 {
+				detail = fmt.Sprintf("The keyword %q is not a valid type specification, and there is no type of that name declared in a \"type\" block of this module.", name)
+			}
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid type specification",
+				Detail:   detail,
+				Subject:  expr.Range().Ptr(),
+			})
+		}
+	}
+	for _, name := range names {
+		if expr := m.Types[name].Definition; expr != nil {
+			undeclared(expr)
+		}
+	}
+	names = names[:0]
+	for name := range m.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if expr := m.Variables[name].namedTypeExpr; expr != nil {
+			undeclared(expr)
+		}
+	}
+	names = names[:0]
+	for name := range m.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if expr := m.Outputs[name].namedTypeExpr; expr != nil {
+			undeclared(expr)
+		}
+	}
+
+	return diags
+}
+
+// resolveNamedTypes decodes the type constraints of the module's named types
+// and of the variables and output values that refer to them.
+//
+// Named types that are declared without a definition use the definition of
+// the type of the same name exported by one of the module's ancestors, which
+// isn't known until the module is called, so anything that depends on them
+// is left unresolved unless final is true.
+func (m *Module) resolveNamedTypes(final bool) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	var resolve func(td *TypeDefinition) bool
+	namedTypes := func(names []string) (map[string]lang.TypeConversionConstraint, bool) {
+		ret := make(map[string]lang.TypeConversionConstraint, len(names))
+		for _, name := range names {
+			td, exists := m.Types[name]
+			if !exists {
+				// Reported by validateTypeDefinitions. We treat the type as
+				// "any" so that we don't report any further errors for it.
+				ret[name] = lang.NewTypeConversionConstraint(cty.DynamicPseudoType, nil)
+				continue
+			}
+			if !resolve(td) {
+				return nil, false
+			}
+			ret[name] = td.constraint()
+		}
+		return ret, true
+	}
+	resolve = func(td *TypeDefinition) bool {
+		if td.ConstraintType != cty.NilType {
+			return true
+		}
+		if td.Definition == nil {
+			if !final {
+				return false
+			}
+			inherited, exists := m.inheritedTypes[td.Name]
+			if !exists || inherited.ConstraintType == cty.NilType {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing type definition",
+					Detail:   fmt.Sprintf("The type %q has no definition, so it must be exported by one of this module's ancestor modules, but none of them export a type with that name.", td.Name),
+					Subject:  &td.DeclRange,
+				})
+				td.setConstraint(lang.TypeConversionConstraint{}, true)
+				return true
+			}
+			td.setConstraint(inherited.constraint(), false)
+			return true
+		}
+		types, ok := namedTypes(lang.TypeConstraintNames(td.Definition))
+		if !ok {
+			return false
+		}
+		convertTarget, tyDiags := lang.ParseTypeConversionConstraintWithTypes(td.Definition, types)
+		diags = append(diags, tyDiags.ToHCL()...)
+		td.setConstraint(convertTarget, tyDiags.HasErrors())
+		return true
+	}
+
+	names := make([]string, 0, len(m.Types))
+	for name := range m.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		resolve(m.Types[name])
+	}
+
+	names = names[:0]
+	for name := range m.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := m.Variables[name]
+		if v.namedTypeExpr == nil {
+			continue
+		}
+		if types, ok := namedTypes(lang.TypeConstraintNames(v.namedTypeExpr)); ok {
+			diags = append(diags, v.resolveNamedType(types)...)
+		}
+	}
+
+	names = names[:0]
+	for name := range m.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o := m.Outputs[name]
+		if o.namedTypeExpr == nil {
+			continue
+		}
+		if types, ok := namedTypes(lang.TypeConstraintNames(o.namedTypeExpr)); ok {
+			diags = append(diags, o.resolveNamedType(types)...)
+		}
+	}
+
+	return diags
+}
+
+// exportedTypes returns the named types that the module's descendants can
+// use by declaring a type of the same name without a definition.
+func (m *Module) exportedTypes() map[string]*TypeDefinition {
+	ret := make(map[string]*TypeDefinition)
+	for name, td := range m.Types {
+		if td.Export {
+			ret[name] = td
+		}
+	}
+	return ret
+}
+
+// localValueNameInTraversal returns the name of the local value that the
+// given traversal refers to, if any.
+func localValueNameInTraversal(traversal hcl.Traversal) (string, bool) {
+	if len(traversal) < 2 || traversal.RootName() != "local" {
+		return "", false
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
+
+func (m *Module) WithStaticCall(ctx context.Context, call StaticModuleCall) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 
 	if m.StaticEvaluator != nil {
 		panic("applying Static Evaluation to a module twice, this is a critical bug in OpenTofu")
 	}
 
+	diags = append(diags, m.resolveNamedTypes(true)...)
+
 	// Static evaluation to build a StaticContext now that module has all relevant Locals / Variables
 	m.StaticEvaluator = NewStaticEvaluator(m, call)
 
@@ -256,15 +581,20 @@ func (m *Module) WithStaticCall(call StaticModuleCall) hcl.Diagnostics {
 
 	// Process all module calls now that we have the static context
 	for _, mc := range m.ModuleCalls {
-		mDiags := mc.decodeStaticFields(context.TODO(), m.StaticEvaluator)
+		mDiags := mc.decodeStaticFields(ctx, m.StaticEvaluator)
 		diags = append(diags, mDiags...)
 	}
 
 	for _, pc := range m.ProviderConfigs {
-		pDiags := pc.decodeStaticFields(context.TODO(), m.StaticEvaluator)
+		pDiags := pc.decodeStaticFields(ctx, m.StaticEvaluator)
 		diags = append(diags, pDiags...)
 	}
 
+	// moved and removed blocks are processed before planning, so any
+	// for_each they use must be expanded statically too.
+	diags = append(diags, m.expandMovedForEach(ctx)...)
+	diags = append(diags, m.expandRemovedForEach(ctx)...)
+
 	// Generate the FQN -> LocalProviderName map
 	m.gatherProviderLocalNames()
 
@@ -277,7 +607,7 @@ func (m *Module) WithStaticCall(call StaticModuleCall) hcl.Diagnostics {
 			})
 		}
 	}
-	_, vDiags := m.StaticEvaluator.EvalContext(context.TODO(), StaticIdentifier{
+	_, vDiags := m.StaticEvaluator.EvalContext(ctx, StaticIdentifier{
 		Module:    m.StaticEvaluator.call.addr,
 		DeclRange: m.StaticEvaluator.call.declRange,
 	}, constRefs)
@@ -390,6 +720,19 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 		m.Encryption = e
 	}
 
+	for _, p := range file.Profiles {
+		if existing, exists := m.Profiles[p.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate profile",
+				Detail:   fmt.Sprintf("A profile named %q was already declared at %s. Profile names must be unique within a module.", existing.Name, existing.DeclRange),
+				Subject:  &p.DeclRange,
+			})
+			continue
+		}
+		m.Profiles[p.Name] = p
+	}
+
 	for _, v := range file.Variables {
 		if existing, exists := m.Variables[v.Name]; exists {
 			diags = append(diags, &hcl.Diagnostic{
@@ -540,6 +883,32 @@ func (m *Module) appendFile(file *File) hcl.Diagnostics {
 		m.Checks[c.Name] = c
 	}
 
+	for _, fn := range file.Functions {
+		if existing, exists := m.Functions[fn.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Duplicate function %q configuration", existing.Name),
+				Detail:   fmt.Sprintf("A function named %q was already declared at %s. Function names must be unique within a module.", existing.Name, existing.DeclRange),
+				Subject:  &fn.DeclRange,
+			})
+			continue
+		}
+		m.Functions[fn.Name] = fn
+	}
+
+	for _, td := range file.Types {
+		if existing, exists := m.Types[td.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("Duplicate type %q configuration", existing.Name),
+				Detail:   fmt.Sprintf("A type named %q was already declared at %s. Type names must be unique within a module.", existing.Name, existing.DeclRange),
+				Subject:  &td.DeclRange,
+			})
+			continue
+		}
+		m.Types[td.Name] = td
+	}
+
 	// Handle the provider associations for all data resources together.
 	for _, r := range m.DataResources {
 		// set the provider FQN for the resource
@@ -683,6 +1052,22 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		}
 	}
 
+	for _, p := range file.Profiles {
+		// A profile in an override file replaces the base profile of the
+		// same name entirely, because its targets and excludes are
+		// mutually-exclusive.
+		if _, exists := m.Profiles[p.Name]; !exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing base profile to override",
+				Detail:   fmt.Sprintf("There is no profile named %q. An override file can only override a profile that was already declared in a primary configuration file.", p.Name),
+				Subject:  &p.DeclRange,
+			})
+			continue
+		}
+		m.Profiles[p.Name] = p
+	}
+
 	for _, v := range file.Variables {
 		existing, exists := m.Variables[v.Name]
 		if !exists {
@@ -818,6 +1203,24 @@ func (m *Module) mergeFile(file *File) hcl.Diagnostics {
 		})
 	}
 
+	for _, fn := range file.Functions {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Cannot override 'function' blocks",
+			Detail:   "Function blocks can appear only in normal files, not in override files.",
+			Subject:  fn.DeclRange.Ptr(),
+		})
+	}
+
+	for _, td := range file.Types {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Cannot override 'type' blocks",
+			Detail:   "Type blocks can appear only in normal files, not in override files.",
+			Subject:  td.DeclRange.Ptr(),
+		})
+	}
+
 	return diags
 }
 
@@ -44,6 +44,11 @@ type ModuleCall struct {
 
 	DependsOn []hcl.Traversal
 
+	// Locals are the local values declared in locals blocks nested in the
+	// module block. They are only visible within the module block, where
+	// they can refer to count.index, each.key and each.value.
+	Locals map[string]*Local
+
 	DeclRange hcl.Range
 }
 
@@ -112,6 +117,11 @@ func decodeModuleBlock(block *hcl.Block, override bool) (*ModuleCall, hcl.Diagno
 	var seenEscapeBlock *hcl.Block
 	for _, block := range content.Blocks {
 		switch block.Type {
+		case "locals":
+			var localsDiags hcl.Diagnostics
+			mc.Locals, localsDiags = decodeNestedLocalsBlock(block, mc.Locals)
+			diags = append(diags, localsDiags...)
+
 		case "lifecycle":
 			if seenLifecycle != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -390,9 +400,10 @@ var moduleBlockSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "_"}, // meta-argument escaping block
 
-		// These are all reserved for future use.
 		{Type: "lifecycle"},
 		{Type: "locals"},
+
+		// These are all reserved for future use.
 		{Type: "provider", LabelNames: []string{"type"}},
 	},
 }
@@ -427,7 +427,7 @@ variable "path" {
 			}
 			mod, diags := NewModule(tFiles, nil, "testdata", SelectiveLoadAll)
 			if mod != nil {
-				diags = diags.Extend(mod.WithStaticCall(call))
+				diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 			}
 			if tc.err == "" {
 				if diags.HasErrors() {
@@ -64,6 +64,10 @@ func (v *Variable) merge(ov *Variable) hcl.Diagnostics {
 	if ov.Type != cty.NilType {
 		v.Type = ov.Type
 		v.ConstraintType = ov.ConstraintType
+		v.namedTypeExpr = ov.namedTypeExpr
+	}
+	if ov.Default != cty.NilVal {
+		v.defaultExpr = ov.defaultExpr
 	}
 	if ov.ParsingMode != 0 {
 		v.ParsingMode = ov.ParsingMode
@@ -168,6 +172,12 @@ func (o *Output) merge(oo *Output) hcl.Diagnostics {
 		o.EphemeralSet = oo.EphemeralSet
 		o.Ephemeral = oo.Ephemeral
 	}
+	if oo.Type != cty.NilType {
+		o.Type = oo.Type
+		o.ConstraintType = oo.ConstraintType
+		o.TypeDefaults = oo.TypeDefaults
+		o.namedTypeExpr = oo.namedTypeExpr
+	}
 
 	// We don't allow depends_on to be overridden because that is likely to
 	// cause confusing misbehavior.
@@ -205,6 +215,13 @@ func (mc *ModuleCall) merge(omc *ModuleCall) hcl.Diagnostics {
 
 	mc.Config = MergeBodies(mc.Config, omc.Config)
 
+	if len(omc.Locals) != 0 && mc.Locals == nil {
+		mc.Locals = make(map[string]*Local, len(omc.Locals))
+	}
+	for name, l := range omc.Locals {
+		mc.Locals[name] = l
+	}
+
 	if len(omc.Providers) != 0 {
 		mc.Providers = omc.Providers
 	}
@@ -281,6 +298,13 @@ func (r *Resource) merge(or *Resource, rps map[string]*RequiredProvider) hcl.Dia
 
 	r.Config = MergeBodies(r.Config, or.Config)
 
+	if len(or.Locals) != 0 && r.Locals == nil {
+		r.Locals = make(map[string]*Local, len(or.Locals))
+	}
+	for name, l := range or.Locals {
+		r.Locals[name] = l
+	}
+
 	// We don't allow depends_on to be overridden because that is likely to
 	// cause confusing misbehavior.
 	if len(or.DependsOn) != 0 {
@@ -18,7 +18,7 @@ import (
 )
 
 func TestModuleOverrideVariable(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-variable")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-variable")
 	assertNoDiagnostics(t, diags)
 	if mod == nil {
 		t.Fatalf("module is nil")
@@ -87,7 +87,7 @@ func TestModuleOverrideVariable(t *testing.T) {
 }
 
 func TestModuleOverrideOutput(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-output")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-output")
 	assertNoDiagnostics(t, diags)
 	if mod == nil {
 		t.Fatalf("module is nil")
@@ -99,6 +99,8 @@ func TestModuleOverrideOutput(t *testing.T) {
 			Name:           "fully_overridden",
 			Description:    "b_override description",
 			DescriptionSet: true,
+			Type:           cty.DynamicPseudoType,
+			ConstraintType: cty.DynamicPseudoType,
 			Expr: &hclsyntax.TemplateExpr{
 				Parts: []hclsyntax.Expression{
 					&hclsyntax.LiteralValueExpr{
@@ -153,6 +155,8 @@ func TestModuleOverrideOutput(t *testing.T) {
 			Name:           "partially_overridden",
 			Description:    "base description",
 			DescriptionSet: true,
+			Type:           cty.DynamicPseudoType,
+			ConstraintType: cty.DynamicPseudoType,
 			Expr: &hclsyntax.TemplateExpr{
 				Parts: []hclsyntax.Expression{
 					&hclsyntax.LiteralValueExpr{
@@ -206,7 +210,7 @@ func TestModuleOverrideOutput(t *testing.T) {
 }
 
 func TestModuleOverrideModule(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-module")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-module")
 	assertNoDiagnostics(t, diags)
 	if mod == nil {
 		t.Fatalf("module is nil")
@@ -324,7 +328,7 @@ func TestModuleOverrideDynamic(t *testing.T) {
 	}
 
 	t.Run("base is dynamic", func(t *testing.T) {
-		mod, diags := testModuleFromDir("testdata/valid-modules/override-dynamic-block-base")
+		mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-dynamic-block-base")
 		assertNoDiagnostics(t, diags)
 		if mod == nil {
 			t.Fatalf("module is nil")
@@ -349,7 +353,7 @@ func TestModuleOverrideDynamic(t *testing.T) {
 		}
 	})
 	t.Run("override is dynamic", func(t *testing.T) {
-		mod, diags := testModuleFromDir("testdata/valid-modules/override-dynamic-block-override")
+		mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-dynamic-block-override")
 		assertNoDiagnostics(t, diags)
 		if mod == nil {
 			t.Fatalf("module is nil")
@@ -410,7 +414,7 @@ func TestModuleOverrideSensitiveVariable(t *testing.T) {
 		},
 	}
 
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-variable-sensitive")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-variable-sensitive")
 
 	assertNoDiagnostics(t, diags)
 
@@ -465,7 +469,7 @@ func TestModuleOverrideEphemeralVariable(t *testing.T) {
 		},
 	}
 
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-variable-ephemeral")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-variable-ephemeral")
 
 	assertNoDiagnostics(t, diags)
 
@@ -489,7 +493,7 @@ func TestModuleOverrideEphemeralVariable(t *testing.T) {
 }
 
 func TestModuleOverrideResourceFQNs(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-resource-provider")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-resource-provider")
 	assertNoDiagnostics(t, diags)
 
 	got := mod.ManagedResources["test_instance.explicit"]
@@ -520,7 +524,7 @@ func TestModuleOverrideResourceFQNs(t *testing.T) {
 }
 
 func TestModuleOverrideIgnoreAllChanges(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-ignore-changes")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-ignore-changes")
 	assertNoDiagnostics(t, diags)
 
 	r := mod.ManagedResources["test_instance.foo"]
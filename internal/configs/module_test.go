@@ -6,10 +6,13 @@
 package configs
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/zclconf/go-cty/cty"
@@ -17,7 +20,7 @@ import (
 
 // TestNewModule_provider_local_name exercises module.gatherProviderLocalNames()
 func TestNewModule_provider_local_name(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/providers-explicit-fqn")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/providers-explicit-fqn")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -154,7 +157,7 @@ func TestNewModule_resource_providers(t *testing.T) {
 }
 
 func TestProviderForLocalConfig(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/providers-explicit-fqn")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/providers-explicit-fqn")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -168,7 +171,7 @@ func TestProviderForLocalConfig(t *testing.T) {
 
 // At most one required_providers block per module is permitted.
 func TestModule_required_providers_multiple(t *testing.T) {
-	_, diags := testModuleFromDir("testdata/invalid-modules/multiple-required-providers")
+	_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/multiple-required-providers")
 	if !diags.HasErrors() {
 		t.Fatal("module should have error diags, but does not")
 	}
@@ -183,7 +186,7 @@ func TestModule_required_providers_multiple(t *testing.T) {
 // resources. These provider settings should still be reflected in the
 // resources' configuration.
 func TestModule_required_providers_after_resource(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/required-providers-after-resource")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/required-providers-after-resource")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -212,7 +215,7 @@ func TestModule_required_providers_after_resource(t *testing.T) {
 // This should also be reflected in any resources in the module using this
 // provider.
 func TestModule_required_provider_overrides(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/required-providers-overrides")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/required-providers-overrides")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -262,7 +265,7 @@ func TestModule_required_provider_overrides(t *testing.T) {
 // In case a required_provider is containing syntax errors, we are returning an empty one just to allow the
 // later validations to add their results.
 func TestModule_required_providers_multiple_one_with_syntax_error(t *testing.T) {
-	_, diags := testModuleFromDir("testdata/invalid-modules/multiple-required-providers-with-syntax-error")
+	_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/multiple-required-providers-with-syntax-error")
 	if !diags.HasErrors() {
 		t.Fatal("module should have error diags, but does not")
 	}
@@ -295,7 +298,7 @@ func TestModule_required_providers_multiple_one_with_syntax_error(t *testing.T)
 // to a default provider if none is found. This applies to both managed and
 // data resources.
 func TestModule_implied_provider(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/implied-providers")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/implied-providers")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -368,7 +371,7 @@ func TestModule_implied_provider(t *testing.T) {
 }
 
 func TestImpliedProviderForUnqualifiedType(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/implied-providers")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/implied-providers")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -396,7 +399,7 @@ func TestImpliedProviderForUnqualifiedType(t *testing.T) {
 }
 
 func TestModule_backend_override(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-backend")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-backend")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -425,7 +428,7 @@ func TestModule_backend_override(t *testing.T) {
 // Unlike most other overrides, backend blocks do not require a base configuration in a primary
 // configuration file, as an omitted backend there implies the local backend.
 func TestModule_backend_override_no_base(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-backend-no-base")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-backend-no-base")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -436,7 +439,7 @@ func TestModule_backend_override_no_base(t *testing.T) {
 }
 
 func TestModule_cloud_override_backend(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-backend-with-cloud")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-backend-with-cloud")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -454,7 +457,7 @@ func TestModule_cloud_override_backend(t *testing.T) {
 // configuration file, as an omitted backend there implies the local backend and cloud blocks
 // override backends.
 func TestModule_cloud_override_no_base(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-cloud-no-base")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-cloud-no-base")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -465,7 +468,7 @@ func TestModule_cloud_override_no_base(t *testing.T) {
 }
 
 func TestModule_cloud_override(t *testing.T) {
-	mod, diags := testModuleFromDir("testdata/valid-modules/override-cloud")
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-cloud")
 	if diags.HasErrors() {
 		t.Fatal(diags.Error())
 	}
@@ -490,15 +493,59 @@ func TestModule_cloud_override(t *testing.T) {
 }
 
 func TestModule_cloud_duplicate_overrides(t *testing.T) {
-	_, diags := testModuleFromDir("testdata/invalid-modules/override-cloud-duplicates")
+	_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/override-cloud-duplicates")
 	want := `Duplicate cloud configurations`
 	if got := diags.Error(); !strings.Contains(got, want) {
 		t.Fatalf("expected module error to contain %q\nerror was:\n%s", want, got)
 	}
 }
 
+func TestModule_profile_override(t *testing.T) {
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/override-profile")
+	if diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+
+	want := map[string]*Profile{
+		"networking": {
+			Name: "networking",
+			Excludes: []addrs.Targetable{
+				addrs.ConfigResource{
+					Module: addrs.Module{"app"},
+					Resource: addrs.Resource{
+						Mode: addrs.ManagedResourceMode,
+						Type: "aws_instance",
+						Name: "web",
+					},
+				},
+			},
+		},
+		"no_databases": {
+			Name: "no_databases",
+			Excludes: []addrs.Targetable{
+				addrs.Module{"database"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, mod.Profiles, cmpopts.IgnoreFields(Profile{}, "DeclRange")); diff != "" {
+		t.Errorf("wrong profiles\n%s", diff)
+	}
+}
+
+func TestModule_profile_duplicate(t *testing.T) {
+	_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/duplicate-profiles")
+	if !diags.HasErrors() {
+		t.Fatal("module should have error diags, but does not")
+	}
+
+	want := `Duplicate profile`
+	if got := diags.Error(); !strings.Contains(got, want) {
+		t.Fatalf("expected error to contain %q\nerror was:\n%s", want, got)
+	}
+}
+
 func TestModuleFromTheFuture(t *testing.T) {
-	_, diags := testModuleFromDir("testdata/invalid-modules/unsupported-version-and-other-error")
+	_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/unsupported-version-and-other-error")
 	if !diags.HasErrors() {
 		t.Fatal("unexpected success; want 'incompatible module' error")
 	}
@@ -548,3 +595,142 @@ func TestResourceByAddr(t *testing.T) {
 	}
 
 }
+
+func TestNewModule_nestedLocals(t *testing.T) {
+	mod := ModuleFromStringForTesting(t, `
+locals {
+  region = "eu"
+}
+
+resource "test_resource" "a" {
+  for_each = toset(["x", "y"])
+
+  locals {
+    name = "${local.region}-${each.key}"
+  }
+  locals {
+    upper = upper(local.name)
+  }
+
+  value = local.upper
+}
+
+module "child" {
+  source = "./child"
+  count  = 2
+
+  locals {
+    index = count.index
+  }
+
+  index = local.index
+}
+`)
+
+	res := mod.ManagedResources["test_resource.a"]
+	if got, want := slices.Sorted(maps.Keys(res.Locals)), []string{"name", "upper"}; !cmp.Equal(got, want) {
+		t.Errorf("wrong resource locals\n%s", cmp.Diff(want, got))
+	}
+	call := mod.ModuleCalls["child"]
+	if got, want := slices.Sorted(maps.Keys(call.Locals)), []string{"index"}; !cmp.Equal(got, want) {
+		t.Errorf("wrong module call locals\n%s", cmp.Diff(want, got))
+	}
+	if got, want := slices.Sorted(maps.Keys(mod.Locals)), []string{"region"}; !cmp.Equal(got, want) {
+		t.Errorf("wrong module locals\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestModule_namedTypes(t *testing.T) {
+	mod, diags := testModuleFromDir(t.Context(), "testdata/valid-modules/types")
+	assertNoDiagnostics(t, diags)
+
+	serverTy := cty.Object(map[string]cty.Type{
+		"name": cty.String,
+		"port": cty.Number,
+	})
+	if got, want := mod.Types["servers"].ConstraintType.WithoutOptionalAttributesDeep(), cty.List(serverTy); !got.Equals(want) {
+		t.Errorf("wrong type for servers\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	primary := mod.Variables["primary"]
+	if !primary.Type.Equals(serverTy) {
+		t.Errorf("wrong type for var.primary\ngot:  %#v\nwant: %#v", primary.Type, serverTy)
+	}
+	if primary.ParsingMode != VariableParseHCL {
+		t.Errorf("wrong parsing mode for var.primary %q", primary.ParsingMode)
+	}
+	wantPrimary := cty.ObjectVal(map[string]cty.Value{
+		"name": cty.StringVal("primary"),
+		"port": cty.NumberIntVal(80),
+	})
+	if !primary.Default.RawEquals(wantPrimary) {
+		t.Errorf("wrong default for var.primary\ngot:  %#v\nwant: %#v", primary.Default, wantPrimary)
+	}
+
+	wantBackends := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("a"),
+			"port": cty.NumberIntVal(80),
+		}),
+		cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("b"),
+			"port": cty.NumberIntVal(8080),
+		}),
+	})
+	if got := mod.Variables["backends"].Default; !got.RawEquals(wantBackends) {
+		t.Errorf("wrong default for var.backends\ngot:  %#v\nwant: %#v", got, wantBackends)
+	}
+
+	output := mod.Outputs["primary"]
+	if !output.Type.Equals(serverTy) {
+		t.Errorf("wrong type for output.primary\ngot:  %#v\nwant: %#v", output.Type, serverTy)
+	}
+	if got, want := len(output.Validations), 1; got != want {
+		t.Errorf("wrong number of validations for output.primary %d; want %d", got, want)
+	}
+
+	wantByZone := cty.Map(cty.Object(map[string]cty.Type{
+		"servers": cty.List(serverTy),
+		"weight":  cty.Number,
+	}))
+	if got := mod.Variables["by_zone"].Type; !got.Equals(wantByZone) {
+		t.Errorf("wrong type for var.by_zone\ngot:  %#v\nwant: %#v", got, wantByZone)
+	}
+}
+
+func TestModule_namedTypesInvalid(t *testing.T) {
+	tests := map[string]struct {
+		wantSummary string
+		wantDetail  string
+	}{
+		"types-cyclic": {
+			"Cyclic type definition",
+			`The type "a" refers to itself through the chain of types a -> b -> a. A type cannot contain itself.`,
+		},
+		"types-undeclared": {
+			"Invalid type specification",
+			`The keyword "server" is not a valid type specification, and there is no type of that name declared in a "type" block of this module.`,
+		},
+		// A module that doesn't declare any types reports unknown keywords
+		// in the same way as the type constraint parser.
+		"variable-type-unknown": {
+			"Invalid type specification",
+			`The keyword "notatype" is not a valid type specification.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := testModuleFromDir(t.Context(), "testdata/invalid-modules/"+name)
+			if len(diags) != 1 {
+				t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.Error())
+			}
+			if diags[0].Summary != test.wantSummary {
+				t.Errorf("wrong diagnostic summary\ngot:  %s\nwant: %s", diags[0].Summary, test.wantSummary)
+			}
+			if diags[0].Detail != test.wantDetail {
+				t.Errorf("wrong diagnostic detail\ngot:  %s\nwant: %s", diags[0].Detail, test.wantDetail)
+			}
+		})
+	}
+}
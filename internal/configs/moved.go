@@ -6,14 +6,36 @@
 package configs
 
 import (
+	"context"
+	"sort"
+
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/hcl2shim"
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/lang/evalchecks"
+	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
 type Moved struct {
 	From *addrs.MoveEndpoint
 	To   *addrs.MoveEndpoint
 
+	// ForEach is the for_each expression of the block, if any.
+	//
+	// A moved block with for_each has nil From and To after decoding. During
+	// static evaluation of the module it's replaced by one Moved per element
+	// of the for_each value, decoded from FromExpr and ToExpr with each.key
+	// and each.value available for use in instance keys. FromExpr and ToExpr
+	// are only set for blocks with for_each.
+	ForEach  hcl.Expression
+	FromExpr hcl.Expression
+	ToExpr   hcl.Expression
+
 	DeclRange hcl.Range
 }
 
@@ -26,58 +48,293 @@ func decodeMovedBlock(block *hcl.Block) (*Moved, hcl.Diagnostics) {
 	content, moreDiags := block.Body.Content(movedBlockSchema)
 	diags = append(diags, moreDiags...)
 
+	if attr, exists := content.Attributes["for_each"]; exists {
+		moved.ForEach = attr.Expr
+	}
+
+	var fromExpr, toExpr hcl.Expression
 	if attr, exists := content.Attributes["from"]; exists {
-		from, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
+		expr, exprDiags := moveEndpointExpr(attr.Expr)
+		diags = append(diags, exprDiags...)
+		fromExpr = expr
+	}
+
+	if attr, exists := content.Attributes["to"]; exists {
+		expr, exprDiags := moveEndpointExpr(attr.Expr)
+		diags = append(diags, exprDiags...)
+		toExpr = expr
+	}
+
+	if moved.ForEach != nil {
+		// The addresses of a moved block with for_each can only be decoded
+		// once we know each.key and each.value, in expandMovedForEach.
+		moved.FromExpr = fromExpr
+		moved.ToExpr = toExpr
+		return moved, diags
+	}
+
+	diags = append(diags, moved.decodeEndpoints(fromExpr, toExpr, nil)...)
+	return moved, diags
+}
+
+// decodeEndpoints decodes the From and To addresses of the receiver from
+// the given expressions, using keyCtx (which may be nil) to evaluate any
+// instance keys that aren't constant.
+func (m *Moved) decodeEndpoints(fromExpr, toExpr hcl.Expression, keyCtx *hcl.EvalContext) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	if fromExpr != nil {
+		from, traversalDiags := moveEndpointTraversalForExpr(fromExpr, keyCtx)
 		diags = append(diags, traversalDiags...)
 		if !traversalDiags.HasErrors() {
 			from, fromDiags := addrs.ParseMoveEndpoint(from)
 			diags = append(diags, fromDiags.ToHCL()...)
-			moved.From = from
+			m.From = from
 		}
 	}
 
-	if attr, exists := content.Attributes["to"]; exists {
-		to, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
+	if toExpr != nil {
+		to, traversalDiags := moveEndpointTraversalForExpr(toExpr, keyCtx)
 		diags = append(diags, traversalDiags...)
 		if !traversalDiags.HasErrors() {
 			to, toDiags := addrs.ParseMoveEndpoint(to)
 			diags = append(diags, toDiags.ToHCL()...)
-			moved.To = to
+			m.To = to
 		}
 	}
 	// ensure that the moved block is not used against ephemeral resources since there is no use against those
-	if !moved.From.SubjectAllowed() {
+	if !m.From.SubjectAllowed() {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Invalid \"moved\" address",
 			Detail:   "The resource referenced by the \"from\" attribute is not allowed to be moved.",
-			Subject:  &moved.DeclRange,
+			Subject:  &m.DeclRange,
 		})
 	}
-	if !moved.To.SubjectAllowed() {
+	if !m.To.SubjectAllowed() {
 		diags = diags.Append(&hcl.Diagnostic{
 			Severity: hcl.DiagError,
 			Summary:  "Invalid \"moved\" address",
 			Detail:   "The resource referenced by the \"to\" attribute is not allowed to be moved.",
-			Subject:  &moved.DeclRange,
+			Subject:  &m.DeclRange,
 		})
 	}
 
 	// we can only move from a module to a module, resource to resource, etc.
 	if !diags.HasErrors() {
-		if !moved.From.MightUnifyWith(moved.To) {
+		if m.From.ModuleWildcardCount() != m.To.ModuleWildcardCount() {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"moved\" addresses",
+				Detail:   "The \"from\" and \"to\" addresses must use the same number of [*] wildcards for module instance keys, because the keys matched by each wildcard in \"from\" are used for the corresponding wildcard in \"to\".",
+				Subject:  &m.DeclRange,
+			})
+		} else if !m.From.MightUnifyWith(m.To) {
 			// We can catch some obviously-wrong combinations early here,
 			// but we still have other dynamic validation to do at runtime.
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  "Invalid \"moved\" addresses",
 				Detail:   "The \"from\" and \"to\" addresses must either both refer to resources or both refer to modules.",
-				Subject:  &moved.DeclRange,
+				Subject:  &m.DeclRange,
 			})
 		}
 	}
 
-	return moved, diags
+	return diags
+}
+
+// expandMovedForEach replaces each of the module's moved blocks that use
+// for_each with one moved block for each element of its for_each value.
+func (m *Module) expandMovedForEach(ctx context.Context) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	var expanded []*Moved
+	for _, mv := range m.Moved {
+		if mv.ForEach == nil {
+			expanded = append(expanded, mv)
+			continue
+		}
+
+		forEach, forEachDiags := evalStaticForEach(ctx, m.StaticEvaluator, mv.ForEach, "moved")
+		diags = append(diags, forEachDiags...)
+		if forEachDiags.HasErrors() {
+			continue
+		}
+
+		for _, key := range sortedForEachKeys(forEach) {
+			keyCtx, ctxDiags := staticEachEvalContext(ctx, m.StaticEvaluator, []hcl.Expression{mv.FromExpr, mv.ToExpr}, "moved", key, forEach[key])
+			diags = append(diags, ctxDiags...)
+			if ctxDiags.HasErrors() {
+				break
+			}
+
+			inst := &Moved{
+				DeclRange: mv.DeclRange,
+			}
+			instDiags := inst.decodeEndpoints(mv.FromExpr, mv.ToExpr, keyCtx)
+			diags = append(diags, instDiags...)
+			if instDiags.HasErrors() {
+				break
+			}
+			expanded = append(expanded, inst)
+		}
+	}
+	m.Moved = expanded
+
+	return diags
+}
+
+// evalStaticForEach evaluates the for_each expression of a "moved" or
+// "removed" block, which must be known during static evaluation of the
+// module because those blocks are processed before planning.
+func evalStaticForEach(ctx context.Context, eval *StaticEvaluator, expr hcl.Expression, blockType string) (map[string]cty.Value, hcl.Diagnostics) {
+	forEachRefsFunc := func(refs []*addrs.Reference) (*hcl.EvalContext, tfdiags.Diagnostics) {
+		var diags tfdiags.Diagnostics
+		evalContext, evalDiags := eval.EvalContext(ctx, StaticIdentifier{
+			Module:    eval.call.addr,
+			Subject:   blockType + ".for_each",
+			DeclRange: expr.Range(),
+		}, refs)
+		return evalContext, diags.Append(evalDiags)
+	}
+
+	forEach, diags := evalchecks.EvaluateForEachExpression(expr, forEachRefsFunc, nil)
+	return forEach, diags.ToHCL()
+}
+
+func sortedForEachKeys(forEach map[string]cty.Value) []string {
+	keys := make([]string, 0, len(forEach))
+	for key := range forEach {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// staticEachEvalContext returns an evaluation context for the instance keys
+// in the given address expressions for one element of a for_each value,
+// with each.key and each.value set along with any static references.
+func staticEachEvalContext(ctx context.Context, eval *StaticEvaluator, exprs []hcl.Expression, blockType string, key string, val cty.Value) (*hcl.EvalContext, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	// Only the expressions used as instance keys can have references, so
+	// we don't want the references to the objects being addressed.
+	var refs []*addrs.Reference
+	for _, expr := range exprs {
+		for _, keyExpr := range moveEndpointKeyExprs(expr) {
+			keyRefs, refsDiags := lang.ReferencesInExpr(addrs.ParseRef, keyExpr)
+			diags = append(diags, refsDiags.ToHCL()...)
+			for _, ref := range keyRefs {
+				// The static evaluator doesn't know about each, so we'll
+				// add it to the context ourselves below.
+				if _, ok := ref.Subject.(addrs.ForEachAttr); !ok {
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	evalCtx, ctxDiags := eval.EvalContext(ctx, StaticIdentifier{
+		Module:  eval.call.addr,
+		Subject: blockType + " address",
+	}, refs)
+	diags = append(diags, ctxDiags...)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	if evalCtx.Variables == nil {
+		evalCtx.Variables = map[string]cty.Value{}
+	}
+	evalCtx.Variables["each"] = cty.ObjectVal(map[string]cty.Value{
+		"key":   cty.StringVal(key),
+		"value": val,
+	})
+	return evalCtx, diags
+}
+
+// moveEndpointExpr prepares the expression of a "from" or "to" argument for
+// use with moveEndpointTraversalForExpr, which only understands native
+// syntax expressions.
+func moveEndpointExpr(expr hcl.Expression) (hcl.Expression, hcl.Diagnostics) {
+	if hcljson.IsJSONExpression(expr) {
+		return hcl2shim.ConvertJSONExpressionToHCL(expr)
+	}
+	return expr, nil
+}
+
+// moveEndpointTraversalForExpr is like hcl.AbsTraversalForExpr, for the
+// "from" and "to" arguments of "moved" and "removed" blocks. In addition to
+// static traversals it accepts the [*] wildcard instance key and, if keyCtx
+// is not nil, instance keys given as expressions to evaluate in keyCtx, such
+// as each.key.
+func moveEndpointTraversalForExpr(expr hcl.Expression, keyCtx *hcl.EvalContext) (hcl.Traversal, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		traversal := make(hcl.Traversal, len(e.Traversal))
+		copy(traversal, e.Traversal)
+		return traversal, diags
+	case *hclsyntax.AnonSymbolExpr:
+		// This is the placeholder for each element inside a splat
+		// expression, which always starts a relative traversal.
+		return nil, diags
+	case *hclsyntax.RelativeTraversalExpr:
+		traversal, moreDiags := moveEndpointTraversalForExpr(e.Source, keyCtx)
+		diags = append(diags, moreDiags...)
+		return append(traversal, e.Traversal...), diags
+	case *hclsyntax.SplatExpr:
+		traversal, moreDiags := moveEndpointTraversalForExpr(e.Source, keyCtx)
+		diags = append(diags, moreDiags...)
+		traversal = append(traversal, hcl.TraverseSplat{SrcRange: e.MarkerRange})
+		rest, moreDiags := moveEndpointTraversalForExpr(e.Each, keyCtx)
+		diags = append(diags, moreDiags...)
+		return append(traversal, rest...), diags
+	case *hclsyntax.IndexExpr:
+		if keyCtx == nil {
+			break
+		}
+		traversal, moreDiags := moveEndpointTraversalForExpr(e.Collection, keyCtx)
+		diags = append(diags, moreDiags...)
+		key, keyDiags := e.Key.Value(keyCtx)
+		diags = append(diags, keyDiags...)
+		if keyDiags.HasErrors() {
+			return traversal, diags
+		}
+		if !key.IsWhollyKnown() || key.IsNull() || key.IsMarked() || (key.Type() != cty.String && key.Type() != cty.Number) {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid instance key",
+				Detail:   "An instance key in this address must be a known string or number, and must not be sensitive or ephemeral.",
+				Subject:  e.Key.Range().Ptr(),
+			})
+			return traversal, diags
+		}
+		return append(traversal, hcl.TraverseIndex{Key: key, SrcRange: e.Key.Range()}), diags
+	}
+
+	// For anything else we'll let HCL return its usual error about the
+	// expression not being a static traversal.
+	return hcl.AbsTraversalForExpr(expr)
+}
+
+// moveEndpointKeyExprs returns the expressions used as dynamic instance keys
+// in the given "from" or "to" expression.
+func moveEndpointKeyExprs(expr hcl.Expression) []hcl.Expression {
+	switch e := expr.(type) {
+	case *hclsyntax.RelativeTraversalExpr:
+		return moveEndpointKeyExprs(e.Source)
+	case *hclsyntax.SplatExpr:
+		return append(moveEndpointKeyExprs(e.Source), moveEndpointKeyExprs(e.Each)...)
+	case *hclsyntax.IndexExpr:
+		return append(moveEndpointKeyExprs(e.Collection), e.Key)
+	default:
+		return nil
+	}
 }
 
 var movedBlockSchema = &hcl.BodySchema{
@@ -90,5 +347,8 @@ var movedBlockSchema = &hcl.BodySchema{
 			Name:     "to",
 			Required: true,
 		},
+		{
+			Name: "for_each",
+		},
 	},
 }
@@ -224,6 +224,36 @@ func TestMovedBlock_inModule(t *testing.T) {
 	}
 }
 
+func TestMovedBlock_forEach(t *testing.T) {
+	parser := NewParser(nil)
+	mod, diags := parser.LoadConfigDir("testdata/valid-modules/moved-blocks-for-each")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Error())
+	}
+	if len(mod.Moved) != 3 || mod.Moved[0].ForEach == nil || mod.Moved[0].From != nil {
+		t.Fatalf("moved block with for_each should not be decoded before static evaluation")
+	}
+
+	diags = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Error())
+	}
+
+	var gotPairs [][2]string
+	for _, mc := range mod.Moved {
+		gotPairs = append(gotPairs, [2]string{mc.From.String(), mc.To.String()})
+	}
+	wantPairs := [][2]string{
+		{`test.foo["a"]`, `module.b["a"].test.foo`},
+		{`test.foo["b"]`, `module.b["b"].test.foo`},
+		{`test.bar[*]`, `module.c.test.bar[*]`},
+		{`module.x[*].test.baz`, `module.y[*].test.baz`},
+	}
+	if diff := cmp.Diff(wantPairs, gotPairs); diff != "" {
+		t.Errorf("wrong addresses\n%s", diff)
+	}
+}
+
 func mustMoveEndpointFromExpr(expr hcl.Expression) *addrs.MoveEndpoint {
 	traversal, hcldiags := hcl.AbsTraversalForExpr(expr)
 	if hcldiags.HasErrors() {
@@ -63,6 +63,14 @@ type Variable struct {
 	NullableSet bool
 
 	DeclRange hcl.Range
+
+	// namedTypeExpr is the type constraint expression for a variable whose
+	// type refers to named types declared in "type" blocks, which can't be
+	// decoded until all of those named types are known. Until then, the
+	// type of the variable is cty.DynamicPseudoType and its default value
+	// is not converted.
+	namedTypeExpr hcl.Expression
+	defaultExpr   hcl.Expression
 }
 
 func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagnostics) {
@@ -122,7 +130,14 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 		v.DescriptionSet = true
 	}
 
-	if attr, exists := content.Attributes["type"]; exists {
+	if attr, exists := content.Attributes["type"]; exists && len(lang.TypeConstraintNames(attr.Expr)) != 0 && !exprIsNativeQuotedString(attr.Expr) {
+		// The named types are resolved once the whole module has been
+		// loaded, in [Module.resolveNamedTypes].
+		v.namedTypeExpr = attr.Expr
+		v.ConstraintType = cty.DynamicPseudoType
+		v.Type = cty.DynamicPseudoType
+		v.ParsingMode = VariableParseHCL
+	} else if exists {
 		ty, tyDefaults, parseMode, tyDiags := decodeVariableType(attr.Expr)
 		diags = append(diags, tyDiags...)
 		v.ConstraintType = ty
@@ -182,7 +197,10 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 		// attribute above.
 		// However, we can't do this if we're in an override file where
 		// the type might not be set; we'll catch that during merge.
-		if v.ConstraintType != cty.NilType {
+		if v.namedTypeExpr != nil {
+			v.defaultExpr = attr.Expr
+		}
+		if v.ConstraintType != cty.NilType && v.namedTypeExpr == nil {
 			// We currently reconstruct a [lang.TypeConversionConstraint] here just
 			// temporarily to call ConvertValue on it, because the representation in
 			// [Variable] long predates this wrapper type.
@@ -236,6 +254,51 @@ func decodeVariableBlock(block *hcl.Block, override bool) (*Variable, hcl.Diagno
 	return v, diags
 }
 
+// resolveNamedType decodes the type constraint of a variable whose type refers
+// to named types, using the given constraints for those types, and then
+// converts its default value to the resulting type.
+func (v *Variable) resolveNamedType(types map[string]lang.TypeConversionConstraint) hcl.Diagnostics {
+	expr := v.namedTypeExpr
+	v.namedTypeExpr = nil
+
+	convertTarget, tyDiags := lang.ParseTypeConversionConstraintWithTypes(expr, types)
+	diags := tyDiags.ToHCL()
+	if diags.HasErrors() {
+		return diags
+	}
+	v.ConstraintType = convertTarget.ConvertTarget
+	v.TypeDefaults = convertTarget.DefaultAttrVals
+	v.Type = v.ConstraintType.WithoutOptionalAttributesDeep()
+	if v.ConstraintType.IsPrimitiveType() {
+		v.ParsingMode = VariableParseLiteral
+	}
+
+	if v.Default == cty.NilVal {
+		return diags
+	}
+	// The default value might come from an override file that doesn't also
+	// override the type, in which case we don't have its expression.
+	subject := v.DeclRange
+	if v.defaultExpr != nil {
+		subject = v.defaultExpr.Range()
+	}
+	val, err := convertTarget.ConvertValue(v.Default)
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid default value for variable",
+			Detail:   fmt.Sprintf("This default value is not compatible with the variable's type constraint: %s.", tfdiags.FormatError(err)),
+			Subject:  subject.Ptr(),
+		})
+		val = cty.DynamicVal
+	}
+	if v.defaultExpr != nil {
+		diags = append(diags, lintVariableDefaultValue(v.defaultExpr, v.ConstraintType)...)
+	}
+	v.Default = val
+	return diags
+}
+
 // lintVariableDefaultValue checks for situations where the expression used to
 // set the default value for an input variable is highly likely to be a mistake
 // despite being technically valid, and returns warnings for those cases.
@@ -394,6 +457,91 @@ func (v *Variable) InputPrompt() string {
 	return v.Description
 }
 
+// TypeDefinition represents a "type" block in a module or file, which
+// declares a named type constraint that variables can use by name in their
+// own type constraints.
+type TypeDefinition struct {
+	Name        string
+	Description string
+
+	// Export is true if child modules can also use this type, by declaring
+	// a type of the same name without a definition.
+	Export bool
+
+	// Definition is the type constraint expression, which can itself refer
+	// to other named types. It is nil if the type's definition is instead
+	// exported by the parent module.
+	Definition hcl.Expression
+
+	// ConstraintType and TypeDefaults are the result of decoding Definition,
+	// which are populated only once any named types it refers to have been
+	// resolved. Until then, ConstraintType is cty.NilType.
+	ConstraintType cty.Type
+	TypeDefaults   *typeexpr.Defaults
+
+	DeclRange hcl.Range
+}
+
+func decodeTypeDefinitionBlock(block *hcl.Block) (*TypeDefinition, hcl.Diagnostics) {
+	td := &TypeDefinition{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	content, diags := block.Body.Content(typeDefinitionBlockSchema)
+
+	if !hclsyntax.ValidIdentifier(td.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid type name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	} else if lang.IsBuiltinTypeKeyword(td.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid type name",
+			Detail:   fmt.Sprintf("The type name %q is reserved because it is part of the type constraint syntax.", td.Name),
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	if attr, exists := content.Attributes["description"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &td.Description)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["export"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &td.Export)
+		diags = append(diags, valDiags...)
+	}
+
+	if attr, exists := content.Attributes["definition"]; exists {
+		td.Definition = attr.Expr
+		if len(lang.TypeConstraintNames(attr.Expr)) == 0 {
+			convertTarget, tyDiags := lang.ParseTypeConversionConstraint(attr.Expr)
+			diags = append(diags, tyDiags.ToHCL()...)
+			td.setConstraint(convertTarget, tyDiags.HasErrors())
+		}
+	}
+
+	return td, diags
+}
+
+func (td *TypeDefinition) constraint() lang.TypeConversionConstraint {
+	return lang.NewTypeConversionConstraint(td.ConstraintType, td.TypeDefaults)
+}
+
+func (td *TypeDefinition) setConstraint(convertTarget lang.TypeConversionConstraint, invalid bool) {
+	if invalid {
+		td.ConstraintType = cty.DynamicPseudoType
+		td.TypeDefaults = nil
+		return
+	}
+	td.ConstraintType = convertTarget.ConvertTarget
+	td.TypeDefaults = convertTarget.DefaultAttrVals
+}
+
 // VariableParsingMode defines how values of a particular variable given by
 // text-only mechanisms (command line arguments and environment variables)
 // should be parsed to produce the final value.
@@ -503,7 +651,16 @@ type Output struct {
 	Deprecated  string
 	Ephemeral   bool
 
+	// Type is the concrete type that the output value is converted to, if
+	// the output block has a "type" argument, or cty.DynamicPseudoType
+	// otherwise. ConstraintType and TypeDefaults are used for the conversion
+	// itself, in the same way as for a [Variable].
+	Type           cty.Type
+	ConstraintType cty.Type
+	TypeDefaults   *typeexpr.Defaults
+
 	Preconditions []*CheckRule
+	Validations   []*CheckRule
 
 	DescriptionSet bool
 	SensitiveSet   bool
@@ -511,6 +668,10 @@ type Output struct {
 
 	DeclRange hcl.Range
 
+	// namedTypeExpr is the type constraint expression for an output value
+	// whose type refers to named types, as for [Variable].
+	namedTypeExpr hcl.Expression
+
 	// IsOverridden indicates if the output is being overridden. It's used in
 	// testing framework to not evaluate expression and use OverrideValue instead.
 	IsOverridden bool
@@ -528,6 +689,13 @@ func decodeOutputBlock(block *hcl.Block, override bool) (*Output, hcl.Diagnostic
 		DeclRange: block.DefRange,
 	}
 
+	// As for variables, we leave the type unset in override files so that
+	// we can recognize whether it was set when we merge.
+	if !override {
+		o.Type = cty.DynamicPseudoType
+		o.ConstraintType = cty.DynamicPseudoType
+	}
+
 	schema := outputBlockSchema
 	if override {
 		schema = schemaForOverrides(schema)
@@ -555,6 +723,20 @@ func decodeOutputBlock(block *hcl.Block, override bool) (*Output, hcl.Diagnostic
 		o.Expr = attr.Expr
 	}
 
+	if attr, exists := content.Attributes["type"]; exists && len(lang.TypeConstraintNames(attr.Expr)) != 0 && !exprIsNativeQuotedString(attr.Expr) {
+		// The named types are resolved once the whole module has been
+		// loaded, in [Module.resolveNamedTypes].
+		o.namedTypeExpr = attr.Expr
+		o.ConstraintType = cty.DynamicPseudoType
+		o.Type = cty.DynamicPseudoType
+	} else if exists {
+		ty, tyDefaults, _, tyDiags := decodeVariableType(attr.Expr)
+		diags = append(diags, tyDiags...)
+		o.ConstraintType = ty
+		o.TypeDefaults = tyDefaults
+		o.Type = ty.WithoutOptionalAttributesDeep()
+	}
+
 	if attr, exists := content.Attributes["sensitive"]; exists {
 		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &o.Sensitive)
 		diags = append(diags, valDiags...)
@@ -593,6 +775,10 @@ func decodeOutputBlock(block *hcl.Block, override bool) (*Output, hcl.Diagnostic
 			cr, moreDiags := decodeCheckRuleBlock(block, override)
 			diags = append(diags, moreDiags...)
 			o.Preconditions = append(o.Preconditions, cr)
+		case "validation":
+			vv, moreDiags := decodeOutputValidationBlock(o.Name, block, override)
+			diags = append(diags, moreDiags...)
+			o.Validations = append(o.Validations, vv)
 		case "postcondition":
 			diags = append(diags, &hcl.Diagnostic{
 				Severity: hcl.DiagError,
@@ -610,6 +796,47 @@ func decodeOutputBlock(block *hcl.Block, override bool) (*Output, hcl.Diagnostic
 	return o, diags
 }
 
+// decodeOutputValidationBlock decodes a "validation" block in an "output"
+// block, whose condition must refer to the output's final value as "self".
+func decodeOutputValidationBlock(outputName string, block *hcl.Block, override bool) (*CheckRule, hcl.Diagnostics) {
+	vv, diags := decodeCheckRuleBlock(block, override)
+	if vv.Condition != nil {
+		refersToSelf := false
+		for _, traversal := range vv.Condition.Variables() {
+			if traversal.RootName() == "self" {
+				refersToSelf = true
+				break
+			}
+		}
+		if !refersToSelf {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid output value validation condition",
+				Detail:   fmt.Sprintf("The condition for output value %q must refer to self in order to test the output's value.", outputName),
+				Subject:  vv.Condition.Range().Ptr(),
+			})
+		}
+	}
+	return vv, diags
+}
+
+// resolveNamedType decodes the type constraint of an output value whose type
+// refers to named types, using the given constraints for those types.
+func (o *Output) resolveNamedType(types map[string]lang.TypeConversionConstraint) hcl.Diagnostics {
+	expr := o.namedTypeExpr
+	o.namedTypeExpr = nil
+
+	convertTarget, tyDiags := lang.ParseTypeConversionConstraintWithTypes(expr, types)
+	diags := tyDiags.ToHCL()
+	if diags.HasErrors() {
+		return diags
+	}
+	o.ConstraintType = convertTarget.ConvertTarget
+	o.TypeDefaults = convertTarget.DefaultAttrVals
+	o.Type = o.ConstraintType.WithoutOptionalAttributesDeep()
+	return diags
+}
+
 func (o *Output) Addr() addrs.OutputValue {
 	return addrs.OutputValue{Name: o.Name}
 }
@@ -661,6 +888,29 @@ func decodeLocalsBlock(block *hcl.Block) ([]*Local, hcl.Diagnostics) {
 	return locals, diags
 }
 
+// decodeNestedLocalsBlock decodes a locals block nested in a resource or
+// module block, adding the local values it declares to the given map, which
+// is allocated if nil.
+func decodeNestedLocalsBlock(block *hcl.Block, locals map[string]*Local) (map[string]*Local, hcl.Diagnostics) {
+	decoded, diags := decodeLocalsBlock(block)
+	if locals == nil {
+		locals = make(map[string]*Local, len(decoded))
+	}
+	for _, l := range decoded {
+		if existing, exists := locals[l.Name]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate local value definition",
+				Detail:   fmt.Sprintf("A local value named %q was already defined at %s. Local value names must be unique within a block.", existing.Name, existing.DeclRange),
+				Subject:  &l.DeclRange,
+			})
+			continue
+		}
+		locals[l.Name] = l
+	}
+	return locals, diags
+}
+
 // Addr returns the address of the local value declared by the receiver,
 // relative to its containing module.
 func (l *Local) Addr() addrs.LocalValue {
@@ -703,6 +953,20 @@ var variableBlockSchema = &hcl.BodySchema{
 	},
 }
 
+var typeDefinitionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name: "description",
+		},
+		{
+			Name: "definition",
+		},
+		{
+			Name: "export",
+		},
+	},
+}
+
 var outputBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		{
@@ -712,6 +976,9 @@ var outputBlockSchema = &hcl.BodySchema{
 			Name:     "value",
 			Required: true,
 		},
+		{
+			Name: "type",
+		},
 		{
 			Name: "depends_on",
 		},
@@ -728,5 +995,6 @@ var outputBlockSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "precondition"},
 		{Type: "postcondition"},
+		{Type: "validation"},
 	},
 }
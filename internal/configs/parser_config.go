@@ -130,6 +130,13 @@ func loadConfigFileBody(body hcl.Body, _ string, override bool) (*File, hcl.Diag
 						file.ProviderMetas = append(file.ProviderMetas, providerCfg)
 					}
 
+				case "profile":
+					profile, cfgDiags := decodeProfileBlock(innerBlock)
+					diags = append(diags, cfgDiags...)
+					if profile != nil {
+						file.Profiles = append(file.Profiles, profile)
+					}
+
 				case "encryption":
 					encryptionCfg, cfgDiags := config.DecodeConfig(innerBlock.Body, innerBlock.DefRange)
 					diags = append(diags, cfgDiags...)
@@ -236,6 +243,20 @@ func loadConfigFileBody(body hcl.Body, _ string, override bool) (*File, hcl.Diag
 				file.Removed = append(file.Removed, cfg)
 			}
 
+		case "function":
+			cfg, cfgDiags := decodeFunctionBlock(block)
+			diags = append(diags, cfgDiags...)
+			if cfg != nil {
+				file.Functions = append(file.Functions, cfg)
+			}
+
+		case "type":
+			cfg, cfgDiags := decodeTypeDefinitionBlock(block)
+			diags = append(diags, cfgDiags...)
+			if cfg != nil {
+				file.Types = append(file.Types, cfg)
+			}
+
 		default:
 			// Should never happen because the above cases should be exhaustive
 			// for all block type names in our schema.
@@ -305,6 +326,14 @@ var configFileSchema = &hcl.BodySchema{
 		{
 			Type: "removed",
 		},
+		{
+			Type:       "function",
+			LabelNames: []string{"name"},
+		},
+		{
+			Type:       "type",
+			LabelNames: []string{"name"},
+		},
 		{
 			Type: "terraform",
 		},
@@ -348,5 +377,9 @@ var terraformBlockSchema = &hcl.BodySchema{
 		{
 			Type: "encryption",
 		},
+		{
+			Type:       "profile",
+			LabelNames: []string{"name"},
+		},
 	},
 }
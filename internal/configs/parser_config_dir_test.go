@@ -88,7 +88,7 @@ func TestParserLoadConfigDirSuccess(t *testing.T) {
 						}
 						panic("Variables not configured for this test!")
 					}, "<testing>", "")
-				diags = diags.Extend(mod.WithStaticCall(call))
+				diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 			}
 			if diags.HasErrors() {
 				t.Errorf("unexpected error diagnostics")
@@ -106,12 +106,6 @@ func TestParserLoadConfigFileFailureMessages(t *testing.T) {
 			"Invalid data resource lifecycle argument",
 			`The lifecycle argument "ignore_changes" is defined only for managed resources ("resource" blocks), and is not valid for data resources.`,
 		},
-		{
-			"invalid-files/variable-type-unknown.tf",
-			hcl.DiagError,
-			"Invalid type specification",
-			`The keyword "notatype" is not a valid type specification.`,
-		},
 		{
 			"invalid-files/unexpected-attr.tf",
 			hcl.DiagError,
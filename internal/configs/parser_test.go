@@ -57,10 +57,10 @@ func testModuleConfigFromFile(ctx context.Context, filename string) (*Config, hc
 
 // testModuleFromDir reads configuration from the given directory path as
 // a module and returns it. This is a helper for use in unit tests.
-func testModuleFromDir(path string) (*Module, hcl.Diagnostics) {
+func testModuleFromDir(ctx context.Context, path string) (*Module, hcl.Diagnostics) {
 	parser := NewParser(nil)
 	mod, diags := parser.LoadConfigDir(path)
-	diags = diags.Extend(mod.WithStaticCall(RootModuleCallForTesting()))
+	diags = diags.Extend(mod.WithStaticCall(ctx, RootModuleCallForTesting()))
 	return mod, diags
 }
 
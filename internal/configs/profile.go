@@ -0,0 +1,134 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// Profile represents a "profile" block inside a "terraform" block, which
+// gives a name to a reusable set of target or exclude addresses that can be
+// selected for a plan using the -profile command line option.
+//
+// Profiles are only meaningful in the root module, and are ignored elsewhere.
+type Profile struct {
+	Name string
+
+	// Targets and Excludes are the addresses the profile limits a plan to, or
+	// excludes from a plan, respectively. At most one of them is set.
+	//
+	// The addresses may be the configuration-level addrs.Module and
+	// addrs.ConfigResource, when written using wildcard instance keys.
+	Targets  []addrs.Targetable
+	Excludes []addrs.Targetable
+
+	DeclRange hcl.Range
+}
+
+func decodeProfileBlock(block *hcl.Block) (*Profile, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	p := &Profile{
+		Name:      block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	if !hclsyntax.ValidIdentifier(p.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid profile name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	content, moreDiags := block.Body.Content(profileBlockSchema)
+	diags = append(diags, moreDiags...)
+
+	if attr, exists := content.Attributes["targets"]; exists {
+		p.Targets, moreDiags = decodeProfileAddrs(attr)
+		diags = append(diags, moreDiags...)
+	}
+	if attr, exists := content.Attributes["excludes"]; exists {
+		p.Excludes, moreDiags = decodeProfileAddrs(attr)
+		diags = append(diags, moreDiags...)
+
+		if _, exists := content.Attributes["targets"]; exists {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid profile",
+				Detail:   "The targets and excludes arguments are mutually-exclusive. Each profile must use either only targets or only excludes.",
+				Subject:  attr.NameRange.Ptr(),
+			})
+		}
+	}
+
+	return p, diags
+}
+
+// decodeProfileAddrs decodes the given attribute as a list of target
+// patterns, as accepted by addrs.ParseTargetPatternStr.
+func decodeProfileAddrs(attr *hcl.Attribute) ([]addrs.Targetable, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	val, valDiags := attr.Expr.Value(nil)
+	diags = append(diags, valDiags...)
+	if valDiags.HasErrors() {
+		return nil, diags
+	}
+
+	ty := val.Type()
+	if val.IsNull() || !val.IsWhollyKnown() || !(ty.IsListType() || ty.IsTupleType() || ty.IsSetType()) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid profile addresses",
+			Detail:   "A list of resource or module address strings is required.",
+			Subject:  attr.Expr.Range().Ptr(),
+		})
+		return nil, diags
+	}
+
+	var ret []addrs.Targetable
+	for it := val.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		if v.IsNull() || v.Type() != cty.String {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid profile addresses",
+				Detail:   "A list of resource or module address strings is required.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+			continue
+		}
+
+		target, targetDiags := addrs.ParseTargetPatternStr(v.AsString())
+		if targetDiags.HasErrors() {
+			for _, diag := range targetDiags {
+				desc := diag.Description()
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid profile address",
+					Detail:   desc.Detail,
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			continue
+		}
+		ret = append(ret, target.Subject)
+	}
+	return ret, diags
+}
+
+var profileBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "targets"},
+		{Name: "excludes"},
+	},
+}
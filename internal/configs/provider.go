@@ -48,6 +48,13 @@ type Provider struct {
 	MockResources     []*MockResource
 	OverrideResources []*OverrideResource
 
+	// MockGenerators, MockSchemaHints and MockSeed configure how values
+	// are generated for computed attributes of mocked resources. See
+	// MockProvider and TestFile for more details.
+	MockGenerators  []*MockGenerator
+	MockSchemaHints bool
+	MockSeed        int64
+
 	ForEach   hcl.Expression
 	Instances map[addrs.InstanceKey]instances.RepetitionData
 }
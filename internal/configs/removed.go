@@ -6,6 +6,7 @@
 package configs
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/hashicorp/hcl/v2"
@@ -17,6 +18,17 @@ import (
 type Removed struct {
 	From *addrs.RemoveEndpoint
 
+	// ForEach is the for_each expression of the block, if any.
+	//
+	// A removed block with for_each has a nil From after decoding. During
+	// static evaluation of the module it's replaced by one Removed per
+	// element of the for_each value, decoded from FromExpr with each.key and
+	// each.value available for use in instance keys. Unlike a removed block
+	// without for_each, the "from" address can then select particular module
+	// or resource instances. FromExpr is only set for blocks with for_each.
+	ForEach  hcl.Expression
+	FromExpr hcl.Expression
+
 	Destroy    bool
 	DestroySet bool
 
@@ -35,13 +47,25 @@ func decodeRemovedBlock(removedBlock *hcl.Block) (*Removed, hcl.Diagnostics) {
 	content, moreDiags := removedBlock.Body.Content(removedBlockSchema)
 	diags = append(diags, moreDiags...)
 
+	if attr, exists := content.Attributes["for_each"]; exists {
+		removed.ForEach = attr.Expr
+	}
+
 	if attr, exists := content.Attributes["from"]; exists {
-		from, traversalDiags := hcl.AbsTraversalForExpr(attr.Expr)
-		diags = append(diags, traversalDiags...)
-		if !traversalDiags.HasErrors() {
-			from, fromDiags := addrs.ParseRemoveEndpoint(from)
-			diags = append(diags, fromDiags.ToHCL()...)
-			removed.From = from
+		expr, exprDiags := moveEndpointExpr(attr.Expr)
+		diags = append(diags, exprDiags...)
+		// The address of a removed block with for_each can only be decoded
+		// once we know each.key and each.value, in expandRemovedForEach.
+		if removed.ForEach != nil {
+			removed.FromExpr = expr
+		} else if !exprDiags.HasErrors() {
+			from, traversalDiags := moveEndpointTraversalForExpr(expr, nil)
+			diags = append(diags, traversalDiags...)
+			if !traversalDiags.HasErrors() {
+				from, fromDiags := addrs.ParseRemoveEndpoint(from)
+				diags = append(diags, fromDiags.ToHCL()...)
+				removed.From = from
+			}
 		}
 	}
 
@@ -82,17 +106,15 @@ func decodeRemovedBlock(removedBlock *hcl.Block) (*Removed, hcl.Diagnostics) {
 					continue
 				}
 				// Skip nil address from decode error to avoid panic; config load will halt anyway.
-				if removed.From == nil {
+				// With for_each the address is checked in expandRemovedForEach instead.
+				if removed.From == nil && removed.ForEach == nil {
 					continue
 				}
-				if removed.From.RelSubject.AddrType() == addrs.ModuleAddrType {
-					diags = append(diags, &hcl.Diagnostic{
-						Severity: hcl.DiagError,
-						Summary:  `Invalid "removed" block`,
-						Detail:   `"removed" blocks containing "provisioner"s can only target resources. Found one block that is targeting a module`,
-						Subject:  &removedBlock.DefRange,
-					})
-					continue
+				if removed.From != nil {
+					if provisionerDiags := removed.checkProvisionerTarget(); provisionerDiags.HasErrors() {
+						diags = append(diags, provisionerDiags...)
+						continue
+					}
 				}
 				removed.Provisioners = append(removed.Provisioners, pv)
 			}
@@ -122,12 +144,88 @@ func decodeRemovedBlock(removedBlock *hcl.Block) (*Removed, hcl.Diagnostics) {
 	return removed, diags
 }
 
+// checkProvisionerTarget returns an error if the receiver has a "provisioner"
+// block but its "from" address is not a resource.
+func (r *Removed) checkProvisionerTarget() hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if r.From.RelSubject.AddrType() == addrs.ModuleAddrType {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  `Invalid "removed" block`,
+			Detail:   `"removed" blocks containing "provisioner"s can only target resources. Found one block that is targeting a module`,
+			Subject:  &r.DeclRange,
+		})
+	}
+	return diags
+}
+
+// expandRemovedForEach replaces each of the module's removed blocks that
+// use for_each with one removed block for each element of its for_each value.
+func (m *Module) expandRemovedForEach(ctx context.Context) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+
+	var expanded []*Removed
+	for _, rm := range m.Removed {
+		if rm.ForEach == nil {
+			expanded = append(expanded, rm)
+			continue
+		}
+
+		forEach, forEachDiags := evalStaticForEach(ctx, m.StaticEvaluator, rm.ForEach, "removed")
+		diags = append(diags, forEachDiags...)
+		if forEachDiags.HasErrors() || rm.FromExpr == nil {
+			continue
+		}
+
+		for _, key := range sortedForEachKeys(forEach) {
+			keyCtx, ctxDiags := staticEachEvalContext(ctx, m.StaticEvaluator, []hcl.Expression{rm.FromExpr}, "removed", key, forEach[key])
+			diags = append(diags, ctxDiags...)
+			if ctxDiags.HasErrors() {
+				break
+			}
+
+			traversal, traversalDiags := moveEndpointTraversalForExpr(rm.FromExpr, keyCtx)
+			diags = append(diags, traversalDiags...)
+			if traversalDiags.HasErrors() {
+				break
+			}
+			from, fromDiags := addrs.ParseRemoveInstanceEndpoint(traversal)
+			diags = append(diags, fromDiags.ToHCL()...)
+			if fromDiags.HasErrors() {
+				break
+			}
+
+			inst := &Removed{
+				From:         from,
+				Destroy:      rm.Destroy,
+				DestroySet:   rm.DestroySet,
+				Provisioners: rm.Provisioners,
+				DeclRange:    rm.DeclRange,
+			}
+			if len(inst.Provisioners) > 0 {
+				provisionerDiags := inst.checkProvisionerTarget()
+				diags = append(diags, provisionerDiags...)
+				if provisionerDiags.HasErrors() {
+					break
+				}
+			}
+			expanded = append(expanded, inst)
+		}
+	}
+	m.Removed = expanded
+
+	return diags
+}
+
 var removedBlockSchema = &hcl.BodySchema{
 	Attributes: []hcl.AttributeSchema{
 		{
 			Name:     "from",
 			Required: true,
 		},
+		{
+			Name: "for_each",
+		},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "lifecycle"},
@@ -450,6 +450,36 @@ func TestRemovedBlock_inModule(t *testing.T) {
 	}
 }
 
+func TestRemovedBlock_forEach(t *testing.T) {
+	parser := NewParser(nil)
+	mod, diags := parser.LoadConfigDir("testdata/valid-modules/removed-blocks-for-each")
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Error())
+	}
+
+	diags = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
+	if diags.HasErrors() {
+		t.Fatalf("unexpected error: %s", diags.Error())
+	}
+
+	type removedInstance struct {
+		Subject, Instance string
+		Destroy           bool
+	}
+	var got []removedInstance
+	for _, rc := range mod.Removed {
+		got = append(got, removedInstance{rc.From.RelSubject.String(), rc.From.RelInstance.String(), rc.Destroy})
+	}
+	want := []removedInstance{
+		{`test.foo`, `test.foo["a"]`, false},
+		{`test.foo`, `test.foo["b"]`, false},
+		{`module.child`, `module.child["x"]`, true},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("wrong addresses\n%s", diff)
+	}
+}
+
 func mustRemoveEndpointFromExpr(expr hcl.Expression) *addrs.RemoveEndpoint {
 	traversal, hcldiags := hcl.AbsTraversalForExpr(expr)
 	if hcldiags.HasErrors() {
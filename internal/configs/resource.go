@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	hcljson "github.com/hashicorp/hcl/v2/json"
-	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/configs/hcl2shim"
@@ -40,6 +39,11 @@ type Resource struct {
 
 	TriggersReplacement []hcl.Expression
 
+	// Locals are the local values declared in locals blocks nested in the
+	// resource block. They are only visible within the resource block, where
+	// they can refer to count.index, each.key and each.value.
+	Locals map[string]*Local
+
 	// Managed is populated only for Mode = addrs.ManagedResourceMode,
 	// containing the additional fields that apply to managed resources.
 	// For all other resource modes, this field is nil.
@@ -60,7 +64,10 @@ type Resource struct {
 	// an empty Overrides even if IsOverridden is set to true. This map
 	// is keyed for particular instances, with addrs.NoKey being the default, and all
 	// associated modules being added to the list.
-	Overrides *addrs.OverrideTrie[map[string]cty.Value]
+	Overrides *addrs.OverrideTrie[*OverrideResource]
+	// OverrideSeed is mixed into the seed used to generate values for the
+	// computed fields of an overridden resource. See TestFile.MockSeed.
+	OverrideSeed int64
 
 	DeclRange hcl.Range
 	TypeRange hcl.Range
@@ -187,6 +194,11 @@ func decodeResourceBlock(block *hcl.Block, override bool) (*Resource, hcl.Diagno
 	var seenEscapeBlock *hcl.Block
 	for _, block := range content.Blocks {
 		switch block.Type {
+		case "locals":
+			var localsDiags hcl.Diagnostics
+			r.Locals, localsDiags = decodeNestedLocalsBlock(block, r.Locals)
+			diags = append(diags, localsDiags...)
+
 		case "lifecycle":
 			if seenLifecycle != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -506,6 +518,11 @@ func decodeDataBlock(block *hcl.Block, override, nested bool) (*Resource, hcl.Di
 			// will see a blend of both.
 			r.Config = hcl.MergeBodies([]hcl.Body{r.Config, block.Body})
 
+		case "locals":
+			var localsDiags hcl.Diagnostics
+			r.Locals, localsDiags = decodeNestedLocalsBlock(block, r.Locals)
+			diags = append(diags, localsDiags...)
+
 		case "lifecycle":
 			if nested {
 				// We don't allow lifecycle arguments in nested data blocks,
@@ -676,6 +693,11 @@ func decodeEphemeralBlock(block *hcl.Block, override bool) (*Resource, hcl.Diagn
 	var seenEscapeBlock *hcl.Block
 	for _, block := range content.Blocks {
 		switch block.Type {
+		case "locals":
+			var localsDiags hcl.Diagnostics
+			r.Locals, localsDiags = decodeNestedLocalsBlock(block, r.Locals)
+			diags = append(diags, localsDiags...)
+
 		case "lifecycle":
 			if seenLifecycle != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -1112,7 +1134,7 @@ var commonResourceAttributes = []hcl.AttributeSchema{
 var ResourceBlockSchema = &hcl.BodySchema{
 	Attributes: commonResourceAttributes,
 	Blocks: []hcl.BlockHeaderSchema{
-		{Type: "locals"}, // reserved for future use
+		{Type: "locals"},
 		{Type: "lifecycle"},
 		{Type: "connection"},
 		{Type: "provisioner", LabelNames: []string{"type"}},
@@ -1124,8 +1146,8 @@ var dataBlockSchema = &hcl.BodySchema{
 	Attributes: commonResourceAttributes,
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "lifecycle"},
-		{Type: "locals"}, // reserved for future use
-		{Type: "_"},      // meta-argument escaping block
+		{Type: "locals"},
+		{Type: "_"}, // meta-argument escaping block
 	},
 }
 
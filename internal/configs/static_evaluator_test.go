@@ -96,7 +96,7 @@ resource "foo" "bar" {}
 
 	t.Run("Empty Eval", func(t *testing.T) {
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(RootModuleCallForTesting())
+		_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 		emptyEval := StaticEvaluator{}
 
 		// Expr with no traversals shouldn't access any fields
@@ -120,7 +120,7 @@ resource "foo" "bar" {}
 
 	t.Run("Simple static cases", func(t *testing.T) {
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(RootModuleCallForTesting())
+		_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 		eval := NewStaticEvaluator(mod, RootModuleCallForTesting())
 
 		locals := []struct {
@@ -158,7 +158,7 @@ resource "foo" "bar" {}
 			return v.Default, nil
 		}, "<testing>", "")
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(call)
+		_ = mod.WithStaticCall(t.Context(), call)
 		eval := NewStaticEvaluator(mod, call)
 
 		locals := []struct {
@@ -185,7 +185,7 @@ resource "foo" "bar" {}
 
 	t.Run("Bad References", func(t *testing.T) {
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(RootModuleCallForTesting())
+		_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 		eval := NewStaticEvaluator(mod, RootModuleCallForTesting())
 
 		locals := []struct {
@@ -206,7 +206,7 @@ resource "foo" "bar" {}
 
 	t.Run("Circular References", func(t *testing.T) {
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(RootModuleCallForTesting())
+		_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 		eval := NewStaticEvaluator(mod, RootModuleCallForTesting())
 
 		locals := []struct {
@@ -244,7 +244,7 @@ resource "foo" "bar" {}
 			}}
 		}, "<testing>", "")
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(call)
+		_ = mod.WithStaticCall(t.Context(), call)
 		eval := NewStaticEvaluator(mod, call)
 
 		badref := mod.Locals["ref_c"]
@@ -259,7 +259,7 @@ resource "foo" "bar" {}
 
 	t.Run("Missing References", func(t *testing.T) {
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(RootModuleCallForTesting())
+		_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 		eval := NewStaticEvaluator(mod, RootModuleCallForTesting())
 
 		locals := []struct {
@@ -281,7 +281,7 @@ resource "foo" "bar" {}
 	t.Run("Workspace", func(t *testing.T) {
 		call := NewStaticModuleCall(nil, hcl.Range{}, nil, "<testing>", "my-workspace")
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(call)
+		_ = mod.WithStaticCall(t.Context(), call)
 		eval := NewStaticEvaluator(mod, call)
 
 		value, diags := eval.Evaluate(t.Context(), mod.Locals["ws"].Expr, dummyIdentifier)
@@ -295,7 +295,7 @@ resource "foo" "bar" {}
 
 	t.Run("Functions", func(t *testing.T) {
 		mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-		_ = mod.WithStaticCall(RootModuleCallForTesting())
+		_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 		eval := NewStaticEvaluator(mod, RootModuleCallForTesting())
 
 		value, diags := eval.Evaluate(t.Context(), mod.Locals["func"].Expr, dummyIdentifier)
@@ -321,7 +321,7 @@ func TestStaticEvaluator_DecodeExpression(t *testing.T) {
 		t.Fatal(fileDiags)
 	}
 	mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-	_ = mod.WithStaticCall(RootModuleCallForTesting())
+	_ = mod.WithStaticCall(t.Context(), RootModuleCallForTesting())
 	mod.Locals["my_ephemeral_local"] = &Local{
 		Name:      "my_ephemeral_local",
 		Expr:      hcl.StaticExpr(cty.StringVal("ephemeral local value").Mark(marks.Ephemeral), hcl.Range{}),
@@ -454,7 +454,7 @@ terraform {
 				},
 			}
 			mod, _ := NewModule([]*File{file}, nil, "dir", SelectiveLoadAll)
-			_ = mod.WithStaticCall(modCall)
+			_ = mod.WithStaticCall(t.Context(), modCall)
 
 			_, diags := mod.Backend.Hash(t.Context(), schema)
 			if diags.HasErrors() {
@@ -24,13 +24,17 @@ import (
 
 // newStaticScope creates a lang.Scope that's backed by the static view of the module represented by the StaticEvaluator
 func newStaticScope(eval *StaticEvaluator, stack0 StaticIdentifier, stack ...StaticIdentifier) *lang.Scope {
-	return &lang.Scope{
+	scope := &lang.Scope{
 		Data:        staticScopeData{eval, append([]StaticIdentifier{stack0}, stack...)},
 		ParseRef:    addrs.ParseRef,
 		BaseDir:     ".", // Always current working directory for now. (same as Evaluator.Scope())
 		PureOnly:    false,
 		ConsoleMode: false,
 	}
+	if eval.cfg != nil {
+		scope.ModuleFunctions = eval.cfg.UserFunctions()
+	}
+	return scope
 }
 
 // This structure represents the data required to evaluate a specific identifier reference (top of the stack)
@@ -186,7 +186,7 @@ func TestStaticScope_GetInputVariable(t *testing.T) {
 			"irrelevant",
 		)
 		mod, diags := p.LoadConfigDir(".")
-		diags = diags.Extend(mod.WithStaticCall(call))
+		diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 		assertNoDiagnostics(t, diags)
 
 		// We'll make sure the config and the test cases remain consistent
@@ -237,7 +237,7 @@ func TestStaticScope_GetInputVariable(t *testing.T) {
 			"irrelevant",
 		)
 		mod, diags := p.LoadConfigDir(".")
-		diags = diags.Extend(mod.WithStaticCall(call))
+		diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 		assertNoDiagnostics(t, diags)
 
 		eval := NewStaticEvaluator(mod, call)
@@ -276,7 +276,7 @@ func TestStaticScope_GetInputVariable(t *testing.T) {
 			"irrelevant",
 		)
 		mod, diags := p.LoadConfigDir(".")
-		diags = diags.Extend(mod.WithStaticCall(call))
+		diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 		assertNoDiagnostics(t, diags)
 
 		eval := NewStaticEvaluator(mod, call)
@@ -319,7 +319,7 @@ func TestStaticScope_GetLocalValue(t *testing.T) {
 			"irrelevant",
 		)
 		mod, diags := p.LoadConfigDir(".")
-		diags = diags.Extend(mod.WithStaticCall(call))
+		diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 		assertNoDiagnostics(t, diags)
 
 		eval := NewStaticEvaluator(mod, call)
@@ -351,7 +351,7 @@ func TestStaticScope_GetLocalValue(t *testing.T) {
 			"irrelevant",
 		)
 		mod, diags := p.LoadConfigDir(".")
-		diags = diags.Extend(mod.WithStaticCall(call))
+		diags = diags.Extend(mod.WithStaticCall(t.Context(), call))
 		assertNoDiagnostics(t, diags)
 
 		eval := NewStaticEvaluator(mod, call)
@@ -7,6 +7,9 @@ package configs
 
 import (
 	"fmt"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
@@ -14,6 +17,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/hcl2shim"
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/instances"
 	"github.com/opentofu/opentofu/internal/tfdiags"
@@ -64,6 +68,10 @@ type TestFile struct {
 	// order.
 	Runs []*TestRun
 
+	// Fuzzes defines the fuzz blocks that should be executed after the run
+	// blocks.
+	Fuzzes []*TestFuzz
+
 	// OverrideResources is a list of resources to be overridden with static values.
 	// Underlying providers shouldn't be called for overridden resources.
 	OverrideResources []*OverrideResource
@@ -76,6 +84,12 @@ type TestFile struct {
 	// with Providers map to use later when instantiating provider instance.
 	MockProviders map[string]*MockProvider
 
+	// MockSeed is mixed into the seed used to generate values for mocked and
+	// overridden resources. It is not part of the test file syntax: the test
+	// command sets it from the -mock-seed option so that failures caused by a
+	// particular set of generated values can be reproduced.
+	MockSeed int64
+
 	VariablesDeclRange hcl.Range
 }
 
@@ -111,6 +125,9 @@ func (file *TestFile) getTestProviderOrMock(addr string) (*Provider, bool) {
 			IsMocked:          true,
 			MockResources:     mockProvider.MockResources,
 			OverrideResources: mockProvider.OverrideResources,
+			MockGenerators:    mockProvider.Generators,
+			MockSchemaHints:   mockProvider.SchemaHints,
+			MockSeed:          file.MockSeed,
 		}
 
 		return p, true
@@ -179,6 +196,12 @@ type TestRun struct {
 	// run.
 	ExpectFailures []hcl.Traversal
 
+	// ExpectCheckFailures should be a list of check blocks that are expected
+	// to report a failed assertion as part of this test run. Unlike
+	// ExpectFailures, errors from the data sources scoped to these check
+	// blocks are not expected and still fail the test run.
+	ExpectCheckFailures []hcl.Traversal
+
 	// OverrideResources is a list of resources to be overridden with static values.
 	// Underlying providers shouldn't be called for overridden resources.
 	OverrideResources []*OverrideResource
@@ -187,6 +210,18 @@ type TestRun struct {
 	// Underlying modules shouldn't be called.
 	OverrideModules []*OverrideModule
 
+	// Timeout is the maximum duration the plan and apply operations for this
+	// run block may take in total. Zero means there is no limit.
+	Timeout time.Duration
+
+	// Retries is the number of additional attempts made at the plan and apply
+	// operations for this run block when they fail.
+	Retries int
+
+	// Tags is a list of labels used to select run blocks with the -tags and
+	// -skip-tags command line options.
+	Tags []string
+
 	NameDeclRange      hcl.Range
 	VariablesDeclRange hcl.Range
 	DeclRange          hcl.Range
@@ -222,6 +257,23 @@ func (run *TestRun) Validate() tfdiags.Diagnostics {
 
 	}
 
+	for _, traversal := range run.ExpectCheckFailures {
+		reference, refDiags := addrs.ParseRefFromTestingScope(traversal)
+		diags = diags.Append(refDiags)
+		if refDiags.HasErrors() {
+			continue
+		}
+
+		if _, ok := reference.Subject.(addrs.Check); !ok {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid `expect_check_failures` reference",
+				Detail:   fmt.Sprintf("You cannot expect check failures from %s. You can only expect check failures from check blocks.", reference.Subject.String()),
+				Subject:  reference.SourceRange.ToHCL().Ptr(),
+			})
+		}
+	}
+
 	// It's not allowed to have multiple `override_resource`, `override_data` or `override_module` blocks
 	// inside a single run block with the same target address so we want to ensure there's no such cases.
 	diags = diags.Append(checkForDuplicatedOverrideResources(run.OverrideResources))
@@ -279,6 +331,11 @@ type OverrideResource struct {
 	// Values represents fields to use as defaults
 	// if they are not present in configuration.
 	Values map[string]cty.Value
+
+	// Generators is a list of rules used to generate values for computed
+	// fields that are not present in Values. They take precedence over
+	// the generators of the mock provider, if any.
+	Generators []*MockGenerator
 }
 
 func (r OverrideResource) getBlockName() string {
@@ -329,6 +386,14 @@ type MockProvider struct {
 
 	MockResources     []*MockResource
 	OverrideResources []*OverrideResource
+
+	// Generators is a list of rules used to generate plausible values
+	// for computed attributes, instead of random strings.
+	Generators []*MockGenerator
+
+	// SchemaHints enables a built-in set of generator rules that
+	// are keyed by common attribute names, such as "arn" or "cidr_block".
+	SchemaHints bool
 }
 
 // moduleUniqueKey is copied from Provider.moduleUniqueKey
@@ -404,6 +469,36 @@ type MockResource struct {
 	Defaults map[string]cty.Value
 }
 
+const blockNameMockGenerator = "generate"
+
+// MockGenerator represents a rule to generate values for computed attributes
+// of mocked or overridden resources. Attribute is a pattern matched against
+// attribute names, where "*" matches any sequence of characters, and Kind
+// selects the shape of the generated value (e.g. "arn", "cidr" or "json").
+type MockGenerator struct {
+	Attribute string
+	Kind      hcl2shim.MockGeneratorKind
+
+	DeclRange hcl.Range
+}
+
+// MockGeneratorRules converts a list of generator blocks into the rules
+// used by hcl2shim.MockValueComposer, preserving their order.
+func MockGeneratorRules(generators []*MockGenerator) []hcl2shim.MockGeneratorRule {
+	if len(generators) == 0 {
+		return nil
+	}
+
+	rules := make([]hcl2shim.MockGeneratorRule, 0, len(generators))
+	for _, g := range generators {
+		rules = append(rules, hcl2shim.MockGeneratorRule{
+			Pattern: g.Attribute,
+			Kind:    g.Kind,
+		})
+	}
+	return rules
+}
+
 func (r MockResource) getBlockName() string {
 	switch r.Mode {
 	case addrs.ManagedResourceMode:
@@ -437,6 +532,29 @@ func loadTestFile(body hcl.Body) (*TestFile, hcl.Diagnostics) {
 				tf.Runs = append(tf.Runs, run)
 			}
 
+		case blockNameFuzz:
+			fuzz, fuzzDiags := decodeTestFuzzBlock(block)
+			diags = append(diags, fuzzDiags...)
+			if fuzzDiags.HasErrors() {
+				continue
+			}
+
+			for _, existing := range tf.Fuzzes {
+				if existing.Name == fuzz.Name {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Duplicate fuzz block",
+						Detail:   fmt.Sprintf("A fuzz block named %q was already defined at %s.", fuzz.Name, existing.DeclRange),
+						Subject:  fuzz.NameDeclRange.Ptr(),
+					})
+					fuzz = nil
+					break
+				}
+			}
+			if fuzz != nil {
+				tf.Fuzzes = append(tf.Fuzzes, fuzz)
+			}
+
 		case "variables":
 			if tf.Variables != nil {
 				diags = append(diags, &hcl.Diagnostic{
@@ -644,9 +762,73 @@ func decodeTestRunBlock(block *hcl.Block) (*TestRun, hcl.Diagnostics) {
 		r.ExpectFailures = failures
 	}
 
+	if attr, exists := content.Attributes["expect_check_failures"]; exists {
+		failures, failDiags := decodeDependsOn(attr)
+		diags = append(diags, failDiags...)
+		r.ExpectCheckFailures = failures
+	}
+
+	if attr, exists := content.Attributes["timeout"]; exists {
+		var raw string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			timeout, err := time.ParseDuration(raw)
+			if err != nil || timeout <= 0 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid \"timeout\" value",
+					Detail:   "The \"timeout\" argument requires a positive duration, such as \"30s\" or \"5m\".",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				r.Timeout = timeout
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes["retries"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &r.Retries)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && r.Retries < 0 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"retries\" value",
+				Detail:   "The \"retries\" argument must not be negative.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+			r.Retries = 0
+		}
+	}
+
+	if attr, exists := content.Attributes["tags"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &r.Tags)
+		diags = append(diags, valDiags...)
+		for _, tag := range r.Tags {
+			if !hclsyntax.ValidIdentifier(tag) {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid run block tag",
+					Detail:   fmt.Sprintf("The tag %q is not valid. %s", tag, badIdentifierDetail),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+		}
+	}
+
 	return &r, diags
 }
 
+// HasTag returns true if the run block is labelled with the given tag.
+func (run *TestRun) HasTag(tag string) bool {
+	for _, t := range run.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 func decodeTestRunModuleBlock(block *hcl.Block) (*TestRunModuleCall, hcl.Diagnostics) {
 	var diags hcl.Diagnostics
 
@@ -839,6 +1021,16 @@ func decodeOverrideResourceBlock(block *hcl.Block) (*OverrideResource, hcl.Diagn
 		res.Values, diags = v, append(diags, moreDiags...)
 	}
 
+	for _, block := range content.Blocks {
+		if block.Type == blockNameMockGenerator {
+			g, moreDiags := decodeMockGeneratorBlock(block)
+			diags = append(diags, moreDiags...)
+			if !moreDiags.HasErrors() {
+				res.Generators = append(res.Generators, g)
+			}
+		}
+	}
+
 	return res, diags
 }
 
@@ -930,8 +1122,19 @@ func decodeMockProviderBlock(block *hcl.Block) (*MockProvider, hcl.Diagnostics)
 		})
 	}
 
+	if attr, exists := content.Attributes["schema_hints"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &provider.SchemaHints)
+		diags = append(diags, valDiags...)
+	}
+
 	for _, block := range content.Blocks {
 		switch block.Type {
+		case blockNameMockGenerator:
+			g, gDiags := decodeMockGeneratorBlock(block)
+			diags = append(diags, gDiags...)
+			if !gDiags.HasErrors() {
+				provider.Generators = append(provider.Generators, g)
+			}
 		case blockNameMockData, blockNameMockResource:
 			res, resDiags := decodeMockResourceBlock(block)
 			diags = append(diags, resDiags...)
@@ -980,6 +1183,53 @@ func decodeMockResourceBlock(block *hcl.Block) (*MockResource, hcl.Diagnostics)
 	return res, diags
 }
 
+func decodeMockGeneratorBlock(block *hcl.Block) (*MockGenerator, hcl.Diagnostics) {
+	g := &MockGenerator{
+		DeclRange: block.DefRange,
+	}
+
+	content, diags := block.Body.Content(mockGeneratorBlockSchema)
+
+	if attr, exists := content.Attributes["attribute"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &g.Attribute)
+		diags = append(diags, valDiags...)
+
+		if _, err := path.Match(g.Attribute, ""); !valDiags.HasErrors() && err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid attribute pattern",
+				Detail:   fmt.Sprintf("The attribute pattern %q is malformed: %s.", g.Attribute, err),
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	if attr, exists := content.Attributes["kind"]; exists {
+		var raw string
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &raw)
+		diags = append(diags, valDiags...)
+
+		if !valDiags.HasErrors() {
+			kind, ok := hcl2shim.ParseMockGeneratorKind(raw)
+			if !ok {
+				kinds := make([]string, 0, len(hcl2shim.MockGeneratorKinds))
+				for _, k := range hcl2shim.MockGeneratorKinds {
+					kinds = append(kinds, fmt.Sprintf("%q", k))
+				}
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid generator kind",
+					Detail:   fmt.Sprintf("The generator kind must be one of %s.", strings.Join(kinds, ", ")),
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			}
+			g.Kind = kind
+		}
+	}
+
+	return g, diags
+}
+
 func parseObjectAttrWithNoVariables(attr *hcl.Attribute) (map[string]cty.Value, hcl.Diagnostics) {
 	attrVal, valDiags := attr.Expr.Value(nil)
 	diags := valDiags
@@ -1053,6 +1303,11 @@ var testFileSchema = &hcl.BodySchema{
 			Type:       "run",
 			LabelNames: []string{"name"},
 		},
+		{
+			// fuzz block generates input values to check the variable validations.
+			Type:       blockNameFuzz,
+			LabelNames: []string{"name"},
+		},
 		{
 			// provider block specifies the infrastructure provider to use for the test.
 			Type:       "provider",
@@ -1088,6 +1343,13 @@ var testRunBlockSchema = &hcl.BodySchema{
 		{Name: "providers"},
 		// expect_failures indicates whether test failures are expected.
 		{Name: "expect_failures"},
+		{Name: "expect_check_failures"},
+		// timeout limits how long the plan and apply operations may take.
+		{Name: "timeout"},
+		// retries sets how many times failed plan and apply operations are retried.
+		{Name: "retries"},
+		// tags labels the run block for filtering on the command line.
+		{Name: "tags"},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
 		{
@@ -1155,6 +1417,11 @@ var overrideResourceBlockSchema = &hcl.BodySchema{
 			Required: false,
 		},
 	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: blockNameMockGenerator,
+		},
+	},
 }
 
 var overrideModuleBlockSchema = &hcl.BodySchema{
@@ -1180,8 +1447,15 @@ var mockProviderBlockSchema = &hcl.BodySchema{
 			Name:     "for_each",
 			Required: false,
 		},
+		{
+			Name:     "schema_hints",
+			Required: false,
+		},
 	},
 	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type: blockNameMockGenerator,
+		},
 		{
 			Type:       blockNameMockResource,
 			LabelNames: []string{"type"},
@@ -1206,3 +1480,16 @@ var mockResourceBlockSchema = &hcl.BodySchema{
 		},
 	},
 }
+
+var mockGeneratorBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{
+			Name:     "attribute",
+			Required: true,
+		},
+		{
+			Name:     "kind",
+			Required: true,
+		},
+	},
+}
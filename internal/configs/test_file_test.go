@@ -8,11 +8,14 @@ package configs
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hcltest"
+
+	"github.com/opentofu/opentofu/internal/configs/hcl2shim"
 )
 
 func TestTestRun_Validate(t *testing.T) {
@@ -90,6 +93,49 @@ func TestTestRun_Validate(t *testing.T) {
 	}
 }
 
+func TestTestRun_ValidateExpectCheckFailures(t *testing.T) {
+	tcs := map[string]struct {
+		expectedCheckFailures []string
+		diagnostic            string
+	}{
+		"check": {
+			expectedCheckFailures: []string{"check.expected_check"},
+		},
+		"variable": {
+			expectedCheckFailures: []string{"var.expected_var"},
+			diagnostic:            "You cannot expect check failures from var.expected_var. You can only expect check failures from check blocks.",
+		},
+		"resource": {
+			expectedCheckFailures: []string{"test_resource.resource"},
+			diagnostic:            "You cannot expect check failures from test_resource.resource. You can only expect check failures from check blocks.",
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			run := &TestRun{}
+			for _, addr := range tc.expectedCheckFailures {
+				run.ExpectCheckFailures = append(run.ExpectCheckFailures, parseTraversal(t, addr))
+			}
+
+			diags := run.Validate()
+
+			if len(tc.diagnostic) == 0 {
+				if len(diags) != 0 {
+					t.Fatalf("expected no diags but got: %s", diags[0].Description().Detail)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected exactly one diag but got %d", len(diags))
+			}
+			if diff := cmp.Diff(tc.diagnostic, diags[0].Description().Detail); len(diff) > 0 {
+				t.Fatalf("unexpected diff:\n%s", diff)
+			}
+		})
+	}
+}
+
 func parseTraversal(t *testing.T, addr string) hcl.Traversal {
 	t.Helper()
 
@@ -178,3 +224,189 @@ func TestDecodeTestRunModuleBlock(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeMockProviderBlock_generators(t *testing.T) {
+	src := `
+mock_provider "aws" {
+  schema_hints = true
+
+  generate {
+    attribute = "*_arn"
+    kind      = "arn"
+  }
+
+  override_resource {
+    target = aws_vpc.main
+    generate {
+      attribute = "cidr_block"
+      kind      = "cidr"
+    }
+  }
+}
+
+mock_provider "aws" {
+  alias = "broken"
+
+  generate {
+    attribute = "id"
+    kind      = "telephone"
+  }
+}
+`
+	file, hclDiags := hclsyntax.ParseConfig([]byte(src), "main.tftest.hcl", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatalf("unexpected parse errors: %s", hclDiags.Error())
+	}
+
+	tf, diags := loadTestFile(file.Body)
+	if len(diags) != 1 || diags[0].Summary != "Invalid generator kind" {
+		t.Fatalf("expected a single invalid generator kind diagnostic, got: %s", diags.Error())
+	}
+
+	mp, ok := tf.MockProviders["aws"]
+	if !ok {
+		t.Fatalf("mock provider aws was not decoded")
+	}
+	if !mp.SchemaHints {
+		t.Errorf("expected schema hints to be enabled")
+	}
+
+	want := []hcl2shim.MockGeneratorRule{{Pattern: "*_arn", Kind: hcl2shim.MockGenerateARN}}
+	if diff := cmp.Diff(want, MockGeneratorRules(mp.Generators)); diff != "" {
+		t.Errorf("wrong provider generators\n%s", diff)
+	}
+
+	if len(mp.OverrideResources) != 1 {
+		t.Fatalf("expected one override resource, got %d", len(mp.OverrideResources))
+	}
+	want = []hcl2shim.MockGeneratorRule{{Pattern: "cidr_block", Kind: hcl2shim.MockGenerateCIDR}}
+	if diff := cmp.Diff(want, MockGeneratorRules(mp.OverrideResources[0].Generators)); diff != "" {
+		t.Errorf("wrong override generators\n%s", diff)
+	}
+}
+
+func TestDecodeTestRunBlock_timeoutRetriesTags(t *testing.T) {
+	tcs := map[string]struct {
+		src     string
+		want    *TestRun
+		summary string
+	}{
+		"defaults": {
+			src:  `run "test" {}`,
+			want: &TestRun{},
+		},
+		"all set": {
+			src: `
+run "test" {
+  timeout = "1m30s"
+  retries = 2
+  tags    = ["slow", "network"]
+}`,
+			want: &TestRun{Timeout: 90 * time.Second, Retries: 2, Tags: []string{"slow", "network"}},
+		},
+		"invalid timeout": {
+			src:     `run "test" { timeout = "soon" }`,
+			summary: "Invalid \"timeout\" value",
+		},
+		"negative timeout": {
+			src:     `run "test" { timeout = "-5s" }`,
+			summary: "Invalid \"timeout\" value",
+		},
+		"negative retries": {
+			src:     `run "test" { retries = -1 }`,
+			summary: "Invalid \"retries\" value",
+		},
+		"invalid tag": {
+			src:     `run "test" { tags = ["not a tag"] }`,
+			summary: "Invalid run block tag",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			file, hclDiags := hclsyntax.ParseConfig([]byte(tc.src), "main.tftest.hcl", hcl.InitialPos)
+			if hclDiags.HasErrors() {
+				t.Fatalf("unexpected parse errors: %s", hclDiags.Error())
+			}
+
+			tf, diags := loadTestFile(file.Body)
+			if tc.summary != "" {
+				if len(diags) != 1 || diags[0].Summary != tc.summary {
+					t.Fatalf("expected a single %q diagnostic, got: %s", tc.summary, diags.Error())
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatalf("unexpected diagnostics: %s", diags.Error())
+			}
+
+			run := tf.Runs[0]
+			if run.Timeout != tc.want.Timeout {
+				t.Errorf("wrong timeout: got %s, want %s", run.Timeout, tc.want.Timeout)
+			}
+			if run.Retries != tc.want.Retries {
+				t.Errorf("wrong retries: got %d, want %d", run.Retries, tc.want.Retries)
+			}
+			if diff := cmp.Diff(tc.want.Tags, run.Tags); diff != "" {
+				t.Errorf("wrong tags\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeTestFuzzBlock(t *testing.T) {
+	src := `
+fuzz "ports" {
+  iterations   = 50
+  seed         = 7
+  expect_valid = var.port > 0
+
+  generator "port" {
+    min = 0
+    max = 70000
+  }
+
+  generator "env" {
+    values = ["dev", "prod"]
+  }
+}
+
+fuzz "defaults" {}
+
+fuzz "broken" {
+  generator "port" {
+    min = 10
+    max = 1
+  }
+}
+`
+	file, hclDiags := hclsyntax.ParseConfig([]byte(src), "main.tftest.hcl", hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatalf("unexpected parse errors: %s", hclDiags.Error())
+	}
+
+	tf, diags := loadTestFile(file.Body)
+	if len(diags) != 1 || diags[0].Summary != "Invalid generator bounds" {
+		t.Fatalf("expected a single invalid generator bounds diagnostic, got: %s", diags.Error())
+	}
+
+	if len(tf.Fuzzes) != 2 {
+		t.Fatalf("expected two fuzz blocks, got %d", len(tf.Fuzzes))
+	}
+
+	ports := tf.Fuzzes[0]
+	if ports.Iterations != 50 || ports.Seed != 7 || ports.ExpectValid == nil {
+		t.Errorf("wrong fuzz arguments: %#v", ports)
+	}
+	if port := ports.Generators["port"]; port == nil || *port.Min != 0 || *port.Max != 70000 || port.ValuesOnly() {
+		t.Errorf("wrong port generator: %#v", port)
+	}
+	if env := ports.Generators["env"]; env == nil || len(env.Values) != 2 || !env.ValuesOnly() {
+		t.Errorf("wrong env generator: %#v", env)
+	}
+
+	defaults := tf.Fuzzes[1]
+	if defaults.Iterations != defaultFuzzIterations || defaults.Seed == 0 || defaults.ExpectValid != nil {
+		t.Errorf("wrong default fuzz arguments: %#v", defaults)
+	}
+}
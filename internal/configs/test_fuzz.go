@@ -0,0 +1,268 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package configs
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	blockNameFuzz          = "fuzz"
+	blockNameFuzzGenerator = "generator"
+
+	// defaultFuzzIterations is the number of cases generated by a fuzz block
+	// that doesn't set the iterations argument.
+	defaultFuzzIterations = 100
+)
+
+// TestFuzz represents a fuzz block within a test file.
+//
+// A fuzz block generates many values for the input variables of the module
+// under test and checks them against the variable validation rules, without
+// planning or applying the module. Generated values follow the type constraint
+// of each variable unless a generator block narrows them down.
+type TestFuzz struct {
+	Name string
+
+	// Iterations is the number of cases to generate.
+	Iterations int
+
+	// Seed initializes the random source for the generated values. Unless
+	// set explicitly, it is derived from the block name so that the same
+	// cases are generated on every execution.
+	Seed int64
+
+	// Generators customizes the values generated for some of the input
+	// variables, keyed by the variable name.
+	Generators map[string]*TestFuzzGenerator
+
+	// ExpectValid is an optional expression that decides whether a case
+	// should be accepted by the validation rules. When it is nil, the fuzz
+	// block only checks that the validation rules can be evaluated.
+	ExpectValid hcl.Expression
+
+	NameDeclRange hcl.Range
+	DeclRange     hcl.Range
+}
+
+// TestFuzzGenerator narrows down the values generated for an input variable
+// by a fuzz block.
+type TestFuzzGenerator struct {
+	Variable string
+
+	// Values is a list of candidate values. When it is the only argument
+	// set, the generated values are always picked from it. Otherwise, the
+	// candidates are mixed in with the generated values.
+	Values []cty.Value
+
+	// Min and Max bound the generated numbers, including the numbers nested
+	// in collections and objects. Either can be nil.
+	Min, Max *float64
+
+	// MaxLength bounds the length of generated strings and collections. Zero
+	// means that the default length is used.
+	MaxLength int
+
+	// Chars is the set of characters generated strings are made of. Empty
+	// means that the default set is used.
+	Chars string
+
+	DeclRange hcl.Range
+}
+
+// ValuesOnly returns true if the generator only picks from its list of
+// candidate values.
+func (g *TestFuzzGenerator) ValuesOnly() bool {
+	return len(g.Values) > 0 && g.Min == nil && g.Max == nil && g.MaxLength == 0 && g.Chars == ""
+}
+
+func decodeTestFuzzBlock(block *hcl.Block) (*TestFuzz, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, contentDiags := block.Body.Content(testFuzzBlockSchema)
+	diags = append(diags, contentDiags...)
+
+	fuzz := TestFuzz{
+		Name:          block.Labels[0],
+		Iterations:    defaultFuzzIterations,
+		Generators:    make(map[string]*TestFuzzGenerator),
+		NameDeclRange: block.LabelRanges[0],
+		DeclRange:     block.DefRange,
+	}
+
+	if !hclsyntax.ValidIdentifier(fuzz.Name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid fuzz block name",
+			Detail:   badIdentifierDetail,
+			Subject:  &block.LabelRanges[0],
+		})
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(fuzz.Name))
+	fuzz.Seed = int64(h.Sum64())
+
+	if attr, exists := content.Attributes["iterations"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &fuzz.Iterations)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && fuzz.Iterations < 1 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"iterations\" value",
+				Detail:   "The \"iterations\" argument must be at least 1.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	if attr, exists := content.Attributes["seed"]; exists {
+		diags = append(diags, gohcl.DecodeExpression(attr.Expr, nil, &fuzz.Seed)...)
+	}
+
+	if attr, exists := content.Attributes["expect_valid"]; exists {
+		fuzz.ExpectValid = attr.Expr
+	}
+
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case blockNameFuzzGenerator:
+			generator, generatorDiags := decodeTestFuzzGeneratorBlock(block)
+			diags = append(diags, generatorDiags...)
+			if generatorDiags.HasErrors() {
+				continue
+			}
+
+			if existing, exists := fuzz.Generators[generator.Variable]; exists {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Duplicate generator block",
+					Detail:   fmt.Sprintf("A generator for variable %q was already defined at %s.", generator.Variable, existing.DeclRange),
+					Subject:  generator.DeclRange.Ptr(),
+				})
+				continue
+			}
+			fuzz.Generators[generator.Variable] = generator
+		}
+	}
+
+	return &fuzz, diags
+}
+
+func decodeTestFuzzGeneratorBlock(block *hcl.Block) (*TestFuzzGenerator, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	content, contentDiags := block.Body.Content(testFuzzGeneratorBlockSchema)
+	diags = append(diags, contentDiags...)
+
+	generator := TestFuzzGenerator{
+		Variable:  block.Labels[0],
+		DeclRange: block.DefRange,
+	}
+
+	if attr, exists := content.Attributes["values"]; exists {
+		val, valDiags := attr.Expr.Value(nil)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() {
+			if (!val.Type().IsListType() && !val.Type().IsTupleType() && !val.Type().IsSetType()) || val.IsNull() || !val.IsWhollyKnown() || val.LengthInt() == 0 {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid \"values\" argument",
+					Detail:   "The \"values\" argument requires a non-empty list of static values.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				for it := val.ElementIterator(); it.Next(); {
+					_, v := it.Element()
+					generator.Values = append(generator.Values, v)
+				}
+			}
+		}
+	}
+
+	decodeBound := func(name string) *float64 {
+		attr, exists := content.Attributes[name]
+		if !exists {
+			return nil
+		}
+		var bound float64
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &bound)
+		diags = append(diags, valDiags...)
+		if valDiags.HasErrors() {
+			return nil
+		}
+		return &bound
+	}
+	generator.Min = decodeBound("min")
+	generator.Max = decodeBound("max")
+
+	if generator.Min != nil && generator.Max != nil && *generator.Min > *generator.Max {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid generator bounds",
+			Detail:   "The \"min\" argument must not be greater than the \"max\" argument.",
+			Subject:  block.DefRange.Ptr(),
+		})
+	}
+
+	if attr, exists := content.Attributes["max_length"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &generator.MaxLength)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && generator.MaxLength < 1 {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"max_length\" value",
+				Detail:   "The \"max_length\" argument must be at least 1.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	if attr, exists := content.Attributes["chars"]; exists {
+		valDiags := gohcl.DecodeExpression(attr.Expr, nil, &generator.Chars)
+		diags = append(diags, valDiags...)
+		if !valDiags.HasErrors() && generator.Chars == "" {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid \"chars\" value",
+				Detail:   "The \"chars\" argument must contain at least one character.",
+				Subject:  attr.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	return &generator, diags
+}
+
+var testFuzzBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "iterations"},
+		{Name: "seed"},
+		{Name: "expect_valid"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{
+		{
+			Type:       blockNameFuzzGenerator,
+			LabelNames: []string{"variable"},
+		},
+	},
+}
+
+var testFuzzGeneratorBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "values"},
+		{Name: "min"},
+		{Name: "max"},
+		{Name: "max_length"},
+		{Name: "chars"},
+	},
+}
@@ -0,0 +1,14 @@
+# The definition of this type comes from the root module, and exporting it
+# again makes it available to the grandchild module too.
+type "server" {
+  export = true
+}
+
+variable "servers" {
+  type    = list(server)
+  default = [{ name = "a" }]
+}
+
+module "grandchild" {
+  source = "./grandchild"
+}
@@ -0,0 +1,13 @@
+type "server" {
+}
+
+type "internal" {
+}
+
+variable "server" {
+  type = server
+}
+
+variable "internal" {
+  type = internal
+}
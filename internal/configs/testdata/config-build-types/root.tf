@@ -0,0 +1,15 @@
+type "server" {
+  definition = object({
+    name = string
+    port = optional(number, 80)
+  })
+  export = true
+}
+
+type "internal" {
+  definition = string
+}
+
+module "child" {
+  source = "./child"
+}
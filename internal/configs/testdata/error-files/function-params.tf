@@ -0,0 +1,18 @@
+function "params" {
+  parameter "a" {
+    type = string
+  }
+  parameter "a" { # ERROR: Duplicate function parameter
+    type = number
+  }
+  variadic_parameter "rest" {
+  }
+  variadic_parameter "more" { # ERROR: Duplicate variadic parameter
+  }
+  parameter "late" { # ERROR: Parameter after variadic parameter
+  }
+  result = a
+}
+
+function "no-result" { # ERROR: Missing required argument
+}
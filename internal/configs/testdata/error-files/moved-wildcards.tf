@@ -0,0 +1,4 @@
+moved { # ERROR: Invalid "moved" addresses
+  from = module.a[*].test.foo
+  to   = test.foo
+}
@@ -0,0 +1,8 @@
+resource "aws_instance" "web" {
+  locals {
+    name = "a"
+  }
+  locals {
+    name = "b" # ERROR: Duplicate local value definition
+  }
+}
@@ -0,0 +1,9 @@
+output "a" {
+  value = "a"
+  type  = list(string, number) # ERROR: Invalid type specification
+
+  validation {
+    condition     = var.a != "" # ERROR: Invalid output value validation condition
+    error_message = "Must not be empty."
+  }
+}
@@ -0,0 +1,17 @@
+terraform {
+  profile "both" {
+    targets  = ["module.network"]
+    excludes = ["module.database"] # ERROR: Invalid profile
+  }
+
+  profile "mixed-wildcard" {
+    targets = ["module.app[*].aws_instance.web[0]"] # ERROR: Invalid profile address
+  }
+
+  profile "not-a-list" {
+    excludes = "module.database" # ERROR: Invalid profile addresses
+  }
+
+  profile "1nvalid" { # ERROR: Invalid profile name
+  }
+}
@@ -0,0 +1,7 @@
+type "string" { # ERROR: Invalid type name
+  definition = object({})
+}
+
+type "bad" {
+  definition = list(string, number) # ERROR: Invalid type specification
+}
@@ -1,3 +0,0 @@
-data "test" "foo" {
-  locals {}
-}
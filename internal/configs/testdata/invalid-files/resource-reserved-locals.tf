@@ -1,3 +0,0 @@
-resource "test" "foo" {
-  locals {}
-}
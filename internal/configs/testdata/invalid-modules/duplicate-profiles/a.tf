@@ -0,0 +1,5 @@
+terraform {
+  profile "networking" {
+    targets = ["module.network"]
+  }
+}
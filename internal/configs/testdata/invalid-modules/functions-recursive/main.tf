@@ -0,0 +1,13 @@
+function "a" {
+  parameter "n" {
+    type = number
+  }
+  result = n <= 0 ? 0 : module::b(n - 1)
+}
+
+function "b" {
+  parameter "n" {
+    type = number
+  }
+  result = module::a(n)
+}
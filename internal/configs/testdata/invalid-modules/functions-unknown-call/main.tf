@@ -0,0 +1,3 @@
+function "a" {
+  result = module::missing()
+}
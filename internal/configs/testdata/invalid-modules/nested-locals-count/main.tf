@@ -0,0 +1,7 @@
+resource "aws_instance" "web" {
+  count = local.replicas
+
+  locals {
+    replicas = 2
+  }
+}
@@ -0,0 +1,11 @@
+locals {
+  name = "module"
+}
+
+resource "aws_instance" "web" {
+  locals {
+    name = "block"
+  }
+
+  tags = { Name = local.name }
+}
@@ -0,0 +1,7 @@
+type "a" {
+  definition = object({ b = b })
+}
+
+type "b" {
+  definition = list(a)
+}
@@ -0,0 +1,3 @@
+type "port" {
+  definition = number
+}
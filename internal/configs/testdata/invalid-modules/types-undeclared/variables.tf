@@ -0,0 +1,3 @@
+variable "servers" {
+  type = list(server)
+}
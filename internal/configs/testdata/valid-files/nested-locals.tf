@@ -0,0 +1,32 @@
+resource "aws_instance" "web" {
+  for_each = var.instances
+
+  locals {
+    name = "web-${each.key}"
+    tags = merge(var.tags, { Name = local.name })
+  }
+
+  ami  = each.value.ami
+  tags = local.tags
+}
+
+data "aws_ami" "base" {
+  count = 2
+
+  locals {
+    suffix = count.index
+  }
+
+  name = "base-${local.suffix}"
+}
+
+module "child" {
+  source   = "./child"
+  for_each = var.instances
+
+  locals {
+    prefix = each.key
+  }
+
+  name = local.prefix
+}
@@ -0,0 +1,16 @@
+terraform {
+  profile "networking" {
+    targets = [
+      "module.network",
+      "aws_vpc.main",
+    ]
+  }
+
+  profile "no_databases" {
+    excludes = [
+      "module.database[*]",
+      "module.app[*].aws_db_instance.main",
+      "aws_db_instance.legacy[*]",
+    ]
+  }
+}
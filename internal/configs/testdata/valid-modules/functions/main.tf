@@ -0,0 +1,33 @@
+function "resource_name" {
+  description = "Builds a name for a resource from its parts."
+
+  parameter "prefix" {
+    type        = string
+    description = "The common prefix for all names."
+  }
+
+  variadic_parameter "parts" {
+    type = string
+  }
+
+  result = join("-", concat([prefix], parts))
+}
+
+function "tags" {
+  parameter "base" {
+    type = object({
+      env   = string
+      owner = optional(string, "platform")
+    })
+  }
+
+  result = merge(base, { name = module::resource_name(base.env, base.owner) })
+}
+
+locals {
+  tags = module::tags({ env = "prod" })
+}
+
+output "tags" {
+  value = local.tags
+}
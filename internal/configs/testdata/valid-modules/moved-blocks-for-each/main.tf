@@ -0,0 +1,19 @@
+locals {
+  names = toset(["a", "b"])
+}
+
+moved {
+  for_each = local.names
+  from     = test.foo[each.key]
+  to       = module.b[each.key].test.foo
+}
+
+moved {
+  from = test.bar[*]
+  to   = module.c.test.bar[*]
+}
+
+moved {
+  from = module.x[*].test.baz
+  to   = module.y[*].test.baz
+}
@@ -0,0 +1,9 @@
+terraform {
+  profile "networking" {
+    targets = ["module.network"]
+  }
+
+  profile "no_databases" {
+    excludes = ["module.database[*]"]
+  }
+}
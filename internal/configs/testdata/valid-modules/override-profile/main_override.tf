@@ -0,0 +1,5 @@
+terraform {
+  profile "networking" {
+    excludes = ["module.app[*].aws_instance.web"]
+  }
+}
@@ -0,0 +1,24 @@
+locals {
+  instances = {
+    first  = "a"
+    second = "b"
+  }
+}
+
+removed {
+  for_each = local.instances
+  from     = test.foo[each.value]
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+removed {
+  for_each = toset(["x"])
+  from     = module.child[each.key]
+
+  lifecycle {
+    destroy = true
+  }
+}
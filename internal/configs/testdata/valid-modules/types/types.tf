@@ -0,0 +1,12 @@
+type "server" {
+  description = "A server to register with the load balancer."
+  definition = object({
+    name = string
+    port = optional(number, 80)
+  })
+}
+
+type "servers" {
+  definition = list(server)
+  export     = true
+}
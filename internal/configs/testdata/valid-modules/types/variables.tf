@@ -0,0 +1,29 @@
+variable "primary" {
+  type = server
+  default = {
+    name = "primary"
+  }
+}
+
+variable "backends" {
+  type = servers
+  default = [
+    { name = "a" },
+    { name = "b", port = 8080 },
+  ]
+}
+
+variable "by_zone" {
+  type    = map(object({ servers = servers, weight = optional(number, 1) }))
+  default = {}
+}
+
+output "primary" {
+  type  = server
+  value = var.primary
+
+  validation {
+    condition     = self.port > 0
+    error_message = "The primary server must have a valid port."
+  }
+}
@@ -0,0 +1,49 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getmodules
+
+import (
+	"context"
+)
+
+// FetchEvents is a collection of function references that a
+// [PackageFetcher] will call, if set, to report details about how a
+// package was fetched.
+//
+// Use [FetchEvents.OnContext] to attach the events to the context passed
+// to [PackageFetcher.FetchPackage].
+type FetchEvents struct {
+	// OCISignatureVerified is called when a package fetched from an OCI
+	// repository had a cosign signature that was accepted by the applicable
+	// trust policy. signer is a UI-oriented description of who made the
+	// signature.
+	OCISignatureVerified func(signer string)
+}
+
+// OnContext produces a context with all of the same behaviors as the given
+// context except that it will additionally carry the receiving FetchEvents.
+func (e *FetchEvents) OnContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxFetchEvents, e)
+}
+
+// fetchEventsForContext looks on the given context for a registered
+// FetchEvents and returns a pointer to it if so.
+//
+// For caller convenience, if there is no events object attached to the
+// given context this function will construct one that has all of its
+// fields set to nil and return that, freeing the caller from having to
+// do a nil check on the result before dereferencing it.
+func fetchEventsForContext(ctx context.Context) *FetchEvents {
+	v := ctx.Value(ctxFetchEvents)
+	if v != nil {
+		return v.(*FetchEvents)
+	}
+	return &FetchEvents{}
+}
+
+type ctxFetchEventsType int
+
+const ctxFetchEvents = ctxFetchEventsType(0)
@@ -126,7 +126,8 @@ type reusingGetter struct {
 	getters map[string]getter.Getter
 
 	previousInstalls   map[string]string // initialized on first install request
-	previousInstallsMu sync.Mutex        // must hold while interacting with previousInstalls
+	previousSigners    map[string]string // signers of previous installs that had verified signatures
+	previousInstallsMu sync.Mutex        // must hold while interacting with previousInstalls and previousSigners
 }
 
 func newReusingGetter(getters map[string]getter.Getter) *reusingGetter {
@@ -176,7 +177,9 @@ func (g *reusingGetter) getWithGoGetter(ctx context.Context, instPath, packageAd
 	defer g.previousInstallsMu.Unlock()
 	if g.previousInstalls == nil {
 		g.previousInstalls = make(map[string]string)
+		g.previousSigners = make(map[string]string)
 	}
+	evts := fetchEventsForContext(ctx)
 
 	if prevDir, exists := g.previousInstalls[packageAddr]; exists {
 		log.Printf("[TRACE] getmodules: copying previous install of %q from %s to %s", packageAddr, prevDir, instPath)
@@ -188,6 +191,11 @@ func (g *reusingGetter) getWithGoGetter(ctx context.Context, instPath, packageAd
 		if err != nil {
 			return fmt.Errorf("failed to copy from %s to %s: %w", prevDir, instPath, err)
 		}
+		// The copy is of a package whose signature we already verified, so
+		// we report that again for this installation too.
+		if signer, ok := g.previousSigners[packageAddr]; ok && evts.OCISignatureVerified != nil {
+			evts.OCISignatureVerified(signer)
+		}
 	} else {
 		log.Printf("[TRACE] getmodules: fetching %q to %q", packageAddr, instPath)
 		client := getter.Client{
@@ -200,7 +208,14 @@ func (g *reusingGetter) getWithGoGetter(ctx context.Context, instPath, packageAd
 			Detectors:     goGetterNoDetectors, // our caller should've already done detection
 			Decompressors: goGetterDecompressors,
 			Getters:       g.getters,
-			Ctx:           ctx,
+			Ctx: (&FetchEvents{
+				OCISignatureVerified: func(signer string) {
+					g.previousSigners[packageAddr] = signer
+					if evts.OCISignatureVerified != nil {
+						evts.OCISignatureVerified(signer)
+					}
+				},
+			}).OnContext(ctx),
 		}
 		err = client.Get()
 		if err != nil {
@@ -13,6 +13,7 @@ import (
 	getter "github.com/hashicorp/go-getter"
 
 	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 	"github.com/opentofu/opentofu/internal/tracing"
 	"github.com/opentofu/opentofu/internal/tracing/traceattrs"
 )
@@ -56,6 +57,7 @@ func NewPackageFetcher(ctx context.Context, env PackageFetcherEnvironment) *Pack
 	// centrally-configured policy, encapsulated in env.OCIRepositoryStore.
 	getters["oci"] = &ociDistributionGetter{
 		getOCIRepositoryStore: env.OCIRepositoryStore,
+		getOCISignaturePolicy: env.OCISignaturePolicy,
 	}
 
 	// The HTTP getter (used for both "http" and "https" schemes) uses
@@ -112,6 +114,11 @@ func (f *PackageFetcher) FetchPackage(ctx context.Context, instDir string, packa
 // concerns is still the best design for that different context.
 type PackageFetcherEnvironment interface {
 	OCIRepositoryStore(ctx context.Context, registryDomainName, repositoryPath string) (OCIRepositoryStore, error)
+
+	// OCISignaturePolicy returns the cosign signature trust policy that
+	// applies to the given repository, or nil if packages from that
+	// repository do not need to be signed.
+	OCISignaturePolicy(ctx context.Context, registryDomainName, repositoryPath string) (*cosign.Policy, error)
 }
 
 // preparePackageFetcherEnvironment takes a [PackageFetcherEnvironment]
@@ -136,3 +143,8 @@ type noopPackageFetcherEnvironment struct{}
 func (n noopPackageFetcherEnvironment) OCIRepositoryStore(ctx context.Context, registryDomainName string, repositoryPath string) (OCIRepositoryStore, error) {
 	return nil, fmt.Errorf("module installation from OCI repositories is not available in this context")
 }
+
+// OCISignaturePolicy implements PackageFetcherEnvironment.
+func (n noopPackageFetcherEnvironment) OCISignaturePolicy(ctx context.Context, registryDomainName string, repositoryPath string) (*cosign.Policy, error) {
+	return nil, nil
+}
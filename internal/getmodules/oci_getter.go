@@ -20,6 +20,7 @@ import (
 	orasContent "oras.land/oras-go/v2/content"
 	orasRegistry "oras.land/oras-go/v2/registry"
 
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 	"github.com/opentofu/opentofu/internal/tracing"
 	"github.com/opentofu/opentofu/internal/tracing/traceattrs"
 )
@@ -57,6 +58,10 @@ var ociBlobMediaTypePreference = []string{
 type ociDistributionGetter struct {
 	getOCIRepositoryStore func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryStore, error)
 
+	// getOCISignaturePolicy returns the cosign signature trust policy for
+	// the given repository, or nil if signatures are not required for it.
+	getOCISignaturePolicy func(ctx context.Context, registryDomain, repositoryName string) (*cosign.Policy, error)
+
 	// go-getter sets this by calling our SetClient method whenever
 	// the client is configured, which happens automatically
 	// when it Get method is called.
@@ -94,6 +99,11 @@ func (g *ociDistributionGetter) Get(destDir string, url *url.URL) error {
 		tracing.SetSpanError(span, err)
 		return err
 	}
+	err = g.verifySignature(ctx, ref, manifestDesc, store)
+	if err != nil {
+		tracing.SetSpanError(span, err)
+		return err
+	}
 	manifest, err := fetchOCIImageManifest(ctx, manifestDesc, store)
 	if err != nil {
 		tracing.SetSpanError(span, err)
@@ -175,6 +185,30 @@ func (g *ociDistributionGetter) context() context.Context {
 	return g.client.Ctx
 }
 
+// verifySignature checks the given manifest descriptor against the cosign
+// signature trust policy for the given repository, if any, and reports the
+// signer through the [FetchEvents] on the given context if successful.
+func (g *ociDistributionGetter) verifySignature(ctx context.Context, ref *orasRegistry.Reference, manifestDesc ociv1.Descriptor, store OCIRepositoryStore) error {
+	if g.getOCISignaturePolicy == nil {
+		return nil
+	}
+	policy, err := g.getOCISignaturePolicy(ctx, ref.Registry, ref.Repository)
+	if err != nil {
+		return fmt.Errorf("loading signature policy for %s/%s: %w", ref.Registry, ref.Repository, err)
+	}
+	if policy == nil {
+		return nil
+	}
+	signer, err := cosign.Verify(ctx, store, manifestDesc, policy)
+	if err != nil {
+		return fmt.Errorf("failed to verify cosign signature for %s/%s: %w", ref.Registry, ref.Repository, err)
+	}
+	if cb := fetchEventsForContext(ctx).OCISignatureVerified; cb != nil {
+		cb(signer)
+	}
+	return nil
+}
+
 func (g *ociDistributionGetter) resolveRepositoryRef(url *url.URL) (*orasRegistry.Reference, error) {
 	if !url.IsAbs() {
 		// Should not get here, but just for robustness since go-getter
@@ -9,11 +9,18 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-getter"
@@ -22,6 +29,8 @@ import (
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	orasContent "oras.land/oras-go/v2/content"
 	orasMemoryStore "oras.land/oras-go/v2/content/memory"
+
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 )
 
 func TestGetterDecompressorsConsistent(t *testing.T) {
@@ -237,6 +246,123 @@ func TestOCIDistributionGetter(t *testing.T) {
 
 }
 
+func TestOCIDistributionGetter_signatures(t *testing.T) {
+	store := digestResolvingInMemoryOCIStore{
+		orasMemoryStore.New(),
+	}
+	blobDesc := ociPushFakeModulePackageBlob(t, "content of signed", store)
+	manifestDesc := ociPushFakeImageManifest(t, blobDesc, ociIndexManifestArtifactType, store)
+	ociCreateTag(t, "latest", manifestDesc, store)
+
+	// We sign the manifest using cosign's tag-based scheme, since the
+	// in-memory store doesn't support the referrers API.
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]any{"docker-reference": "example.com/signed"},
+			"image":    map[string]any{"docker-manifest-digest": manifestDesc.Digest.String()},
+			"type":     cosign.PayloadType,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadDigest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, signingKey, payloadDigest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigLayerDesc := ociv1.Descriptor{
+		MediaType: cosign.SimpleSigningMediaType,
+		Digest:    ociDigest.FromBytes(payload),
+		Size:      int64(len(payload)),
+	}
+	err = store.Push(t.Context(), sigLayerDesc, bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigLayerDesc.Annotations = map[string]string{
+		cosign.SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+	}
+	sigManifestDesc := ociPushFakeImageManifest(t, sigLayerDesc, cosign.SignatureArtifactType, store)
+	ociCreateTag(t, cosign.SignatureTag(manifestDesc), sigManifestDesc, store)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		policy     *cosign.Policy
+		wantSigner string
+		wantError  string
+	}{
+		"trusted": {
+			policy:     &cosign.Policy{PublicKeys: []crypto.PublicKey{signingKey.Public()}},
+			wantSigner: "key " + cosign.KeyID(signingKey.Public()),
+		},
+		"untrusted": {
+			policy:    &cosign.Policy{PublicKeys: []crypto.PublicKey{otherKey.Public()}},
+			wantError: "failed to verify cosign signature for example.com/signed",
+		},
+		"no policy": {
+			policy: nil,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ociGetter := &ociDistributionGetter{
+				getOCIRepositoryStore: func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryStore, error) {
+					return store, nil
+				},
+				getOCISignaturePolicy: func(ctx context.Context, registryDomain, repositoryName string) (*cosign.Policy, error) {
+					return test.policy, nil
+				},
+			}
+			var gotSigner string
+			ctx := (&FetchEvents{
+				OCISignatureVerified: func(signer string) {
+					gotSigner = signer
+				},
+			}).OnContext(t.Context())
+			instPath := t.TempDir()
+			client := getter.Client{
+				Src: "oci://example.com/signed",
+				Dst: instPath,
+				Pwd: instPath,
+
+				Mode: getter.ClientModeDir,
+
+				Detectors: goGetterNoDetectors,
+				Getters: map[string]getter.Getter{
+					"oci": ociGetter,
+				},
+				Ctx: ctx,
+			}
+			err := client.Get()
+
+			if test.wantError != "" {
+				if err == nil {
+					t.Fatalf("unexpected success\nwant error containing: %s", test.wantError)
+				}
+				if got := err.Error(); !strings.Contains(got, test.wantError) {
+					t.Fatalf("unexpected error\ngot:  %s\nwant error containing: %s", got, test.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotSigner != test.wantSigner {
+				t.Errorf("wrong signer reported\ngot:  %q\nwant: %q", gotSigner, test.wantSigner)
+			}
+		})
+	}
+}
+
 func ociPushFakeModulePackageBlob(t *testing.T, fakeContent string, store orasContent.Pusher) ociv1.Descriptor {
 	t.Helper()
 
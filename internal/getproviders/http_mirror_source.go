@@ -211,9 +211,13 @@ func (s *HTTPMirrorSource) PackageMeta(ctx context.Context, provider addrs.Provi
 		Version:        version,
 		TargetPlatform: target,
 
-		Location: PackageHTTPURL{URL: absURL.String(), ClientBuilder: func(ctx context.Context) *retryablehttp.Client {
-			return packageHTTPUrlClientWithRetry(ctx, s.locationConfig.ProviderDownloadRetries)
-		}},
+		Location: PackageHTTPURL{
+			URL: absURL.String(),
+			ClientBuilder: func(ctx context.Context) *retryablehttp.Client {
+				return packageHTTPUrlClient(ctx, s.locationConfig)
+			},
+			BandwidthLimit: s.locationConfig.ProviderDownloadBandwidthLimit,
+		},
 		Filename: path.Base(absURL.Path),
 	}
 	// A network mirror might not provide any hashes at all, in which case
@@ -14,6 +14,18 @@ type LocationConfig struct {
 	// http client to retry when a 5xx retryable error occurs.
 	ProviderDownloadRetries int
 
+	// ProviderDownloadRetryWaitMin and ProviderDownloadRetryWaitMax bound
+	// the exponential backoff between retries of a [PackageHTTPURL]
+	// download, including retries that resume an interrupted download.
+	// Zero selects the default of the underlying HTTP client.
+	ProviderDownloadRetryWaitMin time.Duration
+	ProviderDownloadRetryWaitMax time.Duration
+
+	// ProviderDownloadBandwidthLimit, if greater than zero, is the maximum
+	// number of bytes per second to transfer while downloading each
+	// [PackageHTTPURL] package.
+	ProviderDownloadBandwidthLimit int64
+
 	// TODO - use this when we'll introduce per installation method configuration
 	ProviderDownloadTimeout time.Duration
 
@@ -11,8 +11,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/apparentlymart/go-versions/versions"
 	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
@@ -20,6 +22,7 @@ import (
 	orasRegistryErrors "oras.land/oras-go/v2/registry/remote/errcode"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 	"github.com/opentofu/opentofu/internal/tracing"
 	"github.com/opentofu/opentofu/internal/tracing/traceattrs"
 )
@@ -132,6 +135,16 @@ type OCIRegistryMirrorSource struct {
 	// OCI registry".
 	getOCIRepositoryStore func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryStore, error)
 
+	// getOCISignaturePolicy is the dependency inversion adapter for
+	// finding the cosign signature trust policy that applies to the given
+	// repository, if any.
+	//
+	// If this is nil, or if it returns a nil policy, then signatures are
+	// not required and not checked. Otherwise, PackageMeta fails unless
+	// the index manifest for the requested version has a cosign signature
+	// that the policy trusts.
+	getOCISignaturePolicy func(registryDomain, repositoryName string) (*cosign.Policy, error)
+
 	// We keep an internal cache of the most-recently-instantiated
 	// repository store object because in the common case there will
 	// be call to AvailableVersions immediately followed by
@@ -150,10 +163,12 @@ func NewOCIRegistryMirrorSource(
 	_ context.Context,
 	resolveRepositoryAddr func(addr addrs.Provider) (registryDomain, repositoryName string, err error),
 	getRepositoryStore func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryStore, error),
+	getSignaturePolicy func(registryDomain, repositoryName string) (*cosign.Policy, error),
 ) *OCIRegistryMirrorSource {
 	return &OCIRegistryMirrorSource{
 		resolveOCIRepositoryAddr: resolveRepositoryAddr,
 		getOCIRepositoryStore:    getRepositoryStore,
+		getOCISignaturePolicy:    getSignaturePolicy,
 	}
 }
 
@@ -313,6 +328,10 @@ func (o *OCIRegistryMirrorSource) PackageMeta(ctx context.Context, provider addr
 	if err != nil {
 		return PackageMeta{}, err
 	}
+	signer, err := o.verifySignature(ctx, store, registryDomain, repositoryName, indexDesc)
+	if err != nil {
+		return PackageMeta{}, err
+	}
 	index, err := fetchOCIIndexManifest(ctx, indexDesc, store) // step 2
 	if err != nil {
 		return PackageMeta{}, err
@@ -344,13 +363,34 @@ func (o *OCIRegistryMirrorSource) PackageMeta(ctx context.Context, provider addr
 		return PackageMeta{}, err
 	}
 	authentication := NewPackageHashAuthentication(target, []Hash{expectedHash}, false)
+	if signer != "" {
+		// The signature covers the index manifest, which covers the image
+		// manifest and therefore the blob digest that the hash authentication
+		// above will check, and so together they authenticate the package.
+		authentication = PackageAuthenticationAll(authentication, newOCISignatureAuthentication(signer))
+	}
 
 	// If we got through all of the above then we seem to have found a suitable
 	// package to install, but our job is only to describe its metadata.
+	// The standard "created" annotation on the index manifest, if present,
+	// tells us when this version was released. Whoever pushed the manifest
+	// chose that value, and the signature check above doesn't make it any
+	// more truthful, so the release time is only as trustworthy as the
+	// publisher of the repository.
+	var releaseTime time.Time
+	if created, ok := index.Annotations[ociv1.AnnotationCreated]; ok {
+		releaseTime, err = time.Parse(time.RFC3339, created)
+		if err != nil {
+			log.Printf("[WARN] Ignoring invalid %s annotation %q on index manifest for %s v%s: %s", ociv1.AnnotationCreated, created, provider, version, err)
+			releaseTime = time.Time{}
+		}
+	}
+
 	return PackageMeta{
 		Provider:       provider,
 		Version:        version,
 		TargetPlatform: target,
+		ReleaseTime:    releaseTime,
 		Location: PackageOCIBlobArchive{
 			repoStore:      store,
 			blobDescriptor: blobDesc,
@@ -377,6 +417,28 @@ func (o *OCIRegistryMirrorSource) PackageMeta(ctx context.Context, provider addr
 	}, nil
 }
 
+// verifySignature checks the given index manifest descriptor against the
+// cosign signature trust policy for the given repository, if any.
+//
+// It returns an empty signer and no error if no policy applies.
+func (o *OCIRegistryMirrorSource) verifySignature(ctx context.Context, store OCIRepositoryStore, registryDomain, repositoryName string, indexDesc ociv1.Descriptor) (signer string, err error) {
+	if o.getOCISignaturePolicy == nil {
+		return "", nil
+	}
+	policy, err := o.getOCISignaturePolicy(registryDomain, repositoryName)
+	if err != nil {
+		return "", fmt.Errorf("loading OCI signature policy: %w", err)
+	}
+	if policy == nil {
+		return "", nil
+	}
+	signer, err = cosign.Verify(ctx, store, indexDesc, policy)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify cosign signature for %s/%s: %w", registryDomain, repositoryName, err)
+	}
+	return signer, nil
+}
+
 // ForDisplay implements Source.
 func (o *OCIRegistryMirrorSource) ForDisplay(provider addrs.Provider) string {
 	// We don't really have a good concise way to differentiate between
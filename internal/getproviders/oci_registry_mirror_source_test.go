@@ -8,6 +8,12 @@ package getproviders
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -27,6 +33,7 @@ import (
 	orasRegistryErrors "oras.land/oras-go/v2/registry/remote/errcode"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 )
 
 func TestOCIRegistryMirrorSource(t *testing.T) {
@@ -320,6 +327,136 @@ func TestOCIRegistryMirrorSource(t *testing.T) {
 	})
 }
 
+func TestOCIRegistryMirrorSource_signatures(t *testing.T) {
+	store, err := orasOCI.NewWithContext(t.Context(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	platform := Platform{OS: "amigaos", Arch: "m86k"}
+	fakePackageBytes := makePlaceholderProviderPackageZip(t, "placeholder executable")
+	packageDesc := pushOCIBlob(t, "archive/zip", "", fakePackageBytes, store)
+	manifestDesc := pushOCIImageManifest(t, &ociv1.Manifest{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.opentofu.provider-target",
+		Config:       ociv1.DescriptorEmptyJSON,
+		Layers:       []ociv1.Descriptor{packageDesc},
+	}, store)
+	manifestDesc.Platform = &ociv1.Platform{Architecture: platform.Arch, OS: platform.OS}
+	indexDesc := pushOCIIndexManifest(t, &ociv1.Index{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageIndex,
+		ArtifactType: "application/vnd.opentofu.provider",
+		Manifests:    []ociv1.Descriptor{manifestDesc},
+	}, store)
+	createOCITag(t, "1.0.0", indexDesc, store)
+	pushOCIBlob(t, ociv1.DescriptorEmptyJSON.MediaType, ociv1.DescriptorEmptyJSON.ArtifactType, ociv1.DescriptorEmptyJSON.Data, store)
+
+	// We sign the index manifest using cosign's tag-based scheme, since the
+	// local OCI layout store doesn't support the referrers API.
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]any{"docker-reference": "example.com/foo_bar"},
+			"image":    map[string]any{"docker-manifest-digest": indexDesc.Digest.String()},
+			"type":     cosign.PayloadType,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadDigest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, signingKey, payloadDigest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigLayerDesc := pushOCIBlob(t, cosign.SimpleSigningMediaType, "", payload, store)
+	sigLayerDesc.Annotations = map[string]string{
+		cosign.SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+	}
+	sigManifestDesc := pushOCIImageManifest(t, &ociv1.Manifest{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: cosign.SignatureArtifactType,
+		Config:       ociv1.DescriptorEmptyJSON,
+		Layers:       []ociv1.Descriptor{sigLayerDesc},
+	}, store)
+	createOCITag(t, cosign.SignatureTag(indexDesc), sigManifestDesc, store)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fakeProvider := addrs.MustParseProviderSourceString("example.com/foo/bar")
+	makeSource := func(policy *cosign.Policy) *OCIRegistryMirrorSource {
+		return NewOCIRegistryMirrorSource(
+			t.Context(),
+			func(addr addrs.Provider) (registryDomain string, repositoryName string, err error) {
+				return "example.com", fmt.Sprintf("%s_%s", addr.Namespace, addr.Type), nil
+			},
+			func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryStore, error) {
+				return store, nil
+			},
+			func(registryDomain, repositoryName string) (*cosign.Policy, error) {
+				if registryDomain != "example.com" || repositoryName != "foo_bar" {
+					t.Errorf("policy requested for wrong repository %s/%s", registryDomain, repositoryName)
+				}
+				return policy, nil
+			},
+		)
+	}
+
+	t.Run("trusted signature", func(t *testing.T) {
+		source := makeSource(&cosign.Policy{PublicKeys: []crypto.PublicKey{signingKey.Public()}})
+		meta, err := source.PackageMeta(t.Context(), fakeProvider, MustParseVersion("1.0.0"), platform)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loc := meta.Location.(PackageOCIBlobArchive)
+		authResult, err := loc.InstallProviderPackage(t.Context(), meta, t.TempDir(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := authResult.summaryResult(), signed; got != want {
+			t.Errorf("wrong authentication result\ngot:  %#v\nwant: %#v", got, want)
+		}
+		if got, want := authResult.OCISignersString(), "key "+cosign.KeyID(signingKey.Public()); got != want {
+			t.Errorf("wrong signers\ngot:  %s\nwant: %s", got, want)
+		}
+		if got := authResult.GPGKeyIDsString(); got != "" {
+			t.Errorf("unexpected GPG key IDs %q", got)
+		}
+	})
+	t.Run("untrusted signature", func(t *testing.T) {
+		source := makeSource(&cosign.Policy{PublicKeys: []crypto.PublicKey{otherKey.Public()}})
+		_, err := source.PackageMeta(t.Context(), fakeProvider, MustParseVersion("1.0.0"), platform)
+		if err == nil {
+			t.Fatal("unexpected success; want error")
+		}
+		if got, want := err.Error(), "failed to verify cosign signature for example.com/foo_bar"; !strings.HasPrefix(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant prefix: %s", got, want)
+		}
+	})
+	t.Run("no policy", func(t *testing.T) {
+		source := makeSource(nil)
+		meta, err := source.PackageMeta(t.Context(), fakeProvider, MustParseVersion("1.0.0"), platform)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loc := meta.Location.(PackageOCIBlobArchive)
+		authResult, err := loc.InstallProviderPackage(t.Context(), meta, t.TempDir(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := authResult.summaryResult(), verifiedChecksum; got != want {
+			t.Errorf("wrong authentication result\ngot:  %#v\nwant: %#v", got, want)
+		}
+	})
+}
+
 func pushOCIImageManifest(t *testing.T, manifest *ociv1.Manifest, store orasContent.Pusher) ociv1.Descriptor {
 	t.Helper()
 	manifestBytes, err := json.Marshal(manifest)
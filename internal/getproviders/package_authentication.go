@@ -46,6 +46,10 @@ const (
 // result, which is represented by nil.
 type PackageAuthenticationResult struct {
 	hashes HashDispositions
+
+	// ociSigners describes the signers of any cosign signatures that were
+	// verified for the OCI artifact that the package was installed from.
+	ociSigners []string
 }
 
 // NewPackageAuthenticationResult constructs a new [PackageAuthenticationResult]
@@ -57,7 +61,7 @@ type PackageAuthenticationResult struct {
 // all of the "real" package authentication implementations should live in this
 // package.
 func NewPackageAuthenticationResult(hashes HashDispositions) *PackageAuthenticationResult {
-	return &PackageAuthenticationResult{hashes}
+	return &PackageAuthenticationResult{hashes: hashes}
 }
 
 func (t *PackageAuthenticationResult) summaryResult() packageAuthenticationResult {
@@ -83,7 +87,7 @@ func (t *PackageAuthenticationResult) summaryResult() packageAuthenticationResul
 		}
 	}
 	switch {
-	case signedCount > 0:
+	case signedCount > 0 || len(t.ociSigners) > 0:
 		return signed
 	case registryReportCount > 0:
 		return signingSkipped
@@ -140,12 +144,22 @@ func (t *PackageAuthenticationResult) GPGKeyIDsString() string {
 	return t.hashes.AllGPGSigningKeysString()
 }
 
+// OCISignersString returns a UI-oriented string representation of the signers
+// of all of the cosign signatures that were verified for the OCI artifact
+// the package was installed from, or an empty string if there were none.
+func (t *PackageAuthenticationResult) OCISignersString() string {
+	if t == nil {
+		return ""
+	}
+	return strings.Join(t.ociSigners, ", ")
+}
+
 // Signed returns whether the package was authenticated as signed by anyone.
 func (t *PackageAuthenticationResult) Signed() bool {
 	if t == nil {
 		return false
 	}
-	return t.hashes.HasAnySignedByGPGKeys()
+	return t.hashes.HasAnySignedByGPGKeys() || len(t.ociSigners) > 0
 }
 
 // SigningSkipped returns whether the package was authenticated but the key
@@ -211,10 +225,30 @@ func (checks packageAuthenticationAll) AuthenticatePackage(localLocation Package
 			continue // this result has nothing to contribute to our overall result
 		}
 		authResult.hashes.Merge(thisAuthResult.hashes)
+		authResult.ociSigners = append(authResult.ociSigners, thisAuthResult.ociSigners...)
 	}
 	return authResult, nil
 }
 
+type ociSignatureAuthentication struct {
+	Signer string
+}
+
+// newOCISignatureAuthentication returns a PackageAuthentication implementation
+// that reports that the OCI artifact containing the package was already
+// verified as signed by the given signer.
+//
+// The signature covers the OCI index manifest, which in turn covers the
+// package blob by its digest, and so this must be combined with a hash
+// authentication for that blob digest using [PackageAuthenticationAll].
+func newOCISignatureAuthentication(signer string) PackageAuthentication {
+	return ociSignatureAuthentication{Signer: signer}
+}
+
+func (a ociSignatureAuthentication) AuthenticatePackage(_ PackageLocation) (*PackageAuthenticationResult, error) {
+	return &PackageAuthenticationResult{ociSigners: []string{a.Signer}}, nil
+}
+
 type packageHashAuthentication struct {
 	RequiredHashes []Hash
 	AllHashes      []Hash
@@ -0,0 +1,380 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/time/rate"
+)
+
+// PackageDownloadProgress describes how far the download of a single provider
+// package has progressed.
+type PackageDownloadProgress struct {
+	// Downloaded is the number of bytes of the package that are present
+	// locally so far, including any bytes retained from an earlier
+	// interrupted download.
+	Downloaded int64
+
+	// Total is the size of the whole package in bytes, or -1 if the server
+	// did not report it.
+	Total int64
+
+	// Resumed is the number of bytes that were already present from an
+	// earlier interrupted download when this download began.
+	Resumed int64
+
+	// Elapsed is the time since this download began.
+	Elapsed time.Duration
+}
+
+// ETA estimates how long the rest of the download will take, based on the
+// average rate of the bytes fetched since the download began.
+//
+// The second return value is false if there isn't enough information to
+// make an estimate yet.
+func (p PackageDownloadProgress) ETA() (time.Duration, bool) {
+	fetched := p.Downloaded - p.Resumed
+	if p.Total < 0 || fetched <= 0 || p.Elapsed <= 0 {
+		return 0, false
+	}
+	remaining := p.Total - p.Downloaded
+	if remaining <= 0 {
+		return 0, true
+	}
+	bytesPerSecond := float64(fetched) / p.Elapsed.Seconds()
+	return time.Duration(float64(remaining) / bytesPerSecond * float64(time.Second)), true
+}
+
+// PackageDownloadOptions are per-package settings for a download of a
+// provider package from a [PackageHTTPURL], which are passed through the
+// context given to [PackageLocation.InstallProviderPackage] using
+// [ContextWithPackageDownloadOptions].
+//
+// Other kinds of package location ignore these options.
+type PackageDownloadOptions struct {
+	// PartialFile, if set, is the path of a file to download the package
+	// archive into. The file is retained if the download fails partway
+	// through, and a later download of the same package will then resume
+	// from the end of it using an HTTP range request.
+	//
+	// The caller is responsible for choosing a path that is unique to the
+	// package and for ensuring that only one download uses it at a time.
+	//
+	// If this is not set then the archive is downloaded into a temporary
+	// file and an interrupted download can only restart from the beginning.
+	PartialFile string
+
+	// Progress, if set, is called periodically while the package archive is
+	// being downloaded, and once more when the download is complete.
+	Progress func(PackageDownloadProgress)
+}
+
+// ContextWithPackageDownloadOptions returns a context that carries the given
+// download options, for use in a subsequent call to
+// [PackageLocation.InstallProviderPackage].
+func ContextWithPackageDownloadOptions(ctx context.Context, opts PackageDownloadOptions) context.Context {
+	return context.WithValue(ctx, ctxPackageDownloadOptions, opts)
+}
+
+func packageDownloadOptionsForContext(ctx context.Context) PackageDownloadOptions {
+	opts, _ := ctx.Value(ctxPackageDownloadOptions).(PackageDownloadOptions)
+	return opts
+}
+
+type ctxPackageDownloadOptionsType int
+
+const ctxPackageDownloadOptions = ctxPackageDownloadOptionsType(0)
+
+// packageDownloadProgressInterval is the minimum time between two progress
+// reports for the same download, so that a fast download doesn't overwhelm
+// the UI with events.
+const packageDownloadProgressInterval = time.Second
+
+// errPackageDownloadInterrupted wraps errors that cause a download to stop
+// partway through in a way that might succeed if resumed, such as a dropped
+// connection.
+type errPackageDownloadInterrupted struct {
+	err error
+}
+
+func (e errPackageDownloadInterrupted) Error() string {
+	return e.err.Error()
+}
+
+func (e errPackageDownloadInterrupted) Unwrap() error {
+	return e.err
+}
+
+// httpPackageDownload tracks the state of fetching a single provider package
+// archive over HTTP into a local file, which might span several requests if
+// the download is interrupted and then resumed.
+type httpPackageDownload struct {
+	client   *retryablehttp.Client
+	url      string
+	file     *os.File
+	limiter  *rate.Limiter
+	progress func(PackageDownloadProgress)
+
+	start        time.Time
+	resumed      int64
+	downloaded   int64
+	total        int64
+	lastReported time.Time
+}
+
+// newHTTPPackageDownload prepares to download from the given URL into the
+// given file, resuming from the end of any content the file already has.
+//
+// If bandwidthLimit is greater than zero then the download will be slowed
+// to transfer at most that many bytes per second.
+func newHTTPPackageDownload(client *retryablehttp.Client, url string, f *os.File, bandwidthLimit int64, progress func(PackageDownloadProgress)) (*httpPackageDownload, error) {
+	existing, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partial download %s: %w", f.Name(), err)
+	}
+	d := &httpPackageDownload{
+		client:     client,
+		url:        url,
+		file:       f,
+		progress:   progress,
+		resumed:    existing,
+		downloaded: existing,
+		total:      -1,
+	}
+	if bandwidthLimit > 0 {
+		// The burst size is also the largest single read we'll make from the
+		// response body, so we'll keep it small enough that the transfer
+		// rate stays reasonably smooth even for generous limits.
+		burst := int(min(bandwidthLimit, 64*1024))
+		d.limiter = rate.NewLimiter(rate.Limit(bandwidthLimit), burst)
+	}
+	return d, nil
+}
+
+// run performs the download, resuming it after the client's configured retry
+// backoff each time it's interrupted until the client's maximum number of
+// retries is exhausted.
+func (d *httpPackageDownload) run(ctx context.Context) error {
+	d.start = time.Now()
+	if d.resumed > 0 {
+		log.Printf("[INFO] resuming provider download from %s after %d bytes already present in %s", d.url, d.resumed, d.file.Name())
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := d.fetch(ctx)
+		if err == nil {
+			d.report(true)
+			return nil
+		}
+		var interrupted errPackageDownloadInterrupted
+		if ctx.Err() != nil || !errors.As(err, &interrupted) || attempt >= d.client.RetryMax {
+			return err
+		}
+
+		wait := d.client.Backoff(d.client.RetryWaitMin, d.client.RetryWaitMax, attempt, nil)
+		log.Printf("[INFO] provider download from %s was interrupted after %d bytes; resuming in %s: %s", d.url, d.downloaded, wait, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// fetch makes a single request for the part of the package that is not yet
+// present in the local file, and appends the response body to it.
+func (d *httpPackageDownload) fetch(ctx context.Context) error {
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", d.url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid provider download request: %w", err)
+	}
+	if d.downloaded > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", d.downloaded))
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", HostFromRequest(req.Request), err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if d.downloaded > 0 {
+			// The server doesn't support range requests, so the response
+			// contains the whole package and we must start over.
+			log.Printf("[TRACE] getproviders: %s does not support resuming downloads; starting over", d.url)
+			if err := d.restart(); err != nil {
+				return err
+			}
+		}
+		d.total = resp.ContentLength
+	case http.StatusPartialContent:
+		start, total, err := parseContentRange(resp.Header.Get("Content-Range"))
+		if err != nil || start != d.downloaded {
+			// We can't safely append a range we didn't ask for, so we'll
+			// discard what we have and start over on the next attempt.
+			if err := d.restart(); err != nil {
+				return err
+			}
+			return errPackageDownloadInterrupted{fmt.Errorf("unexpected partial response from %s", d.url)}
+		}
+		d.total = total
+	case http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is at least as large as the package, so it can't
+		// be a prefix of it. It probably belongs to a different package that
+		// was previously served at this same URL.
+		if err := d.restart(); err != nil {
+			return err
+		}
+		return errPackageDownloadInterrupted{fmt.Errorf("partial download does not match the package at %s", d.url)}
+	default:
+		return fmt.Errorf("unsuccessful request to %s: %s", d.url, resp.Status)
+	}
+
+	var body io.Reader = resp.Body
+	if d.limiter != nil {
+		body = &rateLimitedReader{ctx: ctx, r: body, limiter: d.limiter}
+	}
+	// We'll borrow go-getter's "cancelable copy" implementation here so that
+	// the download can potentially be interrupted partway through.
+	_, err = getter.Copy(ctx, d.file, &downloadProgressReader{r: body, d: d})
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return errPackageDownloadInterrupted{err}
+	}
+	if d.total >= 0 && d.downloaded < d.total {
+		return errPackageDownloadInterrupted{fmt.Errorf("incorrect response size: expected %d bytes, but got %d bytes", d.total, d.downloaded)}
+	}
+	return nil
+}
+
+// restart discards everything downloaded so far.
+func (d *httpPackageDownload) restart() error {
+	if err := d.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to discard partial download %s: %w", d.file.Name(), err)
+	}
+	if _, err := d.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to discard partial download %s: %w", d.file.Name(), err)
+	}
+	d.downloaded = 0
+	d.resumed = 0
+	d.total = -1
+	return nil
+}
+
+// report sends a progress event, unless one was sent too recently. If final
+// is set then the event is always sent.
+func (d *httpPackageDownload) report(final bool) {
+	if d.progress == nil {
+		return
+	}
+	now := time.Now()
+	if !final && now.Sub(d.lastReported) < packageDownloadProgressInterval {
+		return
+	}
+	d.lastReported = now
+	d.progress(PackageDownloadProgress{
+		Downloaded: d.downloaded,
+		Total:      d.total,
+		Resumed:    d.resumed,
+		Elapsed:    now.Sub(d.start),
+	})
+}
+
+// downloadProgressReader counts the bytes read through it towards the
+// progress of the associated download.
+type downloadProgressReader struct {
+	r io.Reader
+	d *httpPackageDownload
+}
+
+func (r *downloadProgressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.d.downloaded += int64(n)
+	r.d.report(false)
+	return n, err
+}
+
+// rateLimitedReader blocks reads as needed to keep the rate of bytes read
+// through it within the limit of the given limiter.
+type rateLimitedReader struct {
+	ctx     context.Context
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if burst := r.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// parseContentRange parses the value of a Content-Range response header of
+// the form "bytes start-end/total", returning the start offset and the total
+// size. The total is -1 if the server reported it as unknown.
+func parseContentRange(v string) (start, total int64, err error) {
+	rest, ok := strings.CutPrefix(v, "bytes ")
+	if !ok {
+		return 0, 0, fmt.Errorf("unsupported Content-Range %q", v)
+	}
+	rangeStr, totalStr, ok := strings.Cut(rest, "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", v)
+	}
+	startStr, _, ok := strings.Cut(rangeStr, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", v)
+	}
+	start, err = strconv.ParseInt(startStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", v)
+	}
+	if totalStr == "*" {
+		return start, -1, nil
+	}
+	total, err = strconv.ParseInt(totalStr, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", v)
+	}
+	return start, total, nil
+}
+
+// openPackageDownloadFile opens the file that a package archive should be
+// downloaded into: either the given partial file, creating it if necessary,
+// or a new temporary file if partialFile is empty.
+func openPackageDownloadFile(partialFile string) (*os.File, error) {
+	if partialFile == "" {
+		return os.CreateTemp("", "terraform-provider")
+	}
+	if err := os.MkdirAll(filepath.Dir(partialFile), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(partialFile, os.O_RDWR|os.O_CREATE, 0644)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func TestHTTPPackageDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+
+	tests := map[string]struct {
+		// existing is the number of bytes of content already present in
+		// the partial file before the download starts.
+		existing int
+		handler  func(t *testing.T, requests int) http.HandlerFunc
+		// wantRanges is the Range header expected for each request.
+		wantRanges []string
+	}{
+		"fresh download": {
+			handler: func(t *testing.T, requests int) http.HandlerFunc {
+				return serveContentHandler(content)
+			},
+			wantRanges: []string{""},
+		},
+		"resumes from partial file": {
+			existing: 1000,
+			handler: func(t *testing.T, requests int) http.HandlerFunc {
+				return serveContentHandler(content)
+			},
+			wantRanges: []string{"bytes=1000-"},
+		},
+		"resumes after connection drops": {
+			handler: func(t *testing.T, requests int) http.HandlerFunc {
+				if requests > 0 {
+					return serveContentHandler(content)
+				}
+				return func(w http.ResponseWriter, r *http.Request) {
+					// We promise the whole package but then send only the
+					// first half of it, which the client sees as the
+					// connection being closed partway through.
+					w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write(content[:len(content)/2])
+				}
+			},
+			wantRanges: []string{"", "bytes=" + strconv.Itoa(len(content)/2) + "-"},
+		},
+		"server without range support": {
+			existing: 1000,
+			handler: func(t *testing.T, requests int) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write(content)
+				}
+			},
+			wantRanges: []string{"bytes=1000-"},
+		},
+		"partial file larger than package": {
+			existing: len(content) + 10,
+			handler: func(t *testing.T, requests int) http.HandlerFunc {
+				return serveContentHandler(content)
+			},
+			wantRanges: []string{"bytes=" + strconv.Itoa(len(content)+10) + "-", ""},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var gotRanges []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests := len(gotRanges)
+				gotRanges = append(gotRanges, r.Header.Get("Range"))
+				test.handler(t, requests)(w, r)
+			}))
+			defer server.Close()
+
+			partialFile := filepath.Join(t.TempDir(), "package.zip")
+			existing := content[:min(test.existing, len(content))]
+			if test.existing > len(content) {
+				existing = append(bytes.Clone(content), bytes.Repeat([]byte{'x'}, test.existing-len(content))...)
+			}
+			if err := os.WriteFile(partialFile, existing, 0644); err != nil {
+				t.Fatal(err)
+			}
+			f, err := openPackageDownloadFile(partialFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			client := retryablehttp.NewClient()
+			client.RetryMax = 2
+			client.RetryWaitMin = time.Millisecond
+			client.RetryWaitMax = time.Millisecond
+			var lastProgress PackageDownloadProgress
+			download, err := newHTTPPackageDownload(client, server.URL+"/package.zip", f, 0, func(progress PackageDownloadProgress) {
+				lastProgress = progress
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := download.run(t.Context()); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := os.ReadFile(partialFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Errorf("wrong downloaded content: got %d bytes, want %d bytes", len(got), len(content))
+			}
+			if len(gotRanges) != len(test.wantRanges) {
+				t.Fatalf("wrong requests\ngot ranges:  %q\nwant ranges: %q", gotRanges, test.wantRanges)
+			}
+			for i := range gotRanges {
+				if gotRanges[i] != test.wantRanges[i] {
+					t.Errorf("wrong range for request %d: got %q, want %q", i, gotRanges[i], test.wantRanges[i])
+				}
+			}
+			if got, want := lastProgress.Downloaded, int64(len(content)); got != want {
+				t.Errorf("wrong final progress: got %d bytes, want %d bytes", got, want)
+			}
+		})
+	}
+}
+
+func TestHTTPPackageDownload_bandwidthLimit(t *testing.T) {
+	content := bytes.Repeat([]byte{'x'}, 3000)
+	server := httptest.NewServer(serveContentHandler(content))
+	defer server.Close()
+
+	f, err := openPackageDownloadFile(filepath.Join(t.TempDir(), "package.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// With a limit of 2000 bytes per second and a burst of the same size,
+	// downloading 3000 bytes must take at least half a second.
+	download, err := newHTTPPackageDownload(retryablehttp.NewClient(), server.URL, f, 2000, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := download.run(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("download completed too quickly for the bandwidth limit: %s", elapsed)
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		wantStart int64
+		wantTotal int64
+		wantErr   bool
+	}{
+		"complete":      {input: "bytes 100-199/200", wantStart: 100, wantTotal: 200},
+		"unknown total": {input: "bytes 0-99/*", wantStart: 0, wantTotal: -1},
+		"wrong unit":    {input: "items 0-99/200", wantErr: true},
+		"no total":      {input: "bytes 0-99", wantErr: true},
+		"bad start":     {input: "bytes a-99/200", wantErr: true},
+		"empty":         {input: "", wantErr: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			start, total, err := parseContentRange(test.input)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("unexpected success; want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if start != test.wantStart || total != test.wantTotal {
+				t.Errorf("wrong result %d, %d; want %d, %d", start, total, test.wantStart, test.wantTotal)
+			}
+		})
+	}
+}
+
+func TestPackageDownloadProgressETA(t *testing.T) {
+	progress := PackageDownloadProgress{
+		Downloaded: 300,
+		Total:      1000,
+		Resumed:    100,
+		Elapsed:    2 * time.Second,
+	}
+	// 200 bytes were fetched in 2 seconds, so the remaining 700 bytes
+	// should take another 7 seconds.
+	got, ok := progress.ETA()
+	if !ok {
+		t.Fatalf("no estimate")
+	}
+	if want := 7 * time.Second; got != want {
+		t.Errorf("wrong estimate %s; want %s", got, want)
+	}
+
+	progress.Total = -1
+	if _, ok := progress.ETA(); ok {
+		t.Errorf("unexpected estimate for download of unknown size")
+	}
+}
+
+// serveContentHandler returns a handler that serves the given content,
+// including support for range requests.
+func serveContentHandler(content []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "package.zip", time.Time{}, bytes.NewReader(content))
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"net/http"
 	"os"
 
-	"github.com/hashicorp/go-getter"
 	"github.com/hashicorp/go-retryablehttp"
 
 	"github.com/opentofu/opentofu/internal/httpclient"
@@ -43,6 +42,9 @@ type PackageHTTPURL struct {
 	// can inject a client by its liking to customize the requests
 	// accordingly.
 	ClientBuilder func(ctx context.Context) *retryablehttp.Client
+	// BandwidthLimit, if greater than zero, is the maximum number of bytes
+	// per second to transfer while downloading the package.
+	BandwidthLimit int64
 }
 
 var _ PackageLocation = PackageHTTPURL{}
@@ -67,41 +69,39 @@ func (p PackageHTTPURL) InstallProviderPackage(ctx context.Context, meta Package
 
 	retryableClient := p.ClientBuilder(ctx)
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
+	// If the caller gave us a partial file then we'll download into that,
+	// resuming any earlier interrupted download of the same package and
+	// retaining whatever we manage to download this time if we're
+	// interrupted again.
+	opts := packageDownloadOptionsForContext(ctx)
+	f, err := openPackageDownloadFile(opts.PartialFile)
 	if err != nil {
-		return nil, fmt.Errorf("invalid provider download request: %w", err)
+		return nil, fmt.Errorf("failed to open temporary file to download from %s: %w", url, err)
 	}
-	resp, err := retryableClient.Do(req)
+	complete := false
+	defer func() {
+		f.Close()
+		// Once the download is complete the partial file has served its
+		// purpose: if the package then fails the checks below we want
+		// the next attempt to start over, rather than resume into it.
+		if opts.PartialFile == "" || complete {
+			os.Remove(f.Name())
+		}
+	}()
+
+	download, err := newHTTPPackageDownload(retryableClient, url, f, p.BandwidthLimit, opts.Progress)
 	if err != nil {
+		return nil, err
+	}
+	if err := download.run(ctx); err != nil {
 		if ctx.Err() == context.Canceled {
 			// "context canceled" is not a user-friendly error message,
 			// so we'll return a more appropriate one here.
 			return nil, fmt.Errorf("provider download was interrupted")
 		}
-		return nil, fmt.Errorf("%s: %w", HostFromRequest(req.Request), err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unsuccessful request to %s: %s", url, resp.Status)
-	}
-
-	f, err := os.CreateTemp("", "terraform-provider")
-	if err != nil {
-		return nil, fmt.Errorf("failed to open temporary file to download from %s: %w", url, err)
-	}
-	defer os.Remove(f.Name())
-	defer f.Close()
-
-	// We'll borrow go-getter's "cancelable copy" implementation here so that
-	// the download can potentially be interrupted partway through.
-	n, err := getter.Copy(ctx, f, resp.Body)
-	if err == nil && n < resp.ContentLength {
-		err = fmt.Errorf("incorrect response size: expected %d bytes, but got %d bytes", resp.ContentLength, n)
-	}
-	if err != nil {
 		return nil, err
 	}
+	complete = true
 
 	archiveFilename := f.Name()
 	localLocation := PackageLocalArchive(archiveFilename)
@@ -150,3 +150,16 @@ func packageHTTPUrlClientWithRetry(ctx context.Context, retries int) *retryableh
 	retryableClient.Logger = log.New(logging.LogOutput(), "", log.Flags())
 	return retryableClient
 }
+
+// packageHTTPUrlClient returns a client for downloading provider packages
+// using the retry and backoff settings from the given location config.
+func packageHTTPUrlClient(ctx context.Context, cfg LocationConfig) *retryablehttp.Client {
+	retryableClient := packageHTTPUrlClientWithRetry(ctx, cfg.ProviderDownloadRetries)
+	if cfg.ProviderDownloadRetryWaitMin > 0 {
+		retryableClient.RetryWaitMin = cfg.ProviderDownloadRetryWaitMin
+	}
+	if cfg.ProviderDownloadRetryWaitMax > 0 {
+		retryableClient.RetryWaitMax = cfg.ProviderDownloadRetryWaitMax
+	}
+	return retryableClient
+}
@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/opentofu/svchost"
@@ -226,6 +227,12 @@ func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provide
 
 		SigningKeys SigningKeyList `json:"signing_keys"`
 
+		// PublishedAt is an optional extension to the provider registry
+		// protocol announcing when the version was released, which is
+		// used by the minimum release age rule of provider installation
+		// policies.
+		PublishedAt *time.Time `json:"published_at"`
+
 		Packages map[string]struct {
 			Hashes      []string `json:"hashes"`
 			PackageSize int64    `json:"package_size"`
@@ -299,11 +306,18 @@ func (c *registryClient) PackageMeta(ctx context.Context, provider addrs.Provide
 			Arch: body.Arch,
 		},
 		Filename: body.Filename,
-		Location: PackageHTTPURL{URL: downloadURL.String(), ClientBuilder: func(ctx context.Context) *retryablehttp.Client {
-			return packageHTTPUrlClientWithRetry(ctx, c.locationConfig.ProviderDownloadRetries)
-		}},
+		Location: PackageHTTPURL{
+			URL: downloadURL.String(),
+			ClientBuilder: func(ctx context.Context) *retryablehttp.Client {
+				return packageHTTPUrlClient(ctx, c.locationConfig)
+			},
+			BandwidthLimit: c.locationConfig.ProviderDownloadBandwidthLimit,
+		},
 		// "Authentication" is populated below
 	}
+	if body.PublishedAt != nil {
+		ret.ReleaseTime = *body.PublishedAt
+	}
 
 	packageData := make(map[Platform]RegistryPlatformData)
 	for platformStr, packageMeta := range body.Packages {
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/apparentlymart/go-versions/versions"
 	"github.com/apparentlymart/go-versions/versions/constraints"
@@ -269,6 +270,11 @@ type PackageMeta struct {
 	// This is likely appropriate only for packages that are already available
 	// on the local system.
 	Authentication PackageAuthentication
+
+	// ReleaseTime is the time when this version was released, if the source
+	// knows it, or the zero value otherwise. It is reported by the source
+	// itself, so it is only as trustworthy as the source is.
+	ReleaseTime time.Time
 }
 
 // LessThan returns true if the receiver should sort before the given other
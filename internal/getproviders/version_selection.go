@@ -0,0 +1,149 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"slices"
+
+	"github.com/apparentlymart/go-versions/versions"
+)
+
+// VersionSelection describes how the provider installer would select a
+// version of a provider from its available versions, given all of the
+// version constraints declared for it across a configuration.
+//
+// This is intended for explaining a selection to a user, and so unlike the
+// installer it also retains information about which of the constraints
+// caused newer versions to be excluded.
+type VersionSelection struct {
+	// Available is all of the available versions of the provider, sorted
+	// in ascending order of precedence.
+	Available VersionList
+
+	// Newest is the newest available version that is not a prerelease,
+	// or UnspecifiedVersion if there are no such versions.
+	Newest Version
+
+	// Selected is the version that "tofu init -upgrade" would select, which
+	// is the newest available version that meets all of the constraints and
+	// that the provider installation policy doesn't reject. It is
+	// UnspecifiedVersion if there is no such version.
+	Selected Version
+
+	// Rejected describes the available versions newer than Selected that
+	// meet all of the constraints but that the provider installation policy
+	// rejects, in ascending order of precedence, as recorded by ApplyPolicy.
+	Rejected []RejectedVersion
+
+	// acceptable is the set of versions that meet all of the constraints.
+	acceptable VersionSet
+	// limit is the oldest of the newest versions allowed by each of the
+	// constraints separately, which is what the limiting constraints allow.
+	limit Version
+	// limited is true if at least one constraint excludes Newest.
+	limited bool
+}
+
+// NewVersionSelection returns a VersionSelection for a provider with the
+// given available versions and the given version constraints, each of which
+// typically originates from a different module.
+func NewVersionSelection(available VersionList, constraints []VersionConstraints) VersionSelection {
+	sorted := make(VersionList, len(available))
+	copy(sorted, available)
+	sorted.Sort()
+
+	ret := VersionSelection{
+		Available: sorted,
+		Newest:    sorted.NewestInSet(versions.Released),
+	}
+
+	var all VersionConstraints
+	for i, vc := range constraints {
+		all = append(all, vc...)
+		allowed := sorted.NewestInSet(MeetingConstraints(vc))
+		if i == 0 || allowed.LessThan(ret.limit) {
+			ret.limit = allowed
+		}
+	}
+	ret.acceptable = MeetingConstraints(all)
+	ret.Selected = sorted.NewestInSet(ret.acceptable)
+	ret.limited = len(constraints) != 0 && ret.limit.LessThan(ret.Newest)
+	return ret
+}
+
+// RejectedVersion is a version that the provider installation policy
+// rejects, along with the reason why.
+type RejectedVersion struct {
+	Version Version
+	Reason  error
+}
+
+// ApplyPolicy updates the selection to skip the versions that the given
+// function rejects, in the same way as the provider installer applies its
+// installation policy. The versions that meet all of the constraints are
+// checked newest first, until one of them is accepted.
+func (s *VersionSelection) ApplyPolicy(check func(Version) error) {
+	s.Selected = UnspecifiedVersion
+	s.Rejected = nil
+	for i := len(s.Available) - 1; i >= 0; i-- {
+		v := s.Available[i]
+		if !s.acceptable.Has(v) {
+			continue
+		}
+		if err := check(v); err != nil {
+			s.Rejected = append(s.Rejected, RejectedVersion{Version: v, Reason: err})
+			continue
+		}
+		s.Selected = v
+		break
+	}
+	slices.Reverse(s.Rejected)
+}
+
+// NewestAllowedBy returns the newest available version that the given
+// constraints allow on their own, without considering any others, or
+// UnspecifiedVersion if the constraints allow none of the available versions.
+func (s VersionSelection) NewestAllowedBy(vc VersionConstraints) Version {
+	return s.Available.NewestInSet(MeetingConstraints(vc))
+}
+
+// IsLimitedBy returns true if the given constraints, which must be one of
+// the sets of constraints that the selection was created with, are what
+// prevents a newer version from being selected.
+//
+// A provider's version can be limited by more than one set of constraints
+// if they each allow the same newest version.
+func (s VersionSelection) IsLimitedBy(vc VersionConstraints) bool {
+	return s.limited && s.NewestAllowedBy(vc).Same(s.limit)
+}
+
+// NewerThan returns the available versions that are newer than the given
+// version, separated into those that meet all of the constraints, those
+// that do not, and those that meet the constraints but that the provider
+// installation policy rejects. Prereleases are included only if they meet
+// the constraints.
+func (s VersionSelection) NewerThan(current Version) (allowed, disallowed, rejected VersionList) {
+	for _, v := range s.Available {
+		if !v.GreaterThan(current) {
+			continue
+		}
+		switch {
+		case s.isRejected(v):
+			rejected = append(rejected, v)
+		case s.acceptable.Has(v):
+			allowed = append(allowed, v)
+		case versions.Released.Has(v):
+			disallowed = append(disallowed, v)
+		}
+	}
+	return allowed, disallowed, rejected
+}
+
+func (s VersionSelection) isRejected(v Version) bool {
+	return slices.ContainsFunc(s.Rejected, func(r RejectedVersion) bool {
+		return r.Version.Same(v)
+	})
+}
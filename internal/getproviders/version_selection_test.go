@@ -0,0 +1,199 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package getproviders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVersionSelection(t *testing.T) {
+	available := VersionList{
+		MustParseVersion("2.0.0"),
+		MustParseVersion("1.0.0"),
+		MustParseVersion("1.1.0"),
+		MustParseVersion("1.2.0"),
+		MustParseVersion("3.0.0-beta1"),
+	}
+
+	tests := map[string]struct {
+		constraints  []string
+		wantSelected string
+		wantLimiting []bool
+	}{
+		"no constraints": {
+			constraints:  nil,
+			wantSelected: "2.0.0",
+		},
+		"unconstrained module": {
+			constraints:  []string{""},
+			wantSelected: "2.0.0",
+			wantLimiting: []bool{false},
+		},
+		"one limiting constraint": {
+			constraints:  []string{"~> 1.0", ">= 1.0.0"},
+			wantSelected: "1.2.0",
+			wantLimiting: []bool{true, false},
+		},
+		"most restrictive constraint limits": {
+			constraints:  []string{"~> 1.0", "< 1.2.0"},
+			wantSelected: "1.1.0",
+			wantLimiting: []bool{false, true},
+		},
+		"equally restrictive constraints": {
+			constraints:  []string{"~> 1.0", "~> 1.1"},
+			wantSelected: "1.2.0",
+			wantLimiting: []bool{true, true},
+		},
+		"conflicting constraints": {
+			constraints:  []string{"~> 1.0", ">= 2.0.0"},
+			wantSelected: "0.0.0",
+			wantLimiting: []bool{true, false},
+		},
+		"unavailable version": {
+			constraints:  []string{"1.5.0", ">= 1.0.0"},
+			wantSelected: "0.0.0",
+			wantLimiting: []bool{true, false},
+		},
+		"prerelease": {
+			constraints:  []string{"3.0.0-beta1"},
+			wantSelected: "3.0.0-beta1",
+			wantLimiting: []bool{false},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var constraints []VersionConstraints
+			for _, s := range test.constraints {
+				if s == "" {
+					// A module that requires the provider without
+					// declaring any version constraints.
+					constraints = append(constraints, nil)
+					continue
+				}
+				constraints = append(constraints, MustParseVersionConstraints(s))
+			}
+			got := NewVersionSelection(available, constraints)
+
+			if got.Newest.String() != "2.0.0" {
+				t.Errorf("wrong newest version %s; want 2.0.0", got.Newest)
+			}
+			if got.Selected.String() != test.wantSelected {
+				t.Errorf("wrong selected version %s; want %s", got.Selected, test.wantSelected)
+			}
+			for i, vc := range constraints {
+				if got, want := got.IsLimitedBy(vc), test.wantLimiting[i]; got != want {
+					t.Errorf("wrong result for IsLimitedBy(%q): got %t, want %t", test.constraints[i], got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestVersionSelection_NewerThan(t *testing.T) {
+	available := VersionList{
+		MustParseVersion("1.0.0"),
+		MustParseVersion("1.1.0"),
+		MustParseVersion("1.2.0-beta1"),
+		MustParseVersion("1.2.0"),
+		MustParseVersion("2.0.0"),
+	}
+	sel := NewVersionSelection(available, []VersionConstraints{MustParseVersionConstraints("~> 1.0")})
+
+	allowed, disallowed, rejected := sel.NewerThan(MustParseVersion("1.0.0"))
+	if got, want := versionListString(allowed), "1.1.0, 1.2.0"; got != want {
+		t.Errorf("wrong allowed versions\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := versionListString(disallowed), "2.0.0"; got != want {
+		t.Errorf("wrong disallowed versions\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := versionListString(rejected), ""; got != want {
+		t.Errorf("wrong rejected versions\ngot:  %s\nwant: %s", got, want)
+	}
+
+	allowed, disallowed, _ = sel.NewerThan(UnspecifiedVersion)
+	if got, want := versionListString(allowed), "1.0.0, 1.1.0, 1.2.0"; got != want {
+		t.Errorf("wrong allowed versions for no current version\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := versionListString(disallowed), "2.0.0"; got != want {
+		t.Errorf("wrong disallowed versions for no current version\ngot:  %s\nwant: %s", got, want)
+	}
+
+	sel.ApplyPolicy(func(v Version) error {
+		if v.Same(MustParseVersion("1.2.0")) {
+			return errors.New("denied")
+		}
+		return nil
+	})
+	allowed, disallowed, rejected = sel.NewerThan(MustParseVersion("1.0.0"))
+	if got, want := versionListString(allowed), "1.1.0"; got != want {
+		t.Errorf("wrong allowed versions with policy\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := versionListString(disallowed), "2.0.0"; got != want {
+		t.Errorf("wrong disallowed versions with policy\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := versionListString(rejected), "1.2.0"; got != want {
+		t.Errorf("wrong rejected versions with policy\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestVersionSelection_ApplyPolicy(t *testing.T) {
+	available := VersionList{
+		MustParseVersion("1.0.0"),
+		MustParseVersion("1.1.0"),
+		MustParseVersion("1.2.0"),
+		MustParseVersion("1.3.0"),
+		MustParseVersion("2.0.0"),
+	}
+	sel := NewVersionSelection(available, []VersionConstraints{MustParseVersionConstraints("~> 1.0")})
+
+	// As with the installer, only the versions that meet the constraints are
+	// checked, newest first, until one is accepted.
+	var checked []string
+	sel.ApplyPolicy(func(v Version) error {
+		checked = append(checked, v.String())
+		if v.GreaterThan(MustParseVersion("1.1.0")) {
+			return fmt.Errorf("v%s is too new", v)
+		}
+		return nil
+	})
+	if got, want := strings.Join(checked, ", "), "1.3.0, 1.2.0, 1.1.0"; got != want {
+		t.Errorf("wrong checked versions\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := sel.Selected.String(), "1.1.0"; got != want {
+		t.Errorf("wrong selected version %s; want %s", got, want)
+	}
+	var gotRejected []string
+	for _, r := range sel.Rejected {
+		gotRejected = append(gotRejected, r.Reason.Error())
+	}
+	if got, want := strings.Join(gotRejected, ", "), "v1.2.0 is too new, v1.3.0 is too new"; got != want {
+		t.Errorf("wrong rejected versions\ngot:  %s\nwant: %s", got, want)
+	}
+
+	sel.ApplyPolicy(func(v Version) error {
+		return errors.New("denied")
+	})
+	if sel.Selected != UnspecifiedVersion {
+		t.Errorf("wrong selected version %s; want none", sel.Selected)
+	}
+	if got, want := len(sel.Rejected), 4; got != want {
+		t.Errorf("wrong number of rejected versions %d; want %d", got, want)
+	}
+}
+
+func versionListString(l VersionList) string {
+	var ret string
+	for i, v := range l {
+		if i > 0 {
+			ret += ", "
+		}
+		ret += v.String()
+	}
+	return ret
+}
@@ -233,7 +233,7 @@ func DirFromModule(ctx context.Context, loader configload.Loader, rootDir, modul
 					}
 					return cty.DynamicVal, nil
 				}, rootDir, "")
-				_ = mod.WithStaticCall(call)
+				_ = mod.WithStaticCall(ctx, call)
 
 				for _, mc := range mod.ModuleCalls {
 					if pathTraversesUp(mc.SourceAddrRaw) {
@@ -438,3 +438,12 @@ func (h installHooksInitDir) Download(moduleAddr, packageAddr string, version *v
 	trimAddr := moduleAddr[len(initFromModuleRootKeyPrefix):]
 	h.Wrapped.Download(trimAddr, packageAddr, version)
 }
+
+func (h installHooksInitDir) PackageSignatureVerified(moduleAddr, packageAddr, signer string) {
+	if !strings.HasPrefix(moduleAddr, initFromModuleRootKeyPrefix) {
+		return
+	}
+
+	trimAddr := moduleAddr[len(initFromModuleRootKeyPrefix):]
+	h.Wrapped.PackageSignatureVerified(trimAddr, packageAddr, signer)
+}
@@ -770,7 +770,10 @@ func (i *ModuleInstaller) installRegistryModule(ctx context.Context, req *config
 		// Indirect locations are handled by the package fetcher, similar to
 		// if the same address had been specified directly in the "source"
 		// argument of the module call.
-		err = fetcher.FetchPackage(ctx, instPath, packageLocation.SourceAddr.Package.String())
+		err = fetcher.FetchPackage(
+			fetchEventsForHooks(hooks, key, packageLocation.SourceAddr.Package.String()).OnContext(ctx),
+			instPath, packageLocation.SourceAddr.Package.String(),
+		)
 		if packageLocation.SourceAddr.Subdir != "" {
 			subDir := filepath.FromSlash(packageLocation.SourceAddr.Subdir)
 			modDir = filepath.Join(modDir, subDir)
@@ -882,7 +885,7 @@ func (i *ModuleInstaller) installGoGetterModule(ctx context.Context, req *config
 		return nil, diags
 	}
 
-	err := fetcher.FetchPackage(ctx, instPath, packageAddr.String())
+	err := fetcher.FetchPackage(fetchEventsForHooks(hooks, key, packageAddr.String()).OnContext(ctx), instPath, packageAddr.String())
 	if err != nil {
 		// go-getter generates a poor error for an invalid relative path, so
 		// we'll detect that case and generate a better one.
@@ -1114,3 +1117,13 @@ func splitAddrSubdir(addr addrs.ModuleSource) (string, string) {
 		return addr.String(), ""
 	}
 }
+
+// fetchEventsForHooks returns a [getmodules.FetchEvents] that reports the
+// events relating to fetching the given package to the given hooks.
+func fetchEventsForHooks(hooks ModuleInstallHooks, key string, packageAddr string) *getmodules.FetchEvents {
+	return &getmodules.FetchEvents{
+		OCISignatureVerified: func(signer string) {
+			hooks.PackageSignatureVerified(key, packageAddr, signer)
+		},
+	}
+}
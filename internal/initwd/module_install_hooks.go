@@ -24,6 +24,11 @@ type ModuleInstallHooks interface {
 	// Install is called for each module that is installed, even if it did
 	// not need to be downloaded from a remote source.
 	Install(moduleAddr string, version *version.Version, localPath string)
+
+	// PackageSignatureVerified is called after downloading a module package
+	// whose cosign signature was accepted by the applicable trust policy,
+	// with signer describing who made the signature.
+	PackageSignatureVerified(moduleAddr, packageAddr, signer string)
 }
 
 // ModuleInstallHooksImpl is a do-nothing implementation of InstallHooks that
@@ -38,4 +43,7 @@ func (h ModuleInstallHooksImpl) Download(moduleAddr, packageAddr string, version
 func (h ModuleInstallHooksImpl) Install(moduleAddr string, version *version.Version, localPath string) {
 }
 
+func (h ModuleInstallHooksImpl) PackageSignatureVerified(moduleAddr, packageAddr, signer string) {
+}
+
 var _ ModuleInstallHooks = ModuleInstallHooksImpl{}
@@ -1053,6 +1053,14 @@ func (h *testInstallHooks) Install(moduleAddr string, version *version.Version,
 	})
 }
 
+func (h *testInstallHooks) PackageSignatureVerified(moduleAddr, packageAddr, signer string) {
+	h.Calls = append(h.Calls, testInstallHookCall{
+		Name:        "PackageSignatureVerified",
+		ModuleAddr:  moduleAddr,
+		PackageAddr: packageAddr,
+	})
+}
+
 // tempChdir copies the contents of the given directory to a temporary
 // directory and changes the test process's current working directory to
 // point to that directory. The temporary directory is deleted and the
@@ -0,0 +1,352 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-versions/versions"
+	version "github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/registry"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// ModuleVersions describes the installed version of the module package for
+// one module call, along with the versions of that package that are available
+// from its module registry or OCI repository.
+type ModuleVersions struct {
+	// Path is the path of the module call in the module tree.
+	Path addrs.Module
+
+	// SourceAddr is the source address of the module call.
+	SourceAddr addrs.ModuleSource
+
+	// Constraints are the version constraints of the module call, which
+	// can only be set for module registry sources.
+	Constraints configs.VersionConstraint
+
+	// Installed is the version of the module package recorded in the
+	// modules manifest, or nil if the installed version is not known.
+	//
+	// For OCI sources this is the version in the "tag" argument of the
+	// source address, if the tag is a version number.
+	Installed *version.Version
+
+	// Available is all of the available versions of the module package,
+	// sorted in ascending order.
+	Available version.Collection
+
+	// Checked is true if the available versions were retrieved, which is
+	// possible only for module registry sources and OCI sources.
+	Checked bool
+
+	// Failed is true if the module call has a module registry or OCI source
+	// but its available versions could not be retrieved, in which case
+	// [CheckModuleVersions] also returns an error diagnostic describing why.
+	Failed bool
+}
+
+// Latest returns the newest available version that is not a prerelease, or
+// nil if there are no such versions.
+func (v ModuleVersions) Latest() *version.Version {
+	for i := len(v.Available) - 1; i >= 0; i-- {
+		if v.Available[i].Prerelease() == "" {
+			return v.Available[i]
+		}
+	}
+	return nil
+}
+
+// Newer returns the available versions that are newer than the installed
+// version, separated into those that "tofu init -upgrade" could select and
+// those that it could not without a change to the configuration.
+//
+// For OCI sources all newer versions are in the second result, because the
+// source address selects a specific tag. Prereleases are included only if
+// the version constraints select them exactly, in the same way as the module
+// installer.
+func (v ModuleVersions) Newer() (allowed, disallowed version.Collection) {
+	_, isRegistry := v.SourceAddr.(addrs.ModuleSourceRegistry)
+	for _, available := range v.Available {
+		if v.Installed != nil && !available.GreaterThan(v.Installed) {
+			continue
+		}
+		switch {
+		case isRegistry && v.Constraints.Check(available) && v.prereleaseAllowed(available):
+			allowed = append(allowed, available)
+		case available.Prerelease() == "":
+			disallowed = append(disallowed, available)
+		}
+	}
+	return allowed, disallowed
+}
+
+func (v ModuleVersions) prereleaseAllowed(available *version.Version) bool {
+	if available.Prerelease() == "" {
+		return true
+	}
+	// As with the module installer, prereleases must be requested exactly
+	// using the constraint syntax of the other versions library.
+	acceptable, err := versions.MeetingConstraintsString(v.Constraints.String())
+	if err != nil {
+		return false
+	}
+	parsed, err := versions.ParseVersion(available.String())
+	if err != nil {
+		return false
+	}
+	return acceptable.Has(parsed)
+}
+
+// ModulePackageVersionSource is the interface used by [CheckModuleVersions]
+// to retrieve the available versions of module packages.
+type ModulePackageVersionSource interface {
+	// RegistryPackageVersions returns the versions of the given package that
+	// are available from its module registry.
+	RegistryPackageVersions(ctx context.Context, addr addrs.ModuleRegistryPackage) (version.Collection, error)
+
+	// OCIRepositoryVersions returns the versions of the module package in
+	// the given OCI repository, based on the names of its tags.
+	OCIRepositoryVersions(ctx context.Context, registryDomain, repositoryName string) (version.Collection, error)
+}
+
+// OCIRepositoryTagLister is the interface used by the source returned from
+// [NewModulePackageVersionSource] to list the tags in an OCI repository.
+//
+// This matches a subset of [getproviders.OCIRepositoryStore], and so the
+// same implementations can be used.
+type OCIRepositoryTagLister interface {
+	Tags(ctx context.Context, last string, fn func(tags []string) error) error
+}
+
+// NewModulePackageVersionSource returns a [ModulePackageVersionSource] that
+// uses the given registry client for module registry sources and the stores
+// returned by the given function for OCI sources.
+func NewModulePackageVersionSource(reg *registry.Client, getOCIRepositoryStore func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryTagLister, error)) ModulePackageVersionSource {
+	return &modulePackageVersionSource{
+		reg:                   reg,
+		getOCIRepositoryStore: getOCIRepositoryStore,
+	}
+}
+
+type modulePackageVersionSource struct {
+	reg                   *registry.Client
+	getOCIRepositoryStore func(ctx context.Context, registryDomain, repositoryName string) (OCIRepositoryTagLister, error)
+}
+
+func (s *modulePackageVersionSource) RegistryPackageVersions(ctx context.Context, addr addrs.ModuleRegistryPackage) (version.Collection, error) {
+	resp, err := s.reg.ModulePackageVersions(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Modules) < 1 {
+		// Should never happen, but since this is a remote service that may
+		// be implemented by third-parties we will handle it gracefully.
+		return nil, fmt.Errorf("the registry returned an invalid response")
+	}
+
+	var ret version.Collection
+	for _, mv := range resp.Modules[0].Versions {
+		v, err := version.NewVersion(mv.Version)
+		if err != nil {
+			log.Printf("[WARN] ignoring invalid version %q for %s: %s", mv.Version, addr, err)
+			continue
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+func (s *modulePackageVersionSource) OCIRepositoryVersions(ctx context.Context, registryDomain, repositoryName string) (version.Collection, error) {
+	store, err := s.getOCIRepositoryStore(ctx, registryDomain, repositoryName)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret version.Collection
+	err = store.Tags(ctx, "", func(tagNames []string) error {
+		for _, tagName := range tagNames {
+			if v := ociTagVersion(tagName); v != nil {
+				ret = append(ret, v)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ociTagVersion returns the version number represented by the given OCI tag
+// name, or nil if the tag name is not a version number.
+//
+// The OCI tag name syntax does not allow the "+" symbol that can appear in
+// the version number syntax, so we expect the underscore to stand in for
+// that, in the same way as for providers in OCI repositories.
+//
+// Module packages are commonly tagged with a "v" prefix, such as "v1.0.0",
+// so we accept that too. The Original method of the result returns the
+// version including the prefix, so that it can be reported in the same form
+// as the tag.
+func ociTagVersion(tagName string) *version.Version {
+	raw := strings.ReplaceAll(tagName, "_", "+")
+	if _, err := versions.ParseVersion(strings.TrimPrefix(raw, "v")); err != nil {
+		return nil
+	}
+	v, err := version.NewVersion(raw)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// CheckModuleVersions walks the given configuration, which must have been
+// loaded from a working directory where the modules are already installed,
+// and describes the installed and available versions of each module call.
+//
+// The result is in depth-first order with the calls of each module sorted by
+// name, and so each call appears after the call of its parent module. The
+// available versions of each distinct package are retrieved only once.
+func CheckModuleVersions(ctx context.Context, config *configs.Config, source ModulePackageVersionSource) ([]ModuleVersions, tfdiags.Diagnostics) {
+	checker := &moduleVersionChecker{
+		source:   source,
+		registry: make(map[addrs.ModuleRegistryPackage]version.Collection),
+		oci:      make(map[string]version.Collection),
+		failed:   make(map[string]bool),
+	}
+	var ret []ModuleVersions
+	diags := checker.walk(ctx, config, &ret)
+	return ret, diags
+}
+
+type moduleVersionChecker struct {
+	source ModulePackageVersionSource
+
+	registry map[addrs.ModuleRegistryPackage]version.Collection
+	oci      map[string]version.Collection
+
+	// failed records the packages that we've already reported an error for,
+	// so that we only report each one once.
+	failed map[string]bool
+}
+
+func (c *moduleVersionChecker) walk(ctx context.Context, config *configs.Config, ret *[]ModuleVersions) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, name := range slices.Sorted(maps.Keys(config.Children)) {
+		child := config.Children[name]
+		mv := ModuleVersions{
+			Path:       child.Path,
+			SourceAddr: child.SourceAddr,
+			Installed:  child.Version,
+		}
+		if call, ok := config.Module.ModuleCalls[name]; ok {
+			mv.Constraints = call.Version
+		}
+
+		var moreDiags tfdiags.Diagnostics
+		switch addr := child.SourceAddr.(type) {
+		case addrs.ModuleSourceRegistry:
+			mv.Available, mv.Checked, moreDiags = c.registryVersions(ctx, addr.Package, child)
+			mv.Failed = !mv.Checked
+		case addrs.ModuleSourceRemote:
+			registryDomain, repositoryName, tag, ok := ociModulePackage(addr.Package)
+			if ok {
+				mv.Installed = ociTagVersion(tag)
+				mv.Available, mv.Checked, moreDiags = c.ociVersions(ctx, registryDomain, repositoryName, child)
+				mv.Failed = !mv.Checked
+			}
+		}
+		diags = diags.Append(moreDiags)
+
+		*ret = append(*ret, mv)
+		diags = diags.Append(c.walk(ctx, child, ret))
+	}
+	return diags
+}
+
+func (c *moduleVersionChecker) registryVersions(ctx context.Context, pkg addrs.ModuleRegistryPackage, config *configs.Config) (version.Collection, bool, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	if available, ok := c.registry[pkg]; ok {
+		return available, true, diags
+	}
+	if c.failed[pkg.String()] {
+		return nil, false, diags
+	}
+
+	available, err := c.source.RegistryPackageVersions(ctx, pkg)
+	if err != nil {
+		c.failed[pkg.String()] = true
+		detail := fmt.Sprintf("Failed to retrieve available versions for module %s from %s: %s.", pkg.ForDisplay(), pkg.Host.ForDisplay(), err)
+		if registry.IsModuleNotFound(err) {
+			detail = fmt.Sprintf("Module %s cannot be found in the module registry at %s.", pkg.ForDisplay(), pkg.Host.ForDisplay())
+		}
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Error accessing remote module registry",
+			Detail:   detail,
+			Subject:  config.SourceAddrRange.Ptr(),
+		})
+		return nil, false, diags
+	}
+	sort.Sort(available)
+	c.registry[pkg] = available
+	return available, true, diags
+}
+
+func (c *moduleVersionChecker) ociVersions(ctx context.Context, registryDomain, repositoryName string, config *configs.Config) (version.Collection, bool, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	key := registryDomain + "/" + repositoryName
+	if available, ok := c.oci[key]; ok {
+		return available, true, diags
+	}
+	if c.failed[key] {
+		return nil, false, diags
+	}
+
+	available, err := c.source.OCIRepositoryVersions(ctx, registryDomain, repositoryName)
+	if err != nil {
+		c.failed[key] = true
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Error accessing OCI repository",
+			Detail:   fmt.Sprintf("Failed to list the tags of oci://%s: %s.", key, err),
+			Subject:  config.SourceAddrRange.Ptr(),
+		})
+		return nil, false, diags
+	}
+	sort.Sort(available)
+	c.oci[key] = available
+	return available, true, diags
+}
+
+// ociModulePackage returns the repository address and the tag from the given
+// module package address if it is an OCI source, or false if it is not.
+//
+// The tag is empty if the source address selects a digest instead.
+func ociModulePackage(pkg addrs.ModulePackage) (registryDomain, repositoryName, tag string, ok bool) {
+	u, err := url.Parse(pkg.String())
+	if err != nil || u.Scheme != "oci" {
+		return "", "", "", false
+	}
+	query := u.Query()
+	tag = query.Get("tag")
+	if tag == "" && query.Get("digest") == "" {
+		tag = "latest" // the same default as the module installer
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), tag, true
+}
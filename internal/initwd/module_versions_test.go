@@ -0,0 +1,343 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package initwd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	version "github.com/hashicorp/go-version"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+func TestCheckModuleVersions(t *testing.T) {
+	network := mustRegistrySource(t, "hashicorp/consul/aws")
+	storage := addrs.ModuleSourceRemote{Package: addrs.ModulePackage("oci://example.com/modules/storage?tag=1.0.0")}
+	queue := addrs.ModuleSourceRemote{Package: addrs.ModulePackage("oci://example.com/modules/queue?tag=v1.0.0")}
+	broken := mustRegistrySource(t, "example/broken/aws")
+
+	// The configuration calls the same registry module twice, to check that
+	// its versions are retrieved only once, and calls a module that can't be
+	// found twice, to check that it's reported only once.
+	config := &configs.Config{
+		Module: &configs.Module{
+			ModuleCalls: map[string]*configs.ModuleCall{
+				"network":  {Name: "network", Version: mustVersionConstraint(t, "~> 1.0")},
+				"network2": {Name: "network2", Version: mustVersionConstraint(t, "~> 2.0")},
+				"broken":   {Name: "broken"},
+				"broken2":  {Name: "broken2"},
+				"storage":  {Name: "storage"},
+				"queue":    {Name: "queue"},
+			},
+		},
+		Children: map[string]*configs.Config{
+			"network": {
+				Path:       addrs.Module{"network"},
+				SourceAddr: network,
+				Version:    version.Must(version.NewVersion("1.1.0")),
+				Module:     &configs.Module{},
+				Children: map[string]*configs.Config{
+					"subnets": {
+						Path:       addrs.Module{"network", "subnets"},
+						SourceAddr: addrs.ModuleSourceLocal("./subnets"),
+						Module:     &configs.Module{},
+					},
+				},
+			},
+			"network2": {
+				Path:       addrs.Module{"network2"},
+				SourceAddr: network,
+				Version:    version.Must(version.NewVersion("2.0.0")),
+				Module:     &configs.Module{},
+			},
+			"broken": {
+				Path:       addrs.Module{"broken"},
+				SourceAddr: broken,
+				Module:     &configs.Module{},
+			},
+			"broken2": {
+				Path:       addrs.Module{"broken2"},
+				SourceAddr: broken,
+				Module:     &configs.Module{},
+			},
+			"storage": {
+				Path:       addrs.Module{"storage"},
+				SourceAddr: storage,
+				Module:     &configs.Module{},
+			},
+			"queue": {
+				Path:       addrs.Module{"queue"},
+				SourceAddr: queue,
+				Module:     &configs.Module{},
+			},
+		},
+	}
+
+	source := &testModulePackageVersionSource{
+		registry: map[string][]string{
+			"registry.opentofu.org/hashicorp/consul/aws": {"2.0.0", "1.0.0", "1.1.0"},
+		},
+		oci: map[string][]string{
+			"example.com/modules/storage": {"1.0.0", "1.1.0"},
+			"example.com/modules/queue":   {"v1.1.0", "v1.0.0"},
+		},
+	}
+	got, diags := CheckModuleVersions(context.Background(), config, source)
+
+	if len(diags) != 1 {
+		t.Fatalf("wrong number of diagnostics %d; want 1\n%s", len(diags), diags.ErrWithWarnings())
+	}
+	if got, want := diags[0].Description().Summary, "Error accessing remote module registry"; got != want {
+		t.Errorf("wrong diagnostic summary %q; want %q", got, want)
+	}
+
+	type result struct {
+		Path      string
+		Installed string
+		Available []string
+		Checked   bool
+		Failed    bool
+	}
+	var results []result
+	for _, mv := range got {
+		r := result{
+			Path:    mv.Path.String(),
+			Checked: mv.Checked,
+			Failed:  mv.Failed,
+		}
+		if mv.Installed != nil {
+			r.Installed = mv.Installed.Original()
+		}
+		for _, v := range mv.Available {
+			r.Available = append(r.Available, v.Original())
+		}
+		results = append(results, r)
+	}
+	want := []result{
+		{Path: "module.broken", Failed: true},
+		{Path: "module.broken2", Failed: true},
+		{Path: "module.network", Installed: "1.1.0", Available: []string{"1.0.0", "1.1.0", "2.0.0"}, Checked: true},
+		{Path: "module.network.module.subnets"},
+		{Path: "module.network2", Installed: "2.0.0", Available: []string{"1.0.0", "1.1.0", "2.0.0"}, Checked: true},
+		{Path: "module.queue", Installed: "v1.0.0", Available: []string{"v1.0.0", "v1.1.0"}, Checked: true},
+		{Path: "module.storage", Installed: "1.0.0", Available: []string{"1.0.0", "1.1.0"}, Checked: true},
+	}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	if got, want := source.calls, 4; got != want {
+		t.Errorf("wrong number of calls to the source %d; want %d", got, want)
+	}
+}
+
+func TestModuleVersions_Newer(t *testing.T) {
+	tests := map[string]struct {
+		mv             ModuleVersions
+		wantAllowed    []string
+		wantDisallowed []string
+	}{
+		"registry": {
+			mv: ModuleVersions{
+				SourceAddr:  mustRegistrySource(t, "hashicorp/consul/aws"),
+				Constraints: mustVersionConstraint(t, "~> 1.0"),
+				Installed:   version.Must(version.NewVersion("1.1.0")),
+				Available:   testVersions("1.0.0", "1.1.0", "1.2.0", "1.3.0-beta1", "2.0.0", "2.1.0-beta1"),
+			},
+			wantAllowed:    []string{"1.2.0"},
+			wantDisallowed: []string{"2.0.0"},
+		},
+		"registry prerelease requested exactly": {
+			mv: ModuleVersions{
+				SourceAddr:  mustRegistrySource(t, "hashicorp/consul/aws"),
+				Constraints: mustVersionConstraint(t, "1.3.0-beta1"),
+				Installed:   version.Must(version.NewVersion("1.3.0-beta1")),
+				Available:   testVersions("1.2.0", "1.3.0-beta1", "1.3.0"),
+			},
+			wantDisallowed: []string{"1.3.0"},
+		},
+		"registry not installed": {
+			mv: ModuleVersions{
+				SourceAddr:  mustRegistrySource(t, "hashicorp/consul/aws"),
+				Constraints: mustVersionConstraint(t, ">= 1.1.0"),
+				Available:   testVersions("1.0.0", "1.1.0"),
+			},
+			wantAllowed:    []string{"1.1.0"},
+			wantDisallowed: []string{"1.0.0"},
+		},
+		"oci": {
+			mv: ModuleVersions{
+				SourceAddr: addrs.ModuleSourceRemote{Package: addrs.ModulePackage("oci://example.com/modules/storage?tag=1.0.0")},
+				Installed:  version.Must(version.NewVersion("1.0.0")),
+				Available:  testVersions("0.9.0", "1.0.0", "1.1.0", "2.0.0-rc1"),
+			},
+			wantDisallowed: []string{"1.1.0"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			allowed, disallowed := tc.mv.Newer()
+			if diff := cmp.Diff(tc.wantAllowed, versionStrings(allowed)); diff != "" {
+				t.Errorf("wrong allowed versions\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantDisallowed, versionStrings(disallowed)); diff != "" {
+				t.Errorf("wrong disallowed versions\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOCIRepositoryVersions(t *testing.T) {
+	source := NewModulePackageVersionSource(nil, func(_ context.Context, registryDomain, repositoryName string) (OCIRepositoryTagLister, error) {
+		if got, want := registryDomain+"/"+repositoryName, "example.com/modules/storage"; got != want {
+			t.Errorf("wrong repository %q; want %q", got, want)
+		}
+		return testOCITagLister{"latest", "v1.0.0", "1.1.0", "v1.2.0-beta1", "v2.0.0_build1", "vnext", "sha256-4b8d2e4c"}, nil
+	})
+
+	got, err := source.OCIRepositoryVersions(context.Background(), "example.com", "modules/storage")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The versions from tags with a "v" prefix keep it, so that they're
+	// reported in the same form as the tag.
+	var gotOriginal []string
+	for _, v := range got {
+		gotOriginal = append(gotOriginal, v.Original())
+	}
+	wantOriginal := []string{"v1.0.0", "1.1.0", "v1.2.0-beta1", "v2.0.0+build1"}
+	if diff := cmp.Diff(wantOriginal, gotOriginal); diff != "" {
+		t.Errorf("wrong versions\n%s", diff)
+	}
+	wantVersions := []string{"1.0.0", "1.1.0", "1.2.0-beta1", "2.0.0+build1"}
+	if diff := cmp.Diff(wantVersions, versionStrings(got)); diff != "" {
+		t.Errorf("wrong normalized versions\n%s", diff)
+	}
+}
+
+func TestOCIModulePackage(t *testing.T) {
+	tests := map[string]struct {
+		pkg            string
+		wantRepository string
+		wantTag        string
+		wantOK         bool
+	}{
+		"tag": {
+			pkg:            "oci://example.com/modules/storage?tag=1.0.0",
+			wantRepository: "example.com/modules/storage",
+			wantTag:        "1.0.0",
+			wantOK:         true,
+		},
+		"v-prefixed tag": {
+			pkg:            "oci://example.com/modules/storage?tag=v1.0.0",
+			wantRepository: "example.com/modules/storage",
+			wantTag:        "v1.0.0",
+			wantOK:         true,
+		},
+		"default tag": {
+			pkg:            "oci://example.com/modules/storage",
+			wantRepository: "example.com/modules/storage",
+			wantTag:        "latest",
+			wantOK:         true,
+		},
+		"digest": {
+			pkg:            "oci://example.com/modules/storage?digest=sha256:4b8d2e4c",
+			wantRepository: "example.com/modules/storage",
+			wantOK:         true,
+		},
+		"git": {
+			pkg: "git::https://example.com/storage.git?ref=v1.0.0",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			registryDomain, repositoryName, tag, ok := ociModulePackage(addrs.ModulePackage(tc.pkg))
+			if ok != tc.wantOK {
+				t.Fatalf("wrong ok %t; want %t", ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got := registryDomain + "/" + repositoryName; got != tc.wantRepository {
+				t.Errorf("wrong repository %q; want %q", got, tc.wantRepository)
+			}
+			if tag != tc.wantTag {
+				t.Errorf("wrong tag %q; want %q", tag, tc.wantTag)
+			}
+		})
+	}
+}
+
+type testModulePackageVersionSource struct {
+	registry map[string][]string
+	oci      map[string][]string
+	calls    int
+}
+
+func (s *testModulePackageVersionSource) RegistryPackageVersions(_ context.Context, addr addrs.ModuleRegistryPackage) (version.Collection, error) {
+	s.calls++
+	raw, ok := s.registry[addr.String()]
+	if !ok {
+		return nil, errors.New("module not found")
+	}
+	return testVersions(raw...), nil
+}
+
+func (s *testModulePackageVersionSource) OCIRepositoryVersions(_ context.Context, registryDomain, repositoryName string) (version.Collection, error) {
+	s.calls++
+	raw, ok := s.oci[registryDomain+"/"+repositoryName]
+	if !ok {
+		return nil, errors.New("repository not found")
+	}
+	return testVersions(raw...), nil
+}
+
+func mustRegistrySource(t *testing.T, raw string) addrs.ModuleSource {
+	t.Helper()
+	addr, err := addrs.ParseModuleSourceRegistry(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func mustVersionConstraint(t *testing.T, raw string) configs.VersionConstraint {
+	t.Helper()
+	constraints, err := version.NewConstraint(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return configs.VersionConstraint{Required: constraints}
+}
+
+func testVersions(raw ...string) version.Collection {
+	ret := make(version.Collection, len(raw))
+	for i, s := range raw {
+		ret[i] = version.Must(version.NewVersion(s))
+	}
+	return ret
+}
+
+func versionStrings(l version.Collection) []string {
+	var ret []string
+	for _, v := range l {
+		ret = append(ret, v.String())
+	}
+	return ret
+}
+
+type testOCITagLister []string
+
+func (l testOCITagLister) Tags(_ context.Context, _ string, fn func(tags []string) error) error {
+	return fn(l)
+}
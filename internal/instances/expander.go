@@ -82,6 +82,14 @@ func (e *Expander) SetModuleForEach(parentAddr addrs.ModuleInstance, callAddr ad
 	e.setModuleExpansion(parentAddr, callAddr, expansionForEach(mapping))
 }
 
+// SetModuleDeferred records that the given module call inside the given parent
+// module instance had its planning deferred because its repetition argument
+// could not be resolved yet. A deferred module call expands to no instances
+// at all.
+func (e *Expander) SetModuleDeferred(parentAddr addrs.ModuleInstance, callAddr addrs.ModuleCall) {
+	e.setModuleExpansion(parentAddr, callAddr, expansionDeferredVal)
+}
+
 // SetResourceSingle records that the given resource inside the given module
 // does not use any repetition arguments and is therefore a singleton.
 func (e *Expander) SetResourceSingle(moduleAddr addrs.ModuleInstance, resourceAddr addrs.Resource) {
@@ -110,6 +118,13 @@ func (e *Expander) SetResourceForEach(moduleAddr addrs.ModuleInstance, resourceA
 	e.setResourceExpansion(moduleAddr, resourceAddr, expansionForEach(mapping))
 }
 
+// SetResourceDeferred records that the given resource inside the given module
+// had its planning deferred because its repetition argument could not be
+// resolved yet. A deferred resource expands to no instances at all.
+func (e *Expander) SetResourceDeferred(moduleAddr addrs.ModuleInstance, resourceAddr addrs.Resource) {
+	e.setResourceExpansion(moduleAddr, resourceAddr, expansionDeferredVal)
+}
+
 // ExpandModule finds the exhaustive set of module instances resulting from
 // the expansion of the given module and all of its ancestor modules.
 //
@@ -22,6 +22,7 @@ func TestExpander(t *testing.T) {
 	count2ModuleAddr := addrs.ModuleCall{Name: "count2"}
 	count0ModuleAddr := addrs.ModuleCall{Name: "count0"}
 	forEachModuleAddr := addrs.ModuleCall{Name: "for_each"}
+	deferredModuleAddr := addrs.ModuleCall{Name: "deferred"}
 	enabledModuleAddr := addrs.ModuleCall{Name: "enabled"}
 	singleResourceAddr := addrs.Resource{
 		Mode: addrs.ManagedResourceMode,
@@ -48,6 +49,11 @@ func TestExpander(t *testing.T) {
 		Type: "test",
 		Name: "enabled",
 	}
+	deferredResourceAddr := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test",
+		Name: "deferred",
+	}
 	eachMap := map[string]cty.Value{
 		"a": cty.NumberIntVal(1),
 		"b": cty.NumberIntVal(2),
@@ -64,6 +70,7 @@ func TestExpander(t *testing.T) {
 	//   - resource test.count2 with count = 2
 	//   - resource test.count0 with count = 0
 	//   - resource test.for_each with for_each = { a = 1, b = 2 }
+	//   - resource test.deferred with an unknown count
 	//   - child module "single" with no count or for_each
 	//     - resource test.single with no count or for_each
 	//     - resource test.count2 with count = 2
@@ -77,6 +84,8 @@ func TestExpander(t *testing.T) {
 	//   - child module for_each with for_each = { a = 1, b = 2 }
 	//     - resource test.single with no count or for_each
 	//     - resource test.count2 with count = 2
+	//   - child module "deferred" with an unknown count
+	//     - resource test.single with no count or for_each
 
 	ex := NewExpander()
 
@@ -95,6 +104,7 @@ func TestExpander(t *testing.T) {
 		ex.SetResourceCount(addrs.RootModuleInstance, count0ResourceAddr, 0)
 		ex.SetResourceForEach(addrs.RootModuleInstance, forEachResourceAddr, eachMap)
 		ex.SetResourceEnabled(addrs.RootModuleInstance, enabledResourceAddr, true)
+		ex.SetResourceDeferred(addrs.RootModuleInstance, deferredResourceAddr)
 
 		ex.SetModuleSingle(addrs.RootModuleInstance, singleModuleAddr)
 		{
@@ -136,6 +146,12 @@ func TestExpander(t *testing.T) {
 			ex.SetResourceSingle(moduleInstanceAddr, singleResourceAddr)
 			ex.SetResourceCount(moduleInstanceAddr, count2ResourceAddr, 2)
 		}
+
+		ex.SetModuleDeferred(addrs.RootModuleInstance, deferredModuleAddr)
+		{
+			// As with module "count0", a deferred module has no instances
+			// and so nothing nested inside it gets registered.
+		}
 	}
 
 	t.Run("root module", func(t *testing.T) {
@@ -160,6 +176,16 @@ func TestExpander(t *testing.T) {
 		}
 	})
 
+	t.Run("resource deferred", func(t *testing.T) {
+		got := ex.ExpandModuleResource(
+			addrs.RootModule,
+			deferredResourceAddr,
+		)
+		want := []addrs.AbsResourceInstance(nil)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
 	t.Run("resource enabled", func(t *testing.T) {
 		got := ex.ExpandModuleResource(
 			addrs.RootModule,
@@ -428,6 +454,23 @@ func TestExpander(t *testing.T) {
 			t.Errorf("wrong result\n%s", diff)
 		}
 	})
+	t.Run("module deferred", func(t *testing.T) {
+		got := ex.ExpandModule(mustModuleAddr(`deferred`))
+		want := []addrs.ModuleInstance(nil)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
+	t.Run("module deferred resource single", func(t *testing.T) {
+		got := ex.ExpandModuleResource(
+			mustModuleAddr(`deferred`),
+			singleResourceAddr,
+		)
+		want := []addrs.AbsResourceInstance(nil)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("wrong result\n%s", diff)
+		}
+	})
 	t.Run("module for_each", func(t *testing.T) {
 		got := ex.ExpandModule(mustModuleAddr(`for_each`))
 		want := []addrs.ModuleInstance{
@@ -106,3 +106,20 @@ func (e expansionForEach) repetitionData(key addrs.InstanceKey) RepetitionData {
 		EachValue: v,
 	}
 }
+
+// expansionDeferred is the expansion for an object whose repetition argument
+// could not be resolved during planning, and whose planning was therefore
+// deferred to a later plan/apply round. It produces no instances at all.
+//
+// expansionDeferredVal is the only valid value of this type.
+type expansionDeferred uintptr
+
+var expansionDeferredVal expansionDeferred
+
+func (e expansionDeferred) instanceKeys() []addrs.InstanceKey {
+	return nil
+}
+
+func (e expansionDeferred) repetitionData(key addrs.InstanceKey) RepetitionData {
+	panic("cannot use instance key with deferred object")
+}
@@ -253,6 +253,9 @@ func enhanceFunctionDiag(diag *hcl.Diagnostic, funcExtra hclsyntax.FunctionCallU
 		// Error is in core namespace, mirror non-core equivalent
 		enhanced.Summary = "Call to unknown function"
 		enhanced.Detail = fmt.Sprintf("There is no builtin (%s::) function named %q.", addrs.FunctionNamespaceCore, funcName)
+	} else if fn.IsNamespace(addrs.FunctionNamespaceModule) {
+		enhanced.Summary = "Call to unknown function"
+		enhanced.Detail = fmt.Sprintf("There is no function named %q declared in a \"function\" block of this module.", funcName)
 	} else if fn.IsNamespace(addrs.FunctionNamespaceProvider) {
 		if _, err := fn.AsProviderFunction(); err != nil {
 			// complete mismatch or invalid prefix
@@ -332,6 +335,14 @@ func (s *Scope) EvalContextWithParent(ctx context.Context, p *hcl.EvalContext, r
 }
 
 func (s *Scope) evalContext(ctx context.Context, parent *hcl.EvalContext, refs []*addrs.Reference, selfAddr addrs.Referenceable) (*hcl.EvalContext, tfdiags.Diagnostics) {
+	return s.evalContextVisiting(ctx, parent, refs, selfAddr, nil)
+}
+
+// evalContextVisiting is the implementation of evalContext. The visiting set
+// contains the names of the block-local values that are currently being
+// evaluated, so that references between them that form a cycle can be
+// reported instead of recursing forever.
+func (s *Scope) evalContextVisiting(ctx context.Context, parent *hcl.EvalContext, refs []*addrs.Reference, selfAddr addrs.Referenceable, visiting map[string]bool) (*hcl.EvalContext, tfdiags.Diagnostics) {
 	if s == nil {
 		panic("attempt to construct EvalContext for nil Scope")
 	}
@@ -353,6 +364,23 @@ func (s *Scope) evalContext(ctx context.Context, parent *hcl.EvalContext, refs [
 		return hclCtx, diags
 	}
 
+	// References to block-local values are resolved within this scope rather
+	// than by our data source, so we set them aside before validation.
+	var blockLocalRefs []*addrs.Reference
+	if len(s.BlockLocals) != 0 {
+		var moduleRefs []*addrs.Reference
+		for _, ref := range refs {
+			if subj, ok := ref.Subject.(addrs.LocalValue); ok {
+				if _, exists := s.BlockLocals[subj.Name]; exists {
+					blockLocalRefs = append(blockLocalRefs, ref)
+					continue
+				}
+			}
+			moduleRefs = append(moduleRefs, ref)
+		}
+		refs = moduleRefs
+	}
+
 	// First we'll do static validation of the references. This catches things
 	// early that might otherwise not get caught due to unknown values being
 	// present in the scope during planning.
@@ -409,11 +437,53 @@ func (s *Scope) evalContext(ctx context.Context, parent *hcl.EvalContext, refs [
 		diags = diags.Append(varBuilder.putValueBySubject(ctx, ref))
 	}
 
+	for _, ref := range blockLocalRefs {
+		name := ref.Subject.(addrs.LocalValue).Name
+		if _, done := varBuilder.localValues[name]; done {
+			continue
+		}
+		val, valDiags := s.evalBlockLocal(ctx, name, selfAddr, ref.SourceRange, visiting)
+		diags = diags.Append(valDiags)
+		varBuilder.localValues[name] = val
+	}
+
 	varBuilder.buildAllVariablesInto(hclCtx.Variables)
 
 	return hclCtx, diags
 }
 
+// evalBlockLocal evaluates the block-local value with the given name.
+func (s *Scope) evalBlockLocal(ctx context.Context, name string, selfAddr addrs.Referenceable, rng tfdiags.SourceRange, visiting map[string]bool) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if visiting[name] {
+		return cty.DynamicVal, diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Cycle in local values",
+			Detail:   fmt.Sprintf("The local value %q refers to itself, either directly or through other local values declared in the same block.", name),
+			Subject:  rng.ToHCL().Ptr(),
+		})
+	}
+
+	expr := s.BlockLocals[name]
+	refs, refDiags := ReferencesInExpr(s.ParseRef, expr)
+	diags = diags.Append(refDiags)
+
+	nested := make(map[string]bool, len(visiting)+1)
+	maps.Copy(nested, visiting)
+	nested[name] = true
+
+	hclCtx, ctxDiags := s.evalContextVisiting(ctx, nil, refs, selfAddr, nested)
+	diags = diags.Append(ctxDiags)
+	if diags.HasErrors() {
+		return cty.DynamicVal, diags
+	}
+
+	val, valDiags := expr.Value(hclCtx)
+	diags = diags.Append(enhanceFunctionDiags(valDiags))
+	return normalizeRefValue(val, diags)
+}
+
 type evalVarBuilder struct {
 	s *Scope
 
@@ -550,7 +620,7 @@ func (b *evalVarBuilder) putValueBySubject(ctx context.Context, ref *addrs.Refer
 		b.outputValues[subj.Name], normDiags = normalizeRefValue(b.s.Data.GetOutput(ctx, subj, rng))
 
 	case addrs.Check:
-		b.outputValues[subj.Name], normDiags = normalizeRefValue(b.s.Data.GetCheckBlock(ctx, subj, rng))
+		b.checkBlocks[subj.Name], normDiags = normalizeRefValue(b.s.Data.GetCheckBlock(ctx, subj, rng))
 
 	default:
 		// Should never happen
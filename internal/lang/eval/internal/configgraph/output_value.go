@@ -8,6 +8,7 @@ package configgraph
 import (
 	"context"
 	"fmt"
+	"iter"
 	"slices"
 
 	"github.com/apparentlymart/go-workgraph/workgraph"
@@ -53,6 +54,12 @@ type OutputValue struct {
 	// If ForceEphemeral is true then the final value will be marked as
 	// ephemeral regardless of whether the associated raw value was ephemeral.
 	ForceEphemeral bool
+
+	// CompileValidationRules takes the value of the output value after type
+	// conversion and returns a sequence of compiled [CheckRule] objects
+	// that test whether the author's configured validation conditions have
+	// been met for that value.
+	CompileValidationRules func(ctx context.Context, value cty.Value) iter.Seq[*CheckRule]
 }
 
 var _ exprs.Valuer = (*OutputValue)(nil)
@@ -132,7 +139,30 @@ func (o *OutputValue) Value(ctx context.Context) (cty.Value, tfdiags.Diagnostics
 		finalV = exprs.AsEvalError(cty.UnknownVal(o.TargetType.WithoutOptionalAttributesDeep())).WithMarks(preconditionMarks)
 	}
 
-	finalV = finalV.WithMarks(preconditionMarks)
+	var validationMarks cty.ValueMarks
+	if o.CompileValidationRules != nil && !diags.HasErrors() {
+		validationMarks, moreDiags = CheckAllRules(ctx,
+			o.CompileValidationRules(ctx, finalV),
+			func(ruleDeclRange tfdiags.SourceRange, status checks.Status, errMsg string) tfdiags.Diagnostics {
+				var diags tfdiags.Diagnostics
+				if status == checks.StatusFail {
+					diags = diags.Append(&hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid value for output value",
+						Detail:   fmt.Sprintf("%s\n\nThis problem was reported by the validation rule at %s.", errMsg, ruleDeclRange.StartString()),
+						Subject:  MaybeHCLSourceRange(o.ValueSourceRange()),
+					})
+				}
+				return diags
+			},
+		)
+		diags = diags.Append(moreDiags)
+		if moreDiags.HasErrors() {
+			finalV = exprs.AsEvalError(cty.UnknownVal(o.TargetType.WithoutOptionalAttributesDeep()))
+		}
+	}
+
+	finalV = finalV.WithMarks(preconditionMarks, validationMarks)
 	if o.ForceSensitive {
 		finalV = finalV.Mark(marks.Sensitive)
 	}
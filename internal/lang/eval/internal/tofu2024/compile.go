@@ -93,6 +93,7 @@ func CompileModuleInstance(
 		rootDir:    call.EvalContext.RootModuleDir,
 		sourceDir:  module.SourceDir,
 	}
+	topScope.moduleFunctions = compileModuleFunctions(module, topScope.coreFunctions)
 
 	ret.providerRequirements = func(ctx context.Context) (getproviders.Requirements, *getproviders.ProvidersQualification, tfdiags.Diagnostics) {
 		fakeCfg := &configs.Config{Module: module}
@@ -33,6 +33,12 @@ func compileCoreFunctions(_ context.Context, allowImpureFuncs bool, baseDir stri
 	return oldScope.Functions()
 }
 
+// compileModuleFunctions prepares the table of functions declared in "function"
+// blocks of the given module, which can call any of the given core functions.
+func compileModuleFunctions(module *configs.Module, coreFunctions map[string]function.Function) map[string]function.Function {
+	return lang.MakeUserFunctions(module.UserFunctions(), coreFunctions)
+}
+
 // compileProviderFunctions builds a lookup function that can supply a cty function
 // given a provider function address. This is wired into the module instance's scope
 // to provide custom functions.
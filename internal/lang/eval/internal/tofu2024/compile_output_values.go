@@ -7,6 +7,7 @@ package tofu2024
 
 import (
 	"context"
+	"iter"
 	"slices"
 
 	"github.com/zclconf/go-cty/cty"
@@ -31,6 +32,11 @@ func compileModuleInstanceOutputValues(
 
 		dependsOn, _ := compileDependsOn(vc.DependsOn, declScope, extraMarks)
 
+		targetType := vc.ConstraintType
+		if targetType == cty.NilType {
+			targetType = cty.DynamicPseudoType
+		}
+
 		value := configgraph.ValuerOnce(exprs.DerivedValuerContext(
 			exprs.NewClosure(exprs.EvalableHCLExpression(vc.Expr), declScope),
 			func(ctx context.Context, v cty.Value, diags tfdiags.Diagnostics) (cty.Value, tfdiags.Diagnostics) {
@@ -43,18 +49,19 @@ func compileModuleInstanceOutputValues(
 			Addr:     moduleInstAddr.OutputValue(name),
 			RawValue: value,
 
-			// Our current language doesn't allow specifying a type constraint
-			// for an output value, so these are always the most liberal
-			// possible constraint. Making these customizable could be part
-			// of a solution to:
-			//     https://github.com/opentofu/opentofu/issues/2831
-			TargetType:     cty.DynamicPseudoType,
-			TargetDefaults: nil,
+			TargetType:     targetType,
+			TargetDefaults: vc.TypeDefaults,
 
 			ForceSensitive: vc.Sensitive,
 			ForceEphemeral: vc.Ephemeral,
 			Preconditions:  slices.Collect(compileCheckRules(vc.Preconditions, declScope)),
 		}
+		if len(vc.Validations) != 0 {
+			ret[addr].CompileValidationRules = func(ctx context.Context, value cty.Value) iter.Seq[*configgraph.CheckRule] {
+				// Validation rules refer to the final value as "self".
+				return compileCheckRules(vc.Validations, selfScope(declScope, value, "", ""))
+			}
+		}
 	}
 	return ret
 }
@@ -45,6 +45,7 @@ import (
 type moduleInstanceScope struct {
 	inst              *CompiledModuleInstance
 	coreFunctions     map[string]function.Function
+	moduleFunctions   map[string]function.Function
 	providerFunctions func(context.Context, addrs.ProviderFunction, hcl.Range) (function.Function, tfdiags.Diagnostics)
 
 	// tofu attrs
@@ -80,6 +81,19 @@ func (m *moduleInstanceScope) ResolveFunc(ctx context.Context, call *hcl.StaticC
 			return function.Function{}, diags
 		}
 
+		return fn, diags
+	case addrs.FunctionNamespaceModule:
+		fn, ok := m.moduleFunctions[call.Name]
+		if !ok {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Call to unknown function",
+				Detail:   fmt.Sprintf("There is no function named %q declared in a \"function\" block of this module.", parsed.Name),
+				Subject:  &call.NameRange,
+			})
+			return function.Function{}, diags
+		}
+
 		return fn, diags
 	case addrs.FunctionNamespaceProvider:
 		pf, err := parsed.AsProviderFunction()
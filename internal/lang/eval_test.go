@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -558,6 +559,64 @@ func TestScopeEvalContextWithParent(t *testing.T) {
 	})
 }
 
+func TestScopeEvalExpr_blockLocals(t *testing.T) {
+	parse := func(src string) hcl.Expression {
+		expr, diags := hclsyntax.ParseExpression([]byte(src), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatal(diags.Error())
+		}
+		return expr
+	}
+
+	data := &dataForTests{
+		ForEachAttrs: map[string]cty.Value{
+			"key": cty.StringVal("a"),
+		},
+		LocalValues: map[string]cty.Value{
+			"prefix": cty.StringVal("app"),
+		},
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		scope := &Scope{
+			Data:     data,
+			ParseRef: addrs.ParseRef,
+			BlockLocals: map[string]hcl.Expression{
+				"name":  parse(`"${local.prefix}-${each.key}"`),
+				"upper": parse(`upper(local.name)`),
+			},
+		}
+
+		got, diags := scope.EvalExpr(t.Context(), parse(`[local.name, local.upper]`), cty.DynamicPseudoType)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		want := cty.TupleVal([]cty.Value{cty.StringVal("app-a"), cty.StringVal("APP-A")})
+		if !got.RawEquals(want) {
+			t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		scope := &Scope{
+			Data:     data,
+			ParseRef: addrs.ParseRef,
+			BlockLocals: map[string]hcl.Expression{
+				"a": parse(`local.b`),
+				"b": parse(`local.a`),
+			},
+		}
+
+		_, diags := scope.EvalExpr(t.Context(), parse(`local.a`), cty.DynamicPseudoType)
+		if !diags.HasErrors() {
+			t.Fatal("expected errors")
+		}
+		if got, want := diags.Err().Error(), "Cycle in local values"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
 func TestScopeExpandEvalBlock(t *testing.T) {
 	nestedObjTy := cty.Object(map[string]cty.Type{
 		"boop": cty.String,
@@ -1020,7 +1079,13 @@ func Test_enhanceFunctionDiags(t *testing.T) {
 			"Invalid prefix",
 			"attr = magic::missing_function(54)",
 			"Unknown function namespace",
-			"Function \"magic::missing_function\" does not exist within a valid namespace (provider,core)",
+			"Function \"magic::missing_function\" does not exist within a valid namespace (provider,core,module)",
+		},
+		{
+			"Missing module function",
+			"attr = module::missing_function(54)",
+			"Call to unknown function",
+			"There is no function named \"missing_function\" declared in a \"function\" block of this module.",
 		},
 		{
 			"Too many namespaces",
@@ -8,6 +8,9 @@ package funcs
 import (
 	"fmt"
 	"math/big"
+	"net"
+	"net/netip"
+	"sort"
 
 	"github.com/apparentlymart/go-cidr/cidr"
 	"github.com/opentofu/opentofu/internal/ipaddr"
@@ -263,6 +266,421 @@ var CidrContainsFunc = function.New(&function.Spec{
 	},
 })
 
+// CidrOverlapFunc constructs a function that checks whether two IP network
+// address prefixes have any addresses in common.
+var CidrOverlapFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "a",
+			Type: cty.String,
+		},
+		{
+			Name: "b",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Bool),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		a, err := parsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), function.NewArgError(0, err)
+		}
+		b, err := parsePrefix(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), function.NewArgError(1, err)
+		}
+		if a.Addr().Is4() != b.Addr().Is4() {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("address family mismatch: %s vs. %s", args[0].AsString(), args[1].AsString())
+		}
+
+		return cty.BoolVal(a.Overlaps(b)), nil
+	},
+})
+
+// CidrMergeFunc constructs a function that collapses a list of IP network
+// address prefixes into the smallest list of prefixes covering the same
+// addresses.
+var CidrMergeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefixes",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type:         function.StaticReturnType(cty.List(cty.String)),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		if !args[0].IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		prefixes, err := parsePrefixList(args[0], 0)
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+
+		var v4, v6 []addrRange
+		for _, p := range prefixes {
+			if p.Addr().Is4() {
+				v4 = append(v4, prefixRange(p))
+			} else {
+				v6 = append(v6, prefixRange(p))
+			}
+		}
+
+		var result []netip.Prefix
+		for _, r := range mergeAddrRanges(v4) {
+			result = append(result, r.prefixes()...)
+		}
+		for _, r := range mergeAddrRanges(v6) {
+			result = append(result, r.prefixes()...)
+		}
+		return prefixListVal(result), nil
+	},
+})
+
+// CidrExcludeFunc constructs a function that removes a list of IP network
+// address prefixes from a base prefix, returning the smallest list of
+// prefixes covering the remaining addresses.
+var CidrExcludeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "exclude",
+			Type: cty.List(cty.String),
+		},
+	},
+	Type:         function.StaticReturnType(cty.List(cty.String)),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		base, err := parsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+		if !args[1].IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		excluded, err := parsePrefixList(args[1], 1)
+		if err != nil {
+			return cty.UnknownVal(retType), err
+		}
+
+		var excludedRanges []addrRange
+		for _, p := range excluded {
+			if p.Addr().Is4() != base.Addr().Is4() {
+				return cty.UnknownVal(retType), function.NewArgErrorf(1, "address family mismatch: %s vs. %s", base, p)
+			}
+			excludedRanges = append(excludedRanges, prefixRange(p))
+		}
+
+		// We walk the merged exclusions in order, keeping whatever part of
+		// the base range lies before each one.
+		var remaining []addrRange
+		current := prefixRange(base)
+		done := false
+		for _, ex := range mergeAddrRanges(excludedRanges) {
+			if ex.last.Less(current.first) {
+				continue
+			}
+			if current.last.Less(ex.first) {
+				break
+			}
+			if current.first.Less(ex.first) {
+				remaining = append(remaining, addrRange{current.first, ex.first.Prev()})
+			}
+			if !ex.last.Less(current.last) {
+				done = true
+				break
+			}
+			current.first = ex.last.Next()
+		}
+		if !done {
+			remaining = append(remaining, current)
+		}
+
+		var result []netip.Prefix
+		for _, r := range remaining {
+			result = append(result, r.prefixes()...)
+		}
+		return prefixListVal(result), nil
+	},
+})
+
+// CidrEUI64Func constructs a function that calculates the IPv6 address formed
+// from a network prefix and the modified EUI-64 interface identifier derived
+// from a MAC address, as described in RFC 4291 Appendix A.
+var CidrEUI64Func = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "prefix",
+			Type: cty.String,
+		},
+		{
+			Name: "mac",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		prefix, err := parsePrefix(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		if !prefix.Addr().Is6() {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "EUI-64 addresses are only defined for IPv6 prefixes")
+		}
+		if prefix.Bits() > 64 {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(0, "prefix must be no longer than 64 bits to leave room for an EUI-64 interface identifier")
+		}
+		hw, err := net.ParseMAC(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "invalid MAC address: %s", args[1].AsString())
+		}
+
+		var iid []byte
+		switch len(hw) {
+		case 6:
+			iid = []byte{hw[0], hw[1], hw[2], 0xff, 0xfe, hw[3], hw[4], hw[5]}
+		case 8:
+			iid = append([]byte(nil), hw...)
+		default:
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "MAC address must be in EUI-48 or EUI-64 format")
+		}
+		// The "modified" EUI-64 format inverts the universal/local bit.
+		iid[0] ^= 0x02
+
+		addr := prefix.Addr().As16()
+		copy(addr[8:], iid)
+		return cty.StringVal(netip.AddrFrom16(addr).String()), nil
+	},
+})
+
+// IPParseFunc constructs a function that parses an IP address and returns an
+// object describing it.
+var IPParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "address",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(ipObjectType),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		addr, err := parseAddr(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"address":           cty.StringVal(addr.String()),
+			"version":           cty.NumberIntVal(int64(ipVersion(addr))),
+			"is_private":        cty.BoolVal(addr.IsPrivate()),
+			"is_loopback":       cty.BoolVal(addr.IsLoopback()),
+			"is_link_local":     cty.BoolVal(addr.IsLinkLocalUnicast()),
+			"is_multicast":      cty.BoolVal(addr.IsMulticast()),
+			"is_unspecified":    cty.BoolVal(addr.IsUnspecified()),
+			"is_global_unicast": cty.BoolVal(addr.IsGlobalUnicast()),
+		}), nil
+	},
+})
+
+// IPIncFunc constructs a function that adds a given number, which may be
+// negative, to an IP address.
+var IPIncFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "address",
+			Type: cty.String,
+		},
+		{
+			Name: "n",
+			Type: cty.Number,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		addr, err := parseAddr(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(0, err)
+		}
+		var n *big.Int
+		if err := gocty.FromCtyValue(args[1], &n); err != nil {
+			return cty.UnknownVal(cty.String), function.NewArgError(1, err)
+		}
+
+		raw := addr.AsSlice()
+		sum := new(big.Int).SetBytes(raw)
+		sum.Add(sum, n)
+		if sum.Sign() < 0 || sum.BitLen() > addr.BitLen() {
+			return cty.UnknownVal(cty.String), function.NewArgErrorf(1, "adding %s to %s would go outside of the IPv%d address space", n, addr, ipVersion(addr))
+		}
+		result, _ := netip.AddrFromSlice(sum.FillBytes(make([]byte, len(raw))))
+		return cty.StringVal(result.String()), nil
+	},
+})
+
+var ipObjectType = cty.Object(map[string]cty.Type{
+	"address":           cty.String,
+	"version":           cty.Number,
+	"is_private":        cty.Bool,
+	"is_loopback":       cty.Bool,
+	"is_link_local":     cty.Bool,
+	"is_multicast":      cty.Bool,
+	"is_unspecified":    cty.Bool,
+	"is_global_unicast": cty.Bool,
+})
+
+func ipVersion(addr netip.Addr) int {
+	if addr.Is4() {
+		return 4
+	}
+	return 6
+}
+
+// parsePrefix parses a CIDR prefix with the same leniency as the other CIDR
+// functions, discarding any host bits.
+func parsePrefix(s string) (netip.Prefix, error) {
+	_, network, err := ipaddr.ParseCIDR(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR expression: %w", err)
+	}
+	addr, _ := netip.AddrFromSlice(network.IP)
+	bits, _ := network.Mask.Size()
+	// As in cidrsubnet, an IPv4 prefix written in IPv4-mapped IPv6 syntax is
+	// normalized to IPv4, which also needs its prefix length converted. A
+	// prefix shorter than 96 bits can't have the IPv4-mapped form after its
+	// host bits are discarded, and so it stays IPv6.
+	if addr.Is4In6() {
+		addr = addr.Unmap()
+		bits -= 96
+	}
+	return netip.PrefixFrom(addr, bits), nil
+}
+
+// parseAddr parses an IP address with the same leniency as the CIDR
+// functions.
+func parseAddr(s string) (netip.Addr, error) {
+	ip := ipaddr.ParseIP(s)
+	if ip == nil {
+		return netip.Addr{}, fmt.Errorf("invalid IP address: %s", s)
+	}
+	addr, _ := netip.AddrFromSlice(ip)
+	return addr.Unmap(), nil
+}
+
+// parsePrefixList parses a known list of strings that may each be either a
+// CIDR prefix or a single IP address, which is treated as a prefix containing
+// only that address.
+func parsePrefixList(list cty.Value, argIdx int) ([]netip.Prefix, error) {
+	var ret []netip.Prefix
+	for it := list.ElementIterator(); it.Next(); {
+		idx, v := it.Element()
+		if v.IsNull() {
+			return nil, function.NewArgErrorf(argIdx, "element %s is null", idx.AsBigFloat().String())
+		}
+		s := v.AsString()
+		if addr, err := parseAddr(s); err == nil {
+			ret = append(ret, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		p, err := parsePrefix(s)
+		if err != nil {
+			return nil, function.NewArgErrorf(argIdx, "element %s: %s", idx.AsBigFloat().String(), err)
+		}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func prefixListVal(prefixes []netip.Prefix) cty.Value {
+	if len(prefixes) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	vals := make([]cty.Value, len(prefixes))
+	for i, p := range prefixes {
+		vals[i] = cty.StringVal(p.String())
+	}
+	return cty.ListVal(vals)
+}
+
+// addrRange is an inclusive range of addresses of the same family.
+type addrRange struct {
+	first, last netip.Addr
+}
+
+func prefixRange(p netip.Prefix) addrRange {
+	raw := p.Addr().AsSlice()
+	for i := p.Bits(); i < len(raw)*8; i++ {
+		raw[i/8] |= 1 << (7 - i%8)
+	}
+	last, _ := netip.AddrFromSlice(raw)
+	return addrRange{first: p.Addr(), last: last}
+}
+
+// mergeAddrRanges sorts the given ranges and combines any that overlap or
+// are adjacent.
+func mergeAddrRanges(ranges []addrRange) []addrRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+	sorted := append([]addrRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].first.Less(sorted[j].first)
+	})
+
+	ret := []addrRange{sorted[0]}
+	for _, r := range sorted[1:] {
+		cur := &ret[len(ret)-1]
+		next := cur.last.Next()
+		if !next.IsValid() || !next.Less(r.first) {
+			// Next is invalid only when cur already extends to the last
+			// address in the address space, and so covers r entirely.
+			if cur.last.Less(r.last) {
+				cur.last = r.last
+			}
+			continue
+		}
+		ret = append(ret, r)
+	}
+	return ret
+}
+
+// prefixes returns the smallest list of prefixes that together cover exactly
+// the addresses in the range, in ascending order.
+func (r addrRange) prefixes() []netip.Prefix {
+	var ret []netip.Prefix
+	first := r.first
+	for {
+		// Find the largest aligned prefix starting at first that doesn't
+		// extend past the end of the range.
+		var p netip.Prefix
+		for bits := 0; bits <= first.BitLen(); bits++ {
+			candidate := netip.PrefixFrom(first, bits)
+			if candidate.Masked().Addr() != first {
+				continue
+			}
+			if r.last.Less(prefixRange(candidate).last) {
+				continue
+			}
+			p = candidate
+			break
+		}
+		ret = append(ret, p)
+
+		last := prefixRange(p).last
+		if last == r.last {
+			return ret
+		}
+		first = last.Next()
+	}
+}
+
 // CidrHost calculates a full host IP address within a given IP network address prefix.
 func CidrHost(prefix, hostnum cty.Value) (cty.Value, error) {
 	return CidrHostFunc.Call([]cty.Value{prefix, hostnum})
@@ -291,3 +709,36 @@ func CidrSubnets(prefix cty.Value, newbits ...cty.Value) (cty.Value, error) {
 func CidrContains(prefix, address cty.Value) (cty.Value, error) {
 	return CidrContainsFunc.Call([]cty.Value{prefix, address})
 }
+
+// CidrOverlap checks whether two IP network address prefixes have any
+// addresses in common.
+func CidrOverlap(a, b cty.Value) (cty.Value, error) {
+	return CidrOverlapFunc.Call([]cty.Value{a, b})
+}
+
+// CidrMerge collapses a list of IP network address prefixes into the smallest
+// list of prefixes covering the same addresses.
+func CidrMerge(prefixes cty.Value) (cty.Value, error) {
+	return CidrMergeFunc.Call([]cty.Value{prefixes})
+}
+
+// CidrExclude removes a list of IP network address prefixes from a base
+// prefix.
+func CidrExclude(prefix, exclude cty.Value) (cty.Value, error) {
+	return CidrExcludeFunc.Call([]cty.Value{prefix, exclude})
+}
+
+// CidrEUI64 calculates an IPv6 address from a prefix and a MAC address.
+func CidrEUI64(prefix, mac cty.Value) (cty.Value, error) {
+	return CidrEUI64Func.Call([]cty.Value{prefix, mac})
+}
+
+// IPParse parses an IP address into an object describing it.
+func IPParse(address cty.Value) (cty.Value, error) {
+	return IPParseFunc.Call([]cty.Value{address})
+}
+
+// IPInc adds a given number to an IP address.
+func IPInc(address, n cty.Value) (cty.Value, error) {
+	return IPIncFunc.Call([]cty.Value{address, n})
+}
@@ -571,3 +571,553 @@ func TestCidrContains(t *testing.T) {
 		})
 	}
 }
+
+func TestCidrOverlap(t *testing.T) {
+	tests := []struct {
+		A    cty.Value
+		B    cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.StringVal("10.0.128.0/24"),
+			cty.True,
+			"",
+		},
+		{
+			cty.StringVal("10.0.128.0/24"),
+			cty.StringVal("10.0.0.0/16"),
+			cty.True,
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.StringVal("10.0.1.0/24"),
+			cty.False,
+			"",
+		},
+		{
+			cty.StringVal("fd00::/8"),
+			cty.StringVal("fd12:3456::/32"),
+			cty.True,
+			"",
+		},
+		{ // IPv4 prefix encoded in IPv6 syntax gets normalized
+			cty.StringVal("::ffff:10.0.0.0/112"),
+			cty.StringVal("10.1.0.0/16"),
+			cty.False,
+			"",
+		},
+		{
+			cty.StringVal("::ffff:10.0.0.0/112"),
+			cty.StringVal("10.0.128.0/24"),
+			cty.True,
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/16"),
+			cty.StringVal("fd00::/8"),
+			cty.UnknownVal(cty.Bool),
+			"address family mismatch: 10.0.0.0/16 vs. fd00::/8",
+		},
+		{
+			cty.StringVal("not-a-cidr"),
+			cty.StringVal("10.0.0.0/16"),
+			cty.UnknownVal(cty.Bool),
+			"invalid CIDR expression: invalid CIDR address: not-a-cidr",
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.StringVal("10.0.0.0/16"),
+			cty.UnknownVal(cty.Bool).RefineNotNull(),
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidroverlap(%#v, %#v)", test.A, test.B), func(t *testing.T) {
+			got, err := CidrOverlap(test.A, test.B)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.Err; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrMerge(t *testing.T) {
+	tests := []struct {
+		Prefixes cty.Value
+		Want     cty.Value
+		Err      string
+	}{
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.0.0/24"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/23"),
+			}),
+			"",
+		},
+		{
+			// Overlapping and contained prefixes collapse into one
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/16"),
+				cty.StringVal("10.0.4.0/22"),
+				cty.StringVal("10.0.255.255"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/16"),
+			}),
+			"",
+		},
+		{
+			// Adjacent but unaligned ranges need more than one prefix
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.2.0/24"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.1.0/24"),
+				cty.StringVal("10.0.2.0/24"),
+			}),
+			"",
+		},
+		{
+			// IPv4 prefixes encoded in IPv6 syntax get normalized
+			cty.ListVal([]cty.Value{
+				cty.StringVal("::ffff:10.0.0.0/104"),
+				cty.StringVal("::ffff:0:0/96"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("0.0.0.0/0"),
+			}),
+			"",
+		},
+		{
+			// IPv4 prefixes sort before IPv6 prefixes
+			cty.ListVal([]cty.Value{
+				cty.StringVal("fd00:0:0:1::/64"),
+				cty.StringVal("192.168.0.0/24"),
+				cty.StringVal("fd00::/64"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("192.168.0.0/24"),
+				cty.StringVal("fd00::/63"),
+			}),
+			"",
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("0.0.0.0/1"),
+				cty.StringVal("128.0.0.0/1"),
+				cty.StringVal("255.255.255.255/32"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("0.0.0.0/0"),
+			}),
+			"",
+		},
+		{
+			cty.ListValEmpty(cty.String),
+			cty.ListValEmpty(cty.String),
+			"",
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+				cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.List(cty.String)).RefineNotNull(),
+			"",
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+				cty.StringVal("bad"),
+			}),
+			cty.UnknownVal(cty.List(cty.String)),
+			"element 1: invalid CIDR expression: invalid CIDR address: bad",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrmerge(%#v)", test.Prefixes), func(t *testing.T) {
+			got, err := CidrMerge(test.Prefixes)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.Err; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrExclude(t *testing.T) {
+	tests := []struct {
+		Prefix  cty.Value
+		Exclude cty.Value
+		Want    cty.Value
+		Err     string
+	}{
+		{
+			cty.StringVal("::ffff:10.0.0.0/120"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/25"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.128/25"),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/26"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.64/26"),
+				cty.StringVal("10.0.0.128/25"),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.64/26"),
+				cty.StringVal("10.0.0.255"),
+				cty.StringVal("192.168.0.0/16"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/26"),
+				cty.StringVal("10.0.0.128/26"),
+				cty.StringVal("10.0.0.192/27"),
+				cty.StringVal("10.0.0.224/28"),
+				cty.StringVal("10.0.0.240/29"),
+				cty.StringVal("10.0.0.248/30"),
+				cty.StringVal("10.0.0.252/31"),
+				cty.StringVal("10.0.0.254/32"),
+			}),
+			"",
+		},
+		{
+			// Excluding a larger prefix leaves nothing
+			cty.StringVal("10.0.0.0/24"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/8"),
+			}),
+			cty.ListValEmpty(cty.String),
+			"",
+		},
+		{
+			cty.StringVal("fd00::/62"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("fd00:0:0:1::/64"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("fd00::/64"),
+				cty.StringVal("fd00:0:0:2::/63"),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.ListValEmpty(cty.String),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("10.0.0.0/24"),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("fd00::/8"),
+			}),
+			cty.UnknownVal(cty.List(cty.String)),
+			"address family mismatch: 10.0.0.0/24 vs. fd00::/8",
+		},
+		{
+			cty.StringVal("10.0.0.0/24"),
+			cty.UnknownVal(cty.List(cty.String)),
+			cty.UnknownVal(cty.List(cty.String)).RefineNotNull(),
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidrexclude(%#v, %#v)", test.Prefix, test.Exclude), func(t *testing.T) {
+			got, err := CidrExclude(test.Prefix, test.Exclude)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.Err; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestCidrEUI64(t *testing.T) {
+	tests := []struct {
+		Prefix cty.Value
+		MAC    cty.Value
+		Want   cty.Value
+		Err    string
+	}{
+		{
+			cty.StringVal("2001:db8::/64"),
+			cty.StringVal("00:1a:2b:3c:4d:5e"),
+			cty.StringVal("2001:db8::21a:2bff:fe3c:4d5e"),
+			"",
+		},
+		{
+			cty.StringVal("2001:db8:0:1::/56"),
+			cty.StringVal("02-00-5e-10-00-00"),
+			cty.StringVal("2001:db8::5eff:fe10:0"),
+			"",
+		},
+		{
+			// EUI-64 identifiers are used as-is apart from the U/L bit
+			cty.StringVal("fe80::/64"),
+			cty.StringVal("00:1a:2b:ff:fe:3c:4d:5e"),
+			cty.StringVal("fe80::21a:2bff:fe3c:4d5e"),
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.StringVal("00:1a:2b:3c:4d:5e"),
+			cty.UnknownVal(cty.String),
+			"EUI-64 addresses are only defined for IPv6 prefixes",
+		},
+		{
+			cty.StringVal("2001:db8::/96"),
+			cty.StringVal("00:1a:2b:3c:4d:5e"),
+			cty.UnknownVal(cty.String),
+			"prefix must be no longer than 64 bits to leave room for an EUI-64 interface identifier",
+		},
+		{
+			cty.StringVal("2001:db8::/64"),
+			cty.StringVal("not-a-mac"),
+			cty.UnknownVal(cty.String),
+			"invalid MAC address: not-a-mac",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("cidreui64(%#v, %#v)", test.Prefix, test.MAC), func(t *testing.T) {
+			got, err := CidrEUI64(test.Prefix, test.MAC)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.Err; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestIPParse(t *testing.T) {
+	tests := []struct {
+		Address cty.Value
+		Want    cty.Value
+		Err     string
+	}{
+		{
+			cty.StringVal("10.1.2.3"),
+			cty.ObjectVal(map[string]cty.Value{
+				"address":           cty.StringVal("10.1.2.3"),
+				"version":           cty.NumberIntVal(4),
+				"is_private":        cty.True,
+				"is_loopback":       cty.False,
+				"is_link_local":     cty.False,
+				"is_multicast":      cty.False,
+				"is_unspecified":    cty.False,
+				"is_global_unicast": cty.True,
+			}),
+			"",
+		},
+		{
+			cty.StringVal("127.0.0.1"),
+			cty.ObjectVal(map[string]cty.Value{
+				"address":           cty.StringVal("127.0.0.1"),
+				"version":           cty.NumberIntVal(4),
+				"is_private":        cty.False,
+				"is_loopback":       cty.True,
+				"is_link_local":     cty.False,
+				"is_multicast":      cty.False,
+				"is_unspecified":    cty.False,
+				"is_global_unicast": cty.False,
+			}),
+			"",
+		},
+		{
+			cty.StringVal("FE80::0001"),
+			cty.ObjectVal(map[string]cty.Value{
+				"address":           cty.StringVal("fe80::1"),
+				"version":           cty.NumberIntVal(6),
+				"is_private":        cty.False,
+				"is_loopback":       cty.False,
+				"is_link_local":     cty.True,
+				"is_multicast":      cty.False,
+				"is_unspecified":    cty.False,
+				"is_global_unicast": cty.False,
+			}),
+			"",
+		},
+		{
+			cty.StringVal("::"),
+			cty.ObjectVal(map[string]cty.Value{
+				"address":           cty.StringVal("::"),
+				"version":           cty.NumberIntVal(6),
+				"is_private":        cty.False,
+				"is_loopback":       cty.False,
+				"is_link_local":     cty.False,
+				"is_multicast":      cty.False,
+				"is_unspecified":    cty.True,
+				"is_global_unicast": cty.False,
+			}),
+			"",
+		},
+		{
+			cty.StringVal("10.0.0.0/8"),
+			cty.UnknownVal(ipObjectType),
+			"invalid IP address: 10.0.0.0/8",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ipparse(%#v)", test.Address), func(t *testing.T) {
+			got, err := IPParse(test.Address)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.Err; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestIPInc(t *testing.T) {
+	tests := []struct {
+		Address cty.Value
+		N       cty.Value
+		Want    cty.Value
+		Err     string
+	}{
+		{
+			cty.StringVal("10.0.0.255"),
+			cty.NumberIntVal(1),
+			cty.StringVal("10.0.1.0"),
+			"",
+		},
+		{
+			cty.StringVal("10.0.1.0"),
+			cty.NumberIntVal(-257),
+			cty.StringVal("9.255.255.255"),
+			"",
+		},
+		{
+			cty.StringVal("fd00::ffff"),
+			cty.NumberIntVal(2),
+			cty.StringVal("fd00::1:1"),
+			"",
+		},
+		{
+			cty.StringVal("255.255.255.255"),
+			cty.NumberIntVal(1),
+			cty.UnknownVal(cty.String),
+			"adding 1 to 255.255.255.255 would go outside of the IPv4 address space",
+		},
+		{
+			cty.StringVal("::"),
+			cty.NumberIntVal(-1),
+			cty.UnknownVal(cty.String),
+			"adding -1 to :: would go outside of the IPv6 address space",
+		},
+		{
+			cty.StringVal("10.0.0.1"),
+			cty.NumberFloatVal(1.5),
+			cty.UnknownVal(cty.String),
+			"value must be a whole number",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("ipinc(%#v, %#v)", test.Address, test.N), func(t *testing.T) {
+			got, err := IPInc(test.Address, test.N)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				}
+				if got, want := err.Error(), test.Err; got != want {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, want)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
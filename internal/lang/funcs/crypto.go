@@ -6,18 +6,24 @@
 package funcs
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rsa"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
+	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"hash"
 	"io"
 	"strings"
+	"time"
 
 	uuidv5 "github.com/google/uuid"
 	uuid "github.com/hashicorp/go-uuid"
@@ -134,6 +140,51 @@ var BcryptFunc = function.New(&function.Spec{
 	},
 })
 
+// Ed25519VerifyFunc constructs a function that verifies an Ed25519 signature
+// over a given message.
+var Ed25519VerifyFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "publickey",
+			Type: cty.String,
+		},
+		{
+			Name: "message",
+			Type: cty.String,
+		},
+		{
+			Name: "signature",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Bool),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		rawKey, err := parsePublicKey(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), function.NewArgError(0, err)
+		}
+		publicKey, ok := rawKey.(ed25519.PublicKey)
+		if !ok {
+			return cty.UnknownVal(cty.Bool), function.NewArgErrorf(0, "invalid public key type %T; must be an Ed25519 public key", rawKey)
+		}
+		sig, err := base64.StdEncoding.DecodeString(args[2].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), function.NewArgErrorf(2, "failed to decode signature: signature must be base64-encoded")
+		}
+
+		return cty.BoolVal(ed25519.Verify(publicKey, []byte(args[1].AsString()), sig)), nil
+	},
+})
+
+// HmacSha256Func constructs a function that computes the HMAC-SHA256 of a given
+// message using a given key and encodes it with hexadecimal digits.
+var HmacSha256Func = makeHmacFunction(sha256.New)
+
+// HmacSha512Func constructs a function that computes the HMAC-SHA512 of a given
+// message using a given key and encodes it with hexadecimal digits.
+var HmacSha512Func = makeHmacFunction(sha512.New)
+
 // Md5Func constructs a function that computes the MD5 hash of a given string and encodes it with hexadecimal digits.
 var Md5Func = makeStringHashFunction(md5.New, hex.EncodeToString)
 
@@ -143,6 +194,49 @@ func MakeFileMd5Func(baseDir string) function.Function {
 	return makeFileHashFunction(baseDir, md5.New, hex.EncodeToString)
 }
 
+// PemDecodeFunc constructs a function that decodes all of the PEM blocks
+// found in a given string.
+var PemDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "str",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.List(pemBlockType)),
+	RefineResult: func(b *cty.RefinementBuilder) *cty.RefinementBuilder {
+		return b.NotNull().CollectionLengthLowerBound(1)
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		var blocks []cty.Value
+		rest := []byte(args[0].AsString())
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			headers := make(map[string]cty.Value, len(block.Headers))
+			for k, v := range block.Headers {
+				headers[k] = cty.StringVal(v)
+			}
+			headersVal := cty.MapValEmpty(cty.String)
+			if len(headers) != 0 {
+				headersVal = cty.MapVal(headers)
+			}
+			blocks = append(blocks, cty.ObjectVal(map[string]cty.Value{
+				"type":    cty.StringVal(block.Type),
+				"headers": headersVal,
+				"body":    cty.StringVal(base64.StdEncoding.EncodeToString(block.Bytes)),
+			}))
+		}
+		if len(blocks) == 0 {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "no PEM-encoded blocks found")
+		}
+		return cty.ListVal(blocks), nil
+	},
+})
+
 // RsaDecryptFunc constructs a function that decrypts an RSA-encrypted ciphertext.
 var RsaDecryptFunc = function.New(&function.Spec{
 	Params: []function.Parameter{
@@ -199,6 +293,64 @@ var RsaDecryptFunc = function.New(&function.Spec{
 	},
 })
 
+// RsaVerifyFunc constructs a function that verifies an RSA signature over the
+// SHA256 hash of a given message.
+var RsaVerifyFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "publickey",
+			Type: cty.String,
+		},
+		{
+			Name: "message",
+			Type: cty.String,
+		},
+		{
+			Name: "signature",
+			Type: cty.String,
+		},
+	},
+	VarParam: &function.Parameter{
+		Name: "padding",
+		Type: cty.String,
+	},
+	Type:         function.StaticReturnType(cty.Bool),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		if len(args) > 4 {
+			return cty.UnknownVal(cty.Bool), fmt.Errorf("rsaverify() takes no more than four arguments")
+		}
+		padding := "pkcs1v15"
+		if len(args) > 3 {
+			padding = args[3].AsString()
+		}
+
+		rawKey, err := parsePublicKey(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), function.NewArgError(0, err)
+		}
+		publicKey, ok := rawKey.(*rsa.PublicKey)
+		if !ok {
+			return cty.UnknownVal(cty.Bool), function.NewArgErrorf(0, "invalid public key type %T; must be an RSA public key", rawKey)
+		}
+		sig, err := base64.StdEncoding.DecodeString(args[2].AsString())
+		if err != nil {
+			return cty.UnknownVal(cty.Bool), function.NewArgErrorf(2, "failed to decode signature: signature must be base64-encoded")
+		}
+
+		digest := sha256.Sum256([]byte(args[1].AsString()))
+		switch padding {
+		case "pkcs1v15":
+			err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], sig)
+		case "pss":
+			err = rsa.VerifyPSS(publicKey, crypto.SHA256, digest[:], sig, nil)
+		default:
+			return cty.UnknownVal(cty.Bool), function.NewArgErrorf(3, "unsupported padding scheme %q; must be either \"pkcs1v15\" or \"pss\"", padding)
+		}
+		return cty.BoolVal(err == nil), nil
+	},
+})
+
 // Sha1Func constructs a function that computes the SHA1 hash of a given string
 // and encodes it with hexadecimal digits.
 var Sha1Func = makeStringHashFunction(sha1.New, hex.EncodeToString)
@@ -229,6 +381,145 @@ func MakeFileSha512Func(baseDir string) function.Function {
 	return makeFileHashFunction(baseDir, sha512.New, hex.EncodeToString)
 }
 
+// X509ParseFunc constructs a function that parses a PEM-encoded X.509
+// certificate and returns some of its commonly-used fields.
+var X509ParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "certificate",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(x509CertificateType),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+		block, _ := pem.Decode([]byte(args[0].AsString()))
+		if block == nil || block.Type != "CERTIFICATE" {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "certificate must be a PEM-encoded X.509 certificate")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid certificate: %s", err)
+		}
+
+		ipAddresses := make([]string, len(cert.IPAddresses))
+		for i, ip := range cert.IPAddresses {
+			ipAddresses[i] = ip.String()
+		}
+		uris := make([]string, len(cert.URIs))
+		for i, u := range cert.URIs {
+			uris[i] = u.String()
+		}
+		fingerprint := sha256.Sum256(cert.Raw)
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"subject":            cty.StringVal(cert.Subject.String()),
+			"issuer":             cty.StringVal(cert.Issuer.String()),
+			"serial_number":      cty.StringVal(cert.SerialNumber.Text(16)),
+			"dns_names":          stringListVal(cert.DNSNames),
+			"email_addresses":    stringListVal(cert.EmailAddresses),
+			"ip_addresses":       stringListVal(ipAddresses),
+			"uris":               stringListVal(uris),
+			"not_before":         cty.StringVal(cert.NotBefore.UTC().Format(time.RFC3339)),
+			"not_after":          cty.StringVal(cert.NotAfter.UTC().Format(time.RFC3339)),
+			"is_ca":              cty.BoolVal(cert.IsCA),
+			"sha256_fingerprint": cty.StringVal(hex.EncodeToString(fingerprint[:])),
+		}), nil
+	},
+})
+
+var pemBlockType = cty.Object(map[string]cty.Type{
+	"type":    cty.String,
+	"headers": cty.Map(cty.String),
+	"body":    cty.String,
+})
+
+var x509CertificateType = cty.Object(map[string]cty.Type{
+	"subject":            cty.String,
+	"issuer":             cty.String,
+	"serial_number":      cty.String,
+	"dns_names":          cty.List(cty.String),
+	"email_addresses":    cty.List(cty.String),
+	"ip_addresses":       cty.List(cty.String),
+	"uris":               cty.List(cty.String),
+	"not_before":         cty.String,
+	"not_after":          cty.String,
+	"is_ca":              cty.Bool,
+	"sha256_fingerprint": cty.String,
+})
+
+func stringListVal(strs []string) cty.Value {
+	if len(strs) == 0 {
+		return cty.ListValEmpty(cty.String)
+	}
+	vals := make([]cty.Value, len(strs))
+	for i, s := range strs {
+		vals[i] = cty.StringVal(s)
+	}
+	return cty.ListVal(vals)
+}
+
+// parsePublicKey accepts either a PEM-encoded public key (in PKIX, PKCS #1 or
+// X.509 certificate form) or a key in OpenSSH "authorized_keys" format.
+func parsePublicKey(str string) (crypto.PublicKey, error) {
+	if block, _ := pem.Decode([]byte(str)); block != nil {
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key: %w", err)
+			}
+			return key, nil
+		case "RSA PUBLIC KEY":
+			key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key: %w", err)
+			}
+			return key, nil
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate: %w", err)
+			}
+			return cert.PublicKey, nil
+		default:
+			return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+		}
+	}
+
+	sshKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(str))
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	cryptoKey, ok := sshKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %q", sshKey.Type())
+	}
+	return cryptoKey.CryptoPublicKey(), nil
+}
+
+func makeHmacFunction(hf func() hash.Hash) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "key",
+				Type: cty.String,
+			},
+			{
+				Name: "message",
+				Type: cty.String,
+			},
+		},
+		Type:         function.StaticReturnType(cty.String),
+		RefineResult: refineNotNull,
+		Impl: func(args []cty.Value, retType cty.Type) (ret cty.Value, err error) {
+			h := hmac.New(hf, []byte(args[0].AsString()))
+			h.Write([]byte(args[1].AsString()))
+			return cty.StringVal(hex.EncodeToString(h.Sum(nil))), nil
+		},
+	})
+}
+
 func makeStringHashFunction(hf func() hash.Hash, enc func([]byte) string) function.Function {
 	return function.New(&function.Spec{
 		Params: []function.Parameter{
@@ -325,17 +616,51 @@ func Bcrypt(str cty.Value, cost ...cty.Value) (cty.Value, error) {
 	return BcryptFunc.Call(args)
 }
 
+// Ed25519Verify verifies an Ed25519 signature over the given message using the
+// given public key.
+func Ed25519Verify(publickey, message, signature cty.Value) (cty.Value, error) {
+	return Ed25519VerifyFunc.Call([]cty.Value{publickey, message, signature})
+}
+
+// HmacSha256 computes the HMAC-SHA256 of the given message using the given key
+// and encodes it with hexadecimal digits.
+func HmacSha256(key, message cty.Value) (cty.Value, error) {
+	return HmacSha256Func.Call([]cty.Value{key, message})
+}
+
+// HmacSha512 computes the HMAC-SHA512 of the given message using the given key
+// and encodes it with hexadecimal digits.
+func HmacSha512(key, message cty.Value) (cty.Value, error) {
+	return HmacSha512Func.Call([]cty.Value{key, message})
+}
+
 // Md5 computes the MD5 hash of a given string and encodes it with hexadecimal digits.
 func Md5(str cty.Value) (cty.Value, error) {
 	return Md5Func.Call([]cty.Value{str})
 }
 
+// PemDecode decodes all of the PEM blocks in the given string.
+func PemDecode(str cty.Value) (cty.Value, error) {
+	return PemDecodeFunc.Call([]cty.Value{str})
+}
+
 // RsaDecrypt decrypts an RSA-encrypted ciphertext, returning the corresponding
 // cleartext.
 func RsaDecrypt(ciphertext, privatekey cty.Value) (cty.Value, error) {
 	return RsaDecryptFunc.Call([]cty.Value{ciphertext, privatekey})
 }
 
+// RsaVerify verifies an RSA signature over the SHA256 hash of the given message
+// using the given public key and, optionally, padding scheme.
+func RsaVerify(publickey, message, signature cty.Value, padding ...cty.Value) (cty.Value, error) {
+	args := make([]cty.Value, len(padding)+3)
+	args[0] = publickey
+	args[1] = message
+	args[2] = signature
+	copy(args[3:], padding)
+	return RsaVerifyFunc.Call(args)
+}
+
 // Sha1 computes the SHA1 hash of a given string and encodes it with hexadecimal digits.
 func Sha1(str cty.Value) (cty.Value, error) {
 	return Sha1Func.Call([]cty.Value{str})
@@ -350,3 +675,8 @@ func Sha256(str cty.Value) (cty.Value, error) {
 func Sha512(str cty.Value) (cty.Value, error) {
 	return Sha512Func.Call([]cty.Value{str})
 }
+
+// X509Parse parses a PEM-encoded X.509 certificate.
+func X509Parse(certificate cty.Value) (cty.Value, error) {
+	return X509ParseFunc.Call([]cty.Value{certificate})
+}
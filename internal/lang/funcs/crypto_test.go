@@ -11,6 +11,8 @@ import (
 
 	"github.com/zclconf/go-cty/cty"
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
 )
 
 func TestUUID(t *testing.T) {
@@ -282,6 +284,156 @@ func TestBcrypt(t *testing.T) {
 	}
 }
 
+func TestEd25519Verify(t *testing.T) {
+	tests := []struct {
+		PublicKey cty.Value
+		Message   cty.Value
+		Signature cty.Value
+		Want      cty.Value
+		Err       string
+	}{
+		{
+			cty.StringVal(Ed25519PublicKey),
+			cty.StringVal("message"),
+			cty.StringVal(Ed25519Signature),
+			cty.True,
+			"",
+		},
+		// OpenSSH authorized_keys format
+		{
+			cty.StringVal(Ed25519OpenSSHPublicKey),
+			cty.StringVal("message"),
+			cty.StringVal(Ed25519Signature),
+			cty.True,
+			"",
+		},
+		// Certificate containing the public key
+		{
+			cty.StringVal(Ed25519Certificate),
+			cty.StringVal("message"),
+			cty.StringVal(Ed25519Signature),
+			cty.True,
+			"",
+		},
+		// Wrong message
+		{
+			cty.StringVal(Ed25519PublicKey),
+			cty.StringVal("other message"),
+			cty.StringVal(Ed25519Signature),
+			cty.False,
+			"",
+		},
+		// Marks on any argument are propagated to the result
+		{
+			cty.StringVal(Ed25519PublicKey),
+			cty.StringVal("message").Mark(marks.Ephemeral),
+			cty.StringVal(Ed25519Signature).Mark(marks.Sensitive),
+			cty.True.WithMarks(cty.NewValueMarks(marks.Ephemeral, marks.Sensitive)),
+			"",
+		},
+		// Wrong key type
+		{
+			cty.StringVal(RsaPublicKey),
+			cty.StringVal("message"),
+			cty.StringVal(Ed25519Signature),
+			cty.UnknownVal(cty.Bool),
+			"invalid public key type *rsa.PublicKey; must be an Ed25519 public key",
+		},
+		// Bad signature encoding
+		{
+			cty.StringVal(Ed25519PublicKey),
+			cty.StringVal("message"),
+			cty.StringVal("bad"),
+			cty.UnknownVal(cty.Bool),
+			"failed to decode signature: signature must be base64-encoded",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Ed25519Verify(%#v, %#v, %#v)", test.PublicKey, test.Message, test.Signature), func(t *testing.T) {
+			got, err := Ed25519Verify(test.PublicKey, test.Message, test.Signature)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHmacSha256(t *testing.T) {
+	tests := []struct {
+		Key     cty.Value
+		Message cty.Value
+		Want    cty.Value
+	}{
+		{
+			cty.StringVal("key"),
+			cty.StringVal("The quick brown fox jumps over the lazy dog"),
+			cty.StringVal("f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"),
+		},
+		{
+			cty.StringVal("key").Mark(marks.Sensitive),
+			cty.StringVal("The quick brown fox jumps over the lazy dog"),
+			cty.StringVal("f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8").Mark(marks.Sensitive),
+		},
+		{
+			cty.StringVal("key").Mark(marks.Ephemeral),
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.String).RefineNotNull().Mark(marks.Ephemeral),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hmacsha256(%#v, %#v)", test.Key, test.Message), func(t *testing.T) {
+			got, err := HmacSha256(test.Key, test.Message)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHmacSha512(t *testing.T) {
+	tests := []struct {
+		Key     cty.Value
+		Message cty.Value
+		Want    cty.Value
+	}{
+		{
+			cty.StringVal("key"),
+			cty.StringVal("The quick brown fox jumps over the lazy dog"),
+			cty.StringVal("b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hmacsha512(%#v, %#v)", test.Key, test.Message), func(t *testing.T) {
+			got, err := HmacSha512(test.Key, test.Message)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
 func TestMd5(t *testing.T) {
 	tests := []struct {
 		String cty.Value
@@ -370,6 +522,63 @@ func TestFileMD5(t *testing.T) {
 	}
 }
 
+func TestPemDecode(t *testing.T) {
+	tests := []struct {
+		Str  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("-----BEGIN FOO-----\nProc-Type: 4,ENCRYPTED\n\naGVsbG8=\n-----END FOO-----\n-----BEGIN BAR-----\nd29ybGQ=\n-----END BAR-----\n"),
+			cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"type": cty.StringVal("FOO"),
+					"headers": cty.MapVal(map[string]cty.Value{
+						"Proc-Type": cty.StringVal("4,ENCRYPTED"),
+					}),
+					"body": cty.StringVal("aGVsbG8="),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"type":    cty.StringVal("BAR"),
+					"headers": cty.MapValEmpty(cty.String),
+					"body":    cty.StringVal("d29ybGQ="),
+				}),
+			}),
+			"",
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.UnknownVal(cty.List(pemBlockType)).Refine().NotNull().CollectionLengthLowerBound(1).NewValue(),
+			"",
+		},
+		{
+			cty.StringVal("not PEM"),
+			cty.UnknownVal(cty.List(pemBlockType)),
+			"no PEM-encoded blocks found",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("PemDecode(%#v)", test.Str), func(t *testing.T) {
+			got, err := PemDecode(test.Str)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
 func TestRsaDecrypt(t *testing.T) {
 	tests := []struct {
 		Ciphertext cty.Value
@@ -449,6 +658,87 @@ func TestRsaDecrypt(t *testing.T) {
 	}
 }
 
+func TestRsaVerify(t *testing.T) {
+	tests := []struct {
+		Args []cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature)},
+			cty.True,
+			"",
+		},
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature), cty.StringVal("pkcs1v15")},
+			cty.True,
+			"",
+		},
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("message"), cty.StringVal(RsaPSSSignature), cty.StringVal("pss")},
+			cty.True,
+			"",
+		},
+		// Signature made with a different padding scheme
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("message"), cty.StringVal(RsaPSSSignature)},
+			cty.False,
+			"",
+		},
+		// Wrong message
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("other message"), cty.StringVal(RsaPKCS1v15Signature)},
+			cty.False,
+			"",
+		},
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey).Mark(marks.Sensitive), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature)},
+			cty.True.Mark(marks.Sensitive),
+			"",
+		},
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature), cty.StringVal("oaep")},
+			cty.UnknownVal(cty.Bool),
+			`unsupported padding scheme "oaep"; must be either "pkcs1v15" or "pss"`,
+		},
+		{
+			[]cty.Value{cty.StringVal(Ed25519PublicKey), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature)},
+			cty.UnknownVal(cty.Bool),
+			"invalid public key type ed25519.PublicKey; must be an RSA public key",
+		},
+		{
+			[]cty.Value{cty.StringVal(""), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature)},
+			cty.UnknownVal(cty.Bool),
+			"invalid public key: ssh: no key found",
+		},
+		{
+			[]cty.Value{cty.StringVal(RsaPublicKey), cty.StringVal("message"), cty.StringVal(RsaPKCS1v15Signature), cty.StringVal("pss"), cty.StringVal("pss")},
+			cty.UnknownVal(cty.Bool),
+			"rsaverify() takes no more than four arguments",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("RsaVerify(%#v)", test.Args), func(t *testing.T) {
+			got, err := RsaVerify(test.Args[0], test.Args[1], test.Args[2], test.Args[3:]...)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
 func TestSha1(t *testing.T) {
 	tests := []struct {
 		String cty.Value
@@ -683,6 +973,62 @@ func TestFileSHA512(t *testing.T) {
 	}
 }
 
+func TestX509Parse(t *testing.T) {
+	tests := []struct {
+		Certificate cty.Value
+		Want        cty.Value
+		Err         string
+	}{
+		{
+			cty.StringVal(Ed25519Certificate),
+			cty.ObjectVal(map[string]cty.Value{
+				"subject":            cty.StringVal("CN=example.com,O=Example"),
+				"issuer":             cty.StringVal("CN=example.com,O=Example"),
+				"serial_number":      cty.StringVal("1234"),
+				"dns_names":          cty.ListVal([]cty.Value{cty.StringVal("example.com"), cty.StringVal("www.example.com")}),
+				"email_addresses":    cty.ListValEmpty(cty.String),
+				"ip_addresses":       cty.ListVal([]cty.Value{cty.StringVal("192.0.2.1")}),
+				"uris":               cty.ListValEmpty(cty.String),
+				"not_before":         cty.StringVal("2024-01-01T00:00:00Z"),
+				"not_after":          cty.StringVal("2034-01-01T00:00:00Z"),
+				"is_ca":              cty.True,
+				"sha256_fingerprint": cty.StringVal("a3d2e9fed2035b785eb90e7f1bcae0d86c8e8a8b62360afca0205eb1e5681e11"),
+			}),
+			"",
+		},
+		{
+			cty.UnknownVal(cty.String).Mark(marks.Ephemeral),
+			cty.UnknownVal(x509CertificateType).RefineNotNull().Mark(marks.Ephemeral),
+			"",
+		},
+		{
+			cty.StringVal(Ed25519PublicKey),
+			cty.UnknownVal(x509CertificateType),
+			"certificate must be a PEM-encoded X.509 certificate",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("X509Parse(%#v)", test.Certificate), func(t *testing.T) {
+			got, err := X509Parse(test.Certificate)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
 const (
 	CipherBase64 = "eczGaDhXDbOFRZGhjx2etVzWbRqWDlmq0bvNt284JHVbwCgObiuyX9uV0LSAMY707IEgMkExJqXmsB4OWKxvB7epRB9G/3+F+pcrQpODlDuL9oDUAsa65zEpYF0Wbn7Oh7nrMQncyUPpyr9WUlALl0gRWytOA23S+y5joa4M34KFpawFgoqTu/2EEH4Xl1zo+0fy73fEto+nfkUY+meuyGZ1nUx/+DljP7ZqxHBFSlLODmtuTMdswUbHbXbWneW51D7Jm7xB8nSdiA2JQNK5+Sg5x8aNfgvFTt/m2w2+qpsyFa5Wjeu6fZmXSl840CA07aXbk9vN4I81WmJyblD/ZA=="
 	PrivateKey   = `
@@ -800,4 +1146,36 @@ RXT7GwNQHIY8eDjDnsHxzrxd+raOxOZeKcMHj3XyjCX3NHfTscnsBPAGYpY/Wxzh
 T8UYnFu6RzkixElTf2rseEav7rkdKkI3LAeIZy7B0HulKKsmqVQ7
 -----END RSA PRIVATE KEY-----
 `
+	Ed25519PublicKey = `
+-----BEGIN PUBLIC KEY-----
+MCowBQYDK2VwAyEAA6EHv/POEL4dcN0Y50vAmWfk1jCbpQ1fHdyGZBJVMbg=
+-----END PUBLIC KEY-----
+`
+	Ed25519OpenSSHPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAOhB7/zzhC+HXDdGOdLwJln5NYwm6UNXx3chmQSVTG4 test@example.com"
+	Ed25519Signature        = "e8DqV4KQyNz2/IpuE0p/PnlN3X6JIhCLzNYgL5XeUyuSwimNyOFhrCteNlP5LFsOEq3yaz1G570gV3FfJdPiBQ=="
+	Ed25519Certificate      = `
+-----BEGIN CERTIFICATE-----
+MIIBcTCCASOgAwIBAgICEjQwBQYDK2VwMCgxEDAOBgNVBAoTB0V4YW1wbGUxFDAS
+BgNVBAMTC2V4YW1wbGUuY29tMB4XDTI0MDEwMTAwMDAwMFoXDTM0MDEwMTAwMDAw
+MFowKDEQMA4GA1UEChMHRXhhbXBsZTEUMBIGA1UEAxMLZXhhbXBsZS5jb20wKjAF
+BgMrZXADIQADoQe/884Qvh1w3RjnS8CZZ+TWMJulDV8d3IZkElUxuKNxMG8wDgYD
+VR0PAQH/BAQDAgKEMA8GA1UdEwEB/wQFMAMBAf8wHQYDVR0OBBYEFFZHWqdUY0dM
+AoXfXb8ryrc9plE1MC0GA1UdEQQmMCSCC2V4YW1wbGUuY29tgg93d3cuZXhhbXBs
+ZS5jb22HBMAAAgEwBQYDK2VwA0EAnGsB6zcCmuxboOWdAew78gpMPzHyrR/X0564
+Baf1a8sBfm7X4Bo3MYIo13PJ+lsc4badEQOIA3iFeK+kK3YDAA==
+-----END CERTIFICATE-----
+`
+	RsaPublicKey = `
+-----BEGIN PUBLIC KEY-----
+MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAgUElV5mwqkloIrM8ZNZ7
+2gSCcnSJt7+/Usa5G+D15YQUAdf9c1zEekTfHgDP+04nw/uFNFaE5v1RbHaPxhZY
+Vg5ZErNCa/hzn+x10xzcepeS3KPVXcxae4MR0BEegvqZqJzN9loXsNL/c3H/B+2G
+le3hTxjlWFb3F5qLgR+4Mf4ruhER1v6eHQa/nchi03MBpT4UeJ7MrL92hTJYLdpS
+yCqmr8yjxkKJDVC2uRrr+sTSxfh7r6v24u/vp/QTmBIAlNPgadVAZw17iNNb7vjV
+7Gwl/5gHXonCUKURaV++dBNLrHIZpqcAM8wHRph8mD1EfL9hsz77pHewxolBATV+
+7QIDAQAB
+-----END PUBLIC KEY-----
+`
+	RsaPKCS1v15Signature = "CM2KZnybkJOHCDclT1ILwAZvYU4FX/0Qazc1RVgRAHztG3aWjrn7s7NP6/UWEpCjti6yQNbz624YWYujmsBfYVIbEMF/CEWIs2grzs/1T38KgizEjAXed+8aOV+TbuEr74FAIlc0IO8bA+vF5YwgweNEnnFJw/gBSazUoxPFwZ85cu+IBT7mKOxCjPyDrbRNUlpl5IYTEWmS99ko70j4aR073n5L574L51vJ8ftn4oS3pdslzcV6d9reO8UQTRM3EvrnQyj+XOfs+16vd+cQ5ypxqu7/lcig43B2Lzrhpi7ApwChLaji1V18QguZJHF9TrIrJPF2qAcQ31eC4vACoA=="
+	RsaPSSSignature      = "UTx/qpLW5OjQe9k+2bRKC7qL6e/Urbu27nSD8aQztq+0LMG3JsxMcCaxsLdOVU7qppDV0ak/znpLji75aYKcExNgPkdE6BlMqWYo+oHhU+4QrbfnA1Si+ZkvlphuamA0rD91tHbORCKZJQ18IdqmkEJpaHMIjPRIBDE1rilAplC3C+f8bkbFGm4A08oFHhA3DaXqKjaT6bVj3hE4Uza6DajlE9P8MSZ/oPySvhV1dhvsPqLtA+bgCLIQ6SDYsI96Y4vrQPPIpqHcSlw9tfOFmd5otMwBWp7dx0qq5v4QITwOFkgt+aSFLjYlBVCPF9LCJbCTP4QgOAN72qetnHWKsQ=="
 )
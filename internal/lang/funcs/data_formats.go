@@ -0,0 +1,502 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"gopkg.in/ini.v1"
+
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// TOMLDecodeFunc constructs a function that parses a TOML document and
+// returns its contents as an object.
+var TOMLDecodeFunc = makeDataDecodeFunction("TOML", decodeTOML)
+
+// TOMLEncodeFunc constructs a function that encodes an object or map as a
+// TOML document.
+var TOMLEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "value",
+			Type: cty.DynamicPseudoType,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val := args[0]
+		if !val.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		if ty := val.Type(); !ty.IsObjectType() && !ty.IsMapType() {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "a TOML document must be an object or a map, not %s", ty.FriendlyName())
+		}
+
+		raw, err := ctyToGo(val, nil)
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgError(0, err)
+		}
+
+		var buf bytes.Buffer
+		enc := toml.NewEncoder(&buf)
+		enc.Indent = ""
+		if err := enc.Encode(raw); err != nil {
+			return cty.UnknownVal(retType), fmt.Errorf("failed to encode TOML: %w", err)
+		}
+		return cty.StringVal(buf.String()), nil
+	},
+})
+
+// INIDecodeFunc constructs a function that parses an INI document and returns
+// a map from section name to a map of that section's keys and values.
+var INIDecodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "src",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Map(cty.Map(cty.String))),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		f, err := ini.Load([]byte(args[0].AsString()))
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid INI: %s", err)
+		}
+
+		sections := make(map[string]cty.Value)
+		for _, section := range f.Sections() {
+			keys := section.Keys()
+			if len(keys) == 0 && section.Name() == ini.DefaultSection {
+				// The default section always exists, so we only include it
+				// when the document actually has keys outside of any section.
+				continue
+			}
+			vals := make(map[string]cty.Value, len(keys))
+			for _, key := range keys {
+				vals[key.Name()] = cty.StringVal(key.Value())
+			}
+			if len(vals) == 0 {
+				sections[section.Name()] = cty.MapValEmpty(cty.String)
+			} else {
+				sections[section.Name()] = cty.MapVal(vals)
+			}
+		}
+		if len(sections) == 0 {
+			return cty.MapValEmpty(cty.Map(cty.String)), nil
+		}
+		return cty.MapVal(sections), nil
+	},
+})
+
+// XMLDecodeFunc constructs a function that parses an XML document and returns
+// an object describing its root element.
+var XMLDecodeFunc = makeDataDecodeFunction("XML", decodeXML)
+
+// HCLDecodeFunc constructs a function that parses a body of HCL native syntax
+// containing only attribute definitions, such as a ".tfvars" file, and returns
+// an object of the attribute values.
+var HCLDecodeFunc = makeDataDecodeFunction("HCL", decodeHCL)
+
+// HCLEncodeFunc constructs a function that encodes an object or map as a body
+// of HCL native syntax attribute definitions.
+var HCLEncodeFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "value",
+			Type: cty.DynamicPseudoType,
+		},
+	},
+	Type:         function.StaticReturnType(cty.String),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val := args[0]
+		if !val.IsWhollyKnown() {
+			return cty.UnknownVal(retType), nil
+		}
+		if ty := val.Type(); !ty.IsObjectType() && !ty.IsMapType() {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "an HCL body must be an object or a map, not %s", ty.FriendlyName())
+		}
+		if val.IsNull() {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "cannot encode a null value as an HCL body")
+		}
+
+		names := make([]string, 0, val.LengthInt())
+		attrs := make(map[string]cty.Value, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			name := k.AsString()
+			if !hclsyntax.ValidIdentifier(name) {
+				return cty.UnknownVal(retType), function.NewArgErrorf(0, "cannot use %q as an HCL attribute name: must be a valid identifier", name)
+			}
+			names = append(names, name)
+			attrs[name] = v
+		}
+		sort.Strings(names)
+
+		f := hclwrite.NewEmptyFile()
+		body := f.Body()
+		for _, name := range names {
+			body.SetAttributeValue(name, attrs[name])
+		}
+		return cty.StringVal(string(hclwrite.Format(f.Bytes()))), nil
+	},
+})
+
+// makeDataDecodeFunction builds a function that decodes a string in some
+// serialization format into a value whose type is inferred from the content,
+// in the same way as the yamldecode function.
+func makeDataDecodeFunction(format string, decode func(src string) (cty.Value, error)) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "src",
+				Type: cty.String,
+			},
+		},
+		Type: func(args []cty.Value) (cty.Type, error) {
+			if !args[0].IsKnown() {
+				return cty.DynamicPseudoType, nil
+			}
+			if args[0].IsNull() {
+				return cty.NilType, function.NewArgErrorf(0, "%s source code cannot be null", format)
+			}
+			val, err := decode(args[0].AsString())
+			if err != nil {
+				return cty.NilType, function.NewArgError(0, err)
+			}
+			return val.Type(), nil
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			val, err := decode(args[0].AsString())
+			if err != nil {
+				return cty.NilVal, function.NewArgError(0, err)
+			}
+			return val, nil
+		},
+	})
+}
+
+func decodeTOML(src string) (cty.Value, error) {
+	var raw map[string]interface{}
+	if _, err := toml.Decode(src, &raw); err != nil {
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			// The decoder only exposes its message with a "toml:" prefix and
+			// the position context appended, so we take just the first line.
+			msg, _, _ := strings.Cut(pe.ErrorWithPosition(), "\n")
+			msg = strings.TrimPrefix(msg, "toml: error: ")
+			return cty.NilVal, fmt.Errorf("on line %d: %s", pe.Position.Line, msg)
+		}
+		return cty.NilVal, err
+	}
+	return goToCty(raw)
+}
+
+func decodeXML(src string) (cty.Value, error) {
+	dec := xml.NewDecoder(strings.NewReader(src))
+	var root cty.Value
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return cty.NilVal, xmlDecodeError(err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			if root != cty.NilVal {
+				line, _ := dec.InputPos()
+				return cty.NilVal, fmt.Errorf("on line %d: XML document must have only one root element", line)
+			}
+			val, err := decodeXMLElement(dec, tok)
+			if err != nil {
+				return cty.NilVal, xmlDecodeError(err)
+			}
+			root = cty.ObjectVal(map[string]cty.Value{
+				tok.Name.Local: val,
+			})
+		case xml.CharData:
+			if len(bytes.TrimSpace(tok)) != 0 {
+				line, _ := dec.InputPos()
+				return cty.NilVal, fmt.Errorf("on line %d: unexpected text outside of the root element", line)
+			}
+		}
+	}
+	if root == cty.NilVal {
+		return cty.NilVal, fmt.Errorf("XML document has no root element")
+	}
+	return root, nil
+}
+
+// decodeXMLElement consumes tokens up to and including the end of the element
+// started by the given token. Elements with neither attributes nor child
+// elements decode as their text content; all others decode as an object with
+// attributes prefixed by "@", child elements by name (as a tuple if the name
+// appears more than once) and any non-whitespace text as "#text".
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (cty.Value, error) {
+	attrs := make(map[string]cty.Value)
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || (attr.Name.Space == "" && attr.Name.Local == "xmlns") {
+			continue
+		}
+		attrs["@"+attr.Name.Local] = cty.StringVal(attr.Value)
+	}
+
+	var names []string
+	children := make(map[string][]cty.Value)
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return cty.NilVal, err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			val, err := decodeXMLElement(dec, tok)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			name := tok.Name.Local
+			if _, exists := children[name]; !exists {
+				names = append(names, name)
+			}
+			children[name] = append(children[name], val)
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(attrs) == 0 && len(names) == 0 {
+				return cty.StringVal(content), nil
+			}
+			for _, name := range names {
+				if vals := children[name]; len(vals) == 1 {
+					attrs[name] = vals[0]
+				} else {
+					attrs[name] = cty.TupleVal(vals)
+				}
+			}
+			if content != "" {
+				attrs["#text"] = cty.StringVal(content)
+			}
+			return cty.ObjectVal(attrs), nil
+		}
+	}
+}
+
+func xmlDecodeError(err error) error {
+	var se *xml.SyntaxError
+	if errors.As(err, &se) {
+		return fmt.Errorf("on line %d: %s", se.Line, se.Msg)
+	}
+	return err
+}
+
+func decodeHCL(src string) (cty.Value, error) {
+	file, diags := hclsyntax.ParseConfig([]byte(src), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, hclDecodeError(diags)
+	}
+	body := file.Body.(*hclsyntax.Body)
+	if len(body.Blocks) != 0 {
+		block := body.Blocks[0]
+		return cty.NilVal, fmt.Errorf("on line %d: unexpected %q block; only attribute definitions are allowed", block.TypeRange.Start.Line, block.Type)
+	}
+
+	attrs := make(map[string]cty.Value, len(body.Attributes))
+	for name, attr := range body.Attributes {
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, hclDecodeError(diags)
+		}
+		attrs[name] = val
+	}
+	return cty.ObjectVal(attrs), nil
+}
+
+func hclDecodeError(diags hcl.Diagnostics) error {
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		msg := diag.Summary
+		if diag.Detail != "" {
+			msg = fmt.Sprintf("%s; %s", diag.Summary, diag.Detail)
+		}
+		if diag.Subject != nil {
+			return fmt.Errorf("on line %d, column %d: %s", diag.Subject.Start.Line, diag.Subject.Start.Column, msg)
+		}
+		return errors.New(msg)
+	}
+	return diags
+}
+
+// goToCty converts the result of decoding a TOML document into a cty value,
+// inferring object and tuple types from maps and slices.
+func goToCty(raw interface{}) (cty.Value, error) {
+	switch raw := raw.(type) {
+	case map[string]interface{}:
+		attrs := make(map[string]cty.Value, len(raw))
+		for k, v := range raw {
+			val, err := goToCty(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k] = val
+		}
+		return cty.ObjectVal(attrs), nil
+	case []map[string]interface{}:
+		elems := make([]cty.Value, len(raw))
+		for i, v := range raw {
+			val, err := goToCty(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = val
+		}
+		return cty.TupleVal(elems), nil
+	case []interface{}:
+		elems := make([]cty.Value, len(raw))
+		for i, v := range raw {
+			val, err := goToCty(v)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = val
+		}
+		return cty.TupleVal(elems), nil
+	case string:
+		return cty.StringVal(raw), nil
+	case bool:
+		return cty.BoolVal(raw), nil
+	case int64:
+		return cty.NumberIntVal(raw), nil
+	case float64:
+		if math.IsInf(raw, 0) || math.IsNaN(raw) {
+			return cty.NilVal, fmt.Errorf("cannot represent %v as a number", raw)
+		}
+		return cty.NumberFloatVal(raw), nil
+	case time.Time:
+		// The TOML decoder uses these specially-named locations to mark
+		// date and time values that had no offset in the source.
+		switch raw.Location().String() {
+		case "datetime-local":
+			return cty.StringVal(raw.Format("2006-01-02T15:04:05.999999999")), nil
+		case "date-local":
+			return cty.StringVal(raw.Format("2006-01-02")), nil
+		case "time-local":
+			return cty.StringVal(raw.Format("15:04:05.999999999")), nil
+		default:
+			return cty.StringVal(raw.Format(time.RFC3339Nano)), nil
+		}
+	default:
+		return cty.NilVal, fmt.Errorf("unsupported value of type %T", raw)
+	}
+}
+
+// ctyToGo converts a wholly-known cty value into the plain Go values expected
+// by the TOML encoder. Null object attributes and map elements are omitted,
+// because TOML has no representation of null.
+func ctyToGo(val cty.Value, path cty.Path) (interface{}, error) {
+	if val.IsNull() {
+		return nil, fmt.Errorf("%s: TOML cannot represent null values", tfdiags.FormatCtyPath(path))
+	}
+	ty := val.Type()
+	switch {
+	case ty == cty.String:
+		return val.AsString(), nil
+	case ty == cty.Bool:
+		return val.True(), nil
+	case ty == cty.Number:
+		bf := val.AsBigFloat()
+		if bf.IsInt() {
+			if i, acc := bf.Int64(); acc == big.Exact {
+				return i, nil
+			}
+		}
+		f, _ := bf.Float64()
+		return f, nil
+	case ty.IsObjectType() || ty.IsMapType():
+		ret := make(map[string]interface{}, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			if v.IsNull() {
+				continue
+			}
+			elemPath := path.Index(k)
+			if ty.IsObjectType() {
+				elemPath = path.GetAttr(k.AsString())
+			}
+			raw, err := ctyToGo(v, elemPath)
+			if err != nil {
+				return nil, err
+			}
+			ret[k.AsString()] = raw
+		}
+		return ret, nil
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		ret := make([]interface{}, 0, val.LengthInt())
+		for it := val.ElementIterator(); it.Next(); {
+			k, v := it.Element()
+			raw, err := ctyToGo(v, path.Index(k))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, raw)
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("%s: TOML cannot represent values of type %s", tfdiags.FormatCtyPath(path), ty.FriendlyName())
+	}
+}
+
+// TOMLDecode parses a TOML document and returns its contents as an object.
+func TOMLDecode(src cty.Value) (cty.Value, error) {
+	return TOMLDecodeFunc.Call([]cty.Value{src})
+}
+
+// TOMLEncode encodes an object or map as a TOML document.
+func TOMLEncode(val cty.Value) (cty.Value, error) {
+	return TOMLEncodeFunc.Call([]cty.Value{val})
+}
+
+// INIDecode parses an INI document into a map of sections.
+func INIDecode(src cty.Value) (cty.Value, error) {
+	return INIDecodeFunc.Call([]cty.Value{src})
+}
+
+// XMLDecode parses an XML document into an object describing its root element.
+func XMLDecode(src cty.Value) (cty.Value, error) {
+	return XMLDecodeFunc.Call([]cty.Value{src})
+}
+
+// HCLDecode parses a body of HCL attribute definitions into an object.
+func HCLDecode(src cty.Value) (cty.Value, error) {
+	return HCLDecodeFunc.Call([]cty.Value{src})
+}
+
+// HCLEncode encodes an object or map as a body of HCL attribute definitions.
+func HCLEncode(val cty.Value) (cty.Value, error) {
+	return HCLEncodeFunc.Call([]cty.Value{val})
+}
@@ -0,0 +1,455 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestTOMLDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`
+title = "example"
+port = 8080
+ratio = 0.5
+enabled = true
+tags = ["a", 1]
+released = 1979-05-27T07:32:00Z
+birthday = 1979-05-27
+
+[owner]
+name = "Tom"
+
+[[servers]]
+ip = "10.0.0.1"
+
+[[servers]]
+ip = "10.0.0.2"
+`),
+			cty.ObjectVal(map[string]cty.Value{
+				"title":    cty.StringVal("example"),
+				"port":     cty.NumberIntVal(8080),
+				"ratio":    cty.NumberFloatVal(0.5),
+				"enabled":  cty.True,
+				"tags":     cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.NumberIntVal(1)}),
+				"released": cty.StringVal("1979-05-27T07:32:00Z"),
+				"birthday": cty.StringVal("1979-05-27"),
+				"owner": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("Tom"),
+				}),
+				"servers": cty.TupleVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"ip": cty.StringVal("10.0.0.1")}),
+					cty.ObjectVal(map[string]cty.Value{"ip": cty.StringVal("10.0.0.2")}),
+				}),
+			}),
+			"",
+		},
+		{
+			cty.StringVal(""),
+			cty.EmptyObjectVal,
+			"",
+		},
+		{
+			cty.StringVal("a = 1").Mark(marks.Sensitive),
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.NumberIntVal(1),
+			}).Mark(marks.Sensitive),
+			"",
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.DynamicVal,
+			"",
+		},
+		{
+			cty.StringVal("x = 1\nx = 2"),
+			cty.NilVal,
+			"on line 2: Key 'x' has already been defined.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("tomldecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := TOMLDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestTOMLEncode(t *testing.T) {
+	tests := []struct {
+		Val  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"title":   cty.StringVal("example"),
+				"port":    cty.NumberIntVal(8080),
+				"ratio":   cty.NumberFloatVal(0.5),
+				"enabled": cty.True,
+				"unset":   cty.NullVal(cty.String),
+				"tags":    cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+				"owner": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("Tom"),
+				}),
+			}),
+			cty.StringVal(`enabled = true
+port = 8080
+ratio = 0.5
+tags = ["a", "b"]
+title = "example"
+
+[owner]
+name = "Tom"
+`),
+			"",
+		},
+		{
+			cty.MapVal(map[string]cty.Value{
+				"b": cty.StringVal("2"),
+				"a": cty.StringVal("1").Mark(marks.Sensitive),
+			}),
+			cty.StringVal("a = \"1\"\nb = \"2\"\n").Mark(marks.Sensitive),
+			"",
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			"",
+		},
+		{
+			cty.StringVal("a"),
+			cty.NilVal,
+			"a TOML document must be an object or a map, not string",
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.TupleVal([]cty.Value{cty.NullVal(cty.String)}),
+			}),
+			cty.NilVal,
+			".a[0]: TOML cannot represent null values",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("tomlencode(%#v)", test.Val), func(t *testing.T) {
+			got, err := TOMLEncode(test.Val)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestINIDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`
+; comment
+name = app
+
+[database]
+host = localhost
+port = 5432
+
+[empty]
+`),
+			cty.MapVal(map[string]cty.Value{
+				"DEFAULT": cty.MapVal(map[string]cty.Value{
+					"name": cty.StringVal("app"),
+				}),
+				"database": cty.MapVal(map[string]cty.Value{
+					"host": cty.StringVal("localhost"),
+					"port": cty.StringVal("5432"),
+				}),
+				"empty": cty.MapValEmpty(cty.String),
+			}),
+			"",
+		},
+		{
+			cty.StringVal(""),
+			cty.MapValEmpty(cty.Map(cty.String)),
+			"",
+		},
+		{
+			cty.StringVal("[section]\nbroken"),
+			cty.NilVal,
+			`invalid INI: key-value delimiter not found: broken`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("inidecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := INIDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestXMLDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`<?xml version="1.0"?>
+<!-- comment -->
+<project xmlns="http://maven.apache.org/POM/4.0.0" id="p1">
+  <version>1.2.3</version>
+  <module>a</module>
+  <module>b</module>
+  <empty/>
+  <name lang="en">Example</name>
+</project>
+`),
+			cty.ObjectVal(map[string]cty.Value{
+				"project": cty.ObjectVal(map[string]cty.Value{
+					"@id":     cty.StringVal("p1"),
+					"version": cty.StringVal("1.2.3"),
+					"module":  cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+					"empty":   cty.StringVal(""),
+					"name": cty.ObjectVal(map[string]cty.Value{
+						"@lang": cty.StringVal("en"),
+						"#text": cty.StringVal("Example"),
+					}),
+				}),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("<a>\n<b>"),
+			cty.NilVal,
+			"on line 2: unexpected EOF",
+		},
+		{
+			cty.StringVal("<a/><b/>"),
+			cty.NilVal,
+			"on line 1: XML document must have only one root element",
+		},
+		{
+			cty.StringVal(""),
+			cty.NilVal,
+			"XML document has no root element",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("xmldecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := XMLDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHCLDecode(t *testing.T) {
+	tests := []struct {
+		Src  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal(`
+region = "eu-west-1"
+count  = 3
+tags = {
+  env = "prod"
+}
+zones = ["a", "b"]
+`),
+			cty.ObjectVal(map[string]cty.Value{
+				"region": cty.StringVal("eu-west-1"),
+				"count":  cty.NumberIntVal(3),
+				"tags": cty.ObjectVal(map[string]cty.Value{
+					"env": cty.StringVal("prod"),
+				}),
+				"zones": cty.TupleVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("a = var.b"),
+			cty.NilVal,
+			"on line 1, column 5: Variables not allowed; Variables may not be used here.",
+		},
+		{
+			cty.StringVal("a = 1\nblock {}"),
+			cty.NilVal,
+			`on line 2: unexpected "block" block; only attribute definitions are allowed`,
+		},
+		{
+			cty.StringVal("a = "),
+			cty.NilVal,
+			"on line 1, column 5: Missing expression; Expected the start of an expression, but found the end of the file.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hcldecode(%#v)", test.Src), func(t *testing.T) {
+			got, err := HCLDecode(test.Src)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestHCLEncode(t *testing.T) {
+	tests := []struct {
+		Val  cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"region": cty.StringVal("eu-west-1"),
+				"count":  cty.NumberIntVal(3),
+				"tags": cty.MapVal(map[string]cty.Value{
+					"env":         cty.StringVal("prod"),
+					"cost-center": cty.StringVal("42"),
+				}),
+			}),
+			cty.StringVal(`count  = 3
+region = "eu-west-1"
+tags = {
+  cost-center = "42"
+  env         = "prod"
+}
+`),
+			"",
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"password": cty.StringVal("secret").Mark(marks.Sensitive),
+			}),
+			cty.StringVal("password = \"secret\"\n").Mark(marks.Sensitive),
+			"",
+		},
+		{
+			cty.ObjectVal(map[string]cty.Value{
+				"a": cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.String).RefineNotNull(),
+			"",
+		},
+		{
+			cty.MapVal(map[string]cty.Value{
+				"not valid": cty.StringVal("a"),
+			}),
+			cty.NilVal,
+			`cannot use "not valid" as an HCL attribute name: must be a valid identifier`,
+		},
+		{
+			cty.ListValEmpty(cty.String),
+			cty.NilVal,
+			"an HCL body must be an object or a map, not list of string",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("hclencode(%#v)", test.Val), func(t *testing.T) {
+			got, err := HCLEncode(test.Val)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -152,6 +152,20 @@ var DescriptionList = map[string]descriptionEntry{
 			"`contained_ip_or_prefix` is either an IP address or an address prefix given in CIDR notation.",
 		},
 	},
+	"cidreui64": {
+		Description: "`cidreui64` calculates the IPv6 address formed from a network prefix and the modified EUI-64 interface identifier derived from a MAC address.",
+		ParamDescription: []string{
+			"`prefix` is an IPv6 address prefix given in CIDR notation, with a prefix length of at most 64 bits.",
+			"`mac` is a MAC address in EUI-48 or EUI-64 format.",
+		},
+	},
+	"cidrexclude": {
+		Description: "`cidrexclude` removes a list of address prefixes from a given IP network address prefix, returning the smallest list of prefixes that covers the remaining addresses.",
+		ParamDescription: []string{
+			"`prefix` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
+			"`exclude` is a list of IP addresses or address prefixes given in CIDR notation to remove from `prefix`.",
+		},
+	},
 	"cidrhost": {
 		Description: "`cidrhost` calculates a full host IP address for a given host number within a given IP network address prefix.",
 		ParamDescription: []string{
@@ -159,12 +173,25 @@ var DescriptionList = map[string]descriptionEntry{
 			"`hostnum` is a whole number that can be represented as a binary integer with no more than the number of digits remaining in the address after the given prefix.",
 		},
 	},
+	"cidrmerge": {
+		Description: "`cidrmerge` collapses a list of IP network address prefixes into the smallest list of prefixes that covers the same addresses.",
+		ParamDescription: []string{
+			"`prefixes` is a list of IP addresses or address prefixes given in CIDR notation.",
+		},
+	},
 	"cidrnetmask": {
 		Description: "`cidrnetmask` converts an IPv4 address prefix given in CIDR notation into a subnet mask address.",
 		ParamDescription: []string{
 			"`prefix` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
 		},
 	},
+	"cidroverlap": {
+		Description: "`cidroverlap` determines whether two IP network address prefixes have any addresses in common.",
+		ParamDescription: []string{
+			"`a` must be given in CIDR notation, as defined in [RFC 4632 section 3.1](https://tools.ietf.org/html/rfc4632#section-3.1).",
+			"`b` must be given in CIDR notation, using the same address family as `a`.",
+		},
+	},
 	"cidrsubnet": {
 		Description: "`cidrsubnet` calculates a subnet address within given IP network address prefix.",
 		ParamDescription: []string{
@@ -220,6 +247,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`distinct` takes a list and returns a new list with any duplicate elements removed.",
 		ParamDescription: []string{""},
 	},
+	"ed25519verify": {
+		Description:      "`ed25519verify` verifies an Ed25519 signature over a message, returning `true` if the signature is valid for the given public key.",
+		ParamDescription: []string{"", "", ""},
+	},
 	"element": {
 		Description:      "`element` retrieves a single element from a list.",
 		ParamDescription: []string{"", ""},
@@ -292,6 +323,22 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`formatlist` produces a list of strings by formatting a number of other values according to a specification string.",
 		ParamDescription: []string{"", ""},
 	},
+	"hcldecode": {
+		Description:      "`hcldecode` parses a string containing HCL attribute definitions, such as the content of a `.tfvars` file, and produces an object of the attribute values.",
+		ParamDescription: []string{""},
+	},
+	"hclencode": {
+		Description:      "`hclencode` encodes an object or map as a string of HCL attribute definitions, with the attributes sorted by name.",
+		ParamDescription: []string{""},
+	},
+	"hmacsha256": {
+		Description:      "`hmacsha256` computes the HMAC-SHA256 of a given message using a given secret key, and encodes it with hexadecimal digits.",
+		ParamDescription: []string{"", ""},
+	},
+	"hmacsha512": {
+		Description:      "`hmacsha512` computes the HMAC-SHA512 of a given message using a given secret key, and encodes it with hexadecimal digits.",
+		ParamDescription: []string{"", ""},
+	},
 	"indent": {
 		Description: "`indent` adds a given number of spaces to the beginnings of all but the first line in a given multi-line string.",
 		ParamDescription: []string{
@@ -303,6 +350,21 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`index` finds the element index for a given value in a list.",
 		ParamDescription: []string{"", ""},
 	},
+	"inidecode": {
+		Description:      "`inidecode` parses a string as an INI document, and produces a map from section name to a map of the keys and values in that section.",
+		ParamDescription: []string{""},
+	},
+	"ipinc": {
+		Description: "`ipinc` adds a whole number, which may be negative, to an IP address.",
+		ParamDescription: []string{
+			"`address` is an IPv4 or IPv6 address.",
+			"`n` is the whole number to add to `address`.",
+		},
+	},
+	"ipparse": {
+		Description:      "`ipparse` parses an IP address and returns an object describing its version and which special-purpose address ranges it belongs to.",
+		ParamDescription: []string{""},
+	},
 	"issensitive": {
 		Description:      "`issensitive` takes any value and returns `true` if the value is marked as sensitive, and `false` otherwise.",
 		ParamDescription: []string{""},
@@ -388,6 +450,10 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`pathexpand` takes a filesystem path that might begin with a `~` segment, and if so it replaces that segment with the current user's home directory path.",
 		ParamDescription: []string{""},
 	},
+	"pemdecode": {
+		Description:      "`pemdecode` decodes all of the PEM blocks in a given string, returning the type, headers and base64-encoded body of each one.",
+		ParamDescription: []string{""},
+	},
 	"pow": {
 		Description:      "`pow` calculates an exponent, by raising its first argument to the power of the second argument.",
 		ParamDescription: []string{"", ""},
@@ -416,10 +482,30 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`rsadecrypt` decrypts an RSA-encrypted ciphertext, returning the corresponding cleartext.",
 		ParamDescription: []string{"", ""},
 	},
+	"rsaverify": {
+		Description:      "`rsaverify` verifies an RSA signature over the SHA256 hash of a message, returning `true` if the signature is valid for the given public key.",
+		ParamDescription: []string{"", "", "", "The padding scheme used for the signature: either `\"pkcs1v15\"` (the default) or `\"pss\"`."},
+	},
 	"sensitive": {
 		Description:      "`sensitive` takes any value and returns a copy of it marked so that OpenTofu will treat it as sensitive, with the same meaning and behavior as for [sensitive input variables](/language/values/variables#suppressing-values-in-cli-output).",
 		ParamDescription: []string{""},
 	},
+	"semvercompare": {
+		Description:      "`semvercompare` compares two [semantic version](https://semver.org/) strings, returning `-1` if the first has lower precedence than the second, `1` if it has higher precedence, or `0` if they have the same precedence.",
+		ParamDescription: []string{"", ""},
+	},
+	"semvermatch": {
+		Description:      "`semvermatch` returns `true` if a [semantic version](https://semver.org/) string meets a version constraint string, using the same constraint syntax as `version` arguments in `required_providers`.",
+		ParamDescription: []string{"", ""},
+	},
+	"semverparse": {
+		Description:      "`semverparse` parses a [semantic version](https://semver.org/) string and returns an object with its major, minor and patch numbers, pre-release label and build metadata.",
+		ParamDescription: []string{""},
+	},
+	"semversort": {
+		Description:      "`semversort` takes a list of [semantic version](https://semver.org/) strings and returns a new list with the same strings sorted in ascending order of precedence.",
+		ParamDescription: []string{""},
+	},
 	"setintersection": {
 		Description:      "The `setintersection` function takes multiple sets and produces a single set containing only the elements that all of the given sets have in common. In other words, it computes the [intersection](https://en.wikipedia.org/wiki/Intersection_\\(set_theory\\)) of the sets.",
 		ParamDescription: []string{"", ""},
@@ -542,6 +628,14 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`toset` converts its argument to a set value.",
 		ParamDescription: []string{""},
 	},
+	"tomldecode": {
+		Description:      "`tomldecode` parses a string as a [TOML](https://toml.io/) document, and produces a representation of its value.",
+		ParamDescription: []string{""},
+	},
+	"tomlencode": {
+		Description:      "`tomlencode` encodes an object or map as a [TOML](https://toml.io/) document, with keys sorted by name.",
+		ParamDescription: []string{""},
+	},
 	"tostring": {
 		Description:      "`tostring` converts its argument to a string value.",
 		ParamDescription: []string{""},
@@ -601,6 +695,14 @@ var DescriptionList = map[string]descriptionEntry{
 		Description:      "`values` takes a map and returns a list containing the values of the elements in that map.",
 		ParamDescription: []string{""},
 	},
+	"x509parse": {
+		Description:      "`x509parse` parses a PEM-encoded X.509 certificate and returns an object describing its subject, issuer, subject alternative names, validity period and fingerprint.",
+		ParamDescription: []string{""},
+	},
+	"xmldecode": {
+		Description:      "`xmldecode` parses a string as an XML document, and produces an object representing its root element.",
+		ParamDescription: []string{""},
+	},
 	"yamldecode": {
 		Description:      "`yamldecode` parses a string as a subset of YAML, and produces a representation of its value.",
 		ParamDescription: []string{""},
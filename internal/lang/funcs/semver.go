@@ -0,0 +1,194 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"sort"
+
+	"github.com/apparentlymart/go-versions/versions"
+	"github.com/apparentlymart/go-versions/versions/constraints"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// SemverCompareFunc constructs a function that compares two semantic version
+// strings, returning -1, 0 or 1.
+var SemverCompareFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "a",
+			Type: cty.String,
+		},
+		{
+			Name: "b",
+			Type: cty.String,
+		},
+	},
+	Type: function.StaticReturnType(cty.Number),
+	RefineResult: func(b *cty.RefinementBuilder) *cty.RefinementBuilder {
+		return b.NotNull().NumberRangeInclusive(cty.NumberIntVal(-1), cty.NumberIntVal(1))
+	},
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		a, err := versions.ParseVersion(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid version %q: %s", args[0].AsString(), err)
+		}
+		b, err := versions.ParseVersion(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(1, "invalid version %q: %s", args[1].AsString(), err)
+		}
+
+		switch {
+		case a.LessThan(b):
+			return cty.NumberIntVal(-1), nil
+		case a.GreaterThan(b):
+			return cty.NumberIntVal(1), nil
+		default:
+			return cty.NumberIntVal(0), nil
+		}
+	},
+})
+
+// SemverMatchFunc constructs a function that tests whether a semantic version
+// string meets a version constraint string, using the same constraint syntax
+// and pre-release handling as the "version" argument in required_providers.
+var SemverMatchFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "version",
+			Type: cty.String,
+		},
+		{
+			Name: "constraint",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(cty.Bool),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v, err := versions.ParseVersion(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid version %q: %s", args[0].AsString(), err)
+		}
+		spec, err := constraints.ParseRubyStyleMulti(args[1].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(1, "invalid version constraint %q: %s", args[1].AsString(), err)
+		}
+
+		return cty.BoolVal(versions.MeetingConstraints(spec).Has(v)), nil
+	},
+})
+
+// SemverParseFunc constructs a function that parses a semantic version string
+// into an object describing its parts.
+var SemverParseFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name: "version",
+			Type: cty.String,
+		},
+	},
+	Type:         function.StaticReturnType(semverObjectType),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		v, err := versions.ParseVersion(args[0].AsString())
+		if err != nil {
+			return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid version %q: %s", args[0].AsString(), err)
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			"major":      cty.NumberUIntVal(v.Major),
+			"minor":      cty.NumberUIntVal(v.Minor),
+			"patch":      cty.NumberUIntVal(v.Patch),
+			"prerelease": cty.StringVal(string(v.Prerelease)),
+			"metadata":   cty.StringVal(string(v.Metadata)),
+		}), nil
+	},
+})
+
+// SemverSortFunc constructs a function that sorts a list of semantic version
+// strings into ascending order of precedence.
+var SemverSortFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{
+			Name:         "versions",
+			Type:         cty.List(cty.String),
+			AllowUnknown: true,
+		},
+	},
+	Type:         function.StaticReturnType(cty.List(cty.String)),
+	RefineResult: refineNotNull,
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		listVal := args[0]
+		if !listVal.IsWhollyKnown() {
+			// We can't sort unknown elements, but we do know that the result
+			// will have the same number of elements as the input.
+			if listVal.IsKnown() {
+				return cty.UnknownVal(retType).Refine().CollectionLength(listVal.LengthInt()).NewValue(), nil
+			}
+			return cty.UnknownVal(retType), nil
+		}
+		if listVal.LengthInt() == 0 {
+			return cty.ListValEmpty(cty.String), nil
+		}
+
+		type entry struct {
+			str     string
+			version versions.Version
+		}
+		entries := make([]entry, 0, listVal.LengthInt())
+		for it := listVal.ElementIterator(); it.Next(); {
+			idx, v := it.Element()
+			if v.IsNull() {
+				return cty.UnknownVal(retType), function.NewArgErrorf(0, "element %s is null", idx.AsBigFloat().String())
+			}
+			parsed, err := versions.ParseVersion(v.AsString())
+			if err != nil {
+				return cty.UnknownVal(retType), function.NewArgErrorf(0, "invalid version %q at element %s: %s", v.AsString(), idx.AsBigFloat().String(), err)
+			}
+			entries = append(entries, entry{str: v.AsString(), version: parsed})
+		}
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].version.LessThan(entries[j].version)
+		})
+
+		ret := make([]cty.Value, len(entries))
+		for i, e := range entries {
+			ret[i] = cty.StringVal(e.str)
+		}
+		return cty.ListVal(ret), nil
+	},
+})
+
+var semverObjectType = cty.Object(map[string]cty.Type{
+	"major":      cty.Number,
+	"minor":      cty.Number,
+	"patch":      cty.Number,
+	"prerelease": cty.String,
+	"metadata":   cty.String,
+})
+
+// SemverCompare compares two semantic versions, returning -1 if a has lower
+// precedence than b, 1 if a has higher precedence than b, or 0 otherwise.
+func SemverCompare(a, b cty.Value) (cty.Value, error) {
+	return SemverCompareFunc.Call([]cty.Value{a, b})
+}
+
+// SemverMatch returns true if the given version meets the given constraint.
+func SemverMatch(version, constraint cty.Value) (cty.Value, error) {
+	return SemverMatchFunc.Call([]cty.Value{version, constraint})
+}
+
+// SemverParse parses a semantic version into an object describing its parts.
+func SemverParse(version cty.Value) (cty.Value, error) {
+	return SemverParseFunc.Call([]cty.Value{version})
+}
+
+// SemverSort sorts a list of semantic versions into ascending order.
+func SemverSort(list cty.Value) (cty.Value, error) {
+	return SemverSortFunc.Call([]cty.Value{list})
+}
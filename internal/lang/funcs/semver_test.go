@@ -0,0 +1,296 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package funcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestSemverCompare(t *testing.T) {
+	tests := []struct {
+		A    cty.Value
+		B    cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.StringVal("1.2.3"),
+			cty.StringVal("1.10.0"),
+			cty.NumberIntVal(-1),
+			"",
+		},
+		{
+			cty.StringVal("2.0.0"),
+			cty.StringVal("2.0.0-rc.1"),
+			cty.NumberIntVal(1),
+			"",
+		},
+		{
+			cty.StringVal("1.2.3+build.1"),
+			cty.StringVal("1.2.3+build.2"),
+			cty.NumberIntVal(0),
+			"",
+		},
+		{
+			// Partial versions are treated as having zero for the missing parts
+			cty.StringVal("1.2"),
+			cty.StringVal("1.2.0"),
+			cty.NumberIntVal(0),
+			"",
+		},
+		{
+			cty.StringVal("1.0.0").Mark(marks.Sensitive),
+			cty.StringVal("1.0.1"),
+			cty.NumberIntVal(-1).Mark(marks.Sensitive),
+			"",
+		},
+		{
+			cty.UnknownVal(cty.String),
+			cty.StringVal("1.0.0"),
+			cty.UnknownVal(cty.Number).Refine().NotNull().NumberRangeInclusive(cty.NumberIntVal(-1), cty.NumberIntVal(1)).NewValue(),
+			"",
+		},
+		{
+			cty.StringVal("v1.0.0"),
+			cty.StringVal("1.0.0"),
+			cty.NilVal,
+			`invalid version "v1.0.0": a "v" prefix should not be used`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semvercompare(%#v, %#v)", test.A, test.B), func(t *testing.T) {
+			got, err := SemverCompare(test.A, test.B)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestSemverMatch(t *testing.T) {
+	tests := []struct {
+		Version    cty.Value
+		Constraint cty.Value
+		Want       cty.Value
+		Err        string
+	}{
+		{
+			cty.StringVal("1.28.3"),
+			cty.StringVal(">= 1.27, < 1.30"),
+			cty.True,
+			"",
+		},
+		{
+			cty.StringVal("1.30.0"),
+			cty.StringVal(">= 1.27, < 1.30"),
+			cty.False,
+			"",
+		},
+		{
+			cty.StringVal("1.4.2"),
+			cty.StringVal("~> 1.2"),
+			cty.True,
+			"",
+		},
+		{
+			cty.StringVal("2.0.0"),
+			cty.StringVal("~> 1.2"),
+			cty.False,
+			"",
+		},
+		{
+			// Pre-releases only match when selected exactly, as for
+			// provider version constraints.
+			cty.StringVal("2.0.0-beta1"),
+			cty.StringVal(">= 1.0.0"),
+			cty.False,
+			"",
+		},
+		{
+			cty.StringVal("2.0.0-beta1"),
+			cty.StringVal("2.0.0-beta1"),
+			cty.True,
+			"",
+		},
+		{
+			cty.StringVal("1.0.0"),
+			cty.StringVal(">= 1.0 <"),
+			cty.NilVal,
+			`invalid version constraint ">= 1.0 <": missing comma after ">= 1.0"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semvermatch(%#v, %#v)", test.Version, test.Constraint), func(t *testing.T) {
+			got, err := SemverMatch(test.Version, test.Constraint)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestSemverParse(t *testing.T) {
+	tests := []struct {
+		Version cty.Value
+		Want    cty.Value
+		Err     string
+	}{
+		{
+			cty.StringVal("1.2.3-beta.1+build.5"),
+			cty.ObjectVal(map[string]cty.Value{
+				"major":      cty.NumberIntVal(1),
+				"minor":      cty.NumberIntVal(2),
+				"patch":      cty.NumberIntVal(3),
+				"prerelease": cty.StringVal("beta.1"),
+				"metadata":   cty.StringVal("build.5"),
+			}),
+			"",
+		},
+		{
+			cty.StringVal("4.5"),
+			cty.ObjectVal(map[string]cty.Value{
+				"major":      cty.NumberIntVal(4),
+				"minor":      cty.NumberIntVal(5),
+				"patch":      cty.NumberIntVal(0),
+				"prerelease": cty.StringVal(""),
+				"metadata":   cty.StringVal(""),
+			}),
+			"",
+		},
+		{
+			cty.UnknownVal(cty.String).Mark(marks.Ephemeral),
+			cty.UnknownVal(semverObjectType).RefineNotNull().Mark(marks.Ephemeral),
+			"",
+		},
+		{
+			cty.StringVal("latest"),
+			cty.NilVal,
+			`invalid version "latest": invalid specification; required format is three positive integers separated by periods`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semverparse(%#v)", test.Version), func(t *testing.T) {
+			got, err := SemverParse(test.Version)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
+
+func TestSemverSort(t *testing.T) {
+	tests := []struct {
+		List cty.Value
+		Want cty.Value
+		Err  string
+	}{
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.10.0"),
+				cty.StringVal("1.2.0"),
+				cty.StringVal("2.0.0-rc.1"),
+				cty.StringVal("2.0.0"),
+				cty.StringVal("1.2"),
+				cty.StringVal("1.9.1"),
+			}),
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.2.0"),
+				cty.StringVal("1.2"),
+				cty.StringVal("1.9.1"),
+				cty.StringVal("1.10.0"),
+				cty.StringVal("2.0.0-rc.1"),
+				cty.StringVal("2.0.0"),
+			}),
+			"",
+		},
+		{
+			cty.ListValEmpty(cty.String),
+			cty.ListValEmpty(cty.String),
+			"",
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.0.0"),
+				cty.UnknownVal(cty.String),
+			}),
+			cty.UnknownVal(cty.List(cty.String)).Refine().NotNull().CollectionLength(2).NewValue(),
+			"",
+		},
+		{
+			cty.ListVal([]cty.Value{
+				cty.StringVal("1.0.0"),
+				cty.StringVal("one"),
+			}),
+			cty.NilVal,
+			`invalid version "one" at element 1: invalid specification; required format is three positive integers separated by periods`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("semversort(%#v)", test.List), func(t *testing.T) {
+			got, err := SemverSort(test.List)
+
+			if test.Err != "" {
+				if err == nil {
+					t.Fatal("succeeded; want error")
+				} else if err.Error() != test.Err {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", err.Error(), test.Err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !got.RawEquals(test.Want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.Want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ package lang
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/hashicorp/hcl/v2/ext/tryfunc"
 	ctyyaml "github.com/zclconf/go-cty-yaml"
@@ -61,6 +62,10 @@ func (s *Scope) Functions() map[string]function.Function {
 		for _, name := range coreNames {
 			s.funcs[addrs.ParseFunction(name).FullyQualified().String()] = s.funcs[name]
 		}
+
+		// Module functions can call all of the functions above, and each
+		// other, but not provider-defined functions.
+		maps.Copy(s.funcs, MakeUserFunctions(s.ModuleFunctions, s.funcs))
 	}
 	s.funcsLock.Unlock()
 
@@ -141,8 +146,12 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"ceil":                stdlib.CeilFunc,
 		"chomp":               stdlib.ChompFunc,
 		"cidrcontains":        funcs.CidrContainsFunc,
+		"cidreui64":           funcs.CidrEUI64Func,
+		"cidrexclude":         funcs.CidrExcludeFunc,
 		"cidrhost":            funcs.CidrHostFunc,
+		"cidrmerge":           funcs.CidrMergeFunc,
 		"cidrnetmask":         funcs.CidrNetmaskFunc,
+		"cidroverlap":         funcs.CidrOverlapFunc,
 		"cidrsubnet":          funcs.CidrSubnetFunc,
 		"cidrsubnets":         funcs.CidrSubnetsFunc,
 		"coalesce":            funcs.CoalesceFunc,
@@ -154,6 +163,7 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"csvdecode":           stdlib.CSVDecodeFunc,
 		"dirname":             funcs.DirnameFunc,
 		"distinct":            stdlib.DistinctFunc,
+		"ed25519verify":       funcs.Ed25519VerifyFunc,
 		"element":             stdlib.ElementFunc,
 		"endswith":            funcs.EndsWithFunc,
 		"ephemeralasnull":     funcs.EphemeralAsNullFunc,
@@ -173,8 +183,15 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"format":              stdlib.FormatFunc,
 		"formatdate":          stdlib.FormatDateFunc,
 		"formatlist":          stdlib.FormatListFunc,
+		"hcldecode":           funcs.HCLDecodeFunc,
+		"hclencode":           funcs.HCLEncodeFunc,
+		"hmacsha256":          funcs.HmacSha256Func,
+		"hmacsha512":          funcs.HmacSha512Func,
 		"indent":              stdlib.IndentFunc,
 		"index":               funcs.IndexFunc, // stdlib.IndexFunc is not compatible
+		"inidecode":           funcs.INIDecodeFunc,
+		"ipinc":               funcs.IPIncFunc,
+		"ipparse":             funcs.IPParseFunc,
 		"join":                stdlib.JoinFunc,
 		"jsondecode":          stdlib.JSONDecodeFunc,
 		"jsonencode":          stdlib.JSONEncodeFunc,
@@ -193,6 +210,7 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"one":                 funcs.OneFunc,
 		"parseint":            stdlib.ParseIntFunc,
 		"pathexpand":          funcs.PathExpandFunc,
+		"pemdecode":           funcs.PemDecodeFunc,
 		"pow":                 stdlib.PowFunc,
 		"range":               stdlib.RangeFunc,
 		"regex":               stdlib.RegexFunc,
@@ -200,7 +218,12 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"replace":             funcs.ReplaceFunc,
 		"reverse":             stdlib.ReverseListFunc,
 		"rsadecrypt":          funcs.RsaDecryptFunc,
+		"rsaverify":           funcs.RsaVerifyFunc,
 		"sensitive":           funcs.SensitiveFunc,
+		"semvercompare":       funcs.SemverCompareFunc,
+		"semvermatch":         funcs.SemverMatchFunc,
+		"semverparse":         funcs.SemverParseFunc,
+		"semversort":          funcs.SemverSortFunc,
 		"nonsensitive":        funcs.NonsensitiveFunc,
 		"issensitive":         funcs.IsSensitiveFunc,
 		"setintersection":     stdlib.SetIntersectionFunc,
@@ -225,6 +248,8 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"timeadd":             stdlib.TimeAddFunc,
 		"timecmp":             funcs.TimeCmpFunc,
 		"title":               stdlib.TitleFunc,
+		"tomldecode":          funcs.TOMLDecodeFunc,
+		"tomlencode":          funcs.TOMLEncodeFunc,
 		"tostring":            funcs.MakeToFunc(cty.String),
 		"tonumber":            funcs.MakeToFunc(cty.Number),
 		"tobool":              funcs.MakeToFunc(cty.Bool),
@@ -243,6 +268,8 @@ func makeBaseFunctionTable(baseDir string) map[string]function.Function {
 		"uuid":                funcs.UUIDFunc,
 		"uuidv5":              funcs.UUIDV5Func,
 		"values":              stdlib.ValuesFunc,
+		"x509parse":           funcs.X509ParseFunc,
+		"xmldecode":           funcs.XMLDecodeFunc,
 		"yamldecode":          ctyyaml.YAMLDecodeFunc,
 		"yamlencode":          ctyyaml.YAMLEncodeFunc,
 		"zipmap":              stdlib.ZipmapFunc,
@@ -314,6 +314,23 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cidreui64": {
+			{
+				`cidreui64("2001:db8::/64", "00:1a:2b:3c:4d:5e")`,
+				cty.StringVal("2001:db8::21a:2bff:fe3c:4d5e"),
+			},
+		},
+
+		"cidrexclude": {
+			{
+				`cidrexclude("10.0.0.0/24", ["10.0.0.0/26"])`,
+				cty.ListVal([]cty.Value{
+					cty.StringVal("10.0.0.64/26"),
+					cty.StringVal("10.0.0.128/25"),
+				}),
+			},
+		},
+
 		"cidrhost": {
 			{
 				`cidrhost("192.168.1.0/24", 5)`,
@@ -321,6 +338,15 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cidrmerge": {
+			{
+				`cidrmerge(["10.0.1.0/24", "10.0.0.0/24"])`,
+				cty.ListVal([]cty.Value{
+					cty.StringVal("10.0.0.0/23"),
+				}),
+			},
+		},
+
 		"cidrnetmask": {
 			{
 				`cidrnetmask("192.168.1.0/24")`,
@@ -328,6 +354,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"cidroverlap": {
+			{
+				`cidroverlap("10.0.0.0/16", "10.0.128.0/24")`,
+				cty.True,
+			},
+		},
+
 		"cidrsubnet": {
 			{
 				`cidrsubnet("192.168.2.0/20", 4, 6)`,
@@ -451,6 +484,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"ed25519verify": {
+			{
+				`ed25519verify("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAOhB7/zzhC+HXDdGOdLwJln5NYwm6UNXx3chmQSVTG4", "message", "e8DqV4KQyNz2/IpuE0p/PnlN3X6JIhCLzNYgL5XeUyuSwimNyOFhrCteNlP5LFsOEq3yaz1G570gV3FfJdPiBQ==")`,
+				cty.True,
+			},
+		},
+
 		"element": {
 			{
 				`element(["hello"], 0)`,
@@ -644,6 +684,36 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"hcldecode": {
+			{
+				`hcldecode("a = 1")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"a": cty.NumberIntVal(1),
+				}),
+			},
+		},
+
+		"hclencode": {
+			{
+				`hclencode({b = "x", a = 1})`,
+				cty.StringVal("a = 1\nb = \"x\"\n"),
+			},
+		},
+
+		"hmacsha256": {
+			{
+				`hmacsha256("key", "test")`,
+				cty.StringVal("02afb56304902c656fcb737cdd03de6205bb6d401da2812efd9b2d36a08af159"),
+			},
+		},
+
+		"hmacsha512": {
+			{
+				`hmacsha512("key", "test")`,
+				cty.StringVal("287a0fb89a7fbdfa5b5538636918e537a5b83065e4ff331268b7aaa115dde047a9b0f4fb5b828608fc0b6327f10055f7637b058e9e0dbb9e698901a3e6dd461c"),
+			},
+		},
+
 		"indent": {
 			{
 				fmt.Sprintf("indent(4, %#v)", Poem),
@@ -658,6 +728,31 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"inidecode": {
+			{
+				`inidecode("[s]\na = 1\n")`,
+				cty.MapVal(map[string]cty.Value{
+					"s": cty.MapVal(map[string]cty.Value{
+						"a": cty.StringVal("1"),
+					}),
+				}),
+			},
+		},
+
+		"ipinc": {
+			{
+				`ipinc("10.0.0.255", 1)`,
+				cty.StringVal("10.0.1.0"),
+			},
+		},
+
+		"ipparse": {
+			{
+				`ipparse("10.1.2.3").is_private`,
+				cty.True,
+			},
+		},
+
 		"issensitive": {
 			{
 				`issensitive(1)`,
@@ -837,6 +932,19 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"pemdecode": {
+			{
+				`pemdecode("-----BEGIN FOO-----\naGVsbG8=\n-----END FOO-----\n")`,
+				cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"type":    cty.StringVal("FOO"),
+						"headers": cty.MapValEmpty(cty.String),
+						"body":    cty.StringVal("aGVsbG8="),
+					}),
+				}),
+			},
+		},
+
 		"pow": {
 			{
 				`pow(1,0)`,
@@ -910,6 +1018,13 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"rsaverify": {
+			{
+				`rsaverify(file("rsa_public_key.pem"), "message", "CM2KZnybkJOHCDclT1ILwAZvYU4FX/0Qazc1RVgRAHztG3aWjrn7s7NP6/UWEpCjti6yQNbz624YWYujmsBfYVIbEMF/CEWIs2grzs/1T38KgizEjAXed+8aOV+TbuEr74FAIlc0IO8bA+vF5YwgweNEnnFJw/gBSazUoxPFwZ85cu+IBT7mKOxCjPyDrbRNUlpl5IYTEWmS99ko70j4aR073n5L574L51vJ8ftn4oS3pdslzcV6d9reO8UQTRM3EvrnQyj+XOfs+16vd+cQ5ypxqu7/lcig43B2Lzrhpi7ApwChLaji1V18QguZJHF9TrIrJPF2qAcQ31eC4vACoA==")`,
+				cty.True,
+			},
+		},
+
 		"sensitive": {
 			{
 				`sensitive(1)`,
@@ -917,6 +1032,37 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"semvercompare": {
+			{
+				`semvercompare("1.2.3", "1.10.0")`,
+				cty.NumberIntVal(-1),
+			},
+		},
+
+		"semvermatch": {
+			{
+				`semvermatch("1.28.3", "~> 1.27")`,
+				cty.True,
+			},
+		},
+
+		"semverparse": {
+			{
+				`semverparse("1.2.3-beta").prerelease`,
+				cty.StringVal("beta"),
+			},
+		},
+
+		"semversort": {
+			{
+				`semversort(["1.10.0", "1.9.0"])`,
+				cty.ListVal([]cty.Value{
+					cty.StringVal("1.9.0"),
+					cty.StringVal("1.10.0"),
+				}),
+			},
+		},
+
 		"setintersection": {
 			{
 				`setintersection(["a", "b"], ["b", "c"], ["b", "d"])`,
@@ -1173,6 +1319,22 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"tomldecode": {
+			{
+				`tomldecode("a = 1")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"a": cty.NumberIntVal(1),
+				}),
+			},
+		},
+
+		"tomlencode": {
+			{
+				`tomlencode({b = "x", a = 1})`,
+				cty.StringVal("a = 1\nb = \"x\"\n"),
+			},
+		},
+
 		"tonumber": {
 			{
 				`tonumber("42")`,
@@ -1312,6 +1474,22 @@ func TestFunctions(t *testing.T) {
 			},
 		},
 
+		"x509parse": {
+			{
+				`x509parse(file("certificate.pem")).sha256_fingerprint`,
+				cty.StringVal("a3d2e9fed2035b785eb90e7f1bcae0d86c8e8a8b62360afca0205eb1e5681e11"),
+			},
+		},
+
+		"xmldecode": {
+			{
+				`xmldecode("<a>b</a>")`,
+				cty.ObjectVal(map[string]cty.Value{
+					"a": cty.StringVal("b"),
+				}),
+			},
+		},
+
 		"yamldecode": {
 			{
 				`yamldecode("true")`,
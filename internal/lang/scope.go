@@ -47,6 +47,19 @@ type Scope struct {
 	// hidden in their own scope.
 	SourceAddr addrs.Referenceable
 
+	// BlockLocals are the local values declared in a nested locals block of
+	// the resource or module block that the scope is evaluating, keyed by
+	// name. References to these names are resolved by evaluating the given
+	// expressions in this same scope, so they can use the same count, each
+	// and self values as the rest of the block, and they take precedence
+	// over the module-level local values.
+	BlockLocals map[string]hcl.Expression
+
+	// ModuleFunctions are the functions declared in "function" blocks of the
+	// module that the scope is evaluating, keyed by name. They are available
+	// to expressions in the module:: namespace.
+	ModuleFunctions map[string]*UserFunction
+
 	// BaseDir is the base directory used by any interpolation functions that
 	// accept filesystem paths as arguments.
 	BaseDir string
@@ -74,6 +74,230 @@ func ParseTypeConversionConstraint(expr hcl.Expression) (TypeConversionConstrain
 	}, diags
 }
 
+// ParseTypeConversionConstraintWithTypes is like
+// [ParseTypeConversionConstraint] except that the given named types can also
+// be used, by name, anywhere that the type constraint syntax expects a type.
+func ParseTypeConversionConstraintWithTypes(expr hcl.Expression, types map[string]TypeConversionConstraint) (TypeConversionConstraint, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	ty, typeDefaults, hclDiags := typeConstraintWithTypes(expr, types)
+	diags = diags.Append(hclDiags)
+
+	return TypeConversionConstraint{
+		ConvertTarget:   ty,
+		DefaultAttrVals: typeDefaults,
+	}, diags
+}
+
+// TypeConstraintNames returns the names that the given type constraint
+// expression uses in positions where a type is expected but that are not
+// part of the built-in type constraint syntax, and so must refer to named
+// types. Each name is returned only once, in the order of first appearance.
+func TypeConstraintNames(expr hcl.Expression) []string {
+	var names []string
+	seen := make(map[string]bool)
+	var visit func(expr hcl.Expression)
+	visit = func(expr hcl.Expression) {
+		if kw := hcl.ExprAsKeyword(expr); kw != "" {
+			if !builtinTypeKeywords[kw] && !seen[kw] {
+				seen[kw] = true
+				names = append(names, kw)
+			}
+			return
+		}
+		call, diags := hcl.ExprCall(expr)
+		if diags.HasErrors() {
+			return
+		}
+		for i, arg := range call.Arguments {
+			if call.Name == "optional" && i > 0 {
+				// The second argument of optional is a default value.
+				break
+			}
+			switch call.Name {
+			case "object":
+				if attrDefs, diags := hcl.ExprMap(arg); !diags.HasErrors() {
+					for _, attrDef := range attrDefs {
+						visit(attrDef.Value)
+					}
+					continue
+				}
+			case "tuple":
+				if elemDefs, diags := hcl.ExprList(arg); !diags.HasErrors() {
+					for _, elemDef := range elemDefs {
+						visit(elemDef)
+					}
+					continue
+				}
+			}
+			visit(arg)
+		}
+	}
+	visit(expr)
+	return names
+}
+
+// builtinTypeKeywords are the keywords of the type constraint syntax, which
+// cannot be used as the names of named types.
+var builtinTypeKeywords = map[string]bool{
+	"any":      true,
+	"bool":     true,
+	"list":     true,
+	"map":      true,
+	"number":   true,
+	"object":   true,
+	"optional": true,
+	"set":      true,
+	"string":   true,
+	"tuple":    true,
+}
+
+// IsBuiltinTypeKeyword returns true if the given name is a keyword of the
+// type constraint syntax, and so cannot be used as the name of a named type.
+func IsBuiltinTypeKeyword(name string) bool {
+	return builtinTypeKeywords[name]
+}
+
+// typeConstraintWithTypes is the implementation of
+// [ParseTypeConversionConstraintWithTypes]. It handles just the parts of the
+// type constraint syntax that can contain other types, delegating everything
+// else (including reporting any errors) to [typeexpr.TypeConstraintWithDefaults].
+func typeConstraintWithTypes(expr hcl.Expression, types map[string]TypeConversionConstraint) (cty.Type, *typeexpr.Defaults, hcl.Diagnostics) {
+	if len(types) == 0 || len(TypeConstraintNames(expr)) == 0 {
+		return typeexpr.TypeConstraintWithDefaults(expr)
+	}
+
+	if kw := hcl.ExprAsKeyword(expr); kw != "" {
+		if named, ok := types[kw]; ok {
+			return named.ConvertTarget, named.DefaultAttrVals, nil
+		}
+		return typeexpr.TypeConstraintWithDefaults(expr)
+	}
+
+	call, diags := hcl.ExprCall(expr)
+	if diags.HasErrors() || len(call.Arguments) != 1 {
+		return typeexpr.TypeConstraintWithDefaults(expr)
+	}
+
+	switch call.Name {
+	case "list", "set", "map":
+		ety, elemDefaults, diags := typeConstraintWithTypes(call.Arguments[0], types)
+		var ty cty.Type
+		switch call.Name {
+		case "list":
+			ty = cty.List(ety)
+		case "set":
+			ty = cty.Set(ety)
+		default:
+			ty = cty.Map(ety)
+		}
+		if elemDefaults == nil {
+			return ty, nil, diags
+		}
+		return ty, &typeexpr.Defaults{
+			Type:     ty,
+			Children: map[string]*typeexpr.Defaults{"": elemDefaults},
+		}, diags
+
+	case "tuple":
+		elemDefs, listDiags := hcl.ExprList(call.Arguments[0])
+		if listDiags.HasErrors() {
+			return typeexpr.TypeConstraintWithDefaults(expr)
+		}
+		etys := make([]cty.Type, len(elemDefs))
+		children := make(map[string]*typeexpr.Defaults)
+		for i, elemDef := range elemDefs {
+			ety, elemDefaults, elemDiags := typeConstraintWithTypes(elemDef, types)
+			diags = append(diags, elemDiags...)
+			etys[i] = ety
+			if elemDefaults != nil {
+				children[fmt.Sprintf("%d", i)] = elemDefaults
+			}
+		}
+		ty := cty.Tuple(etys)
+		return ty, structuredTypeDefaults(ty, nil, children), diags
+
+	case "object":
+		attrDefs, mapDiags := hcl.ExprMap(call.Arguments[0])
+		if mapDiags.HasErrors() {
+			return typeexpr.TypeConstraintWithDefaults(expr)
+		}
+		atys := make(map[string]cty.Type)
+		defaultValues := make(map[string]cty.Value)
+		children := make(map[string]*typeexpr.Defaults)
+		var optAttrs []string
+		for _, attrDef := range attrDefs {
+			attrName := hcl.ExprAsKeyword(attrDef.Key)
+			if _, exists := atys[attrName]; attrName == "" || exists {
+				// Invalid or duplicate attribute names are reported by the
+				// standard implementation.
+				return typeexpr.TypeConstraintWithDefaults(expr)
+			}
+
+			atyExpr := attrDef.Value
+			var defaultExpr hcl.Expression
+			if optCall, callDiags := hcl.ExprCall(atyExpr); !callDiags.HasErrors() && optCall.Name == "optional" {
+				switch len(optCall.Arguments) {
+				case 1:
+				case 2:
+					defaultExpr = optCall.Arguments[1]
+				default:
+					return typeexpr.TypeConstraintWithDefaults(expr)
+				}
+				optAttrs = append(optAttrs, attrName)
+				atyExpr = optCall.Arguments[0]
+			}
+
+			aty, attrDefaults, attrDiags := typeConstraintWithTypes(atyExpr, types)
+			diags = append(diags, attrDiags...)
+			atys[attrName] = aty
+			if attrDefaults != nil {
+				children[attrName] = attrDefaults
+			}
+
+			if defaultExpr != nil {
+				defaultVal, defaultDiags := defaultExpr.Value(nil)
+				diags = append(diags, defaultDiags...)
+				if defaultDiags.HasErrors() {
+					continue
+				}
+				defaultVal, err := convert.Convert(defaultVal, aty)
+				if err != nil {
+					diags = append(diags, &hcl.Diagnostic{
+						Severity: hcl.DiagError,
+						Summary:  "Invalid default value for optional attribute",
+						Detail:   fmt.Sprintf("This default value is not compatible with the attribute's type constraint: %s.", err),
+						Subject:  defaultExpr.Range().Ptr(),
+					})
+					continue
+				}
+				defaultValues[attrName] = defaultVal
+			}
+		}
+		ty := cty.ObjectWithOptionalAttrs(atys, optAttrs)
+		return ty, structuredTypeDefaults(ty, defaultValues, children), diags
+
+	default:
+		return typeexpr.TypeConstraintWithDefaults(expr)
+	}
+}
+
+func structuredTypeDefaults(ty cty.Type, defaultValues map[string]cty.Value, children map[string]*typeexpr.Defaults) *typeexpr.Defaults {
+	if len(defaultValues) == 0 && len(children) == 0 {
+		return nil
+	}
+	defaults := &typeexpr.Defaults{
+		Type: ty,
+	}
+	if len(defaultValues) > 0 {
+		defaults.DefaultValues = defaultValues
+	}
+	if len(children) > 0 {
+		defaults.Children = children
+	}
+	return defaults
+}
+
 // ConvertValue attempts to convert the given value to conform to the constraint
 // represented by the receiver.
 //
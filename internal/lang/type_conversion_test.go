@@ -6,6 +6,8 @@
 package lang
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
@@ -114,6 +116,128 @@ func TestTypeConversionConstraint(t *testing.T) {
 	}
 }
 
+func TestParseTypeConversionConstraintWithTypes(t *testing.T) {
+	parse := func(t *testing.T, src string) hcl.Expression {
+		t.Helper()
+		expr, hclDiags := hclsyntax.ParseExpression([]byte(src), "", hcl.InitialPos)
+		if hclDiags.HasErrors() {
+			t.Fatal(hclDiags.Error())
+		}
+		return expr
+	}
+
+	server, diags := ParseTypeConversionConstraint(parse(t, `object({ name = string, port = optional(number, 80) })`))
+	if diags.HasErrors() {
+		t.Fatal(diags.Err().Error())
+	}
+	types := map[string]TypeConversionConstraint{
+		"server": server,
+	}
+
+	tests := map[string]struct {
+		InputValue     cty.Value
+		ConstraintExpr string
+
+		WantNames []string
+		WantValue cty.Value
+		WantError string
+	}{
+		"named type": {
+			InputValue:     cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a")}),
+			ConstraintExpr: `server`,
+			WantNames:      []string{"server"},
+			WantValue: cty.ObjectVal(map[string]cty.Value{
+				"name": cty.StringVal("a"),
+				"port": cty.NumberIntVal(80),
+			}),
+		},
+		"list of named type": {
+			InputValue: cty.TupleVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a")}),
+			}),
+			ConstraintExpr: `list(server)`,
+			WantNames:      []string{"server"},
+			WantValue: cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("a"),
+					"port": cty.NumberIntVal(80),
+				}),
+			}),
+		},
+		"object with optional named type attribute": {
+			InputValue:     cty.ObjectVal(map[string]cty.Value{"primary": cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a")})}),
+			ConstraintExpr: `object({ primary = server, backup = optional(server, { name = "b" }) })`,
+			WantNames:      []string{"server"},
+			WantValue: cty.ObjectVal(map[string]cty.Value{
+				"primary": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("a"),
+					"port": cty.NumberIntVal(80),
+				}),
+				"backup": cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("b"),
+					"port": cty.NumberIntVal(80),
+				}),
+			}),
+		},
+		"tuple of named types": {
+			InputValue: cty.TupleVal([]cty.Value{
+				cty.StringVal("x"),
+				cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("a"), "port": cty.NumberIntVal(8080)}),
+			}),
+			ConstraintExpr: `tuple([string, server])`,
+			WantNames:      []string{"server"},
+			WantValue: cty.TupleVal([]cty.Value{
+				cty.StringVal("x"),
+				cty.ObjectVal(map[string]cty.Value{
+					"name": cty.StringVal("a"),
+					"port": cty.NumberIntVal(8080),
+				}),
+			}),
+		},
+		"no named types": {
+			InputValue:     cty.StringVal("10"),
+			ConstraintExpr: `number`,
+			WantValue:      cty.NumberIntVal(10),
+		},
+		"unknown named type": {
+			ConstraintExpr: `map(client)`,
+			WantNames:      []string{"client"},
+			WantError:      `The keyword "client" is not a valid type specification.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr := parse(t, test.ConstraintExpr)
+			if got, want := TypeConstraintNames(expr), test.WantNames; !slices.Equal(got, want) {
+				t.Errorf("wrong names\ngot:  %#v\nwant: %#v", got, want)
+			}
+
+			constraint, diags := ParseTypeConversionConstraintWithTypes(expr, types)
+			if wantErr := test.WantError; wantErr != "" {
+				if !diags.HasErrors() {
+					t.Fatalf("unexpected success\nwant error: %s", wantErr)
+				}
+				if got := diags.Err().Error(); !strings.Contains(got, wantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, wantErr)
+				}
+				return
+			}
+			if diags.HasErrors() {
+				t.Fatal(diags.Err().Error())
+			}
+
+			gotVal, err := constraint.ConvertValue(test.InputValue)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if wantVal := test.WantValue; !wantVal.RawEquals(gotVal) {
+				t.Fatalf("wrong result\ngot:  %#v\nwant: %#v", gotVal, wantVal)
+			}
+		})
+	}
+}
+
 func TestConvertFunc(t *testing.T) {
 	tests := []struct {
 		CallExpr  string
@@ -0,0 +1,273 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// UserFunction is the definition of a pure function declared in a "function"
+// block of a module.
+//
+// The result expression of a user function is evaluated in a scope that
+// contains only the function's parameters, as variables named after them, and
+// the functions that are available to the module, so it cannot refer to any
+// other objects declared in the module.
+type UserFunction struct {
+	Description string
+	Params      []UserFunctionParam
+
+	// VarParam, if not nil, describes a parameter that accepts zero or more
+	// additional arguments after all of the fixed parameters. Its value in
+	// the result expression is a list of those arguments.
+	VarParam *UserFunctionParam
+
+	Result hcl.Expression
+}
+
+// UserFunctionParam describes a single parameter of a [UserFunction].
+type UserFunctionParam struct {
+	Name        string
+	Description string
+	Type        cty.Type
+
+	// Defaults, if not nil, are the default values for any optional object
+	// attributes in Type, which are applied to each argument before
+	// evaluating the result expression.
+	Defaults *typeexpr.Defaults
+}
+
+// UserFunctionName returns the name that the user function with the given
+// name is called by, which is in the module:: namespace.
+func UserFunctionName(name string) string {
+	return addrs.Function{
+		Namespaces: []string{addrs.FunctionNamespaceModule},
+		Name:       name,
+	}.String()
+}
+
+// UserFunctionCalls returns the names of the other user functions that the
+// result expression of the given function calls directly, without their
+// module:: prefix and in lexical order.
+func UserFunctionCalls(fn *UserFunction) []string {
+	var names []string
+	if syntaxExpr, ok := fn.Result.(hclsyntax.Expression); ok {
+		_ = hclsyntax.VisitAll(syntaxExpr, func(node hclsyntax.Node) hcl.Diagnostics {
+			if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+				names = append(names, call.Name)
+			}
+			return nil
+		})
+	} else if fexpr, ok := fn.Result.(hcl.ExpressionWithFunctions); ok {
+		for _, traversal := range fexpr.Functions() {
+			if len(traversal) == 0 {
+				continue
+			}
+			if root, ok := traversal[0].(hcl.TraverseRoot); ok {
+				names = append(names, root.Name)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var ret []string
+	for _, name := range names {
+		called := addrs.ParseFunction(name)
+		if len(called.Namespaces) != 1 || !called.IsNamespace(addrs.FunctionNamespaceModule) {
+			continue
+		}
+		if !seen[called.Name] {
+			seen[called.Name] = true
+			ret = append(ret, called.Name)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// UserFunctionCycle returns the names of the functions that form the first
+// cycle of calls found between the given user functions, in calling order
+// starting with the lexically-smallest name that is part of a cycle, or nil
+// if none of the functions are recursive.
+func UserFunctionCycle(fns map[string]*UserFunction) []string {
+	names := make([]string, 0, len(fns))
+	for name := range fns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(fns))
+	var stack []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range stack {
+				if n == name {
+					return append([]string(nil), stack[i:]...)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, called := range UserFunctionCalls(fns[name]) {
+			if _, exists := fns[called]; !exists {
+				continue
+			}
+			if cycle := visit(called); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, name := range names {
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// MakeUserFunctions returns the given user functions as cty functions, keyed
+// by their names in the module:: namespace.
+//
+// The result expression of each function can call any of the functions in
+// baseFuncs and any of the other user functions. Functions that call
+// themselves, whether directly or through other user functions, always fail
+// because the result of a recursive call could never be determined.
+func MakeUserFunctions(fns map[string]*UserFunction, baseFuncs map[string]function.Function) map[string]function.Function {
+	if len(fns) == 0 {
+		return nil
+	}
+
+	recursive := make(map[string]bool)
+	remaining := make(map[string]*UserFunction, len(fns))
+	for name, fn := range fns {
+		remaining[name] = fn
+	}
+	for {
+		cycle := UserFunctionCycle(remaining)
+		if cycle == nil {
+			break
+		}
+		for _, name := range cycle {
+			recursive[name] = true
+			delete(remaining, name)
+		}
+	}
+
+	// All of the functions share a single table so that they can call each
+	// other, and so we populate it only after creating all of them.
+	table := make(map[string]function.Function, len(baseFuncs)+len(fns))
+	for name, fn := range baseFuncs {
+		table[name] = fn
+	}
+	ret := make(map[string]function.Function, len(fns))
+	for name, fn := range fns {
+		fullName := UserFunctionName(name)
+		ret[fullName] = fn.function(fullName, table, recursive[name])
+	}
+	for name, fn := range ret {
+		table[name] = fn
+	}
+	return ret
+}
+
+func (fn *UserFunction) function(fullName string, table map[string]function.Function, recursive bool) function.Function {
+	params := make([]function.Parameter, len(fn.Params))
+	for i, p := range fn.Params {
+		params[i] = p.parameter()
+	}
+	var varParam *function.Parameter
+	if fn.VarParam != nil {
+		p := fn.VarParam.parameter()
+		varParam = &p
+	}
+
+	return function.New(&function.Spec{
+		Description: fn.Description,
+		Params:      params,
+		VarParam:    varParam,
+		Type:        function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			if recursive {
+				return cty.DynamicVal, fmt.Errorf("function %s calls itself, either directly or through other functions, which is not allowed", fullName)
+			}
+
+			vars := make(map[string]cty.Value, len(args))
+			for i, p := range fn.Params {
+				vars[p.Name] = p.applyDefaults(args[i])
+			}
+			if fn.VarParam != nil {
+				vars[fn.VarParam.Name] = fn.VarParam.variadicValue(args[len(fn.Params):])
+			}
+
+			hclCtx := &hcl.EvalContext{
+				Variables: vars,
+				Functions: table,
+			}
+			val, diags := fn.Result.Value(hclCtx)
+			if diags.HasErrors() {
+				return cty.DynamicVal, diags
+			}
+			return val, nil
+		},
+	})
+}
+
+func (p *UserFunctionParam) parameter() function.Parameter {
+	return function.Parameter{
+		Name:         p.Name,
+		Description:  p.Description,
+		Type:         p.Type,
+		AllowNull:    true,
+		AllowUnknown: true,
+		AllowMarked:  true,
+	}
+}
+
+func (p *UserFunctionParam) applyDefaults(val cty.Value) cty.Value {
+	if p.Defaults == nil {
+		return val
+	}
+	return p.Defaults.Apply(val)
+}
+
+// variadicValue returns the value of a variadic parameter for the given
+// arguments, which is a list unless the parameter's type is not specific
+// enough to guarantee that all of the arguments have the same type.
+func (p *UserFunctionParam) variadicValue(args []cty.Value) cty.Value {
+	vals := make([]cty.Value, len(args))
+	for i, arg := range args {
+		vals[i] = p.applyDefaults(arg)
+	}
+	if p.Type.HasDynamicTypes() {
+		return cty.TupleVal(vals)
+	}
+	if len(vals) == 0 {
+		return cty.ListValEmpty(p.Type)
+	}
+	return cty.ListVal(vals)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package lang
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/lang/marks"
+)
+
+func TestScopeModuleFunctions(t *testing.T) {
+	fns := map[string]*UserFunction{
+		"greet": {
+			Params: []UserFunctionParam{
+				{Name: "name", Type: cty.String},
+			},
+			Result: mustParseUserFunctionExpr(t, `"Hello, ${upper(name)}!"`),
+		},
+		"greet_all": {
+			VarParam: &UserFunctionParam{Name: "names", Type: cty.String},
+			Result:   mustParseUserFunctionExpr(t, `join(" ", [for n in names : module::greet(n)])`),
+		},
+		"count_all": {
+			VarParam: &UserFunctionParam{Name: "things", Type: cty.DynamicPseudoType},
+			Result:   mustParseUserFunctionExpr(t, `length(things)`),
+		},
+		"add": {
+			Params: []UserFunctionParam{
+				{Name: "a", Type: cty.Number},
+				{Name: "b", Type: cty.Number},
+			},
+			Result: mustParseUserFunctionExpr(t, `a + b`),
+		},
+	}
+
+	tests := map[string]struct {
+		expr    string
+		want    cty.Value
+		wantErr string
+	}{
+		"simple": {
+			expr: `module::greet("world")`,
+			want: cty.StringVal("Hello, WORLD!"),
+		},
+		"argument conversion": {
+			expr: `module::add("1", 2)`,
+			want: cty.NumberIntVal(3),
+		},
+		"calls another function": {
+			expr: `module::greet_all("a", "b")`,
+			want: cty.StringVal("Hello, A! Hello, B!"),
+		},
+		"no variadic arguments": {
+			expr: `module::greet_all()`,
+			want: cty.StringVal(""),
+		},
+		"variadic arguments of different types": {
+			expr: `module::count_all("a", 1, true)`,
+			want: cty.NumberIntVal(3),
+		},
+		"unknown argument": {
+			expr: `module::add(1, unknown)`,
+			want: cty.UnknownVal(cty.Number).RefineNotNull(),
+		},
+		"sensitive argument": {
+			expr: `module::greet(secret)`,
+			want: cty.StringVal("Hello, SHH!").Mark(marks.Sensitive),
+		},
+		"wrong argument type": {
+			expr:    `module::add("a", 1)`,
+			wantErr: `Invalid value for "a" parameter`,
+		},
+		"unknown function": {
+			expr:    `module::nope()`,
+			wantErr: `There is no function named "nope" declared in a "function" block of this module.`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			scope := &Scope{
+				ModuleFunctions: fns,
+			}
+			expr := mustParseUserFunctionExpr(t, test.expr)
+			hclCtx, diags := scope.EvalContext(context.Background(), nil)
+			if diags.HasErrors() {
+				t.Fatalf("unexpected diagnostics: %s", diags.Err())
+			}
+			hclCtx.Variables = map[string]cty.Value{
+				"unknown": cty.UnknownVal(cty.Number),
+				"secret":  cty.StringVal("shh").Mark(marks.Sensitive),
+			}
+
+			got, hclDiags := expr.Value(hclCtx)
+			hclDiags = enhanceFunctionDiags(hclDiags)
+			if test.wantErr != "" {
+				if !hclDiags.HasErrors() {
+					t.Fatalf("unexpected success; want error containing %q", test.wantErr)
+				}
+				if got := hclDiags.Error(); !strings.Contains(got, test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %s\nwant: %s", got, test.wantErr)
+				}
+				return
+			}
+			if hclDiags.HasErrors() {
+				t.Fatalf("unexpected diagnostics: %s", hclDiags.Error())
+			}
+			if !got.RawEquals(test.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMakeUserFunctions_recursive(t *testing.T) {
+	fns := map[string]*UserFunction{
+		"a": {Result: mustParseUserFunctionExpr(t, `module::b()`)},
+		"b": {Result: mustParseUserFunctionExpr(t, `module::a()`)},
+		"c": {Result: mustParseUserFunctionExpr(t, `"c"`)},
+	}
+
+	if got, want := UserFunctionCycle(fns), []string{"a", "b"}; !cmp.Equal(got, want) {
+		t.Errorf("wrong cycle\n%s", cmp.Diff(want, got))
+	}
+
+	table := MakeUserFunctions(fns, nil)
+
+	_, err := table["module::b"].Call(nil)
+	if err == nil {
+		t.Fatal("unexpected success calling recursive function")
+	}
+	if got, want := err.Error(), "function module::b calls itself"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+
+	got, err := table["module::c"].Call(nil)
+	if err != nil {
+		t.Fatalf("unexpected error calling non-recursive function: %s", err)
+	}
+	if want := cty.StringVal("c"); !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestUserFunctionCycle_none(t *testing.T) {
+	fns := map[string]*UserFunction{
+		"a": {Result: mustParseUserFunctionExpr(t, `module::b(module::c())`)},
+		"b": {Result: mustParseUserFunctionExpr(t, `module::c()`)},
+		"c": {Result: mustParseUserFunctionExpr(t, `upper("c")`)},
+	}
+
+	if got := UserFunctionCycle(fns); got != nil {
+		t.Errorf("unexpected cycle %#v", got)
+	}
+	if got, want := UserFunctionCalls(fns["a"]), []string{"b", "c"}; !cmp.Equal(got, want) {
+		t.Errorf("wrong calls\n%s", cmp.Diff(want, got))
+	}
+}
+
+func mustParseUserFunctionExpr(t *testing.T, src string) hcl.Expression {
+	t.Helper()
+
+	expr, diags := hclsyntax.ParseExpression([]byte(src), "test.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("invalid expression %q: %s", src, diags.Error())
+	}
+	return expr
+}
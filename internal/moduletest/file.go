@@ -16,7 +16,8 @@ type File struct {
 	Name   string
 	Status Status
 
-	Runs []*Run
+	Runs   []*Run
+	Fuzzes []*Fuzz
 
 	Diagnostics tfdiags.Diagnostics
 }
@@ -0,0 +1,713 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package moduletest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs"
+	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+const (
+	// fuzzShrinkBudget limits the number of evaluations spent shrinking a
+	// failing case.
+	fuzzShrinkBudget = 1000
+
+	fuzzDefaultChars            = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./: "
+	fuzzDefaultStringLength     = 12
+	fuzzDefaultCollectionLength = 4
+	fuzzDefaultMin              = -1000
+	fuzzDefaultMax              = 1000
+)
+
+// Fuzz represents a single fuzz block within a test file.
+type Fuzz struct {
+	Config *configs.TestFuzz
+
+	Name   string
+	Status Status
+
+	// Cases is the number of generated cases that were evaluated, not
+	// counting the ones evaluated while shrinking a failing case.
+	Cases int
+
+	Diagnostics tfdiags.Diagnostics
+}
+
+// fuzzOutcome is the result of evaluating a single generated case.
+type fuzzOutcome int
+
+const (
+	fuzzOK fuzzOutcome = iota
+	// fuzzMismatch means the validation rules disagreed with expect_valid.
+	fuzzMismatch
+	// fuzzValidationError means a validation rule or precondition could not
+	// be evaluated.
+	fuzzValidationError
+	// fuzzExpectError means the expect_valid expression could not be
+	// evaluated.
+	fuzzExpectError
+)
+
+// fuzzResult holds the details of an evaluated case.
+type fuzzResult struct {
+	outcome fuzzOutcome
+	valid   bool
+	diags   tfdiags.Diagnostics
+}
+
+// Execute generates cases for the input variables of the given module and
+// checks each of them. The variable validation rules, and the preconditions
+// that only refer to input variables, are evaluated through the static
+// evaluator so no providers are involved.
+//
+// The first failing case is shrunk to a minimal counterexample, which is
+// reported in the diagnostics along with the status of the fuzz block.
+func (fuzz *Fuzz) Execute(ctx context.Context, module *configs.Module) {
+	var names []string
+	for name := range module.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for name, generator := range fuzz.Config.Generators {
+		variable, exists := module.Variables[name]
+		if !exists {
+			fuzz.Status = Error
+			fuzz.Diagnostics = fuzz.Diagnostics.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unknown variable in generator",
+				Detail:   fmt.Sprintf("The module under test does not declare a variable named %q.", name),
+				Subject:  generator.DeclRange.Ptr(),
+			})
+			continue
+		}
+		for _, v := range generator.Values {
+			if _, err := convert.Convert(v, variable.ConstraintType); err != nil {
+				fuzz.Status = Error
+				fuzz.Diagnostics = fuzz.Diagnostics.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid generator value",
+					Detail:   fmt.Sprintf("The value %s is not suitable for variable %q: %s.", fuzzFormatValue(v), name, tfdiags.FormatError(err)),
+					Subject:  generator.DeclRange.Ptr(),
+				})
+			}
+		}
+	}
+	if fuzz.Status == Error {
+		return
+	}
+
+	checks := fuzzChecks(module)
+	gen := &fuzzGenerator{rand: rand.New(rand.NewSource(fuzz.Config.Seed))} //nolint:gosec // the values don't need to be cryptographically secure
+
+	for i := 0; i < fuzz.Config.Iterations; i++ {
+		vals := make(map[string]cty.Value, len(names))
+		for _, name := range names {
+			vals[name] = gen.variable(module.Variables[name], fuzz.Config.Generators[name])
+		}
+
+		fuzz.Cases++
+		result := fuzz.evaluate(ctx, module, checks, vals)
+		if result.outcome == fuzzOK {
+			continue
+		}
+
+		vals, result = fuzz.shrink(ctx, module, checks, names, vals, result)
+		fuzz.report(names, vals, result)
+		return
+	}
+
+	fuzz.Status = Pass
+}
+
+// evaluate checks a single case.
+func (fuzz *Fuzz) evaluate(ctx context.Context, module *configs.Module, checks []fuzzCheck, vals map[string]cty.Value) fuzzResult {
+	call := configs.NewStaticModuleCall(addrs.RootModule, fuzz.Config.DeclRange, func(v *configs.Variable) (cty.Value, hcl.Diagnostics) {
+		val, ok := vals[v.Name]
+		if !ok {
+			return cty.NullVal(cty.DynamicPseudoType), nil
+		}
+		return val, nil
+	}, module.SourceDir, "default")
+	eval := configs.NewStaticEvaluator(module, call)
+
+	var diags tfdiags.Diagnostics
+	valid := true
+	for _, check := range checks {
+		val, valDiags := eval.Evaluate(ctx, check.rule.Condition, check.ident)
+		diags = diags.Append(valDiags)
+		if valDiags.HasErrors() {
+			return fuzzResult{outcome: fuzzValidationError, diags: diags}
+		}
+
+		val, _ = val.Unmark()
+		if val.IsNull() || !val.IsKnown() || !val.Type().Equals(cty.Bool) {
+			return fuzzResult{outcome: fuzzValidationError, diags: diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid condition result",
+				Detail:   fmt.Sprintf("The condition of %s must produce either true or false, but produced %s.", check.ident.Subject, fuzzFormatValue(val)),
+				Subject:  check.rule.Condition.Range().Ptr(),
+			})}
+		}
+		if val.False() {
+			valid = false
+		}
+	}
+
+	if fuzz.Config.ExpectValid == nil {
+		return fuzzResult{outcome: fuzzOK, valid: valid}
+	}
+
+	ident := configs.StaticIdentifier{
+		Module:    addrs.RootModule,
+		Subject:   fmt.Sprintf("fuzz.%s.expect_valid", fuzz.Name),
+		DeclRange: fuzz.Config.ExpectValid.Range(),
+	}
+	expected, expectDiags := eval.Evaluate(ctx, fuzz.Config.ExpectValid, ident)
+	if !expectDiags.HasErrors() {
+		var err error
+		expected, _ = expected.Unmark()
+		expected, err = convert.Convert(expected, cty.Bool)
+		if err != nil || expected.IsNull() || !expected.IsKnown() {
+			expectDiags = expectDiags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid expect_valid result",
+				Detail:   "The expect_valid expression must produce either true or false.",
+				Subject:  fuzz.Config.ExpectValid.Range().Ptr(),
+			})
+		}
+	}
+	if expectDiags.HasErrors() {
+		return fuzzResult{outcome: fuzzExpectError, valid: valid, diags: tfdiags.Diagnostics(nil).Append(expectDiags)}
+	}
+
+	if expected.True() != valid {
+		return fuzzResult{outcome: fuzzMismatch, valid: valid}
+	}
+	return fuzzResult{outcome: fuzzOK, valid: valid}
+}
+
+// shrink repeatedly replaces the values of a failing case with smaller ones,
+// as long as the case keeps failing in the same way.
+func (fuzz *Fuzz) shrink(ctx context.Context, module *configs.Module, checks []fuzzCheck, names []string, vals map[string]cty.Value, result fuzzResult) (map[string]cty.Value, fuzzResult) {
+	budget := fuzzShrinkBudget
+	for improved := true; improved && budget > 0; {
+		improved = false
+		for _, name := range names {
+			generator := fuzz.Config.Generators[name]
+			if generator != nil && generator.ValuesOnly() {
+				// Anything outside of the candidate values wouldn't be a
+				// meaningful counterexample.
+				continue
+			}
+
+			for _, candidate := range fuzzShrinkValue(vals[name], generator) {
+				if budget <= 0 {
+					break
+				}
+				budget--
+
+				next := make(map[string]cty.Value, len(vals))
+				for k, v := range vals {
+					next[k] = v
+				}
+				next[name] = candidate
+
+				if r := fuzz.evaluate(ctx, module, checks, next); r.outcome == result.outcome {
+					vals, result = next, r
+					improved = true
+					break
+				}
+			}
+		}
+	}
+	return vals, result
+}
+
+// report records a failing case in the diagnostics of the fuzz block.
+func (fuzz *Fuzz) report(names []string, vals map[string]cty.Value, result fuzzResult) {
+	var inputs strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&inputs, "\n  %s = %s", name, fuzzFormatValue(vals[name]))
+	}
+	origin := fmt.Sprintf("The input values were shrunk from case %d of %d, generated with seed %d.", fuzz.Cases, fuzz.Config.Iterations, fuzz.Config.Seed)
+
+	switch result.outcome {
+	case fuzzMismatch:
+		fuzz.Status = Fail
+		accepted := "rejected"
+		if result.valid {
+			accepted = "accepted"
+		}
+		fuzz.Diagnostics = fuzz.Diagnostics.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Fuzz property violated",
+			Detail:   fmt.Sprintf("The validation rules %s the following input values, but expect_valid evaluated to %t:\n%s\n\n%s", accepted, !result.valid, inputs.String(), origin),
+			Subject:  fuzz.Config.ExpectValid.Range().Ptr(),
+		})
+	case fuzzValidationError:
+		fuzz.Status = Fail
+		fuzz.Diagnostics = fuzz.Diagnostics.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Fuzz property violated",
+			Detail:   fmt.Sprintf("The validation rules could not be evaluated for the following input values:\n%s\n\n%s", inputs.String(), origin),
+			Subject:  fuzz.Config.NameDeclRange.Ptr(),
+		})
+		fuzz.Diagnostics = fuzz.Diagnostics.Append(result.diags)
+	case fuzzExpectError:
+		fuzz.Status = Error
+		fuzz.Diagnostics = fuzz.Diagnostics.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to evaluate expect_valid",
+			Detail:   fmt.Sprintf("The expect_valid expression could not be evaluated for the following input values:\n%s\n\n%s", inputs.String(), origin),
+			Subject:  fuzz.Config.ExpectValid.Range().Ptr(),
+		})
+		fuzz.Diagnostics = fuzz.Diagnostics.Append(result.diags)
+	}
+}
+
+// fuzzCheck is a condition evaluated for every generated case.
+type fuzzCheck struct {
+	rule  *configs.CheckRule
+	ident configs.StaticIdentifier
+}
+
+// fuzzChecks returns the variable validation rules of the module, and the
+// preconditions that only refer to input variables, in a stable order.
+func fuzzChecks(module *configs.Module) []fuzzCheck {
+	var checks []fuzzCheck
+
+	add := func(subject string, rules []*configs.CheckRule, onlyVariables bool) {
+		for _, rule := range rules {
+			if onlyVariables && !fuzzOnlyRefersToVariables(rule) {
+				continue
+			}
+			checks = append(checks, fuzzCheck{
+				rule: rule,
+				ident: configs.StaticIdentifier{
+					Module:    addrs.RootModule,
+					Subject:   subject,
+					DeclRange: rule.DeclRange,
+				},
+			})
+		}
+	}
+
+	var names []string
+	for name := range module.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(fmt.Sprintf("var.%s.validation", name), module.Variables[name].Validations, false)
+	}
+
+	var resources []*configs.Resource
+	for _, r := range module.ManagedResources {
+		resources = append(resources, r)
+	}
+	for _, r := range module.DataResources {
+		resources = append(resources, r)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Addr().String() < resources[j].Addr().String()
+	})
+	for _, r := range resources {
+		add(fmt.Sprintf("%s.precondition", r.Addr()), r.Preconditions, true)
+	}
+
+	names = names[:0]
+	for name := range module.Outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(fmt.Sprintf("output.%s.precondition", name), module.Outputs[name].Preconditions, true)
+	}
+
+	return checks
+}
+
+// fuzzOnlyRefersToVariables returns true if the condition of the rule refers
+// to nothing but input variables.
+func fuzzOnlyRefersToVariables(rule *configs.CheckRule) bool {
+	refs, diags := lang.ReferencesInExpr(addrs.ParseRef, rule.Condition)
+	if diags.HasErrors() {
+		return false
+	}
+	for _, ref := range refs {
+		if _, ok := ref.Subject.(addrs.InputVariable); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzGenerator produces random values that conform to a type constraint.
+type fuzzGenerator struct {
+	rand *rand.Rand
+}
+
+// variable generates a value for the given input variable.
+func (g *fuzzGenerator) variable(variable *configs.Variable, generator *configs.TestFuzzGenerator) cty.Value {
+	if generator != nil && len(generator.Values) > 0 {
+		if generator.ValuesOnly() || g.rand.Intn(4) == 0 {
+			return generator.Values[g.rand.Intn(len(generator.Values))]
+		}
+	}
+
+	if variable.Nullable && g.rand.Intn(10) == 0 {
+		return cty.NullVal(variable.ConstraintType)
+	}
+	return g.value(g.concreteType(variable.ConstraintType, 0), generator)
+}
+
+// concreteType replaces the "any" placeholders within the given type with
+// randomly chosen types, so that the elements of each generated collection
+// share the same type.
+func (g *fuzzGenerator) concreteType(ty cty.Type, depth int) cty.Type {
+	switch {
+	case ty == cty.DynamicPseudoType:
+		choices := []cty.Type{cty.String, cty.Number, cty.Bool}
+		if depth < 2 {
+			choices = append(choices, cty.List(cty.String), cty.Map(cty.Number))
+		}
+		return choices[g.rand.Intn(len(choices))]
+	case ty.IsListType():
+		return cty.List(g.concreteType(ty.ElementType(), depth+1))
+	case ty.IsSetType():
+		return cty.Set(g.concreteType(ty.ElementType(), depth+1))
+	case ty.IsMapType():
+		return cty.Map(g.concreteType(ty.ElementType(), depth+1))
+	case ty.IsTupleType():
+		var etys []cty.Type
+		for _, ety := range ty.TupleElementTypes() {
+			etys = append(etys, g.concreteType(ety, depth+1))
+		}
+		return cty.Tuple(etys)
+	case ty.IsObjectType():
+		atys := make(map[string]cty.Type)
+		var optional []string
+		for name, aty := range ty.AttributeTypes() {
+			atys[name] = g.concreteType(aty, depth+1)
+			if ty.AttributeOptional(name) {
+				optional = append(optional, name)
+			}
+		}
+		return cty.ObjectWithOptionalAttrs(atys, optional)
+	default:
+		return ty
+	}
+}
+
+// value generates a value of the given type, which must not contain any
+// "any" placeholders. The generator, if any, bounds the generated numbers
+// and lengths.
+func (g *fuzzGenerator) value(ty cty.Type, generator *configs.TestFuzzGenerator) cty.Value {
+	switch {
+	case ty == cty.Bool:
+		return cty.BoolVal(g.rand.Intn(2) == 0)
+
+	case ty == cty.Number:
+		return g.number(generator)
+
+	case ty == cty.String:
+		return g.string(generator, fuzzMaxLength(generator, fuzzDefaultStringLength))
+
+	case ty.IsListType(), ty.IsSetType():
+		var elems []cty.Value
+		for n := g.rand.Intn(fuzzMaxLength(generator, fuzzDefaultCollectionLength) + 1); n > 0; n-- {
+			elems = append(elems, g.value(ty.ElementType(), generator))
+		}
+		switch {
+		case len(elems) == 0 && ty.IsListType():
+			return cty.ListValEmpty(ty.ElementType())
+		case len(elems) == 0:
+			return cty.SetValEmpty(ty.ElementType())
+		case ty.IsListType():
+			return cty.ListVal(elems)
+		default:
+			return cty.SetVal(elems)
+		}
+
+	case ty.IsMapType():
+		elems := make(map[string]cty.Value)
+		for n := g.rand.Intn(fuzzMaxLength(generator, fuzzDefaultCollectionLength) + 1); n > 0; n-- {
+			key := g.string(nil, 8).AsString()
+			elems[key] = g.value(ty.ElementType(), generator)
+		}
+		if len(elems) == 0 {
+			return cty.MapValEmpty(ty.ElementType())
+		}
+		return cty.MapVal(elems)
+
+	case ty.IsTupleType():
+		var elems []cty.Value
+		for _, ety := range ty.TupleElementTypes() {
+			elems = append(elems, g.value(ety, generator))
+		}
+		if len(elems) == 0 {
+			return cty.EmptyTupleVal
+		}
+		return cty.TupleVal(elems)
+
+	case ty.IsObjectType():
+		attrs := make(map[string]cty.Value)
+		for name, aty := range ty.AttributeTypes() {
+			if ty.AttributeOptional(name) && g.rand.Intn(4) == 0 {
+				attrs[name] = cty.NullVal(aty)
+				continue
+			}
+			attrs[name] = g.value(aty, generator)
+		}
+		if len(attrs) == 0 {
+			return cty.EmptyObjectVal
+		}
+		return cty.ObjectVal(attrs)
+
+	default:
+		return cty.NullVal(ty)
+	}
+}
+
+// number generates a number within the bounds of the generator. Edge cases
+// such as the bounds themselves and zero are picked more often.
+func (g *fuzzGenerator) number(generator *configs.TestFuzzGenerator) cty.Value {
+	lo, hi := fuzzBounds(generator)
+
+	if g.rand.Intn(5) == 0 {
+		var edges []float64
+		for _, edge := range []float64{lo, hi, 0, 1, -1} {
+			if edge >= lo && edge <= hi {
+				edges = append(edges, edge)
+			}
+		}
+		return cty.NumberFloatVal(edges[g.rand.Intn(len(edges))])
+	}
+
+	ilo, ihi := math.Ceil(lo), math.Floor(hi)
+	if g.rand.Intn(10) == 0 || ilo > ihi {
+		return cty.NumberFloatVal(lo + g.rand.Float64()*(hi-lo))
+	}
+	return cty.NumberIntVal(int64(ilo) + g.rand.Int63n(int64(ihi-ilo)+1))
+}
+
+// string generates a string of up to maxLength characters.
+func (g *fuzzGenerator) string(generator *configs.TestFuzzGenerator, maxLength int) cty.Value {
+	chars := []rune(fuzzDefaultChars)
+	if generator != nil && generator.Chars != "" {
+		chars = []rune(generator.Chars)
+	}
+
+	var b strings.Builder
+	for n := g.rand.Intn(maxLength + 1); n > 0; n-- {
+		b.WriteRune(chars[g.rand.Intn(len(chars))])
+	}
+	return cty.StringVal(b.String())
+}
+
+// fuzzShrinkValue returns smaller candidates for the given value, the most
+// aggressive first.
+func fuzzShrinkValue(v cty.Value, generator *configs.TestFuzzGenerator) []cty.Value {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	ty := v.Type()
+	var candidates []cty.Value
+	switch {
+	case ty == cty.Bool:
+		if v.True() {
+			candidates = append(candidates, cty.False)
+		}
+
+	case ty == cty.Number:
+		lo, hi := fuzzBounds(generator)
+		target := math.Max(lo, math.Min(hi, 0))
+		f, _ := v.AsBigFloat().Float64()
+		if f == target {
+			return nil
+		}
+		candidates = append(candidates, cty.NumberFloatVal(target))
+		if f != math.Trunc(f) && math.Trunc(f) >= lo && math.Trunc(f) <= hi {
+			candidates = append(candidates, cty.NumberFloatVal(math.Trunc(f)))
+		}
+		if mid := math.Trunc(target + (f-target)/2); mid != f && mid != target {
+			candidates = append(candidates, cty.NumberFloatVal(mid))
+		}
+		if f == math.Trunc(f) {
+			step := 1.0
+			if f > target {
+				step = -1.0
+			}
+			if next := f + step; next != target {
+				candidates = append(candidates, cty.NumberFloatVal(next))
+			}
+		}
+
+	case ty == cty.String:
+		s := []rune(v.AsString())
+		if len(s) == 0 {
+			return nil
+		}
+		candidates = append(candidates, cty.StringVal(""))
+		if len(s) > 2 {
+			candidates = append(candidates, cty.StringVal(string(s[:len(s)/2])))
+		}
+		if len(s) > 1 {
+			candidates = append(candidates, cty.StringVal(string(s[1:])), cty.StringVal(string(s[:len(s)-1])))
+		}
+
+	case ty.IsListType(), ty.IsSetType():
+		elems := v.AsValueSlice()
+		if len(elems) == 0 {
+			return nil
+		}
+		rebuild := func(elems []cty.Value) cty.Value {
+			switch {
+			case len(elems) == 0 && ty.IsListType():
+				return cty.ListValEmpty(ty.ElementType())
+			case len(elems) == 0:
+				return cty.SetValEmpty(ty.ElementType())
+			case ty.IsListType():
+				return cty.ListVal(elems)
+			default:
+				return cty.SetVal(elems)
+			}
+		}
+		candidates = append(candidates, rebuild(nil))
+		for i := range elems {
+			candidates = append(candidates, rebuild(fuzzWithout(elems, i)))
+		}
+		for i := range elems {
+			for _, smaller := range fuzzShrinkValue(elems[i], generator) {
+				candidates = append(candidates, rebuild(fuzzWith(elems, i, smaller)))
+			}
+		}
+
+	case ty.IsTupleType():
+		elems := v.AsValueSlice()
+		for i := range elems {
+			for _, smaller := range fuzzShrinkValue(elems[i], generator) {
+				candidates = append(candidates, cty.TupleVal(fuzzWith(elems, i, smaller)))
+			}
+		}
+
+	case ty.IsMapType():
+		elems := v.AsValueMap()
+		if len(elems) == 0 {
+			return nil
+		}
+		candidates = append(candidates, cty.MapValEmpty(ty.ElementType()))
+		for _, key := range fuzzSortedKeys(elems) {
+			without := make(map[string]cty.Value, len(elems))
+			for k, e := range elems {
+				if k != key {
+					without[k] = e
+				}
+			}
+			if len(without) == 0 {
+				continue
+			}
+			candidates = append(candidates, cty.MapVal(without))
+		}
+		for _, key := range fuzzSortedKeys(elems) {
+			for _, smaller := range fuzzShrinkValue(elems[key], generator) {
+				candidates = append(candidates, cty.MapVal(fuzzWithAttr(elems, key, smaller)))
+			}
+		}
+
+	case ty.IsObjectType():
+		attrs := v.AsValueMap()
+		for _, name := range fuzzSortedKeys(attrs) {
+			if ty.AttributeOptional(name) && !attrs[name].IsNull() {
+				candidates = append(candidates, cty.ObjectVal(fuzzWithAttr(attrs, name, cty.NullVal(ty.AttributeType(name)))))
+			}
+			for _, smaller := range fuzzShrinkValue(attrs[name], generator) {
+				candidates = append(candidates, cty.ObjectVal(fuzzWithAttr(attrs, name, smaller)))
+			}
+		}
+	}
+	return candidates
+}
+
+func fuzzBounds(generator *configs.TestFuzzGenerator) (float64, float64) {
+	lo, hi := float64(fuzzDefaultMin), float64(fuzzDefaultMax)
+	if generator != nil && generator.Min != nil {
+		lo = *generator.Min
+		if generator.Max == nil && hi < lo {
+			hi = lo + fuzzDefaultMax - fuzzDefaultMin
+		}
+	}
+	if generator != nil && generator.Max != nil {
+		hi = *generator.Max
+		if generator.Min == nil && lo > hi {
+			lo = hi - (fuzzDefaultMax - fuzzDefaultMin)
+		}
+	}
+	return lo, hi
+}
+
+func fuzzMaxLength(generator *configs.TestFuzzGenerator, def int) int {
+	if generator != nil && generator.MaxLength > 0 {
+		return generator.MaxLength
+	}
+	return def
+}
+
+func fuzzWithout(elems []cty.Value, i int) []cty.Value {
+	ret := make([]cty.Value, 0, len(elems)-1)
+	ret = append(ret, elems[:i]...)
+	return append(ret, elems[i+1:]...)
+}
+
+func fuzzWith(elems []cty.Value, i int, v cty.Value) []cty.Value {
+	ret := make([]cty.Value, len(elems))
+	copy(ret, elems)
+	ret[i] = v
+	return ret
+}
+
+func fuzzWithAttr(attrs map[string]cty.Value, name string, v cty.Value) map[string]cty.Value {
+	ret := make(map[string]cty.Value, len(attrs))
+	for k, e := range attrs {
+		ret[k] = e
+	}
+	ret[name] = v
+	return ret
+}
+
+func fuzzSortedKeys(m map[string]cty.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fuzzFormatValue renders a value using the HCL syntax.
+func fuzzFormatValue(v cty.Value) string {
+	v, _ = v.UnmarkDeep()
+	if !v.IsWhollyKnown() {
+		return "(unknown)"
+	}
+	return strings.TrimSpace(string(hclwrite.TokensForValue(v).Bytes()))
+}
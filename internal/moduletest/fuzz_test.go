@@ -0,0 +1,174 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package moduletest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs"
+)
+
+func TestFuzz_Execute(t *testing.T) {
+	float := func(f float64) *float64 { return &f }
+
+	tcs := map[string]struct {
+		module      string
+		generators  map[string]*configs.TestFuzzGenerator
+		expectValid string
+
+		status  Status
+		summary string
+		detail  *regexp.Regexp
+	}{
+		"pass": {
+			module: `
+variable "port" {
+  type     = number
+  nullable = false
+  validation {
+    condition     = var.port >= 1 && var.port <= 65535
+    error_message = "Invalid port."
+  }
+}
+`,
+			generators: map[string]*configs.TestFuzzGenerator{
+				"port": {Variable: "port", Min: float(-10), Max: float(70000)},
+			},
+			expectValid: `var.port >= 1 && var.port <= 65535`,
+			status:      Pass,
+		},
+		"mismatch is shrunk": {
+			module: `
+variable "name" {
+  type     = string
+  nullable = false
+  validation {
+    condition     = length(var.name) <= 10
+    error_message = "Name too long."
+  }
+}
+`,
+			generators: map[string]*configs.TestFuzzGenerator{
+				"name": {Variable: "name", MaxLength: 20, Chars: "ab"},
+			},
+			expectValid: `length(var.name) <= 8`,
+			status:      Fail,
+			summary:     "Fuzz property violated",
+			// The shortest counterexample is a string of nine characters.
+			detail: regexp.MustCompile(`accepted the following input values, but expect_valid evaluated to false:\n\n  name = "[ab]{9}"\n`),
+		},
+		"validation error": {
+			module: `
+variable "tags" {
+  type = list(string)
+  validation {
+    condition     = length(var.tags) < 3
+    error_message = "Too many tags."
+  }
+}
+`,
+			status:  Fail,
+			summary: "Fuzz property violated",
+			detail:  regexp.MustCompile(`could not be evaluated for the following input values:\n\n  tags = null\n`),
+		},
+		"values only": {
+			module: `
+variable "env" {
+  type     = string
+  nullable = false
+  validation {
+    condition     = contains(["dev", "prod"], var.env)
+    error_message = "Invalid environment."
+  }
+}
+`,
+			generators: map[string]*configs.TestFuzzGenerator{
+				"env": {Variable: "env", Values: []cty.Value{cty.StringVal("dev"), cty.StringVal("prod"), cty.StringVal("qa")}},
+			},
+			expectValid: `var.env != "qa"`,
+			status:      Pass,
+		},
+		"unknown variable": {
+			module: `variable "a" {}`,
+			generators: map[string]*configs.TestFuzzGenerator{
+				"b": {Variable: "b"},
+			},
+			status:  Error,
+			summary: "Unknown variable in generator",
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			module := configs.ModuleFromStringForTesting(t, tc.module)
+
+			config := &configs.TestFuzz{
+				Name:       "test",
+				Iterations: 200,
+				Seed:       1,
+				Generators: tc.generators,
+			}
+			if tc.expectValid != "" {
+				expr, diags := hclsyntax.ParseExpression([]byte(tc.expectValid), "test.tftest.hcl", hcl.InitialPos)
+				if diags.HasErrors() {
+					t.Fatalf("invalid expect_valid: %s", diags.Error())
+				}
+				config.ExpectValid = expr
+			}
+
+			fuzz := &Fuzz{Config: config, Name: config.Name}
+			fuzz.Execute(t.Context(), module)
+
+			if fuzz.Status != tc.status {
+				t.Fatalf("wrong status %s, want %s\n%s", fuzz.Status, tc.status, fuzz.Diagnostics.ErrWithWarnings())
+			}
+			if tc.status == Pass {
+				if fuzz.Cases != config.Iterations {
+					t.Errorf("expected %d cases, got %d", config.Iterations, fuzz.Cases)
+				}
+				return
+			}
+
+			if len(fuzz.Diagnostics) == 0 {
+				t.Fatalf("expected diagnostics")
+			}
+			desc := fuzz.Diagnostics[0].Description()
+			if desc.Summary != tc.summary {
+				t.Errorf("wrong summary %q, want %q", desc.Summary, tc.summary)
+			}
+			if tc.detail != nil && !tc.detail.MatchString(desc.Detail) {
+				t.Errorf("wrong detail:\n%s", desc.Detail)
+			}
+		})
+	}
+}
+
+func TestFuzzShrinkValue(t *testing.T) {
+	lo := 5.0
+	generator := &configs.TestFuzzGenerator{Min: &lo}
+
+	for _, candidate := range fuzzShrinkValue(cty.NumberIntVal(100), generator) {
+		f, _ := candidate.AsBigFloat().Float64()
+		if f < lo {
+			t.Errorf("candidate %v is below the minimum", f)
+		}
+	}
+
+	if got := fuzzShrinkValue(cty.NumberIntVal(5), generator); len(got) != 0 {
+		t.Errorf("expected no candidates for the minimum, got %#v", got)
+	}
+
+	list := cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")})
+	got := fuzzShrinkValue(list, nil)
+	if len(got) == 0 || !got[0].RawEquals(cty.ListValEmpty(cty.String)) {
+		t.Errorf("expected the empty list to be the first candidate, got %#v", got)
+	}
+}
@@ -130,8 +130,13 @@ func (run *Run) GetReferences() ([]*addrs.Reference, tfdiags.Diagnostics) {
 // diagnostics were generated by custom conditions. OpenTofu adds the
 // addrs.CheckRule that generated each diagnostic to the diagnostic itself so we
 // can tell which diagnostics can be expected.
+//
+// Check blocks referenced from expect_check_failures rather than
+// expect_failures only expect failures from their assertions, so errors from
+// their scoped data sources are still reported.
 func (run *Run) ValidateExpectedFailures(expectedFailures addrs.Map[addrs.Referenceable, bool], sourceRanges addrs.Map[addrs.Referenceable, tfdiags.SourceRange], originals tfdiags.Diagnostics) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
+	assertionsOnly := run.expectedCheckFailuresOnly()
 	for _, diag := range originals {
 		if rule, ok := addrs.DiagnosticOriginatesFromCheckRule(diag); ok {
 			switch rule.Container.CheckableKind() {
@@ -276,7 +281,7 @@ func (run *Run) ValidateExpectedFailures(expectedFailures addrs.Map[addrs.Refere
 					if addr.Module.IsRoot() && original.Severity() == tfdiags.Error {
 						// Okay, we have a genuine error from the root module,
 						// so we can now check if we want to ignore it or not.
-						if expectedFailures.Has(addr.Check) {
+						if expectedFailures.Has(addr.Check) && !assertionsOnly[addr.Check.Name] {
 							// Then this failure is expected! Mark the original map as
 							// having found a failure and continue.
 							expectedFailures.Put(addr.Check, true)
@@ -313,6 +318,15 @@ func (run *Run) ValidateExpectedFailures(expectedFailures addrs.Map[addrs.Refere
 		if !failed {
 			// Then we expected a failure, and it did not occur. Add it to the
 			// diagnostics.
+			if check, ok := addr.(addrs.Check); ok && assertionsOnly[check.Name] {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Missing expected check failure",
+					Detail:   fmt.Sprintf("The check block, %s, was expected to report a failed assertion but did not.", addr.String()),
+					Subject:  sourceRanges.Get(addr).ToHCL().Ptr(),
+				})
+				continue
+			}
 			diags = diags.Append(&hcl.Diagnostic{
 				Severity: hcl.DiagError,
 				Summary:  "Missing expected failure",
@@ -339,5 +353,33 @@ func (run *Run) BuildExpectedFailuresAndSourceMaps() (addrs.Map[addrs.Referencea
 		expectedFailures.Put(reference.Subject, false)
 		sourceRanges.Put(reference.Subject, reference.SourceRange)
 	}
+	for _, traversal := range run.Config.ExpectCheckFailures {
+		reference, _ := addrs.ParseRefFromTestingScope(traversal)
+		if expectedFailures.Has(reference.Subject) {
+			// Also listed in expect_failures, which already covers it.
+			continue
+		}
+		expectedFailures.Put(reference.Subject, false)
+		sourceRanges.Put(reference.Subject, reference.SourceRange)
+	}
 	return expectedFailures, sourceRanges
 }
+
+// expectedCheckFailuresOnly returns the names of the check blocks that are
+// referenced from expect_check_failures but not from expect_failures.
+func (run *Run) expectedCheckFailuresOnly() map[string]bool {
+	names := make(map[string]bool)
+	for _, traversal := range run.Config.ExpectCheckFailures {
+		reference, _ := addrs.ParseRefFromTestingScope(traversal)
+		if check, ok := reference.Subject.(addrs.Check); ok {
+			names[check.Name] = true
+		}
+	}
+	for _, traversal := range run.Config.ExpectFailures {
+		reference, _ := addrs.ParseRefFromTestingScope(traversal)
+		if check, ok := reference.Subject.(addrs.Check); ok {
+			delete(names, check.Name)
+		}
+	}
+	return names
+}
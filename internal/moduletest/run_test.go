@@ -25,9 +25,10 @@ func TestRun_ValidateExpectedFailures(t *testing.T) {
 	}
 
 	tcs := map[string]struct {
-		ExpectedFailures []string
-		Input            tfdiags.Diagnostics
-		Output           []output
+		ExpectedFailures      []string
+		ExpectedCheckFailures []string
+		Input                 tfdiags.Diagnostics
+		Output                []output
 	}{
 		"empty": {
 			ExpectedFailures: nil,
@@ -746,17 +747,81 @@ func TestRun_ValidateExpectedFailures(t *testing.T) {
 				},
 			},
 		},
+		"expected check failures": {
+			ExpectedCheckFailures: []string{
+				"check.expected",
+				"check.missing",
+			},
+			Input: createDiagnostics(func(diags tfdiags.Diagnostics) tfdiags.Diagnostics {
+				// First, a failed assertion that was expected and should be
+				// removed.
+				diags = diags.Append(
+					tfdiags.Override(
+						tfdiags.Sourceless(tfdiags.Error, "expected assertion failure", "this should be removed"),
+						tfdiags.Warning,
+						func() tfdiags.DiagnosticExtraWrapper {
+							return &addrs.CheckRuleDiagnosticExtra{
+								CheckRule: addrs.NewCheckRule(addrs.AbsCheck{
+									Module: addrs.RootModuleInstance,
+									Check: addrs.Check{
+										Name: "expected",
+									},
+								}, addrs.CheckAssertion, 0),
+							}
+						}))
+
+				// Second, an error from a scoped data source of the same
+				// check block that is not expected.
+				diags = diags.Append(
+					tfdiags.Override(
+						tfdiags.Sourceless(tfdiags.Error, "data source failure", "this should be an error and not removed"),
+						tfdiags.Warning,
+						func() tfdiags.DiagnosticExtraWrapper {
+							return &addrs.CheckRuleDiagnosticExtra{
+								CheckRule: addrs.NewCheckRule(addrs.AbsCheck{
+									Module: addrs.RootModuleInstance,
+									Check: addrs.Check{
+										Name: "expected",
+									},
+								}, addrs.CheckDataResource, 0),
+							}
+						}))
+
+				return diags
+			}),
+			Output: []output{
+				{
+					Description: tfdiags.Description{
+						Summary: "data source failure",
+						Detail:  "this should be an error and not removed",
+					},
+					Severity: tfdiags.Error,
+				},
+				{
+					Description: tfdiags.Description{
+						Summary: "Missing expected check failure",
+						Detail:  "The check block, check.missing, was expected to report a failed assertion but did not.",
+					},
+					Severity: tfdiags.Error,
+				},
+			},
+		},
 	}
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
-			var traversals []hcl.Traversal
-			for _, ef := range tc.ExpectedFailures {
-				traversal, diags := hclsyntax.ParseTraversalAbs([]byte(ef), "foo.tf", hcl.Pos{Line: 1, Column: 1})
-				if diags.HasErrors() {
-					t.Errorf("invalid expected failure %s: %v", ef, diags.Error())
+			parse := func(addrs []string) []hcl.Traversal {
+				var traversals []hcl.Traversal
+				for _, ef := range addrs {
+					traversal, diags := hclsyntax.ParseTraversalAbs([]byte(ef), "foo.tf", hcl.Pos{Line: 1, Column: 1})
+					if diags.HasErrors() {
+						t.Errorf("invalid expected failure %s: %v", ef, diags.Error())
+					}
+					traversals = append(traversals, traversal)
 				}
-				traversals = append(traversals, traversal)
+				return traversals
 			}
+			traversals := parse(tc.ExpectedFailures)
+			checkTraversals := parse(tc.ExpectedCheckFailures)
 
 			if t.Failed() {
 				return
@@ -764,7 +829,8 @@ func TestRun_ValidateExpectedFailures(t *testing.T) {
 
 			run := Run{
 				Config: &configs.TestRun{
-					ExpectFailures: traversals,
+					ExpectFailures:      traversals,
+					ExpectCheckFailures: checkTraversals,
 				},
 			}
 			expectedFailures, sourceRanges := run.BuildExpectedFailuresAndSourceMaps()
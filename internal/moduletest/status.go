@@ -23,6 +23,7 @@ const (
 	Skip
 	Pass
 	Fail
+	Timeout
 	Error
 )
 
@@ -12,12 +12,13 @@ func _() {
 	_ = x[Skip-1]
 	_ = x[Pass-2]
 	_ = x[Fail-3]
-	_ = x[Error-4]
+	_ = x[Timeout-4]
+	_ = x[Error-5]
 }
 
-const _Status_name = "PendingSkipPassFailError"
+const _Status_name = "PendingSkipPassFailTimeoutError"
 
-var _Status_index = [...]uint8{0, 7, 11, 15, 19, 24}
+var _Status_index = [...]uint8{0, 7, 11, 15, 19, 26, 31}
 
 func (i Status) String() string {
 	idx := int(i) - 0
@@ -0,0 +1,24 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package cosign implements verification of cosign signatures attached to
+// artifacts in OCI Distribution repositories, shared by both the provider and
+// module installers, along with key-based signing of the artifacts that
+// OpenTofu publishes to such repositories.
+//
+// This package deals only with the "simple signing" signature format that
+// cosign stores as a separate OCI artifact, discovered either through the
+// OCI Distribution referrers API or through cosign's tag naming scheme. It
+// does not contact a Sigstore transparency log, and so it verifies
+// certificate-based ("keyless") signatures only against a fixed set of
+// trusted certificate authorities and transparency log keys chosen by the
+// operator, using the transparency log bundle that cosign attaches to each
+// such signature as proof of when it was made.
+//
+// This package intentionally depends only on the OCI-related libraries and
+// not on any other OpenTofu packages, so that it can be used from both
+// package getproviders and package getmodules without creating a dependency
+// cycle.
+package cosign
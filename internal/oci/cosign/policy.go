@@ -0,0 +1,137 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+// Policy describes which signers are trusted to sign the artifacts in a
+// particular set of OCI repositories.
+//
+// A signature is acceptable if it was made by any of the keys in PublicKeys,
+// or if it was made using a certificate that chains to one of the certificates
+// in Roots and that matches both Identities and OIDCIssuer, at the time that
+// one of the transparency logs in TransparencyLogKeys recorded the signature.
+type Policy struct {
+	// PublicKeys are the public keys whose signatures are trusted directly.
+	PublicKeys []crypto.PublicKey
+
+	// Roots are the certificate authorities trusted to issue signing
+	// certificates. If this is nil then certificate-based signatures are
+	// never accepted.
+	Roots *x509.CertPool
+
+	// Identities are the subject alternative names, such as email addresses
+	// or workflow URIs, that a signing certificate may have. A certificate
+	// must match at least one of these, so certificate-based signatures are
+	// never accepted when this is empty.
+	Identities []string
+
+	// OIDCIssuer, if set, is the OIDC issuer URL that must be recorded in a
+	// signing certificate.
+	OIDCIssuer string
+
+	// TransparencyLogKeys are the public keys of the Rekor transparency logs
+	// trusted to record when certificate-based signatures were made. Signing
+	// certificates are too short-lived to check at the current time, so
+	// certificate-based signatures are never accepted when this is empty.
+	TransparencyLogKeys []crypto.PublicKey
+}
+
+// ParsePublicKeysPEM parses all of the PEM-encoded "PUBLIC KEY" blocks in the
+// given source, as written by "cosign generate-key-pair".
+func ParsePublicKeysPEM(src []byte) ([]crypto.PublicKey, error) {
+	var ret []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, src = pem.Decode(src)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		ret = append(ret, key)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no PEM-encoded public keys found")
+	}
+	return ret, nil
+}
+
+// KeyID returns a short identifier for the given public key, suitable for
+// display in the UI.
+//
+// The result is the first 16 hexadecimal digits of the SHA-256 hash of the
+// key's PKIX encoding, which is similar in length to a GPG key ID.
+func KeyID(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		// Only unsupported key types can fail here, and ParsePublicKeysPEM
+		// can't return any of those.
+		return "(unknown)"
+	}
+	sum := sha256.Sum256(der)
+	return strings.ToUpper(hex.EncodeToString(sum[:8]))
+}
+
+// Policies is a set of trust policies, each associated with a prefix of OCI
+// repository addresses.
+//
+// The zero value of Policies contains no policies, and so does not require
+// signatures for any repository.
+type Policies struct {
+	entries []policiesEntry
+}
+
+type policiesEntry struct {
+	registryDomain, repositoryPrefix string
+	policy                           *Policy
+}
+
+// Add adds a policy that applies to repositories on the given registry whose
+// names either equal or start with the given prefix followed by a slash.
+//
+// An empty repositoryPrefix applies the policy to all of the repositories on
+// the given registry.
+func (ps *Policies) Add(registryDomain, repositoryPrefix string, policy *Policy) {
+	ps.entries = append(ps.entries, policiesEntry{registryDomain, repositoryPrefix, policy})
+}
+
+// PolicyFor returns the policy that applies to the given repository, or nil
+// if no policy applies and so signatures are not required.
+//
+// If more than one policy matches then the one with the longest repository
+// prefix takes precedence.
+func (ps Policies) PolicyFor(registryDomain, repositoryName string) *Policy {
+	var ret *Policy
+	bestLen := -1
+	for _, entry := range ps.entries {
+		if entry.registryDomain != registryDomain {
+			continue
+		}
+		prefix := entry.repositoryPrefix
+		if prefix != "" && repositoryName != prefix && !strings.HasPrefix(repositoryName, prefix+"/") {
+			continue
+		}
+		if len(prefix) > bestLen {
+			ret = entry.policy
+			bestLen = len(prefix)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,264 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	ociDigest "github.com/opencontainers/go-digest"
+	ociSpecs "github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	orasErrors "oras.land/oras-go/v2/errdef"
+)
+
+// PushStore is the interface that [Sign] uses to publish signatures to the
+// OCI repository containing the signed artifact.
+//
+// As with [Store], this matches a subset of the ORAS-Go repository API.
+type PushStore interface {
+	Store
+	Push(ctx context.Context, expected ociv1.Descriptor, content io.Reader) error
+	Tag(ctx context.Context, desc ociv1.Descriptor, reference string) error
+}
+
+// Sign signs the artifact described by subject with the given private key,
+// and publishes the signature to the given store in the same form as
+// "cosign sign --key" would.
+//
+// dockerReference is the address of the repository containing the subject,
+// like "example.com/namespace/name", which is recorded in the signed payload.
+//
+// The signature is published using cosign's tag naming scheme rather than
+// the referrers API, because all registries support tags and [Verify] always
+// checks the signature tag. As with cosign, any signatures already published
+// for the subject are retained alongside the new one.
+func Sign(ctx context.Context, store PushStore, subject ociv1.Descriptor, dockerReference string, key crypto.Signer) error {
+	var p signaturePayload
+	p.Critical.Identity.DockerReference = dockerReference
+	p.Critical.Image.DockerManifestDigest = subject.Digest.String()
+	p.Critical.Type = PayloadType
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	sig, err := signWithKey(key, payload)
+	if err != nil {
+		return err
+	}
+
+	layer := ociv1.Descriptor{
+		MediaType: SimpleSigningMediaType,
+		Digest:    ociDigest.FromBytes(payload),
+		Size:      int64(len(payload)),
+		Annotations: map[string]string{
+			SignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+		},
+	}
+	if err := pushContent(ctx, store, layer, payload); err != nil {
+		return fmt.Errorf("pushing signature payload: %w", err)
+	}
+	if err := pushContent(ctx, store, ociv1.DescriptorEmptyJSON, ociv1.DescriptorEmptyJSON.Data); err != nil {
+		return fmt.Errorf("pushing signature config: %w", err)
+	}
+
+	manifest := ociv1.Manifest{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: SignatureArtifactType,
+		Config:       ociv1.DescriptorEmptyJSON,
+	}
+	tag := SignatureTag(subject)
+	prevDesc, err := store.Resolve(ctx, tag)
+	switch {
+	case err == nil:
+		prev, err := fetchSignatureManifest(ctx, store, prevDesc)
+		if err != nil {
+			return fmt.Errorf("reading existing signatures: %w", err)
+		}
+		manifest.Layers = slices.Clone(prev.Layers)
+	case errors.Is(err, orasErrors.ErrNotFound):
+		// This is the first signature for this subject.
+	default:
+		return fmt.Errorf("resolving cosign signature tag: %w", err)
+	}
+	manifest.Layers = append(manifest.Layers, layer)
+
+	manifestSrc, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	manifestDesc := ociv1.Descriptor{
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: SignatureArtifactType,
+		Digest:       ociDigest.FromBytes(manifestSrc),
+		Size:         int64(len(manifestSrc)),
+	}
+	if err := pushContent(ctx, store, manifestDesc, manifestSrc); err != nil {
+		return fmt.Errorf("pushing signature manifest: %w", err)
+	}
+	if err := store.Tag(ctx, manifestDesc, tag); err != nil {
+		return fmt.Errorf("tagging signature manifest: %w", err)
+	}
+	return nil
+}
+
+// signaturePayload is the "simple signing" payload that cosign signs, which
+// identifies the signed artifact by its manifest digest.
+type signaturePayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]any `json:"optional"`
+}
+
+// signWithKey signs the given payload in the way that verifyWithKey expects
+// for the type of the given key.
+func signWithKey(key crypto.Signer, payload []byte) ([]byte, error) {
+	switch pub := key.Public().(type) {
+	case ed25519.PublicKey:
+		return key.Sign(rand.Reader, payload, crypto.Hash(0))
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", pub)
+	}
+}
+
+// pushContent pushes the given content to the store, tolerating content that
+// the store already has.
+func pushContent(ctx context.Context, store PushStore, desc ociv1.Descriptor, content []byte) error {
+	err := store.Push(ctx, desc, bytes.NewReader(content))
+	if err != nil && !errors.Is(err, orasErrors.ErrAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// ParsePrivateKeyPEM parses the first PEM-encoded private key in the given
+// source, for use with [Sign].
+//
+// The key can be either an unencrypted PKCS#8, SEC 1 or PKCS#1 private key,
+// or a private key encrypted with a password as written by
+// "cosign generate-key-pair", in which case it is decrypted using the
+// given passphrase.
+func ParsePrivateKeyPEM(src []byte, passphrase []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, src = pem.Decode(src)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM-encoded private key found")
+		}
+
+		var key any
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+			var der []byte
+			der, err = decryptPrivateKey(block.Bytes, passphrase)
+			if err == nil {
+				key, err = x509.ParsePKCS8PrivateKey(der)
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key: %w", err)
+		}
+
+		switch key := key.(type) {
+		case *ecdsa.PrivateKey:
+			return key, nil
+		case ed25519.PrivateKey:
+			return key, nil
+		case *rsa.PrivateKey:
+			return key, nil
+		default:
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+	}
+}
+
+// encryptedPrivateKey is the JSON structure that cosign uses for the body
+// of an encrypted private key.
+type encryptedPrivateKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// decryptPrivateKey decrypts the body of an encrypted cosign private key,
+// returning the PKCS#8 encoding of the key.
+func decryptPrivateKey(src []byte, passphrase []byte) ([]byte, error) {
+	var enc encryptedPrivateKey
+	if err := json.Unmarshal(src, &enc); err != nil {
+		return nil, err
+	}
+	if enc.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported key derivation function %q", enc.KDF.Name)
+	}
+	if enc.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported cipher %q", enc.Cipher.Name)
+	}
+	var nonce [24]byte
+	if len(enc.Cipher.Nonce) != len(nonce) {
+		return nil, fmt.Errorf("invalid nonce")
+	}
+	copy(nonce[:], enc.Cipher.Nonce)
+
+	derived, err := scrypt.Key(passphrase, enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	var secret [32]byte
+	copy(secret[:], derived)
+
+	ret, ok := secretbox.Open(nil, enc.Ciphertext, &nonce, &secret)
+	if !ok {
+		return nil, fmt.Errorf("incorrect passphrase")
+	}
+	return ret, nil
+}
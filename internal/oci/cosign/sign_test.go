@@ -0,0 +1,176 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	orasMemoryStore "oras.land/oras-go/v2/content/memory"
+)
+
+func TestSign(t *testing.T) {
+	ecdsaKey := generateTestKey(t)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]crypto.Signer{
+		"ecdsa":   ecdsaKey,
+		"ed25519": ed25519Key,
+		"rsa":     rsaKey,
+	}
+	for name, key := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := orasMemoryStore.New()
+			subject := pushTestSubject(t, store)
+
+			if err := Sign(t.Context(), store, subject, "example.com/repo", key); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := Verify(t.Context(), store, subject, &Policy{PublicKeys: []crypto.PublicKey{key.Public()}})
+			checkVerifyResult(t, got, err, "key "+KeyID(key.Public()), "")
+		})
+	}
+}
+
+func TestSign_existingSignatures(t *testing.T) {
+	firstKey := generateTestKey(t)
+	secondKey := generateTestKey(t)
+
+	store := orasMemoryStore.New()
+	subject := pushTestSubject(t, store)
+	if err := Sign(t.Context(), store, subject, "example.com/repo", firstKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := Sign(t.Context(), store, subject, "example.com/repo", secondKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both signatures must remain verifiable, because a subject can be
+	// signed by more than one party.
+	for _, key := range []crypto.Signer{firstKey, secondKey} {
+		got, err := Verify(t.Context(), store, subject, &Policy{PublicKeys: []crypto.PublicKey{key.Public()}})
+		checkVerifyResult(t, got, err, "key "+KeyID(key.Public()), "")
+	}
+}
+
+func TestParsePrivateKeyPEM(t *testing.T) {
+	key := generateTestKey(t)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		src        []byte
+		passphrase string
+		wantErr    string
+	}{
+		"pkcs8": {
+			src: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+		},
+		"sec1": {
+			src: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+		},
+		"after other blocks": {
+			src: append(
+				pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("ignored")}),
+				pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})...,
+			),
+		},
+		"cosign encrypted": {
+			src:        encryptTestKey(t, der, "s3cret"),
+			passphrase: "s3cret",
+		},
+		"cosign encrypted with wrong passphrase": {
+			src:        encryptTestKey(t, der, "s3cret"),
+			passphrase: "wrong",
+			wantErr:    "invalid private key: incorrect passphrase",
+		},
+		"public key only": {
+			src:     pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("ignored")}),
+			wantErr: "no PEM-encoded private key found",
+		},
+		"invalid key": {
+			src:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("nope")}),
+			wantErr: "invalid private key: ",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParsePrivateKeyPEM(test.src, []byte(test.passphrase))
+			if test.wantErr != "" {
+				var gotErr string
+				if err != nil {
+					gotErr = err.Error()
+				}
+				checkVerifyResult(t, gotErr, err, "", test.wantErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !key.PublicKey.Equal(got.Public()) {
+				t.Error("parsed key does not match the original key")
+			}
+		})
+	}
+}
+
+// encryptTestKey encrypts the given PKCS#8 private key in the same way as
+// "cosign generate-key-pair", but with a cheaper key derivation.
+func encryptTestKey(t *testing.T, der []byte, passphrase string) []byte {
+	t.Helper()
+	var enc encryptedPrivateKey
+	enc.KDF.Name = "scrypt"
+	enc.KDF.Params.N = 1024
+	enc.KDF.Params.R = 8
+	enc.KDF.Params.P = 1
+	enc.KDF.Salt = make([]byte, 32)
+	enc.Cipher.Name = "nacl/secretbox"
+	enc.Cipher.Nonce = make([]byte, 24)
+	if _, err := rand.Read(enc.KDF.Salt); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rand.Read(enc.Cipher.Nonce); err != nil {
+		t.Fatal(err)
+	}
+
+	derived, err := scrypt.Key([]byte(passphrase), enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var secret [32]byte
+	copy(secret[:], derived)
+	var nonce [24]byte
+	copy(nonce[:], enc.Cipher.Nonce)
+	enc.Ciphertext = secretbox.Seal(nil, der, &nonce, &secret)
+
+	src, err := json.Marshal(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: src})
+}
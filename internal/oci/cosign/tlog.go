@@ -0,0 +1,135 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// BundleAnnotation is the annotation on a signature layer whose value is a
+// JSON-encoded bundle describing the entry that cosign added to a Rekor
+// transparency log for the signature, along with the log's signed entry
+// timestamp for it.
+const BundleAnnotation = "dev.sigstore.cosign/bundle"
+
+// rekorBundle is the JSON representation of the value of [BundleAnnotation].
+type rekorBundle struct {
+	SignedEntryTimestamp []byte             `json:"SignedEntryTimestamp"`
+	Payload              rekorBundlePayload `json:"Payload"`
+}
+
+// rekorBundlePayload is the part of a Rekor bundle that the signed entry
+// timestamp covers.
+//
+// The fields are in lexical order of their JSON names so that encoding/json
+// produces the canonical form that the log signs.
+type rekorBundlePayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekordEntry is the subset of the "hashedrekord" transparency log
+// entry type that describes what was signed, by whom.
+type hashedRekordEntry struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+// verifyTransparencyLogEntry checks that the given JSON-encoded Rekor bundle
+// was signed by one of the given transparency logs and that it records the
+// given signature of payload, made using cert, and if so returns the time
+// at which the log recorded the signature.
+func verifyTransparencyLogEntry(bundleJSON string, logKeys []crypto.PublicKey, payload, sig []byte, cert *x509.Certificate) (time.Time, error) {
+	var bundle rekorBundle
+	if err := json.Unmarshal([]byte(bundleJSON), &bundle); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log bundle: %w", err)
+	}
+
+	var logKey crypto.PublicKey
+	for _, key := range logKeys {
+		if transparencyLogID(key) == bundle.Payload.LogID {
+			logKey = key
+			break
+		}
+	}
+	if logKey == nil {
+		return time.Time{}, fmt.Errorf("signature was recorded in transparency log %q, which the trust policy does not trust", bundle.Payload.LogID)
+	}
+	var signed bytes.Buffer
+	enc := json.NewEncoder(&signed)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(bundle.Payload); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log bundle: %w", err)
+	}
+	// The canonical form has no trailing newline.
+	if err := verifyWithKey(logKey, bytes.TrimSuffix(signed.Bytes(), []byte("\n")), bundle.SignedEntryTimestamp); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log signed entry timestamp")
+	}
+
+	// The log's signature only proves that the log recorded the entry at the
+	// given time, so we must also check that the entry is for this signature.
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log entry encoding: %w", err)
+	}
+	var entry hashedRekordEntry
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return time.Time{}, fmt.Errorf("invalid transparency log entry: %w", err)
+	}
+	if entry.Kind != "hashedrekord" {
+		return time.Time{}, fmt.Errorf("unsupported transparency log entry kind %q", entry.Kind)
+	}
+	digest := sha256.Sum256(payload)
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(digest[:]) {
+		return time.Time{}, fmt.Errorf("transparency log entry is for a different payload")
+	}
+	if !bytes.Equal(entry.Spec.Signature.Content, sig) {
+		return time.Time{}, fmt.Errorf("transparency log entry is for a different signature")
+	}
+	entryCerts, err := parseCertificatesPEM(entry.Spec.Signature.PublicKey.Content)
+	if err != nil || len(entryCerts) != 1 || !entryCerts[0].Equal(cert) {
+		return time.Time{}, fmt.Errorf("transparency log entry is for a different signing certificate")
+	}
+
+	return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+}
+
+// transparencyLogID returns the identifier that a Rekor transparency log
+// using the given public key records in its entries, which is the
+// hexadecimal SHA-256 hash of the key's PKIX encoding.
+func transparencyLogID(key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		// Only unsupported key types can fail here, and ParsePublicKeysPEM
+		// can't return any of those.
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
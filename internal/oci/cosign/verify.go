@@ -0,0 +1,421 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	orasErrors "oras.land/oras-go/v2/errdef"
+)
+
+const (
+	// SignatureArtifactType is the artifact type cosign uses for signature
+	// manifests that it attaches to their subject as OCI referrers.
+	SignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+
+	// SimpleSigningMediaType is the media type of each layer of a signature
+	// manifest, whose content is the payload that was signed.
+	SimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+
+	// SignatureAnnotation is the annotation on a signature layer whose value
+	// is the base64-encoded signature of the layer's payload.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// CertificateAnnotation and ChainAnnotation are the annotations on a
+	// signature layer whose values are the PEM-encoded signing certificate,
+	// and any intermediate certificates, for a certificate-based signature.
+	CertificateAnnotation = "dev.sigstore.cosign/certificate"
+	ChainAnnotation       = "dev.sigstore.cosign/chain"
+
+	// PayloadType is the value cosign uses for the critical.type property of
+	// the payload of a signature.
+	PayloadType = "cosign container image signature"
+)
+
+// manifestSizeLimit and payloadSizeLimit are the maximum sizes of signature
+// manifest and signature payload we're willing to buffer in memory. Real
+// signatures are far smaller than this.
+const (
+	manifestSizeLimit = 4 * 1024 * 1024
+	payloadSizeLimit  = 1024 * 1024
+)
+
+var (
+	// These are the certificate extensions Fulcio uses to record the OIDC
+	// issuer that authenticated the certificate's subject. The first is
+	// deprecated but still included in certificates issued today.
+	oidcIssuerV1OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidcIssuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Store is the interface that [Verify] uses to interact with the OCI
+// repository containing the signed artifact.
+//
+// This matches a subset of both getproviders.OCIRepositoryStore and
+// getmodules.OCIRepositoryStore, so that the stores used for installing
+// either kind of package can be used directly.
+type Store interface {
+	Resolve(ctx context.Context, reference string) (ociv1.Descriptor, error)
+	Fetch(ctx context.Context, target ociv1.Descriptor) (io.ReadCloser, error)
+}
+
+// ReferrerLister is an optional interface that a [Store] can implement to
+// allow [Verify] to discover signatures using the OCI Distribution referrers
+// API. Signatures are discovered only using cosign's tag naming scheme for
+// stores that don't implement this.
+type ReferrerLister interface {
+	Referrers(ctx context.Context, desc ociv1.Descriptor, artifactType string, fn func(referrers []ociv1.Descriptor) error) error
+}
+
+// Verify searches the given store for cosign signatures of the artifact
+// described by subject, and returns a description of the signer of the first
+// signature that is acceptable under the given policy.
+//
+// Verify returns an error if there are no signatures at all or if none of
+// them are acceptable, in which case the artifact should not be used.
+func Verify(ctx context.Context, store Store, subject ociv1.Descriptor, policy *Policy) (signer string, err error) {
+	sigDescs, err := findSignatureManifests(ctx, store, subject)
+	if err != nil {
+		return "", err
+	}
+	if len(sigDescs) == 0 {
+		return "", fmt.Errorf("no cosign signatures found for %s", subject.Digest)
+	}
+
+	var problems []string
+	for _, sigDesc := range sigDescs {
+		manifest, err := fetchSignatureManifest(ctx, store, sigDesc)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", sigDesc.Digest, err))
+			continue
+		}
+		for _, layer := range manifest.Layers {
+			if layer.MediaType != SimpleSigningMediaType {
+				continue
+			}
+			signer, err := verifySignatureLayer(ctx, store, layer, subject, policy)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %s", layer.Digest, err))
+				continue
+			}
+			return signer, nil
+		}
+	}
+	if len(problems) == 0 {
+		return "", fmt.Errorf("no cosign signatures found for %s", subject.Digest)
+	}
+	return "", fmt.Errorf("none of the cosign signatures for %s are trusted:\n  - %s", subject.Digest, strings.Join(problems, "\n  - "))
+}
+
+// findSignatureManifests returns descriptors for all of the signature
+// manifests associated with the given subject.
+func findSignatureManifests(ctx context.Context, store Store, subject ociv1.Descriptor) ([]ociv1.Descriptor, error) {
+	var ret []ociv1.Descriptor
+	if lister, ok := store.(ReferrerLister); ok {
+		err := lister.Referrers(ctx, subject, SignatureArtifactType, func(referrers []ociv1.Descriptor) error {
+			ret = append(ret, referrers...)
+			return nil
+		})
+		if err != nil {
+			// Not all registries support the referrers API, so we'll still
+			// try the tag naming scheme below.
+			log.Printf("[WARN] Failed to list OCI referrers of %s: %s", subject.Digest, err)
+		}
+	}
+
+	desc, err := store.Resolve(ctx, SignatureTag(subject))
+	if err != nil {
+		if errors.Is(err, orasErrors.ErrNotFound) {
+			return ret, nil
+		}
+		return nil, fmt.Errorf("resolving cosign signature tag: %w", err)
+	}
+	if !slices.ContainsFunc(ret, func(d ociv1.Descriptor) bool { return d.Digest == desc.Digest }) {
+		ret = append(ret, desc)
+	}
+	return ret, nil
+}
+
+// SignatureTag returns the tag name that cosign uses for the signatures of
+// the given subject when the registry doesn't support the referrers API.
+func SignatureTag(subject ociv1.Descriptor) string {
+	return strings.Replace(subject.Digest.String(), ":", "-", 1) + ".sig"
+}
+
+func fetchSignatureManifest(ctx context.Context, store Store, desc ociv1.Descriptor) (*ociv1.Manifest, error) {
+	if desc.MediaType != ociv1.MediaTypeImageManifest {
+		return nil, fmt.Errorf("unsupported signature manifest media type %q", desc.MediaType)
+	}
+	src, err := fetchVerified(ctx, store, desc, manifestSizeLimit)
+	if err != nil {
+		return nil, err
+	}
+	var manifest ociv1.Manifest
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid signature manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+func verifySignatureLayer(ctx context.Context, store Store, layer ociv1.Descriptor, subject ociv1.Descriptor, policy *Policy) (string, error) {
+	sigB64 := layer.Annotations[SignatureAnnotation]
+	if sigB64 == "" {
+		return "", fmt.Errorf("signature layer has no %s annotation", SignatureAnnotation)
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigB64)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	payload, err := fetchVerified(ctx, store, layer, payloadSizeLimit)
+	if err != nil {
+		return "", err
+	}
+
+	// We must check that the signed payload actually refers to our subject,
+	// since otherwise a valid signature for some other artifact could be
+	// presented as if it were a signature for this one.
+	var p struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", fmt.Errorf("invalid signature payload: %w", err)
+	}
+	if p.Critical.Type != PayloadType {
+		return "", fmt.Errorf("unsupported signature payload type %q", p.Critical.Type)
+	}
+	if p.Critical.Image.DockerManifestDigest != subject.Digest.String() {
+		return "", fmt.Errorf("signature is for %s, not %s", p.Critical.Image.DockerManifestDigest, subject.Digest)
+	}
+
+	return policy.verify(payload, sig, layer.Annotations)
+}
+
+// verify checks whether the given signature of payload is acceptable under
+// the receiving policy, and if so returns a description of the signer.
+func (p *Policy) verify(payload, sig []byte, annotations map[string]string) (string, error) {
+	if certPEM := annotations[CertificateAnnotation]; certPEM != "" {
+		cert, err := p.verifyCertificate(certPEM, annotations[ChainAnnotation], annotations[BundleAnnotation], payload, sig)
+		if err != nil {
+			return "", err
+		}
+		if err := verifyWithKey(cert.PublicKey, payload, sig); err != nil {
+			return "", err
+		}
+		identity, _ := certificateIdentity(cert, p.Identities)
+		return identity, nil
+	}
+
+	if len(p.PublicKeys) == 0 {
+		return "", fmt.Errorf("signature was made by a key, but the trust policy has no public keys")
+	}
+	for _, key := range p.PublicKeys {
+		if err := verifyWithKey(key, payload, sig); err == nil {
+			return "key " + KeyID(key), nil
+		}
+	}
+	return "", fmt.Errorf("signature was not made by any of the trusted keys")
+}
+
+// verifyCertificate checks that the given PEM-encoded signing certificate is
+// acceptable under the receiving policy for the given signature of payload,
+// and returns it if so.
+//
+// Signing certificates are typically valid for only a few minutes, so the
+// signature must be accompanied by a bundle proving when a trusted
+// transparency log recorded it, and the certificate chain is checked as of
+// that time.
+func (p *Policy) verifyCertificate(certPEM, chainPEM, bundleJSON string, payload, sig []byte) (*x509.Certificate, error) {
+	if p.Roots == nil || len(p.Identities) == 0 {
+		return nil, fmt.Errorf("signature was made using a certificate, but the trust policy has no certificate roots and identities")
+	}
+	if len(p.TransparencyLogKeys) == 0 {
+		return nil, fmt.Errorf("signature was made using a certificate, but the trust policy has no transparency log public keys")
+	}
+	if bundleJSON == "" {
+		return nil, fmt.Errorf("signature was made using a certificate, but has no %s annotation proving when it was made", BundleAnnotation)
+	}
+	certs, err := parseCertificatesPEM([]byte(certPEM))
+	if err != nil || len(certs) != 1 {
+		return nil, fmt.Errorf("invalid signing certificate")
+	}
+	cert := certs[0]
+	intermediates := x509.NewCertPool()
+	if chainPEM != "" {
+		chain, err := parseCertificatesPEM([]byte(chainPEM))
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing certificate chain: %w", err)
+		}
+		for _, c := range chain {
+			intermediates.AddCert(c)
+		}
+	}
+
+	signedAt, err := verifyTransparencyLogEntry(bundleJSON, p.TransparencyLogKeys, payload, sig, cert)
+	if err != nil {
+		return nil, err
+	}
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:         p.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   signedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("untrusted signing certificate: %w", err)
+	}
+
+	identity, ok := certificateIdentity(cert, p.Identities)
+	if !ok {
+		return nil, fmt.Errorf("signing certificate identity %q is not trusted", identity)
+	}
+	if p.OIDCIssuer != "" {
+		if issuer := certificateOIDCIssuer(cert); issuer != p.OIDCIssuer {
+			return nil, fmt.Errorf("signing certificate was issued for OIDC issuer %q, not %q", issuer, p.OIDCIssuer)
+		}
+	}
+	return cert, nil
+}
+
+// certificateIdentity returns the first subject alternative name of the given
+// certificate that is one of the given identities, or the first subject
+// alternative name of any kind if none match.
+func certificateIdentity(cert *x509.Certificate, identities []string) (string, bool) {
+	var sans []string
+	sans = append(sans, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		sans = append(sans, u.String())
+	}
+	for _, san := range sans {
+		if slices.Contains(identities, san) {
+			return san, true
+		}
+	}
+	if len(sans) == 0 {
+		return "", false
+	}
+	return sans[0], false
+}
+
+func certificateOIDCIssuer(cert *x509.Certificate) string {
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidcIssuerV2OID):
+			var issuer string
+			if _, err := asn1.UnmarshalWithParams(ext.Value, &issuer, "utf8"); err == nil {
+				return issuer
+			}
+		case ext.Id.Equal(oidcIssuerV1OID):
+			return string(ext.Value)
+		}
+	}
+	return ""
+}
+
+func verifyWithKey(key crypto.PublicKey, payload, sig []byte) error {
+	// cosign signs the SHA-256 hash of the payload for all key types except
+	// ed25519, which signs the payload directly.
+	digest := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+	return nil
+}
+
+func parseCertificatesPEM(src []byte) ([]*x509.Certificate, error) {
+	var ret []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, src = pem.Decode(src)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, cert)
+	}
+	return ret, nil
+}
+
+// ParseCertificatesPEM parses all of the PEM-encoded certificates in the given
+// source into a certificate pool, for use as [Policy.Roots].
+func ParseCertificatesPEM(src []byte) (*x509.CertPool, error) {
+	certs, err := parseCertificatesPEM(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM-encoded certificates found")
+	}
+	pool := x509.NewCertPool()
+	for _, cert := range certs {
+		pool.AddCert(cert)
+	}
+	return pool, nil
+}
+
+// fetchVerified fetches the content described by desc, checking that it
+// matches the descriptor's digest and does not exceed the given size limit.
+func fetchVerified(ctx context.Context, store Store, desc ociv1.Descriptor, limit int64) ([]byte, error) {
+	if desc.Size > limit {
+		return nil, fmt.Errorf("%s is too large", desc.Digest)
+	}
+	if err := desc.Digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid digest: %w", err)
+	}
+	rc, err := store.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	src, err := io.ReadAll(io.LimitReader(rc, desc.Size))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", desc.Digest, err)
+	}
+	if got := desc.Digest.Algorithm().FromBytes(src); got != desc.Digest {
+		return nil, fmt.Errorf("content does not match digest %s", desc.Digest)
+	}
+	return src, nil
+}
@@ -0,0 +1,553 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	ociDigest "github.com/opencontainers/go-digest"
+	ociSpecs "github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	orasContent "oras.land/oras-go/v2/content"
+	orasMemoryStore "oras.land/oras-go/v2/content/memory"
+	orasOCI "oras.land/oras-go/v2/content/oci"
+	orasErrors "oras.land/oras-go/v2/errdef"
+	orasRemote "oras.land/oras-go/v2/registry/remote"
+
+	"github.com/opentofu/opentofu/internal/command/e2etest/fakeocireg"
+)
+
+func TestVerify(t *testing.T) {
+	trustedKey := generateTestKey(t)
+	otherKey := generateTestKey(t)
+	ca := newTestCA(t)
+	tlog := generateTestKey(t)
+	otherTlog := generateTestKey(t)
+
+	const identity = "https://github.com/example/provider/.github/workflows/release.yml@refs/tags/v1.0.0"
+	const issuer = "https://token.actions.githubusercontent.com"
+	signedAt := time.Now().Add(-25 * time.Minute)
+	certPolicy := &Policy{
+		Roots:               ca.pool(),
+		Identities:          []string{identity},
+		OIDCIssuer:          issuer,
+		TransparencyLogKeys: []crypto.PublicKey{tlog.Public()},
+	}
+
+	tests := map[string]struct {
+		sign    func(t *testing.T, store testStore, subject ociv1.Descriptor)
+		policy  *Policy
+		want    string
+		wantErr string
+	}{
+		"trusted key": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushKeySignature(t, store, subject, subject, trustedKey)
+			},
+			policy: &Policy{PublicKeys: []crypto.PublicKey{trustedKey.Public()}},
+			want:   "key " + KeyID(trustedKey.Public()),
+		},
+		"one of several signatures is trusted": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushKeySignature(t, store, subject, subject, otherKey)
+				pushKeySignature(t, store, subject, subject, trustedKey)
+			},
+			policy: &Policy{PublicKeys: []crypto.PublicKey{trustedKey.Public()}},
+			want:   "key " + KeyID(trustedKey.Public()),
+		},
+		"untrusted key": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushKeySignature(t, store, subject, subject, otherKey)
+			},
+			policy:  &Policy{PublicKeys: []crypto.PublicKey{trustedKey.Public()}},
+			wantErr: "signature was not made by any of the trusted keys",
+		},
+		"signature for a different artifact": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				other := pushBlob(t, store, ociv1.MediaTypeImageManifest, []byte(`{"other":true}`))
+				pushKeySignature(t, store, subject, other, trustedKey)
+			},
+			policy:  &Policy{PublicKeys: []crypto.PublicKey{trustedKey.Public()}},
+			wantErr: "signature is for sha256:",
+		},
+		"no signatures": {
+			sign:    func(t *testing.T, store testStore, subject ociv1.Descriptor) {},
+			policy:  &Policy{PublicKeys: []crypto.PublicKey{trustedKey.Public()}},
+			wantErr: "no cosign signatures found",
+		},
+		"trusted certificate": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, issuer, tlog, signedAt)
+			},
+			policy: certPolicy,
+			want:   identity,
+		},
+		"certificate with untrusted identity": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, "https://example.com/other", issuer, tlog, signedAt)
+			},
+			policy:  certPolicy,
+			wantErr: `signing certificate identity "https://example.com/other" is not trusted`,
+		},
+		"certificate with wrong issuer": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, "https://accounts.example.com", tlog, signedAt)
+			},
+			policy:  certPolicy,
+			wantErr: `issued for OIDC issuer "https://accounts.example.com"`,
+		},
+		"certificate from untrusted authority": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, newTestCA(t), identity, issuer, tlog, signedAt)
+			},
+			policy:  certPolicy,
+			wantErr: "untrusted signing certificate",
+		},
+		"certificate without transparency log bundle": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, issuer, nil, signedAt)
+			},
+			policy:  certPolicy,
+			wantErr: "has no dev.sigstore.cosign/bundle annotation",
+		},
+		"certificate recorded in untrusted transparency log": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, issuer, otherTlog, signedAt)
+			},
+			policy:  certPolicy,
+			wantErr: "which the trust policy does not trust",
+		},
+		"certificate recorded after it expired": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, issuer, tlog, time.Now())
+			},
+			policy:  certPolicy,
+			wantErr: "untrusted signing certificate",
+		},
+		"certificate with bundle for another signature": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				key := generateTestKey(t)
+				cert := ca.issue(t, key, identity, issuer)
+				payload := testPayload(t, subject)
+				sig := signPayload(t, key, payload)
+				otherSig := signPayload(t, key, payload)
+				pushSignature(t, store, subject, payload, map[string]string{
+					SignatureAnnotation:   sig,
+					CertificateAnnotation: cert,
+					BundleAnnotation:      testRekorBundle(t, tlog, signedAt, payload, otherSig, cert),
+				})
+			},
+			policy:  certPolicy,
+			wantErr: "transparency log entry is for a different signature",
+		},
+		"certificate without policy transparency log keys": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, issuer, tlog, signedAt)
+			},
+			policy:  &Policy{Roots: ca.pool(), Identities: []string{identity}, OIDCIssuer: issuer},
+			wantErr: "the trust policy has no transparency log public keys",
+		},
+		"certificate without policy roots": {
+			sign: func(t *testing.T, store testStore, subject ociv1.Descriptor) {
+				pushCertificateSignature(t, store, subject, ca, identity, issuer, tlog, signedAt)
+			},
+			policy:  &Policy{PublicKeys: []crypto.PublicKey{trustedKey.Public()}},
+			wantErr: "the trust policy has no certificate roots and identities",
+		},
+	}
+
+	// Each test runs both against a fake registry that supports the referrers
+	// API and against an in-memory store that supports only cosign's tag
+	// naming scheme.
+	for name, test := range tests {
+		t.Run(name+" (referrers)", func(t *testing.T) {
+			layoutDir := t.TempDir()
+			layout, err := orasOCI.NewWithContext(t.Context(), layoutDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			subject := pushTestSubject(t, layout)
+			test.sign(t, referrersStore{layout}, subject)
+
+			server, err := fakeocireg.NewServer(t.Context(), map[string]string{"repo": layoutDir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer server.Close()
+			repo, err := orasRemote.NewRepository(server.Listener.Addr().String() + "/repo")
+			if err != nil {
+				t.Fatal(err)
+			}
+			repo.Client = server.Client()
+
+			got, err := Verify(t.Context(), repo, subject, test.policy)
+			checkVerifyResult(t, got, err, test.want, test.wantErr)
+		})
+		t.Run(name+" (tag)", func(t *testing.T) {
+			store := orasMemoryStore.New()
+			subject := pushTestSubject(t, store)
+			test.sign(t, tagStore{store}, subject)
+
+			got, err := Verify(t.Context(), store, subject, test.policy)
+			checkVerifyResult(t, got, err, test.want, test.wantErr)
+		})
+	}
+}
+
+func TestPoliciesPolicyFor(t *testing.T) {
+	registryPolicy := &Policy{}
+	repoPolicy := &Policy{}
+	nestedPolicy := &Policy{}
+	var policies Policies
+	policies.Add("example.com", "", registryPolicy)
+	policies.Add("example.com", "foo", repoPolicy)
+	policies.Add("example.com", "foo/bar", nestedPolicy)
+
+	tests := []struct {
+		registryDomain, repositoryName string
+		want                           *Policy
+	}{
+		{"example.com", "baz", registryPolicy},
+		{"example.com", "foo", repoPolicy},
+		{"example.com", "foo/baz", repoPolicy},
+		{"example.com", "foobar", registryPolicy},
+		{"example.com", "foo/bar", nestedPolicy},
+		{"example.com", "foo/bar/baz", nestedPolicy},
+		{"example.net", "foo", nil},
+	}
+	for _, test := range tests {
+		got := policies.PolicyFor(test.registryDomain, test.repositoryName)
+		if got != test.want {
+			t.Errorf("wrong policy for %s/%s", test.registryDomain, test.repositoryName)
+		}
+	}
+
+	var empty Policies
+	if got := empty.PolicyFor("example.com", "foo"); got != nil {
+		t.Errorf("unexpected policy from empty set")
+	}
+}
+
+func checkVerifyResult(t *testing.T, got string, err error, want, wantErr string) {
+	t.Helper()
+	if wantErr != "" {
+		if err == nil {
+			t.Fatalf("unexpected success; want error containing %q", wantErr)
+		}
+		if !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("wrong error\ngot:  %s\nwant: containing %s", err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != want {
+		t.Errorf("wrong signer\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+// testStore is the interface that test helpers use to attach a signature
+// manifest to its subject, which differs depending on whether the store
+// is served with support for the referrers API.
+type testStore interface {
+	orasContent.Pusher
+	attach(t *testing.T, subject, sigManifest ociv1.Descriptor)
+}
+
+// referrersStore attaches signatures by setting the subject of the signature
+// manifest, as cosign does when using the OCI referrers API.
+type referrersStore struct {
+	*orasOCI.Store
+}
+
+func (s referrersStore) attach(t *testing.T, subject, sigManifest ociv1.Descriptor) {
+	// The subject is set inside the manifest itself, so there's nothing more
+	// to do here.
+}
+
+// tagStore attaches signatures using cosign's tag naming scheme.
+type tagStore struct {
+	*orasMemoryStore.Store
+}
+
+func (s tagStore) attach(t *testing.T, subject, sigManifest ociv1.Descriptor) {
+	// cosign appends new signatures as layers of a single manifest under the
+	// signature tag, but replacing the tag is sufficient for our tests
+	// except for those with multiple signatures, for which we build the
+	// combined manifest here instead.
+	tag := SignatureTag(subject)
+	if prev, err := s.Resolve(t.Context(), tag); err == nil {
+		prevManifest := fetchManifest(t, s.Store, prev)
+		newManifest := fetchManifest(t, s.Store, sigManifest)
+		prevManifest.Layers = append(prevManifest.Layers, newManifest.Layers...)
+		sigManifest = pushManifest(t, s, prevManifest)
+	}
+	if err := s.Tag(t.Context(), sigManifest, tag); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pushTestSubject(t *testing.T, store orasContent.Pusher) ociv1.Descriptor {
+	t.Helper()
+	layer := pushBlob(t, store, "archive/zip", []byte("placeholder package"))
+	return pushManifest(t, store, &ociv1.Manifest{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: "application/vnd.opentofu.modulepkg",
+		Config:       ociv1.DescriptorEmptyJSON,
+		Layers:       []ociv1.Descriptor{layer},
+	})
+}
+
+func pushKeySignature(t *testing.T, store testStore, subject, signed ociv1.Descriptor, key *ecdsa.PrivateKey) {
+	t.Helper()
+	payload := testPayload(t, signed)
+	pushSignature(t, store, subject, payload, map[string]string{
+		SignatureAnnotation: signPayload(t, key, payload),
+	})
+}
+
+// pushCertificateSignature signs the given subject using a new certificate
+// issued by ca, recorded at the given time in the transparency log with the
+// given key. The signature has no transparency log bundle if tlog is nil.
+func pushCertificateSignature(t *testing.T, store testStore, subject ociv1.Descriptor, ca *testCA, identity, issuer string, tlog *ecdsa.PrivateKey, signedAt time.Time) {
+	t.Helper()
+	key := generateTestKey(t)
+	cert := ca.issue(t, key, identity, issuer)
+	payload := testPayload(t, subject)
+	sig := signPayload(t, key, payload)
+	annotations := map[string]string{
+		SignatureAnnotation:   sig,
+		CertificateAnnotation: cert,
+	}
+	if tlog != nil {
+		annotations[BundleAnnotation] = testRekorBundle(t, tlog, signedAt, payload, sig, cert)
+	}
+	pushSignature(t, store, subject, payload, annotations)
+}
+
+// testRekorBundle returns a Rekor bundle for the given base64-encoded
+// signature of payload, signed by the transparency log with the given key as
+// if it recorded the signature at the given time.
+func testRekorBundle(t *testing.T, tlog *ecdsa.PrivateKey, signedAt time.Time, payload []byte, sig, cert string) string {
+	t.Helper()
+	digest := sha256.Sum256(payload)
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{
+				"hash": map[string]any{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+			},
+			"signature": map[string]any{
+				"content":   sig,
+				"publicKey": map[string]any{"content": base64.StdEncoding.EncodeToString([]byte(cert))},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundlePayload := rekorBundlePayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: signedAt.Unix(),
+		LogID:          transparencyLogID(tlog.Public()),
+		LogIndex:       1,
+	}
+	// This is the canonical form that Rekor signs, written out directly
+	// rather than using rekorBundlePayload's JSON encoding so that the test
+	// would catch a change to that encoding.
+	canonical := fmt.Sprintf(
+		`{"body":%q,"integratedTime":%d,"logID":%q,"logIndex":%d}`,
+		bundlePayload.Body, bundlePayload.IntegratedTime, bundlePayload.LogID, bundlePayload.LogIndex,
+	)
+	set, err := base64.StdEncoding.DecodeString(signPayload(t, tlog, []byte(canonical)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := json.Marshal(rekorBundle{SignedEntryTimestamp: set, Payload: bundlePayload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bundle)
+}
+
+func pushSignature(t *testing.T, store testStore, subject ociv1.Descriptor, payload []byte, annotations map[string]string) {
+	t.Helper()
+	layer := pushBlob(t, store, SimpleSigningMediaType, payload)
+	layer.Annotations = annotations
+	manifest := &ociv1.Manifest{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: SignatureArtifactType,
+		Config:       ociv1.DescriptorEmptyJSON,
+		Layers:       []ociv1.Descriptor{layer},
+	}
+	if _, ok := store.(referrersStore); ok {
+		manifest.Subject = &subject
+	}
+	store.attach(t, subject, pushManifest(t, store, manifest))
+}
+
+func testPayload(t *testing.T, signed ociv1.Descriptor) []byte {
+	t.Helper()
+	payload, err := json.Marshal(map[string]any{
+		"critical": map[string]any{
+			"identity": map[string]any{"docker-reference": "example.com/repo"},
+			"image":    map[string]any{"docker-manifest-digest": signed.Digest.String()},
+			"type":     PayloadType,
+		},
+		"optional": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return payload
+}
+
+func signPayload(t *testing.T, key *ecdsa.PrivateKey, payload []byte) string {
+	t.Helper()
+	digest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func pushManifest(t *testing.T, store orasContent.Pusher, manifest *ociv1.Manifest) ociv1.Descriptor {
+	t.Helper()
+	src, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc := pushBlob(t, store, ociv1.MediaTypeImageManifest, src)
+	desc.ArtifactType = manifest.ArtifactType
+	return desc
+}
+
+func fetchManifest(t *testing.T, store orasContent.Fetcher, desc ociv1.Descriptor) *ociv1.Manifest {
+	t.Helper()
+	src, err := orasContent.FetchAll(t.Context(), store, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest ociv1.Manifest
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	return &manifest
+}
+
+func pushBlob(t *testing.T, store orasContent.Pusher, mediaType string, content []byte) ociv1.Descriptor {
+	t.Helper()
+	desc := ociv1.Descriptor{
+		MediaType: mediaType,
+		Digest:    ociDigest.FromBytes(content),
+		Size:      int64(len(content)),
+	}
+	err := store.Push(context.Background(), desc, bytes.NewReader(content))
+	if err != nil && !errors.Is(err, orasErrors.ErrAlreadyExists) {
+		t.Fatal(err)
+	}
+	return desc
+}
+
+func generateTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+type testCA struct {
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key := generateTestKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test signing CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{key, cert}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue returns a PEM-encoded short-lived signing certificate for the given
+// key, similar to those issued by Fulcio.
+func (ca *testCA) issue(t *testing.T, key *ecdsa.PrivateKey, identity, issuer string) string {
+	t.Helper()
+	uri, err := url.Parse(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerExt, err := asn1.MarshalWithParams(issuer, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The certificate has already expired, as would be typical for a
+	// signature made some time ago.
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-30 * time.Minute),
+		NotAfter:     time.Now().Add(-20 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{uri},
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidcIssuerV2OID, Value: issuerExt},
+		},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/opentofu/opentofu/internal/getmodules"
 	"github.com/opentofu/opentofu/internal/getproviders"
 	"github.com/opentofu/opentofu/internal/httpclient"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
 	"github.com/opentofu/opentofu/internal/tracing"
 	"github.com/opentofu/opentofu/internal/tracing/traceattrs"
 )
@@ -37,6 +38,29 @@ import (
 // at all.
 type OCICredsPolicyBuilder func(context.Context) (ociauthconfig.CredentialsConfigs, error)
 
+// OCISignaturePoliciesBuilder is the type of a callback function that the
+// [providerSource] and [remoteModulePackageFetcher] functions will use to
+// find the cosign signature trust policies for OCI repositories.
+//
+// As with [OCICredsPolicyBuilder], this is a callback so that we can avoid
+// reading the configured key and certificate files unless we are actually
+// installing something from an OCI registry. A nil OCISignaturePoliciesBuilder
+// represents that no policies are configured.
+type OCISignaturePoliciesBuilder func() (cosign.Policies, error)
+
+// PolicyFor returns the cosign signature trust policy that applies to the
+// given repository, or nil if signatures are not required for it.
+func (b OCISignaturePoliciesBuilder) PolicyFor(registryDomain, repositoryName string) (*cosign.Policy, error) {
+	if b == nil {
+		return nil, nil
+	}
+	policies, err := b()
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature policy configuration for OCI registries: %w", err)
+	}
+	return policies.PolicyFor(registryDomain, repositoryName), nil
+}
+
 var ociReposMu sync.Mutex
 var ociRepos map[ociRepoKey]ociRepositoryStore
 
@@ -73,6 +97,26 @@ func GetOCIRepositoryStore(ctx context.Context, registryDomain, repositoryName s
 		return store, nil
 	}
 
+	repo, err := newORASRepository(ctx, registryDomain, repositoryName, credsPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save this in case we get asked again for the same registry.
+	// (A subsequent call is common for provider installation since there
+	// are several independent steps that all request stores separately.)
+	ociRepos[key] = repo
+
+	// NOTE: At this point we don't yet know if the named repository actually exists
+	// in the registry. The caller will find that out when they try to interact
+	// with the methods of the returned object.
+	return repo, nil
+}
+
+// newORASRepository instantiates an ORAS-Go client for the given repository
+// on the given registry, using the given OCI credentials policy to decide
+// which credentials to use.
+func newORASRepository(ctx context.Context, registryDomain, repositoryName string, credsPolicy ociauthconfig.CredentialsConfigs) (*orasRemote.Repository, error) {
 	ctx, span := tracing.Tracer().Start(
 		ctx, "Authenticate to OCI Registry",
 		tracing.SpanAttributes(
@@ -117,15 +161,6 @@ func GetOCIRepositoryStore(ctx context.Context, registryDomain, repositoryName s
 		tracing.SetSpanError(span, err)
 		return nil, err // This is only for repositoryName validation errors, and we should've caught those much earlier than here
 	}
-
-	// Save this in case we get asked again for the same registry.
-	// (A subsequent call is common for provider installation since there
-	// are several independent steps that all request stores separately.)
-	ociRepos[key] = repo
-
-	// NOTE: At this point we don't yet know if the named repository actually exists
-	// in the registry. The caller will find that out when they try to interact
-	// with the methods of the returned object.
 	return repo, nil
 }
 
@@ -0,0 +1,526 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+// This file deals with publishing module and provider packages to OCI
+// Distribution repositories, in the same layouts that the module installer
+// and the provider installer expect when installing from them.
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	ociDigest "github.com/opencontainers/go-digest"
+	ociSpecs "github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	orasErrors "oras.land/oras-go/v2/errdef"
+	orasRegistry "oras.land/oras-go/v2/registry"
+
+	"github.com/opentofu/opentofu/internal/command/cliconfig/ociauthconfig"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
+	"github.com/opentofu/opentofu/internal/tracing"
+	"github.com/opentofu/opentofu/internal/tracing/traceattrs"
+)
+
+// The following must match the artifact and media types that package
+// getproviders expects when installing providers from an OCI repository.
+const (
+	ociProviderIndexArtifactType  = "application/vnd.opentofu.provider"
+	ociProviderTargetArtifactType = "application/vnd.opentofu.provider-target"
+	ociProviderPackageMediaType   = "archive/zip"
+)
+
+// The following must match the artifact and media types that package
+// getmodules expects when installing modules from an OCI repository.
+const (
+	ociModuleArtifactType     = "application/vnd.opentofu.modulepkg"
+	ociModulePackageMediaType = "archive/zip"
+)
+
+// ociManifestSizeLimit is the largest manifest we'll attempt to push. This
+// matches the limit that the installers enforce, which in turn matches the
+// recommended limit for registries to accept from the OCI Distribution spec.
+const ociManifestSizeLimit = 4 * 1024 * 1024
+
+// ociModulePackageModTime is the modification time recorded for every file
+// in the archives created by [PushModulePackage], so that pushing the same
+// module package twice produces the same digest.
+var ociModulePackageModTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// OCIRepositoryPushStore is the interface used to publish packages to an OCI
+// repository.
+//
+// As with [ociRepositoryStore], this is intentionally a subset of the ORAS-Go
+// API so that the ORAS-Go implementation can be used directly without it
+// becoming part of the public API of this package.
+type OCIRepositoryPushStore interface {
+	cosign.PushStore
+	Exists(ctx context.Context, target ociv1.Descriptor) (bool, error)
+}
+
+// GetOCIRepositoryPushStore instantiates an [OCIRepositoryPushStore] to use
+// when publishing to the given repository on the given registry, using the
+// given OCI credentials policy to decide which credentials to use.
+//
+// Unlike [GetOCIRepositoryStore], this always creates a new store because
+// each publishing operation interacts with only one repository.
+func GetOCIRepositoryPushStore(ctx context.Context, registryDomain, repositoryName string, credsPolicy ociauthconfig.CredentialsConfigs) (OCIRepositoryPushStore, error) {
+	return newORASRepository(ctx, registryDomain, repositoryName, credsPolicy)
+}
+
+// OCIRepositoryAddress is the address of a repository in an OCI Distribution
+// registry.
+type OCIRepositoryAddress struct {
+	// RegistryDomain is the domain name of the registry, optionally followed
+	// by a colon and a port number.
+	RegistryDomain string
+
+	// RepositoryName is the name of the repository within the registry.
+	RepositoryName string
+}
+
+func (a OCIRepositoryAddress) String() string {
+	return a.RegistryDomain + "/" + a.RepositoryName
+}
+
+// ParseOCIRepositoryURL parses an "oci://" URL using the same syntax as
+// OCI module source addresses, like "oci://example.com/namespace/name".
+//
+// The URL may include a "tag" argument in its query string, in which case
+// the tag is also returned. Otherwise the returned tag is empty.
+func ParseOCIRepositoryURL(raw string) (OCIRepositoryAddress, string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return OCIRepositoryAddress{}, "", err
+	}
+	if u.Scheme != "oci" {
+		return OCIRepositoryAddress{}, "", fmt.Errorf("must be an oci:// URL")
+	}
+	if u.User != nil || u.Fragment != "" {
+		return OCIRepositoryAddress{}, "", fmt.Errorf("must not include user information or a fragment")
+	}
+
+	ref := orasRegistry.Reference{
+		Registry:   u.Host,
+		Repository: strings.TrimPrefix(u.Path, "/"),
+	}
+	if err := ref.Validate(); err != nil {
+		// ORAS-Go's error messages already include an "invalid reference:"
+		// prefix, so we won't add anything new here.
+		return OCIRepositoryAddress{}, "", err
+	}
+
+	var tag string
+	for name, values := range u.Query() {
+		if name != "tag" {
+			return OCIRepositoryAddress{}, "", fmt.Errorf("unsupported argument %q", name)
+		}
+		if len(values) > 1 {
+			return OCIRepositoryAddress{}, "", fmt.Errorf("too many %q arguments", name)
+		}
+		tagRef := ref
+		tagRef.Reference = values[0]
+		if err := tagRef.ValidateReferenceAsTag(); err != nil {
+			return OCIRepositoryAddress{}, "", err
+		}
+		tag = values[0]
+	}
+
+	return OCIRepositoryAddress{
+		RegistryDomain: ref.Registry,
+		RepositoryName: ref.Repository,
+	}, tag, nil
+}
+
+// PushOptions are the options for [PushModulePackage] and
+// [PushProviderPackages].
+type PushOptions struct {
+	// Annotations are additional annotations for the top-level manifest.
+	//
+	// The standard "org.opencontainers.image.created" annotation is always
+	// included, set to the current time, unless it is set here.
+	Annotations map[string]string
+
+	// SigningKey, if set, is used to sign the top-level manifest with a
+	// cosign signature, which the installers can then verify under an
+	// oci_signature_policy in the CLI configuration.
+	SigningKey crypto.Signer
+}
+
+// PushModulePackage creates a zip archive of the module package in the given
+// directory and publishes it to the given repository under the given tag, in
+// the layout that the module installer expects.
+//
+// The directory's ".git" and ".terraform" subdirectories are not included in
+// the package.
+//
+// The result describes the image manifest for the module package, which
+// can be installed using a module source address of the form
+// "oci://REGISTRY/REPOSITORY?digest=DIGEST" in addition to the tag.
+func PushModulePackage(ctx context.Context, store OCIRepositoryPushStore, addr OCIRepositoryAddress, tag string, dir string, opts PushOptions) (ociv1.Descriptor, error) {
+	ctx, span := tracing.Tracer().Start(
+		ctx, "Push module package",
+		tracing.SpanAttributes(
+			traceattrs.OpenTofuOCIRegistryDomain(addr.RegistryDomain),
+			traceattrs.OpenTofuOCIRepositoryName(addr.RepositoryName),
+			traceattrs.OpenTofuOCIReferenceTag(tag),
+		),
+	)
+	defer span.End()
+	prepErr := func(err error) error {
+		tracing.SetSpanError(span, err)
+		return err
+	}
+
+	archive, err := os.CreateTemp("", "tofu-module-package-*.zip")
+	if err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+	defer os.Remove(archive.Name())
+	err = writeModulePackageArchive(archive, dir)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return ociv1.Descriptor{}, prepErr(fmt.Errorf("creating module package archive: %w", err))
+	}
+
+	layer, err := pushFileBlob(ctx, store, ociModulePackageMediaType, archive.Name())
+	if err != nil {
+		return ociv1.Descriptor{}, prepErr(fmt.Errorf("pushing module package: %w", err))
+	}
+	if err := pushEmptyConfig(ctx, store); err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+	desc, err := pushManifest(ctx, store, &ociv1.Manifest{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageManifest,
+		ArtifactType: ociModuleArtifactType,
+		Config:       ociv1.DescriptorEmptyJSON,
+		Layers:       []ociv1.Descriptor{layer},
+		Annotations:  manifestAnnotations(opts.Annotations),
+	}, ociv1.MediaTypeImageManifest, ociModuleArtifactType)
+	if err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+
+	if err := finishPush(ctx, store, addr, desc, tag, opts); err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+	return desc, nil
+}
+
+// PushProviderPackages publishes the given packages for the given version of
+// a provider to the given repository, in the layout that the provider
+// installer expects.
+//
+// The packages are the zip archives of the provider for each of its target
+// platforms, which are published together as a single multi-platform index
+// manifest tagged with the version number.
+//
+// The result describes the index manifest for the provider version.
+func PushProviderPackages(ctx context.Context, store OCIRepositoryPushStore, addr OCIRepositoryAddress, version getproviders.Version, packages map[getproviders.Platform]string, opts PushOptions) (ociv1.Descriptor, error) {
+	// The tag naming scheme must match what the provider installer expects,
+	// which replaces the "+" of any build metadata with "_" because "+"
+	// is not allowed in tag names.
+	tag := strings.ReplaceAll(version.String(), "+", "_")
+
+	ctx, span := tracing.Tracer().Start(
+		ctx, "Push provider packages",
+		tracing.SpanAttributes(
+			traceattrs.OpenTofuOCIRegistryDomain(addr.RegistryDomain),
+			traceattrs.OpenTofuOCIRepositoryName(addr.RepositoryName),
+			traceattrs.OpenTofuOCIReferenceTag(tag),
+		),
+	)
+	defer span.End()
+	prepErr := func(err error) error {
+		tracing.SetSpanError(span, err)
+		return err
+	}
+
+	if len(packages) == 0 {
+		return ociv1.Descriptor{}, prepErr(fmt.Errorf("no packages to push"))
+	}
+	if err := pushEmptyConfig(ctx, store); err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+
+	platforms := slices.SortedFunc(maps.Keys(packages), func(a, b getproviders.Platform) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	index := &ociv1.Index{
+		Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+		MediaType:    ociv1.MediaTypeImageIndex,
+		ArtifactType: ociProviderIndexArtifactType,
+		Annotations:  manifestAnnotations(opts.Annotations),
+	}
+	for _, platform := range platforms {
+		layer, err := pushFileBlob(ctx, store, ociProviderPackageMediaType, packages[platform])
+		if err != nil {
+			return ociv1.Descriptor{}, prepErr(fmt.Errorf("pushing package for %s: %w", platform, err))
+		}
+		desc, err := pushManifest(ctx, store, &ociv1.Manifest{
+			Versioned:    ociSpecs.Versioned{SchemaVersion: 2},
+			MediaType:    ociv1.MediaTypeImageManifest,
+			ArtifactType: ociProviderTargetArtifactType,
+			Config:       ociv1.DescriptorEmptyJSON,
+			Layers:       []ociv1.Descriptor{layer},
+		}, ociv1.MediaTypeImageManifest, ociProviderTargetArtifactType)
+		if err != nil {
+			return ociv1.Descriptor{}, prepErr(fmt.Errorf("pushing manifest for %s: %w", platform, err))
+		}
+		// The installer selects a manifest by exactly matching the operating
+		// system and architecture, and ignores descriptors that set any
+		// other platform properties.
+		desc.Platform = &ociv1.Platform{
+			OS:           platform.OS,
+			Architecture: platform.Arch,
+		}
+		index.Manifests = append(index.Manifests, desc)
+	}
+
+	desc, err := pushManifest(ctx, store, index, ociv1.MediaTypeImageIndex, ociProviderIndexArtifactType)
+	if err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+	if err := finishPush(ctx, store, addr, desc, tag, opts); err != nil {
+		return ociv1.Descriptor{}, prepErr(err)
+	}
+	return desc, nil
+}
+
+// FindProviderPackages finds the provider packages in the given directory,
+// which must all be for the same version of the same provider and be named
+// in the usual way as terraform-provider-TYPE_VERSION_OS_ARCH.zip.
+//
+// Other files in the directory are ignored, so that this can be used directly
+// with the output directory of typical provider release tooling, which also
+// contains checksums, signatures and other metadata.
+func FindProviderPackages(dir string) (getproviders.Version, map[getproviders.Platform]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return getproviders.Version{}, nil, err
+	}
+
+	var typeName string
+	var version getproviders.Version
+	packages := make(map[getproviders.Platform]string)
+	for _, entry := range entries {
+		rest, ok := strings.CutPrefix(entry.Name(), "terraform-provider-")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		rest, ok = strings.CutSuffix(rest, ".zip")
+		if !ok {
+			continue
+		}
+		filename := filepath.Join(dir, entry.Name())
+
+		// The type name cannot contain underscores, so everything after the
+		// first one is the version and platform.
+		thisType, rest, _ := strings.Cut(rest, "_")
+		rawVersion, rawPlatform, _ := strings.Cut(rest, "_")
+		thisVersion, err := getproviders.ParseVersion(rawVersion)
+		if err != nil {
+			return getproviders.Version{}, nil, fmt.Errorf("invalid version in package filename %s: %w", filename, err)
+		}
+		platform, err := getproviders.ParsePlatform(rawPlatform)
+		if err != nil {
+			return getproviders.Version{}, nil, fmt.Errorf("invalid platform in package filename %s: %w", filename, err)
+		}
+
+		if len(packages) == 0 {
+			typeName, version = thisType, thisVersion
+		} else if thisType != typeName {
+			return getproviders.Version{}, nil, fmt.Errorf("%s contains packages for more than one provider: %s and %s", dir, typeName, thisType)
+		} else if !thisVersion.Same(version) {
+			return getproviders.Version{}, nil, fmt.Errorf("%s contains packages for more than one version: %s and %s", dir, version, thisVersion)
+		}
+		packages[platform] = filename
+	}
+	if len(packages) == 0 {
+		return getproviders.Version{}, nil, fmt.Errorf("%s contains no provider packages named terraform-provider-TYPE_VERSION_OS_ARCH.zip", dir)
+	}
+	return version, packages, nil
+}
+
+// writeModulePackageArchive writes a zip archive of the files in the given
+// directory to w.
+func writeModulePackageArchive(w io.Writer, dir string) error {
+	zw := zip.NewWriter(w)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if d.IsDir() && (d.Name() == ".git" || d.Name() == ".terraform") {
+			return filepath.SkipDir
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsDir() && !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file or directory, which is not supported in module packages", path)
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		header.Modified = ociModulePackageModTime
+		if d.IsDir() {
+			header.Name += "/"
+			_, err := zw.CreateHeader(header)
+			return err
+		}
+		header.Method = zip.Deflate
+
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(fw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// manifestAnnotations returns the annotations for a top-level manifest,
+// based on the annotations given in [PushOptions].
+func manifestAnnotations(given map[string]string) map[string]string {
+	ret := maps.Clone(given)
+	if ret == nil {
+		ret = make(map[string]string)
+	}
+	if _, ok := ret[ociv1.AnnotationCreated]; !ok {
+		ret[ociv1.AnnotationCreated] = time.Now().UTC().Format(time.RFC3339)
+	}
+	return ret
+}
+
+// finishPush tags the given top-level manifest and then signs it if
+// requested in the given options.
+func finishPush(ctx context.Context, store OCIRepositoryPushStore, addr OCIRepositoryAddress, desc ociv1.Descriptor, tag string, opts PushOptions) error {
+	if err := store.Tag(ctx, desc, tag); err != nil {
+		return fmt.Errorf("tagging %s: %w", tag, err)
+	}
+	if opts.SigningKey != nil {
+		if err := cosign.Sign(ctx, store, desc, addr.String(), opts.SigningKey); err != nil {
+			return fmt.Errorf("signing %s: %w", desc.Digest, err)
+		}
+	}
+	return nil
+}
+
+// pushFileBlob pushes the content of the given file as a blob with the given
+// media type, unless the repository already has it.
+func pushFileBlob(ctx context.Context, store OCIRepositoryPushStore, mediaType string, filename string) (ociv1.Descriptor, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	desc := ociv1.Descriptor{
+		MediaType: mediaType,
+		Digest:    ociDigest.NewDigest(ociDigest.SHA256, h),
+		Size:      size,
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	if err := pushContent(ctx, store, desc, f); err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	return desc, nil
+}
+
+// pushEmptyConfig pushes the empty JSON object that all of our image
+// manifests use as their config, since registries may reject manifests
+// that refer to blobs they don't have.
+func pushEmptyConfig(ctx context.Context, store OCIRepositoryPushStore) error {
+	desc := ociv1.DescriptorEmptyJSON
+	if err := pushContent(ctx, store, desc, bytes.NewReader(desc.Data)); err != nil {
+		return fmt.Errorf("pushing manifest config: %w", err)
+	}
+	return nil
+}
+
+// pushManifest pushes the JSON encoding of the given manifest, returning its
+// descriptor.
+func pushManifest(ctx context.Context, store OCIRepositoryPushStore, manifest any, mediaType, artifactType string) (ociv1.Descriptor, error) {
+	src, err := json.Marshal(manifest)
+	if err != nil {
+		return ociv1.Descriptor{}, err
+	}
+	if len(src) > ociManifestSizeLimit {
+		return ociv1.Descriptor{}, fmt.Errorf("manifest size exceeds OpenTofu's size limit of %d MiB", ociManifestSizeLimit/1024/1024)
+	}
+	desc := ociv1.Descriptor{
+		MediaType:    mediaType,
+		ArtifactType: artifactType,
+		Digest:       ociDigest.FromBytes(src),
+		Size:         int64(len(src)),
+	}
+	if err := pushContent(ctx, store, desc, bytes.NewReader(src)); err != nil {
+		return ociv1.Descriptor{}, fmt.Errorf("pushing manifest: %w", err)
+	}
+	return desc, nil
+}
+
+// pushContent pushes the given content unless the repository already has it.
+func pushContent(ctx context.Context, store OCIRepositoryPushStore, desc ociv1.Descriptor, content io.Reader) error {
+	exists, err := store.Exists(ctx, desc)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	err = store.Push(ctx, desc, content)
+	if err != nil && !errors.Is(err, orasErrors.ErrAlreadyExists) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oci
+
+import (
+	"archive/zip"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	orasContent "oras.land/oras-go/v2/content"
+	orasOCI "oras.land/oras-go/v2/content/oci"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getmodules"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/oci/cosign"
+)
+
+func TestPushModulePackage(t *testing.T) {
+	key := generateTestSigningKey(t)
+	policy := &cosign.Policy{PublicKeys: []crypto.PublicKey{key.Public()}}
+	addr := OCIRepositoryAddress{RegistryDomain: "example.com", RepositoryName: "modules/network"}
+
+	moduleDir := t.TempDir()
+	writeTestFile(t, filepath.Join(moduleDir, "main.tf"), `variable "name" {}`)
+	writeTestFile(t, filepath.Join(moduleDir, "modules", "subnet", "main.tf"), `variable "cidr" {}`)
+	writeTestFile(t, filepath.Join(moduleDir, ".git", "config"), "[core]")
+	writeTestFile(t, filepath.Join(moduleDir, ".terraform", "modules", "modules.json"), "{}")
+
+	store := newTestPushStore(t)
+	desc, err := PushModulePackage(t.Context(), store, addr, "v1.0.0", moduleDir, PushOptions{
+		Annotations: map[string]string{ociv1.AnnotationSource: "https://example.com/network"},
+		SigningKey:  key,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if desc.MediaType != ociv1.MediaTypeImageManifest {
+		t.Errorf("wrong media type %q", desc.MediaType)
+	}
+
+	// The module installer must be able to install the result, including
+	// verifying the signature we made.
+	fetcher := getmodules.NewPackageFetcher(t.Context(), testPackageFetcherEnvironment{store, policy})
+	instDir := filepath.Join(t.TempDir(), "network")
+	err = fetcher.FetchPackage(t.Context(), instDir, "oci://example.com/modules/network?tag=v1.0.0")
+	if err != nil {
+		t.Fatalf("failed to install pushed module package: %s", err)
+	}
+	for _, name := range []string{"main.tf", filepath.Join("modules", "subnet", "main.tf")} {
+		if _, err := os.Stat(filepath.Join(instDir, name)); err != nil {
+			t.Errorf("installed package lacks %s: %s", name, err)
+		}
+	}
+	for _, name := range []string{".git", ".terraform"} {
+		if _, err := os.Stat(filepath.Join(instDir, name)); !os.IsNotExist(err) {
+			t.Errorf("installed package unexpectedly includes %s", name)
+		}
+	}
+
+	// Pushing the same package again must reuse the same package blob.
+	again, err := PushModulePackage(t.Context(), store, addr, "v1.0.1", moduleDir, PushOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error pushing again: %s", err)
+	}
+	if got, want := fetchTestManifest(t, store, again).Layers[0].Digest, fetchTestManifest(t, store, desc).Layers[0].Digest; got != want {
+		t.Errorf("package digest changed between pushes\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestPushProviderPackages(t *testing.T) {
+	key := generateTestSigningKey(t)
+	policy := &cosign.Policy{PublicKeys: []crypto.PublicKey{key.Public()}}
+	addr := OCIRepositoryAddress{RegistryDomain: "example.com", RepositoryName: "providers/random"}
+	provider := addrs.MustParseProviderSourceString("example.com/example/random")
+	version := getproviders.MustParseVersion("1.2.0+abc")
+
+	dir := t.TempDir()
+	packages := map[getproviders.Platform]string{
+		{OS: "linux", Arch: "amd64"}:  writeTestProviderPackage(t, dir, "random", "1.2.0+abc", "linux_amd64"),
+		{OS: "darwin", Arch: "arm64"}: writeTestProviderPackage(t, dir, "random", "1.2.0+abc", "darwin_arm64"),
+	}
+
+	store := newTestPushStore(t)
+	desc, err := PushProviderPackages(t.Context(), store, addr, version, packages, PushOptions{
+		SigningKey: key,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if desc.MediaType != ociv1.MediaTypeImageIndex {
+		t.Errorf("wrong media type %q", desc.MediaType)
+	}
+
+	// The provider installer must be able to find each of the packages,
+	// including verifying the signature we made.
+	source := getproviders.NewOCIRegistryMirrorSource(
+		t.Context(),
+		func(addrs.Provider) (string, string, error) {
+			return addr.RegistryDomain, addr.RepositoryName, nil
+		},
+		func(context.Context, string, string) (getproviders.OCIRepositoryStore, error) {
+			return store, nil
+		},
+		func(string, string) (*cosign.Policy, error) {
+			return policy, nil
+		},
+	)
+	gotVersions, _, err := source.AvailableVersions(t.Context(), provider)
+	if err != nil {
+		t.Fatalf("failed to list versions: %s", err)
+	}
+	if len(gotVersions) != 1 || !gotVersions[0].Same(version) {
+		t.Errorf("wrong versions %s; want %s", gotVersions, version)
+	}
+	for platform := range packages {
+		meta, err := source.PackageMeta(t.Context(), provider, version, platform)
+		if err != nil {
+			t.Errorf("failed to find package for %s: %s", platform, err)
+			continue
+		}
+		if meta.ReleaseTime.IsZero() {
+			t.Errorf("package for %s has no release time", platform)
+		}
+	}
+	_, err = source.PackageMeta(t.Context(), provider, version, getproviders.Platform{OS: "windows", Arch: "amd64"})
+	if _, ok := err.(getproviders.ErrPlatformNotSupported); !ok {
+		t.Errorf("wrong error for unpublished platform: %s", err)
+	}
+}
+
+func TestParseOCIRepositoryURL(t *testing.T) {
+	tests := map[string]struct {
+		want    OCIRepositoryAddress
+		wantTag string
+		wantErr string
+	}{
+		"oci://example.com/foo/bar": {
+			want: OCIRepositoryAddress{RegistryDomain: "example.com", RepositoryName: "foo/bar"},
+		},
+		"oci://localhost:5000/foo?tag=v1.0.0": {
+			want:    OCIRepositoryAddress{RegistryDomain: "localhost:5000", RepositoryName: "foo"},
+			wantTag: "v1.0.0",
+		},
+		"https://example.com/foo": {
+			wantErr: "must be an oci:// URL",
+		},
+		"oci://example.com/foo?digest=sha256:abc": {
+			wantErr: `unsupported argument "digest"`,
+		},
+		"oci://example.com/foo?tag=a&tag=b": {
+			wantErr: `too many "tag" arguments`,
+		},
+		"oci://example.com/foo?tag=not+valid": {
+			wantErr: "invalid reference",
+		},
+		"oci://example.com/Foo": {
+			wantErr: "invalid reference",
+		},
+	}
+	for raw, test := range tests {
+		t.Run(raw, func(t *testing.T) {
+			got, gotTag, err := ParseOCIRepositoryURL(raw)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %v\nwant: containing %s", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.want || gotTag != test.wantTag {
+				t.Errorf("wrong result\ngot:  %s, %q\nwant: %s, %q", got, gotTag, test.want, test.wantTag)
+			}
+		})
+	}
+}
+
+func TestFindProviderPackages(t *testing.T) {
+	tests := map[string]struct {
+		files         []string
+		wantVersion   string
+		wantPlatforms []string
+		wantErr       string
+	}{
+		"release directory": {
+			files: []string{
+				"terraform-provider-random_1.2.0_linux_amd64.zip",
+				"terraform-provider-random_1.2.0_windows_386.zip",
+				"terraform-provider-random_1.2.0_SHA256SUMS",
+				"terraform-provider-random_1.2.0_SHA256SUMS.sig",
+				"terraform-provider-random_1.2.0_manifest.json",
+				"artifacts.json",
+			},
+			wantVersion:   "1.2.0",
+			wantPlatforms: []string{"linux_amd64", "windows_386"},
+		},
+		"more than one version": {
+			files: []string{
+				"terraform-provider-random_1.2.0_linux_amd64.zip",
+				"terraform-provider-random_1.3.0_linux_amd64.zip",
+			},
+			wantErr: "contains packages for more than one version",
+		},
+		"more than one provider": {
+			files: []string{
+				"terraform-provider-null_1.2.0_linux_amd64.zip",
+				"terraform-provider-random_1.2.0_linux_amd64.zip",
+			},
+			wantErr: "contains packages for more than one provider",
+		},
+		"invalid platform": {
+			files:   []string{"terraform-provider-random_1.2.0_linux.zip"},
+			wantErr: "invalid platform in package filename",
+		},
+		"no packages": {
+			files:   []string{"README.md"},
+			wantErr: "contains no provider packages",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, name := range test.files {
+				writeTestFile(t, filepath.Join(dir, name), "")
+			}
+
+			gotVersion, gotPackages, err := FindProviderPackages(dir)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("wrong error\ngot:  %v\nwant: containing %s", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if gotVersion.String() != test.wantVersion {
+				t.Errorf("wrong version %s; want %s", gotVersion, test.wantVersion)
+			}
+			if len(gotPackages) != len(test.wantPlatforms) {
+				t.Errorf("wrong number of packages %d; want %d", len(gotPackages), len(test.wantPlatforms))
+			}
+			for _, raw := range test.wantPlatforms {
+				platform := getproviders.Platform{}
+				platform.OS, platform.Arch, _ = strings.Cut(raw, "_")
+				if _, ok := gotPackages[platform]; !ok {
+					t.Errorf("missing package for %s", platform)
+				}
+			}
+		})
+	}
+}
+
+// testPackageFetcherEnvironment is a [getmodules.PackageFetcherEnvironment]
+// that installs all OCI module packages from a single store.
+type testPackageFetcherEnvironment struct {
+	store  *orasOCI.Store
+	policy *cosign.Policy
+}
+
+func (e testPackageFetcherEnvironment) OCIRepositoryStore(context.Context, string, string) (getmodules.OCIRepositoryStore, error) {
+	return e.store, nil
+}
+
+func (e testPackageFetcherEnvironment) OCISignaturePolicy(context.Context, string, string) (*cosign.Policy, error) {
+	return e.policy, nil
+}
+
+func newTestPushStore(t *testing.T) *orasOCI.Store {
+	t.Helper()
+	store, err := orasOCI.NewWithContext(t.Context(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func fetchTestManifest(t *testing.T, store *orasOCI.Store, desc ociv1.Descriptor) *ociv1.Manifest {
+	t.Helper()
+	src, err := orasContent.FetchAll(t.Context(), store, desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest ociv1.Manifest
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	return &manifest
+}
+
+func generateTestSigningKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func writeTestFile(t *testing.T, filename, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestProviderPackage(t *testing.T, dir, typeName, version, platform string) string {
+	t.Helper()
+	filename := filepath.Join(dir, "terraform-provider-"+typeName+"_"+version+"_"+platform+".zip")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     "terraform-provider-" + typeName + "_v" + version,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("placeholder executable for " + platform)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
@@ -31,6 +31,16 @@ type Changes struct {
 	// can be easily re-calculated during the apply phase. Therefore only root
 	// module outputs will survive a round-trip through a plan file.
 	Outputs []*OutputChangeSrc
+
+	// Deferred tracks resources whose planning was deferred to a later
+	// plan/apply round, and which therefore have no planned changes for any
+	// of their instances in this plan.
+	Deferred []*DeferredResource
+
+	// DeferredModuleCalls tracks module calls whose planning was deferred to
+	// a later plan/apply round, and which therefore have no planned changes
+	// for anything inside any of their module instances in this plan.
+	DeferredModuleCalls []*DeferredModuleCall
 }
 
 // NewChanges returns a valid Changes object that describes no changes.
@@ -228,3 +228,104 @@ func (cs *ChangesSync) RemoveOutputChange(addr addrs.AbsOutputValue) {
 		return
 	}
 }
+
+// AppendDeferredResource records that planning of the given resource was
+// deferred to a later plan/apply round.
+func (cs *ChangesSync) AppendDeferredResource(deferred *DeferredResource) {
+	if cs == nil {
+		panic("AppendDeferredResource on nil ChangesSync")
+	}
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	d := *deferred
+	cs.changes.Deferred = append(cs.changes.Deferred, &d)
+}
+
+// IsResourceDeferred returns true if planning of the resource with the given
+// address was deferred.
+func (cs *ChangesSync) IsResourceDeferred(addr addrs.AbsResource) bool {
+	if cs == nil {
+		panic("IsResourceDeferred on nil ChangesSync")
+	}
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	for _, d := range cs.changes.Deferred {
+		if d.Addr.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConfigResourceDeferred returns true if planning of any instance of the
+// resource with the given configuration address was deferred, across all
+// instances of its containing module.
+func (cs *ChangesSync) IsConfigResourceDeferred(addr addrs.ConfigResource) bool {
+	if cs == nil {
+		panic("IsConfigResourceDeferred on nil ChangesSync")
+	}
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	for _, d := range cs.changes.Deferred {
+		if d.Addr.Config().Equal(addr) {
+			return true
+		}
+	}
+	for _, d := range cs.changes.DeferredModuleCalls {
+		if d.Addr.Module.Module().Child(d.Addr.Call.Name).TargetContains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// AppendDeferredModuleCall records that planning of the given module call was
+// deferred to a later plan/apply round.
+func (cs *ChangesSync) AppendDeferredModuleCall(deferred *DeferredModuleCall) {
+	if cs == nil {
+		panic("AppendDeferredModuleCall on nil ChangesSync")
+	}
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	d := *deferred
+	cs.changes.DeferredModuleCalls = append(cs.changes.DeferredModuleCalls, &d)
+}
+
+// IsModuleCallDeferred returns true if planning of the module call with the
+// given address was deferred.
+func (cs *ChangesSync) IsModuleCallDeferred(addr addrs.AbsModuleCall) bool {
+	if cs == nil {
+		panic("IsModuleCallDeferred on nil ChangesSync")
+	}
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	for _, d := range cs.changes.DeferredModuleCalls {
+		if d.Addr.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsModuleInstanceDeferred returns true if the given module instance belongs
+// to a module call whose planning was deferred, either directly or through
+// one of its ancestors.
+func (cs *ChangesSync) IsModuleInstanceDeferred(addr addrs.ModuleInstance) bool {
+	if cs == nil {
+		panic("IsModuleInstanceDeferred on nil ChangesSync")
+	}
+	cs.lock.Lock()
+	defer cs.lock.Unlock()
+
+	for _, d := range cs.changes.DeferredModuleCalls {
+		if len(addr) > len(d.Addr.Module) && addr[:len(d.Addr.Module)+1].IsDeclaredByCall(d.Addr) {
+			return true
+		}
+	}
+	return false
+}
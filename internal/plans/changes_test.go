@@ -162,3 +162,59 @@ func TestChangeEncodeSensitive(t *testing.T) {
 		})
 	}
 }
+
+func TestChangesSyncDeferredModuleCalls(t *testing.T) {
+	changes := NewChanges()
+	sync := changes.SyncWrapper()
+	sync.AppendDeferredModuleCall(&DeferredModuleCall{
+		Addr:   addrs.RootModuleInstance.Child("parent", addrs.IntKey(0)).ChildCall("child"),
+		Reason: DeferredReasonInstanceCountUnknown,
+	})
+
+	moduleInstanceTests := map[string]bool{
+		"module.parent[0]":                                 false,
+		"module.parent[1].module.child[0]":                 false,
+		"module.parent[0].module.child[0]":                 true,
+		`module.parent[0].module.child["a"]`:               true,
+		"module.parent[0].module.child[0].module.grandkid": true,
+		"module.parent[0].module.other":                    false,
+	}
+	for addrStr, want := range moduleInstanceTests {
+		addr, diags := addrs.ParseModuleInstanceStr(addrStr)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		if got := sync.IsModuleInstanceDeferred(addr); got != want {
+			t.Errorf("wrong result for IsModuleInstanceDeferred(%s): got %t, want %t", addrStr, got, want)
+		}
+	}
+
+	resource := addrs.Resource{
+		Mode: addrs.ManagedResourceMode,
+		Type: "test_thing",
+		Name: "a",
+	}
+	configResourceTests := map[string]bool{
+		"module.parent":                              false,
+		"module.parent.module.child":                 true,
+		"module.parent.module.child.module.grandkid": true,
+		"module.parent.module.other":                 false,
+	}
+	for modStr, want := range configResourceTests {
+		mod, diags := addrs.ParseModuleInstanceStr(modStr)
+		if diags.HasErrors() {
+			t.Fatal(diags.Err())
+		}
+		addr := resource.InModule(mod.Module())
+		if got := sync.IsConfigResourceDeferred(addr); got != want {
+			t.Errorf("wrong result for IsConfigResourceDeferred(%s): got %t, want %t", addr, got, want)
+		}
+	}
+
+	if !sync.IsModuleCallDeferred(addrs.RootModuleInstance.Child("parent", addrs.IntKey(0)).ChildCall("child")) {
+		t.Errorf("module.parent[0].module.child is not deferred")
+	}
+	if sync.IsModuleCallDeferred(addrs.RootModuleInstance.Child("parent", addrs.IntKey(1)).ChildCall("child")) {
+		t.Errorf("module.parent[1].module.child is deferred")
+	}
+}
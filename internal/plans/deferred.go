@@ -0,0 +1,64 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package plans
+
+import (
+	"github.com/opentofu/opentofu/internal/addrs"
+)
+
+// DeferredResource describes a resource whose planning was deferred to a
+// later plan/apply round because OpenTofu could not yet determine which
+// instances it ought to have.
+//
+// A deferred resource has no planned changes for any of its instances in the
+// current plan, and any objects it already has in the prior state are left
+// untouched until a subsequent round is able to plan it.
+type DeferredResource struct {
+	// Addr is the absolute address of the resource whose planning was
+	// deferred, in the module instance where its expansion was unknown.
+	Addr addrs.AbsResource
+
+	// Reason describes why planning was deferred.
+	Reason DeferredReason
+}
+
+// DeferredModuleCall describes a module call whose planning was deferred to
+// a later plan/apply round because OpenTofu could not yet determine which
+// module instances it ought to have.
+//
+// Nothing inside a deferred module call has planned changes in the current
+// plan, and any objects its module instances already have in the prior state
+// are left untouched until a subsequent round is able to plan it.
+type DeferredModuleCall struct {
+	// Addr is the absolute address of the module call whose planning was
+	// deferred, in the module instance where its expansion was unknown.
+	Addr addrs.AbsModuleCall
+
+	// Reason describes why planning was deferred.
+	Reason DeferredReason
+}
+
+// DeferredReason describes why the planning of a resource or module call was
+// deferred.
+type DeferredReason rune
+
+//go:generate go tool golang.org/x/tools/cmd/stringer -type=DeferredReason deferred.go
+
+const (
+	// DeferredReasonInvalid is the zero value of DeferredReason, and is not
+	// a valid reason.
+	DeferredReasonInvalid DeferredReason = 0
+
+	// DeferredReasonInstanceCountUnknown indicates that the count or for_each
+	// argument of the resource or module call depends on values that won't be
+	// known until apply.
+	DeferredReasonInstanceCountUnknown DeferredReason = 'C'
+
+	// DeferredReasonDeferredPrereq indicates that the resource depends on
+	// another resource whose planning was deferred, and so it must also wait
+	// for a later round.
+	DeferredReasonDeferredPrereq DeferredReason = 'P'
+)
@@ -0,0 +1,33 @@
+// Code generated by "stringer -type=DeferredReason deferred.go"; DO NOT EDIT.
+
+package plans
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[DeferredReasonInvalid-0]
+	_ = x[DeferredReasonInstanceCountUnknown-67]
+	_ = x[DeferredReasonDeferredPrereq-80]
+}
+
+const (
+	_DeferredReason_name_0 = "DeferredReasonInvalid"
+	_DeferredReason_name_1 = "DeferredReasonInstanceCountUnknown"
+	_DeferredReason_name_2 = "DeferredReasonDeferredPrereq"
+)
+
+func (i DeferredReason) String() string {
+	switch {
+	case i == 0:
+		return _DeferredReason_name_0
+	case i == 67:
+		return _DeferredReason_name_1
+	case i == 80:
+		return _DeferredReason_name_2
+	default:
+		return "DeferredReason(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
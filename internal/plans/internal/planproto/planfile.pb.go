@@ -240,6 +240,56 @@ func (ResourceInstanceActionReason) EnumDescriptor() ([]byte, []int) {
 	return file_planfile_proto_rawDescGZIP(), []int{2}
 }
 
+// DeferredReason describes why planning of a resource was deferred.
+type DeferredReason int32
+
+const (
+	DeferredReason_DEFERRED_REASON_INVALID DeferredReason = 0
+	DeferredReason_INSTANCE_COUNT_UNKNOWN  DeferredReason = 1
+	DeferredReason_DEFERRED_PREREQ         DeferredReason = 2
+)
+
+// Enum value maps for DeferredReason.
+var (
+	DeferredReason_name = map[int32]string{
+		0: "DEFERRED_REASON_INVALID",
+		1: "INSTANCE_COUNT_UNKNOWN",
+		2: "DEFERRED_PREREQ",
+	}
+	DeferredReason_value = map[string]int32{
+		"DEFERRED_REASON_INVALID": 0,
+		"INSTANCE_COUNT_UNKNOWN":  1,
+		"DEFERRED_PREREQ":         2,
+	}
+)
+
+func (x DeferredReason) Enum() *DeferredReason {
+	p := new(DeferredReason)
+	*p = x
+	return p
+}
+
+func (x DeferredReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeferredReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_planfile_proto_enumTypes[3].Descriptor()
+}
+
+func (DeferredReason) Type() protoreflect.EnumType {
+	return &file_planfile_proto_enumTypes[3]
+}
+
+func (x DeferredReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeferredReason.Descriptor instead.
+func (DeferredReason) EnumDescriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{3}
+}
+
 // SchemaNestingMode mirrors configschema.NestingMode, and is used both for
 // nested block types and for NestedType-style (object) attributes.
 type SchemaNestingMode int32
@@ -284,11 +334,11 @@ func (x SchemaNestingMode) String() string {
 }
 
 func (SchemaNestingMode) Descriptor() protoreflect.EnumDescriptor {
-	return file_planfile_proto_enumTypes[3].Descriptor()
+	return file_planfile_proto_enumTypes[4].Descriptor()
 }
 
 func (SchemaNestingMode) Type() protoreflect.EnumType {
-	return &file_planfile_proto_enumTypes[3]
+	return &file_planfile_proto_enumTypes[4]
 }
 
 func (x SchemaNestingMode) Number() protoreflect.EnumNumber {
@@ -297,7 +347,7 @@ func (x SchemaNestingMode) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SchemaNestingMode.Descriptor instead.
 func (SchemaNestingMode) EnumDescriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{3}
+	return file_planfile_proto_rawDescGZIP(), []int{4}
 }
 
 // Status describes the status of a particular checkable object at the
@@ -338,11 +388,11 @@ func (x CheckResults_Status) String() string {
 }
 
 func (CheckResults_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_planfile_proto_enumTypes[4].Descriptor()
+	return file_planfile_proto_enumTypes[5].Descriptor()
 }
 
 func (CheckResults_Status) Type() protoreflect.EnumType {
-	return &file_planfile_proto_enumTypes[4]
+	return &file_planfile_proto_enumTypes[5]
 }
 
 func (x CheckResults_Status) Number() protoreflect.EnumNumber {
@@ -351,7 +401,7 @@ func (x CheckResults_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CheckResults_Status.Descriptor instead.
 func (CheckResults_Status) EnumDescriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{5, 0}
+	return file_planfile_proto_rawDescGZIP(), []int{7, 0}
 }
 
 type CheckResults_ObjectKind int32
@@ -393,11 +443,11 @@ func (x CheckResults_ObjectKind) String() string {
 }
 
 func (CheckResults_ObjectKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_planfile_proto_enumTypes[5].Descriptor()
+	return file_planfile_proto_enumTypes[6].Descriptor()
 }
 
 func (CheckResults_ObjectKind) Type() protoreflect.EnumType {
-	return &file_planfile_proto_enumTypes[5]
+	return &file_planfile_proto_enumTypes[6]
 }
 
 func (x CheckResults_ObjectKind) Number() protoreflect.EnumNumber {
@@ -406,7 +456,7 @@ func (x CheckResults_ObjectKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CheckResults_ObjectKind.Descriptor instead.
 func (CheckResults_ObjectKind) EnumDescriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{5, 1}
+	return file_planfile_proto_rawDescGZIP(), []int{7, 1}
 }
 
 // Plan is the root message type for the tfplan file
@@ -447,6 +497,16 @@ type Plan struct {
 	// outputs that are not changing, as context for detecting inconsistencies
 	// at apply time.
 	OutputChanges []*OutputChange `protobuf:"bytes,4,rep,name=output_changes,json=outputChanges,proto3" json:"output_changes,omitempty"`
+	// An unordered set of resources whose planning was deferred to a later
+	// plan/apply round because the instances they ought to have could not be
+	// determined yet. None of the instances of these resources have changes
+	// in resource_changes.
+	DeferredResources []*DeferredResource `protobuf:"bytes,23,rep,name=deferred_resources,json=deferredResources,proto3" json:"deferred_resources,omitempty"`
+	// An unordered set of module calls whose planning was deferred to a later
+	// plan/apply round because the module instances they ought to have could
+	// not be determined yet. None of the objects inside these module calls
+	// have changes in resource_changes.
+	DeferredModuleCalls []*DeferredModuleCall `protobuf:"bytes,25,rep,name=deferred_module_calls,json=deferredModuleCalls,proto3" json:"deferred_module_calls,omitempty"`
 	// An unordered set of check results for the entire configuration.
 	//
 	// Each element represents a single static configuration object that has
@@ -461,6 +521,11 @@ type Plan struct {
 	// target addresses are present, the plan applies to the whole
 	// configuration.
 	ExcludeAddrs []string `protobuf:"bytes,6,rep,name=exclude_addrs,json=excludeAddrs,proto3" json:"exclude_addrs,omitempty"`
+	// The name of the configuration profile that the target and exclude
+	// addresses were taken from, or empty if the plan was created without
+	// a profile. Target and exclude addresses may use the wildcard instance
+	// key "[*]" for addresses that came from a profile.
+	Profile string `protobuf:"bytes,24,opt,name=profile,proto3" json:"profile,omitempty"`
 	// An unordered set of force-replace addresses to include when applying.
 	// This must match the set of addresses that was used when creating the
 	// plan, or else applying the plan will fail when it reaches a different
@@ -580,6 +645,20 @@ func (x *Plan) GetOutputChanges() []*OutputChange {
 	return nil
 }
 
+func (x *Plan) GetDeferredResources() []*DeferredResource {
+	if x != nil {
+		return x.DeferredResources
+	}
+	return nil
+}
+
+func (x *Plan) GetDeferredModuleCalls() []*DeferredModuleCall {
+	if x != nil {
+		return x.DeferredModuleCalls
+	}
+	return nil
+}
+
 func (x *Plan) GetCheckResults() []*CheckResults {
 	if x != nil {
 		return x.CheckResults
@@ -601,6 +680,13 @@ func (x *Plan) GetExcludeAddrs() []string {
 	return nil
 }
 
+func (x *Plan) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
 func (x *Plan) GetForceReplaceAddrs() []string {
 	if x != nil {
 		return x.ForceReplaceAddrs
@@ -1039,6 +1125,117 @@ func (x *OutputChange) GetSensitive() bool {
 	return false
 }
 
+// DeferredResource describes a resource whose planning was deferred.
+type DeferredResource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// addr is the absolute address of the resource, which can be parsed
+	// using addrs.ParseAbsResourceStr.
+	Addr          string         `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Reason        DeferredReason `protobuf:"varint,2,opt,name=reason,proto3,enum=tfplan.DeferredReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferredResource) Reset() {
+	*x = DeferredResource{}
+	mi := &file_planfile_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferredResource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferredResource) ProtoMessage() {}
+
+func (x *DeferredResource) ProtoReflect() protoreflect.Message {
+	mi := &file_planfile_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferredResource.ProtoReflect.Descriptor instead.
+func (*DeferredResource) Descriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeferredResource) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *DeferredResource) GetReason() DeferredReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DeferredReason_DEFERRED_REASON_INVALID
+}
+
+// DeferredModuleCall describes a module call whose planning was deferred.
+type DeferredModuleCall struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// addr is the absolute address of the module call, which can be parsed
+	// using addrs.ParseModuleInstanceStr as a module instance address whose
+	// last step has no instance key.
+	Addr          string         `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Reason        DeferredReason `protobuf:"varint,2,opt,name=reason,proto3,enum=tfplan.DeferredReason" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeferredModuleCall) Reset() {
+	*x = DeferredModuleCall{}
+	mi := &file_planfile_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeferredModuleCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeferredModuleCall) ProtoMessage() {}
+
+func (x *DeferredModuleCall) ProtoReflect() protoreflect.Message {
+	mi := &file_planfile_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeferredModuleCall.ProtoReflect.Descriptor instead.
+func (*DeferredModuleCall) Descriptor() ([]byte, []int) {
+	return file_planfile_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeferredModuleCall) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *DeferredModuleCall) GetReason() DeferredReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DeferredReason_DEFERRED_REASON_INVALID
+}
+
 type CheckResults struct {
 	state protoimpl.MessageState  `protogen:"open.v1"`
 	Kind  CheckResults_ObjectKind `protobuf:"varint,1,opt,name=kind,proto3,enum=tfplan.CheckResults_ObjectKind" json:"kind,omitempty"`
@@ -1056,7 +1253,7 @@ type CheckResults struct {
 
 func (x *CheckResults) Reset() {
 	*x = CheckResults{}
-	mi := &file_planfile_proto_msgTypes[5]
+	mi := &file_planfile_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1068,7 +1265,7 @@ func (x *CheckResults) String() string {
 func (*CheckResults) ProtoMessage() {}
 
 func (x *CheckResults) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[5]
+	mi := &file_planfile_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1081,7 +1278,7 @@ func (x *CheckResults) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckResults.ProtoReflect.Descriptor instead.
 func (*CheckResults) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{5}
+	return file_planfile_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CheckResults) GetKind() CheckResults_ObjectKind {
@@ -1133,7 +1330,7 @@ type DynamicValue struct {
 
 func (x *DynamicValue) Reset() {
 	*x = DynamicValue{}
-	mi := &file_planfile_proto_msgTypes[6]
+	mi := &file_planfile_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1145,7 +1342,7 @@ func (x *DynamicValue) String() string {
 func (*DynamicValue) ProtoMessage() {}
 
 func (x *DynamicValue) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[6]
+	mi := &file_planfile_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1158,7 +1355,7 @@ func (x *DynamicValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DynamicValue.ProtoReflect.Descriptor instead.
 func (*DynamicValue) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{6}
+	return file_planfile_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DynamicValue) GetMsgpack() []byte {
@@ -1180,7 +1377,7 @@ type Path struct {
 
 func (x *Path) Reset() {
 	*x = Path{}
-	mi := &file_planfile_proto_msgTypes[7]
+	mi := &file_planfile_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1192,7 +1389,7 @@ func (x *Path) String() string {
 func (*Path) ProtoMessage() {}
 
 func (x *Path) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[7]
+	mi := &file_planfile_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,7 +1402,7 @@ func (x *Path) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Path.ProtoReflect.Descriptor instead.
 func (*Path) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{7}
+	return file_planfile_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Path) GetSteps() []*Path_Step {
@@ -1229,7 +1426,7 @@ type Importing struct {
 
 func (x *Importing) Reset() {
 	*x = Importing{}
-	mi := &file_planfile_proto_msgTypes[8]
+	mi := &file_planfile_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1241,7 +1438,7 @@ func (x *Importing) String() string {
 func (*Importing) ProtoMessage() {}
 
 func (x *Importing) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[8]
+	mi := &file_planfile_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1254,7 +1451,7 @@ func (x *Importing) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Importing.ProtoReflect.Descriptor instead.
 func (*Importing) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{8}
+	return file_planfile_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Importing) GetId() string {
@@ -1281,7 +1478,7 @@ type SchemaObject struct {
 
 func (x *SchemaObject) Reset() {
 	*x = SchemaObject{}
-	mi := &file_planfile_proto_msgTypes[9]
+	mi := &file_planfile_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1293,7 +1490,7 @@ func (x *SchemaObject) String() string {
 func (*SchemaObject) ProtoMessage() {}
 
 func (x *SchemaObject) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[9]
+	mi := &file_planfile_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1306,7 +1503,7 @@ func (x *SchemaObject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaObject.ProtoReflect.Descriptor instead.
 func (*SchemaObject) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{9}
+	return file_planfile_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SchemaObject) GetAttributes() []*SchemaAttribute {
@@ -1342,7 +1539,7 @@ type SchemaAttribute struct {
 
 func (x *SchemaAttribute) Reset() {
 	*x = SchemaAttribute{}
-	mi := &file_planfile_proto_msgTypes[10]
+	mi := &file_planfile_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1354,7 +1551,7 @@ func (x *SchemaAttribute) String() string {
 func (*SchemaAttribute) ProtoMessage() {}
 
 func (x *SchemaAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[10]
+	mi := &file_planfile_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1367,7 +1564,7 @@ func (x *SchemaAttribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaAttribute.ProtoReflect.Descriptor instead.
 func (*SchemaAttribute) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{10}
+	return file_planfile_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SchemaAttribute) GetName() string {
@@ -1439,7 +1636,7 @@ type SchemaNestedBlock struct {
 
 func (x *SchemaNestedBlock) Reset() {
 	*x = SchemaNestedBlock{}
-	mi := &file_planfile_proto_msgTypes[11]
+	mi := &file_planfile_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1451,7 +1648,7 @@ func (x *SchemaNestedBlock) String() string {
 func (*SchemaNestedBlock) ProtoMessage() {}
 
 func (x *SchemaNestedBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[11]
+	mi := &file_planfile_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1464,7 +1661,7 @@ func (x *SchemaNestedBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaNestedBlock.ProtoReflect.Descriptor instead.
 func (*SchemaNestedBlock) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{11}
+	return file_planfile_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SchemaNestedBlock) GetTypeName() string {
@@ -1512,7 +1709,7 @@ type SchemaBlock struct {
 
 func (x *SchemaBlock) Reset() {
 	*x = SchemaBlock{}
-	mi := &file_planfile_proto_msgTypes[12]
+	mi := &file_planfile_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1524,7 +1721,7 @@ func (x *SchemaBlock) String() string {
 func (*SchemaBlock) ProtoMessage() {}
 
 func (x *SchemaBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[12]
+	mi := &file_planfile_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1537,7 +1734,7 @@ func (x *SchemaBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaBlock.ProtoReflect.Descriptor instead.
 func (*SchemaBlock) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{12}
+	return file_planfile_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *SchemaBlock) GetAttributes() []*SchemaAttribute {
@@ -1564,7 +1761,7 @@ type Schema struct {
 
 func (x *Schema) Reset() {
 	*x = Schema{}
-	mi := &file_planfile_proto_msgTypes[13]
+	mi := &file_planfile_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1576,7 +1773,7 @@ func (x *Schema) String() string {
 func (*Schema) ProtoMessage() {}
 
 func (x *Schema) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[13]
+	mi := &file_planfile_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1589,7 +1786,7 @@ func (x *Schema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Schema.ProtoReflect.Descriptor instead.
 func (*Schema) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{13}
+	return file_planfile_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Schema) GetVersion() int64 {
@@ -1618,7 +1815,7 @@ type ResourceIdentitySchema struct {
 
 func (x *ResourceIdentitySchema) Reset() {
 	*x = ResourceIdentitySchema{}
-	mi := &file_planfile_proto_msgTypes[14]
+	mi := &file_planfile_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1630,7 +1827,7 @@ func (x *ResourceIdentitySchema) String() string {
 func (*ResourceIdentitySchema) ProtoMessage() {}
 
 func (x *ResourceIdentitySchema) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[14]
+	mi := &file_planfile_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1643,7 +1840,7 @@ func (x *ResourceIdentitySchema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceIdentitySchema.ProtoReflect.Descriptor instead.
 func (*ResourceIdentitySchema) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{14}
+	return file_planfile_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ResourceIdentitySchema) GetVersion() int64 {
@@ -1674,7 +1871,7 @@ type ResourceSchema struct {
 
 func (x *ResourceSchema) Reset() {
 	*x = ResourceSchema{}
-	mi := &file_planfile_proto_msgTypes[15]
+	mi := &file_planfile_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1686,7 +1883,7 @@ func (x *ResourceSchema) String() string {
 func (*ResourceSchema) ProtoMessage() {}
 
 func (x *ResourceSchema) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[15]
+	mi := &file_planfile_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1699,7 +1896,7 @@ func (x *ResourceSchema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceSchema.ProtoReflect.Descriptor instead.
 func (*ResourceSchema) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{15}
+	return file_planfile_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ResourceSchema) GetSchema() *Schema {
@@ -1729,7 +1926,7 @@ type ProviderSchema struct {
 
 func (x *ProviderSchema) Reset() {
 	*x = ProviderSchema{}
-	mi := &file_planfile_proto_msgTypes[16]
+	mi := &file_planfile_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1741,7 +1938,7 @@ func (x *ProviderSchema) String() string {
 func (*ProviderSchema) ProtoMessage() {}
 
 func (x *ProviderSchema) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[16]
+	mi := &file_planfile_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1754,7 +1951,7 @@ func (x *ProviderSchema) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProviderSchema.ProtoReflect.Descriptor instead.
 func (*ProviderSchema) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{16}
+	return file_planfile_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ProviderSchema) GetProviderConfig() *Schema {
@@ -1791,7 +1988,7 @@ type Schemas struct {
 
 func (x *Schemas) Reset() {
 	*x = Schemas{}
-	mi := &file_planfile_proto_msgTypes[17]
+	mi := &file_planfile_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1803,7 +2000,7 @@ func (x *Schemas) String() string {
 func (*Schemas) ProtoMessage() {}
 
 func (x *Schemas) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[17]
+	mi := &file_planfile_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1816,7 +2013,7 @@ func (x *Schemas) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Schemas.ProtoReflect.Descriptor instead.
 func (*Schemas) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{17}
+	return file_planfile_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Schemas) GetProviders() map[string]*ProviderSchema {
@@ -1836,7 +2033,7 @@ type PlanResourceAttr struct {
 
 func (x *PlanResourceAttr) Reset() {
 	*x = PlanResourceAttr{}
-	mi := &file_planfile_proto_msgTypes[19]
+	mi := &file_planfile_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1848,7 +2045,7 @@ func (x *PlanResourceAttr) String() string {
 func (*PlanResourceAttr) ProtoMessage() {}
 
 func (x *PlanResourceAttr) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[19]
+	mi := &file_planfile_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1889,7 +2086,7 @@ type CheckResults_ObjectResult struct {
 
 func (x *CheckResults_ObjectResult) Reset() {
 	*x = CheckResults_ObjectResult{}
-	mi := &file_planfile_proto_msgTypes[20]
+	mi := &file_planfile_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1901,7 +2098,7 @@ func (x *CheckResults_ObjectResult) String() string {
 func (*CheckResults_ObjectResult) ProtoMessage() {}
 
 func (x *CheckResults_ObjectResult) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[20]
+	mi := &file_planfile_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1914,7 +2111,7 @@ func (x *CheckResults_ObjectResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckResults_ObjectResult.ProtoReflect.Descriptor instead.
 func (*CheckResults_ObjectResult) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{5, 0}
+	return file_planfile_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *CheckResults_ObjectResult) GetObjectAddr() string {
@@ -1951,7 +2148,7 @@ type Path_Step struct {
 
 func (x *Path_Step) Reset() {
 	*x = Path_Step{}
-	mi := &file_planfile_proto_msgTypes[21]
+	mi := &file_planfile_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1963,7 +2160,7 @@ func (x *Path_Step) String() string {
 func (*Path_Step) ProtoMessage() {}
 
 func (x *Path_Step) ProtoReflect() protoreflect.Message {
-	mi := &file_planfile_proto_msgTypes[21]
+	mi := &file_planfile_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1976,7 +2173,7 @@ func (x *Path_Step) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Path_Step.ProtoReflect.Descriptor instead.
 func (*Path_Step) Descriptor() ([]byte, []int) {
-	return file_planfile_proto_rawDescGZIP(), []int{7, 0}
+	return file_planfile_proto_rawDescGZIP(), []int{9, 0}
 }
 
 func (x *Path_Step) GetSelector() isPath_Step_Selector {
@@ -2028,7 +2225,7 @@ var File_planfile_proto protoreflect.FileDescriptor
 
 const file_planfile_proto_rawDesc = "" +
 	"\n" +
-	"\x0eplanfile.proto\x12\x06tfplan\"\x8f\b\n" +
+	"\x0eplanfile.proto\x12\x06tfplan\"\xc2\t\n" +
 	"\x04Plan\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\x04R\aversion\x12%\n" +
 	"\aui_mode\x18\x11 \x01(\x0e2\f.tfplan.ModeR\x06uiMode\x12\x18\n" +
@@ -2036,10 +2233,13 @@ const file_planfile_proto_rawDesc = "" +
 	"\tvariables\x18\x02 \x03(\v2\x1b.tfplan.Plan.VariablesEntryR\tvariables\x12I\n" +
 	"\x10resource_changes\x18\x03 \x03(\v2\x1e.tfplan.ResourceInstanceChangeR\x0fresourceChanges\x12E\n" +
 	"\x0eresource_drift\x18\x12 \x03(\v2\x1e.tfplan.ResourceInstanceChangeR\rresourceDrift\x12;\n" +
-	"\x0eoutput_changes\x18\x04 \x03(\v2\x14.tfplan.OutputChangeR\routputChanges\x129\n" +
+	"\x0eoutput_changes\x18\x04 \x03(\v2\x14.tfplan.OutputChangeR\routputChanges\x12G\n" +
+	"\x12deferred_resources\x18\x17 \x03(\v2\x18.tfplan.DeferredResourceR\x11deferredResources\x12N\n" +
+	"\x15deferred_module_calls\x18\x19 \x03(\v2\x1a.tfplan.DeferredModuleCallR\x13deferredModuleCalls\x129\n" +
 	"\rcheck_results\x18\x13 \x03(\v2\x14.tfplan.CheckResultsR\fcheckResults\x12!\n" +
 	"\ftarget_addrs\x18\x05 \x03(\tR\vtargetAddrs\x12#\n" +
-	"\rexclude_addrs\x18\x06 \x03(\tR\fexcludeAddrs\x12.\n" +
+	"\rexclude_addrs\x18\x06 \x03(\tR\fexcludeAddrs\x12\x18\n" +
+	"\aprofile\x18\x18 \x01(\tR\aprofile\x12.\n" +
 	"\x13force_replace_addrs\x18\x10 \x03(\tR\x11forceReplaceAddrs\x12+\n" +
 	"\x11terraform_version\x18\x0e \x01(\tR\x10terraformVersion\x12)\n" +
 	"\abackend\x18\r \x01(\v2\x0f.tfplan.BackendR\abackend\x12K\n" +
@@ -2083,7 +2283,13 @@ const file_planfile_proto_rawDesc = "" +
 	"\fOutputChange\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
 	"\x06change\x18\x02 \x01(\v2\x0e.tfplan.ChangeR\x06change\x12\x1c\n" +
-	"\tsensitive\x18\x03 \x01(\bR\tsensitive\"\xfc\x03\n" +
+	"\tsensitive\x18\x03 \x01(\bR\tsensitive\"V\n" +
+	"\x10DeferredResource\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12.\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x16.tfplan.DeferredReasonR\x06reason\"X\n" +
+	"\x12DeferredModuleCall\x12\x12\n" +
+	"\x04addr\x18\x01 \x01(\tR\x04addr\x12.\n" +
+	"\x06reason\x18\x02 \x01(\x0e2\x16.tfplan.DeferredReasonR\x06reason\"\xfc\x03\n" +
 	"\fCheckResults\x123\n" +
 	"\x04kind\x18\x01 \x01(\x0e2\x1f.tfplan.CheckResults.ObjectKindR\x04kind\x12\x1f\n" +
 	"\vconfig_addr\x18\x02 \x01(\tR\n" +
@@ -2210,7 +2416,11 @@ const file_planfile_proto_rawDesc = "" +
 	"\x1dDELETE_BECAUSE_NO_MOVE_TARGET\x10\f\x12 \n" +
 	"\x1cDELETE_BECAUSE_ENABLED_FALSE\x10\x0e\x12-\n" +
 	")FORGOT_BECAUSE_LIFECYCLE_DESTROY_IN_STATE\x10\x0f\x12.\n" +
-	"*FORGOT_BECAUSE_LIFECYCLE_DESTROY_IN_CONFIG\x10\x10*\xad\x01\n" +
+	"*FORGOT_BECAUSE_LIFECYCLE_DESTROY_IN_CONFIG\x10\x10*^\n" +
+	"\x0eDeferredReason\x12\x1b\n" +
+	"\x17DEFERRED_REASON_INVALID\x10\x00\x12\x1a\n" +
+	"\x16INSTANCE_COUNT_UNKNOWN\x10\x01\x12\x13\n" +
+	"\x0fDEFERRED_PREREQ\x10\x02*\xad\x01\n" +
 	"\x11SchemaNestingMode\x12\x1a\n" +
 	"\x16SCHEMA_NESTING_INVALID\x10\x00\x12\x19\n" +
 	"\x15SCHEMA_NESTING_SINGLE\x10\x01\x12\x18\n" +
@@ -2231,94 +2441,101 @@ func file_planfile_proto_rawDescGZIP() []byte {
 	return file_planfile_proto_rawDescData
 }
 
-var file_planfile_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_planfile_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_planfile_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_planfile_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_planfile_proto_goTypes = []any{
 	(Mode)(0),                         // 0: tfplan.Mode
 	(Action)(0),                       // 1: tfplan.Action
 	(ResourceInstanceActionReason)(0), // 2: tfplan.ResourceInstanceActionReason
-	(SchemaNestingMode)(0),            // 3: tfplan.SchemaNestingMode
-	(CheckResults_Status)(0),          // 4: tfplan.CheckResults.Status
-	(CheckResults_ObjectKind)(0),      // 5: tfplan.CheckResults.ObjectKind
-	(*Plan)(nil),                      // 6: tfplan.Plan
-	(*Backend)(nil),                   // 7: tfplan.Backend
-	(*Change)(nil),                    // 8: tfplan.Change
-	(*ResourceInstanceChange)(nil),    // 9: tfplan.ResourceInstanceChange
-	(*OutputChange)(nil),              // 10: tfplan.OutputChange
-	(*CheckResults)(nil),              // 11: tfplan.CheckResults
-	(*DynamicValue)(nil),              // 12: tfplan.DynamicValue
-	(*Path)(nil),                      // 13: tfplan.Path
-	(*Importing)(nil),                 // 14: tfplan.Importing
-	(*SchemaObject)(nil),              // 15: tfplan.SchemaObject
-	(*SchemaAttribute)(nil),           // 16: tfplan.SchemaAttribute
-	(*SchemaNestedBlock)(nil),         // 17: tfplan.SchemaNestedBlock
-	(*SchemaBlock)(nil),               // 18: tfplan.SchemaBlock
-	(*Schema)(nil),                    // 19: tfplan.Schema
-	(*ResourceIdentitySchema)(nil),    // 20: tfplan.ResourceIdentitySchema
-	(*ResourceSchema)(nil),            // 21: tfplan.ResourceSchema
-	(*ProviderSchema)(nil),            // 22: tfplan.ProviderSchema
-	(*Schemas)(nil),                   // 23: tfplan.Schemas
-	nil,                               // 24: tfplan.Plan.VariablesEntry
-	(*PlanResourceAttr)(nil),          // 25: tfplan.Plan.resource_attr
-	(*CheckResults_ObjectResult)(nil), // 26: tfplan.CheckResults.ObjectResult
-	(*Path_Step)(nil),                 // 27: tfplan.Path.Step
-	nil,                               // 28: tfplan.ProviderSchema.ManagedResourceTypesEntry
-	nil,                               // 29: tfplan.ProviderSchema.DataSourcesEntry
-	nil,                               // 30: tfplan.Schemas.ProvidersEntry
+	(DeferredReason)(0),               // 3: tfplan.DeferredReason
+	(SchemaNestingMode)(0),            // 4: tfplan.SchemaNestingMode
+	(CheckResults_Status)(0),          // 5: tfplan.CheckResults.Status
+	(CheckResults_ObjectKind)(0),      // 6: tfplan.CheckResults.ObjectKind
+	(*Plan)(nil),                      // 7: tfplan.Plan
+	(*Backend)(nil),                   // 8: tfplan.Backend
+	(*Change)(nil),                    // 9: tfplan.Change
+	(*ResourceInstanceChange)(nil),    // 10: tfplan.ResourceInstanceChange
+	(*OutputChange)(nil),              // 11: tfplan.OutputChange
+	(*DeferredResource)(nil),          // 12: tfplan.DeferredResource
+	(*DeferredModuleCall)(nil),        // 13: tfplan.DeferredModuleCall
+	(*CheckResults)(nil),              // 14: tfplan.CheckResults
+	(*DynamicValue)(nil),              // 15: tfplan.DynamicValue
+	(*Path)(nil),                      // 16: tfplan.Path
+	(*Importing)(nil),                 // 17: tfplan.Importing
+	(*SchemaObject)(nil),              // 18: tfplan.SchemaObject
+	(*SchemaAttribute)(nil),           // 19: tfplan.SchemaAttribute
+	(*SchemaNestedBlock)(nil),         // 20: tfplan.SchemaNestedBlock
+	(*SchemaBlock)(nil),               // 21: tfplan.SchemaBlock
+	(*Schema)(nil),                    // 22: tfplan.Schema
+	(*ResourceIdentitySchema)(nil),    // 23: tfplan.ResourceIdentitySchema
+	(*ResourceSchema)(nil),            // 24: tfplan.ResourceSchema
+	(*ProviderSchema)(nil),            // 25: tfplan.ProviderSchema
+	(*Schemas)(nil),                   // 26: tfplan.Schemas
+	nil,                               // 27: tfplan.Plan.VariablesEntry
+	(*PlanResourceAttr)(nil),          // 28: tfplan.Plan.resource_attr
+	(*CheckResults_ObjectResult)(nil), // 29: tfplan.CheckResults.ObjectResult
+	(*Path_Step)(nil),                 // 30: tfplan.Path.Step
+	nil,                               // 31: tfplan.ProviderSchema.ManagedResourceTypesEntry
+	nil,                               // 32: tfplan.ProviderSchema.DataSourcesEntry
+	nil,                               // 33: tfplan.Schemas.ProvidersEntry
 }
 var file_planfile_proto_depIdxs = []int32{
 	0,  // 0: tfplan.Plan.ui_mode:type_name -> tfplan.Mode
-	24, // 1: tfplan.Plan.variables:type_name -> tfplan.Plan.VariablesEntry
-	9,  // 2: tfplan.Plan.resource_changes:type_name -> tfplan.ResourceInstanceChange
-	9,  // 3: tfplan.Plan.resource_drift:type_name -> tfplan.ResourceInstanceChange
-	10, // 4: tfplan.Plan.output_changes:type_name -> tfplan.OutputChange
-	11, // 5: tfplan.Plan.check_results:type_name -> tfplan.CheckResults
-	7,  // 6: tfplan.Plan.backend:type_name -> tfplan.Backend
-	25, // 7: tfplan.Plan.relevant_attributes:type_name -> tfplan.Plan.resource_attr
-	12, // 8: tfplan.Backend.config:type_name -> tfplan.DynamicValue
-	1,  // 9: tfplan.Change.action:type_name -> tfplan.Action
-	12, // 10: tfplan.Change.values:type_name -> tfplan.DynamicValue
-	13, // 11: tfplan.Change.before_sensitive_paths:type_name -> tfplan.Path
-	13, // 12: tfplan.Change.after_sensitive_paths:type_name -> tfplan.Path
-	14, // 13: tfplan.Change.importing:type_name -> tfplan.Importing
-	12, // 14: tfplan.Change.before_identity:type_name -> tfplan.DynamicValue
-	12, // 15: tfplan.Change.after_identity:type_name -> tfplan.DynamicValue
-	8,  // 16: tfplan.ResourceInstanceChange.change:type_name -> tfplan.Change
-	13, // 17: tfplan.ResourceInstanceChange.required_replace:type_name -> tfplan.Path
-	2,  // 18: tfplan.ResourceInstanceChange.action_reason:type_name -> tfplan.ResourceInstanceActionReason
-	8,  // 19: tfplan.OutputChange.change:type_name -> tfplan.Change
-	5,  // 20: tfplan.CheckResults.kind:type_name -> tfplan.CheckResults.ObjectKind
-	4,  // 21: tfplan.CheckResults.status:type_name -> tfplan.CheckResults.Status
-	26, // 22: tfplan.CheckResults.objects:type_name -> tfplan.CheckResults.ObjectResult
-	27, // 23: tfplan.Path.steps:type_name -> tfplan.Path.Step
-	12, // 24: tfplan.Importing.identity:type_name -> tfplan.DynamicValue
-	16, // 25: tfplan.SchemaObject.attributes:type_name -> tfplan.SchemaAttribute
-	3,  // 26: tfplan.SchemaObject.nesting:type_name -> tfplan.SchemaNestingMode
-	15, // 27: tfplan.SchemaAttribute.nested_type:type_name -> tfplan.SchemaObject
-	18, // 28: tfplan.SchemaNestedBlock.block:type_name -> tfplan.SchemaBlock
-	3,  // 29: tfplan.SchemaNestedBlock.nesting:type_name -> tfplan.SchemaNestingMode
-	16, // 30: tfplan.SchemaBlock.attributes:type_name -> tfplan.SchemaAttribute
-	17, // 31: tfplan.SchemaBlock.block_types:type_name -> tfplan.SchemaNestedBlock
-	18, // 32: tfplan.Schema.block:type_name -> tfplan.SchemaBlock
-	15, // 33: tfplan.ResourceIdentitySchema.body:type_name -> tfplan.SchemaObject
-	19, // 34: tfplan.ResourceSchema.schema:type_name -> tfplan.Schema
-	20, // 35: tfplan.ResourceSchema.identity_schema:type_name -> tfplan.ResourceIdentitySchema
-	19, // 36: tfplan.ProviderSchema.provider_config:type_name -> tfplan.Schema
-	28, // 37: tfplan.ProviderSchema.managed_resource_types:type_name -> tfplan.ProviderSchema.ManagedResourceTypesEntry
-	29, // 38: tfplan.ProviderSchema.data_sources:type_name -> tfplan.ProviderSchema.DataSourcesEntry
-	30, // 39: tfplan.Schemas.providers:type_name -> tfplan.Schemas.ProvidersEntry
-	12, // 40: tfplan.Plan.VariablesEntry.value:type_name -> tfplan.DynamicValue
-	13, // 41: tfplan.Plan.resource_attr.attr:type_name -> tfplan.Path
-	4,  // 42: tfplan.CheckResults.ObjectResult.status:type_name -> tfplan.CheckResults.Status
-	12, // 43: tfplan.Path.Step.element_key:type_name -> tfplan.DynamicValue
-	21, // 44: tfplan.ProviderSchema.ManagedResourceTypesEntry.value:type_name -> tfplan.ResourceSchema
-	21, // 45: tfplan.ProviderSchema.DataSourcesEntry.value:type_name -> tfplan.ResourceSchema
-	22, // 46: tfplan.Schemas.ProvidersEntry.value:type_name -> tfplan.ProviderSchema
-	47, // [47:47] is the sub-list for method output_type
-	47, // [47:47] is the sub-list for method input_type
-	47, // [47:47] is the sub-list for extension type_name
-	47, // [47:47] is the sub-list for extension extendee
-	0,  // [0:47] is the sub-list for field type_name
+	27, // 1: tfplan.Plan.variables:type_name -> tfplan.Plan.VariablesEntry
+	10, // 2: tfplan.Plan.resource_changes:type_name -> tfplan.ResourceInstanceChange
+	10, // 3: tfplan.Plan.resource_drift:type_name -> tfplan.ResourceInstanceChange
+	11, // 4: tfplan.Plan.output_changes:type_name -> tfplan.OutputChange
+	12, // 5: tfplan.Plan.deferred_resources:type_name -> tfplan.DeferredResource
+	13, // 6: tfplan.Plan.deferred_module_calls:type_name -> tfplan.DeferredModuleCall
+	14, // 7: tfplan.Plan.check_results:type_name -> tfplan.CheckResults
+	8,  // 8: tfplan.Plan.backend:type_name -> tfplan.Backend
+	28, // 9: tfplan.Plan.relevant_attributes:type_name -> tfplan.Plan.resource_attr
+	15, // 10: tfplan.Backend.config:type_name -> tfplan.DynamicValue
+	1,  // 11: tfplan.Change.action:type_name -> tfplan.Action
+	15, // 12: tfplan.Change.values:type_name -> tfplan.DynamicValue
+	16, // 13: tfplan.Change.before_sensitive_paths:type_name -> tfplan.Path
+	16, // 14: tfplan.Change.after_sensitive_paths:type_name -> tfplan.Path
+	17, // 15: tfplan.Change.importing:type_name -> tfplan.Importing
+	15, // 16: tfplan.Change.before_identity:type_name -> tfplan.DynamicValue
+	15, // 17: tfplan.Change.after_identity:type_name -> tfplan.DynamicValue
+	9,  // 18: tfplan.ResourceInstanceChange.change:type_name -> tfplan.Change
+	16, // 19: tfplan.ResourceInstanceChange.required_replace:type_name -> tfplan.Path
+	2,  // 20: tfplan.ResourceInstanceChange.action_reason:type_name -> tfplan.ResourceInstanceActionReason
+	9,  // 21: tfplan.OutputChange.change:type_name -> tfplan.Change
+	3,  // 22: tfplan.DeferredResource.reason:type_name -> tfplan.DeferredReason
+	3,  // 23: tfplan.DeferredModuleCall.reason:type_name -> tfplan.DeferredReason
+	6,  // 24: tfplan.CheckResults.kind:type_name -> tfplan.CheckResults.ObjectKind
+	5,  // 25: tfplan.CheckResults.status:type_name -> tfplan.CheckResults.Status
+	29, // 26: tfplan.CheckResults.objects:type_name -> tfplan.CheckResults.ObjectResult
+	30, // 27: tfplan.Path.steps:type_name -> tfplan.Path.Step
+	15, // 28: tfplan.Importing.identity:type_name -> tfplan.DynamicValue
+	19, // 29: tfplan.SchemaObject.attributes:type_name -> tfplan.SchemaAttribute
+	4,  // 30: tfplan.SchemaObject.nesting:type_name -> tfplan.SchemaNestingMode
+	18, // 31: tfplan.SchemaAttribute.nested_type:type_name -> tfplan.SchemaObject
+	21, // 32: tfplan.SchemaNestedBlock.block:type_name -> tfplan.SchemaBlock
+	4,  // 33: tfplan.SchemaNestedBlock.nesting:type_name -> tfplan.SchemaNestingMode
+	19, // 34: tfplan.SchemaBlock.attributes:type_name -> tfplan.SchemaAttribute
+	20, // 35: tfplan.SchemaBlock.block_types:type_name -> tfplan.SchemaNestedBlock
+	21, // 36: tfplan.Schema.block:type_name -> tfplan.SchemaBlock
+	18, // 37: tfplan.ResourceIdentitySchema.body:type_name -> tfplan.SchemaObject
+	22, // 38: tfplan.ResourceSchema.schema:type_name -> tfplan.Schema
+	23, // 39: tfplan.ResourceSchema.identity_schema:type_name -> tfplan.ResourceIdentitySchema
+	22, // 40: tfplan.ProviderSchema.provider_config:type_name -> tfplan.Schema
+	31, // 41: tfplan.ProviderSchema.managed_resource_types:type_name -> tfplan.ProviderSchema.ManagedResourceTypesEntry
+	32, // 42: tfplan.ProviderSchema.data_sources:type_name -> tfplan.ProviderSchema.DataSourcesEntry
+	33, // 43: tfplan.Schemas.providers:type_name -> tfplan.Schemas.ProvidersEntry
+	15, // 44: tfplan.Plan.VariablesEntry.value:type_name -> tfplan.DynamicValue
+	16, // 45: tfplan.Plan.resource_attr.attr:type_name -> tfplan.Path
+	5,  // 46: tfplan.CheckResults.ObjectResult.status:type_name -> tfplan.CheckResults.Status
+	15, // 47: tfplan.Path.Step.element_key:type_name -> tfplan.DynamicValue
+	24, // 48: tfplan.ProviderSchema.ManagedResourceTypesEntry.value:type_name -> tfplan.ResourceSchema
+	24, // 49: tfplan.ProviderSchema.DataSourcesEntry.value:type_name -> tfplan.ResourceSchema
+	25, // 50: tfplan.Schemas.ProvidersEntry.value:type_name -> tfplan.ProviderSchema
+	51, // [51:51] is the sub-list for method output_type
+	51, // [51:51] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_planfile_proto_init() }
@@ -2326,7 +2543,7 @@ func file_planfile_proto_init() {
 	if File_planfile_proto != nil {
 		return
 	}
-	file_planfile_proto_msgTypes[21].OneofWrappers = []any{
+	file_planfile_proto_msgTypes[23].OneofWrappers = []any{
 		(*Path_Step_AttributeName)(nil),
 		(*Path_Step_ElementKey)(nil),
 	}
@@ -2335,8 +2552,8 @@ func file_planfile_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_planfile_proto_rawDesc), len(file_planfile_proto_rawDesc)),
-			NumEnums:      6,
-			NumMessages:   25,
+			NumEnums:      7,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
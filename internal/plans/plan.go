@@ -61,6 +61,12 @@ type Plan struct {
 	ForceReplaceAddrs []addrs.AbsResourceInstance
 	Backend           Backend
 
+	// Profile is the name of the configuration profile whose targets and
+	// excludes were used to create this plan, or an empty string if the plan
+	// wasn't created with a profile. It's recorded so that applying a saved
+	// plan can verify that it was created with the expected profile.
+	Profile string
+
 	// Errored is true if the Changes information is incomplete because
 	// the planning operation failed. An errored plan cannot be applied,
 	// but can be cautiously inspected for debugging purposes.
@@ -100,6 +100,53 @@ func readPlan(r io.Reader) (*plans.Plan, error) {
 		})
 	}
 
+	for _, rawDR := range rawPlan.DeferredResources {
+		addr, diags := addrs.ParseAbsResourceStr(rawDR.Addr)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("invalid deferred resource address %q: %w", rawDR.Addr, diags.Err())
+		}
+
+		var reason plans.DeferredReason
+		switch rawDR.Reason {
+		case planproto.DeferredReason_INSTANCE_COUNT_UNKNOWN:
+			reason = plans.DeferredReasonInstanceCountUnknown
+		case planproto.DeferredReason_DEFERRED_PREREQ:
+			reason = plans.DeferredReasonDeferredPrereq
+		default:
+			return nil, fmt.Errorf("deferred resource %s has unsupported reason %s", addr, rawDR.Reason)
+		}
+
+		plan.Changes.Deferred = append(plan.Changes.Deferred, &plans.DeferredResource{
+			Addr:   addr,
+			Reason: reason,
+		})
+	}
+
+	for _, rawDM := range rawPlan.DeferredModuleCalls {
+		inst, diags := addrs.ParseModuleInstanceStr(rawDM.Addr)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("invalid deferred module call address %q: %w", rawDM.Addr, diags.Err())
+		}
+		if len(inst) == 0 || inst[len(inst)-1].InstanceKey != addrs.NoKey {
+			return nil, fmt.Errorf("invalid deferred module call address %q: must be a module call, not a module instance", rawDM.Addr)
+		}
+		parent, call := inst.Call()
+		addr := call.Absolute(parent)
+
+		var reason plans.DeferredReason
+		switch rawDM.Reason {
+		case planproto.DeferredReason_INSTANCE_COUNT_UNKNOWN:
+			reason = plans.DeferredReasonInstanceCountUnknown
+		default:
+			return nil, fmt.Errorf("deferred module call %s has unsupported reason %s", addr, rawDM.Reason)
+		}
+
+		plan.Changes.DeferredModuleCalls = append(plan.Changes.DeferredModuleCalls, &plans.DeferredModuleCall{
+			Addr:   addr,
+			Reason: reason,
+		})
+	}
+
 	plan.Checks.ConfigResults = addrs.MakeMap[addrs.ConfigCheckable, *states.CheckResultAggregate]()
 	for _, rawCRs := range rawPlan.CheckResults {
 		aggr := &states.CheckResultAggregate{}
@@ -219,7 +266,7 @@ func readPlan(r io.Reader) (*plans.Plan, error) {
 	}
 
 	for _, rawTargetAddr := range rawPlan.TargetAddrs {
-		target, diags := addrs.ParseTargetStr(rawTargetAddr)
+		target, diags := addrs.ParseTargetPatternStr(rawTargetAddr)
 		if diags.HasErrors() {
 			return nil, fmt.Errorf("plan contains invalid target address %q: %w", target, diags.Err())
 		}
@@ -227,13 +274,15 @@ func readPlan(r io.Reader) (*plans.Plan, error) {
 	}
 
 	for _, rawExcludeAddr := range rawPlan.ExcludeAddrs {
-		exclude, diags := addrs.ParseTargetStr(rawExcludeAddr)
+		exclude, diags := addrs.ParseTargetPatternStr(rawExcludeAddr)
 		if diags.HasErrors() {
 			return nil, fmt.Errorf("plan contains invalid exclude address %q: %w", exclude, diags.Err())
 		}
 		plan.ExcludeAddrs = append(plan.ExcludeAddrs, exclude.Subject)
 	}
 
+	plan.Profile = rawPlan.Profile
+
 	for _, rawReplaceAddr := range rawPlan.ForceReplaceAddrs {
 		addr, diags := addrs.ParseAbsResourceInstanceStr(rawReplaceAddr)
 		if diags.HasErrors() {
@@ -586,6 +635,34 @@ func writePlan(plan *plans.Plan, w io.Writer) error {
 		})
 	}
 
+	for _, dr := range plan.Changes.Deferred {
+		rawDR := &planproto.DeferredResource{
+			Addr: dr.Addr.String(),
+		}
+		switch dr.Reason {
+		case plans.DeferredReasonInstanceCountUnknown:
+			rawDR.Reason = planproto.DeferredReason_INSTANCE_COUNT_UNKNOWN
+		case plans.DeferredReasonDeferredPrereq:
+			rawDR.Reason = planproto.DeferredReason_DEFERRED_PREREQ
+		default:
+			return fmt.Errorf("deferred resource %s has unsupported reason %s", dr.Addr, dr.Reason)
+		}
+		rawPlan.DeferredResources = append(rawPlan.DeferredResources, rawDR)
+	}
+
+	for _, dm := range plan.Changes.DeferredModuleCalls {
+		rawDM := &planproto.DeferredModuleCall{
+			Addr: dm.Addr.String(),
+		}
+		switch dm.Reason {
+		case plans.DeferredReasonInstanceCountUnknown:
+			rawDM.Reason = planproto.DeferredReason_INSTANCE_COUNT_UNKNOWN
+		default:
+			return fmt.Errorf("deferred module call %s has unsupported reason %s", dm.Addr, dm.Reason)
+		}
+		rawPlan.DeferredModuleCalls = append(rawPlan.DeferredModuleCalls, rawDM)
+	}
+
 	if plan.Checks != nil {
 		for _, configElem := range plan.Checks.ConfigResults.Elems {
 			crs := configElem.Value
@@ -667,13 +744,15 @@ func writePlan(plan *plans.Plan, w io.Writer) error {
 	}
 
 	for _, targetAddr := range plan.TargetAddrs {
-		rawPlan.TargetAddrs = append(rawPlan.TargetAddrs, targetAddr.String())
+		rawPlan.TargetAddrs = append(rawPlan.TargetAddrs, addrs.TargetPatternString(targetAddr))
 	}
 
 	for _, excludeAddr := range plan.ExcludeAddrs {
-		rawPlan.ExcludeAddrs = append(rawPlan.ExcludeAddrs, excludeAddr.String())
+		rawPlan.ExcludeAddrs = append(rawPlan.ExcludeAddrs, addrs.TargetPatternString(excludeAddr))
 	}
 
+	rawPlan.Profile = plan.Profile
+
 	for _, replaceAddr := range plan.ForceReplaceAddrs {
 		rawPlan.ForceReplaceAddrs = append(rawPlan.ForceReplaceAddrs, replaceAddr.String())
 	}
@@ -36,6 +36,30 @@ func TestTFPlanRoundTrip(t *testing.T) {
 		},
 		EphemeralVariables: map[string]bool{"bar": false, "baz": true, "foo": false},
 		Changes: &plans.Changes{
+			Deferred: []*plans.DeferredResource{
+				{
+					Addr: addrs.Resource{
+						Mode: addrs.ManagedResourceMode,
+						Type: "test_thing",
+						Name: "counted",
+					}.Absolute(addrs.RootModuleInstance),
+					Reason: plans.DeferredReasonInstanceCountUnknown,
+				},
+				{
+					Addr: addrs.Resource{
+						Mode: addrs.ManagedResourceMode,
+						Type: "test_thing",
+						Name: "downstream",
+					}.Absolute(addrs.RootModuleInstance.Child("child", addrs.IntKey(0))),
+					Reason: plans.DeferredReasonDeferredPrereq,
+				},
+			},
+			DeferredModuleCalls: []*plans.DeferredModuleCall{
+				{
+					Addr:   addrs.RootModuleInstance.Child("parent", addrs.StringKey("a")).ChildCall("child"),
+					Reason: plans.DeferredReasonInstanceCountUnknown,
+				},
+			},
 			Outputs: []*plans.OutputChangeSrc{
 				{
 					Addr: addrs.OutputValue{Name: "bar"}.Absolute(addrs.RootModuleInstance),
@@ -308,7 +332,17 @@ func TestTFPlanRoundTrip(t *testing.T) {
 				Type: "test_thing",
 				Name: "woot",
 			}.Absolute(addrs.RootModuleInstance),
+			addrs.Module{"network", "subnets"},
+			addrs.ConfigResource{
+				Module: addrs.Module{"app"},
+				Resource: addrs.Resource{
+					Mode: addrs.ManagedResourceMode,
+					Type: "test_thing",
+					Name: "web",
+				},
+			},
 		},
+		Profile: "networking",
 		Backend: plans.Backend{
 			Type: "local",
 			Config: mustNewDynamicValue(
@@ -26,21 +26,46 @@ type Library interface {
 }
 
 func NewLibrary(providerFactories ProviderFactories, provisionerFactories ProvisionerFactories) Library {
+	return NewLibraryWithSchemaStore(providerFactories, provisionerFactories, nil)
+}
+
+// NewLibraryWithSchemaStore is like NewLibrary, but the returned library will
+// also try to load provider schemas from the given store before starting a
+// provider to read its schema, and will save any schemas it does read from
+// a provider into the store.
+//
+// A nil store is equivalent to calling NewLibrary.
+func NewLibraryWithSchemaStore(providerFactories ProviderFactories, provisionerFactories ProvisionerFactories, store ProviderSchemaStore) Library {
 	return &library{
-		providerFactories: providerFactories,
-		providerSchemas:   map[addrs.Provider]*providerSchemaEntry{},
+		providerFactories:   providerFactories,
+		providerSchemas:     map[addrs.Provider]*providerSchemaEntry{},
+		providerSchemaStore: store,
 
 		provisionerFactories: provisionerFactories,
 		provisionerSchemas:   map[string]*provisionerSchemaEntry{},
 	}
 }
 
+// ProviderSchemaStore is a persistent store of provider schemas that
+// survives between OpenTofu runs.
+type ProviderSchemaStore interface {
+	// LoadProviderSchema returns a previously-stored schema for the given
+	// provider, if one is available.
+	LoadProviderSchema(addr addrs.Provider) (providers.ProviderSchema, bool)
+
+	// StoreProviderSchema saves a schema that was read from the given
+	// provider. Implementations should treat any failure to save as
+	// non-fatal, since the store is only an optimization.
+	StoreProviderSchema(addr addrs.Provider, schema providers.ProviderSchema)
+}
+
 // library is the default Library implementation, with included fields to facilitate
 // schema caching among managers.
 type library struct {
 	providerSchemasLock sync.Mutex
 	providerSchemas     map[addrs.Provider]*providerSchemaEntry
 	providerFactories   ProviderFactories
+	providerSchemaStore ProviderSchemaStore
 
 	provisionerSchemasLock sync.Mutex
 	provisionerSchemas     map[string]*provisionerSchemaEntry
@@ -105,6 +105,15 @@ func (p *providerManager) GetProviderSchema(ctx context.Context, addr addrs.Prov
 	entry.Lock()
 	defer entry.Unlock()
 
+	if !entry.populated && p.providerSchemaStore != nil {
+		if schema, ok := p.providerSchemaStore.LoadProviderSchema(addr); ok {
+			log.Printf("[TRACE] plugins.providerManager Using stored schema for provider %q", addr)
+			entry.schema = schema
+			entry.populated = true
+			entry.diags = entry.diags.Append(entry.schema.Validate(addr))
+		}
+	}
+
 	if !entry.populated {
 		log.Printf("[TRACE] plugins.providerManager Initializing provider %q to read its schema", addr)
 
@@ -126,6 +135,9 @@ func (p *providerManager) GetProviderSchema(ctx context.Context, addr addrs.Prov
 			err := entry.schema.Validate(addr)
 			entry.diags = entry.diags.Append(err)
 		}
+		if !entry.diags.HasErrors() && p.providerSchemaStore != nil {
+			p.providerSchemaStore.StoreProviderSchema(addr, entry.schema)
+		}
 	}
 
 	return entry.schema, entry.diags
@@ -7,6 +7,8 @@ package providercache
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -65,6 +67,32 @@ func (d *Dir) installPackageWithLock(ctx context.Context, meta getproviders.Pack
 	return meta.Location.InstallProviderPackage(ctx, meta, newPath, allowedHashes)
 }
 
+// PartialDownloadDirName is the name of the directory, inside a provider cache
+// directory, where the archives of provider packages are kept while they are
+// being downloaded, so that an interrupted download can be resumed later.
+//
+// As with [SchemaCacheDirName], the leading period means that this can never
+// be mistaken for a provider registry hostname, and the files are stored
+// directly inside it so that [getproviders.SearchLocalDirectory] never treats
+// them as packages. Any file in this directory can be safely deleted when no
+// installation is in progress, at the cost of restarting its download.
+const PartialDownloadDirName = ".partial-downloads"
+
+// partialDownloadPath returns the path where the archive of the given package
+// should be kept while it's being downloaded into the receiving directory.
+//
+// The path depends only on the package's identity and not on its location,
+// because some registries return a different short-lived download URL
+// each time they are asked for the same package. The package checksums are
+// still verified after the download completes, so resuming into a partial
+// file from another location cannot cause an incorrect package to be
+// installed.
+func (d *Dir) partialDownloadPath(meta getproviders.PackageMeta) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", meta.Provider, meta.Version, meta.TargetPlatform)
+	return filepath.Join(d.baseDir, PartialDownloadDirName, hex.EncodeToString(h.Sum(nil))+".zip")
+}
+
 // LinkFromOtherCache takes a CachedProvider value produced from another Dir
 // and links it into the cache represented by the receiver Dir.
 //
@@ -0,0 +1,270 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// InstallPolicy represents operator-defined rules about which providers and
+// provider versions an [Installer] is allowed to select.
+//
+// The installer applies the policy after selecting the newest available
+// version that matches the configured version constraints. If that version
+// is rejected then the installer falls back to the newest version that the
+// policy allows, and fails only if there is no such version.
+type InstallPolicy struct {
+	// Allow, if non-empty, restricts installation to only the providers
+	// that match at least one of these patterns.
+	Allow getproviders.MultiSourceMatchingPatterns
+
+	// Deny forbids installation of any provider that matches at least one
+	// of these patterns, even if it also matches Allow.
+	Deny getproviders.MultiSourceMatchingPatterns
+
+	// MinimumReleaseAge, if nonzero, rejects any version that was released
+	// less than this duration ago.
+	//
+	// Versions whose source doesn't report when they were released are
+	// also rejected, because we cannot prove they are old enough.
+	MinimumReleaseAge time.Duration
+
+	// VersionRules further constrain the versions that can be selected for
+	// providers that match each rule's patterns.
+	VersionRules []InstallPolicyVersionRule
+
+	// DeniedVersionsFiles are paths to files listing specific provider
+	// versions that must never be installed, such as versions with known
+	// vulnerabilities, in the format accepted by [ParseDeniedVersions].
+	//
+	// The files are read each time the policy is used by
+	// [Installer.EnsureProviderVersions], so that updates to them take
+	// effect immediately.
+	DeniedVersionsFiles []string
+
+	// now is overridden in tests to control the current time used for
+	// MinimumReleaseAge. If nil, [time.Now] is used.
+	now func() time.Time
+}
+
+// InstallPolicyVersionRule is a rule within an [InstallPolicy] that requires
+// the selected version of any matching provider to meet some additional
+// version constraints.
+type InstallPolicyVersionRule struct {
+	Providers   getproviders.MultiSourceMatchingPatterns
+	Constraints getproviders.VersionConstraints
+}
+
+// DeniedVersion is an entry from a denied versions file, as returned by
+// [ParseDeniedVersions].
+type DeniedVersion struct {
+	Provider addrs.Provider
+	Versions getproviders.VersionSet
+
+	// Reason is the comment given for the entry, if any, which is
+	// typically a vulnerability identifier such as a CVE number.
+	Reason string
+
+	// Pos describes where the entry was defined, as "filename:line".
+	Pos string
+}
+
+// ParseDeniedVersions parses the content of a denied versions file.
+//
+// Each line of the file is either blank, a comment starting with "#", or a
+// provider source address followed by a version constraint and then
+// optionally a "#" and a comment explaining why the versions are denied:
+//
+//	hashicorp/aws 5.1.0 # CVE-2024-0001
+//	example.com/foo/bar >= 1.2.0, < 1.2.4
+//
+// The given filename is used only for error messages and for the Pos field
+// of the results.
+func ParseDeniedVersions(src []byte, filename string) ([]DeniedVersion, error) {
+	var ret []DeniedVersion
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	lineNum := 0
+	for sc.Scan() {
+		lineNum++
+		line, reason, _ := strings.Cut(sc.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		pos := fmt.Sprintf("%s:%d", filename, lineNum)
+		addrStr, constraintStr, ok := strings.Cut(line, " ")
+		constraintStr = strings.TrimSpace(constraintStr)
+		if !ok || constraintStr == "" {
+			return nil, fmt.Errorf("%s: each entry must be a provider source address followed by a version constraint", pos)
+		}
+		addr, diags := addrs.ParseProviderSourceString(addrStr)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("%s: invalid provider source address %q: %w", pos, addrStr, diags.Err())
+		}
+		constraints, err := getproviders.ParseVersionConstraints(constraintStr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid version constraint %q: %w", pos, constraintStr, err)
+		}
+		ret = append(ret, DeniedVersion{
+			Provider: addr,
+			Versions: getproviders.MeetingConstraints(constraints),
+			Reason:   strings.TrimSpace(reason),
+			Pos:      pos,
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filename, err)
+	}
+	return ret, nil
+}
+
+// loadedInstallPolicy is an [InstallPolicy] whose denied versions files have
+// been read, ready to check individual provider versions.
+type loadedInstallPolicy struct {
+	*InstallPolicy
+	denied []DeniedVersion
+}
+
+// load reads the policy's denied versions files.
+//
+// A nil policy allows everything, and so loading it always succeeds.
+func (p *InstallPolicy) load() (*loadedInstallPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+	ret := &loadedInstallPolicy{InstallPolicy: p}
+	for _, filename := range p.DeniedVersionsFiles {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("reading provider installation policy denied versions file: %w", err)
+		}
+		denied, err := ParseDeniedVersions(src, filename)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider installation policy denied versions file: %w", err)
+		}
+		ret.denied = append(ret.denied, denied...)
+	}
+	return ret, nil
+}
+
+// checkProvider returns an error if the policy forbids installing any
+// version of the given provider.
+func (p *loadedInstallPolicy) checkProvider(provider addrs.Provider) error {
+	if p == nil {
+		return nil
+	}
+	if len(p.Allow) != 0 && !p.Allow.MatchesProvider(provider) {
+		return fmt.Errorf("provider installation policy does not allow %s, because it matches none of the allowed patterns", provider)
+	}
+	if p.Deny.MatchesProvider(provider) {
+		return fmt.Errorf("provider installation policy denies %s, because it matches a denied pattern", provider)
+	}
+	return nil
+}
+
+// checkVersion returns an error describing which rule rejects the given
+// version of the given provider, or nil if the version is acceptable.
+//
+// If MinimumReleaseAge is set then this uses the given source to find out
+// when the version was released, which typically requires a network request.
+func (p *loadedInstallPolicy) checkVersion(ctx context.Context, source getproviders.Source, provider addrs.Provider, version getproviders.Version, target getproviders.Platform) error {
+	if p == nil {
+		return nil
+	}
+	for _, denied := range p.denied {
+		if denied.Provider != provider || !denied.Versions.Has(version) {
+			continue
+		}
+		if denied.Reason != "" {
+			return fmt.Errorf("v%s is denied by %s: %s", version, denied.Pos, denied.Reason)
+		}
+		return fmt.Errorf("v%s is denied by %s", version, denied.Pos)
+	}
+	for _, rule := range p.VersionRules {
+		if !rule.Providers.MatchesProvider(provider) {
+			continue
+		}
+		if !getproviders.MeetingConstraints(rule.Constraints).Has(version) {
+			return fmt.Errorf("v%s does not match the policy's version constraint %s", version, getproviders.VersionConstraintsString(rule.Constraints))
+		}
+	}
+	if p.MinimumReleaseAge > 0 {
+		meta, err := source.PackageMeta(ctx, provider, version, target)
+		if err != nil {
+			return fmt.Errorf("v%s cannot be checked for its release time: %w", version, err)
+		}
+		if meta.ReleaseTime.IsZero() {
+			return fmt.Errorf("v%s has no known release time, so it cannot be checked against the minimum release age of %s", version, p.MinimumReleaseAge)
+		}
+		now := time.Now
+		if p.now != nil {
+			now = p.now
+		}
+		if age := now().Sub(meta.ReleaseTime); age < p.MinimumReleaseAge {
+			return fmt.Errorf("v%s was released at %s, which is less than the minimum release age of %s", version, meta.ReleaseTime.UTC().Format(time.RFC3339), p.MinimumReleaseAge)
+		}
+	}
+	return nil
+}
+
+// InstallPolicyChecker checks individual provider versions against an
+// [InstallPolicy] in the same way as an [Installer], for callers that need
+// to explain which versions the installer would select without actually
+// installing anything.
+type InstallPolicyChecker struct {
+	policy *loadedInstallPolicy
+	source getproviders.Source
+	target getproviders.Platform
+}
+
+// NewInstallPolicyChecker reads the denied versions files of the given
+// policy and returns a checker for it. If the policy has a minimum release
+// age, the checker uses the given source to find out when each version for
+// the given target platform was released.
+//
+// A nil policy allows any provider and any version.
+func NewInstallPolicyChecker(policy *InstallPolicy, source getproviders.Source, target getproviders.Platform) (*InstallPolicyChecker, error) {
+	loaded, err := policy.load()
+	if err != nil {
+		return nil, err
+	}
+	return &InstallPolicyChecker{
+		policy: loaded,
+		source: source,
+		target: target,
+	}, nil
+}
+
+// CheckVersion returns an error describing why the policy rejects the given
+// version of the given provider, or nil if the installer could select it.
+func (c *InstallPolicyChecker) CheckVersion(ctx context.Context, provider addrs.Provider, version getproviders.Version) error {
+	if err := c.policy.checkProvider(provider); err != nil {
+		return err
+	}
+	return c.policy.checkVersion(ctx, c.source, provider, version, c.target)
+}
+
+// formatPolicyRejections returns a bulleted list describing the given errors
+// returned from [loadedInstallPolicy.checkVersion].
+func formatPolicyRejections(rejected []error) string {
+	var buf strings.Builder
+	for i, err := range rejected {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "  - %s", err)
+	}
+	return buf.String()
+}
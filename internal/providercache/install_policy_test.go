@@ -0,0 +1,264 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/depsfile"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+func TestParseDeniedVersions(t *testing.T) {
+	src := []byte(`
+# Versions with known vulnerabilities
+hashicorp/aws 5.1.0 # CVE-2024-0001
+example.com/foo/bar >= 1.2.0, < 1.2.4
+`)
+	got, err := ParseDeniedVersions(src, "denied")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := len(got), 2; got != want {
+		t.Fatalf("wrong number of entries %d; want %d", got, want)
+	}
+
+	if got, want := got[0].Provider, addrs.MustParseProviderSourceString("hashicorp/aws"); got != want {
+		t.Errorf("wrong provider %s; want %s", got, want)
+	}
+	if got, want := got[0].Reason, "CVE-2024-0001"; got != want {
+		t.Errorf("wrong reason %q; want %q", got, want)
+	}
+	if got, want := got[0].Pos, "denied:3"; got != want {
+		t.Errorf("wrong position %q; want %q", got, want)
+	}
+	if !got[1].Versions.Has(getproviders.MustParseVersion("1.2.3")) {
+		t.Errorf("second entry does not deny v1.2.3")
+	}
+	if got[1].Versions.Has(getproviders.MustParseVersion("1.2.4")) {
+		t.Errorf("second entry denies v1.2.4")
+	}
+	if got, want := got[1].Reason, ""; got != want {
+		t.Errorf("wrong reason %q; want %q", got, want)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		tests := map[string]string{
+			"hashicorp/aws":               "each entry must be a provider source address followed by a version constraint",
+			"not/a/valid/address 1.0.0":   "invalid provider source address",
+			"hashicorp/aws not-a-version": "invalid version constraint",
+		}
+		for src, wantErr := range tests {
+			_, err := ParseDeniedVersions([]byte(src), "denied")
+			if err == nil {
+				t.Errorf("%q: unexpected success; want error", src)
+				continue
+			}
+			if got := err.Error(); !strings.Contains(got, wantErr) {
+				t.Errorf("%q: wrong error\ngot:  %s\nwant substring: %s", src, got, wantErr)
+			}
+		}
+	})
+}
+
+func TestEnsureProviderVersions_installPolicy(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	platform := getproviders.Platform{OS: "gameboy", Arch: "lr35902"}
+	beepProvider := addrs.MustParseProviderSourceString("example.com/foo/beep")
+	boopProvider := addrs.MustParseProviderSourceString("example.com/foo/boop")
+
+	var packages []getproviders.PackageMeta
+	for version, released := range map[string]time.Time{
+		"1.0.0": now.Add(-30 * 24 * time.Hour),
+		"1.1.0": now.Add(-20 * 24 * time.Hour), // denied by the file below
+		"1.2.0": now.Add(-24 * time.Hour),      // too new
+	} {
+		meta, close, err := getproviders.FakeInstallablePackageMeta(beepProvider, getproviders.MustParseVersion(version), nil, platform, "terraform-provider-beep")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(close)
+		meta.ReleaseTime = released
+		packages = append(packages, meta)
+	}
+	source := getproviders.NewMockSource(packages, nil)
+
+	deniedFile := filepath.Join(t.TempDir(), "denied")
+	if err := os.WriteFile(deniedFile, []byte("example.com/foo/beep 1.1.0 # CVE-2025-0001\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	allow, err := getproviders.ParseMultiSourceMatchingPatterns([]string{"example.com/foo/*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deny, err := getproviders.ParseMultiSourceMatchingPatterns([]string{"example.com/foo/boop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := &InstallPolicy{
+		Allow:               allow,
+		Deny:                deny,
+		MinimumReleaseAge:   7 * 24 * time.Hour,
+		DeniedVersionsFiles: []string{deniedFile},
+		now:                 func() time.Time { return now },
+	}
+
+	t.Run("fallback", func(t *testing.T) {
+		dir := NewDirWithPlatform(t.TempDir(), platform)
+		installer := NewInstaller(dir, source)
+		installer.SetInstallPolicy(policy)
+
+		var gotRejected []string
+		var gotFallback getproviders.Version
+		evts := &InstallerEvents{
+			QueryPackagesPolicyFallback: func(provider addrs.Provider, selectedVersion getproviders.Version, rejected []error) {
+				gotFallback = selectedVersion
+				for _, err := range rejected {
+					gotRejected = append(gotRejected, err.Error())
+				}
+			},
+		}
+		ctx := evts.OnContext(t.Context())
+
+		reqs := getproviders.Requirements{
+			beepProvider: getproviders.MustParseVersionConstraints(">= 1.0.0"),
+		}
+		locks, err := installer.EnsureProviderVersions(ctx, depsfile.NewLocks(), reqs, InstallNewProvidersOnly)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got, want := locks.Provider(beepProvider).Version(), getproviders.MustParseVersion("1.0.0"); got != want {
+			t.Errorf("wrong selected version %s; want %s", got, want)
+		}
+		if got, want := gotFallback, getproviders.MustParseVersion("1.0.0"); got != want {
+			t.Errorf("wrong fallback version %s; want %s", got, want)
+		}
+		wantRejected := []string{
+			"v1.2.0 was released at 2025-05-31T00:00:00Z, which is less than the minimum release age of 168h0m0s",
+			"v1.1.0 is denied by " + deniedFile + ":1: CVE-2025-0001",
+		}
+		if diff := cmp.Diff(wantRejected, gotRejected); diff != "" {
+			t.Errorf("wrong rejections\n%s", diff)
+		}
+	})
+
+	t.Run("locked version rejected", func(t *testing.T) {
+		dir := NewDirWithPlatform(t.TempDir(), platform)
+		installer := NewInstaller(dir, source)
+		installer.SetInstallPolicy(policy)
+
+		locks := depsfile.NewLocks()
+		locks.SetProvider(beepProvider, getproviders.MustParseVersion("1.1.0"), getproviders.MustParseVersionConstraints(">= 1.0.0"), nil)
+		reqs := getproviders.Requirements{
+			beepProvider: getproviders.MustParseVersionConstraints(">= 1.0.0"),
+		}
+		_, err := installer.EnsureProviderVersions(t.Context(), locks, reqs, InstallNewProvidersOnly)
+		if err == nil {
+			t.Fatalf("unexpected success; want error")
+		}
+		if got, want := err.Error(), "the previously-selected version is rejected by the provider installation policy: v1.1.0 is denied by"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+		}
+	})
+
+	t.Run("no allowed versions", func(t *testing.T) {
+		dir := NewDirWithPlatform(t.TempDir(), platform)
+		installer := NewInstaller(dir, source)
+		installer.SetInstallPolicy(policy)
+
+		reqs := getproviders.Requirements{
+			beepProvider: getproviders.MustParseVersionConstraints(">= 1.1.0"),
+		}
+		_, err := installer.EnsureProviderVersions(t.Context(), depsfile.NewLocks(), reqs, InstallNewProvidersOnly)
+		if err == nil {
+			t.Fatalf("unexpected success; want error")
+		}
+		if got, want := err.Error(), "all available releases matching the given constraints >= 1.1.0 are rejected by the provider installation policy"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+		}
+	})
+
+	t.Run("provider denied", func(t *testing.T) {
+		dir := NewDirWithPlatform(t.TempDir(), platform)
+		installer := NewInstaller(dir, source)
+		installer.SetInstallPolicy(policy)
+
+		reqs := getproviders.Requirements{
+			boopProvider: nil,
+		}
+		_, err := installer.EnsureProviderVersions(t.Context(), depsfile.NewLocks(), reqs, InstallNewProvidersOnly)
+		if err == nil {
+			t.Fatalf("unexpected success; want error")
+		}
+		if got, want := err.Error(), "provider installation policy denies example.com/foo/boop, because it matches a denied pattern"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+		}
+	})
+
+	t.Run("checker", func(t *testing.T) {
+		checker, err := NewInstallPolicyChecker(policy, source, platform)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		tests := []struct {
+			provider addrs.Provider
+			version  string
+			wantErr  string
+		}{
+			{beepProvider, "1.0.0", ""},
+			{beepProvider, "1.1.0", "v1.1.0 is denied by " + deniedFile + ":1: CVE-2025-0001"},
+			{beepProvider, "1.2.0", "v1.2.0 was released at 2025-05-31T00:00:00Z, which is less than the minimum release age of 168h0m0s"},
+			{boopProvider, "1.0.0", "provider installation policy denies example.com/foo/boop, because it matches a denied pattern"},
+		}
+		for _, test := range tests {
+			err := checker.CheckVersion(t.Context(), test.provider, getproviders.MustParseVersion(test.version))
+			var gotErr string
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != test.wantErr {
+				t.Errorf("wrong result for %s v%s\ngot:  %s\nwant: %s", test.provider, test.version, gotErr, test.wantErr)
+			}
+		}
+
+		// A nil policy allows everything.
+		checker, err = NewInstallPolicyChecker(nil, source, platform)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if err := checker.CheckVersion(t.Context(), boopProvider, getproviders.MustParseVersion("1.0.0")); err != nil {
+			t.Errorf("unexpected error for nil policy: %s", err)
+		}
+	})
+
+	t.Run("unreadable denied versions file", func(t *testing.T) {
+		dir := NewDirWithPlatform(t.TempDir(), platform)
+		installer := NewInstaller(dir, source)
+		installer.SetInstallPolicy(&InstallPolicy{
+			DeniedVersionsFiles: []string{filepath.Join(t.TempDir(), "nonexistent")},
+		})
+
+		reqs := getproviders.Requirements{
+			beepProvider: nil,
+		}
+		_, err := installer.EnsureProviderVersions(t.Context(), depsfile.NewLocks(), reqs, InstallNewProvidersOnly)
+		if err == nil {
+			t.Fatalf("unexpected success; want error")
+		}
+		if got, want := err.Error(), "reading provider installation policy denied versions file"; !strings.Contains(got, want) {
+			t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, want)
+		}
+	})
+}
@@ -63,6 +63,10 @@ type Installer struct {
 	// lifecycle for, and therefore does not need to worry about the
 	// installation of.
 	unmanagedProviderTypes map[addrs.Provider]struct{}
+
+	// policy is an optional set of operator-defined rules about which
+	// providers and provider versions may be selected.
+	policy *InstallPolicy
 }
 
 // NewInstaller constructs and returns a new installer with the given target
@@ -166,6 +170,15 @@ func (i *Installer) SetUnmanagedProviderTypes(types map[addrs.Provider]struct{})
 	i.unmanagedProviderTypes = types
 }
 
+// SetInstallPolicy tells the receiver to enforce the given policy when
+// selecting provider versions to install.
+//
+// The default, if this method isn't called, is to allow any provider and
+// any version that matches the configured version constraints.
+func (i *Installer) SetInstallPolicy(policy *InstallPolicy) {
+	i.policy = policy
+}
+
 // EnsureProviderVersions compares the given provider requirements with what
 // is already available in the installer's target directory and then takes
 // appropriate installation actions to ensure that suitable packages
@@ -209,6 +222,11 @@ func (i *Installer) EnsureProviderVersions(ctx context.Context, locks *depsfile.
 	// final locks file reflects what the installer has selected.
 	locks = locks.DeepCopy()
 
+	policy, err := i.policy.load()
+	if err != nil {
+		return nil, err
+	}
+
 	if cb := evts.PendingProviders; cb != nil {
 		cb(reqs)
 	}
@@ -219,14 +237,14 @@ func (i *Installer) EnsureProviderVersions(ctx context.Context, locks *depsfile.
 	// just ask the source to confirm the continued existence of what
 	// was locked, or otherwise we'll find the newest version matching the
 	// configured version constraint.
-	mightNeed, locked := i.ensureProviderVersionsMightNeed(ctx, locks, reqs, mode, errs)
+	mightNeed, locked := i.ensureProviderVersionsMightNeed(ctx, locks, reqs, mode, policy, errs)
 
 	// Step 2: Query the provider source for each of the providers we selected
 	// in the first step and select the latest available version that is
 	// in the set of acceptable versions.
 	//
 	// This produces a set of packages to install to our cache in the next step.
-	need, err := i.ensureProviderVersionsNeed(ctx, locks, reqs, mightNeed, locked, errs)
+	need, err := i.ensureProviderVersionsNeed(ctx, locks, reqs, mightNeed, locked, policy, errs)
 	if err != nil {
 		return nil, err
 	}
@@ -271,6 +289,7 @@ func (i *Installer) ensureProviderVersionsMightNeed(
 	locks *depsfile.Locks,
 	reqs getproviders.Requirements,
 	mode InstallMode,
+	policy *loadedInstallPolicy,
 	errs map[addrs.Provider]error,
 ) (
 	map[addrs.Provider]getproviders.VersionSet,
@@ -315,6 +334,19 @@ func (i *Installer) ensureProviderVersionsMightNeed(
 			// unmanaged providers do not require installation
 			continue
 		}
+		if err := policy.checkProvider(provider); err != nil {
+			errs[provider] = err
+			// As with the locked version mismatch below, we emit an
+			// artificial QueryPackagesBegin so that the event stream
+			// remains consistent.
+			if cb := evts.QueryPackagesBegin; cb != nil {
+				cb(provider, versionConstraints, false)
+			}
+			if cb := evts.QueryPackagesFailure; cb != nil {
+				cb(provider, err)
+			}
+			continue
+		}
 		acceptableVersions := versions.MeetingConstraints(versionConstraints)
 		if !mode.forceQueryAllProviders() {
 			// If we're not forcing potential changes of version then an
@@ -357,6 +389,7 @@ func (i *Installer) ensureProviderVersionsNeed(
 	reqs getproviders.Requirements,
 	mightNeed map[addrs.Provider]getproviders.VersionSet,
 	locked map[addrs.Provider]bool,
+	policy *loadedInstallPolicy,
 	errs map[addrs.Provider]error,
 ) (map[addrs.Provider]getproviders.Version, error) {
 	evts := installerEventsForContext(ctx)
@@ -380,6 +413,21 @@ func (i *Installer) ensureProviderVersionsNeed(
 			return getproviders.Version{}, err
 		}
 
+		targetPlatform := i.targetDir.targetPlatform
+		if locked[provider] {
+			// A version that was selected before the policy changed must
+			// still be rejected, but we can't fall back to any other version
+			// without the operator's permission to change the lock file.
+			pl := locks.Provider(provider)
+			if err := policy.checkVersion(ctx, i.source, provider, pl.Version(), targetPlatform); err != nil {
+				err = fmt.Errorf("the previously-selected version is rejected by the provider installation policy: %w; must use tofu init -upgrade to allow selection of a different version", err)
+				if cb := evts.QueryPackagesFailure; cb != nil {
+					cb(provider, err)
+				}
+				return getproviders.Version{}, err
+			}
+		}
+
 		if locked[provider] && i.globalCacheDir != nil {
 			pl := locks.Provider(provider)
 			if i.globalCacheDir.ProviderVersion(pl.Provider(), pl.Version()) != nil {
@@ -405,14 +453,26 @@ func (i *Installer) ensureProviderVersionsNeed(
 				cb(provider, warnings)
 			}
 		}
-		available.Sort()                           // put the versions in increasing order of precedence
-		for i := len(available) - 1; i >= 0; i-- { // walk backwards to consider newer versions first
-			if acceptableVersions.Has(available[i]) {
-				if cb := evts.QueryPackagesSuccess; cb != nil {
-					cb(provider, available[i])
+		available.Sort() // put the versions in increasing order of precedence
+		var rejected []error
+		for idx := len(available) - 1; idx >= 0; idx-- { // walk backwards to consider newer versions first
+			if !acceptableVersions.Has(available[idx]) {
+				continue
+			}
+			if !locked[provider] {
+				// Locked versions were already checked above.
+				if err := policy.checkVersion(ctx, i.source, provider, available[idx], targetPlatform); err != nil {
+					rejected = append(rejected, err)
+					continue
 				}
-				return available[i], nil
 			}
+			if cb := evts.QueryPackagesPolicyFallback; cb != nil && len(rejected) > 0 {
+				cb(provider, available[idx], rejected)
+			}
+			if cb := evts.QueryPackagesSuccess; cb != nil {
+				cb(provider, available[idx])
+			}
+			return available[idx], nil
 		}
 		// If we get here then the source has no packages that meet the given
 		// version constraint, which we model as a query error.
@@ -422,6 +482,8 @@ func (i *Installer) ensureProviderVersionsNeed(
 			// reason.
 			lock := locks.Provider(provider)
 			err = fmt.Errorf("the previously-selected version %s is no longer available", lock.Version())
+		} else if len(rejected) > 0 {
+			err = fmt.Errorf("all available releases matching the given constraints %s are rejected by the provider installation policy:\n%s", getproviders.VersionConstraintsString(reqs[provider]), formatPolicyRejections(rejected))
 		} else {
 			err = fmt.Errorf("no available releases match the given constraints %s", getproviders.VersionConstraintsString(reqs[provider]))
 			log.Printf("[DEBUG] %s", err.Error())
@@ -668,8 +730,21 @@ func (i *Installer) ensureProviderVersionInDirectory(
 		allowedHashes = []getproviders.Hash{}
 	}
 
+	// Packages downloaded over HTTP are fetched into a partial file in the
+	// target directory, so that if the download is interrupted a later
+	// attempt can resume from where this one stopped.
+	downloadOpts := getproviders.PackageDownloadOptions{
+		PartialFile: installTo.partialDownloadPath(meta),
+	}
+	if cb := evts.FetchPackageProgress; cb != nil {
+		downloadOpts.Progress = func(progress getproviders.PackageDownloadProgress) {
+			cb(provider, version, progress)
+		}
+	}
+	installCtx := getproviders.ContextWithPackageDownloadOptions(ctx, downloadOpts)
+
 	allowSkippingInstallWithoutHashes := i.globalCacheDirMayBreakDependencyLockFile && isGlobalCache
-	authResult, err := installTo.InstallPackage(ctx, meta, allowedHashes, allowSkippingInstallWithoutHashes)
+	authResult, err := installTo.InstallPackage(installCtx, meta, allowedHashes, allowSkippingInstallWithoutHashes)
 	if err != nil {
 		// TODO: Consider retrying for certain kinds of error that seem
 		// likely to be transient. For now, we just treat all errors equally.
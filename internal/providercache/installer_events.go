@@ -77,6 +77,13 @@ type InstallerEvents struct {
 	QueryPackagesFailure func(provider addrs.Provider, err error)
 	QueryPackagesWarning func(provider addrs.Provider, warn []string)
 
+	// QueryPackagesPolicyFallback is called, before QueryPackagesSuccess,
+	// when the newest versions matching the version constraints were rejected
+	// by the installer's [InstallPolicy] and so an older version was
+	// selected instead. rejected describes why each newer version was
+	// rejected, in order from newest to oldest.
+	QueryPackagesPolicyFallback func(provider addrs.Provider, selectedVersion getproviders.Version, rejected []error)
+
 	// The LinkFromCache... family of events delimit the operation of linking
 	// a selected provider package from the system-wide shared cache into the
 	// current configuration's local cache.
@@ -113,6 +120,14 @@ type InstallerEvents struct {
 	FetchPackageSuccess func(provider addrs.Provider, version getproviders.Version, localDir string, authResult *getproviders.PackageAuthenticationResult)
 	FetchPackageFailure func(provider addrs.Provider, version getproviders.Version, err error)
 
+	// FetchPackageProgress is called periodically between FetchPackageBegin
+	// and FetchPackageSuccess or FetchPackageFailure while a package is
+	// being downloaded over HTTP, and once more when the download completes.
+	//
+	// progress.Resumed is non-zero if the download resumed from a partial
+	// download left over from an earlier interrupted attempt.
+	FetchPackageProgress func(provider addrs.Provider, version getproviders.Version, progress getproviders.PackageDownloadProgress)
+
 	// CacheDirLockContended is called if acquiring a lock on the specified
 	// cache directory takes more than a few seconds, suggesting that some
 	// other process is already holding a lock.
@@ -215,6 +230,13 @@ func (e *InstallerEvents) Sync() *InstallerEvents {
 				e.QueryPackagesWarning(provider, warn)
 			}
 		},
+		QueryPackagesPolicyFallback: func(provider addrs.Provider, selectedVersion getproviders.Version, rejected []error) {
+			lock.Lock()
+			defer lock.Unlock()
+			if e.QueryPackagesPolicyFallback != nil {
+				e.QueryPackagesPolicyFallback(provider, selectedVersion, rejected)
+			}
+		},
 		LinkFromCacheBegin: func(provider addrs.Provider, version getproviders.Version, cacheRoot string) {
 			lock.Lock()
 			defer lock.Unlock()
@@ -264,6 +286,13 @@ func (e *InstallerEvents) Sync() *InstallerEvents {
 				e.FetchPackageFailure(provider, version, err)
 			}
 		},
+		FetchPackageProgress: func(provider addrs.Provider, version getproviders.Version, progress getproviders.PackageDownloadProgress) {
+			lock.Lock()
+			defer lock.Unlock()
+			if e.FetchPackageProgress != nil {
+				e.FetchPackageProgress(provider, version, progress)
+			}
+		},
 		CacheDirLockContended: func(cacheDir string) {
 			lock.Lock()
 			defer lock.Unlock()
@@ -106,6 +106,20 @@ func installerLogEventsForTests(into chan<- *testInstallerEventLogItem) *Install
 				Args:     warns,
 			}
 		},
+		QueryPackagesPolicyFallback: func(provider addrs.Provider, selectedVersion getproviders.Version, rejected []error) {
+			rejectedStrs := make([]string, len(rejected))
+			for i, err := range rejected {
+				rejectedStrs[i] = err.Error() // stringified to guarantee cmp-ability
+			}
+			into <- &testInstallerEventLogItem{
+				Event:    "QueryPackagesPolicyFallback",
+				Provider: provider,
+				Args: struct {
+					Version  string
+					Rejected []string
+				}{selectedVersion.String(), rejectedStrs},
+			}
+		},
 		LinkFromCacheBegin: func(provider addrs.Provider, version getproviders.Version, cacheRoot string) {
 			into <- &testInstallerEventLogItem{
 				Event:    "LinkFromCacheBegin",
@@ -0,0 +1,477 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/version"
+)
+
+// SchemaCacheDirName is the name of the directory, inside a provider cache
+// directory, where a [SchemaCache] for that directory stores its entries.
+//
+// The leading period means that this can never be mistaken for a provider
+// registry hostname, and the entries are stored directly inside it so that
+// [getproviders.SearchLocalDirectory] never treats them as packages.
+const SchemaCacheDirName = ".schema-cache"
+
+// schemaCacheFormatVersion is included in every cache key so that changing
+// the serialization format implicitly invalidates all existing entries.
+const schemaCacheFormatVersion = "1"
+
+// SchemaCache is a content-addressed on-disk cache of provider schemas, which
+// allows OpenTofu to avoid starting a provider plugin only to ask it for its
+// schema.
+//
+// Entries are addressed by keys returned from [SchemaCacheKey], which are
+// derived from the checksums of the provider package that produced the
+// schema. Installing a different package therefore naturally causes a cache
+// miss, rather than reuse of a stale schema.
+type SchemaCache struct {
+	baseDir string
+}
+
+// NewSchemaCache returns a [SchemaCache] that stores its entries in the
+// given directory, creating it when first needed.
+func NewSchemaCache(baseDir string) *SchemaCache {
+	return &SchemaCache{baseDir: baseDir}
+}
+
+// NewSchemaCacheForDir returns a [SchemaCache] that stores its entries
+// alongside the provider packages in the given cache directory.
+func NewSchemaCacheForDir(dir *Dir) *SchemaCache {
+	return NewSchemaCache(filepath.Join(dir.BasePath(), SchemaCacheDirName))
+}
+
+// SchemaCacheKey returns the key that identifies the schema of the given
+// version of the given provider, as installed from a package matching any of
+// the given checksums, such as those recorded in the dependency lock file.
+//
+// The result is empty if there are no hashes, because then there is nothing
+// to prove that a later package is the same one that produced the schema.
+func SchemaCacheKey(provider addrs.Provider, providerVersion getproviders.Version, hashes []getproviders.Hash) string {
+	if len(hashes) == 0 {
+		return ""
+	}
+	sorted := slices.Clone(hashes)
+	slices.Sort(sorted)
+
+	h := sha256.New()
+	// The OpenTofu version is included because the in-memory representation
+	// of a schema is partly decided by OpenTofu itself, and so might differ
+	// between releases even for the same provider package.
+	fmt.Fprintf(h, "%s\n%s\n%s\n%s\n", schemaCacheFormatVersion, version.String(), provider, providerVersion)
+	for _, hash := range sorted {
+		fmt.Fprintf(h, "%s\n", hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Load returns the schema previously stored with the given key, if any.
+//
+// Any problem reading an entry is treated as a cache miss, so that a
+// corrupted cache can at worst cause OpenTofu to fetch the schema from the
+// provider again.
+func (c *SchemaCache) Load(key string) (providers.ProviderSchema, bool) {
+	if key == "" {
+		return providers.ProviderSchema{}, false
+	}
+	f, err := os.Open(c.entryPath(key))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] providercache.SchemaCache: failed to open %s: %s", c.entryPath(key), err)
+		}
+		return providers.ProviderSchema{}, false
+	}
+	defer f.Close()
+
+	schema, err := readCachedSchema(f)
+	if err != nil {
+		log.Printf("[WARN] providercache.SchemaCache: ignoring invalid entry %s: %s", c.entryPath(key), err)
+		return providers.ProviderSchema{}, false
+	}
+	log.Printf("[TRACE] providercache.SchemaCache: loaded schema from %s", c.entryPath(key))
+	return schema, true
+}
+
+// Store saves the given schema with the given key, unless there's already
+// an entry with that key.
+//
+// Schemas that have any diagnostics are not stored, because diagnostics
+// cannot be faithfully reproduced from the cache.
+func (c *SchemaCache) Store(key string, schema providers.ProviderSchema) error {
+	if key == "" || len(schema.Diagnostics) != 0 {
+		return nil
+	}
+	path := c.entryPath(key)
+	if _, err := os.Stat(path); err == nil {
+		return nil // entries are immutable, so there's nothing to do
+	}
+
+	if err := os.MkdirAll(c.baseDir, 0755); err != nil {
+		return fmt.Errorf("failed to create schema cache directory: %w", err)
+	}
+	// We write to a temporary file first and then rename it into place, so
+	// that a concurrent Load can never observe a partially-written entry.
+	f, err := os.CreateTemp(c.baseDir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create schema cache entry: %w", err)
+	}
+	tmpPath := f.Name()
+	err = writeCachedSchema(f, schema)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write schema cache entry: %w", err)
+	}
+	log.Printf("[TRACE] providercache.SchemaCache: stored schema in %s", path)
+	return nil
+}
+
+func (c *SchemaCache) entryPath(key string) string {
+	return filepath.Join(c.baseDir, key+".json")
+}
+
+// The following types are the JSON serialization of a provider schema in a
+// cache entry. We use dedicated types, rather than serializing the
+// providers and configschema types directly, so that changes to those types
+// can't silently change the cache format.
+
+type cachedProviderSchema struct {
+	Provider           cachedSchema                  `json:"provider"`
+	ProviderMeta       cachedSchema                  `json:"provider_meta"`
+	ResourceTypes      map[string]cachedSchema       `json:"resource_types,omitempty"`
+	DataSources        map[string]cachedSchema       `json:"data_sources,omitempty"`
+	EphemeralResources map[string]cachedSchema       `json:"ephemeral_resources,omitempty"`
+	Functions          map[string]cachedFunctionSpec `json:"functions,omitempty"`
+	PlanDestroy        bool                          `json:"plan_destroy,omitempty"`
+	SchemaOptional     bool                          `json:"get_provider_schema_optional,omitempty"`
+}
+
+type cachedSchema struct {
+	Version               int64         `json:"version,omitempty"`
+	Block                 *cachedBlock  `json:"block,omitempty"`
+	IdentitySchemaVersion int64         `json:"identity_version,omitempty"`
+	IdentitySchema        *cachedObject `json:"identity,omitempty"`
+}
+
+type cachedBlock struct {
+	Attributes         map[string]*cachedAttribute   `json:"attributes,omitempty"`
+	BlockTypes         map[string]*cachedNestedBlock `json:"block_types,omitempty"`
+	Description        string                        `json:"description,omitempty"`
+	DescriptionKind    configschema.StringKind       `json:"description_kind,omitempty"`
+	Deprecated         bool                          `json:"deprecated,omitempty"`
+	DeprecationMessage string                        `json:"deprecation_message,omitempty"`
+	Ephemeral          bool                          `json:"ephemeral,omitempty"`
+}
+
+type cachedNestedBlock struct {
+	cachedBlock
+	Nesting  configschema.NestingMode `json:"nesting"`
+	MinItems int                      `json:"min_items,omitempty"`
+	MaxItems int                      `json:"max_items,omitempty"`
+}
+
+type cachedAttribute struct {
+	// Type is nil when the attribute has NestedType instead, because
+	// cty.NilType has no JSON representation.
+	Type               *cty.Type               `json:"type,omitempty"`
+	NestedType         *cachedObject           `json:"nested_type,omitempty"`
+	Description        string                  `json:"description,omitempty"`
+	DescriptionKind    configschema.StringKind `json:"description_kind,omitempty"`
+	Required           bool                    `json:"required,omitempty"`
+	Optional           bool                    `json:"optional,omitempty"`
+	Computed           bool                    `json:"computed,omitempty"`
+	Sensitive          bool                    `json:"sensitive,omitempty"`
+	Deprecated         bool                    `json:"deprecated,omitempty"`
+	DeprecationMessage string                  `json:"deprecation_message,omitempty"`
+	WriteOnly          bool                    `json:"write_only,omitempty"`
+}
+
+type cachedObject struct {
+	Attributes map[string]*cachedAttribute `json:"attributes,omitempty"`
+	Nesting    configschema.NestingMode    `json:"nesting"`
+}
+
+type cachedFunctionSpec struct {
+	Parameters         []cachedFunctionParameterSpec `json:"parameters,omitempty"`
+	VariadicParameter  *cachedFunctionParameterSpec  `json:"variadic_parameter,omitempty"`
+	Return             cty.Type                      `json:"return"`
+	Summary            string                        `json:"summary,omitempty"`
+	Description        string                        `json:"description,omitempty"`
+	DescriptionFormat  providers.TextFormatting      `json:"description_format,omitempty"`
+	DeprecationMessage string                        `json:"deprecation_message,omitempty"`
+}
+
+type cachedFunctionParameterSpec struct {
+	Name               string                   `json:"name"`
+	Type               cty.Type                 `json:"type"`
+	AllowNullValue     bool                     `json:"allow_null_value,omitempty"`
+	AllowUnknownValues bool                     `json:"allow_unknown_values,omitempty"`
+	Description        string                   `json:"description,omitempty"`
+	DescriptionFormat  providers.TextFormatting `json:"description_format,omitempty"`
+}
+
+func writeCachedSchema(w io.Writer, schema providers.ProviderSchema) error {
+	raw := cachedProviderSchema{
+		Provider:           cachedSchemaFrom(schema.Provider),
+		ProviderMeta:       cachedSchemaFrom(schema.ProviderMeta),
+		ResourceTypes:      cachedSchemasFrom(schema.ResourceTypes),
+		DataSources:        cachedSchemasFrom(schema.DataSources),
+		EphemeralResources: cachedSchemasFrom(schema.EphemeralResources),
+		PlanDestroy:        schema.ServerCapabilities.PlanDestroy,
+		SchemaOptional:     schema.ServerCapabilities.GetProviderSchemaOptional,
+	}
+	if len(schema.Functions) != 0 {
+		raw.Functions = make(map[string]cachedFunctionSpec, len(schema.Functions))
+		for name, fn := range schema.Functions {
+			raw.Functions[name] = cachedFunctionSpecFrom(fn)
+		}
+	}
+	return json.NewEncoder(w).Encode(raw)
+}
+
+func readCachedSchema(r io.Reader) (providers.ProviderSchema, error) {
+	var raw cachedProviderSchema
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return providers.ProviderSchema{}, err
+	}
+	ret := providers.ProviderSchema{
+		Provider:           raw.Provider.schema(),
+		ProviderMeta:       raw.ProviderMeta.schema(),
+		ResourceTypes:      schemasFromCached(raw.ResourceTypes),
+		DataSources:        schemasFromCached(raw.DataSources),
+		EphemeralResources: schemasFromCached(raw.EphemeralResources),
+		Functions:          make(map[string]providers.FunctionSpec, len(raw.Functions)),
+		ServerCapabilities: providers.ServerCapabilities{
+			PlanDestroy:               raw.PlanDestroy,
+			GetProviderSchemaOptional: raw.SchemaOptional,
+		},
+	}
+	for name, fn := range raw.Functions {
+		ret.Functions[name] = fn.functionSpec()
+	}
+	return ret, nil
+}
+
+func cachedSchemaFrom(s providers.Schema) cachedSchema {
+	return cachedSchema{
+		Version:               s.Version,
+		Block:                 cachedBlockFrom(s.Block),
+		IdentitySchemaVersion: s.IdentitySchemaVersion,
+		IdentitySchema:        cachedObjectFrom(s.IdentitySchema),
+	}
+}
+
+func (s cachedSchema) schema() providers.Schema {
+	return providers.Schema{
+		Version:               s.Version,
+		Block:                 s.Block.block(),
+		IdentitySchemaVersion: s.IdentitySchemaVersion,
+		IdentitySchema:        s.IdentitySchema.object(),
+	}
+}
+
+func cachedSchemasFrom(schemas map[string]providers.Schema) map[string]cachedSchema {
+	if len(schemas) == 0 {
+		return nil
+	}
+	ret := make(map[string]cachedSchema, len(schemas))
+	for name, s := range schemas {
+		ret[name] = cachedSchemaFrom(s)
+	}
+	return ret
+}
+
+func schemasFromCached(schemas map[string]cachedSchema) map[string]providers.Schema {
+	// The provider clients always return non-nil maps, so we do the same.
+	ret := make(map[string]providers.Schema, len(schemas))
+	for name, s := range schemas {
+		ret[name] = s.schema()
+	}
+	return ret
+}
+
+func cachedBlockFrom(b *configschema.Block) *cachedBlock {
+	if b == nil {
+		return nil
+	}
+	ret := &cachedBlock{
+		Description:        b.Description,
+		DescriptionKind:    b.DescriptionKind,
+		Deprecated:         b.Deprecated,
+		DeprecationMessage: b.DeprecationMessage,
+		Ephemeral:          b.Ephemeral,
+	}
+	if len(b.Attributes) != 0 {
+		ret.Attributes = make(map[string]*cachedAttribute, len(b.Attributes))
+		for name, a := range b.Attributes {
+			ret.Attributes[name] = cachedAttributeFrom(a)
+		}
+	}
+	if len(b.BlockTypes) != 0 {
+		ret.BlockTypes = make(map[string]*cachedNestedBlock, len(b.BlockTypes))
+		for name, nb := range b.BlockTypes {
+			ret.BlockTypes[name] = &cachedNestedBlock{
+				cachedBlock: *cachedBlockFrom(&nb.Block),
+				Nesting:     nb.Nesting,
+				MinItems:    nb.MinItems,
+				MaxItems:    nb.MaxItems,
+			}
+		}
+	}
+	return ret
+}
+
+func (b *cachedBlock) block() *configschema.Block {
+	if b == nil {
+		return nil
+	}
+	ret := &configschema.Block{
+		Attributes:         make(map[string]*configschema.Attribute, len(b.Attributes)),
+		BlockTypes:         make(map[string]*configschema.NestedBlock, len(b.BlockTypes)),
+		Description:        b.Description,
+		DescriptionKind:    b.DescriptionKind,
+		Deprecated:         b.Deprecated,
+		DeprecationMessage: b.DeprecationMessage,
+		Ephemeral:          b.Ephemeral,
+	}
+	for name, a := range b.Attributes {
+		ret.Attributes[name] = a.attribute()
+	}
+	for name, nb := range b.BlockTypes {
+		ret.BlockTypes[name] = &configschema.NestedBlock{
+			Block:    *nb.cachedBlock.block(),
+			Nesting:  nb.Nesting,
+			MinItems: nb.MinItems,
+			MaxItems: nb.MaxItems,
+		}
+	}
+	return ret
+}
+
+func cachedAttributeFrom(a *configschema.Attribute) *cachedAttribute {
+	ret := &cachedAttribute{
+		NestedType:         cachedObjectFrom(a.NestedType),
+		Description:        a.Description,
+		DescriptionKind:    a.DescriptionKind,
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Deprecated:         a.Deprecated,
+		DeprecationMessage: a.DeprecationMessage,
+		WriteOnly:          a.WriteOnly,
+	}
+	if a.Type != cty.NilType {
+		ty := a.Type
+		ret.Type = &ty
+	}
+	return ret
+}
+
+func (a *cachedAttribute) attribute() *configschema.Attribute {
+	ret := &configschema.Attribute{
+		NestedType:         a.NestedType.object(),
+		Description:        a.Description,
+		DescriptionKind:    a.DescriptionKind,
+		Required:           a.Required,
+		Optional:           a.Optional,
+		Computed:           a.Computed,
+		Sensitive:          a.Sensitive,
+		Deprecated:         a.Deprecated,
+		DeprecationMessage: a.DeprecationMessage,
+		WriteOnly:          a.WriteOnly,
+	}
+	if a.Type != nil {
+		ret.Type = *a.Type
+	}
+	return ret
+}
+
+func cachedObjectFrom(o *configschema.Object) *cachedObject {
+	if o == nil {
+		return nil
+	}
+	ret := &cachedObject{
+		Attributes: make(map[string]*cachedAttribute, len(o.Attributes)),
+		Nesting:    o.Nesting,
+	}
+	for name, a := range o.Attributes {
+		ret.Attributes[name] = cachedAttributeFrom(a)
+	}
+	return ret
+}
+
+func (o *cachedObject) object() *configschema.Object {
+	if o == nil {
+		return nil
+	}
+	ret := &configschema.Object{
+		Attributes: make(map[string]*configschema.Attribute, len(o.Attributes)),
+		Nesting:    o.Nesting,
+	}
+	for name, a := range o.Attributes {
+		ret.Attributes[name] = a.attribute()
+	}
+	return ret
+}
+
+func cachedFunctionSpecFrom(fn providers.FunctionSpec) cachedFunctionSpec {
+	ret := cachedFunctionSpec{
+		Return:             fn.Return,
+		Summary:            fn.Summary,
+		Description:        fn.Description,
+		DescriptionFormat:  fn.DescriptionFormat,
+		DeprecationMessage: fn.DeprecationMessage,
+	}
+	for _, p := range fn.Parameters {
+		ret.Parameters = append(ret.Parameters, cachedFunctionParameterSpec(p))
+	}
+	if fn.VariadicParameter != nil {
+		p := cachedFunctionParameterSpec(*fn.VariadicParameter)
+		ret.VariadicParameter = &p
+	}
+	return ret
+}
+
+func (fn cachedFunctionSpec) functionSpec() providers.FunctionSpec {
+	ret := providers.FunctionSpec{
+		Return:             fn.Return,
+		Summary:            fn.Summary,
+		Description:        fn.Description,
+		DescriptionFormat:  fn.DescriptionFormat,
+		DeprecationMessage: fn.DeprecationMessage,
+	}
+	for _, p := range fn.Parameters {
+		ret.Parameters = append(ret.Parameters, providers.FunctionParameterSpec(p))
+	}
+	if fn.VariadicParameter != nil {
+		p := providers.FunctionParameterSpec(*fn.VariadicParameter)
+		ret.VariadicParameter = &p
+	}
+	return ret
+}
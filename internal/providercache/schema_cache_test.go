@@ -0,0 +1,153 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providercache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+func TestSchemaCache(t *testing.T) {
+	provider := addrs.MustParseProviderSourceString("example.com/foo/beep")
+	v1 := getproviders.MustParseVersion("1.0.0")
+	hashes := []getproviders.Hash{
+		getproviders.MustParseHash("h1:2y06Ykj0FRneZfGCTxI9wRTori8iB7ZL5kQ6YyEnh84="),
+		getproviders.MustParseHash("zh:4e5b8cb2d47b1d7d1a8f5e6da6c1f77f0d4a2e6e94a6e8e5e4f5b2d4a8d6b4c2"),
+	}
+
+	schema := providers.ProviderSchema{
+		Provider: providers.Schema{
+			Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"region": {Type: cty.String, Optional: true, Description: "The region."},
+				},
+				BlockTypes: map[string]*configschema.NestedBlock{},
+				Ephemeral:  true,
+			},
+		},
+		ResourceTypes: map[string]providers.Schema{
+			"beep_thing": {
+				Version: 2,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id": {Type: cty.String, Computed: true},
+						"rules": {
+							NestedType: &configschema.Object{
+								Attributes: map[string]*configschema.Attribute{
+									"port": {Type: cty.Number, Required: true},
+								},
+								Nesting: configschema.NestingList,
+							},
+							Optional: true,
+						},
+						"password": {Type: cty.String, Optional: true, Sensitive: true, WriteOnly: true},
+					},
+					BlockTypes: map[string]*configschema.NestedBlock{
+						"timeouts": {
+							Block: configschema.Block{
+								Attributes: map[string]*configschema.Attribute{
+									"create": {Type: cty.String, Optional: true},
+								},
+								BlockTypes: map[string]*configschema.NestedBlock{},
+							},
+							Nesting:  configschema.NestingSingle,
+							MaxItems: 1,
+						},
+					},
+					Deprecated:         true,
+					DeprecationMessage: "Use beep_other instead.",
+				},
+				IdentitySchemaVersion: 1,
+				IdentitySchema: &configschema.Object{
+					Attributes: map[string]*configschema.Attribute{
+						"id": {Type: cty.String, Required: true},
+					},
+					Nesting: configschema.NestingSingle,
+				},
+			},
+		},
+		DataSources:        map[string]providers.Schema{},
+		EphemeralResources: map[string]providers.Schema{},
+		Functions: map[string]providers.FunctionSpec{
+			"upper": {
+				Parameters: []providers.FunctionParameterSpec{
+					{Name: "s", Type: cty.String, DescriptionFormat: providers.TextFormattingPlain},
+				},
+				VariadicParameter: &providers.FunctionParameterSpec{Name: "rest", Type: cty.List(cty.DynamicPseudoType), AllowNullValue: true},
+				Return:            cty.String,
+				Summary:           "Converts to uppercase",
+			},
+		},
+		ServerCapabilities: providers.ServerCapabilities{
+			PlanDestroy:               true,
+			GetProviderSchemaOptional: true,
+		},
+	}
+
+	cache := NewSchemaCache(filepath.Join(t.TempDir(), SchemaCacheDirName))
+	key := SchemaCacheKey(provider, v1, hashes)
+
+	if _, ok := cache.Load(key); ok {
+		t.Fatal("unexpected cache hit before storing anything")
+	}
+	if err := cache.Store(key, schema); err != nil {
+		t.Fatalf("unexpected error storing schema: %s", err)
+	}
+	got, ok := cache.Load(key)
+	if !ok {
+		t.Fatal("unexpected cache miss after storing schema")
+	}
+	if diff := cmp.Diff(schema, got, cmp.Comparer(cty.Type.Equals)); diff != "" {
+		t.Errorf("wrong schema after round-trip\n%s", diff)
+	}
+
+	t.Run("key", func(t *testing.T) {
+		if got, want := SchemaCacheKey(provider, v1, []getproviders.Hash{hashes[1], hashes[0]}), key; got != want {
+			t.Errorf("key depends on the order of hashes")
+		}
+		if got := SchemaCacheKey(provider, v1, hashes[:1]); got == key {
+			t.Errorf("key did not change when the hashes changed")
+		}
+		if got := SchemaCacheKey(provider, getproviders.MustParseVersion("1.0.1"), hashes); got == key {
+			t.Errorf("key did not change when the version changed")
+		}
+		if got := SchemaCacheKey(provider, v1, nil); got != "" {
+			t.Errorf("got key %q for a provider with no hashes; want empty", got)
+		}
+	})
+
+	t.Run("diagnostics are not stored", func(t *testing.T) {
+		otherKey := SchemaCacheKey(provider, getproviders.MustParseVersion("2.0.0"), hashes)
+		withWarnings := schema
+		withWarnings.Diagnostics = withWarnings.Diagnostics.Append(tfdiags.SimpleWarning("oops"))
+		if err := cache.Store(otherKey, withWarnings); err != nil {
+			t.Fatalf("unexpected error storing schema: %s", err)
+		}
+		if _, ok := cache.Load(otherKey); ok {
+			t.Error("unexpected cache hit for schema with diagnostics")
+		}
+	})
+
+	t.Run("corrupt entry", func(t *testing.T) {
+		otherKey := SchemaCacheKey(provider, getproviders.MustParseVersion("3.0.0"), hashes)
+		if err := os.WriteFile(cache.entryPath(otherKey), []byte("{not json"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := cache.Load(otherKey); ok {
+			t.Error("unexpected cache hit for corrupt entry")
+		}
+	})
+}
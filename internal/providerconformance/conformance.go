@@ -0,0 +1,238 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerconformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/version"
+)
+
+// The names of the checks, as recorded in [ResourceTypeReport.Checks] and
+// [Violation.Check].
+const (
+	checkSchema       = "schema"
+	checkPlanCreate   = "plan create"
+	checkUpgradeState = "upgrade state"
+	checkApplyCreate  = "apply create"
+	checkRead         = "read"
+	checkPlanNoOp     = "plan no-op"
+	checkImport       = "import"
+	checkPlanDestroy  = "plan destroy"
+	checkApplyDestroy = "apply destroy"
+)
+
+// Options controls which checks [Check] runs.
+type Options struct {
+	// ResourceTypes limits the checks to the given managed resource types.
+	// If empty, all of the provider's managed resource types are checked.
+	ResourceTypes []string
+
+	// Apply enables the checks that create a real object of each resource
+	// type, using the provider's credentials, and then read, plan, import
+	// and destroy it. Otherwise, only the checks without side effects run.
+	Apply bool
+}
+
+// Report is the result of running the checks against a provider.
+type Report struct {
+	// Violations are the violations that don't belong to a single resource
+	// type, such as problems with the provider's schema.
+	Violations []Violation
+
+	// ResourceTypes are the results for each managed resource type that was
+	// checked, in lexical order of type name.
+	ResourceTypes []ResourceTypeReport
+}
+
+// HasErrors returns true if the report contains any violation that isn't
+// just a warning.
+func (r *Report) HasErrors() bool {
+	for _, v := range r.Violations {
+		if !v.Warning {
+			return true
+		}
+	}
+	for _, rt := range r.ResourceTypes {
+		for _, v := range rt.Violations {
+			if !v.Warning {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResourceTypeReport is the result of running the checks against a single
+// managed resource type.
+type ResourceTypeReport struct {
+	TypeName string
+
+	// Checks are the names of the checks that ran, in the order they ran.
+	Checks []string
+
+	// Skipped, if not empty, explains why some of the checks could not run,
+	// such as the provider rejecting the generated configuration.
+	Skipped string
+
+	Violations []Violation
+}
+
+// Violation is a response from the provider that breaks one of the rules
+// that OpenTofu enforces on providers.
+type Violation struct {
+	// Check is the name of the check that found the violation.
+	Check string
+
+	// Message describes the violation, including the address of the
+	// attribute it concerns where relevant.
+	Message string
+
+	// Warning is set for violations that OpenTofu tolerates, such as those
+	// from providers using the legacy SDK type system. These don't fail a
+	// real run, but can still cause confusing plans.
+	Warning bool
+}
+
+// Check runs the conformance checks against the given provider, which must
+// not be configured yet.
+//
+// The returned diagnostics describe problems that prevented the checks from
+// running at all, such as the provider failing to return its schema or to
+// accept the generated provider configuration. Violations of the protocol
+// are instead reported in the returned report.
+func Check(ctx context.Context, provider providers.Interface, opts Options) (*Report, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	report := &Report{}
+
+	schemas := provider.GetProviderSchema(ctx)
+	diags = diags.Append(schemas.Diagnostics)
+	if diags.HasErrors() {
+		return report, diags
+	}
+	report.Violations = append(report.Violations, checkSchemas(schemas)...)
+
+	typeNames := make([]string, 0, len(schemas.ResourceTypes))
+	if len(opts.ResourceTypes) != 0 {
+		for _, typeName := range opts.ResourceTypes {
+			if _, ok := schemas.ResourceTypes[typeName]; !ok {
+				diags = diags.Append(tfdiags.Sourceless(
+					tfdiags.Error,
+					"Unsupported resource type",
+					fmt.Sprintf("The provider does not support a managed resource type named %q.", typeName),
+				))
+				continue
+			}
+			typeNames = append(typeNames, typeName)
+		}
+		if diags.HasErrors() {
+			return report, diags
+		}
+	} else {
+		for typeName := range schemas.ResourceTypes {
+			typeNames = append(typeNames, typeName)
+		}
+	}
+	sort.Strings(typeNames)
+
+	providerConfig := generateConfig(schemas.Provider.Block, 0)
+	validateResp := provider.ValidateProviderConfig(ctx, providers.ValidateProviderConfigRequest{
+		Config: providerConfig,
+	})
+	diags = diags.Append(validateResp.Diagnostics)
+	if !diags.HasErrors() {
+		configureResp := provider.ConfigureProvider(ctx, providers.ConfigureProviderRequest{
+			TerraformVersion: version.String(),
+			Config:           providerConfig,
+		})
+		diags = diags.Append(configureResp.Diagnostics)
+	}
+	if diags.HasErrors() {
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Failed to configure provider",
+			"The provider could not be configured with the generated configuration, which sets each required provider argument to a placeholder value and leaves the others unset. Use environment variables to set any other settings the provider needs, such as credentials.",
+		))
+		return report, diags
+	}
+
+	for _, typeName := range typeNames {
+		if ctx.Err() != nil {
+			break
+		}
+		rt, moreDiags := checkResourceType(ctx, provider, schemas, typeName, opts)
+		diags = diags.Append(moreDiags)
+		report.ResourceTypes = append(report.ResourceTypes, rt)
+	}
+
+	return report, diags
+}
+
+// checkSchemas applies the same rules as [providers.ProviderSchema.Validate],
+// but reports every problem rather than only the first one.
+func checkSchemas(schemas providers.ProviderSchema) []Violation {
+	var ret []Violation
+
+	validate := func(kind string, typeName string, schema providers.Schema) {
+		if schema.Version < 0 {
+			ret = append(ret, Violation{
+				Check:   checkSchema,
+				Message: fmt.Sprintf("GetProviderSchema returned a negative schema version for %s %q.", kind, typeName),
+			})
+		}
+		err := schema.Block.InternalValidate()
+		if err == nil {
+			return
+		}
+		errs := []error{err}
+		var multiErr *multierror.Error
+		if errors.As(err, &multiErr) {
+			errs = multiErr.Errors
+		}
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, fmt.Sprintf("GetProviderSchema returned an invalid schema for %s %q: %s.", kind, typeName, err))
+		}
+		sort.Strings(msgs)
+		for _, msg := range msgs {
+			ret = append(ret, Violation{Check: checkSchema, Message: msg})
+		}
+	}
+
+	if schemas.Provider.Version < 0 {
+		ret = append(ret, Violation{
+			Check:   checkSchema,
+			Message: "GetProviderSchema returned a negative schema version for the provider configuration.",
+		})
+	}
+	for _, typeName := range sortedKeys(schemas.ResourceTypes) {
+		validate("managed resource type", typeName, schemas.ResourceTypes[typeName])
+	}
+	for _, typeName := range sortedKeys(schemas.DataSources) {
+		validate("data resource type", typeName, schemas.DataSources[typeName])
+	}
+	for _, typeName := range sortedKeys(schemas.EphemeralResources) {
+		validate("ephemeral resource type", typeName, schemas.EphemeralResources[typeName])
+	}
+
+	return ret
+}
+
+func sortedKeys(m map[string]providers.Schema) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+	return ret
+}
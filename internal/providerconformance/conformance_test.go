@@ -0,0 +1,430 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerconformance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+	"github.com/opentofu/opentofu/internal/tofu"
+)
+
+func TestCheck_conforming(t *testing.T) {
+	p := &tofu.MockProvider{}
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		Provider: providers.Schema{
+			Block: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"region": {Type: cty.String, Required: true},
+					"token":  {Type: cty.String, Optional: true},
+				},
+			},
+		},
+		ResourceTypes: map[string]providers.Schema{
+			"test_thing": {
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id":   {Type: cty.String, Computed: true},
+						"name": {Type: cty.String, Required: true},
+						"tags": {Type: cty.Map(cty.String), Optional: true},
+					},
+					BlockTypes: map[string]*configschema.NestedBlock{
+						"rule": {
+							Nesting:  configschema.NestingList,
+							MinItems: 1,
+							Block: configschema.Block{
+								Attributes: map[string]*configschema.Attribute{
+									"port": {Type: cty.Number, Required: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ServerCapabilities: providers.ServerCapabilities{PlanDestroy: true},
+	}
+	// The mock provider sets unknown computed attributes to their zero
+	// value when applying.
+	created := cty.ObjectVal(map[string]cty.Value{
+		"id":   cty.StringVal(""),
+		"name": cty.StringVal("tofu-conformance-0"),
+		"tags": cty.NullVal(cty.Map(cty.String)),
+		"rule": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{
+				"port": cty.NumberIntVal(1),
+			}),
+		}),
+	})
+	p.ImportResourceStateFn = func(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+		return providers.ImportResourceStateResponse{
+			ImportedResources: []providers.ImportedResource{
+				{TypeName: req.TypeName, State: created},
+			},
+		}
+	}
+
+	report, diags := Check(context.Background(), p, Options{Apply: true})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	want := &Report{
+		ResourceTypes: []ResourceTypeReport{
+			{
+				TypeName: "test_thing",
+				Checks: []string{
+					checkPlanCreate,
+					checkUpgradeState,
+					checkApplyCreate,
+					checkRead,
+					checkPlanNoOp,
+					checkImport,
+					checkPlanDestroy,
+					checkApplyDestroy,
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, report); diff != "" {
+		t.Errorf("wrong report\n%s", diff)
+	}
+
+	wantConfig := cty.ObjectVal(map[string]cty.Value{
+		"region": cty.StringVal("tofu-conformance-0"),
+		"token":  cty.NullVal(cty.String),
+	})
+	if got := p.ConfigureProviderRequest.Config; !got.RawEquals(wantConfig) {
+		t.Errorf("wrong provider configuration\ngot:  %#v\nwant: %#v", got, wantConfig)
+	}
+	if got := p.ApplyResourceChangeRequest.PriorState; !got.RawEquals(created) {
+		t.Errorf("wrong object destroyed\ngot:  %#v\nwant: %#v", got, created)
+	}
+}
+
+func TestCheck_violations(t *testing.T) {
+	tests := map[string]struct {
+		setup          func(p *tofu.MockProvider)
+		apply          bool
+		wantChecks     []string
+		wantSkipped    string
+		wantViolations []Violation
+	}{
+		"plan changes configured value": {
+			setup: func(p *tofu.MockProvider) {
+				p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+					return providers.PlanResourceChangeResponse{PlannedState: testThing(cty.UnknownVal(cty.String), "other")}
+				}
+			},
+			wantChecks: []string{checkPlanCreate, checkUpgradeState},
+			wantViolations: []Violation{
+				{
+					Check:   checkPlanCreate,
+					Message: `PlanResourceChange planned an invalid value for test_thing.name: planned value cty.StringVal("other") does not match config value cty.StringVal("tofu-conformance-0").`,
+				},
+			},
+		},
+		"plan changes configured value with legacy type system": {
+			setup: func(p *tofu.MockProvider) {
+				p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+					return providers.PlanResourceChangeResponse{
+						PlannedState:     testThing(cty.UnknownVal(cty.String), "other"),
+						LegacyTypeSystem: true,
+					}
+				}
+			},
+			wantChecks: []string{checkPlanCreate, checkUpgradeState},
+			wantViolations: []Violation{
+				{
+					Check:   checkPlanCreate,
+					Message: `PlanResourceChange planned an invalid value for test_thing.name: planned value cty.StringVal("other") does not match config value cty.StringVal("tofu-conformance-0").`,
+					Warning: true,
+				},
+			},
+		},
+		"plan does not conform to schema": {
+			setup: func(p *tofu.MockProvider) {
+				p.PlanResourceChangeFn = func(req providers.PlanResourceChangeRequest) providers.PlanResourceChangeResponse {
+					return providers.PlanResourceChangeResponse{
+						PlannedState: cty.ObjectVal(map[string]cty.Value{
+							"name": cty.StringVal("tofu-conformance-0"),
+						}),
+					}
+				}
+			},
+			wantChecks: []string{checkPlanCreate},
+			wantViolations: []Violation{
+				{
+					Check:   checkPlanCreate,
+					Message: `PlanResourceChange returned a value that does not conform to the schema: test_thing: missing required attribute "id".`,
+				},
+			},
+		},
+		"configuration rejected": {
+			setup: func(p *tofu.MockProvider) {
+				p.ValidateResourceConfigFn = func(req providers.ValidateResourceConfigRequest) providers.ValidateResourceConfigResponse {
+					var diags tfdiags.Diagnostics
+					return providers.ValidateResourceConfigResponse{Diagnostics: diags.Append(errors.New("name must be a valid hostname"))}
+				}
+			},
+			wantSkipped: "The provider rejected the generated configuration: name must be a valid hostname",
+		},
+		"upgrade changes state": {
+			setup: func(p *tofu.MockProvider) {
+				p.UpgradeResourceStateFn = func(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+					return providers.UpgradeResourceStateResponse{UpgradedState: testThing(cty.StringVal("tofu-conformance-0"), "renamed")}
+				}
+			},
+			wantChecks: []string{checkPlanCreate, checkUpgradeState},
+			wantViolations: []Violation{
+				{
+					Check:   checkUpgradeState,
+					Message: `UpgradeResourceState changed a state that was already at the current schema version: test_thing.name: was cty.StringVal("tofu-conformance-0"), but now cty.StringVal("renamed").`,
+					Warning: true,
+				},
+			},
+		},
+		"upgrade returns null": {
+			setup: func(p *tofu.MockProvider) {
+				p.UpgradeResourceStateFn = func(req providers.UpgradeResourceStateRequest) providers.UpgradeResourceStateResponse {
+					return providers.UpgradeResourceStateResponse{UpgradedState: cty.NullVal(testThingType)}
+				}
+			},
+			wantChecks: []string{checkPlanCreate, checkUpgradeState},
+			wantViolations: []Violation{
+				{
+					Check:   checkUpgradeState,
+					Message: "UpgradeResourceState returned a null object for a state that was not null.",
+				},
+			},
+		},
+		"apply inconsistent with plan": {
+			setup: func(p *tofu.MockProvider) {
+				p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+					if req.PlannedState.IsNull() {
+						return providers.ApplyResourceChangeResponse{NewState: req.PlannedState}
+					}
+					return providers.ApplyResourceChangeResponse{NewState: testThing(cty.StringVal("abc"), "other")}
+				}
+			},
+			apply:      true,
+			wantChecks: []string{checkPlanCreate, checkUpgradeState, checkApplyCreate, checkRead, checkPlanNoOp, checkImport, checkApplyDestroy},
+			wantViolations: []Violation{
+				{
+					Check:   checkApplyCreate,
+					Message: `ApplyResourceChange produced an inconsistent result for test_thing.name: was cty.StringVal("tofu-conformance-0"), but now cty.StringVal("other").`,
+				},
+				{
+					Check:   checkPlanNoOp,
+					Message: `PlanResourceChange planned a change for the object just created, with the configuration it was created from: test_thing.name: was cty.StringVal("other"), but now cty.StringVal("tofu-conformance-0").`,
+					Warning: true,
+				},
+				{
+					Check:   checkImport,
+					Message: "ImportResourceState returned no objects for the ID of the object just created.",
+				},
+			},
+		},
+		"apply returns unknown values": {
+			setup: func(p *tofu.MockProvider) {
+				p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+					return providers.ApplyResourceChangeResponse{NewState: req.PlannedState}
+				}
+			},
+			apply:      true,
+			wantChecks: []string{checkPlanCreate, checkUpgradeState, checkApplyCreate, checkRead, checkPlanNoOp, checkApplyDestroy},
+			wantViolations: []Violation{
+				{
+					Check:   checkApplyCreate,
+					Message: "ApplyResourceChange returned a value that is not wholly known.",
+				},
+				{
+					// The computed attribute that was unknown is saved as
+					// null, and so becomes unknown again in the next plan.
+					Check:   checkPlanNoOp,
+					Message: "PlanResourceChange planned a change for the object just created, with the configuration it was created from: test_thing.id: was null, but now cty.UnknownVal(cty.String).",
+					Warning: true,
+				},
+			},
+		},
+		"destroy returns object": {
+			setup: func(p *tofu.MockProvider) {
+				p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+					if req.PlannedState.IsNull() {
+						return providers.ApplyResourceChangeResponse{NewState: req.PriorState}
+					}
+					return providers.ApplyResourceChangeResponse{NewState: testThing(cty.StringVal("abc"), "tofu-conformance-0")}
+				}
+			},
+			apply:      true,
+			wantChecks: []string{checkPlanCreate, checkUpgradeState, checkApplyCreate, checkRead, checkPlanNoOp, checkImport, checkApplyDestroy},
+			wantViolations: []Violation{
+				{
+					Check:   checkImport,
+					Message: "ImportResourceState returned no objects for the ID of the object just created.",
+				},
+				{
+					Check:   checkApplyDestroy,
+					Message: "ApplyResourceChange returned a non-null object for a destroy action.",
+				},
+			},
+		},
+		"import returns multiple objects": {
+			setup: func(p *tofu.MockProvider) {
+				p.ApplyResourceChangeFn = func(req providers.ApplyResourceChangeRequest) providers.ApplyResourceChangeResponse {
+					if req.PlannedState.IsNull() {
+						return providers.ApplyResourceChangeResponse{NewState: req.PlannedState}
+					}
+					return providers.ApplyResourceChangeResponse{NewState: testThing(cty.StringVal("abc"), "tofu-conformance-0")}
+				}
+				p.ImportResourceStateFn = func(req providers.ImportResourceStateRequest) providers.ImportResourceStateResponse {
+					imported := providers.ImportedResource{
+						TypeName: "test_thing",
+						State:    testThing(cty.StringVal("abc"), "tofu-conformance-0"),
+					}
+					return providers.ImportResourceStateResponse{ImportedResources: []providers.ImportedResource{imported, imported}}
+				}
+			},
+			apply:      true,
+			wantChecks: []string{checkPlanCreate, checkUpgradeState, checkApplyCreate, checkRead, checkPlanNoOp, checkImport, checkApplyDestroy},
+			wantViolations: []Violation{
+				{
+					Check:   checkImport,
+					Message: "ImportResourceState returned 2 objects, but OpenTofu only supports importing a single object.",
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := &tofu.MockProvider{}
+			p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+				ResourceTypes: map[string]providers.Schema{
+					"test_thing": {Block: testThingSchema},
+				},
+			}
+			tc.setup(p)
+
+			report, diags := Check(context.Background(), p, Options{Apply: tc.apply})
+			if diags.HasErrors() {
+				t.Fatalf("unexpected errors: %s", diags.Err())
+			}
+
+			want := &Report{
+				ResourceTypes: []ResourceTypeReport{
+					{
+						TypeName:   "test_thing",
+						Checks:     tc.wantChecks,
+						Skipped:    tc.wantSkipped,
+						Violations: tc.wantViolations,
+					},
+				},
+			}
+			if diff := cmp.Diff(want, report); diff != "" {
+				t.Errorf("wrong report\n%s", diff)
+			}
+			if got, want := report.HasErrors(), hasErrors(tc.wantViolations); got != want {
+				t.Errorf("wrong HasErrors result %t; want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestCheck_schema(t *testing.T) {
+	p := &tofu.MockProvider{}
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"test_thing": {
+				Version: -1,
+				Block: &configschema.Block{
+					Attributes: map[string]*configschema.Attribute{
+						"id": {Type: cty.String},
+					},
+				},
+			},
+		},
+	}
+
+	report, diags := Check(context.Background(), p, Options{})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	want := &Report{
+		Violations: []Violation{
+			{
+				Check:   checkSchema,
+				Message: `GetProviderSchema returned a negative schema version for managed resource type "test_thing".`,
+			},
+			{
+				Check:   checkSchema,
+				Message: `GetProviderSchema returned an invalid schema for managed resource type "test_thing": id: must set Optional, Required or Computed.`,
+			},
+		},
+		ResourceTypes: []ResourceTypeReport{
+			{
+				TypeName: "test_thing",
+				Skipped:  "The resource type's schema is invalid.",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, report); diff != "" {
+		t.Errorf("wrong report\n%s", diff)
+	}
+}
+
+func TestCheck_unsupportedResourceType(t *testing.T) {
+	p := &tofu.MockProvider{}
+	p.GetProviderSchemaResponse = &providers.GetProviderSchemaResponse{
+		ResourceTypes: map[string]providers.Schema{
+			"test_thing": {Block: testThingSchema},
+		},
+	}
+
+	_, diags := Check(context.Background(), p, Options{ResourceTypes: []string{"test_other"}})
+	if !diags.HasErrors() {
+		t.Fatal("succeeded; want error")
+	}
+	if got, want := diags.Err().Error(), `Unsupported resource type: The provider does not support a managed resource type named "test_other".`; got != want {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+	if p.ConfigureProviderCalled {
+		t.Error("provider was configured; want it not to be")
+	}
+}
+
+var testThingSchema = &configschema.Block{
+	Attributes: map[string]*configschema.Attribute{
+		"id":   {Type: cty.String, Computed: true},
+		"name": {Type: cty.String, Required: true},
+	},
+}
+
+var testThingType = testThingSchema.ImpliedType()
+
+func testThing(id cty.Value, name string) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"id":   id,
+		"name": cty.StringVal(name),
+	})
+}
+
+func hasErrors(violations []Violation) bool {
+	for _, v := range violations {
+		if !v.Warning {
+			return true
+		}
+	}
+	return false
+}
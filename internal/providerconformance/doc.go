@@ -0,0 +1,28 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerconformance implements "tofu providers conformance", which
+// drives a provider through the same sequence of protocol calls that
+// OpenTofu makes during validate, plan and apply, and reports each response
+// that breaks the rules OpenTofu enforces on providers.
+//
+// Those rules are normally only checked while running a real configuration,
+// where a violation appears as an error about an "inconsistent result" or
+// an "invalid plan" for one resource instance, after the earlier steps have
+// already succeeded. The checks in this package instead run against a
+// configuration generated from each resource type's schema, which sets only
+// the required arguments, so that a provider developer can find the
+// violations without writing a configuration for every resource type.
+//
+// The checks use the same functions as the language runtime, such as
+// [objchange.AssertPlanValid] and [objchange.AssertObjectCompatible], so
+// that a provider which passes them won't be rejected by OpenTofu for the
+// same reason. Some checks are only tolerated for providers using the legacy
+// SDK type system, as OpenTofu does; those are reported as warnings.
+//
+// By default the checks only use calls that have no side effects. The
+// checks that create, read, import and destroy a real object of each
+// resource type only run when [Options.Apply] is set.
+package providerconformance
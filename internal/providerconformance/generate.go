@@ -0,0 +1,170 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerconformance
+
+import (
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+// generateConfig returns a configuration value for the given schema that
+// sets each required argument to a value from generateValue and includes the
+// minimum number of each nested block type, leaving everything else unset,
+// in the same shape as the language runtime would decode it from a
+// configuration body.
+//
+// The seed is used to make the values distinct from those generated for
+// other elements of the same collection, since otherwise the elements of a
+// set would coalesce.
+func generateConfig(schema *configschema.Block, seed int) cty.Value {
+	if schema == nil {
+		return cty.EmptyObjectVal
+	}
+
+	ty := schema.ImpliedType()
+	vals := make(map[string]cty.Value, len(schema.Attributes)+len(schema.BlockTypes))
+	for name, attrS := range schema.Attributes {
+		vals[name] = generateAttribute(attrS, ty.AttributeType(name), seed)
+	}
+	for name, blockS := range schema.BlockTypes {
+		vals[name] = generateNestedBlocks(blockS, ty.AttributeType(name), seed)
+	}
+	return cty.ObjectVal(vals)
+}
+
+func generateAttribute(attrS *configschema.Attribute, ty cty.Type, seed int) cty.Value {
+	if !attrS.Required {
+		return cty.NullVal(ty)
+	}
+	if attrS.NestedType == nil {
+		return generateValue(ty, seed)
+	}
+
+	obj := attrS.NestedType
+	if obj.Nesting == configschema.NestingSingle || obj.Nesting == configschema.NestingGroup {
+		return generateNestedObject(obj, ty, seed)
+	}
+	return collectionVal(ty, []cty.Value{generateNestedObject(obj, ty.ElementType(), seed)})
+}
+
+func generateNestedObject(obj *configschema.Object, ty cty.Type, seed int) cty.Value {
+	vals := make(map[string]cty.Value, len(obj.Attributes))
+	for name, attrS := range obj.Attributes {
+		vals[name] = generateAttribute(attrS, ty.AttributeType(name), seed)
+	}
+	return cty.ObjectVal(vals)
+}
+
+func generateNestedBlocks(blockS *configschema.NestedBlock, ty cty.Type, seed int) cty.Value {
+	switch blockS.Nesting {
+	case configschema.NestingSingle:
+		if blockS.MinItems == 0 {
+			return cty.NullVal(ty)
+		}
+		return generateConfig(&blockS.Block, seed)
+	case configschema.NestingGroup:
+		// Group blocks are never null, even when absent from the
+		// configuration.
+		return generateConfig(&blockS.Block, seed)
+	default:
+		if ty == cty.DynamicPseudoType {
+			// Nested blocks containing dynamically-typed attributes are
+			// decoded as a tuple or object instead of a list or map, since
+			// each block may have a different type.
+			ty = cty.EmptyTuple
+			if blockS.Nesting == configschema.NestingMap {
+				ty = cty.EmptyObject
+			}
+		}
+		elems := make([]cty.Value, blockS.MinItems)
+		for i := range elems {
+			elems[i] = generateConfig(&blockS.Block, seed+i)
+		}
+		return collectionVal(ty, elems)
+	}
+}
+
+// generateValue returns a placeholder value of the given type, which is a
+// value with a single element for collection types.
+func generateValue(ty cty.Type, seed int) cty.Value {
+	ty = ty.WithoutOptionalAttributesDeep()
+	switch {
+	case ty == cty.String || ty == cty.DynamicPseudoType:
+		return cty.StringVal(fmt.Sprintf("tofu-conformance-%d", seed))
+	case ty == cty.Number:
+		return cty.NumberIntVal(int64(seed + 1))
+	case ty == cty.Bool:
+		return cty.True
+	case ty.IsListType() || ty.IsSetType() || ty.IsMapType():
+		return collectionVal(ty, []cty.Value{generateValue(ty.ElementType(), seed)})
+	case ty.IsObjectType():
+		vals := make(map[string]cty.Value, len(ty.AttributeTypes()))
+		for name, aty := range ty.AttributeTypes() {
+			vals[name] = generateValue(aty, seed)
+		}
+		return cty.ObjectVal(vals)
+	case ty.IsTupleType():
+		elems := make([]cty.Value, len(ty.TupleElementTypes()))
+		for i, ety := range ty.TupleElementTypes() {
+			elems[i] = generateValue(ety, seed)
+		}
+		return cty.TupleVal(elems)
+	default:
+		// Capsule types can't be written in a configuration, so the only
+		// value we can use for them is null.
+		return cty.NullVal(ty)
+	}
+}
+
+// collectionVal returns a value of the given collection type containing the
+// given elements. Maps and objects use the keys "key0", "key1", and so on,
+// and only the kind of the tuple and object types is used.
+func collectionVal(ty cty.Type, elems []cty.Value) cty.Value {
+	switch {
+	case ty.IsListType():
+		if len(elems) == 0 {
+			return cty.ListValEmpty(ty.ElementType())
+		}
+		return cty.ListVal(elems)
+	case ty.IsSetType():
+		if len(elems) == 0 {
+			return cty.SetValEmpty(ty.ElementType())
+		}
+		return cty.SetVal(elems)
+	case ty.IsMapType(), ty.IsObjectType():
+		vals := make(map[string]cty.Value, len(elems))
+		for i, elem := range elems {
+			vals[fmt.Sprintf("key%d", i)] = elem
+		}
+		if ty.IsObjectType() {
+			return cty.ObjectVal(vals)
+		}
+		if len(vals) == 0 {
+			return cty.MapValEmpty(ty.ElementType())
+		}
+		return cty.MapVal(vals)
+	case ty.IsTupleType():
+		return cty.TupleVal(elems)
+	default:
+		return cty.NullVal(ty)
+	}
+}
+
+// knownValue returns the given value with any unknown values replaced by
+// placeholder values of the same type, to produce something that a provider
+// could have saved in the state.
+func knownValue(val cty.Value) cty.Value {
+	ret, _ := cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.IsKnown() {
+			return generateValue(v.Type(), 0), nil
+		}
+		return v, nil
+	})
+	return ret
+}
@@ -0,0 +1,138 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerconformance
+
+import (
+	"testing"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+)
+
+func TestGenerateConfig(t *testing.T) {
+	portBlock := configschema.Block{
+		Attributes: map[string]*configschema.Attribute{
+			"port": {Type: cty.Number, Required: true},
+		},
+	}
+	portType := portBlock.ImpliedType()
+
+	tests := map[string]struct {
+		schema *configschema.Block
+		want   cty.Value
+	}{
+		"nil": {
+			schema: nil,
+			want:   cty.EmptyObjectVal,
+		},
+		"attributes": {
+			schema: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"name":    {Type: cty.String, Required: true},
+					"count":   {Type: cty.Number, Optional: true},
+					"enabled": {Type: cty.Bool, Computed: true},
+					"zones":   {Type: cty.List(cty.String), Required: true},
+					"labels":  {Type: cty.Map(cty.Bool), Required: true},
+				},
+			},
+			want: cty.ObjectVal(map[string]cty.Value{
+				"name":    cty.StringVal("tofu-conformance-0"),
+				"count":   cty.NullVal(cty.Number),
+				"enabled": cty.NullVal(cty.Bool),
+				"zones":   cty.ListVal([]cty.Value{cty.StringVal("tofu-conformance-0")}),
+				"labels":  cty.MapVal(map[string]cty.Value{"key0": cty.True}),
+			}),
+		},
+		"nested attributes": {
+			schema: &configschema.Block{
+				Attributes: map[string]*configschema.Attribute{
+					"rules": {
+						Required: true,
+						NestedType: &configschema.Object{
+							Nesting: configschema.NestingList,
+							Attributes: map[string]*configschema.Attribute{
+								"port":  {Type: cty.Number, Required: true},
+								"label": {Type: cty.String, Optional: true},
+							},
+						},
+					},
+					"settings": {
+						Optional: true,
+						NestedType: &configschema.Object{
+							Nesting: configschema.NestingSingle,
+							Attributes: map[string]*configschema.Attribute{
+								"mode": {Type: cty.String, Required: true},
+							},
+						},
+					},
+				},
+			},
+			want: cty.ObjectVal(map[string]cty.Value{
+				"rules": cty.ListVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{
+						"port":  cty.NumberIntVal(1),
+						"label": cty.NullVal(cty.String),
+					}),
+				}),
+				"settings": cty.NullVal(cty.Object(map[string]cty.Type{
+					"mode": cty.String,
+				})),
+			}),
+		},
+		"nested blocks": {
+			schema: &configschema.Block{
+				BlockTypes: map[string]*configschema.NestedBlock{
+					"optional_single": {Nesting: configschema.NestingSingle, Block: portBlock},
+					"required_single": {Nesting: configschema.NestingSingle, Block: portBlock, MinItems: 1, MaxItems: 1},
+					"group":           {Nesting: configschema.NestingGroup, Block: portBlock},
+					"list":            {Nesting: configschema.NestingList, Block: portBlock},
+					"set":             {Nesting: configschema.NestingSet, Block: portBlock, MinItems: 2},
+					"map":             {Nesting: configschema.NestingMap, Block: portBlock},
+				},
+			},
+			want: cty.ObjectVal(map[string]cty.Value{
+				"optional_single": cty.NullVal(portType),
+				"required_single": cty.ObjectVal(map[string]cty.Value{"port": cty.NumberIntVal(1)}),
+				"group":           cty.ObjectVal(map[string]cty.Value{"port": cty.NumberIntVal(1)}),
+				"list":            cty.ListValEmpty(portType),
+				"set": cty.SetVal([]cty.Value{
+					cty.ObjectVal(map[string]cty.Value{"port": cty.NumberIntVal(1)}),
+					cty.ObjectVal(map[string]cty.Value{"port": cty.NumberIntVal(2)}),
+				}),
+				"map": cty.MapValEmpty(portType),
+			}),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := generateConfig(tc.schema, 0)
+			if !got.RawEquals(tc.want) {
+				t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKnownValue(t *testing.T) {
+	val := cty.ObjectVal(map[string]cty.Value{
+		"id":    cty.UnknownVal(cty.String),
+		"name":  cty.StringVal("example"),
+		"ports": cty.UnknownVal(cty.List(cty.Number)),
+		"tags":  cty.MapVal(map[string]cty.Value{"env": cty.UnknownVal(cty.String)}),
+	})
+	want := cty.ObjectVal(map[string]cty.Value{
+		"id":    cty.StringVal("tofu-conformance-0"),
+		"name":  cty.StringVal("example"),
+		"ports": cty.ListVal([]cty.Value{cty.NumberIntVal(1)}),
+		"tags":  cty.MapVal(map[string]cty.Value{"env": cty.StringVal("tofu-conformance-0")}),
+	})
+
+	if got := knownValue(val); !got.RawEquals(want) {
+		t.Errorf("wrong result\ngot:  %#v\nwant: %#v", got, want)
+	}
+}
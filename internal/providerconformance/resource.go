@@ -0,0 +1,472 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerconformance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/opentofu/opentofu/internal/configs/configschema"
+	"github.com/opentofu/opentofu/internal/plans/objchange"
+	"github.com/opentofu/opentofu/internal/providers"
+	"github.com/opentofu/opentofu/internal/tfdiags"
+)
+
+// resourceTypeChecker runs the checks for a single managed resource type,
+// recording the results in its report.
+type resourceTypeChecker struct {
+	provider     providers.Interface
+	typeName     string
+	schema       *configschema.Block
+	version      int64
+	nullIdentity cty.Value
+	planDestroy  bool
+
+	report *ResourceTypeReport
+}
+
+// object is a remote object created by the checks, along with the data that
+// the provider returned for it alongside its state.
+type object struct {
+	state    cty.Value
+	private  []byte
+	identity cty.Value
+}
+
+// checkResourceType runs the checks for the given managed resource type,
+// making the same sequence of calls that OpenTofu makes to plan the creation
+// of an object with the generated configuration and, if opts.Apply is set,
+// to create, refresh, import and then destroy that object.
+//
+// The returned diagnostics are warnings about objects that could not be
+// destroyed after they were created.
+func checkResourceType(ctx context.Context, provider providers.Interface, schemas providers.ProviderSchema, typeName string, opts Options) (ResourceTypeReport, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	report := ResourceTypeReport{TypeName: typeName}
+
+	schema := schemas.ResourceTypes[typeName]
+	if err := schema.Block.InternalValidate(); err != nil {
+		// The problems with the schema were already reported by checkSchemas.
+		report.Skipped = "The resource type's schema is invalid."
+		return report, diags
+	}
+
+	c := &resourceTypeChecker{
+		provider:     provider,
+		typeName:     typeName,
+		schema:       schema.Block,
+		version:      schema.Version,
+		nullIdentity: cty.NullVal(cty.DynamicPseudoType),
+		planDestroy:  schemas.ServerCapabilities.PlanDestroy,
+		report:       &report,
+	}
+	if schema.IdentitySchema != nil {
+		c.nullIdentity = cty.NullVal(schema.IdentitySchema.ImpliedType())
+	}
+
+	config := generateConfig(c.schema, 0)
+	validateResp := provider.ValidateResourceConfig(ctx, providers.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   config,
+	})
+	if validateResp.Diagnostics.HasErrors() {
+		report.Skipped = fmt.Sprintf("The provider rejected the generated configuration: %s", validateResp.Diagnostics.Err())
+		return report, diags
+	}
+
+	planned, planResp, ok := c.planCreate(ctx, config)
+	if !ok {
+		return report, diags
+	}
+	c.upgradeState(ctx, knownValue(planned))
+	if !opts.Apply {
+		return report, diags
+	}
+
+	created, ok := c.applyCreate(ctx, config, planned, planResp)
+	if !ok {
+		if !created.state.IsNull() {
+			diags = diags.Append(c.notDestroyed("ApplyResourceChange returned an invalid state for it."))
+		}
+		return report, diags
+	}
+	if report.Skipped == "" {
+		if current, ok := c.read(ctx, created); ok {
+			c.planNoOp(ctx, config, current)
+			c.importState(ctx, current)
+			created = current
+		}
+	}
+	diags = diags.Append(c.destroy(ctx, created))
+
+	return report, diags
+}
+
+// planCreate checks the plan to create an object with the given
+// configuration, returning the planned state and whether the remaining
+// checks can use it.
+func (c *resourceTypeChecker) planCreate(ctx context.Context, config cty.Value) (cty.Value, providers.PlanResourceChangeResponse, bool) {
+	c.report.Checks = append(c.report.Checks, checkPlanCreate)
+
+	prior := cty.NullVal(c.schema.ImpliedType())
+	resp := c.provider.PlanResourceChange(ctx, providers.PlanResourceChangeRequest{
+		TypeName:         c.typeName,
+		PriorState:       prior,
+		ProposedNewState: objchange.ProposedNew(c.schema, prior, config),
+		Config:           config,
+		ProviderMeta:     cty.NullVal(cty.DynamicPseudoType),
+		PriorIdentity:    c.nullIdentity,
+	})
+	if resp.Diagnostics.HasErrors() {
+		c.report.Skipped = fmt.Sprintf("The provider could not plan the generated configuration: %s", resp.Diagnostics.Err())
+		return cty.NilVal, resp, false
+	}
+	if !c.checkPlan(checkPlanCreate, prior, config, resp) {
+		return cty.NilVal, resp, false
+	}
+	if resp.PlannedState.IsNull() {
+		// AssertPlanValid has already reported this, since the
+		// configuration isn't null.
+		return cty.NilVal, resp, false
+	}
+	return resp.PlannedState, resp, true
+}
+
+// checkPlan applies the rules that OpenTofu enforces on the response to
+// PlanResourceChange, returning false if the planned state doesn't conform
+// to the schema and so can't be used any further.
+func (c *resourceTypeChecker) checkPlan(check string, prior, config cty.Value, resp providers.PlanResourceChangeResponse) bool {
+	planned := resp.PlannedState
+	if planned == cty.NilVal {
+		c.violation(check, false, "PlanResourceChange returned no planned state.")
+		return false
+	}
+	if !c.conforms(check, "PlanResourceChange", planned) {
+		return false
+	}
+	for _, err := range objchange.AssertPlanValid(c.schema, prior, config, planned) {
+		c.violation(check, resp.LegacyTypeSystem, "PlanResourceChange planned an invalid value for %s.", tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+	return true
+}
+
+// upgradeState checks that UpgradeResourceState accepts the given state at
+// the current schema version, as OpenTofu sends at the start of every plan,
+// and returns an equivalent state.
+func (c *resourceTypeChecker) upgradeState(ctx context.Context, state cty.Value) {
+	c.report.Checks = append(c.report.Checks, checkUpgradeState)
+
+	raw, err := ctyjson.Marshal(state, c.schema.ImpliedType())
+	if err != nil {
+		// Should not happen, since the planned state conforms to the schema.
+		c.violation(checkUpgradeState, false, "Failed to encode the planned state as JSON: %s.", err)
+		return
+	}
+	resp := c.provider.UpgradeResourceState(ctx, providers.UpgradeResourceStateRequest{
+		TypeName:     c.typeName,
+		Version:      c.version,
+		RawStateJSON: raw,
+	})
+	if resp.Diagnostics.HasErrors() {
+		c.violation(checkUpgradeState, false, "UpgradeResourceState returned errors for a state at the current schema version %d: %s", c.version, resp.Diagnostics.Err())
+		return
+	}
+
+	upgraded := resp.UpgradedState
+	if upgraded.IsNull() {
+		c.violation(checkUpgradeState, false, "UpgradeResourceState returned a null object for a state that was not null.")
+		return
+	}
+	if !c.conforms(checkUpgradeState, "UpgradeResourceState", upgraded) {
+		return
+	}
+	if !upgraded.IsWhollyKnown() {
+		c.violation(checkUpgradeState, false, "UpgradeResourceState returned a state that is not wholly known.")
+		return
+	}
+	for _, err := range objchange.AssertObjectCompatible(c.schema, state, upgraded) {
+		c.violation(checkUpgradeState, true, "UpgradeResourceState changed a state that was already at the current schema version: %s.", tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+}
+
+// applyCreate creates an object from the given plan, returning the new
+// object and whether it can be used by the remaining checks.
+//
+// If the provider returns errors along with a state that conforms to the
+// schema, the object is still usable so that it can be destroyed, but the
+// report is marked as skipped.
+func (c *resourceTypeChecker) applyCreate(ctx context.Context, config, planned cty.Value, planResp providers.PlanResourceChangeResponse) (object, bool) {
+	c.report.Checks = append(c.report.Checks, checkApplyCreate)
+
+	resp := c.provider.ApplyResourceChange(ctx, providers.ApplyResourceChangeRequest{
+		TypeName:        c.typeName,
+		PriorState:      cty.NullVal(c.schema.ImpliedType()),
+		PlannedState:    planned,
+		Config:          config,
+		PlannedPrivate:  planResp.PlannedPrivate,
+		ProviderMeta:    cty.NullVal(cty.DynamicPseudoType),
+		PlannedIdentity: planResp.PlannedIdentity,
+	})
+	created := object{
+		state:    resp.NewState,
+		private:  resp.Private,
+		identity: resp.NewIdentity,
+	}
+
+	if created.state.IsNull() {
+		if resp.Diagnostics.HasErrors() {
+			c.report.Skipped = fmt.Sprintf("The provider could not create an object with the generated configuration: %s", resp.Diagnostics.Err())
+		} else {
+			c.violation(checkApplyCreate, false, "ApplyResourceChange returned a null object for a create action.")
+		}
+		return created, false
+	}
+	if !c.conforms(checkApplyCreate, "ApplyResourceChange", created.state) {
+		return created, false
+	}
+	if !created.state.IsWhollyKnown() {
+		c.violation(checkApplyCreate, false, "ApplyResourceChange returned a value that is not wholly known.")
+		// OpenTofu saves the object with the unknown values replaced by
+		// nulls, and so we do the same.
+		created.state = cty.UnknownAsNull(created.state)
+	}
+	if resp.Diagnostics.HasErrors() {
+		c.report.Skipped = fmt.Sprintf("The provider could not create an object with the generated configuration: %s", resp.Diagnostics.Err())
+		return created, true
+	}
+
+	for _, err := range objchange.AssertObjectCompatible(c.schema, planned, created.state) {
+		c.violation(checkApplyCreate, resp.LegacyTypeSystem, "ApplyResourceChange produced an inconsistent result for %s.", tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+	return created, true
+}
+
+// read refreshes the given object, returning its current state and whether
+// the remaining checks can use it.
+func (c *resourceTypeChecker) read(ctx context.Context, obj object) (object, bool) {
+	c.report.Checks = append(c.report.Checks, checkRead)
+
+	current, ok := c.readObject(ctx, checkRead, "the object just created", obj)
+	if !ok {
+		return obj, false
+	}
+	for _, err := range objchange.AssertObjectCompatible(c.schema, obj.state, current.state) {
+		c.violation(checkRead, true, "ReadResource returned a different value than ApplyResourceChange for the object just created, which appears as a change made outside of OpenTofu: %s.", tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+	return current, true
+}
+
+// readObject calls ReadResource for the given object, checking the rules
+// that apply to its response, where desc describes the object in messages.
+func (c *resourceTypeChecker) readObject(ctx context.Context, check string, desc string, obj object) (object, bool) {
+	resp := c.provider.ReadResource(ctx, providers.ReadResourceRequest{
+		TypeName:      c.typeName,
+		PriorState:    obj.state,
+		Private:       obj.private,
+		ProviderMeta:  cty.NullVal(cty.DynamicPseudoType),
+		PriorIdentity: obj.identity,
+	})
+	if resp.Diagnostics.HasErrors() {
+		c.violation(check, false, "ReadResource returned errors for %s: %s", desc, resp.Diagnostics.Err())
+		return obj, false
+	}
+	if resp.NewState.IsNull() {
+		c.violation(check, false, "ReadResource reported that %s does not exist.", desc)
+		return obj, false
+	}
+	if !c.conforms(check, "ReadResource", resp.NewState) {
+		return obj, false
+	}
+	if !resp.NewState.IsWhollyKnown() {
+		c.violation(check, false, "ReadResource returned a value for %s that is not wholly known.", desc)
+		return obj, false
+	}
+	return object{state: resp.NewState, private: resp.Private, identity: resp.NewIdentity}, true
+}
+
+// planNoOp checks that planning the given object with the configuration it
+// was created from doesn't propose any changes.
+func (c *resourceTypeChecker) planNoOp(ctx context.Context, config cty.Value, obj object) {
+	c.report.Checks = append(c.report.Checks, checkPlanNoOp)
+
+	resp := c.provider.PlanResourceChange(ctx, providers.PlanResourceChangeRequest{
+		TypeName:         c.typeName,
+		PriorState:       obj.state,
+		ProposedNewState: objchange.ProposedNew(c.schema, obj.state, config),
+		Config:           config,
+		PriorPrivate:     obj.private,
+		ProviderMeta:     cty.NullVal(cty.DynamicPseudoType),
+		PriorIdentity:    obj.identity,
+	})
+	if resp.Diagnostics.HasErrors() {
+		c.violation(checkPlanNoOp, false, "PlanResourceChange returned errors for the object just created, with the configuration it was created from: %s", resp.Diagnostics.Err())
+		return
+	}
+	if !c.checkPlan(checkPlanNoOp, obj.state, config, resp) {
+		return
+	}
+
+	planned := resp.PlannedState
+	for _, path := range resp.RequiresReplace {
+		_, priorErr := path.Apply(obj.state)
+		_, plannedErr := path.Apply(planned)
+		if priorErr != nil && plannedErr != nil {
+			c.violation(checkPlanNoOp, false, "PlanResourceChange indicated \"requires replacement\" for a non-existent attribute path %s%s.", c.typeName, tfdiags.FormatCtyPath(path))
+		}
+	}
+
+	if eq := planned.Equals(obj.state); eq.IsKnown() && eq.True() {
+		return
+	}
+	errs := objchange.AssertObjectCompatible(c.schema, obj.state, planned)
+	if len(errs) == 0 {
+		c.violation(checkPlanNoOp, true, "PlanResourceChange planned unknown values for the object just created, with the configuration it was created from.")
+	}
+	for _, err := range errs {
+		c.violation(checkPlanNoOp, true, "PlanResourceChange planned a change for the object just created, with the configuration it was created from: %s.", tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+}
+
+// importState checks that importing the given object by its "id" attribute,
+// if the resource type has one, produces the same object.
+func (c *resourceTypeChecker) importState(ctx context.Context, obj object) {
+	attrS, ok := c.schema.Attributes["id"]
+	if !ok || attrS.Type != cty.String {
+		return
+	}
+	id := obj.state.GetAttr("id")
+	if id.IsNull() {
+		return
+	}
+	c.report.Checks = append(c.report.Checks, checkImport)
+
+	resp := c.provider.ImportResourceState(ctx, providers.ImportResourceStateRequest{
+		TypeName: c.typeName,
+		Target:   providers.ImportTarget{ID: id.AsString()},
+	})
+	if resp.Diagnostics.HasErrors() {
+		c.violation(checkImport, true, "ImportResourceState returned errors for the ID of the object just created, so the resource type might not support import: %s", resp.Diagnostics.Err())
+		return
+	}
+
+	switch len(resp.ImportedResources) {
+	case 0:
+		c.violation(checkImport, false, "ImportResourceState returned no objects for the ID of the object just created.")
+		return
+	case 1:
+	default:
+		c.violation(checkImport, false, "ImportResourceState returned %d objects, but OpenTofu only supports importing a single object.", len(resp.ImportedResources))
+		return
+	}
+
+	imported := resp.ImportedResources[0]
+	switch imported.TypeName {
+	case "":
+		c.violation(checkImport, false, "ImportResourceState returned an object without a resource type name.")
+		return
+	case c.typeName:
+	default:
+		c.violation(checkImport, false, "ImportResourceState returned an object of resource type %q.", imported.TypeName)
+		return
+	}
+	if imported.State.IsNull() {
+		c.violation(checkImport, false, "ImportResourceState returned a null object.")
+		return
+	}
+	if !c.conforms(checkImport, "ImportResourceState", imported.State) {
+		return
+	}
+
+	// OpenTofu always refreshes an object right after importing it.
+	current, ok := c.readObject(ctx, checkImport, "the imported object", object{
+		state:    imported.State,
+		private:  imported.Private,
+		identity: imported.Identity,
+	})
+	if !ok {
+		return
+	}
+	for _, err := range objchange.AssertObjectCompatible(c.schema, obj.state, current.state) {
+		c.violation(checkImport, true, "The imported object differs from the object just created: %s.", tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+}
+
+// destroy destroys the given object, returning a warning if it might still
+// exist afterwards.
+func (c *resourceTypeChecker) destroy(ctx context.Context, obj object) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	null := cty.NullVal(c.schema.ImpliedType())
+
+	private := obj.private
+	if c.planDestroy {
+		c.report.Checks = append(c.report.Checks, checkPlanDestroy)
+		resp := c.provider.PlanResourceChange(ctx, providers.PlanResourceChangeRequest{
+			TypeName:         c.typeName,
+			PriorState:       obj.state,
+			ProposedNewState: null,
+			Config:           null,
+			PriorPrivate:     obj.private,
+			ProviderMeta:     cty.NullVal(cty.DynamicPseudoType),
+			PriorIdentity:    obj.identity,
+		})
+		if resp.Diagnostics.HasErrors() {
+			return diags.Append(c.notDestroyed(resp.Diagnostics.Err().Error()))
+		}
+		if !resp.PlannedState.IsNull() {
+			c.violation(checkPlanDestroy, false, "PlanResourceChange planned a non-null value for a destroy action.")
+		}
+		private = resp.PlannedPrivate
+	}
+
+	c.report.Checks = append(c.report.Checks, checkApplyDestroy)
+	resp := c.provider.ApplyResourceChange(ctx, providers.ApplyResourceChangeRequest{
+		TypeName:        c.typeName,
+		PriorState:      obj.state,
+		PlannedState:    null,
+		Config:          null,
+		PlannedPrivate:  private,
+		ProviderMeta:    cty.NullVal(cty.DynamicPseudoType),
+		PlannedIdentity: c.nullIdentity,
+	})
+	if resp.Diagnostics.HasErrors() {
+		return diags.Append(c.notDestroyed(resp.Diagnostics.Err().Error()))
+	}
+	if !resp.NewState.IsNull() {
+		c.violation(checkApplyDestroy, false, "ApplyResourceChange returned a non-null object for a destroy action.")
+	}
+	return diags
+}
+
+// notDestroyed returns a warning that an object created by the checks could
+// not be destroyed for the given reason.
+func (c *resourceTypeChecker) notDestroyed(reason string) tfdiags.Diagnostic {
+	return tfdiags.Sourceless(
+		tfdiags.Warning,
+		"Object not destroyed",
+		fmt.Sprintf("The %s object created by the conformance checks could not be destroyed, so it might need to be deleted manually: %s", c.typeName, reason),
+	)
+}
+
+// conforms checks that the given value returned by the named RPC conforms to
+// the resource type's schema.
+func (c *resourceTypeChecker) conforms(check string, rpc string, val cty.Value) bool {
+	errs := val.Type().TestConformance(c.schema.ImpliedType())
+	for _, err := range errs {
+		c.violation(check, false, "%s returned a value that does not conform to the schema: %s.", rpc, tfdiags.FormatErrorPrefixed(err, c.typeName))
+	}
+	return len(errs) == 0
+}
+
+func (c *resourceTypeChecker) violation(check string, warning bool, format string, args ...any) {
+	c.report.Violations = append(c.report.Violations, Violation{
+		Check:   check,
+		Message: fmt.Sprintf(format, args...),
+		Warning: warning,
+	})
+}
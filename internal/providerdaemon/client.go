@@ -0,0 +1,100 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdaemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+// SocketPathEnvVar is the environment variable that OpenTofu commands check
+// for the control socket path of a running provider daemon.
+const SocketPathEnvVar = "TF_PROVIDER_DAEMON"
+
+// DefaultSocketPath returns the control socket path used by
+// "tofu providers serve" when the operator doesn't choose one.
+func DefaultSocketPath() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("tofu-providers-%d.sock", os.Getpid()))
+}
+
+// Client makes requests to a provider daemon.
+type Client struct {
+	socketPath string
+	http       *http.Client
+}
+
+// NewClient returns a client for the daemon listening on the given control
+// socket path.
+func NewClient(socketPath string) *Client {
+	var dialer net.Dialer
+	return &Client{
+		socketPath: socketPath,
+		http: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", socketPath)
+				},
+			},
+			// Starting a provider process when there's no spare one ready
+			// can take some time, but shouldn't take this long.
+			Timeout: 2 * time.Minute,
+		},
+	}
+}
+
+// SocketPath returns the control socket path the client connects to.
+func (c *Client) SocketPath() string {
+	return c.socketPath
+}
+
+// Checkout asks the daemon for a running process of the described provider,
+// returning the configuration for connecting to it.
+//
+// The caller is responsible for shutting down the process once it's
+// finished with it.
+func (c *Client) Checkout(ctx context.Context, req CheckoutRequest) (*plugin.ReattachConfig, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://providerdaemon"+checkoutPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("provider daemon at %s is not available: %w", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+			return nil, fmt.Errorf("provider daemon returned unexpected status %s", resp.Status)
+		}
+		return nil, fmt.Errorf("provider daemon: %s", errResp.Error)
+	}
+	var checkout checkoutResponse
+	if err := json.NewDecoder(resp.Body).Decode(&checkout); err != nil {
+		return nil, fmt.Errorf("invalid response from provider daemon: %w", err)
+	}
+	reattach, err := checkout.Reattach.pluginConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid response from provider daemon: %w", err)
+	}
+	return reattach, nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package providerdaemon implements "tofu providers serve", a long-lived
+// local process that starts provider plugins ahead of time so that OpenTofu
+// commands can connect to an already-running process instead of waiting for
+// a new one to start.
+//
+// The daemon listens for requests on a unix socket. A client sends the
+// details of the provider package it would otherwise have run itself, and
+// the daemon responds with the information needed to connect to a running
+// process of that package using go-plugin's reattach mechanism, in the same
+// way as for providers listed in the TF_REATTACH_PROVIDERS environment
+// variable.
+package providerdaemon
@@ -0,0 +1,73 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdaemon
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Listen creates a unix socket at the given path that only the current user
+// can connect to, and returns a listener for it. It returns an error if
+// anything already exists at the path.
+//
+// The socket is first created inside a new directory that only the current
+// user can access, and its permissions are restricted before it's linked
+// into place, so that there's no window in which another user could connect
+// to it.
+//
+// Closing the returned listener removes the socket.
+func Listen(socketPath string) (net.Listener, error) {
+	// The temporary directory must be on the same filesystem as the socket
+	// path for the link to succeed, so we create it alongside. Its name is
+	// kept short because of the length limit on unix socket paths.
+	tmpDir, err := os.MkdirTemp(filepath.Dir(socketPath), ".tofu-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpPath := filepath.Join(tmpDir, "s")
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmpPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The temporary path is removed along with its directory below, so the
+	// listener must not try to remove it again when closed.
+	ln.SetUnlinkOnClose(false)
+
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	// Unlike a rename, a link never replaces a file that already exists at
+	// the given path.
+	if err := os.Link(tmpPath, socketPath); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return &socketListener{UnixListener: ln, path: socketPath}, nil
+}
+
+// socketListener is the listener returned by [Listen], which removes its
+// socket when closed.
+type socketListener struct {
+	*net.UnixListener
+	path string
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func (l *socketListener) Close() error {
+	l.closeOnce.Do(func() {
+		l.closeErr = l.UnixListener.Close()
+		_ = os.Remove(l.path)
+	})
+	return l.closeErr
+}
@@ -0,0 +1,74 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdaemon
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestListen(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the provider daemon is not supported on Windows")
+	}
+
+	// Unix socket paths have a short length limit, so we can't use
+	// t.TempDir here.
+	socketDir, err := os.MkdirTemp("", "tofu-pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(socketDir) })
+	socketPath := filepath.Join(socketDir, "control.sock")
+
+	ln, err := Listen(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		conn, err := ln.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	info, err := os.Lstat(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := info.Mode(), os.ModeSocket|0600; got != want {
+		t.Errorf("wrong mode %s; want %s", got, want)
+	}
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("can't connect to the socket: %s", err)
+	}
+	conn.Close()
+
+	// Nothing but the socket is left behind in the directory.
+	entries, err := os.ReadDir(socketDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("wrong number of directory entries %d; want 1", len(entries))
+	}
+
+	// The socket can't be replaced while it exists.
+	if _, err := Listen(socketPath); err == nil {
+		t.Error("unexpected success listening on an existing socket path")
+	}
+
+	if err := ln.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(socketPath); !os.IsNotExist(err) {
+		t.Errorf("socket still exists after closing the listener: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package providerdaemon
+
+// processExists always returns true on Windows, where the provider daemon
+// is not supported.
+func processExists(pid int) bool {
+	return true
+}
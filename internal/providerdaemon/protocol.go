@@ -0,0 +1,102 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdaemon
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/go-plugin"
+)
+
+// checkoutPath is the path of the control endpoint that a client uses to
+// ask the daemon for a running provider process.
+const checkoutPath = "/v1/checkout"
+
+// CheckoutRequest describes the provider process a client wants to use.
+type CheckoutRequest struct {
+	// Provider is the provider source address, used only for logging and
+	// for the pool key.
+	Provider string `json:"provider"`
+
+	// Version is the selected version of the provider.
+	Version string `json:"version"`
+
+	// PackageDir is the absolute path of the unpacked provider package in
+	// the client's provider cache directory.
+	PackageDir string `json:"package_dir"`
+
+	// Hashes are the checksums recorded for the provider in the client's
+	// dependency lock file. The daemon refuses to start a package that
+	// matches none of them.
+	Hashes []string `json:"hashes"`
+
+	// Dir and Env are the working directory and environment of the client,
+	// which the provider process inherits. Processes are never shared
+	// between requests whose directory or environment differ, except for
+	// the environment variables that the daemon ignores as described in
+	// the documentation of [Server].
+	Dir string   `json:"dir"`
+	Env []string `json:"env"`
+
+	// ClientPID is the process ID of the client, which the daemon uses to
+	// clean up processes whose client exited without closing them.
+	ClientPID int `json:"client_pid"`
+}
+
+// checkoutResponse is the body of a successful response to a checkout
+// request. Reattach uses the same shape as each entry in the
+// TF_REATTACH_PROVIDERS environment variable.
+type checkoutResponse struct {
+	Reattach reattachConfig `json:"reattach"`
+}
+
+type reattachConfig struct {
+	Protocol        string
+	ProtocolVersion int
+	Addr            struct {
+		Network string
+		String  string
+	}
+	Pid int
+}
+
+// errorResponse is the body of an unsuccessful response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func newReattachConfig(c *plugin.ReattachConfig) reattachConfig {
+	var ret reattachConfig
+	ret.Protocol = string(c.Protocol)
+	ret.ProtocolVersion = c.ProtocolVersion
+	ret.Addr.Network = c.Addr.Network()
+	ret.Addr.String = c.Addr.String()
+	ret.Pid = c.Pid
+	return ret
+}
+
+func (c reattachConfig) pluginConfig() (*plugin.ReattachConfig, error) {
+	var addr net.Addr
+	var err error
+	switch c.Addr.Network {
+	case "unix":
+		addr, err = net.ResolveUnixAddr("unix", c.Addr.String)
+	case "tcp":
+		addr, err = net.ResolveTCPAddr("tcp", c.Addr.String)
+	default:
+		return nil, fmt.Errorf("unknown address type %q", c.Addr.Network)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s address %q: %w", c.Addr.Network, c.Addr.String, err)
+	}
+	return &plugin.ReattachConfig{
+		Protocol:        plugin.Protocol(c.Protocol),
+		ProtocolVersion: c.ProtocolVersion,
+		Addr:            addr,
+		Pid:             c.Pid,
+	}, nil
+}
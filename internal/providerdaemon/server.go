@@ -0,0 +1,450 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdaemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/logging"
+	tfplugin "github.com/opentofu/opentofu/internal/plugin"
+	"github.com/opentofu/opentofu/internal/providercache"
+)
+
+// reapInterval is how often the server checks for processes whose client
+// has gone away and for pools that have been idle for too long.
+const reapInterval = 5 * time.Second
+
+// Server keeps provider plugin processes running so that they are ready
+// for OpenTofu commands to connect to.
+//
+// Processes are grouped into pools by provider package, working directory
+// and environment, and a process is never shared between clients whose
+// pool differs. Each working directory therefore has its own pools, because
+// a provider process resolves relative paths from the directory it started
+// in, but variables that vary between otherwise-identical commands, such as
+// OLDPWD, or that only OpenTofu uses, such as TF_VAR_ variables, don't
+// affect the pool and aren't passed on to the provider process. Each process is handed to exactly one client, which takes
+// over responsibility for shutting it down when it's finished. The server
+// therefore saves only the startup time of each process, by starting the
+// next one for a pool as soon as the previous one has been handed out.
+type Server struct {
+	// IdleTimeout is how long the server waits without any checkout
+	// requests or active processes before it shuts down. Pools that are
+	// unused for this long also have their spare processes stopped.
+	IdleTimeout time.Duration
+
+	// Warm is the number of spare processes to keep ready in each pool.
+	Warm int
+
+	// socketDir is a directory private to the server where the provider
+	// processes create their own unix sockets.
+	socketDir string
+
+	mu           sync.Mutex
+	pools        map[string]*pool
+	leases       []*lease
+	lastActivity time.Time
+}
+
+type pool struct {
+	key    string
+	req    CheckoutRequest
+	cached *providercache.CachedProvider
+
+	verified  bool
+	verifyErr error
+
+	spares   []*plugin.Client
+	starting int
+	lastUsed time.Time
+}
+
+type lease struct {
+	client    *plugin.Client
+	clientPID int
+}
+
+// NewServer creates a server whose provider processes create their sockets
+// in the given directory, which should be accessible only to the current
+// user.
+func NewServer(socketDir string) *Server {
+	return &Server{
+		IdleTimeout:  15 * time.Minute,
+		Warm:         1,
+		socketDir:    socketDir,
+		pools:        make(map[string]*pool),
+		lastActivity: time.Now(),
+	}
+}
+
+// Serve accepts checkout requests from the given listener until the given
+// context is cancelled or the server has been idle for longer than
+// IdleTimeout, and then stops all of the processes it started that it has
+// not yet handed out to a client.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+checkoutPath, s.handleCheckout)
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		ticker := time.NewTicker(reapInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.reap(time.Now()) {
+					log.Printf("[INFO] providerdaemon: shutting down after being idle for %s", s.IdleTimeout)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	err := srv.Serve(ln)
+	s.stopSpares()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *Server) handleCheckout(w http.ResponseWriter, r *http.Request) {
+	var req CheckoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("invalid request: %s", err)})
+		return
+	}
+	reattach, err := s.Checkout(req)
+	if err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, checkoutResponse{Reattach: newReattachConfig(reattach)})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// Checkout returns the reattach configuration for a running process of the
+// requested provider, starting one if there is no spare process ready.
+//
+// The caller becomes responsible for shutting down the returned process.
+func (s *Server) Checkout(req CheckoutRequest) (*plugin.ReattachConfig, error) {
+	p, err := s.pool(req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.lastActivity = time.Now()
+	p.lastUsed = s.lastActivity
+	if !p.verified {
+		p.verified = true
+		p.verifyErr = verifyPackage(p.cached, p.req.Hashes)
+	}
+	if p.verifyErr != nil {
+		s.mu.Unlock()
+		return nil, p.verifyErr
+	}
+	var client *plugin.Client
+	for len(p.spares) > 0 && client == nil {
+		client, p.spares = p.spares[0], p.spares[1:]
+		if client.Exited() {
+			client = nil
+		}
+	}
+	s.mu.Unlock()
+
+	if client == nil {
+		client, err = s.startProcess(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+	reattach := client.ReattachConfig()
+	if reattach == nil {
+		client.Kill()
+		return nil, fmt.Errorf("provider process for %s exited before it could be used", req.Provider)
+	}
+	reattach.ProtocolVersion = client.NegotiatedVersion()
+
+	s.mu.Lock()
+	s.leases = append(s.leases, &lease{client: client, clientPID: req.ClientPID})
+	s.mu.Unlock()
+	log.Printf("[DEBUG] providerdaemon: process %d for %s v%s checked out by pid %d", reattach.Pid, req.Provider, req.Version, req.ClientPID)
+
+	go s.refill(p)
+	return reattach, nil
+}
+
+// pool returns the pool for the given request, creating it if necessary.
+func (s *Server) pool(req CheckoutRequest) (*pool, error) {
+	provider, diags := addrs.ParseProviderSourceString(req.Provider)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid provider address %q: %w", req.Provider, diags.Err())
+	}
+	version, err := getproviders.ParseVersion(req.Version)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q for %s: %w", req.Version, provider, err)
+	}
+	if !filepath.IsAbs(req.PackageDir) || !filepath.IsAbs(req.Dir) {
+		return nil, fmt.Errorf("package and working directories must be absolute paths")
+	}
+	if len(req.Hashes) == 0 {
+		return nil, fmt.Errorf("no checksums given for %s v%s, so its package cannot be verified", provider, version)
+	}
+
+	// The client's process ID is not part of the pool's identity.
+	req.ClientPID = 0
+	req.Env = poolEnv(req.Env)
+	key := poolKey(req)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p, ok := s.pools[key]; ok {
+		return p, nil
+	}
+	p := &pool{
+		key: key,
+		req: req,
+		cached: &providercache.CachedProvider{
+			Provider:   provider,
+			Version:    version,
+			PackageDir: req.PackageDir,
+		},
+		lastUsed: time.Now(),
+	}
+	s.pools[key] = p
+	return p, nil
+}
+
+// ignoredEnv and ignoredEnvPrefixes describe the environment variables that
+// a shell changes from one command to the next, or that only OpenTofu itself
+// uses. Provider processes don't inherit them, so that they don't prevent
+// otherwise-identical clients from sharing processes.
+var (
+	ignoredEnv = []string{
+		"_",
+		"OLDPWD",
+		"SHLVL",
+		"TF_CLI_CONFIG_FILE",
+		"TF_DATA_DIR",
+		"TF_ENCRYPTION",
+		"TF_INPUT",
+		"TF_IN_AUTOMATION",
+		"TF_PLUGIN_CACHE_DIR",
+		"TF_PROVIDER_DAEMON",
+		"TF_REATTACH_PROVIDERS",
+		"TF_WORKSPACE",
+	}
+	ignoredEnvPrefixes = []string{
+		"TF_CLI_ARGS",
+		"TF_VAR_",
+	}
+)
+
+// poolEnv returns the given environment without the variables that are not
+// part of a pool's identity.
+func poolEnv(env []string) []string {
+	return slices.DeleteFunc(slices.Clone(env), func(entry string) bool {
+		name, _, _ := strings.Cut(entry, "=")
+		if slices.Contains(ignoredEnv, name) {
+			return true
+		}
+		return slices.ContainsFunc(ignoredEnvPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(name, prefix)
+		})
+	})
+}
+
+// poolKey returns a string that identifies all of the requests that can
+// safely share processes with the given request.
+func poolKey(req CheckoutRequest) string {
+	h := sha256.New()
+	write := func(s string) {
+		// Including each length prevents ambiguity between adjacent strings.
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	write(req.Provider)
+	write(req.Version)
+	write(req.PackageDir)
+	write(req.Dir)
+	for _, hash := range slices.Sorted(slices.Values(req.Hashes)) {
+		write(hash)
+	}
+	for _, env := range slices.Sorted(slices.Values(req.Env)) {
+		write(env)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func verifyPackage(cached *providercache.CachedProvider, hashStrs []string) error {
+	hashes := make([]getproviders.Hash, 0, len(hashStrs))
+	for _, s := range hashStrs {
+		hash, err := getproviders.ParseHash(s)
+		if err != nil {
+			return fmt.Errorf("invalid checksum %q for %s v%s: %w", s, cached.Provider, cached.Version, err)
+		}
+		hashes = append(hashes, hash)
+	}
+	matched, err := cached.MatchesAnyHash(hashes)
+	if err != nil {
+		return fmt.Errorf("failed to verify checksum of %s v%s package in %s: %w", cached.Provider, cached.Version, cached.PackageDir, err)
+	}
+	if !matched {
+		return fmt.Errorf("the package for %s v%s in %s does not match any of the given checksums", cached.Provider, cached.Version, cached.PackageDir)
+	}
+	return nil
+}
+
+func (s *Server) startProcess(p *pool) (*plugin.Client, error) {
+	execFile, err := p.cached.ExecutableFile()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(execFile)
+	cmd.Dir = p.req.Dir
+	cmd.Env = append(slices.Clone(p.req.Env), fmt.Sprintf("%s=%s", plugin.EnvUnixSocketDir, s.socketDir))
+
+	client := plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig:  tfplugin.Handshake,
+		Logger:           logging.NewProviderLogger("daemon."),
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+		Managed:          true,
+		Cmd:              cmd,
+		SkipHostEnv:      true,
+		VersionedPlugins: tfplugin.VersionedPlugins,
+	})
+	if _, err := client.Start(); err != nil {
+		client.Kill()
+		return nil, fmt.Errorf("failed to start %s v%s: %w", p.cached.Provider, p.cached.Version, err)
+	}
+	return client, nil
+}
+
+// refill starts new spare processes for the given pool until it has Warm
+// of them, counting those already being started.
+func (s *Server) refill(p *pool) {
+	for {
+		s.mu.Lock()
+		if s.pools[p.key] != p || len(p.spares)+p.starting >= s.Warm {
+			s.mu.Unlock()
+			return
+		}
+		p.starting++
+		s.mu.Unlock()
+
+		client, err := s.startProcess(p)
+
+		s.mu.Lock()
+		p.starting--
+		retired := s.pools[p.key] != p
+		if err == nil && !retired {
+			p.spares = append(p.spares, client)
+		}
+		s.mu.Unlock()
+		if err == nil && retired {
+			// The pool was removed while we were starting this process.
+			client.Kill()
+			return
+		}
+		if err != nil {
+			log.Printf("[WARN] providerdaemon: failed to start spare process: %s", err)
+			return
+		}
+	}
+}
+
+// reap forgets about processes that have exited, stops processes whose
+// client has exited without doing so itself, and stops the spare processes
+// of pools that have been unused for longer than IdleTimeout.
+//
+// It returns true if the whole server has been idle for longer than
+// IdleTimeout and so should shut down.
+func (s *Server) reap(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.leases = slices.DeleteFunc(s.leases, func(l *lease) bool {
+		if l.client.Exited() {
+			return true
+		}
+		if l.clientPID != 0 && !processExists(l.clientPID) {
+			log.Printf("[DEBUG] providerdaemon: stopping process whose client pid %d has exited", l.clientPID)
+			go l.client.Kill()
+			return true
+		}
+		return false
+	})
+	for key, p := range s.pools {
+		p.spares = slices.DeleteFunc(p.spares, (*plugin.Client).Exited)
+		if p.starting == 0 && now.Sub(p.lastUsed) > s.IdleTimeout {
+			for _, client := range p.spares {
+				go client.Kill()
+			}
+			delete(s.pools, key)
+		}
+	}
+
+	if len(s.leases) != 0 {
+		s.lastActivity = now
+		return false
+	}
+	return now.Sub(s.lastActivity) > s.IdleTimeout
+}
+
+func (s *Server) stopSpares() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var wg sync.WaitGroup
+	for key, p := range s.pools {
+		for _, client := range p.spares {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client.Kill()
+			}()
+		}
+		delete(s.pools, key)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,168 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerdaemon
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_checkoutErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the provider daemon is not supported on Windows")
+	}
+
+	// Unix socket paths have a short length limit, so we can't use
+	// t.TempDir here.
+	socketDir, err := os.MkdirTemp("", "tofu-pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(socketDir) })
+	socketPath := filepath.Join(socketDir, "control.sock")
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := NewServer(socketDir)
+	go server.Serve(t.Context(), ln)
+
+	packageDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(packageDir, "terraform-provider-beep"), []byte("not really a provider"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	valid := CheckoutRequest{
+		Provider:   "example.com/foo/beep",
+		Version:    "1.0.0",
+		PackageDir: packageDir,
+		Hashes:     []string{"h1:2y06Ykj0FRneZfGCTxI9wRTori8iB7ZL5kQ6YyEnh84="},
+		Dir:        t.TempDir(),
+		ClientPID:  os.Getpid(),
+	}
+
+	tests := map[string]struct {
+		modify  func(req *CheckoutRequest)
+		wantErr string
+	}{
+		"invalid provider": {
+			func(req *CheckoutRequest) { req.Provider = "not/a/valid/address" },
+			`invalid provider address "not/a/valid/address"`,
+		},
+		"relative package dir": {
+			func(req *CheckoutRequest) { req.PackageDir = "beep" },
+			"package and working directories must be absolute paths",
+		},
+		"no hashes": {
+			func(req *CheckoutRequest) { req.Hashes = nil },
+			"no checksums given for example.com/foo/beep v1.0.0",
+		},
+		"hash mismatch": {
+			func(req *CheckoutRequest) {},
+			"does not match any of the given checksums",
+		},
+	}
+
+	client := NewClient(socketPath)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := valid
+			test.modify(&req)
+			_, err := client.Checkout(t.Context(), req)
+			if err == nil {
+				t.Fatal("unexpected success; want error")
+			}
+			if got := err.Error(); !strings.Contains(got, test.wantErr) {
+				t.Errorf("wrong error\ngot:  %s\nwant substring: %s", got, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPoolKey(t *testing.T) {
+	base := CheckoutRequest{
+		Provider:   "example.com/foo/beep",
+		Version:    "1.0.0",
+		PackageDir: "/cache/example.com/foo/beep/1.0.0/linux_amd64",
+		Hashes:     []string{"h1:a", "zh:b"},
+		Dir:        "/work/a",
+		Env:        []string{"A=1", "B=2"},
+	}
+	key := poolKey(base)
+
+	same := base
+	same.Hashes = []string{"zh:b", "h1:a"}
+	same.Env = []string{"B=2", "A=1"}
+	if got := poolKey(same); got != key {
+		t.Errorf("key depends on the order of hashes or environment variables")
+	}
+
+	different := map[string]func(req *CheckoutRequest){
+		"dir":     func(req *CheckoutRequest) { req.Dir = "/work/b" },
+		"env":     func(req *CheckoutRequest) { req.Env = []string{"A=1", "B=3"} },
+		"version": func(req *CheckoutRequest) { req.Version = "1.0.1" },
+		"ambiguous concatenation": func(req *CheckoutRequest) {
+			req.Env = []string{"A=1B=2"}
+		},
+	}
+	for name, modify := range different {
+		req := base
+		modify(&req)
+		if got := poolKey(req); got == key {
+			t.Errorf("%s: key did not change", name)
+		}
+	}
+}
+
+func TestPoolEnv(t *testing.T) {
+	got := poolEnv([]string{
+		"HOME=/home/user",
+		"OLDPWD=/work/a",
+		"SHLVL=2",
+		"_=/usr/bin/tofu",
+		"TF_VAR_region=us-east-1",
+		"TF_CLI_ARGS_plan=-refresh=false",
+		"TF_WORKSPACE=staging",
+		"TF_LOG=debug",
+		"AWS_PROFILE=dev",
+	})
+	want := []string{
+		"HOME=/home/user",
+		"TF_LOG=debug",
+		"AWS_PROFILE=dev",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("wrong result\ngot:  %q\nwant: %q", got, want)
+	}
+}
+
+func TestServer_reapIdle(t *testing.T) {
+	server := NewServer(t.TempDir())
+	server.IdleTimeout = time.Minute
+	now := time.Now()
+	server.lastActivity = now
+	server.pools["unused"] = &pool{key: "unused", lastUsed: now.Add(-2 * time.Minute)}
+	server.pools["recent"] = &pool{key: "recent", lastUsed: now}
+
+	if server.reap(now) {
+		t.Error("server wants to shut down before the idle timeout")
+	}
+	if _, ok := server.pools["unused"]; ok {
+		t.Error("unused pool was not removed")
+	}
+	if _, ok := server.pools["recent"]; !ok {
+		t.Error("recently-used pool was removed")
+	}
+	if !server.reap(now.Add(2 * time.Minute)) {
+		t.Error("server does not want to shut down after the idle timeout")
+	}
+}
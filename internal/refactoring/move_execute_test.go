@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/states"
@@ -645,6 +646,121 @@ func TestApplyMoves(t *testing.T) {
 				`module.boo.foo.from`,
 			},
 		},
+
+		"wildcard move of resources in each module instance": {
+			[]MoveStatement{
+				testMoveStatement(t, "", "module.a[*].foo.from", "module.b[*].foo.to"),
+			},
+			states.BuildState(func(s *states.SyncState) {
+				s.SetResourceInstanceCurrent(
+					mustParseInstAddr("module.a[0].foo.from"),
+					&states.ResourceInstanceObjectSrc{
+						Status:    states.ObjectReady,
+						AttrsJSON: []byte(`{}`),
+					},
+					providerAddr,
+					addrs.NoKey,
+				)
+				s.SetResourceInstanceCurrent(
+					mustParseInstAddr(`module.a["x"].foo.from[1]`),
+					&states.ResourceInstanceObjectSrc{
+						Status:    states.ObjectReady,
+						AttrsJSON: []byte(`{}`),
+					},
+					providerAddr,
+					addrs.NoKey,
+				)
+			}),
+			MoveResults{
+				Changes: addrs.MakeMap(
+					addrs.MakeMapElem(mustParseInstAddr("module.b[0].foo.to"), MoveSuccess{
+						mustParseInstAddr("module.a[0].foo.from"),
+						mustParseInstAddr("module.b[0].foo.to"),
+						false,
+					}),
+					addrs.MakeMapElem(mustParseInstAddr(`module.b["x"].foo.to[1]`), MoveSuccess{
+						mustParseInstAddr(`module.a["x"].foo.from[1]`),
+						mustParseInstAddr(`module.b["x"].foo.to[1]`),
+						false,
+					}),
+				),
+				Blocked: emptyResults.Blocked,
+			},
+			[]string{
+				`module.b["x"].foo.to[1]`,
+				`module.b[0].foo.to`,
+			},
+		},
+
+		"wildcard move of whole resource into a module": {
+			[]MoveStatement{
+				testMoveStatement(t, "", "foo.from[*]", "module.b.foo.from[*]"),
+			},
+			states.BuildState(func(s *states.SyncState) {
+				s.SetResourceInstanceCurrent(
+					mustParseInstAddr(`foo.from["k"]`),
+					&states.ResourceInstanceObjectSrc{
+						Status:    states.ObjectReady,
+						AttrsJSON: []byte(`{}`),
+					},
+					providerAddr,
+					addrs.NoKey,
+				)
+			}),
+			MoveResults{
+				Changes: addrs.MakeMap(
+					addrs.MakeMapElem(mustParseInstAddr(`module.b.foo.from["k"]`), MoveSuccess{
+						mustParseInstAddr(`foo.from["k"]`),
+						mustParseInstAddr(`module.b.foo.from["k"]`),
+						false,
+					}),
+				),
+				Blocked: emptyResults.Blocked,
+			},
+			[]string{
+				`module.b.foo.from["k"]`,
+			},
+		},
+
+		"wildcard move of nested module calls": {
+			[]MoveStatement{
+				testMoveStatement(t, "", "module.a[*].module.c", "module.b[*].module.c"),
+			},
+			states.BuildState(func(s *states.SyncState) {
+				s.SetResourceInstanceCurrent(
+					mustParseInstAddr("module.a[1].module.c[0].foo.bar"),
+					&states.ResourceInstanceObjectSrc{
+						Status:    states.ObjectReady,
+						AttrsJSON: []byte(`{}`),
+					},
+					providerAddr,
+					addrs.NoKey,
+				)
+				s.SetResourceInstanceCurrent(
+					mustParseInstAddr("module.a[1].foo.bar"),
+					&states.ResourceInstanceObjectSrc{
+						Status:    states.ObjectReady,
+						AttrsJSON: []byte(`{}`),
+					},
+					providerAddr,
+					addrs.NoKey,
+				)
+			}),
+			MoveResults{
+				Changes: addrs.MakeMap(
+					addrs.MakeMapElem(mustParseInstAddr("module.b[1].module.c[0].foo.bar"), MoveSuccess{
+						mustParseInstAddr("module.a[1].module.c[0].foo.bar"),
+						mustParseInstAddr("module.b[1].module.c[0].foo.bar"),
+						false,
+					}),
+				),
+				Blocked: emptyResults.Blocked,
+			},
+			[]string{
+				`module.a[1].foo.bar`,
+				`module.b[1].module.c[0].foo.bar`,
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -680,18 +796,12 @@ func testMoveStatement(t *testing.T, module string, from string, to string) Move
 		moduleAddr = addrs.Module(strings.Split(module, "."))
 	}
 
-	fromTraversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(from), "from", hcl.InitialPos)
-	if hclDiags.HasErrors() {
-		t.Fatalf("invalid 'from' argument: %s", hclDiags.Error())
-	}
+	fromTraversal := testMoveTraversal(t, from, "from")
 	fromAddr, diags := addrs.ParseMoveEndpoint(fromTraversal)
 	if diags.HasErrors() {
 		t.Fatalf("invalid 'from' argument: %s", diags.Err().Error())
 	}
-	toTraversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(to), "to", hcl.InitialPos)
-	if diags.HasErrors() {
-		t.Fatalf("invalid 'to' argument: %s", hclDiags.Error())
-	}
+	toTraversal := testMoveTraversal(t, to, "to")
 	toAddr, diags := addrs.ParseMoveEndpoint(toTraversal)
 	if diags.HasErrors() {
 		t.Fatalf("invalid 'from' argument: %s", diags.Err().Error())
@@ -710,6 +820,26 @@ func testMoveStatement(t *testing.T, module string, from string, to string) Move
 	}
 }
 
+// testMoveTraversal parses the given move endpoint address as an absolute
+// traversal, additionally accepting the wildcard instance key [*] in any
+// index position.
+func testMoveTraversal(t *testing.T, src string, argName string) hcl.Traversal {
+	t.Helper()
+
+	const placeholder = "__wildcard__"
+	src = strings.ReplaceAll(src, "[*]", `["`+placeholder+`"]`)
+	traversal, hclDiags := hclsyntax.ParseTraversalAbs([]byte(src), argName, hcl.InitialPos)
+	if hclDiags.HasErrors() {
+		t.Fatalf("invalid '%s' argument: %s", argName, hclDiags.Error())
+	}
+	for i, step := range traversal {
+		if idx, ok := step.(hcl.TraverseIndex); ok && idx.Key.Type() == cty.String && idx.Key.AsString() == placeholder {
+			traversal[i] = hcl.TraverseSplat{SrcRange: idx.SrcRange}
+		}
+	}
+	return traversal
+}
+
 func allResourceInstanceAddrsInState(state *states.State) []string {
 	var ret []string
 	for _, ms := range state.Modules {
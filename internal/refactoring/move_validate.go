@@ -54,14 +54,8 @@ func ValidateMoves(stmts []MoveStatement, rootCfg *configs.Config, declaredInsts
 	stmtTo := addrs.MakeMap[addrs.AbsMoveable, AbsMoveEndpoint]()
 
 	for _, stmt := range stmts {
-		// Earlier code that constructs MoveStatement values should ensure that
-		// both stmt.From and stmt.To always belong to the same statement.
-		fromMod, _ := stmt.From.ModuleCallTraversals()
-
-		for _, fromModInst := range declaredInsts.InstancesForModule(fromMod) {
-			absFrom := stmt.From.InModuleInstance(fromModInst)
-
-			absTo := stmt.To.InModuleInstance(fromModInst)
+		for _, move := range statementAbsMoves(stmt, declaredInsts) {
+			absFrom, absTo := move.From, move.To
 
 			if addrs.Equivalent(absFrom, absTo) {
 				diags = diags.Append(&hcl.Diagnostic{
@@ -161,6 +155,56 @@ func ValidateMoves(stmts []MoveStatement, rootCfg *configs.Config, declaredInsts
 	return diags
 }
 
+// absMove is a single pair of absolute addresses that a move statement
+// moves between.
+type absMove struct {
+	From, To addrs.AbsMoveable
+}
+
+// statementAbsMoves returns the absolute addresses that the given statement
+// moves between for each of the declared instances of the module where it
+// was declared.
+//
+// For a statement with wildcard instance keys there is a separate move for
+// each declared instance of the module containing the "to" object, because
+// the "from" object is no longer declared and so can't be used to decide
+// which keys the wildcards stand for.
+func statementAbsMoves(stmt MoveStatement, declaredInsts instances.Set) []absMove {
+	var ret []absMove
+
+	// Earlier code that constructs MoveStatement values should ensure that
+	// both stmt.From and stmt.To always belong to the same statement.
+	if !stmt.To.HasWildcards() {
+		fromMod, _ := stmt.From.ModuleCallTraversals()
+		for _, fromModInst := range declaredInsts.InstancesForModule(fromMod) {
+			ret = append(ret, absMove{
+				From: stmt.From.InModuleInstance(fromModInst),
+				To:   stmt.To.InModuleInstance(fromModInst),
+			})
+		}
+		return ret
+	}
+
+	toMod, toCalls := stmt.To.ModuleCallTraversals()
+	toObjMod := make(addrs.Module, 0, len(toMod)+len(toCalls))
+	toObjMod = append(toObjMod, toMod...)
+	for _, call := range toCalls {
+		toObjMod = append(toObjMod, call.Name)
+	}
+	for _, toModInst := range declaredInsts.InstancesForModule(toObjMod) {
+		keys, ok := stmt.To.MatchWildcards(toModInst)
+		if !ok {
+			continue
+		}
+		declModInst := toModInst[:len(toMod)]
+		ret = append(ret, absMove{
+			From: stmt.From.SubstituteWildcards(keys).InModuleInstance(declModInst),
+			To:   stmt.To.SubstituteWildcards(keys).InModuleInstance(declModInst),
+		})
+	}
+	return ret
+}
+
 func validateMoveStatementGraph(g *dag.AcyclicGraph) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 	for _, cycle := range g.Cycles() {
@@ -15,7 +15,13 @@ import (
 )
 
 type RemoveStatement struct {
-	From      addrs.ConfigRemovable
+	From addrs.ConfigRemovable
+	// Instance, if not nil, narrows the statement down to a particular module
+	// instance, resource in a module instance, or resource instance within
+	// From. It's only set for "removed" blocks that use for_each, and its
+	// module steps for the module where the block was declared use wildcard
+	// keys, because the block applies to all instances of that module.
+	Instance  addrs.Targetable
 	Destroy   bool
 	DeclRange tfdiags.SourceRange
 	// Provisioners here are used only to be able to return these to the transformer that is injecting these
@@ -38,11 +44,11 @@ func FindRemoveStatements(rootCfg *configs.Config) ([]*RemoveStatement, tfdiags.
 // This function is searching in the Config for any "removed" block targeting the given resource.
 // This method shouldn't be concerned if resAddr is pointing to a "module" or a "data" block because all of
 // these will be validated way before this function is going to be called.
-func FindResourceRemovedStatement(rootCfg *configs.Config, resAddr addrs.ConfigResource) *RemoveStatement {
+func FindResourceRemovedStatement(rootCfg *configs.Config, resAddr addrs.AbsResourceInstance) *RemoveStatement {
 	rm := findRemoveStatements(rootCfg, nil)
 	// no need to call validateRemoveStatements again since these should have been validated in the plan phase
 	for _, rs := range rm {
-		if rs.From.TargetContains(resAddr) {
+		if rs.Selects(resAddr) {
 			return rs
 		}
 	}
@@ -50,17 +56,90 @@ func FindResourceRemovedStatement(rootCfg *configs.Config, resAddr addrs.ConfigR
 }
 
 // FindResourceRemovedBlockProvisioners is returning the provisioners of the RemoveStatement found by calling FindResourceRemovedStatement
-func FindResourceRemovedBlockProvisioners(rootCfg *configs.Config, resAddr addrs.ConfigResource) []*configs.Provisioner {
+func FindResourceRemovedBlockProvisioners(rootCfg *configs.Config, resAddr addrs.AbsResourceInstance) []*configs.Provisioner {
 	if rs := FindResourceRemovedStatement(rootCfg, resAddr); rs != nil {
 		return rs.Provisioners
 	}
 	return nil
 }
 
+// Selects returns true if the given resource instance is one of the objects
+// that the receiver removes.
+func (rs *RemoveStatement) Selects(addr addrs.AbsResourceInstance) bool {
+	if !rs.From.TargetContains(addr) {
+		return false
+	}
+
+	switch inst := rs.Instance.(type) {
+	case nil:
+		return true
+	case addrs.ModuleInstance:
+		return len(inst) <= len(addr.Module) && moduleInstanceMatches(inst, addr.Module[:len(inst)])
+	case addrs.AbsResource:
+		return moduleInstanceMatches(inst.Module, addr.Module) && inst.Resource == addr.Resource.Resource
+	case addrs.AbsResourceInstance:
+		return moduleInstanceMatches(inst.Module, addr.Module) && inst.Resource == addr.Resource
+	default:
+		panic(fmt.Sprintf("unhandled remove instance address type %T", inst))
+	}
+}
+
+// moduleInstanceMatches returns true if the given module instance addresses
+// are equal, treating any wildcard instance keys in pattern as matching any
+// key.
+func moduleInstanceMatches(pattern, addr addrs.ModuleInstance) bool {
+	if len(pattern) != len(addr) {
+		return false
+	}
+	for i, step := range pattern {
+		if step.IsPlaceholder() {
+			if step.Name != addr[i].Name {
+				return false
+			}
+			continue
+		}
+		if step != addr[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// absRemoveInstance returns the given relative instance address from a
+// "removed" block declared in the module at modAddr, prefixed with wildcard
+// module steps for that module.
+func absRemoveInstance(modAddr addrs.Module, rel addrs.Targetable) addrs.Targetable {
+	prefix := func(relMod addrs.ModuleInstance) addrs.ModuleInstance {
+		ret := make(addrs.ModuleInstance, 0, len(modAddr)+len(relMod))
+		for _, name := range modAddr {
+			ret = append(ret, addrs.ModuleInstanceStep{Name: name, InstanceKey: addrs.WildcardKey{addrs.UnknownKeyType}})
+		}
+		return append(ret, relMod...)
+	}
+
+	switch rel := rel.(type) {
+	case nil:
+		return nil
+	case addrs.ModuleInstance:
+		return prefix(rel)
+	case addrs.AbsResource:
+		return rel.Resource.Absolute(prefix(rel.Module))
+	case addrs.AbsResourceInstance:
+		return rel.Resource.Absolute(prefix(rel.Module))
+	default:
+		panic(fmt.Sprintf("unhandled remove instance address type %T", rel))
+	}
+}
+
 func findRemoveStatements(cfg *configs.Config, into []*RemoveStatement) []*RemoveStatement {
 	modAddr := cfg.Path
 
 	for _, rc := range cfg.Module.Removed {
+		if rc.From == nil {
+			// A removed block with for_each is only usable once it's been
+			// expanded during static evaluation of its module.
+			continue
+		}
 		var removedEndpoint *RemoveStatement
 		switch FromAddress := rc.From.RelSubject.(type) {
 		case addrs.ConfigResource:
@@ -75,7 +154,7 @@ func findRemoveStatements(cfg *configs.Config, into []*RemoveStatement) []*Remov
 				Module:   absModule,
 			}
 
-			removedEndpoint = &RemoveStatement{From: absConfigResource, Destroy: rc.Destroy, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange), Provisioners: rc.Provisioners}
+			removedEndpoint = &RemoveStatement{From: absConfigResource, Instance: absRemoveInstance(modAddr, rc.From.RelInstance), Destroy: rc.Destroy, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange), Provisioners: rc.Provisioners}
 
 		case addrs.Module:
 			// Get the absolute address of the module by appending the module config address
@@ -83,7 +162,7 @@ func findRemoveStatements(cfg *configs.Config, into []*RemoveStatement) []*Remov
 			var absModule = make(addrs.Module, 0, len(modAddr)+len(FromAddress))
 			absModule = append(absModule, modAddr...)
 			absModule = append(absModule, FromAddress...)
-			removedEndpoint = &RemoveStatement{From: absModule, Destroy: rc.Destroy, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange), Provisioners: rc.Provisioners}
+			removedEndpoint = &RemoveStatement{From: absModule, Instance: absRemoveInstance(modAddr, rc.From.RelInstance), Destroy: rc.Destroy, DeclRange: tfdiags.SourceRangeFromHCL(rc.DeclRange), Provisioners: rc.Provisioners}
 
 		default:
 			panic(fmt.Sprintf("unhandled address type %T", FromAddress))
@@ -112,6 +191,13 @@ func validateRemoveStatements(cfg *configs.Config, removeStatements []*RemoveSta
 			panic(fmt.Sprintf("incompatible Remove endpoint in %s", rs.DeclRange.ToHCL()))
 		}
 
+		if rs.Instance != nil {
+			// A statement for particular instances is valid even if the
+			// block is still in the configuration, because only the
+			// instances that are no longer declared will be removed.
+			continue
+		}
+
 		// validate that a resource/module with this address doesn't exist in the config
 		switch fromAddr := fromAddr.(type) {
 		case addrs.ConfigResource:
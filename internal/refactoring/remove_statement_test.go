@@ -136,3 +136,80 @@ func mustConfigResourceAddr(s string) addrs.ConfigResource {
 	}
 	return addr.Config()
 }
+
+func TestRemoveStatementSelects(t *testing.T) {
+	wildcard := addrs.WildcardKey{addrs.UnknownKeyType}
+	childAny := addrs.ModuleInstance{{Name: "child", InstanceKey: wildcard}}
+
+	tests := map[string]struct {
+		stmt refactoring.RemoveStatement
+		addr string
+		want bool
+	}{
+		"whole resource": {
+			stmt: refactoring.RemoveStatement{From: mustConfigResourceAddr("foo.a")},
+			addr: `foo.a["x"]`,
+			want: true,
+		},
+		"matching resource instance": {
+			stmt: refactoring.RemoveStatement{
+				From:     mustConfigResourceAddr("foo.a"),
+				Instance: mustAbsResourceInstanceAddr(`foo.a["x"]`),
+			},
+			addr: `foo.a["x"]`,
+			want: true,
+		},
+		"other resource instance": {
+			stmt: refactoring.RemoveStatement{
+				From:     mustConfigResourceAddr("foo.a"),
+				Instance: mustAbsResourceInstanceAddr(`foo.a["x"]`),
+			},
+			addr: `foo.a["y"]`,
+			want: false,
+		},
+		"resource instance in any instance of the declaring module": {
+			stmt: refactoring.RemoveStatement{
+				From: mustConfigResourceAddr("module.child.foo.a"),
+				Instance: addrs.AbsResourceInstance{
+					Module:   childAny,
+					Resource: mustAbsResourceInstanceAddr("foo.a[1]").Resource,
+				},
+			},
+			addr: `module.child["b"].foo.a[1]`,
+			want: true,
+		},
+		"module instance": {
+			stmt: refactoring.RemoveStatement{
+				From:     addrs.Module{"child"},
+				Instance: addrs.RootModuleInstance.Child("child", addrs.StringKey("a")),
+			},
+			addr: `module.child["a"].module.grandchild.foo.a`,
+			want: true,
+		},
+		"other module instance": {
+			stmt: refactoring.RemoveStatement{
+				From:     addrs.Module{"child"},
+				Instance: addrs.RootModuleInstance.Child("child", addrs.StringKey("a")),
+			},
+			addr: `module.child["b"].foo.a`,
+			want: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := test.stmt.Selects(mustAbsResourceInstanceAddr(test.addr))
+			if got != test.want {
+				t.Errorf("wrong result for %s\ngot:  %t\nwant: %t", test.addr, got, test.want)
+			}
+		})
+	}
+}
+
+func mustAbsResourceInstanceAddr(s string) addrs.AbsResourceInstance {
+	addr, diags := addrs.ParseAbsResourceInstanceStr(s)
+	if diags.HasErrors() {
+		panic(diags.Err())
+	}
+	return addr
+}
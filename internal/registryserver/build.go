@@ -0,0 +1,243 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/apparentlymart/go-versions/versions"
+
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// These are the paths, relative to the root of the output directory, where
+// Build places the documents for each of the registry services. They are
+// also what the service discovery document refers to.
+const (
+	discoveryDocumentPath = ".well-known/terraform.json"
+	providersServicePath  = "v1/providers/"
+	modulesServicePath    = "v1/modules/"
+)
+
+// BuildOptions are the settings for Build.
+type BuildOptions struct {
+	// SigningKey is the GPG key used to sign the SHA256SUMS document of each
+	// provider version. Its private key must already be decrypted.
+	//
+	// OpenTofu requires a valid signature for providers from any registry
+	// other than the default one, so this is required when the source
+	// directory contains any providers.
+	SigningKey *openpgp.Entity
+}
+
+// BuildResult describes the packages that Build published.
+type BuildResult struct {
+	Providers []BuiltProvider
+	Modules   []BuiltModule
+}
+
+// BuiltProvider is a provider version that Build published, along with the
+// platforms it has packages for.
+type BuiltProvider struct {
+	Namespace string
+	Type      string
+	Version   getproviders.Version
+	Platforms []getproviders.Platform
+}
+
+// BuiltModule is a module version that Build published.
+type BuiltModule struct {
+	Namespace string
+	Name      string
+	System    string
+	Version   versions.Version
+}
+
+// Build generates a static registry in outputDir from the provider packages
+// and module archives in sourceDir.
+//
+// The source directory uses the following layout, where either of the two
+// top-level directories may be absent:
+//
+//	providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_OS_ARCH.zip
+//	providers/NAMESPACE/TYPE/terraform-provider-TYPE_VERSION_manifest.json
+//	modules/NAMESPACE/NAME/SYSTEM/VERSION.tar.gz
+//
+// The provider package names are the same as the ones used in the official
+// provider releases. The optional manifest file declares the plugin protocol
+// versions of a provider version, which otherwise default to 5.0. Module
+// archives may also be .tgz, .tar.xz, .tar.bz2 or .zip files.
+//
+// The registry announces the latest modification time of the source files of
+// a provider version as the time the version was published, which is what
+// the minimum_release_age installation policy compares against. Tools that
+// copy the source tree should therefore preserve modification times.
+//
+// outputDir must either not exist yet or be empty, so that packages removed
+// from the source directory cannot linger in the result.
+func Build(sourceDir, outputDir string, opts BuildOptions) (*BuildResult, error) {
+	if err := checkOutputDir(outputDir); err != nil {
+		return nil, err
+	}
+
+	providers, err := findProviders(filepath.Join(sourceDir, "providers"))
+	if err != nil {
+		return nil, err
+	}
+	modules, err := findModules(filepath.Join(sourceDir, "modules"))
+	if err != nil {
+		return nil, err
+	}
+	if len(providers) == 0 && len(modules) == 0 {
+		return nil, fmt.Errorf("%s contains no provider packages or module archives", sourceDir)
+	}
+
+	var signer *signingKey
+	if len(providers) > 0 {
+		if opts.SigningKey == nil {
+			return nil, errors.New("a GPG signing key is required to publish providers")
+		}
+		signer, err = newSigningKey(opts.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = writeJSON(filepath.Join(outputDir, filepath.FromSlash(discoveryDocumentPath)), map[string]string{
+		"providers.v1": "/" + providersServicePath,
+		"modules.v1":   "/" + modulesServicePath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &BuildResult{}
+	for _, provider := range providers {
+		built, err := buildProvider(filepath.Join(outputDir, filepath.FromSlash(providersServicePath)), provider, signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish provider %s/%s: %w", provider.Namespace, provider.Type, err)
+		}
+		ret.Providers = append(ret.Providers, built...)
+	}
+	for _, module := range modules {
+		built, err := buildModule(filepath.Join(outputDir, filepath.FromSlash(modulesServicePath)), module)
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish module %s/%s/%s: %w", module.Namespace, module.Name, module.System, err)
+		}
+		ret.Modules = append(ret.Modules, built...)
+	}
+	return ret, nil
+}
+
+func checkOutputDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot use output directory: %w", err)
+	}
+	if len(entries) != 0 {
+		return fmt.Errorf("output directory %s is not empty", dir)
+	}
+	return nil
+}
+
+// sourceEntries returns the entries of the given directory of the source
+// tree, skipping hidden files such as those created by file managers.
+//
+// A directory that does not exist is treated as empty.
+func sourceEntries(dir string) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ret := entries[:0]
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		ret = append(ret, entry)
+	}
+	return ret, nil
+}
+
+// sourceSubdirs is like sourceEntries but returns only the names of the
+// entries, and fails if any of them is not a directory.
+func sourceSubdirs(dir string) ([]string, error) {
+	entries, err := sourceEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			return nil, fmt.Errorf("unexpected file %s: expected only directories here", filepath.Join(dir, entry.Name()))
+		}
+		ret = append(ret, entry.Name())
+	}
+	return ret, nil
+}
+
+// copyFile copies the file at src to dst, creating the parent directories
+// of dst if necessary, and returns the SHA256 checksum and size of the
+// content.
+func copyFile(src, dst string) ([sha256.Size]byte, int64, error) {
+	var sum [sha256.Size]byte
+
+	in, err := os.Open(src)
+	if err != nil {
+		return sum, 0, err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return sum, 0, err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return sum, 0, err
+	}
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, h), in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return sum, 0, fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+	copy(sum[:], h.Sum(nil))
+	return sum, size, nil
+}
+
+// writeFile writes the given content to a file at the given path, creating
+// its parent directories if necessary.
+func writeFile(filename string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, content, 0644)
+}
+
+func writeJSON(filename string, v any) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(filename, append(content, '\n'))
+}
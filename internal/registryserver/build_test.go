@@ -0,0 +1,398 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/google/go-cmp/cmp"
+	regaddr "github.com/opentofu/registry-address/v2"
+	"github.com/opentofu/svchost"
+	"github.com/opentofu/svchost/disco"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+	"github.com/opentofu/opentofu/internal/registry"
+)
+
+func TestBuild_providers(t *testing.T) {
+	source := t.TempDir()
+	providerDir := filepath.Join(source, "providers", "example", "random")
+	writeTestZip(t, filepath.Join(providerDir, "terraform-provider-random_1.0.0_linux_amd64.zip"), "terraform-provider-random_v1.0.0")
+	writeTestZip(t, filepath.Join(providerDir, "terraform-provider-random_1.0.0_darwin_arm64.zip"), "terraform-provider-random_v1.0.0")
+	writeTestZip(t, filepath.Join(providerDir, "terraform-provider-random_2.0.0_linux_amd64.zip"), "terraform-provider-random_v2.0.0")
+	writeTestFile(t, filepath.Join(providerDir, "terraform-provider-random_2.0.0_manifest.json"), `{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)
+	published := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"terraform-provider-random_1.0.0_linux_amd64.zip", "terraform-provider-random_1.0.0_darwin_arm64.zip"} {
+		if err := os.Chtimes(filepath.Join(providerDir, name), published, published); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	key := testSigningKey(t)
+	output := filepath.Join(t.TempDir(), "registry")
+	result, err := Build(source, output, BuildOptions{SigningKey: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	wantResult := []BuiltProvider{
+		{
+			Namespace: "example",
+			Type:      "random",
+			Version:   getproviders.MustParseVersion("1.0.0"),
+			Platforms: []getproviders.Platform{{OS: "darwin", Arch: "arm64"}, {OS: "linux", Arch: "amd64"}},
+		},
+		{
+			Namespace: "example",
+			Type:      "random",
+			Version:   getproviders.MustParseVersion("2.0.0"),
+			Platforms: []getproviders.Platform{{OS: "linux", Arch: "amd64"}},
+		},
+	}
+	if diff := cmp.Diff(wantResult, result.Providers); diff != "" {
+		t.Errorf("wrong result\n%s", diff)
+	}
+
+	// The real registry client must be able to find and install the
+	// providers, including verifying their signatures.
+	services := testRegistryServices(t, output)
+	src := getproviders.NewRegistrySource(t.Context(), services, nil, getproviders.LocationConfig{})
+	provider := addrs.NewProvider(svchost.Hostname("example.com"), "example", "random")
+
+	gotVersions, _, err := src.AvailableVersions(t.Context(), provider)
+	if err != nil {
+		t.Fatalf("failed to list versions: %s", err)
+	}
+	wantVersions := getproviders.VersionList{getproviders.MustParseVersion("1.0.0"), getproviders.MustParseVersion("2.0.0")}
+	if diff := cmp.Diff(wantVersions, gotVersions); diff != "" {
+		t.Errorf("wrong versions\n%s", diff)
+	}
+
+	meta, err := src.PackageMeta(t.Context(), provider, getproviders.MustParseVersion("1.0.0"), getproviders.Platform{OS: "linux", Arch: "amd64"})
+	if err != nil {
+		t.Fatalf("failed to get package metadata: %s", err)
+	}
+	if !meta.ReleaseTime.Equal(published) {
+		t.Errorf("wrong release time\ngot:  %s\nwant: %s", meta.ReleaseTime, published)
+	}
+	targetDir := t.TempDir()
+	authResult, err := meta.Location.InstallProviderPackage(t.Context(), meta, targetDir, nil)
+	if err != nil {
+		t.Fatalf("failed to install package: %s", err)
+	}
+	if !authResult.Signed() {
+		t.Errorf("package is not signed: %s", authResult)
+	}
+	if got, want := authResult.GPGKeyIDsString(), key.PrimaryKey.KeyIdString(); !strings.Contains(got, want) {
+		t.Errorf("package not signed by the expected key\ngot:  %s\nwant: %s", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(targetDir, "terraform-provider-random_v1.0.0")); err != nil {
+		t.Errorf("provider executable not installed: %s", err)
+	}
+
+	meta, err = src.PackageMeta(t.Context(), provider, getproviders.MustParseVersion("2.0.0"), getproviders.Platform{OS: "linux", Arch: "amd64"})
+	if err != nil {
+		t.Fatalf("failed to get package metadata: %s", err)
+	}
+	if diff := cmp.Diff(getproviders.VersionList{getproviders.MustParseVersion("6.0.0")}, meta.ProtocolVersions); diff != "" {
+		t.Errorf("wrong protocol versions from manifest\n%s", diff)
+	}
+}
+
+func TestBuild_modules(t *testing.T) {
+	source := t.TempDir()
+	moduleDir := filepath.Join(source, "modules", "example", "network", "aws")
+	writeTestTarGz(t, filepath.Join(moduleDir, "1.0.0.tar.gz"), "main.tf", "# version 1.0.0\n")
+	writeTestTarGz(t, filepath.Join(moduleDir, "1.1.0.tgz"), "main.tf", "# version 1.1.0\n")
+
+	output := filepath.Join(t.TempDir(), "registry")
+	result, err := Build(source, output, BuildOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := len(result.Modules), 2; got != want {
+		t.Fatalf("wrong number of modules %d; want %d", got, want)
+	}
+
+	services := testRegistryServices(t, output)
+	client := registry.NewClient(t.Context(), services, nil)
+	pkg := regaddr.ModulePackage{
+		Host:         svchost.Hostname("example.com"),
+		Namespace:    "example",
+		Name:         "network",
+		TargetSystem: "aws",
+	}
+
+	versions, err := client.ModulePackageVersions(t.Context(), pkg)
+	if err != nil {
+		t.Fatalf("failed to list versions: %s", err)
+	}
+	var gotVersions []string
+	for _, v := range versions.Modules[0].Versions {
+		gotVersions = append(gotVersions, v.Version)
+	}
+	if diff := cmp.Diff([]string{"1.0.0", "1.1.0"}, gotVersions); diff != "" {
+		t.Errorf("wrong versions\n%s", diff)
+	}
+
+	location, err := client.ModulePackageLocation(t.Context(), pkg, "1.1.0", "")
+	if err != nil {
+		t.Fatalf("failed to get module location: %s", err)
+	}
+	direct, ok := location.(registry.PackageLocationDirect)
+	if !ok {
+		t.Fatalf("wrong location type %T; want registry.PackageLocationDirect", location)
+	}
+	targetDir, err := client.InstallModulePackage(t.Context(), direct, t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to install module: %s", err)
+	}
+	got, err := os.ReadFile(filepath.Join(targetDir, "main.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# version 1.1.0\n"; string(got) != want {
+		t.Errorf("wrong module content %q; want %q", got, want)
+	}
+}
+
+func TestBuild_errors(t *testing.T) {
+	tests := map[string]struct {
+		files      map[string]string
+		noKey      bool
+		outputFile string
+		wantErr    string
+	}{
+		"empty source": {
+			wantErr: "contains no provider packages or module archives",
+		},
+		"providers without signing key": {
+			files: map[string]string{
+				"providers/example/random/terraform-provider-random_1.0.0_linux_amd64.zip": "",
+			},
+			noKey:   true,
+			wantErr: "a GPG signing key is required to publish providers",
+		},
+		"output directory not empty": {
+			files: map[string]string{
+				"modules/example/network/aws/1.0.0.zip": "",
+			},
+			outputFile: "stale",
+			wantErr:    "is not empty",
+		},
+		"provider package for another type": {
+			files: map[string]string{
+				"providers/example/random/terraform-provider-null_1.0.0_linux_amd64.zip": "",
+			},
+			wantErr: "unexpected file",
+		},
+		"provider package with invalid platform": {
+			files: map[string]string{
+				"providers/example/random/terraform-provider-random_1.0.0_linux.zip": "",
+			},
+			wantErr: "invalid platform in package filename",
+		},
+		"provider namespace not normalized": {
+			files: map[string]string{
+				"providers/Example/random/terraform-provider-random_1.0.0_linux_amd64.zip": "",
+			},
+			wantErr: `must be named "example"`,
+		},
+		"provider manifest without packages": {
+			files: map[string]string{
+				"providers/example/random/terraform-provider-random_1.0.0_manifest.json": `{"version":1,"metadata":{"protocol_versions":["5.0"]}}`,
+			},
+			wantErr: "has a manifest for version 1.0.0 but no packages",
+		},
+		"module archive with unsupported format": {
+			files: map[string]string{
+				"modules/example/network/aws/1.0.0.rar": "",
+			},
+			wantErr: "unexpected file",
+		},
+		"module with invalid target system": {
+			files: map[string]string{
+				"modules/example/network/AWS/1.0.0.zip": "",
+			},
+			wantErr: "invalid module package directory",
+		},
+		"module version with two archives": {
+			files: map[string]string{
+				"modules/example/network/aws/1.0.0.zip":    "",
+				"modules/example/network/aws/1.0.0.tar.gz": "",
+			},
+			wantErr: "are archives of version 1.0.0",
+		},
+	}
+
+	key := testSigningKey(t)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			source := t.TempDir()
+			for filename, content := range test.files {
+				writeTestFile(t, filepath.Join(source, filepath.FromSlash(filename)), content)
+			}
+			output := t.TempDir()
+			if test.outputFile != "" {
+				writeTestFile(t, filepath.Join(output, test.outputFile), "")
+			}
+			opts := BuildOptions{SigningKey: key}
+			if test.noKey {
+				opts.SigningKey = nil
+			}
+
+			_, err := Build(source, output, opts)
+			if err == nil {
+				t.Fatalf("unexpected success; want error containing %q", test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("wrong error\ngot:  %s\nwant: an error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	entity := testSigningKey(t)
+	if err := entity.EncryptPrivateKeys([]byte("correct horse"), nil); err != nil {
+		t.Fatal(err)
+	}
+	var buf strings.Builder
+	w, err := armor.Encode(&buf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.SerializePrivateWithoutSigning(w, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(t.TempDir(), "key.asc")
+	writeTestFile(t, filename, buf.String())
+
+	if _, err := LoadSigningKey(filename, nil); err == nil || !strings.Contains(err.Error(), "protected by a passphrase") {
+		t.Errorf("wrong error without passphrase: %v", err)
+	}
+	if _, err := LoadSigningKey(filename, []byte("wrong")); err == nil {
+		t.Errorf("unexpected success with wrong passphrase")
+	}
+	got, err := LoadSigningKey(filename, []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := newSigningKey(got); err != nil {
+		t.Errorf("decrypted key cannot sign: %s", err)
+	}
+}
+
+// testRegistryServices serves the registry in the given directory from a
+// local test server, and returns a service discovery object that considers
+// the host "example.com" to be served by it.
+func testRegistryServices(t *testing.T, dir string) *disco.Disco {
+	t.Helper()
+
+	server := httptest.NewServer(NewHandler(dir))
+	t.Cleanup(server.Close)
+
+	services := disco.New()
+	services.ForceHostServices(svchost.Hostname("example.com"), map[string]any{
+		"providers.v1": server.URL + "/" + providersServicePath,
+		"modules.v1":   server.URL + "/" + modulesServicePath,
+	})
+	return services
+}
+
+// testSigningKey returns a freshly-generated GPG key, which avoids including
+// private key material in the source code.
+func testSigningKey(t *testing.T) *openpgp.Entity {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("registry test", "throwaway key used only for testing", "testing@invalid", nil)
+	if err != nil {
+		t.Fatalf("failed to generate a PGP key for testing: %s", err)
+	}
+	return entity
+}
+
+func writeTestFile(t *testing.T, filename string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestZip(t *testing.T, filename string, member string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(member)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("#!/bin/sh\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestTarGz(t *testing.T, filename string, member string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gw)
+	err = tw.WriteHeader(&tar.Header{
+		Name:     member,
+		Mode:     0644,
+		Size:     int64(len(content)),
+		Typeflag: tar.TypeReg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package registryserver implements "tofu registry build" and
+// "tofu registry serve", which together provide a minimal private
+// implementation of the provider and module registry protocols.
+//
+// Build turns a directory of provider packages and module archives into a
+// tree of static files: the service discovery document, the JSON documents
+// of the provider and module registry protocols, and a signed SHA256SUMS
+// document for each provider version. The result can be published on any
+// static HTTPS file server whose root is the registry hostname, or served
+// directly using [NewHandler].
+//
+// All of the URLs inside the generated documents are relative, so the same
+// output works regardless of the hostname it is eventually served from.
+package registryserver
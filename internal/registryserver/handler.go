@@ -0,0 +1,59 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"net/http"
+	"path"
+)
+
+// NewHandler returns an HTTP handler that serves a registry generated by
+// Build in the given directory.
+//
+// The handler serves the files of the directory as they are, except that it
+// never lists the contents of a directory and it labels the documents of
+// the registry protocols as JSON, which a generic static file server could
+// not know to do because they have no filename extension.
+func NewHandler(dir string) http.Handler {
+	root := http.Dir(dir)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		name := path.Clean("/" + r.URL.Path)
+		f, err := root.Open(name)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil || info.IsDir() {
+			http.NotFound(w, r)
+			return
+		}
+
+		if isProtocolDocument(name) {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+	})
+}
+
+// isProtocolDocument returns true if the given path, relative to the root of
+// a directory generated by Build, is one of the JSON documents of the
+// service discovery or registry protocols.
+func isProtocolDocument(name string) bool {
+	switch path.Base(name) {
+	case "terraform.json", "versions", "download":
+		return true
+	}
+	// Provider download documents are at VERSION/download/OS/ARCH.
+	return path.Base(path.Dir(path.Dir(name))) == "download"
+}
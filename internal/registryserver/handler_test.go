@@ -0,0 +1,95 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewHandler(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".well-known", "terraform.json"), `{"providers.v1":"/v1/providers/"}`)
+	writeTestFile(t, filepath.Join(dir, "v1", "providers", "example", "random", "versions"), `{"versions":[]}`)
+	writeTestFile(t, filepath.Join(dir, "v1", "providers", "example", "random", "1.0.0", "download", "linux", "amd64"), `{}`)
+	writeTestFile(t, filepath.Join(dir, "v1", "providers", "example", "random", "1.0.0", "terraform-provider-random_1.0.0_SHA256SUMS"), "")
+
+	tests := map[string]struct {
+		method          string
+		path            string
+		wantStatus      int
+		wantContentType string
+	}{
+		"discovery document": {
+			path:            "/.well-known/terraform.json",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		"versions document": {
+			path:            "/v1/providers/example/random/versions",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		"download document": {
+			path:            "/v1/providers/example/random/1.0.0/download/linux/amd64",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		"checksums": {
+			path:            "/v1/providers/example/random/1.0.0/terraform-provider-random_1.0.0_SHA256SUMS",
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/plain; charset=utf-8",
+		},
+		"head request": {
+			method:          http.MethodHead,
+			path:            "/v1/providers/example/random/versions",
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+		},
+		"directory": {
+			path:       "/v1/providers/",
+			wantStatus: http.StatusNotFound,
+		},
+		"missing file": {
+			path:       "/v1/providers/example/null/versions",
+			wantStatus: http.StatusNotFound,
+		},
+		"outside the directory": {
+			path:       "/../etc/passwd",
+			wantStatus: http.StatusNotFound,
+		},
+		"unsupported method": {
+			method:     http.MethodPost,
+			path:       "/v1/providers/example/random/versions",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	handler := NewHandler(dir)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "http://registry.example.com/", nil)
+			req.URL.Path = test.path
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != test.wantStatus {
+				t.Errorf("wrong status %d; want %d", rec.Code, test.wantStatus)
+			}
+			if test.wantContentType != "" {
+				if got := rec.Header().Get("Content-Type"); got != test.wantContentType {
+					t.Errorf("wrong content type %q; want %q", got, test.wantContentType)
+				}
+			}
+		})
+	}
+}
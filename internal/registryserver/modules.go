@@ -0,0 +1,178 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apparentlymart/go-versions/versions"
+	regaddr "github.com/opentofu/registry-address/v2"
+
+	"github.com/opentofu/opentofu/internal/registry/response"
+)
+
+// moduleArchiveExtensions are the filename extensions of the module archive
+// formats that the module installer accepts from a registry.
+var moduleArchiveExtensions = []string{".tar.gz", ".tgz", ".tar.xz", ".tar.bz2", ".zip"}
+
+// moduleSource is a module package found in the source directory.
+type moduleSource struct {
+	Namespace string
+	Name      string
+	System    string
+	Versions  []*moduleVersionSource
+}
+
+// moduleVersionSource is a single version of a module package found in the
+// source directory.
+type moduleVersionSource struct {
+	Version   versions.Version
+	Archive   string
+	Extension string
+}
+
+// findModules finds all of the module packages in the "modules" directory
+// of the source tree, sorted by namespace, name and target system.
+func findModules(dir string) ([]*moduleSource, error) {
+	namespaces, err := sourceSubdirs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*moduleSource
+	for _, namespace := range namespaces {
+		names, err := sourceSubdirs(filepath.Join(dir, namespace))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			systems, err := sourceSubdirs(filepath.Join(dir, namespace, name))
+			if err != nil {
+				return nil, err
+			}
+			for _, system := range systems {
+				packageDir := filepath.Join(dir, namespace, name, system)
+				if _, err := regaddr.ParseModuleSource(path.Join(namespace, name, system)); err != nil {
+					return nil, fmt.Errorf("invalid module package directory %s: %w", packageDir, err)
+				}
+				found, err := findModuleVersions(packageDir)
+				if err != nil {
+					return nil, err
+				}
+				if len(found) == 0 {
+					continue
+				}
+				ret = append(ret, &moduleSource{
+					Namespace: namespace,
+					Name:      name,
+					System:    system,
+					Versions:  found,
+				})
+			}
+		}
+	}
+	return ret, nil
+}
+
+// findModuleVersions finds the archives in the directory of a single module
+// package, sorted by version.
+func findModuleVersions(dir string) ([]*moduleVersionSource, error) {
+	entries, err := sourceEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]string)
+	var ret []*moduleVersionSource
+	for _, entry := range entries {
+		filename := filepath.Join(dir, entry.Name())
+		var rawVersion, ext string
+		for _, candidate := range moduleArchiveExtensions {
+			if v, ok := strings.CutSuffix(entry.Name(), candidate); ok {
+				rawVersion, ext = v, candidate
+				break
+			}
+		}
+		if entry.IsDir() || ext == "" {
+			return nil, fmt.Errorf("unexpected file %s: expected only module archives named VERSION%s", filename, strings.Join(moduleArchiveExtensions, ", VERSION"))
+		}
+
+		version, err := versions.ParseVersion(rawVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in module archive filename %s: %w", filename, err)
+		}
+		if other, exists := seen[version.String()]; exists {
+			return nil, fmt.Errorf("both %s and %s are archives of version %s", other, filename, version)
+		}
+		seen[version.String()] = filename
+
+		ret = append(ret, &moduleVersionSource{
+			Version:   version,
+			Archive:   filename,
+			Extension: ext,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Version.LessThan(ret[j].Version)
+	})
+	return ret, nil
+}
+
+// buildModule writes the documents and archives for all versions of the
+// given module package into servicesDir, which is the directory
+// corresponding to the modules.v1 service.
+func buildModule(servicesDir string, module *moduleSource) ([]BuiltModule, error) {
+	packageDir := filepath.Join(servicesDir, module.Namespace, module.Name, module.System)
+
+	var ret []BuiltModule
+	versionsDoc := &response.ModuleProviderVersions{
+		Source: path.Join(module.Namespace, module.Name, module.System),
+	}
+	for _, v := range module.Versions {
+		versionDir := filepath.Join(packageDir, v.Version.String())
+		filename := fmt.Sprintf("%s-%s-%s%s", module.Name, module.System, v.Version, v.Extension)
+		if _, _, err := copyFile(v.Archive, filepath.Join(versionDir, filename)); err != nil {
+			return nil, err
+		}
+
+		// The location is relative to the download document, which is in the
+		// same directory as the archive. We publish the archive ourselves,
+		// so the client fetches it directly rather than treating the location
+		// as a remote source address, and uses the same credentials as for
+		// the registry itself in case the registry is served behind
+		// authentication.
+		useRegistryCredentials := response.StrictBool(true)
+		err := writeJSON(filepath.Join(versionDir, "download"), &response.ModuleLocationRegistryResp{
+			Location:               "./" + filename,
+			UseRegistryCredentials: &useRegistryCredentials,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		versionsDoc.Versions = append(versionsDoc.Versions, &response.ModuleVersion{
+			Version: v.Version.String(),
+		})
+		ret = append(ret, BuiltModule{
+			Namespace: module.Namespace,
+			Name:      module.Name,
+			System:    module.System,
+			Version:   v.Version,
+		})
+	}
+
+	err := writeJSON(filepath.Join(packageDir, "versions"), &response.ModuleVersions{
+		Modules: []*response.ModuleProviderVersions{versionsDoc},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,381 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/getproviders"
+)
+
+// defaultProviderProtocols are the plugin protocol versions announced for a
+// provider version that has no manifest file.
+var defaultProviderProtocols = []string{"5.0"}
+
+// providerSource is a provider found in the source directory.
+type providerSource struct {
+	Namespace string
+	Type      string
+	Versions  []*providerVersionSource
+}
+
+// providerVersionSource is a single version of a provider found in the
+// source directory, along with the paths of its packages.
+//
+// PublishedAt is the latest modification time of the source files of the
+// version, which stands in for the time it was released.
+type providerVersionSource struct {
+	Version     getproviders.Version
+	Protocols   []string
+	Packages    map[getproviders.Platform]string
+	PublishedAt time.Time
+}
+
+// providerManifest is the format of the manifest file that HashiCorp-style
+// provider releases include to describe the release.
+type providerManifest struct {
+	Version  int `json:"version"`
+	Metadata struct {
+		ProtocolVersions []string `json:"protocol_versions"`
+	} `json:"metadata"`
+}
+
+// These are the documents of the provider registry protocol.
+type (
+	providerVersionsDoc struct {
+		Versions []providerVersionsDocVersion `json:"versions"`
+	}
+	providerVersionsDocVersion struct {
+		Version   string                `json:"version"`
+		Protocols []string              `json:"protocols"`
+		Platforms []providerPlatformDoc `json:"platforms"`
+	}
+	providerPlatformDoc struct {
+		OS   string `json:"os"`
+		Arch string `json:"arch"`
+	}
+	providerDownloadDoc struct {
+		Protocols              []string                      `json:"protocols"`
+		OS                     string                        `json:"os"`
+		Arch                   string                        `json:"arch"`
+		Filename               string                        `json:"filename"`
+		DownloadURL            string                        `json:"download_url"`
+		SHA256SumsURL          string                        `json:"shasums_url"`
+		SHA256SumsSignatureURL string                        `json:"shasums_signature_url"`
+		SHA256Sum              string                        `json:"shasum"`
+		SigningKeys            providerSigningKeysDoc        `json:"signing_keys"`
+		Packages               map[string]providerPackageDoc `json:"packages"`
+		PublishedAt            time.Time                     `json:"published_at"`
+	}
+	providerSigningKeysDoc struct {
+		GPGPublicKeys []providerGPGPublicKeyDoc `json:"gpg_public_keys"`
+	}
+	providerGPGPublicKeyDoc struct {
+		KeyID      string `json:"key_id"`
+		ASCIIArmor string `json:"ascii_armor"`
+	}
+	providerPackageDoc struct {
+		Hashes      []string `json:"hashes"`
+		PackageSize int64    `json:"package_size"`
+	}
+)
+
+// findProviders finds all of the providers in the "providers" directory of
+// the source tree, sorted by namespace and type.
+func findProviders(dir string) ([]*providerSource, error) {
+	namespaces, err := sourceSubdirs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*providerSource
+	for _, namespace := range namespaces {
+		if err := checkProviderPart(filepath.Join(dir, namespace), "namespace"); err != nil {
+			return nil, err
+		}
+		typeNames, err := sourceSubdirs(filepath.Join(dir, namespace))
+		if err != nil {
+			return nil, err
+		}
+		for _, typeName := range typeNames {
+			if err := checkProviderPart(filepath.Join(dir, namespace, typeName), "type"); err != nil {
+				return nil, err
+			}
+			versions, err := findProviderVersions(filepath.Join(dir, namespace, typeName), typeName)
+			if err != nil {
+				return nil, err
+			}
+			if len(versions) == 0 {
+				continue
+			}
+			ret = append(ret, &providerSource{
+				Namespace: namespace,
+				Type:      typeName,
+				Versions:  versions,
+			})
+		}
+	}
+	return ret, nil
+}
+
+// checkProviderPart returns an error if the name of the given directory is
+// not a valid provider namespace or type in its normalized form, because
+// the registry client always requests the normalized form.
+func checkProviderPart(dir string, what string) error {
+	name := filepath.Base(dir)
+	normalized, err := addrs.ParseProviderPart(name)
+	if err != nil {
+		return fmt.Errorf("invalid provider %s directory %s: %w", what, dir, err)
+	}
+	if normalized != name {
+		return fmt.Errorf("invalid provider %s directory %s: must be named %q", what, dir, normalized)
+	}
+	return nil
+}
+
+// findProviderVersions finds the packages and manifests in the directory
+// of a single provider, sorted by version.
+func findProviderVersions(dir string, typeName string) ([]*providerVersionSource, error) {
+	entries, err := sourceEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := "terraform-provider-" + typeName + "_"
+	byVersion := make(map[string]*providerVersionSource)
+	versionSource := func(raw string) (*providerVersionSource, error) {
+		version, err := getproviders.ParseVersion(raw)
+		if err != nil {
+			return nil, err
+		}
+		key := version.String()
+		if byVersion[key] == nil {
+			byVersion[key] = &providerVersionSource{
+				Version:  version,
+				Packages: make(map[getproviders.Platform]string),
+			}
+		}
+		return byVersion[key], nil
+	}
+
+	for _, entry := range entries {
+		filename := filepath.Join(dir, entry.Name())
+		rest, ok := strings.CutPrefix(entry.Name(), prefix)
+		if entry.IsDir() || !ok {
+			return nil, fmt.Errorf("unexpected file %s: expected only files named %sVERSION_OS_ARCH.zip or %sVERSION_manifest.json", filename, prefix, prefix)
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		if rawVersion, ok := strings.CutSuffix(rest, "_manifest.json"); ok {
+			v, err := versionSource(rawVersion)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version in manifest filename %s: %w", filename, err)
+			}
+			v.Protocols, err = readProviderManifest(filename)
+			if err != nil {
+				return nil, err
+			}
+			v.notePublished(info.ModTime())
+			continue
+		}
+
+		rest, ok = strings.CutSuffix(rest, ".zip")
+		if !ok {
+			return nil, fmt.Errorf("unexpected file %s: provider packages must be .zip archives", filename)
+		}
+		rawVersion, rawPlatform, _ := strings.Cut(rest, "_")
+		v, err := versionSource(rawVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in package filename %s: %w", filename, err)
+		}
+		platform, err := getproviders.ParsePlatform(rawPlatform)
+		if err != nil {
+			return nil, fmt.Errorf("invalid platform in package filename %s: %w", filename, err)
+		}
+		v.Packages[platform] = filename
+		v.notePublished(info.ModTime())
+	}
+
+	ret := make([]*providerVersionSource, 0, len(byVersion))
+	for _, v := range byVersion {
+		if len(v.Packages) == 0 {
+			return nil, fmt.Errorf("%s has a manifest for version %s but no packages", dir, v.Version)
+		}
+		if len(v.Protocols) == 0 {
+			v.Protocols = defaultProviderProtocols
+		}
+		ret = append(ret, v)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Version.LessThan(ret[j].Version)
+	})
+	return ret, nil
+}
+
+// notePublished moves the publication time of the version forward to the
+// given modification time of one of its source files, if that is later.
+func (v *providerVersionSource) notePublished(modTime time.Time) {
+	modTime = modTime.UTC().Truncate(time.Second)
+	if modTime.After(v.PublishedAt) {
+		v.PublishedAt = modTime
+	}
+}
+
+func readProviderManifest(filename string) ([]string, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var manifest providerManifest
+	dec := json.NewDecoder(bytes.NewReader(src))
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid provider manifest %s: %w", filename, err)
+	}
+	if manifest.Version != 1 {
+		return nil, fmt.Errorf("invalid provider manifest %s: unsupported manifest format version %d", filename, manifest.Version)
+	}
+	if len(manifest.Metadata.ProtocolVersions) == 0 {
+		return nil, fmt.Errorf("invalid provider manifest %s: protocol_versions must not be empty", filename)
+	}
+	for _, raw := range manifest.Metadata.ProtocolVersions {
+		if _, err := getproviders.ParseVersion(raw); err != nil {
+			return nil, fmt.Errorf("invalid provider manifest %s: invalid protocol version %q: %w", filename, raw, err)
+		}
+	}
+	return manifest.Metadata.ProtocolVersions, nil
+}
+
+// buildProvider writes the documents and packages for all versions of the
+// given provider into servicesDir, which is the directory corresponding to
+// the providers.v1 service.
+func buildProvider(servicesDir string, provider *providerSource, signer *signingKey) ([]BuiltProvider, error) {
+	providerDir := filepath.Join(servicesDir, provider.Namespace, provider.Type)
+
+	var ret []BuiltProvider
+	var versionsDoc providerVersionsDoc
+	for _, v := range provider.Versions {
+		platforms, err := buildProviderVersion(filepath.Join(providerDir, v.Version.String()), provider.Type, v, signer)
+		if err != nil {
+			return nil, err
+		}
+
+		versionDoc := providerVersionsDocVersion{
+			Version:   v.Version.String(),
+			Protocols: v.Protocols,
+		}
+		for _, platform := range platforms {
+			versionDoc.Platforms = append(versionDoc.Platforms, providerPlatformDoc{OS: platform.OS, Arch: platform.Arch})
+		}
+		versionsDoc.Versions = append(versionsDoc.Versions, versionDoc)
+
+		ret = append(ret, BuiltProvider{
+			Namespace: provider.Namespace,
+			Type:      provider.Type,
+			Version:   v.Version,
+			Platforms: platforms,
+		})
+	}
+
+	if err := writeJSON(filepath.Join(providerDir, "versions"), versionsDoc); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// buildProviderVersion writes the packages of a single provider version
+// into versionDir, along with their signed checksums and the download
+// document for each platform. It returns the platforms that the version
+// supports, in a stable order.
+func buildProviderVersion(versionDir string, typeName string, v *providerVersionSource, signer *signingKey) ([]getproviders.Platform, error) {
+	platforms := make([]getproviders.Platform, 0, len(v.Packages))
+	for platform := range v.Packages {
+		platforms = append(platforms, platform)
+	}
+	sort.Slice(platforms, func(i, j int) bool {
+		return platforms[i].LessThan(platforms[j])
+	})
+
+	filenamePrefix := fmt.Sprintf("terraform-provider-%s_%s", typeName, v.Version)
+	sumsFilename := filenamePrefix + "_SHA256SUMS"
+	sigFilename := sumsFilename + ".sig"
+
+	var sums bytes.Buffer
+	shasums := make(map[getproviders.Platform]string, len(platforms))
+	packages := make(map[string]providerPackageDoc, len(platforms))
+	for _, platform := range platforms {
+		filename := filenamePrefix + "_" + platform.String() + ".zip"
+		dst := filepath.Join(versionDir, filename)
+		sum, size, err := copyFile(v.Packages[platform], dst)
+		if err != nil {
+			return nil, err
+		}
+		hashV1, err := getproviders.PackageHashV1(getproviders.PackageLocalArchive(dst))
+		if err != nil {
+			return nil, fmt.Errorf("invalid package %s: %w", v.Packages[platform], err)
+		}
+
+		shasums[platform] = fmt.Sprintf("%x", sum)
+		fmt.Fprintf(&sums, "%s  %s\n", shasums[platform], filename)
+		packages[platform.String()] = providerPackageDoc{
+			Hashes: []string{
+				hashV1.String(),
+				getproviders.HashLegacyZipSHAFromSHA(sum).String(),
+			},
+			PackageSize: size,
+		}
+	}
+
+	sig, err := signer.sign(sums.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign checksums for %s: %w", v.Version, err)
+	}
+	if err := writeFile(filepath.Join(versionDir, sumsFilename), sums.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writeFile(filepath.Join(versionDir, sigFilename), sig); err != nil {
+		return nil, err
+	}
+
+	for _, platform := range platforms {
+		// The download document lives at VERSION/download/OS/ARCH, so the
+		// files alongside it are two levels up.
+		doc := providerDownloadDoc{
+			Protocols:              v.Protocols,
+			OS:                     platform.OS,
+			Arch:                   platform.Arch,
+			Filename:               filenamePrefix + "_" + platform.String() + ".zip",
+			SHA256SumsURL:          path.Join("..", "..", sumsFilename),
+			SHA256SumsSignatureURL: path.Join("..", "..", sigFilename),
+			SHA256Sum:              shasums[platform],
+			SigningKeys: providerSigningKeysDoc{
+				GPGPublicKeys: []providerGPGPublicKeyDoc{{
+					KeyID:      signer.keyID,
+					ASCIIArmor: signer.armoredPublicKey,
+				}},
+			},
+			Packages:    packages,
+			PublishedAt: v.PublishedAt,
+		}
+		doc.DownloadURL = path.Join("..", "..", doc.Filename)
+		if err := writeJSON(filepath.Join(versionDir, "download", platform.OS, platform.Arch), doc); err != nil {
+			return nil, err
+		}
+	}
+
+	return platforms, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) The OpenTofu Authors
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (c) 2023 HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registryserver
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+)
+
+// LoadSigningKey reads an ASCII-armored GPG private key from the given file,
+// for use as [BuildOptions.SigningKey].
+//
+// If the private key is protected by a passphrase then it is decrypted using
+// the given passphrase.
+func LoadSigningKey(filename string, passphrase []byte) (*openpgp.Entity, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	entities, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GPG key in %s: %w", filename, err)
+	}
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("%s must contain exactly one GPG key, but it contains %d", filename, len(entities))
+	}
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, fmt.Errorf("%s contains only a public key, but signing requires the private key", filename)
+	}
+
+	if len(passphrase) == 0 {
+		if entity.PrivateKey.Encrypted {
+			return nil, fmt.Errorf("the private key in %s is protected by a passphrase, but no passphrase was given", filename)
+		}
+		return entity, nil
+	}
+	if err := entity.DecryptPrivateKeys(passphrase); err != nil {
+		return nil, fmt.Errorf("failed to decrypt the private key in %s: %w", filename, err)
+	}
+	return entity, nil
+}
+
+// signingKey is a GPG key prepared for signing the checksums of provider
+// packages, along with the public key information to announce in the
+// download documents.
+type signingKey struct {
+	entity           *openpgp.Entity
+	keyID            string
+	armoredPublicKey string
+}
+
+func newSigningKey(entity *openpgp.Entity) (*signingKey, error) {
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Serialize(w); err != nil {
+		return nil, fmt.Errorf("failed to export the public key of the signing key: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	// Signing the checksums of a provider is pointless if the key cannot
+	// be used to verify them, so we check that up front rather than leaving
+	// the mistake to be discovered by whoever installs the provider.
+	sig, err := (&signingKey{entity: entity}).sign(nil)
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(nil), bytes.NewReader(sig), nil); err != nil {
+		return nil, fmt.Errorf("signatures made with the signing key cannot be verified: %w", err)
+	}
+
+	return &signingKey{
+		entity:           entity,
+		keyID:            entity.PrimaryKey.KeyIdString(),
+		armoredPublicKey: buf.String(),
+	}, nil
+}
+
+// sign returns a detached binary signature of the given document, which is
+// the format that the registry client expects.
+func (k *signingKey) sign(document []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := openpgp.DetachSign(&buf, k.entity, bytes.NewReader(document), nil); err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -192,6 +192,15 @@ To suppress this error for the future 'destroy' runs, you can add the CLI flag "
 		}
 	}
 
+	if (len(plan.Changes.Deferred) > 0 || len(plan.Changes.DeferredModuleCalls) > 0) && !diags.HasErrors() {
+		diags = diags.Append(deferredResourcesWarning(
+			"Another apply round is needed",
+			"The plan deferred the following resources and module calls, so their changes have not been applied yet:",
+			plan.Changes,
+			"Run \"tofu apply\" again to plan and apply the deferred resources.",
+		))
+	}
+
 	if len(plan.TargetAddrs) > 0 || len(plan.ExcludeAddrs) > 0 {
 		diags = diags.Append(tfdiags.Sourceless(
 			tfdiags.Warning,
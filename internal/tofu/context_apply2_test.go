@@ -7353,3 +7353,81 @@ func TestContext2Apply_ephemeralInModuleWithExpansion(t *testing.T) {
 
 	}
 }
+
+func TestContext2Apply_allowDeferralConvergesOverRounds(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "aws_instance" "upstream" {}
+
+			resource "aws_instance" "counted" {
+				count = length(aws_instance.upstream.id)
+			}
+
+			resource "aws_instance" "downstream" {
+				foo = aws_instance.counted[0].id
+			}
+
+			output "counted" {
+				value = length(aws_instance.counted)
+			}
+		`,
+	})
+
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	p.ApplyResourceChangeFn = testApplyFn
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		}, nil),
+	})
+	opts := &PlanOpts{
+		Mode:          plans.NormalMode,
+		AllowDeferral: true,
+	}
+
+	// In the first round only aws_instance.upstream can be planned, and so
+	// the apply must report that another round is needed.
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), opts)
+	assertNoErrors(t, diags)
+	state, diags := ctx.Apply(context.Background(), plan, m, nil)
+	assertNoErrors(t, diags)
+
+	var warned bool
+	for _, diag := range diags {
+		if diag.Severity() == tfdiags.Warning && diag.Description().Summary == "Another apply round is needed" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Errorf("apply didn't warn that another round is needed")
+	}
+	if got := len(state.Module(addrs.RootModuleInstance).Resources); got != 1 {
+		t.Errorf("wrong number of resources after first round: got %d, want 1\n%s", got, state)
+	}
+	if got := state.OutputValue(addrs.OutputValue{Name: "counted"}.Absolute(addrs.RootModuleInstance)); got != nil && !got.Value.IsNull() {
+		t.Errorf("output.counted is set after first round, but should be null: %#v", got.Value)
+	}
+
+	// The second round can plan everything, because the upstream id is
+	// now known.
+	plan, diags = ctx.Plan(context.Background(), m, state, opts)
+	assertNoErrors(t, diags)
+	if len(plan.Changes.Deferred) != 0 {
+		t.Errorf("unexpected deferred resources in second round: %#v", plan.Changes.Deferred)
+	}
+	state, diags = ctx.Apply(context.Background(), plan, m, nil)
+	assertNoErrors(t, diags)
+
+	// testApplyFn sets every id to "foo", so there are three counted instances.
+	if got := len(state.Resource(mustAbsResourceAddr("aws_instance.counted")).Instances); got != 3 {
+		t.Errorf("wrong number of aws_instance.counted instances: got %d, want 3", got)
+	}
+	if state.Resource(mustAbsResourceAddr("aws_instance.downstream")) == nil {
+		t.Errorf("aws_instance.downstream was not created in the second round")
+	}
+	out := state.OutputValue(addrs.OutputValue{Name: "counted"}.Absolute(addrs.RootModuleInstance))
+	if out == nil || !out.Value.RawEquals(cty.NumberIntVal(3)) {
+		t.Errorf("wrong value for output.counted: %#v", out)
+	}
+}
@@ -80,6 +80,11 @@ type PlanOpts struct {
 	// warnings as part of the planning result.
 	Excludes []addrs.Targetable
 
+	// Profile is the name of the configuration profile that Targets or
+	// Excludes were populated from, if any. OpenTofu Core doesn't interpret
+	// profiles itself, but records the name in the plan.
+	Profile string
+
 	// ForceReplace is a set of resource instance addresses whose corresponding
 	// objects should be forced planned for replacement if the provider's
 	// plan would otherwise have been to either update the object in-place or
@@ -91,6 +96,16 @@ type PlanOpts struct {
 	// fully-functional new object.
 	ForceReplace []addrs.AbsResourceInstance
 
+	// AllowDeferral allows OpenTofu to defer planning of any resource or
+	// module call whose count or for_each value depends on values that won't
+	// be known until apply, along with everything that depends on it, instead
+	// of returning an error. The deferred resources and module calls are
+	// recorded in the plan and will be planned in a subsequent plan/apply
+	// round.
+	//
+	// This option is only valid in the normal planning mode.
+	AllowDeferral bool
+
 	// ExternalReferences allows the external caller to pass in references to
 	// nodes that should not be pruned even if they are not referenced within
 	// the actual graph.
@@ -203,6 +218,16 @@ func (c *Context) Plan(ctx context.Context, config *configs.Config, prevRunState
 		))
 		return nil, diags
 	}
+	if opts.AllowDeferral && opts.Mode != plans.NormalMode {
+		// Only the normal planning mode evaluates count and for_each for
+		// resources that might need to be deferred.
+		diags = diags.Append(tfdiags.Sourceless(
+			tfdiags.Error,
+			"Unsupported plan mode",
+			"Deferring resources with unknown instances (with -allow-deferral) is allowed only in normal planning mode.",
+		))
+		return nil, diags
+	}
 
 	// By the time we get here, we should have values defined for all of
 	// the root module variables, even if some of them are "unknown". It's the
@@ -241,6 +266,14 @@ The -target and -exclude options are not for routine use, and are provided only
 		panic(fmt.Sprintf("unsupported plan mode %s", opts.Mode))
 	}
 	diags = diags.Append(planDiags)
+	if plan != nil && (len(plan.Changes.Deferred) > 0 || len(plan.Changes.DeferredModuleCalls) > 0) {
+		diags = diags.Append(deferredResourcesWarning(
+			"Some resources were deferred",
+			"OpenTofu cannot yet determine which instances the following resources and module calls ought to have, so their planning has been deferred to a later plan/apply round:",
+			plan.Changes,
+			"After applying this plan, run \"tofu apply\" again to plan the deferred resources.",
+		))
+	}
 	// NOTE: We're intentionally not returning early when diags.HasErrors
 	// here because we'll still populate other metadata below on a best-effort
 	// basis to try to give the UI some extra context to return alongside the
@@ -275,6 +308,7 @@ The -target and -exclude options are not for routine use, and are provided only
 		plan.EphemeralVariables = config.Module.EphemeralVariablesHints()
 		plan.TargetAddrs = opts.Targets
 		plan.ExcludeAddrs = opts.Excludes
+		plan.Profile = opts.Profile
 	} else if !diags.HasErrors() {
 		panic("nil plan but no errors")
 	}
@@ -894,6 +928,7 @@ func (c *Context) planGraph(ctx context.Context, config *configs.Config, prevRun
 			ForceReplace:            opts.ForceReplace,
 			skipRefresh:             opts.SkipRefresh,
 			preDestroyRefresh:       opts.PreDestroyRefresh,
+			allowDeferral:           opts.AllowDeferral,
 			Operation:               walkPlan,
 			ExternalReferences:      opts.ExternalReferences,
 			ImportTargets:           opts.ImportTargets,
@@ -936,6 +971,36 @@ func (c *Context) planGraph(ctx context.Context, config *configs.Config, prevRun
 	}
 }
 
+// deferredResourcesWarning returns a warning diagnostic listing the deferred
+// resources and module calls in the given changes between the given
+// introduction and conclusion.
+func deferredResourcesWarning(summary, intro string, changes *plans.Changes, conclusion string) tfdiags.Diagnostic {
+	var buf strings.Builder
+	buf.WriteString(intro)
+	buf.WriteString("\n")
+	for _, d := range changes.Deferred {
+		switch d.Reason {
+		case plans.DeferredReasonInstanceCountUnknown:
+			fmt.Fprintf(&buf, "\n  - %s (count or for_each is not known until apply)", d.Addr)
+		case plans.DeferredReasonDeferredPrereq:
+			fmt.Fprintf(&buf, "\n  - %s (depends on a deferred resource)", d.Addr)
+		default:
+			fmt.Fprintf(&buf, "\n  - %s", d.Addr)
+		}
+	}
+	for _, d := range changes.DeferredModuleCalls {
+		switch d.Reason {
+		case plans.DeferredReasonInstanceCountUnknown:
+			fmt.Fprintf(&buf, "\n  - %s (count or for_each is not known until apply)", d.Addr)
+		default:
+			fmt.Fprintf(&buf, "\n  - %s", d.Addr)
+		}
+	}
+	buf.WriteString("\n\n")
+	buf.WriteString(conclusion)
+	return tfdiags.Sourceless(tfdiags.Warning, summary, buf.String())
+}
+
 // driftedResources is a best-effort attempt to compare the current and prior
 // state. If we cannot decode the prior state for some reason, this should only
 // return warnings to help the user correlate any missing resources in the
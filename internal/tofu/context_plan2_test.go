@@ -4693,6 +4693,141 @@ output "a" {
 	})
 }
 
+func TestContext2Plan_outputValidation(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+variable "boop" {
+  type = string
+}
+
+output "a" {
+  value = var.boop
+  validation {
+    condition     = self == "boop"
+    error_message = "Wrong boop ${self}."
+  }
+}
+`,
+	})
+
+	ctx := testContext2(t, &ContextOpts{})
+
+	t.Run("validate", func(t *testing.T) {
+		diags := ctx.Validate(context.Background(), m)
+		assertNoErrors(t, diags)
+	})
+
+	t.Run("condition pass", func(t *testing.T) {
+		plan, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+			Mode: plans.NormalMode,
+			SetVariables: InputValues{
+				"boop": &InputValue{
+					Value:      cty.StringVal("boop"),
+					SourceType: ValueFromCLIArg,
+				},
+			},
+		})
+		assertNoErrors(t, diags)
+		addr := addrs.RootModuleInstance.OutputValue("a")
+		if gotResult := plan.Checks.GetObjectResult(addr); gotResult == nil {
+			t.Errorf("no check result for %s", addr)
+		} else {
+			wantResult := &states.CheckResultObject{
+				Status: checks.StatusPass,
+			}
+			if diff := cmp.Diff(wantResult, gotResult, valueComparer); diff != "" {
+				t.Errorf("wrong check result\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("condition fail", func(t *testing.T) {
+		_, diags := ctx.Plan(context.Background(), m, states.NewState(), &PlanOpts{
+			Mode: plans.NormalMode,
+			SetVariables: InputValues{
+				"boop": &InputValue{
+					Value:      cty.StringVal("nope"),
+					SourceType: ValueFromCLIArg,
+				},
+			},
+		})
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), "Module output value validation failed: Wrong boop nope."; got != want {
+			t.Fatalf("wrong error:\ngot:  %s\nwant: %q", got, want)
+		}
+	})
+}
+
+func TestContext2Plan_outputTypeConstraint(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+module "child" {
+  source = "./child"
+}
+
+output "servers" {
+  value = module.child.servers
+}
+`,
+		"child/main.tf": `
+type "server" {
+  definition = object({
+    name = string
+    port = optional(number, 80)
+  })
+}
+
+output "servers" {
+  type  = list(server)
+  value = [{ name = "a" }, { name = "b", port = "8080" }]
+}
+`,
+	})
+
+	ctx := testContext2(t, &ContextOpts{})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	change, err := plan.Changes.OutputValue(addrs.RootModuleInstance.OutputValue("servers")).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := cty.ListVal([]cty.Value{
+		cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("a"),
+			"port": cty.NumberIntVal(80),
+		}),
+		cty.ObjectVal(map[string]cty.Value{
+			"name": cty.StringVal("b"),
+			"port": cty.NumberIntVal(8080),
+		}),
+	})
+	if !change.After.RawEquals(want) {
+		t.Errorf("wrong value for output.servers\ngot:  %#v\nwant: %#v", change.After, want)
+	}
+
+	t.Run("unsuitable value", func(t *testing.T) {
+		m := testModuleInline(t, map[string]string{
+			"main.tf": `
+output "a" {
+  type  = number
+  value = "nope"
+}
+`,
+		})
+		_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), `Invalid value for output value: Unsuitable value for output value "a": a number is required.`; got != want {
+			t.Fatalf("wrong error:\ngot:  %s\nwant: %q", got, want)
+		}
+	})
+}
+
 func TestContext2Plan_preconditionErrors(t *testing.T) {
 	SkipExperimental(t, ExperimentalChangeDiagWording, ExperimentalBugVariableInput)
 
@@ -9728,3 +9863,466 @@ resource "test_resource" "example" {
 		t.Fatalf("expected to have exactly one resource change but got %d", changes)
 	}
 }
+
+func TestContext2Plan_blockLocals(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+locals {
+  prefix = "app"
+}
+
+resource "test_object" "a" {
+  for_each = {
+    web = 80
+    api = 8080
+  }
+
+  locals {
+    name = "${local.prefix}-${each.key}"
+    port = each.value + 1
+  }
+
+  test_string = local.name
+  test_number = local.port
+
+  lifecycle {
+    postcondition {
+      condition     = self.test_string == local.name
+      error_message = "Wrong name."
+    }
+  }
+}
+
+module "child" {
+  source = "./child"
+  count  = 2
+
+  locals {
+    name = "${local.prefix}-${count.index}"
+  }
+
+  name = local.name
+}
+
+output "names" {
+  value = module.child[*].name
+}
+`,
+		"child/main.tf": `
+variable "name" {
+  type = string
+}
+
+output "name" {
+  value = var.name
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	schema := p.GetProviderSchemaResponse.ResourceTypes["test_object"]
+	for key, want := range map[string]struct {
+		name string
+		port int64
+	}{
+		"web": {"app-web", 81},
+		"api": {"app-api", 8081},
+	} {
+		addr := mustResourceInstanceAddr(fmt.Sprintf("test_object.a[%q]", key))
+		change := plan.Changes.ResourceInstance(addr)
+		if change == nil {
+			t.Fatalf("no change for %s", addr)
+		}
+		decoded, err := change.Decode(&schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := decoded.After.GetAttr("test_string"); !got.RawEquals(cty.StringVal(want.name)) {
+			t.Errorf("wrong test_string for %s: %#v", addr, got)
+		}
+		if got := decoded.After.GetAttr("test_number"); !got.RawEquals(cty.NumberIntVal(want.port)) {
+			t.Errorf("wrong test_number for %s: %#v", addr, got)
+		}
+	}
+
+	out := plan.Changes.OutputValue(addrs.OutputValue{Name: "names"}.Absolute(addrs.RootModuleInstance))
+	if out == nil {
+		t.Fatal("no change for output names")
+	}
+	decoded, err := out.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := cty.TupleVal([]cty.Value{cty.StringVal("app-0"), cty.StringVal("app-1")})
+	if !decoded.After.RawEquals(want) {
+		t.Errorf("wrong output value\ngot:  %#v\nwant: %#v", decoded.After, want)
+	}
+}
+
+func TestContext2Plan_blockLocalsOutOfScope(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+resource "test_object" "a" {
+  locals {
+    name = "a"
+  }
+
+  test_string = local.name
+}
+
+resource "test_object" "b" {
+  test_string = local.name
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	_, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	if !diags.HasErrors() {
+		t.Fatal("expected errors")
+	}
+	if got, want := diags.Err().Error(), "Reference to undeclared local value"; !strings.Contains(got, want) {
+		t.Errorf("wrong error\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestContext2Plan_moduleFunctions(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+function "label" {
+  parameter "name" {
+    type = string
+  }
+  variadic_parameter "suffixes" {
+    type = string
+  }
+  result = join("-", concat([upper(name)], suffixes))
+}
+
+resource "test_object" "a" {
+  test_string = module::label("app", "web")
+}
+
+module "child" {
+  source = "./child"
+}
+
+output "label" {
+  value = module::label(test_object.a.test_string, "x")
+}
+
+output "child" {
+  value = module.child.label
+}
+`,
+		"child/main.tf": `
+function "label" {
+  parameter "name" {
+    type = string
+  }
+  result = "child-${name}"
+}
+
+output "label" {
+  value = module::label("a")
+}
+`,
+	})
+
+	p := simpleMockProvider()
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("test"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	plan, diags := ctx.Plan(context.Background(), m, states.NewState(), DefaultPlanOpts)
+	assertNoErrors(t, diags)
+
+	schema := p.GetProviderSchemaResponse.ResourceTypes["test_object"]
+	change := plan.Changes.ResourceInstance(mustResourceInstanceAddr("test_object.a"))
+	if change == nil {
+		t.Fatal("no change for test_object.a")
+	}
+	decoded, err := change.Decode(&schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := decoded.After.GetAttr("test_string"), cty.StringVal("APP-web"); !got.RawEquals(want) {
+		t.Errorf("wrong test_string\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	for name, want := range map[string]cty.Value{
+		"label": cty.StringVal("APP-WEB-x"),
+		"child": cty.StringVal("child-a"),
+	} {
+		out := plan.Changes.OutputValue(addrs.OutputValue{Name: name}.Absolute(addrs.RootModuleInstance))
+		if out == nil {
+			t.Fatalf("no change for output %s", name)
+		}
+		decoded, err := out.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.After.RawEquals(want) {
+			t.Errorf("wrong value for output %s\ngot:  %#v\nwant: %#v", name, decoded.After, want)
+		}
+	}
+}
+
+func TestContext2Plan_allowDeferralUnknownExpansion(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "aws_instance" "upstream" {}
+
+			resource "aws_instance" "counted" {
+				count = length(aws_instance.upstream.id)
+			}
+
+			resource "aws_instance" "each" {
+				for_each = toset([aws_instance.upstream.id])
+			}
+
+			resource "aws_instance" "downstream" {
+				foo = aws_instance.counted[0].id
+			}
+
+			resource "aws_instance" "unrelated" {}
+
+			output "counted" {
+				value = aws_instance.counted[*].id
+			}
+		`,
+	})
+
+	// aws_instance.counted already has an instance, which must not be
+	// planned for destruction just because the resource was deferred.
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			mustResourceInstanceAddr("aws_instance.counted[0]"),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"existing"}`),
+				Status:    states.ObjectReady,
+			},
+			mustProviderConfig(`provider["registry.opentofu.org/hashicorp/aws"]`),
+			addrs.NoKey,
+		)
+	})
+
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	t.Run("without deferral", func(t *testing.T) {
+		_, diags := ctx.Plan(context.Background(), m, state, DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), "Invalid count argument"; !strings.Contains(got, want) {
+			t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+		}
+	})
+
+	t.Run("with deferral", func(t *testing.T) {
+		plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+			Mode:          plans.NormalMode,
+			AllowDeferral: true,
+		})
+		assertNoErrors(t, diags)
+
+		gotDeferred := map[string]plans.DeferredReason{}
+		for _, d := range plan.Changes.Deferred {
+			gotDeferred[d.Addr.String()] = d.Reason
+		}
+		wantDeferred := map[string]plans.DeferredReason{
+			"aws_instance.counted":    plans.DeferredReasonInstanceCountUnknown,
+			"aws_instance.each":       plans.DeferredReasonInstanceCountUnknown,
+			"aws_instance.downstream": plans.DeferredReasonDeferredPrereq,
+		}
+		if diff := cmp.Diff(wantDeferred, gotDeferred); diff != "" {
+			t.Errorf("wrong deferred resources\n%s", diff)
+		}
+
+		gotChanges := map[string]plans.Action{}
+		for _, rc := range plan.Changes.Resources {
+			gotChanges[rc.Addr.String()] = rc.Action
+		}
+		wantChanges := map[string]plans.Action{
+			"aws_instance.upstream":  plans.Create,
+			"aws_instance.unrelated": plans.Create,
+		}
+		if diff := cmp.Diff(wantChanges, gotChanges); diff != "" {
+			t.Errorf("wrong resource changes\n%s", diff)
+		}
+
+		outputChange := plan.Changes.OutputValue(addrs.OutputValue{Name: "counted"}.Absolute(addrs.RootModuleInstance))
+		if outputChange == nil {
+			t.Fatal("no change for output.counted")
+		}
+		change, err := outputChange.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if change.After.IsKnown() {
+			t.Errorf("output.counted is known, but should be unknown: %#v", change.After)
+		}
+
+		var warning string
+		for _, diag := range diags {
+			if diag.Severity() == tfdiags.Warning && diag.Description().Summary == "Some resources were deferred" {
+				warning = diag.Description().Detail
+			}
+		}
+		if !strings.Contains(warning, "aws_instance.counted (count or for_each is not known until apply)") {
+			t.Errorf("missing or incomplete deferral warning:\n%s", warning)
+		}
+	})
+
+	t.Run("not in destroy mode", func(t *testing.T) {
+		_, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+			Mode:          plans.DestroyMode,
+			AllowDeferral: true,
+		})
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), "only in normal planning mode"; !strings.Contains(got, want) {
+			t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+		}
+	})
+}
+
+func TestContext2Plan_allowDeferralUnknownModuleExpansion(t *testing.T) {
+	m := testModuleInline(t, map[string]string{
+		"main.tf": `
+			resource "aws_instance" "upstream" {}
+
+			module "counted" {
+				source = "./child"
+				count  = length(aws_instance.upstream.id)
+			}
+
+			module "each" {
+				source   = "./child"
+				for_each = toset([aws_instance.upstream.id])
+			}
+
+			resource "aws_instance" "downstream" {
+				foo = module.counted[0].id
+			}
+
+			resource "aws_instance" "unrelated" {}
+		`,
+		"child/main.tf": `
+			resource "aws_instance" "foo" {}
+
+			output "id" {
+				value = aws_instance.foo.id
+			}
+		`,
+	})
+
+	// module.counted already has an instance, whose objects must not be
+	// planned for destruction just because the module call was deferred.
+	state := states.BuildState(func(s *states.SyncState) {
+		s.SetResourceInstanceCurrent(
+			mustResourceInstanceAddr("module.counted[0].aws_instance.foo"),
+			&states.ResourceInstanceObjectSrc{
+				AttrsJSON: []byte(`{"id":"existing"}`),
+				Status:    states.ObjectReady,
+			},
+			mustProviderConfig(`provider["registry.opentofu.org/hashicorp/aws"]`),
+			addrs.NoKey,
+		)
+	})
+
+	p := testProvider("aws")
+	p.PlanResourceChangeFn = testDiffFn
+	ctx := testContext2(t, &ContextOpts{
+		Plugins: plugins.NewLibrary(map[addrs.Provider]providers.Factory{
+			addrs.NewDefaultProvider("aws"): testProviderFuncFixed(p),
+		}, nil),
+	})
+
+	t.Run("without deferral", func(t *testing.T) {
+		_, diags := ctx.Plan(context.Background(), m, state, DefaultPlanOpts)
+		if !diags.HasErrors() {
+			t.Fatal("succeeded; want errors")
+		}
+		if got, want := diags.Err().Error(), "Invalid count argument"; !strings.Contains(got, want) {
+			t.Fatalf("wrong error\ngot:  %s\nwant: message containing %q", got, want)
+		}
+	})
+
+	t.Run("with deferral", func(t *testing.T) {
+		plan, diags := ctx.Plan(context.Background(), m, state, &PlanOpts{
+			Mode:          plans.NormalMode,
+			AllowDeferral: true,
+		})
+		assertNoErrors(t, diags)
+
+		gotDeferredCalls := map[string]plans.DeferredReason{}
+		for _, d := range plan.Changes.DeferredModuleCalls {
+			gotDeferredCalls[d.Addr.String()] = d.Reason
+		}
+		wantDeferredCalls := map[string]plans.DeferredReason{
+			"module.counted": plans.DeferredReasonInstanceCountUnknown,
+			"module.each":    plans.DeferredReasonInstanceCountUnknown,
+		}
+		if diff := cmp.Diff(wantDeferredCalls, gotDeferredCalls); diff != "" {
+			t.Errorf("wrong deferred module calls\n%s", diff)
+		}
+
+		gotDeferred := map[string]plans.DeferredReason{}
+		for _, d := range plan.Changes.Deferred {
+			gotDeferred[d.Addr.String()] = d.Reason
+		}
+		wantDeferred := map[string]plans.DeferredReason{
+			"aws_instance.downstream": plans.DeferredReasonDeferredPrereq,
+		}
+		if diff := cmp.Diff(wantDeferred, gotDeferred); diff != "" {
+			t.Errorf("wrong deferred resources\n%s", diff)
+		}
+
+		gotChanges := map[string]plans.Action{}
+		for _, rc := range plan.Changes.Resources {
+			gotChanges[rc.Addr.String()] = rc.Action
+		}
+		wantChanges := map[string]plans.Action{
+			"aws_instance.upstream":  plans.Create,
+			"aws_instance.unrelated": plans.Create,
+		}
+		if diff := cmp.Diff(wantChanges, gotChanges); diff != "" {
+			t.Errorf("wrong resource changes\n%s", diff)
+		}
+
+		var warning string
+		for _, diag := range diags {
+			if diag.Severity() == tfdiags.Warning && diag.Description().Summary == "Some resources were deferred" {
+				warning = diag.Description().Detail
+			}
+		}
+		if !strings.Contains(warning, "module.counted (count or for_each is not known until apply)") {
+			t.Errorf("missing or incomplete deferral warning:\n%s", warning)
+		}
+	})
+}
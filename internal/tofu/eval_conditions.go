@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -34,6 +35,15 @@ import (
 // If any of the rules do not pass, the returned diagnostics will contain
 // errors. Otherwise, it will either be empty or contain only warnings.
 func evalCheckRules(ctx context.Context, typ addrs.CheckRuleType, rules []*configs.CheckRule, evalCtx EvalContext, self addrs.Checkable, keyData instances.RepetitionData, diagSeverity tfdiags.Severity) tfdiags.Diagnostics {
+	return evalCheckRulesWithSelfValue(ctx, typ, rules, evalCtx, self, keyData, cty.NilVal, diagSeverity)
+}
+
+// evalCheckRulesWithSelfValue is like evalCheckRules, except that the rules
+// can also refer to the given value as "self".
+//
+// This is for the validation rules of output values, where "self" is the
+// output's own value rather than a referenceable object.
+func evalCheckRulesWithSelfValue(ctx context.Context, typ addrs.CheckRuleType, rules []*configs.CheckRule, evalCtx EvalContext, self addrs.Checkable, keyData instances.RepetitionData, selfVal cty.Value, diagSeverity tfdiags.Severity) tfdiags.Diagnostics {
 	var diags tfdiags.Diagnostics
 
 	checkState := evalCtx.Checks()
@@ -54,7 +64,7 @@ func evalCheckRules(ctx context.Context, typ addrs.CheckRuleType, rules []*confi
 	severity := diagSeverity.ToHCL()
 
 	for i, rule := range rules {
-		result, ruleDiags := evalCheckRule(ctx, addrs.NewCheckRule(self, typ, i), rule, evalCtx, keyData, severity)
+		result, ruleDiags := evalCheckRule(ctx, addrs.NewCheckRule(self, typ, i), rule, evalCtx, keyData, selfVal, severity)
 		diags = diags.Append(ruleDiags)
 
 		log.Printf("[TRACE] evalCheckRules: %s status is now %s", self, result.Status)
@@ -73,7 +83,7 @@ type checkResult struct {
 	FailureMessage string
 }
 
-func validateCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData) (string, *hcl.EvalContext, tfdiags.Diagnostics) {
+func validateCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData, selfVal cty.Value) (string, *hcl.EvalContext, tfdiags.Diagnostics) {
 	var diags tfdiags.Diagnostics
 
 	refs, moreDiags := lang.ReferencesInExpr(addrs.ParseRef, rule.Condition)
@@ -101,11 +111,24 @@ func validateCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.
 		default:
 			panic(fmt.Sprintf("Invalid source reference type %t", addr.Container))
 		}
+	case addrs.OutputValidation:
+		// Output value validations refer to the output's value as "self",
+		// which isn't a referenceable object, so we add it to the evaluation
+		// context directly below instead.
+		refs = slices.DeleteFunc(refs, func(ref *addrs.Reference) bool {
+			return ref.Subject == addrs.Self
+		})
 	}
 	scope := evalCtx.EvaluationScope(selfReference, sourceReference, keyData)
 
 	hclCtx, moreDiags := scope.EvalContext(ctx, refs)
 	diags = diags.Append(moreDiags)
+	if selfVal != cty.NilVal {
+		if hclCtx.Variables == nil {
+			hclCtx.Variables = make(map[string]cty.Value)
+		}
+		hclCtx.Variables["self"] = selfVal
+	}
 
 	errorMessage, moreDiags := evalCheckErrorMessage(rule.ErrorMessage, hclCtx)
 	diags = diags.Append(moreDiags)
@@ -113,11 +136,11 @@ func validateCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.
 	return errorMessage, hclCtx, diags
 }
 
-func evalCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData, severity hcl.DiagnosticSeverity) (checkResult, tfdiags.Diagnostics) {
+func evalCheckRule(ctx context.Context, addr addrs.CheckRule, rule *configs.CheckRule, evalCtx EvalContext, keyData instances.RepetitionData, selfVal cty.Value, severity hcl.DiagnosticSeverity) (checkResult, tfdiags.Diagnostics) {
 	// NOTE: Intentionally not passing the caller's selected severity in here,
 	// because this reports errors in the configuration itself, not the failure
 	// of an otherwise-valid condition.
-	errorMessage, hclCtx, diags := validateCheckRule(ctx, addr, rule, evalCtx, keyData)
+	errorMessage, hclCtx, diags := validateCheckRule(ctx, addr, rule, evalCtx, keyData, selfVal)
 
 	const errInvalidCondition = "Invalid condition result"
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/instances"
@@ -175,6 +176,11 @@ type EvalContext interface {
 	// path argument.
 	WithPath(path addrs.ModuleInstance) EvalContext
 
+	// WithBlockLocals returns a copy of the context whose evaluation scopes
+	// also resolve the given local values, declared in a nested locals
+	// block of the resource or module block being evaluated.
+	WithBlockLocals(locals map[string]*configs.Local) EvalContext
+
 	// Returns the currently configured encryption setup
 	GetEncryption() encryption.Encryption
 }
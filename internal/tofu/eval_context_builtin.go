@@ -17,6 +17,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/instances"
@@ -44,6 +45,11 @@ type BuiltinEvalContext struct {
 	// panic if this is not set.
 	pathSet bool
 
+	// blockLocals are the local values declared in a nested locals block of
+	// the resource or module block being evaluated, if any. They are made
+	// available to every scope returned by EvaluationScope.
+	blockLocals map[string]*configs.Local
+
 	// Evaluator is used for evaluating expressions within the scope of this
 	// eval context.
 	Evaluator *Evaluator
@@ -89,6 +95,12 @@ func (c *BuiltinEvalContext) WithPath(path addrs.ModuleInstance) EvalContext {
 	return &newEvalCtx
 }
 
+func (c *BuiltinEvalContext) WithBlockLocals(locals map[string]*configs.Local) EvalContext {
+	newEvalCtx := *c
+	newEvalCtx.blockLocals = locals
+	return &newEvalCtx
+}
+
 func (c *BuiltinEvalContext) Stopped() <-chan struct{} {
 	// This can happen during tests. During tests, we just block forever.
 	if c.StopContext == nil {
@@ -321,7 +333,12 @@ func (c *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source ad
 	mc := c.Evaluator.Config.DescendentForInstance(c.PathValue)
 
 	if mc == nil || mc.Module.ProviderRequirements == nil {
-		return c.Evaluator.Scope(data, self, source, nil)
+		scope := c.Evaluator.Scope(data, self, source, nil)
+		scope.BlockLocals = blockLocalExprs(c.blockLocals)
+		if mc != nil {
+			scope.ModuleFunctions = mc.Module.UserFunctions()
+		}
+		return scope
 	}
 
 	scope := c.Evaluator.Scope(data, self, source, func(ctx context.Context, pf addrs.ProviderFunction, rng tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics) {
@@ -365,10 +382,25 @@ func (c *BuiltinEvalContext) EvaluationScope(self addrs.Referenceable, source ad
 		return evalContextProviderFunction(ctx, provider, c.Evaluator.Operation, pf, rng)
 	})
 	scope.SetActiveExperiments(mc.Module.ActiveExperiments)
+	scope.BlockLocals = blockLocalExprs(c.blockLocals)
+	scope.ModuleFunctions = mc.Module.UserFunctions()
 
 	return scope
 }
 
+// blockLocalExprs returns the expressions of the given block-local values,
+// keyed by name, or nil if there are none.
+func blockLocalExprs(locals map[string]*configs.Local) map[string]hcl.Expression {
+	if len(locals) == 0 {
+		return nil
+	}
+	exprs := make(map[string]hcl.Expression, len(locals))
+	for name, local := range locals {
+		exprs[name] = local.Expr
+	}
+	return exprs
+}
+
 func (c *BuiltinEvalContext) Path() addrs.ModuleInstance {
 	if !c.pathSet {
 		panic("context path not set")
@@ -15,6 +15,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/encryption"
 	"github.com/opentofu/opentofu/internal/instances"
@@ -89,6 +90,8 @@ type MockEvalContext struct {
 	PathCalled bool
 	PathPath   addrs.ModuleInstance
 
+	WithBlockLocalsLocals map[string]*configs.Local
+
 	SetRootModuleArgumentCalled bool
 	SetRootModuleArgumentAddr   addrs.InputVariable
 	SetRootModuleArgumentValue  cty.Value
@@ -275,6 +278,12 @@ func (c *MockEvalContext) WithPath(path addrs.ModuleInstance) EvalContext {
 	return &newC
 }
 
+func (c *MockEvalContext) WithBlockLocals(locals map[string]*configs.Local) EvalContext {
+	newC := *c
+	newC.WithBlockLocalsLocals = locals
+	return &newC
+}
+
 func (c *MockEvalContext) Path() addrs.ModuleInstance {
 	c.PathCalled = true
 	return c.PathPath
@@ -19,6 +19,7 @@ import (
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/didyoumean"
@@ -70,6 +71,12 @@ type Evaluator struct {
 	Changes *plans.ChangesSync
 
 	PlanTimestamp time.Time
+
+	// CheckResults is a snapshot of the results of the check blocks, used to
+	// resolve references to check blocks from the assertions within a test
+	// run block. It is nil in every other context, where check blocks can't
+	// be referenced.
+	CheckResults *states.CheckResults
 }
 
 // Scope creates an evaluation scope for the given module path and optional
@@ -390,6 +397,13 @@ func (d *evaluationStateData) GetModule(_ context.Context, addr addrs.ModuleCall
 	}
 	outputConfigs := moduleConfig.Module.Outputs
 
+	// Planning of a deferred module call will happen only in a later round,
+	// so we can't yet say anything about which instances it has or what
+	// their output values are.
+	if d.Evaluator.Changes != nil && d.Evaluator.Changes.IsModuleCallDeferred(addr.Absolute(d.ModulePath)) {
+		return cty.DynamicVal, diags
+	}
+
 	// Collect all the relevant outputs that current exist in the state.
 	// We know the instance path up to this point, and the child module name,
 	// so we only need to store these by instance key.
@@ -721,6 +735,17 @@ func (d *evaluationStateData) GetResource(ctx context.Context, addr addrs.Resour
 	}
 	ty := schema.ImpliedType()
 
+	// Planning of a deferred resource will happen only in a later round, so
+	// we can't yet say anything about which instances it has or what their
+	// values are.
+	if d.Evaluator.Changes != nil && d.Evaluator.Changes.IsResourceDeferred(addr.Absolute(d.ModulePath)) {
+		val := cty.DynamicVal
+		if schema.Ephemeral {
+			val = val.Mark(marks.Ephemeral)
+		}
+		return val, diags
+	}
+
 	rs := d.Evaluator.State.Resource(addr.Absolute(d.ModulePath))
 
 	if rs == nil {
@@ -1108,20 +1133,64 @@ func (d *evaluationStateData) GetOutput(_ context.Context, addr addrs.OutputValu
 }
 
 func (d *evaluationStateData) GetCheckBlock(_ context.Context, addr addrs.Check, rng tfdiags.SourceRange) (cty.Value, tfdiags.Diagnostics) {
-	// For now, check blocks don't contain any meaningful data and can only
-	// be referenced from the testing scope within an expect_failures attribute.
-	//
-	// We've added them into the scope explicitly since they are referenceable,
-	// but we'll actually just return an error message saying they can't be
-	// referenced in this context.
+	// Check blocks can only be referenced from the testing scope, within
+	// either an expect_failures attribute or an assertion of a run block.
+	// Only the latter requires a value, for which the check results of the
+	// plan or apply operation under test are provided.
 	var diags tfdiags.Diagnostics
-	diags = diags.Append(&hcl.Diagnostic{
-		Severity: hcl.DiagError,
-		Summary:  "Reference to \"check\" in invalid context",
-		Detail:   "The \"check\" object can only be referenced from an \"expect_failures\" attribute within a OpenTofu testing \"run\" block.",
-		Subject:  rng.ToHCL().Ptr(),
-	})
-	return cty.NilVal, diags
+	if d.Evaluator.CheckResults == nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Reference to \"check\" in invalid context",
+			Detail:   "The \"check\" object can only be referenced from an \"expect_failures\" attribute or an assertion within a OpenTofu testing \"run\" block.",
+			Subject:  rng.ToHCL().Ptr(),
+		})
+		return cty.NilVal, diags
+	}
+
+	moduleConfig := d.Evaluator.Config.DescendentForInstance(d.ModulePath)
+	if moduleConfig == nil || moduleConfig.Module.Checks[addr.Name] == nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Reference to undeclared check block",
+			Detail:   fmt.Sprintf("There is no check block named %q declared in %s.", addr.Name, moduleDisplayAddr(d.ModulePath)),
+			Subject:  rng.ToHCL().Ptr(),
+		})
+		return cty.DynamicVal, diags
+	}
+
+	status := checks.StatusUnknown
+	messages := cty.ListValEmpty(cty.String)
+	if aggr := d.Evaluator.CheckResults.ConfigResults.Get(addr.InModule(moduleConfig.Path)); aggr != nil {
+		status = aggr.Status
+
+		var msgs []cty.Value
+		for _, elem := range aggr.ObjectResults.Elems {
+			for _, msg := range elem.Value.FailureMessages {
+				msgs = append(msgs, cty.StringVal(msg))
+			}
+		}
+		if len(msgs) > 0 {
+			messages = cty.ListVal(msgs)
+		}
+	}
+
+	var statusStr string
+	switch status {
+	case checks.StatusPass:
+		statusStr = "pass"
+	case checks.StatusFail:
+		statusStr = "fail"
+	case checks.StatusError:
+		statusStr = "error"
+	default:
+		statusStr = "unknown"
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{
+		"status":   cty.StringVal(statusStr),
+		"messages": messages,
+	}), diags
 }
 
 // getEphemeralResourceInstanceValue returns the value of the ephemeral instance from the state object.
@@ -70,6 +70,11 @@ type PlanGraphBuilder struct {
 	// where we _only_ do the refresh step.)
 	skipPlanChanges bool
 
+	// allowDeferral indicates that resources and module calls whose count or
+	// for_each can't be determined yet should be deferred to a later
+	// plan/apply round instead of causing an error.
+	allowDeferral bool
+
 	ConcreteProvider                ConcreteProviderNodeFunc
 	ConcreteResource                ConcreteResourceNodeFunc
 	ConcreteResourceInstance        ConcreteResourceInstanceNodeFunc
@@ -299,9 +304,15 @@ func (b *PlanGraphBuilder) initPlan() {
 			skipPlanChanges:      b.skipPlanChanges,
 			preDestroyRefresh:    b.preDestroyRefresh,
 			forceReplace:         b.ForceReplace,
+			allowDeferral:        b.allowDeferral,
 		}
 	}
 
+	b.ConcreteModule = func(n *nodeExpandModule) dag.Vertex {
+		n.allowDeferral = b.allowDeferral
+		return n
+	}
+
 	b.ConcreteResourceOrphan = func(a *NodeAbstractResourceInstance) dag.Vertex {
 		return &NodePlannableResourceInstanceOrphan{
 			NodeAbstractResourceInstance: a,
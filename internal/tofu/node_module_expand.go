@@ -13,6 +13,7 @@ import (
 	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/dag"
 	"github.com/opentofu/opentofu/internal/lang"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
 
@@ -27,6 +28,11 @@ type nodeExpandModule struct {
 	Addr       addrs.Module
 	Config     *configs.Module
 	ModuleCall *configs.ModuleCall
+
+	// allowDeferral indicates that a module call whose count or for_each
+	// value isn't known yet should have its planning deferred to a later
+	// round, rather than causing an error.
+	allowDeferral bool
 }
 
 var (
@@ -129,6 +135,25 @@ func (n *nodeExpandModule) Execute(ctx context.Context, evalCtx EvalContext, op
 	// to our module, and register module instances with each of them.
 	for _, module := range expander.ExpandModule(n.Addr.Parent()) {
 		evalCtx = evalCtx.WithPath(module)
+
+		// A module call deferred during planning stays deferred during apply,
+		// and so expands to no instances at all in either walk.
+		if changes := evalCtx.Changes(); changes != nil {
+			if changes.IsModuleCallDeferred(call.Absolute(module)) {
+				expander.SetModuleDeferred(module, call)
+				continue
+			}
+			if n.allowDeferral && n.deferExpansion(ctx, evalCtx, module) {
+				log.Printf("[TRACE] nodeExpandModule: deferring planning of %s (%s)", call.Absolute(module), plans.DeferredReasonInstanceCountUnknown)
+				changes.AppendDeferredModuleCall(&plans.DeferredModuleCall{
+					Addr:   call.Absolute(module),
+					Reason: plans.DeferredReasonInstanceCountUnknown,
+				})
+				expander.SetModuleDeferred(module, call)
+				continue
+			}
+		}
+
 		switch {
 		case n.ModuleCall.Count != nil:
 			count, ctDiags := evaluateCountExpression(ctx, n.ModuleCall.Count, evalCtx, module)
@@ -162,6 +187,26 @@ func (n *nodeExpandModule) Execute(ctx context.Context, evalCtx EvalContext, op
 
 }
 
+// deferExpansion returns true if the count or for_each value of the module
+// call isn't known yet in the given module instance, and so its planning must
+// be deferred to a later plan/apply round.
+//
+// Errors in evaluating count or for_each are ignored here, because Execute
+// will evaluate them again and report them.
+func (n *nodeExpandModule) deferExpansion(ctx context.Context, evalCtx EvalContext, module addrs.ModuleInstance) bool {
+	const allowUnknown = true
+	const tupleNotAllowed = false
+	switch {
+	case n.ModuleCall.Count != nil:
+		countVal, diags := evaluateCountExpressionValue(ctx, n.ModuleCall.Count, evalCtx)
+		return !diags.HasErrors() && !countVal.IsKnown()
+	case n.ModuleCall.ForEach != nil:
+		forEachVal, diags := evaluateForEachExpressionValue(ctx, n.ModuleCall.ForEach, evalCtx, allowUnknown, tupleNotAllowed, module)
+		return !diags.HasErrors() && !forEachVal.IsKnown()
+	}
+	return false
+}
+
 // nodeCloseModule represents an expanded module during apply, and is visited
 // after all other module instance nodes. This node will depend on all module
 // instance resource and outputs, and anything depending on the module should
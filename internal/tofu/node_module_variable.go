@@ -32,6 +32,10 @@ type nodeExpandModuleVariable struct {
 	Module addrs.Module
 	Config *configs.Variable
 	Expr   hcl.Expression
+
+	// Locals are the local values declared within the module block that
+	// Expr belongs to.
+	Locals map[string]*configs.Local
 }
 
 var (
@@ -59,6 +63,7 @@ func (n *nodeExpandModuleVariable) DynamicExpand(ctx EvalContext) (*Graph, error
 			Addr:           addr,
 			Config:         n.Config,
 			Expr:           n.Expr,
+			Locals:         n.Locals,
 			ModuleInstance: module,
 		}
 		g.Add(o)
@@ -101,7 +106,7 @@ func (n *nodeExpandModuleVariable) References() []*addrs.Reference {
 	// our value expression is assigned within a "module" block in the parent
 	// module.
 	refs, _ := lang.ReferencesInExpr(addrs.ParseRef, n.Expr)
-	return refs
+	return blockLocalsReferences(n.Locals, refs)
 }
 
 // GraphNodeReferenceOutside implementation
@@ -120,6 +125,9 @@ type nodeModuleVariable struct {
 	Addr   addrs.AbsInputVariableInstance
 	Config *configs.Variable // Config is the var in the config
 	Expr   hcl.Expression    // Expr is the value expression given in the call
+	// Locals are the local values declared within the module block, which
+	// Expr may refer to.
+	Locals map[string]*configs.Local
 	// ModuleInstance in order to create the appropriate context for evaluating
 	// ModuleCallArguments, ex. so count.index and each.key can resolve
 	ModuleInstance addrs.ModuleInstance
@@ -226,6 +234,9 @@ func (n *nodeModuleVariable) evalModuleVariable(ctx context.Context, evalCtx Eva
 			moduleInstanceRepetitionData = evalCtx.InstanceExpander().GetModuleInstanceRepetitionData(n.ModuleInstance)
 		}
 
+		if len(n.Locals) != 0 {
+			evalCtx = evalCtx.WithBlockLocals(n.Locals)
+		}
 		scope := evalCtx.EvaluationScope(nil, nil, moduleInstanceRepetitionData)
 		val, moreDiags := scope.EvalExpr(ctx, expr, cty.DynamicPseudoType)
 		diags = diags.Append(moreDiags)
@@ -294,6 +294,18 @@ func referencesForOutput(c *configs.Output) []*addrs.Reference {
 		refs = append(refs, errRefs...)
 	}
 
+	// Validation rules refer to the output's own value as "self", which
+	// isn't a dependency.
+	for _, check := range c.Validations {
+		condRefs, _ := lang.ReferencesInExpr(addrs.ParseRef, check.Condition)
+		errRefs, _ := lang.ReferencesInExpr(addrs.ParseRef, check.ErrorMessage)
+		for _, ref := range append(condRefs, errRefs...) {
+			if ref.Subject != addrs.Self {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
 	return refs
 }
 
@@ -323,13 +335,14 @@ func (n *NodeApplyableOutput) Execute(ctx context.Context, evalCtx EvalContext,
 		}
 	}
 
+	checkRuleSeverity := tfdiags.Error
+	if n.RefreshOnly {
+		checkRuleSeverity = tfdiags.Warning
+	}
+
 	// Checks are not evaluated during a destroy. The checks may fail, may not
 	// be valid, or may not have been registered at all.
 	if !n.DestroyApply {
-		checkRuleSeverity := tfdiags.Error
-		if n.RefreshOnly {
-			checkRuleSeverity = tfdiags.Warning
-		}
 		checkDiags := evalCheckRules(
 			ctx,
 			addrs.OutputPrecondition,
@@ -354,6 +367,11 @@ func (n *NodeApplyableOutput) Execute(ctx context.Context, evalCtx EvalContext,
 			var evalDiags tfdiags.Diagnostics
 			val, evalDiags = evalCtx.EvaluateExpr(ctx, n.Config.Expr, cty.DynamicPseudoType, nil)
 			diags = diags.Append(evalDiags)
+			if !evalDiags.HasErrors() {
+				var convDiags tfdiags.Diagnostics
+				val, convDiags = n.convertValue(val)
+				diags = diags.Append(convDiags)
+			}
 
 		// If the module is being overridden and we have a value to use,
 		// we just use it
@@ -427,6 +445,21 @@ If you do intend to export this data, annotate the output value as sensitive by
 		return diags.Append(ephDiags)
 	}
 
+	if !n.DestroyApply {
+		checkDiags := evalCheckRulesWithSelfValue(
+			ctx,
+			addrs.OutputValidation,
+			n.Config.Validations,
+			evalCtx, n.Addr, EvalDataForNoInstanceKey,
+			val,
+			checkRuleSeverity,
+		)
+		diags = diags.Append(checkDiags)
+		if diags.HasErrors() {
+			return diags // an invalid value must not be saved
+		}
+	}
+
 	n.setValue(state, changes, val)
 
 	// If we were able to evaluate a new value, we can update that in the
@@ -439,6 +472,29 @@ If you do intend to export this data, annotate the output value as sensitive by
 	return diags
 }
 
+// convertValue converts the given value of the output to the type constraint
+// given in its configuration, if any.
+func (n *NodeApplyableOutput) convertValue(val cty.Value) (cty.Value, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	if n.Config.ConstraintType == cty.NilType || n.Config.ConstraintType == cty.DynamicPseudoType {
+		return val, diags
+	}
+
+	convertTarget := lang.NewTypeConversionConstraint(n.Config.ConstraintType, n.Config.TypeDefaults)
+	converted, err := convertTarget.ConvertValue(val)
+	if err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid value for output value",
+			Detail:   fmt.Sprintf("Unsuitable value for output value %q: %s.", n.Addr.OutputValue.Name, tfdiags.FormatError(err)),
+			Subject:  n.Config.UsageRange().Ptr(),
+		})
+		return cty.UnknownVal(n.Config.Type), diags
+	}
+	return converted, diags
+}
+
 func (n *NodeApplyableOutput) validateEphemerality(val cty.Value) (diags tfdiags.Diagnostics) {
 	if n.Config.Ephemeral && n.Addr.Module.IsRoot() {
 		diags = diags.Append(&hcl.Diagnostic{
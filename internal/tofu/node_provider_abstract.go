@@ -91,11 +91,18 @@ func (n *NodeAbstractProvider) AttachProviderConfigSchema(schema *configschema.B
 }
 
 // GraphNodeProvider (Partial Implementation)
-func (n *NodeAbstractProvider) MocksAndOverrides() (IsMocked bool, MockResources []*configs.MockResource, OverrideResources []*configs.OverrideResource) {
+func (n *NodeAbstractProvider) MocksAndOverrides() ProviderMocks {
 	if n.Config == nil {
-		return false, nil, nil
+		return ProviderMocks{}
+	}
+	return ProviderMocks{
+		IsMocked:          n.Config.IsMocked,
+		MockResources:     n.Config.MockResources,
+		OverrideResources: n.Config.OverrideResources,
+		MockGenerators:    n.Config.MockGenerators,
+		MockSchemaHints:   n.Config.MockSchemaHints,
+		MockSeed:          n.Config.MockSeed,
 	}
-	return n.Config.IsMocked, n.Config.MockResources, n.Config.OverrideResources
 }
 
 // GraphNodeDotter impl.
@@ -251,11 +251,47 @@ func (n *NodeAbstractResource) References() []*addrs.Reference {
 			refs, _ = lang.ReferencesInExpr(addrs.ParseRef, check.ErrorMessage)
 			result = append(result, refs...)
 		}
+
+		result = blockLocalsReferences(c.Locals, result)
 	}
 
 	return result
 }
 
+// blockLocalsReferences replaces the references to the given block-local
+// values in refs with the references made by the values themselves, since
+// block-local values are not represented by nodes in the graph.
+func blockLocalsReferences(locals map[string]*configs.Local, refs []*addrs.Reference) []*addrs.Reference {
+	if len(locals) == 0 {
+		return refs
+	}
+
+	for _, l := range locals {
+		localRefs, _ := lang.ReferencesInExpr(addrs.ParseRef, l.Expr)
+		refs = append(refs, localRefs...)
+	}
+
+	result := refs[:0]
+	for _, ref := range refs {
+		if subj, ok := ref.Subject.(addrs.LocalValue); ok {
+			if _, exists := locals[subj.Name]; exists {
+				continue
+			}
+		}
+		result = append(result, ref)
+	}
+	return result
+}
+
+// blockLocalsEvalContext returns a copy of the given context that can also
+// resolve the local values declared within the resource block.
+func (n *NodeAbstractResource) blockLocalsEvalContext(evalCtx EvalContext) EvalContext {
+	if n.Config == nil || len(n.Config.Locals) == 0 {
+		return evalCtx
+	}
+	return evalCtx.WithBlockLocals(n.Config.Locals)
+}
+
 // DestroyReferences is a _partial_ implementation of [GraphNodeDestroyer]
 // providing a default implementation for any embedding node type that has
 // its own implementations of all of the other methods of that interface.
@@ -561,6 +597,14 @@ func (n *NodeAbstractResource) writeResourceState(ctx context.Context, evalCtx E
 	// to expand the module here to create all resources.
 	expander := evalCtx.InstanceExpander()
 
+	// If planning of this resource was deferred then we don't evaluate its
+	// repetition argument at all, since it's either unknown or derived from
+	// something else that was deferred. It has no instances in this round.
+	if changes := evalCtx.Changes(); changes != nil && changes.IsResourceDeferred(addr) {
+		expander.SetResourceDeferred(addr.Module, n.Addr.Resource)
+		return diags
+	}
+
 	switch {
 	case n.Config != nil && n.Config.Count != nil:
 		count, countDiags := evaluateCountExpression(ctx, n.Config.Count, evalCtx, addr)
@@ -3215,10 +3215,15 @@ func (n *NodeAbstractResourceInstance) getProvider(ctx context.Context, evalCtx
 
 	var isOverridden bool
 	var overrideValues map[string]cty.Value
+	var generators mockGenerators
 
 	if n.ResolvedProvider.IsMocked {
 		isOverridden = true
 
+		generators.rules = configs.MockGeneratorRules(n.ResolvedProvider.MockGenerators)
+		generators.schemaHints = n.ResolvedProvider.MockSchemaHints
+		generators.seed = n.ResolvedProvider.MockSeed
+
 		// Mocked by the provider
 		for _, res := range n.ResolvedProvider.MockResources {
 			if res.Type == n.Addr.Resource.Resource.Type && res.Mode == n.Addr.Resource.Resource.Mode {
@@ -3227,17 +3232,18 @@ func (n *NodeAbstractResourceInstance) getProvider(ctx context.Context, evalCtx
 			}
 		}
 
-		trie := addrs.NewOverrideTrie[map[string]cty.Value]()
+		trie := addrs.NewOverrideTrie[*configs.OverrideResource]()
 		// Overridden by the provider (overrides mocks)
 		for _, res := range n.ResolvedProvider.OverrideResources {
 			if res.TargetParsed.AffectedAbsResource().Equal(n.Addr.AffectedAbsResource()) && res.Mode == n.Addr.AffectedAbsResource().Resource.Mode {
-				trie.Set(res.TargetParsed, res.Values, res.Target.SourceRange().Ptr())
+				trie.Set(res.TargetParsed, res, res.Target.SourceRange().Ptr())
 			}
 		}
 
-		overrideValuesP, providerOverrideDiags := trie.Get(&n.Addr)
-		if overrideValuesP != nil {
-			overrideValues = *overrideValuesP
+		overrideRes, providerOverrideDiags := trie.Get(&n.Addr)
+		if overrideRes != nil {
+			overrideValues = (*overrideRes).Values
+			generators.rules = append(configs.MockGeneratorRules((*overrideRes).Generators), generators.rules...)
 		}
 
 		if providerOverrideDiags.HasErrors() {
@@ -3249,18 +3255,20 @@ func (n *NodeAbstractResourceInstance) getProvider(ctx context.Context, evalCtx
 		// Overridden in the currently running test (overrides any provider settings)
 		isOverridden = true
 
-		newOverrideValues, resourceOverrideDiags := n.Config.Overrides.Get(&n.Addr)
+		overrideRes, resourceOverrideDiags := n.Config.Overrides.Get(&n.Addr)
 		if resourceOverrideDiags.HasErrors() {
 			return nil, providers.ProviderSchema{}, resourceOverrideDiags.Err()
 		}
 		// set resource override, if it exists
-		if newOverrideValues != nil {
-			overrideValues = *newOverrideValues
+		if overrideRes != nil {
+			overrideValues = (*overrideRes).Values
+			generators.rules = append(configs.MockGeneratorRules((*overrideRes).Generators), generators.rules...)
+			generators.seed = n.Config.OverrideSeed
 		}
 	}
 
 	if isOverridden {
-		provider, err := newProviderForTestWithSchema(underlyingProvider, schema, overrideValues)
+		provider, err := newProviderForTestWithSchema(underlyingProvider, schema, overrideValues, generators)
 		return provider, schema, err
 	}
 
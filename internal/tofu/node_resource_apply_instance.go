@@ -131,6 +131,8 @@ func (n *NodeApplyableResourceInstance) Execute(ctx context.Context, evalCtx Eva
 	)
 	defer span.End()
 
+	evalCtx = n.blockLocalsEvalContext(evalCtx)
+
 	if n.Config == nil {
 		// If there is no config, and there is no change, then we have nothing
 		// to do and the change was left in the plan for informational
@@ -180,7 +180,7 @@ func (n *NodePlanDeposedResourceInstanceObject) Execute(ctx context.Context, eva
 		// Note that removed statements take precedence, since it is the latest intent the user declared
 		// As opposed to the lifecycle attribute, which might have been altered after the resource got deposed
 		for _, rs := range n.RemoveStatements {
-			if rs.From.TargetContains(n.Addr) {
+			if rs.Selects(n.Addr) {
 				shouldDestroy = rs.Destroy
 				log.Printf("[DEBUG] NodePlanDeposedResourceInstanceObject.Execute: %s (deposed %s) removed block found, overriding shouldDestroy to %t", n.Addr, n.DeposedKey, shouldDestroy)
 			}
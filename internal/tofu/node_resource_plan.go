@@ -14,6 +14,7 @@ import (
 
 	"github.com/opentofu/opentofu/internal/addrs"
 	"github.com/opentofu/opentofu/internal/dag"
+	"github.com/opentofu/opentofu/internal/plans"
 	"github.com/opentofu/opentofu/internal/states"
 	"github.com/opentofu/opentofu/internal/tfdiags"
 )
@@ -38,6 +39,11 @@ type nodeExpandPlannableResource struct {
 	// for any instances.
 	skipPlanChanges bool
 
+	// allowDeferral indicates that a resource whose count or for_each value
+	// isn't known yet should have its planning deferred to a later round,
+	// rather than causing an error.
+	allowDeferral bool
+
 	// forceReplace are resource instance addresses where the user wants to
 	// force generating a replace action. This set isn't pre-filtered, so
 	// it might contain addresses that have nothing to do with the resource
@@ -112,8 +118,14 @@ func (n *nodeExpandPlannableResource) DynamicExpand(evalCtx EvalContext) (*Graph
 	// Lock the state while we inspect it
 	state := evalCtx.State().Lock()
 
+	changes := evalCtx.Changes()
 	var orphans []*states.Resource
 	for _, res := range state.Resources(n.Addr) {
+		// Objects inside a deferred module call are left untouched until a
+		// later round can decide which module instances it has.
+		if changes != nil && changes.IsModuleInstanceDeferred(res.Addr.Module) {
+			continue
+		}
 		found := false
 		for _, m := range moduleInstances {
 			if m.Equal(res.Addr.Module) {
@@ -233,6 +245,21 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(ctx context.Contex
 	// working in, so that it can evaluate expressions in the appropriate scope.
 	moduleCtx := globalCtx.WithPath(resAddr.Module)
 
+	if n.allowDeferral {
+		if reason := n.deferralReason(ctx, moduleCtx, resAddr); reason != plans.DeferredReasonInvalid {
+			log.Printf("[TRACE] nodeExpandPlannableResource: deferring planning of %s (%s)", resAddr, reason)
+			moduleCtx.Changes().AppendDeferredResource(&plans.DeferredResource{
+				Addr:   resAddr,
+				Reason: reason,
+			})
+			// writeResourceState will notice the deferral and register an
+			// expansion with no instances. We must not go on to build the
+			// instance subgraph, because then any existing instances would
+			// be treated as orphans and planned for destruction.
+			return n.writeResourceState(ctx, moduleCtx, resAddr).ErrWithWarnings()
+		}
+	}
+
 	// writeResourceState is responsible for informing the expander of what
 	// repetition mode this resource has, which allows expander.ExpandResource
 	// to work below.
@@ -331,6 +358,41 @@ func (n *nodeExpandPlannableResource) expandResourceInstances(ctx context.Contex
 	return diags.ErrWithWarnings()
 }
 
+// deferralReason decides whether planning of the given resource must be
+// deferred to a later plan/apply round, returning
+// plans.DeferredReasonInvalid if it can be planned now.
+//
+// A resource is deferred if it depends on another resource that was deferred,
+// or if its count or for_each value isn't known yet. Errors in evaluating
+// count or for_each are ignored here, because writeResourceState will
+// evaluate them again and report them.
+func (n *nodeExpandPlannableResource) deferralReason(ctx context.Context, evalCtx EvalContext, addr addrs.AbsResource) plans.DeferredReason {
+	for _, dep := range n.dependencies {
+		if evalCtx.Changes().IsConfigResourceDeferred(dep) {
+			return plans.DeferredReasonDeferredPrereq
+		}
+	}
+	if n.Config == nil {
+		return plans.DeferredReasonInvalid
+	}
+
+	const allowUnknown = true
+	const tupleNotAllowed = false
+	switch {
+	case n.Config.Count != nil:
+		countVal, diags := evaluateCountExpressionValue(ctx, n.Config.Count, evalCtx)
+		if !diags.HasErrors() && !countVal.IsKnown() {
+			return plans.DeferredReasonInstanceCountUnknown
+		}
+	case n.Config.ForEach != nil:
+		forEachVal, diags := evaluateForEachExpressionValue(ctx, n.Config.ForEach, evalCtx, allowUnknown, tupleNotAllowed, addr)
+		if !diags.HasErrors() && !forEachVal.IsKnown() {
+			return plans.DeferredReasonInstanceCountUnknown
+		}
+	}
+	return plans.DeferredReasonInvalid
+}
+
 func (n *nodeExpandPlannableResource) resourceInstanceSubgraph(ctx context.Context, evalCtx EvalContext, addr addrs.AbsResource, instanceAddrs []addrs.AbsResourceInstance) (*Graph, error) {
 	var diags tfdiags.Diagnostics
 
@@ -107,6 +107,8 @@ func (n *NodePlannableResourceInstance) Execute(ctx context.Context, evalCtx Eva
 	)
 	defer span.End()
 
+	evalCtx = n.blockLocalsEvalContext(evalCtx)
+
 	diags := n.resolveProvider(ctx, evalCtx, true, states.NotDeposed)
 	if diags.HasErrors() {
 		tracing.SetSpanError(span, diags)
@@ -206,7 +206,7 @@ func (n *NodePlannableResourceInstanceOrphan) managedResourceExecute(ctx context
 	// Note that removed statements take precedence, since it is the latest intent the user declared
 	// As opposed to the lifecycle attribute, which was the previous intention declared on the orphaned resource
 	for _, rs := range n.RemoveStatements {
-		if rs.From.TargetContains(n.Addr) {
+		if rs.Selects(n.Addr) {
 			shouldDestroy = rs.Destroy
 			log.Printf("[DEBUG] NodePlannableResourceInstanceOrphan.managedResourceExecute: %s (orphan) removed block found, overriding shouldDestroy to %t", addr, shouldDestroy)
 		}
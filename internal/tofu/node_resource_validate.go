@@ -62,6 +62,7 @@ func (n *NodeValidatableResource) Execute(ctx context.Context, evalCtx EvalConte
 	if n.Config == nil {
 		return diags
 	}
+	evalCtx = n.blockLocalsEvalContext(evalCtx)
 
 	diags = diags.Append(n.validateResource(ctx, evalCtx))
 
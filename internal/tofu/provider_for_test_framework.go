@@ -32,9 +32,21 @@ type providerForTest struct {
 	schema   providers.ProviderSchema
 
 	overrideValues map[string]cty.Value
+	generators     mockGenerators
 }
 
-func newProviderForTestWithSchema(internal providers.Interface, schema providers.ProviderSchema, overrideValues map[string]cty.Value) (providerForTest, error) {
+// mockGenerators configures how providerForTest generates the values of
+// computed attributes that are not set in its overrideValues.
+type mockGenerators struct {
+	rules       []hcl2shim.MockGeneratorRule
+	schemaHints bool
+
+	// seed is mixed into the per-type seed of the generated values, so
+	// that a different set of values can be produced for the same resource.
+	seed int64
+}
+
+func newProviderForTestWithSchema(internal providers.Interface, schema providers.ProviderSchema, overrideValues map[string]cty.Value, generators mockGenerators) (providerForTest, error) {
 	if schema.Diagnostics.HasErrors() {
 		return providerForTest{}, fmt.Errorf("invalid provider schema for test wrapper: %w", schema.Diagnostics.Err())
 	}
@@ -43,6 +55,7 @@ func newProviderForTestWithSchema(internal providers.Interface, schema providers
 		internal:       internal,
 		schema:         schema,
 		overrideValues: overrideValues,
+		generators:     generators,
 	}, nil
 }
 
@@ -76,7 +89,7 @@ func (p providerForTest) PlanResourceChange(_ context.Context, r providers.PlanR
 	filteredConfig := filterComputedOnlyAttributes(schema, r.Config)
 
 	var resp providers.PlanResourceChangeResponse
-	resp.PlannedState, resp.Diagnostics = newMockValueComposer(r.TypeName).
+	resp.PlannedState, resp.Diagnostics = p.newMockValueComposer(r.TypeName).
 		ComposeBySchema(schema, filteredConfig, p.overrideValues)
 
 	return resp
@@ -111,7 +124,7 @@ func (p providerForTest) ReadDataSource(_ context.Context, r providers.ReadDataS
 	resSchema, _ := p.schema.SchemaForResourceType(addrs.DataResourceMode, r.TypeName)
 
 	var resp providers.ReadDataSourceResponse
-	resp.State, resp.Diagnostics = newMockValueComposer(r.TypeName).ComposeBySchema(resSchema.Block, r.Config, p.overrideValues)
+	resp.State, resp.Diagnostics = p.newMockValueComposer(r.TypeName).ComposeBySchema(resSchema.Block, r.Config, p.overrideValues)
 
 	return resp
 }
@@ -119,7 +132,7 @@ func (p providerForTest) ReadDataSource(_ context.Context, r providers.ReadDataS
 func (p providerForTest) OpenEphemeralResource(_ context.Context, r providers.OpenEphemeralResourceRequest) (resp providers.OpenEphemeralResourceResponse) {
 	resSchema, _ := p.schema.SchemaForResourceType(addrs.EphemeralResourceMode, r.TypeName)
 
-	resp.Result, resp.Diagnostics = newMockValueComposer(r.TypeName).ComposeBySchema(resSchema.Block, r.Config, p.overrideValues)
+	resp.Result, resp.Diagnostics = p.newMockValueComposer(r.TypeName).ComposeBySchema(resSchema.Block, r.Config, p.overrideValues)
 	return resp
 }
 
@@ -211,8 +224,9 @@ func (p providerForTest) Close(ctx context.Context) error {
 	return p.internal.Close(ctx)
 }
 
-func newMockValueComposer(typeName string) hcl2shim.MockValueComposer {
+func (p providerForTest) newMockValueComposer(typeName string) hcl2shim.MockValueComposer {
 	hash := fnv.New32()
 	hash.Write([]byte(typeName))
-	return hcl2shim.NewMockValueComposer(int64(hash.Sum32()))
+	return hcl2shim.NewMockValueComposer(int64(hash.Sum32())^p.generators.seed).
+		WithGenerators(p.generators.rules, p.generators.schemaHints)
 }
@@ -17,7 +17,7 @@ import (
 func TestProviderForTest_ReadResource(t *testing.T) {
 	mockProvider := &MockProvider{}
 
-	provider, err := newProviderForTestWithSchema(mockProvider, mockProvider.GetProviderSchema(t.Context()), nil)
+	provider, err := newProviderForTestWithSchema(mockProvider, mockProvider.GetProviderSchema(t.Context()), nil, mockGenerators{})
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err.Error())
 	}
@@ -59,17 +59,17 @@ func (c *Context) TestContext(config *configs.Config, state *states.State, plan
 // this function.
 func (tc *TestContext) EvaluateAgainstState(run *moduletest.Run) {
 	defer tc.acquireRun("evaluate")()
-	tc.evaluate(tc.State.SyncWrapper(), plans.NewChanges().SyncWrapper(), run, walkApply)
+	tc.evaluate(tc.State.SyncWrapper(), plans.NewChanges().SyncWrapper(), tc.State.CheckResults, run, walkApply)
 }
 
 // EvaluateAgainstPlan processes the assertions inside the provided
 // configs.TestRun against the embedded plan and state.
 func (tc *TestContext) EvaluateAgainstPlan(run *moduletest.Run) {
 	defer tc.acquireRun("evaluate")()
-	tc.evaluate(tc.State.SyncWrapper(), tc.Plan.Changes.SyncWrapper(), run, walkPlan)
+	tc.evaluate(tc.State.SyncWrapper(), tc.Plan.Changes.SyncWrapper(), tc.Plan.Checks, run, walkPlan)
 }
 
-func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesSync, run *moduletest.Run, operation walkOperation) {
+func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesSync, checkResults *states.CheckResults, run *moduletest.Run, operation walkOperation) {
 	// The state does not include the module that has no resources, making its outputs unusable.
 	// synchronizeStates function synchronizes the state with the planned state, ensuring inclusion of all modules.
 	if tc.Plan != nil && tc.Plan.PlannedState != nil &&
@@ -77,6 +77,12 @@ func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesS
 		state = synchronizeStates(tc.State, tc.Plan.PlannedState)
 	}
 
+	// The check results must never be nil here, as that would stop check
+	// blocks from being referenced by the assertions.
+	if checkResults == nil {
+		checkResults = new(states.CheckResults)
+	}
+
 	data := &evaluationStateData{
 		Evaluator: &Evaluator{
 			Operation: operation,
@@ -96,6 +102,7 @@ func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesS
 			}(),
 			VariableValuesLock: new(sync.Mutex),
 			PlanTimestamp:      tc.Plan.Timestamp,
+			CheckResults:       checkResults,
 		},
 		ModulePath:      nil, // nil for the root module
 		InstanceKeyData: EvalDataForNoInstanceKey,
@@ -107,6 +114,9 @@ func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesS
 		BaseDir:       ".",
 		PureOnly:      operation != walkApply,
 		PlanTimestamp: tc.Plan.Timestamp,
+		// Assertions are evaluated in the scope of the root module, so they
+		// can call its functions too.
+		ModuleFunctions: tc.Config.Module.UserFunctions(),
 		ProviderFunctions: func(ctx context.Context, pf addrs.ProviderFunction, rng tfdiags.SourceRange) (*function.Function, tfdiags.Diagnostics) {
 			// TODO pass in tc.plugins
 			return evalContextProviderFunction(ctx, nil, walkPlan, pf, rng)
@@ -127,6 +137,10 @@ func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesS
 		diags = diags.Append(moreDiags)
 		refs = append(refs, moreRefs...)
 
+		if operation == walkApply {
+			diags = diags.Append(tc.checkEphemeralResourcesOpened(refs))
+		}
+
 		hclCtx, moreDiags := scope.EvalContext(context.TODO(), refs)
 		diags = diags.Append(moreDiags)
 
@@ -204,6 +218,38 @@ func (tc *TestContext) evaluate(state *states.SyncState, changes *plans.ChangesS
 	}
 }
 
+// checkEphemeralResourcesOpened returns an error for each of the given
+// references to an ephemeral resource that was not opened by the apply
+// operation. Unlike planning, applying only opens the ephemeral resources
+// whose values are used elsewhere in the configuration.
+func (tc *TestContext) checkEphemeralResourcesOpened(refs []*addrs.Reference) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	for _, ref := range refs {
+		var addr addrs.Resource
+		switch subject := ref.Subject.(type) {
+		case addrs.Resource:
+			addr = subject
+		case addrs.ResourceInstance:
+			addr = subject.Resource
+		default:
+			continue
+		}
+		if addr.Mode != addrs.EphemeralResourceMode {
+			continue
+		}
+
+		if tc.State.Resource(addr.Absolute(addrs.RootModuleInstance)) == nil {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Ephemeral resource not opened",
+				Detail:   fmt.Sprintf("The assertion refers to %s, but it was not opened during the apply operation because nothing in the configuration uses its value. Reference it from a check block of the module under test, or assert on it within a run block that uses command = plan.", addr),
+				Subject:  ref.SourceRange.ToHCL().Ptr(),
+			})
+		}
+	}
+	return diags
+}
+
 // synchronizeStates compares the planned state to the current state and incorporates any missing modules
 // from the planned state into the current state.
 //
@@ -14,6 +14,8 @@ import (
 	ctymsgpack "github.com/zclconf/go-cty/cty/msgpack"
 
 	"github.com/opentofu/opentofu/internal/addrs"
+	"github.com/opentofu/opentofu/internal/checks"
+	"github.com/opentofu/opentofu/internal/configs"
 	"github.com/opentofu/opentofu/internal/configs/configschema"
 	"github.com/opentofu/opentofu/internal/lang/marks"
 	"github.com/opentofu/opentofu/internal/moduletest"
@@ -369,6 +371,103 @@ run "test_case" {
 				},
 			},
 		},
+		"check_block_status": {
+			configs: map[string]string{
+				"main.tf": `
+variable "value" {
+	default = 1
+}
+
+check "a" {
+	assert {
+		condition = var.value > 1
+		error_message = "always fails"
+	}
+}
+
+check "b" {
+	assert {
+		condition = var.value == 1
+		error_message = "never fails"
+	}
+}
+`,
+				"main.tftest.hcl": `
+run "test_case" {
+	assert {
+		condition = check.a.status == "fail" && check.a.messages == tolist(["always fails"])
+		error_message = "invalid check.a"
+	}
+
+	assert {
+		condition = check.b.status == "pass" && length(check.b.messages) == 0
+		error_message = "invalid check.b"
+	}
+}
+`,
+			},
+			state: func() *states.State {
+				state := states.NewState()
+				results := checks.NewState(&configs.Config{
+					Module: &configs.Module{
+						Checks: map[string]*configs.Check{
+							"a": {Name: "a", Asserts: []*configs.CheckRule{{}}},
+							"b": {Name: "b", Asserts: []*configs.CheckRule{{}}},
+						},
+					},
+				})
+				a := addrs.Check{Name: "a"}.Absolute(addrs.RootModuleInstance)
+				b := addrs.Check{Name: "b"}.Absolute(addrs.RootModuleInstance)
+				results.ReportCheckableObjects(a.ConfigCheckable(), addrs.MakeSet[addrs.Checkable](a))
+				results.ReportCheckableObjects(b.ConfigCheckable(), addrs.MakeSet[addrs.Checkable](b))
+				results.ReportCheckFailure(a, addrs.CheckAssertion, 0, "always fails")
+				results.ReportCheckResult(b, addrs.CheckAssertion, 0, checks.StatusPass)
+				state.CheckResults = states.NewCheckResults(results)
+				return state
+			}(),
+			provider:       &MockProvider{},
+			expectedStatus: moduletest.Pass,
+		},
+		"ephemeral_resource_not_opened": {
+			configs: map[string]string{
+				"main.tf": `
+ephemeral "test_ephemeral" "a" {
+}
+`,
+				"main.tftest.hcl": `
+run "test_case" {
+	assert {
+		condition = ephemeral.test_ephemeral.a.value == "secret"
+		error_message = "invalid value"
+	}
+}
+`,
+			},
+			state: states.NewState(),
+			provider: &MockProvider{
+				GetProviderSchemaResponse: &providers.GetProviderSchemaResponse{
+					EphemeralResources: map[string]providers.Schema{
+						"test_ephemeral": {
+							Block: &configschema.Block{
+								Attributes: map[string]*configschema.Attribute{
+									"value": {
+										Type:     cty.String,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedStatus: moduletest.Error,
+			expectedDiags: []tfdiags.Description{
+				{
+					Summary: "Ephemeral resource not opened",
+					Detail:  "The assertion refers to ephemeral.test_ephemeral.a, but it was not opened during the apply operation because nothing in the configuration uses its value. Reference it from a check block of the module under test, or assert on it within a run block that uses command = plan.",
+				},
+			},
+		},
 	}
 	for name, tc := range tcs {
 		t.Run(name, func(t *testing.T) {
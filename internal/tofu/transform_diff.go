@@ -229,7 +229,7 @@ func (t *DiffTransformer) Transform(_ context.Context, g *Graph) error {
 			if dk == states.NotDeposed {
 				// If any removed block is targeting the resource in this node, ensure that any provisioners defined in that block are going to be
 				// executed before actual resource destruction.
-				abstract.removedBlockProvisioners = refactoring.FindResourceRemovedBlockProvisioners(t.Config, abstract.Addr.ConfigResource())
+				abstract.removedBlockProvisioners = refactoring.FindResourceRemovedBlockProvisioners(t.Config, abstract.Addr)
 				node = &NodeDestroyResourceInstance{
 					NodeAbstractResourceInstance: abstract,
 					DeposedKey:                   dk,
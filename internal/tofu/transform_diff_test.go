@@ -214,7 +214,7 @@ func TestTransformRemovedProvisioners(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected the only vertex to be a NodeDestroyResourceInstance. got instead %s", reflect.TypeOf(verts[0]).String())
 	}
-	wantProvisioners := refactoring.FindResourceRemovedBlockProvisioners(module, resAddr.ConfigResource())
+	wantProvisioners := refactoring.FindResourceRemovedBlockProvisioners(module, resAddr)
 	if len(wantProvisioners) == 0 { // just a sanity check to ensure that the call generating the wanted provisioners is actually returning 1 provisioner
 		t.Fatalf("expected to have 1 provisioner in the config. this is an indication that the functionality for searching provisioners might be broken or that the test is wrongly configured")
 	}
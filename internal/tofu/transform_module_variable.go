@@ -114,6 +114,7 @@ func (t *ModuleVariableTransformer) transformSingle(g *Graph, parent, c *configs
 			Module: c.Path,
 			Config: v,
 			Expr:   expr,
+			Locals: callConfig.Locals,
 		}
 		g.Add(input)
 
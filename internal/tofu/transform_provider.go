@@ -65,7 +65,7 @@ type GraphNodeProvider interface {
 	// Call close for all provider instances within this GraphNodeProvider
 	Close(ctx context.Context) error
 	// For test framework
-	MocksAndOverrides() (IsMocked bool, MockResources []*configs.MockResource, OverrideResources []*configs.OverrideResource)
+	MocksAndOverrides() ProviderMocks
 }
 
 // GraphNodeCloseProvider is an interface that nodes that can be a close
@@ -96,9 +96,21 @@ type ResolvedProvider struct {
 	Instance func(addrs.InstanceKey) (providers.Configured, error)
 
 	// Test overrides
+	ProviderMocks
+}
+
+// ProviderMocks holds the testing framework settings of a provider
+// configuration, which are used to mock or override its resources.
+type ProviderMocks struct {
 	IsMocked          bool
 	MockResources     []*configs.MockResource
 	OverrideResources []*configs.OverrideResource
+
+	// MockGenerators, MockSchemaHints and MockSeed control how the values
+	// of computed attributes are generated for mocked resources.
+	MockGenerators  []*configs.MockGenerator
+	MockSchemaHints bool
+	MockSeed        int64
 }
 
 // GraphNodeProviderConsumer is an interface that nodes that require
@@ -201,7 +213,7 @@ func (t *ProviderTransformer) Transform(_ context.Context, g *Graph) error {
 				KeyResource:   req.KeyResource,
 				KeyExact:      req.KeyExact,
 			}
-			resolved.IsMocked, resolved.MockResources, resolved.OverrideResources = target.MocksAndOverrides()
+			resolved.ProviderMocks = target.MocksAndOverrides()
 			pv.SetProvider(resolved)
 
 			g.Connect(dag.BasicEdge(v, target))
@@ -273,7 +285,7 @@ func (t *ProviderTransformer) Transform(_ context.Context, g *Graph) error {
 			resolved.Instance = target.Instance
 
 			// Include test mocking and override extensions
-			resolved.IsMocked, resolved.MockResources, resolved.OverrideResources = target.MocksAndOverrides()
+			resolved.ProviderMocks = target.MocksAndOverrides()
 
 			log.Printf("[DEBUG] ProviderTransformer: %q (%T) needs %s", dag.VertexName(v), v, dag.VertexName(target))
 			pv.SetProvider(resolved)
@@ -754,7 +766,7 @@ func (n *graphNodeProxyProvider) Target() GraphNodeProvider {
 	}
 }
 
-func (n *graphNodeProxyProvider) MocksAndOverrides() (IsMocked bool, MockResources []*configs.MockResource, OverrideResources []*configs.OverrideResource) {
+func (n *graphNodeProxyProvider) MocksAndOverrides() ProviderMocks {
 	return n.Target().MocksAndOverrides()
 }
 